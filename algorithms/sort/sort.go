@@ -0,0 +1,184 @@
+// Package sort implements the classic comparison sorts generically, to
+// compare them with each other and with slices.Sort:
+//
+//   - Insertion: O(n²), but with no overhead at all, so it wins on short
+//     slices and on slices that are already nearly sorted.
+//   - Quick: O(n log n) on average, in place, and not stable. This one
+//     picks the median of three elements as the pivot, so sorted and
+//     reversed input do not make it quadratic.
+//   - Merge: O(n log n) always and stable, at the cost of a buffer as
+//     large as the input.
+//   - Heap: O(n log n) always and in place, but it jumps around memory and
+//     is usually the slowest of the three in practice.
+//
+// Every algorithm comes in two forms, like slices.Sort and
+// slices.SortFunc: one for cmp.Ordered elements and one taking a
+// comparison function that returns a negative number, zero or a positive
+// number as a is less than, equal to or greater than b.
+//
+// slices.Sort is pattern-defeating quicksort, which combines all of these:
+// insertion sort for short runs, quicksort with careful pivots, and
+// heapsort as a fallback when partitioning keeps going badly.
+package sort
+
+import "cmp"
+
+// Insertion sorts s in place with insertion sort. It is stable.
+func Insertion[T cmp.Ordered](s []T) {
+	InsertionFunc(s, cmp.Compare[T])
+}
+
+// InsertionFunc sorts s in place with insertion sort, ordered by cmp. It
+// is stable.
+func InsertionFunc[T any](s []T, cmp func(a, b T) int) {
+	for i := 1; i < len(s); i++ {
+		// Shift the sorted prefix right until s[i] fits.
+		v := s[i]
+		j := i
+		for ; j > 0 && cmp(v, s[j-1]) < 0; j-- {
+			s[j] = s[j-1]
+		}
+		s[j] = v
+	}
+}
+
+// Quick sorts s in place with quicksort. It is not stable.
+func Quick[T cmp.Ordered](s []T) {
+	QuickFunc(s, cmp.Compare[T])
+}
+
+// QuickFunc sorts s in place with quicksort, ordered by cmp. It is not
+// stable.
+func QuickFunc[T any](s []T, cmp func(a, b T) int) {
+	// Recurse into the smaller part and loop on the larger one, so the
+	// stack never grows deeper than log n.
+	for len(s) > 1 {
+		p := partition(s, cmp)
+		if p < len(s)-p {
+			QuickFunc(s[:p], cmp)
+			s = s[p+1:]
+		} else {
+			QuickFunc(s[p+1:], cmp)
+			s = s[:p]
+		}
+	}
+}
+
+// partition moves the median of s's first, middle and last elements to
+// its final position p, with nothing greater before it and nothing less
+// after it, and returns p.
+func partition[T any](s []T, cmp func(a, b T) int) int {
+	lo, mid, hi := 0, len(s)/2, len(s)-1
+	if cmp(s[mid], s[lo]) < 0 {
+		s[mid], s[lo] = s[lo], s[mid]
+	}
+	if cmp(s[hi], s[lo]) < 0 {
+		s[hi], s[lo] = s[lo], s[hi]
+	}
+	if cmp(s[hi], s[mid]) < 0 {
+		s[hi], s[mid] = s[mid], s[hi]
+	}
+	// Now s[lo] <= s[mid] <= s[hi]. Park the pivot at the end and
+	// partition the rest with Hoare's two scans, which stop on equal
+	// elements so a slice of duplicates splits in the middle.
+	s[mid], s[hi] = s[hi], s[mid]
+	pivot := s[hi]
+	i, j := 0, hi-1
+	for {
+		for cmp(s[i], pivot) < 0 {
+			i++
+		}
+		for j > i && cmp(pivot, s[j]) < 0 {
+			j--
+		}
+		if i >= j {
+			break
+		}
+		s[i], s[j] = s[j], s[i]
+		i++
+		j--
+	}
+	s[i], s[hi] = s[hi], s[i]
+	return i
+}
+
+// Merge sorts s with top-down merge sort. It is stable and allocates one
+// buffer of len(s) elements.
+func Merge[T cmp.Ordered](s []T) {
+	MergeFunc(s, cmp.Compare[T])
+}
+
+// MergeFunc sorts s with top-down merge sort, ordered by cmp. It is stable
+// and allocates one buffer of len(s) elements.
+func MergeFunc[T any](s []T, cmp func(a, b T) int) {
+	if len(s) < 2 {
+		return
+	}
+	mergeSort(s, make([]T, len(s)), cmp)
+}
+
+// mergeSort sorts s using buf, which has the same length, as scratch.
+func mergeSort[T any](s, buf []T, cmp func(a, b T) int) {
+	if len(s) < 2 {
+		return
+	}
+	mid := len(s) / 2
+	mergeSort(s[:mid], buf[:mid], cmp)
+	mergeSort(s[mid:], buf[mid:], cmp)
+	if cmp(s[mid-1], s[mid]) <= 0 {
+		return // already in order, which makes sorted input O(n)
+	}
+	copy(buf, s)
+	left, right := buf[:mid], buf[mid:]
+	i, j, k := 0, 0, 0
+	for i < len(left) && j < len(right) {
+		// Taking from the left on ties is what makes the sort stable.
+		if cmp(right[j], left[i]) < 0 {
+			s[k] = right[j]
+			j++
+		} else {
+			s[k] = left[i]
+			i++
+		}
+		k++
+	}
+	k += copy(s[k:], left[i:])
+	copy(s[k:], right[j:])
+}
+
+// Heap sorts s in place with heapsort. It is not stable.
+func Heap[T cmp.Ordered](s []T) {
+	HeapFunc(s, cmp.Compare[T])
+}
+
+// HeapFunc sorts s in place with heapsort, ordered by cmp. It is not
+// stable.
+func HeapFunc[T any](s []T, cmp func(a, b T) int) {
+	// Build a max-heap, then repeatedly swap the largest element to the
+	// end of the slice and restore the heap on what is left.
+	for i := len(s)/2 - 1; i >= 0; i-- {
+		siftDown(s, i, cmp)
+	}
+	for end := len(s) - 1; end > 0; end-- {
+		s[0], s[end] = s[end], s[0]
+		siftDown(s[:end], 0, cmp)
+	}
+}
+
+// siftDown moves s[i] down the max-heap s until neither child is greater.
+func siftDown[T any](s []T, i int, cmp func(a, b T) int) {
+	for {
+		child := 2*i + 1
+		if child >= len(s) {
+			return
+		}
+		if child+1 < len(s) && cmp(s[child], s[child+1]) < 0 {
+			child++
+		}
+		if cmp(s[i], s[child]) >= 0 {
+			return
+		}
+		s[i], s[child] = s[child], s[i]
+		i = child
+	}
+}
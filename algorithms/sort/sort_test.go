@@ -0,0 +1,160 @@
+package sort
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"testing"
+
+	"learning-go/randsource"
+)
+
+// sorts lists the algorithms next to slices.Sort, which they are checked
+// and timed against.
+var sorts = []struct {
+	name   string
+	fn     func([]int)
+	fnFunc func([]int, func(a, b int) int)
+	stable bool
+}{
+	{"insertion", Insertion[int], InsertionFunc[int], true},
+	{"quick", Quick[int], QuickFunc[int], false},
+	{"merge", Merge[int], MergeFunc[int], true},
+	{"heap", Heap[int], HeapFunc[int], false},
+	{"slices.Sort", slices.Sort[[]int], slices.SortFunc[[]int], false},
+}
+
+// orders are the kinds of input sortInput makes.
+var orders = []string{"random", "sorted", "reversed", "few"}
+
+// sortInput returns n ints in the given order: "random", "sorted",
+// "reversed", or "few" for random values with many duplicates.
+func sortInput(r interface{ IntN(int) int }, order string, n int) []int {
+	s := make([]int, n)
+	for i := range s {
+		switch order {
+		case "sorted":
+			s[i] = i
+		case "reversed":
+			s[i] = n - i
+		case "few":
+			s[i] = r.IntN(4)
+		default:
+			s[i] = r.IntN(1_000_000)
+		}
+	}
+	return s
+}
+
+func TestSmallInputs(t *testing.T) {
+	for _, in := range [][]int{nil, {}, {1}, {2, 1}, {1, 1}, {3, 1, 2}, {2, 2, 1, 1}} {
+		want := slices.Clone(in)
+		slices.Sort(want)
+		for _, alg := range sorts {
+			got := slices.Clone(in)
+			alg.fn(got)
+			if !slices.Equal(got, want) {
+				t.Errorf("%s(%v) = %v, want %v", alg.name, in, got, want)
+			}
+		}
+	}
+}
+
+// On many random inputs every algorithm must agree with slices.Sort.
+// LEARN_SEED reproduces a failing run.
+func TestAgreesWithSlicesSort(t *testing.T) {
+	r := randsource.New("algorithms/sort")
+	for trial := range 500 {
+		order := orders[trial%len(orders)]
+		in := sortInput(r, order, r.IntN(300))
+		want := slices.Clone(in)
+		slices.Sort(want)
+		for _, alg := range sorts {
+			got := slices.Clone(in)
+			alg.fn(got)
+			if !slices.Equal(got, want) {
+				t.Fatalf("%s on %s input %v = %v (seed %d)", alg.name, order, in, got, randsource.Seed())
+			}
+		}
+	}
+}
+
+// The stable sorts must keep equal elements in their original order. The
+// check sorts the indices of in by value alone and then looks for equal
+// values whose indices came out decreasing.
+func TestStable(t *testing.T) {
+	r := randsource.New("algorithms/sort/stable")
+	for trial := range 200 {
+		in := sortInput(r, "few", r.IntN(300))
+		byValue := func(a, b int) int { return cmp.Compare(in[a], in[b]) }
+		for _, alg := range sorts {
+			if !alg.stable {
+				continue
+			}
+			perm := make([]int, len(in))
+			for i := range perm {
+				perm[i] = i
+			}
+			alg.fnFunc(perm, byValue)
+			for i := 1; i < len(perm); i++ {
+				if in[perm[i-1]] == in[perm[i]] && perm[i-1] > perm[i] {
+					t.Fatalf("%s is not stable on %v (trial %d, seed %d)", alg.name, in, trial, randsource.Seed())
+				}
+			}
+		}
+	}
+}
+
+func TestFuncDescending(t *testing.T) {
+	desc := func(a, b string) int { return cmp.Compare(b, a) }
+	want := []string{"z", "m", "go", "b", "a"}
+	for name, f := range map[string]func([]string, func(a, b string) int){
+		"InsertionFunc": InsertionFunc[string],
+		"QuickFunc":     QuickFunc[string],
+		"MergeFunc":     MergeFunc[string],
+		"HeapFunc":      HeapFunc[string],
+	} {
+		got := []string{"b", "z", "a", "go", "m"}
+		f(got, desc)
+		if !slices.Equal(got, want) {
+			t.Errorf("%s descending = %q, want %q", name, got, want)
+		}
+	}
+}
+
+// BenchmarkSort times every sort on random input of growing size and on
+// sorted and reversed input. On random input insertion sort is the fastest
+// up to about a hundred elements and then falls far behind, which is why
+// real sorts, slices.Sort included, switch to it for short runs. Sorted
+// input is insertion sort's best case and, thanks to its early exit, merge
+// sort's too.
+func BenchmarkSort(b *testing.B) {
+	r := randsource.New("algorithms/sort/bench")
+	inputs := []struct {
+		order string
+		sizes []int
+	}{
+		{"random", []int{8, 32, 128, 1024, 10_000}},
+		{"sorted", []int{64, 10_000}},
+		{"reversed", []int{64, 10_000}},
+	}
+	for _, input := range inputs {
+		for _, n := range input.sizes {
+			in := sortInput(r, input.order, n)
+			for _, alg := range sorts {
+				if alg.name == "insertion" && n > 1024 && input.order != "sorted" {
+					continue // quadratic: too slow to bother
+				}
+				b.Run(fmt.Sprintf("%s/n=%d/%s", input.order, n, alg.name), func(b *testing.B) {
+					// Every run sorts a fresh copy. The copy is timed too:
+					// stopping the timer for it costs far more than the copy.
+					s := make([]int, n)
+					for range b.N {
+						copy(s, in)
+						alg.fn(s)
+					}
+				})
+			}
+		}
+	}
+}
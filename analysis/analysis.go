@@ -0,0 +1,243 @@
+// Package analysis contains go/analysis checks for mistakes that are easy
+// to make while learning Go. Several of them were made in this very
+// repository's early exercises, which makes them good teaching material:
+//
+//   - ChanPointer flags *chan T, which chapter12 once used to pass channels
+//   - SelectDefault flags a select with a default case on unbuffered
+//     channels, the pattern that made chapter12 exit before reading anything
+//   - StringByteIndex flags s[i] on strings holding non-ASCII text, the
+//     mistake chapter3 once made when printing "the fourth rune" of an emoji
+//     string
+//
+// Run them all with cmd/learnvet, or use Analyzers with any driver.
+package analysis
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Analyzers lists every check in this package.
+var Analyzers = []*analysis.Analyzer{ChanPointer, SelectDefault, StringByteIndex}
+
+// ChanPointer reports pointers to channels.
+var ChanPointer = &analysis.Analyzer{
+	Name: "chanptr",
+	Doc: "report pointers to channels\n\n" +
+		"A channel value already refers to the underlying channel, so *chan T " +
+		"adds indirection without any benefit. Pass chan T, or better a " +
+		"directional chan<- T or <-chan T.",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runChanPointer,
+}
+
+func runChanPointer(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	insp.Preorder([]ast.Node{(*ast.StarExpr)(nil)}, func(n ast.Node) {
+		star := n.(*ast.StarExpr)
+		// A StarExpr is either a pointer type (*chan int) or a dereference
+		// (*ch); only the former has a type name recorded for it.
+		tv, ok := pass.TypesInfo.Types[star]
+		if !ok || !tv.IsType() {
+			return
+		}
+		ptr, ok := tv.Type.(*types.Pointer)
+		if !ok {
+			return
+		}
+		if _, ok := ptr.Elem().Underlying().(*types.Chan); ok {
+			pass.Reportf(star.Pos(), "pointer to channel %s: channels are already references, pass %s (or a directional channel) instead",
+				types.TypeString(ptr, types.RelativeTo(pass.Pkg)), types.TypeString(ptr.Elem(), types.RelativeTo(pass.Pkg)))
+		}
+	})
+	return nil, nil
+}
+
+// SelectDefault reports select statements with a default case that
+// operate on channels created without a buffer.
+var SelectDefault = &analysis.Analyzer{
+	Name: "selectdefault",
+	Doc: "report select with default on unbuffered channels\n\n" +
+		"A send or receive on an unbuffered channel only proceeds when the " +
+		"other side is ready at that instant. With a default case, select " +
+		"usually takes the default before the other goroutine gets there, " +
+		"so values are silently skipped.",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runSelectDefault,
+}
+
+func runSelectDefault(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	unbuffered := madeUnbuffered(pass, insp)
+
+	insp.Preorder([]ast.Node{(*ast.SelectStmt)(nil)}, func(n ast.Node) {
+		sel := n.(*ast.SelectStmt)
+		hasDefault := false
+		for _, stmt := range sel.Body.List {
+			if cc := stmt.(*ast.CommClause); cc.Comm == nil {
+				hasDefault = true
+			}
+		}
+		if !hasDefault {
+			return
+		}
+		for _, stmt := range sel.Body.List {
+			cc := stmt.(*ast.CommClause)
+			if cc.Comm == nil {
+				continue
+			}
+			ch := commChannel(cc.Comm)
+			id, ok := ast.Unparen(ch).(*ast.Ident)
+			if !ok {
+				continue
+			}
+			if obj := pass.TypesInfo.Uses[id]; obj != nil && unbuffered[obj] {
+				pass.Reportf(cc.Pos(), "select with default on unbuffered channel %s: the default case wins unless the other goroutine is ready at that exact moment", id.Name)
+			}
+		}
+	})
+	return nil, nil
+}
+
+// madeUnbuffered returns the variables that are assigned make(chan T)
+// without a capacity anywhere in the package.
+func madeUnbuffered(pass *analysis.Pass, insp *inspector.Inspector) map[types.Object]bool {
+	objs := make(map[types.Object]bool)
+	record := func(lhs ast.Expr, rhs ast.Expr) {
+		call, ok := ast.Unparen(rhs).(*ast.CallExpr)
+		if !ok || len(call.Args) != 1 {
+			return
+		}
+		if fn, ok := ast.Unparen(call.Fun).(*ast.Ident); !ok || fn.Name != "make" {
+			return
+		}
+		if _, ok := pass.TypesInfo.TypeOf(call).Underlying().(*types.Chan); !ok {
+			return
+		}
+		if id, ok := lhs.(*ast.Ident); ok {
+			if obj := pass.TypesInfo.ObjectOf(id); obj != nil {
+				objs[obj] = true
+			}
+		}
+	}
+	insp.Preorder([]ast.Node{(*ast.AssignStmt)(nil), (*ast.ValueSpec)(nil)}, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if len(n.Lhs) == len(n.Rhs) {
+				for i := range n.Lhs {
+					record(n.Lhs[i], n.Rhs[i])
+				}
+			}
+		case *ast.ValueSpec:
+			if len(n.Names) == len(n.Values) {
+				for i := range n.Names {
+					record(n.Names[i], n.Values[i])
+				}
+			}
+		}
+	})
+	return objs
+}
+
+// commChannel returns the channel operand of a select case.
+func commChannel(stmt ast.Stmt) ast.Expr {
+	var expr ast.Expr
+	switch s := stmt.(type) {
+	case *ast.SendStmt:
+		return s.Chan
+	case *ast.ExprStmt:
+		expr = s.X
+	case *ast.AssignStmt:
+		expr = s.Rhs[0]
+	}
+	if u, ok := ast.Unparen(expr).(*ast.UnaryExpr); ok && u.Op == token.ARROW {
+		return u.X
+	}
+	return nil
+}
+
+// StringByteIndex reports indexing into strings that contain non-ASCII
+// characters.
+var StringByteIndex = &analysis.Analyzer{
+	Name: "stringbyteindex",
+	Doc: "report byte indexing of non-ASCII strings\n\n" +
+		"s[i] returns the i-th byte, not the i-th character. For strings " +
+		"holding multi-byte UTF-8 text (accents, scripts other than Latin, " +
+		"emoji) that is almost never what was meant; range over the string " +
+		"or convert it to []rune instead.",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runStringByteIndex,
+}
+
+func runStringByteIndex(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	// Variables initialized from a non-ASCII string constant.
+	nonASCII := make(map[types.Object]bool)
+	record := func(lhs, rhs ast.Expr) {
+		if !isNonASCIIConst(pass, rhs) {
+			return
+		}
+		if id, ok := lhs.(*ast.Ident); ok {
+			if obj := pass.TypesInfo.ObjectOf(id); obj != nil {
+				nonASCII[obj] = true
+			}
+		}
+	}
+	insp.Preorder([]ast.Node{(*ast.AssignStmt)(nil), (*ast.ValueSpec)(nil)}, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if len(n.Lhs) == len(n.Rhs) {
+				for i := range n.Lhs {
+					record(n.Lhs[i], n.Rhs[i])
+				}
+			}
+		case *ast.ValueSpec:
+			if len(n.Names) == len(n.Values) {
+				for i := range n.Names {
+					record(n.Names[i], n.Values[i])
+				}
+			}
+		}
+	})
+
+	insp.Preorder([]ast.Node{(*ast.IndexExpr)(nil)}, func(n ast.Node) {
+		idx := n.(*ast.IndexExpr)
+		t := pass.TypesInfo.TypeOf(idx.X)
+		if t == nil {
+			return
+		}
+		if b, ok := t.Underlying().(*types.Basic); !ok || b.Info()&types.IsString == 0 {
+			return
+		}
+		flag := isNonASCIIConst(pass, idx.X)
+		if id, ok := ast.Unparen(idx.X).(*ast.Ident); ok && nonASCII[pass.TypesInfo.Uses[id]] {
+			flag = true
+		}
+		if flag {
+			pass.Reportf(idx.Pos(), "indexing a string that contains non-ASCII characters returns a byte, not a character; use []rune(s)[i] or range over the string")
+		}
+	})
+	return nil, nil
+}
+
+func isNonASCIIConst(pass *analysis.Pass, e ast.Expr) bool {
+	tv, ok := pass.TypesInfo.Types[e]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return false
+	}
+	s := constant.StringVal(tv.Value)
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return true
+		}
+	}
+	return false
+}
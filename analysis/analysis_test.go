@@ -0,0 +1,27 @@
+package analysis
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// Each analyzer runs over its package under testdata/src; the diagnostics
+// expected there are written as // want comments on the offending lines,
+// and every other line must pass without one.
+func TestAnalyzers(t *testing.T) {
+	tests := []struct {
+		analyzer *analysis.Analyzer
+		pkg      string
+	}{
+		{ChanPointer, "chanptr"},
+		{SelectDefault, "selectdefault"},
+		{StringByteIndex, "stringbyteindex"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.analyzer.Name, func(t *testing.T) {
+			analysistest.Run(t, analysistest.TestData(), tt.analyzer, tt.pkg)
+		})
+	}
+}
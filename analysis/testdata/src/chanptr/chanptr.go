@@ -0,0 +1,20 @@
+package chanptr
+
+func send(ch *chan int) { // want `pointer to channel \*chan int`
+	*ch <- 1
+}
+
+type ints chan int
+
+func close2(ch *ints) { // want `pointer to channel \*ints`
+	close(*ch)
+}
+
+// Dereferencing is not a pointer type, and a directional channel is fine.
+func receive(ch <-chan int, p *int) int {
+	return <-ch + *p
+}
+
+var _ = send
+var _ = close2
+var _ = receive
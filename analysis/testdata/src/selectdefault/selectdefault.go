@@ -0,0 +1,33 @@
+package selectdefault
+
+func unbuffered() {
+	ch := make(chan int)
+	done := make(chan struct{})
+	select {
+	case v := <-ch: // want `select with default on unbuffered channel ch`
+		_ = v
+	case done <- struct{}{}: // want `select with default on unbuffered channel done`
+	default:
+	}
+}
+
+func buffered() {
+	ch := make(chan int, 1)
+	select {
+	case ch <- 1:
+	default:
+	}
+}
+
+// Without a default case, select waits, which is what unbuffered channels
+// need.
+func blocking() {
+	var ch = make(chan int)
+	select {
+	case <-ch:
+	}
+}
+
+var _ = unbuffered
+var _ = buffered
+var _ = blocking
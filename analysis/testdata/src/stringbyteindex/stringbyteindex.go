@@ -0,0 +1,16 @@
+package stringbyteindex
+
+const greeting = "سلام"
+
+func index() {
+	s := "👍🏽 ok"
+	_ = s[3]        // want `indexing a string that contains non-ASCII characters`
+	_ = greeting[0] // want `indexing a string that contains non-ASCII characters`
+	_ = "héllo"[1]  // want `indexing a string that contains non-ASCII characters`
+
+	ascii := "hello"
+	_ = ascii[1]
+	_ = []rune(s)[0]
+}
+
+var _ = index
@@ -0,0 +1,80 @@
+// Package benchmarks is a set of Go benchmarks that each compare a common
+// performance pitfall with its fix:
+//
+//   - growing a slice with append versus preallocating it with make
+//   - concatenating strings with += versus strings.Builder
+//   - calling methods on a large struct through value versus pointer
+//     receivers
+//   - filling a map that starts empty versus one sized up front
+//   - passing values over an unbuffered versus a buffered channel
+//
+// The benchmarks are ordinary func(*testing.B) values, so they run outside
+// of "go test" through testing.Benchmark. Run prints them in the format of
+// "go test -bench . -benchmem", with the lesson of each group above it and
+// the advice in every benchmark's name:
+//
+//	go run ./cmd/bench                 # or: make bench
+//	go run ./cmd/bench -run Map        # only the groups matching a regexp
+//	go run ./cmd/bench | go run ./cmd/benchtrack record -
+//
+// "go test -bench . -benchmem ./benchmarks" runs the same cases under the
+// same names, without the lessons.
+package benchmarks
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"runtime"
+	"testing"
+)
+
+// Group is a set of benchmarks that do the same work in different ways.
+type Group struct {
+	Name string
+	// Lesson is what the numbers show, printed above the results.
+	Lesson string
+	Cases  []Case
+}
+
+// Case is one benchmark. Its name reads as advice, such as
+// "make_with_cap_when_size_is_known".
+type Case struct {
+	Name string
+	F    func(b *testing.B)
+}
+
+// Groups lists every benchmark group, in the order Run prints them.
+var Groups = []Group{
+	sliceGrowth,
+	stringConcat,
+	receivers,
+	mapSizing,
+	channels,
+	memoization,
+}
+
+// pkg is the package path Run reports, so results recorded by benchtrack
+// are named like the ones "go test -bench" would produce.
+const pkg = "learning-go/benchmarks"
+
+// Run runs the benchmarks of every group whose name matches filter, or of
+// all groups if filter is nil, and writes the results to w as "go test
+// -bench . -benchmem" would.
+func Run(w io.Writer, filter *regexp.Regexp) {
+	fmt.Fprintf(w, "pkg: %s\n", pkg)
+	for _, g := range Groups {
+		if filter != nil && !filter.MatchString(g.Name) {
+			continue
+		}
+		fmt.Fprintf(w, "\n# %s: %s\n", g.Name, g.Lesson)
+		for _, c := range g.Cases {
+			res := testing.Benchmark(func(b *testing.B) {
+				b.ReportAllocs()
+				c.F(b)
+			})
+			fmt.Fprintf(w, "Benchmark%s/%s-%d\t%s\t%s\n",
+				g.Name, c.Name, runtime.GOMAXPROCS(0), res.String(), res.MemString())
+		}
+	}
+}
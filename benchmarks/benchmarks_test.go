@@ -0,0 +1,69 @@
+package benchmarks
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// The Benchmark functions below let "go test -bench" run the same groups
+// as cmd/bench, under the same names:
+//
+//	go test -bench . -benchmem ./benchmarks
+//	go test -bench 'MapSizing/pre' ./benchmarks
+
+// runGroup runs every case of g as a sub-benchmark.
+func runGroup(b *testing.B, g Group) {
+	for _, c := range g.Cases {
+		b.Run(c.Name, func(b *testing.B) {
+			b.ReportAllocs()
+			c.F(b)
+		})
+	}
+}
+
+func BenchmarkSliceGrowth(b *testing.B)  { runGroup(b, sliceGrowth) }
+func BenchmarkStringConcat(b *testing.B) { runGroup(b, stringConcat) }
+func BenchmarkReceivers(b *testing.B)    { runGroup(b, receivers) }
+func BenchmarkMapSizing(b *testing.B)    { runGroup(b, mapSizing) }
+func BenchmarkChannels(b *testing.B)     { runGroup(b, channels) }
+func BenchmarkMemoization(b *testing.B)  { runGroup(b, memoization) }
+
+// Case names are the advice. "go test" splits sub-benchmark names on "/"
+// and rewrites spaces, so a name must have neither to match what cmd/bench
+// prints.
+var caseName = regexp.MustCompile(`^[^\s/]+$`)
+
+func TestGroups(t *testing.T) {
+	groups := make(map[string]bool)
+	for _, g := range Groups {
+		if groups[g.Name] {
+			t.Errorf("group %s listed twice", g.Name)
+		}
+		groups[g.Name] = true
+		if g.Lesson == "" || len(g.Cases) < 2 {
+			t.Errorf("group %s needs a lesson and at least two cases to compare", g.Name)
+		}
+		cases := make(map[string]bool)
+		for _, c := range g.Cases {
+			if !caseName.MatchString(c.Name) {
+				t.Errorf("%s/%s: a case name must have no spaces or slashes", g.Name, c.Name)
+			}
+			if cases[c.Name] {
+				t.Errorf("%s/%s listed twice", g.Name, c.Name)
+			}
+			cases[c.Name] = true
+			if c.F == nil {
+				t.Errorf("%s/%s has no benchmark function", g.Name, c.Name)
+			}
+		}
+	}
+}
+
+func TestRunFilter(t *testing.T) {
+	var out strings.Builder
+	Run(&out, regexp.MustCompile(`^NoSuchGroup$`))
+	if got, want := out.String(), "pkg: "+pkg+"\n"; got != want {
+		t.Errorf("Run with a filter matching nothing wrote %q, want %q", got, want)
+	}
+}
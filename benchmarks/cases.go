@@ -0,0 +1,304 @@
+package benchmarks
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"learning-go/memo"
+)
+
+// n is the number of elements the slice, string and map benchmarks build.
+const n = 10_000
+
+// Sinks keep results alive so the compiler cannot skip the work.
+var (
+	sinkInt    int
+	sinkInts   []int
+	sinkString string
+	sinkMap    map[int]int
+)
+
+var sliceGrowth = Group{
+	Name: "SliceGrowth",
+	Lesson: "append reallocates and copies every time the capacity runs out; " +
+		"make with a capacity allocates once.",
+	Cases: []Case{
+		{"append_to_nil_slice", func(b *testing.B) {
+			for range b.N {
+				var s []int
+				for i := range n {
+					s = append(s, i)
+				}
+				sinkInts = s
+			}
+		}},
+		{"make_with_cap_when_size_is_known", func(b *testing.B) {
+			for range b.N {
+				s := make([]int, 0, n)
+				for i := range n {
+					s = append(s, i)
+				}
+				sinkInts = s
+			}
+		}},
+		{"make_with_len_and_assign_by_index", func(b *testing.B) {
+			for range b.N {
+				s := make([]int, n)
+				for i := range s {
+					s[i] = i
+				}
+				sinkInts = s
+			}
+		}},
+	},
+}
+
+// words are the pieces the string benchmarks join.
+var words = func() []string {
+	w := make([]string, 1000)
+	for i := range w {
+		w[i] = "word" + strconv.Itoa(i)
+	}
+	return w
+}()
+
+var stringConcat = Group{
+	Name: "StringConcat",
+	Lesson: "strings are immutable, so += copies everything built so far; " +
+		"a strings.Builder appends to one growing buffer.",
+	Cases: []Case{
+		{"plus_equals_in_a_loop_is_quadratic", func(b *testing.B) {
+			for range b.N {
+				s := ""
+				for _, w := range words {
+					s += w + " "
+				}
+				sinkString = s
+			}
+		}},
+		{"strings.Builder", func(b *testing.B) {
+			for range b.N {
+				var sb strings.Builder
+				for _, w := range words {
+					sb.WriteString(w)
+					sb.WriteByte(' ')
+				}
+				sinkString = sb.String()
+			}
+		}},
+		{"strings.Builder_with_Grow_when_size_is_known", func(b *testing.B) {
+			size := 0
+			for _, w := range words {
+				size += len(w) + 1
+			}
+			for range b.N {
+				var sb strings.Builder
+				sb.Grow(size)
+				for _, w := range words {
+					sb.WriteString(w)
+					sb.WriteByte(' ')
+				}
+				sinkString = sb.String()
+			}
+		}},
+		{"strings.Join_when_the_pieces_are_in_a_slice", func(b *testing.B) {
+			for range b.N {
+				sinkString = strings.Join(words, " ")
+			}
+		}},
+	},
+}
+
+// large is a 1 KiB struct, big enough that copying it shows up.
+type large struct {
+	values [128]int
+}
+
+// SumValue has a value receiver, so every call copies all of l.
+//
+//go:noinline
+func (l large) SumValue() int {
+	return l.values[0] + l.values[len(l.values)-1]
+}
+
+// SumPointer has a pointer receiver, so every call copies one pointer.
+//
+//go:noinline
+func (l *large) SumPointer() int {
+	return l.values[0] + l.values[len(l.values)-1]
+}
+
+var receivers = Group{
+	Name: "Receivers",
+	Lesson: "a value receiver copies the whole struct on every call; " +
+		"for large structs, use a pointer receiver.",
+	Cases: []Case{
+		{"value_receiver_copies_1KiB_per_call", func(b *testing.B) {
+			l := large{}
+			for range b.N {
+				sinkInt += l.SumValue()
+			}
+		}},
+		{"pointer_receiver_copies_8_bytes", func(b *testing.B) {
+			l := &large{}
+			for range b.N {
+				sinkInt += l.SumPointer()
+			}
+		}},
+	},
+}
+
+var mapSizing = Group{
+	Name: "MapSizing",
+	Lesson: "a map that starts empty rehashes into bigger tables as it grows; " +
+		"a size hint to make allocates the final table once.",
+	Cases: []Case{
+		{"make_without_size_hint", func(b *testing.B) {
+			for range b.N {
+				m := make(map[int]int)
+				for i := range n {
+					m[i] = i
+				}
+				sinkMap = m
+			}
+		}},
+		{"make_with_size_hint_when_size_is_known", func(b *testing.B) {
+			for range b.N {
+				m := make(map[int]int, n)
+				for i := range n {
+					m[i] = i
+				}
+				sinkMap = m
+			}
+		}},
+	},
+}
+
+// transfer sends count values over a channel with the given buffer size
+// to a receiving goroutine and waits until it has them all.
+func transfer(count, buffer int) int {
+	ch := make(chan int, buffer)
+	done := make(chan int)
+	go func() {
+		sum := 0
+		for v := range ch {
+			sum += v
+		}
+		done <- sum
+	}()
+	for i := range count {
+		ch <- i
+	}
+	close(ch)
+	return <-done
+}
+
+var channels = Group{
+	Name: "Channels",
+	Lesson: "every send on an unbuffered channel waits for the receiver, " +
+		"which costs a goroutine switch; a buffer lets the sender run ahead.",
+	Cases: []Case{
+		{"unbuffered_hands_off_every_value", func(b *testing.B) {
+			for range b.N {
+				sinkInt += transfer(1000, 0)
+			}
+		}},
+		{"buffered_by_1", func(b *testing.B) {
+			for range b.N {
+				sinkInt += transfer(1000, 1)
+			}
+		}},
+		{"buffered_by_100_lets_the_sender_run_ahead", func(b *testing.B) {
+			for range b.N {
+				sinkInt += transfer(1000, 100)
+			}
+		}},
+	},
+}
+
+// fib is the exponential recursive Fibonacci that memoization fixes.
+func fib(n int) int {
+	if n < 2 {
+		return n
+	}
+	return fib(n-1) + fib(n-2)
+}
+
+// slowAPI stands for a remote lookup: it takes a millisecond whoever asks.
+func slowAPI(key string) (int, error) {
+	time.Sleep(time.Millisecond)
+	return len(key), nil
+}
+
+// callers is how many goroutines ask slowAPI for the same key at once.
+const callers = 8
+
+// fanOut calls get from callers goroutines at once and waits for them.
+func fanOut(get func(string) (int, error)) {
+	var wg sync.WaitGroup
+	var total atomic.Int64
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, _ := get("gopher")
+			total.Add(int64(v))
+		}()
+	}
+	wg.Wait()
+	sinkInt = int(total.Load())
+}
+
+var memoization = Group{
+	Name: "Memoization",
+	Lesson: "a memoized recursive function computes each value once instead of " +
+		"exponentially often; single flight turns simultaneous identical " +
+		"requests into one.",
+	Cases: []Case{
+		{"recursive_fib_25", func(b *testing.B) {
+			for range b.N {
+				sinkInt = fib(25)
+			}
+		}},
+		{"memoized_fib_25_with_a_new_cache_each_time", func(b *testing.B) {
+			for range b.N {
+				var f func(int) int
+				f = memo.Func(func(n int) int {
+					if n < 2 {
+						return n
+					}
+					return f(n-1) + f(n-2)
+				})
+				sinkInt = f(25)
+			}
+		}},
+		{"slow_api_called_by_every_goroutine", func(b *testing.B) {
+			var calls atomic.Int64
+			for range b.N {
+				fanOut(func(key string) (int, error) {
+					calls.Add(1)
+					return slowAPI(key)
+				})
+			}
+			b.ReportMetric(float64(calls.Load())/float64(b.N), "calls/op")
+		}},
+		{"slow_api_behind_single_flight", func(b *testing.B) {
+			var calls atomic.Int64
+			for range b.N {
+				var g memo.Group[string, int]
+				fanOut(func(key string) (int, error) {
+					v, err, _ := g.Do(key, func() (int, error) {
+						calls.Add(1)
+						return slowAPI(key)
+					})
+					return v, err
+				})
+			}
+			b.ReportMetric(float64(calls.Load())/float64(b.N), "calls/op")
+		}},
+	},
+}
@@ -0,0 +1,240 @@
+// Package benchtrack records benchmark results per git commit and compares
+// two commits to catch performance regressions. It reads the standard
+// output format of "go test -bench" (with or without -benchmem):
+//
+//	pkg: learning-go/seq
+//	BenchmarkMerge-8   	  512345	      2345 ns/op	     128 B/op	       2 allocs/op
+//
+// Results are kept in a JSON file so the history can be inspected and
+// diffed by hand.
+package benchtrack
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Result is one benchmark line. Benchmarks run with -count > 1 produce
+// several Results with the same name.
+type Result struct {
+	// Name is the package and benchmark without the GOMAXPROCS suffix,
+	// e.g. "learning-go/seq.BenchmarkMerge".
+	Name        string  `json:"name"`
+	Iterations  int     `json:"iterations"`
+	NsPerOp     float64 `json:"ns_per_op"`
+	BytesPerOp  float64 `json:"bytes_per_op"`
+	AllocsPerOp float64 `json:"allocs_per_op"`
+}
+
+var procsSuffix = regexp.MustCompile(`-\d+$`)
+
+// Parse reads "go test -bench" output and returns every benchmark result
+// in it. Other lines (PASS, ok, logs) are ignored.
+func Parse(r io.Reader) ([]Result, error) {
+	var results []Result
+	pkg := ""
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := sc.Text()
+		if rest, ok := strings.CutPrefix(line, "pkg: "); ok {
+			pkg = strings.TrimSpace(rest)
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") {
+			continue
+		}
+		n, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue // e.g. "BenchmarkX --- FAIL"
+		}
+		res := Result{Name: procsSuffix.ReplaceAllString(fields[0], ""), Iterations: n}
+		if pkg != "" {
+			res.Name = pkg + "." + res.Name
+		}
+		// The rest of the line is value/unit pairs.
+		for i := 2; i+1 < len(fields); i += 2 {
+			v, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("benchtrack: %q: bad value %q", line, fields[i])
+			}
+			switch fields[i+1] {
+			case "ns/op":
+				res.NsPerOp = v
+			case "B/op":
+				res.BytesPerOp = v
+			case "allocs/op":
+				res.AllocsPerOp = v
+			}
+		}
+		results = append(results, res)
+	}
+	return results, sc.Err()
+}
+
+// Run is the results recorded for one commit.
+type Run struct {
+	Commit   string    `json:"commit"`
+	Recorded time.Time `json:"recorded"`
+	Results  []Result  `json:"results"`
+}
+
+// Store is the benchmark history, one Run per commit.
+type Store struct {
+	path string
+	Runs []Run `json:"runs"`
+}
+
+// Open loads the history at path. A missing file is an empty history.
+func Open(path string) (*Store, error) {
+	s := &Store{path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("benchtrack: %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Record stores results for commit, replacing any earlier run of the same
+// commit.
+func (s *Store) Record(commit string, results []Result, now time.Time) {
+	run := Run{Commit: commit, Recorded: now, Results: results}
+	for i := range s.Runs {
+		if s.Runs[i].Commit == commit {
+			s.Runs[i] = run
+			return
+		}
+	}
+	s.Runs = append(s.Runs, run)
+}
+
+// Get returns the run recorded for commit.
+func (s *Store) Get(commit string) (Run, bool) {
+	for _, r := range s.Runs {
+		if r.Commit == commit {
+			return r, true
+		}
+	}
+	return Run{}, false
+}
+
+// Save writes the history back to the file it was opened from.
+func (s *Store) Save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// Metric names a measured quantity.
+type Metric string
+
+const (
+	NsPerOp     Metric = "ns/op"
+	BytesPerOp  Metric = "B/op"
+	AllocsPerOp Metric = "allocs/op"
+)
+
+// Delta is the change of one metric of one benchmark between two runs.
+type Delta struct {
+	Name   string
+	Metric Metric
+	Old    float64
+	New    float64
+	// Change is (New-Old)/Old, e.g. 0.25 for 25% slower. It is +Inf when
+	// Old is zero and New is not.
+	Change float64
+	// Regression is set when Change exceeds the threshold.
+	Regression bool
+}
+
+// Compare compares the median of each metric for every benchmark present
+// in both base and head. Benchmarks present in only one of them are
+// skipped. A metric that grew by more than threshold (0.1 for 10%) is a
+// regression; lower is better for every metric.
+func Compare(base, head []Result, threshold float64) []Delta {
+	oldM, newM := medians(base), medians(head)
+	names := make([]string, 0, len(newM))
+	for name := range newM {
+		if _, ok := oldM[name]; ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var deltas []Delta
+	for _, name := range names {
+		for _, m := range []Metric{NsPerOp, BytesPerOp, AllocsPerOp} {
+			o, n := oldM[name][m], newM[name][m]
+			if o == 0 && n == 0 {
+				continue // not measured, or nothing to compare
+			}
+			change := math.Inf(1)
+			if o != 0 {
+				change = (n - o) / o
+			}
+			deltas = append(deltas, Delta{
+				Name: name, Metric: m, Old: o, New: n,
+				Change: change, Regression: change > threshold,
+			})
+		}
+	}
+	return deltas
+}
+
+func medians(results []Result) map[string]map[Metric]float64 {
+	samples := make(map[string]map[Metric][]float64)
+	for _, r := range results {
+		m := samples[r.Name]
+		if m == nil {
+			m = make(map[Metric][]float64)
+			samples[r.Name] = m
+		}
+		m[NsPerOp] = append(m[NsPerOp], r.NsPerOp)
+		m[BytesPerOp] = append(m[BytesPerOp], r.BytesPerOp)
+		m[AllocsPerOp] = append(m[AllocsPerOp], r.AllocsPerOp)
+	}
+	out := make(map[string]map[Metric]float64, len(samples))
+	for name, metrics := range samples {
+		out[name] = make(map[Metric]float64, len(metrics))
+		for metric, values := range metrics {
+			out[name][metric] = median(values)
+		}
+	}
+	return out
+}
+
+func median(values []float64) float64 {
+	sort.Float64s(values)
+	n := len(values)
+	if n%2 == 1 {
+		return values[n/2]
+	}
+	return (values[n/2-1] + values[n/2]) / 2
+}
@@ -0,0 +1,128 @@
+package benchtrack
+
+import (
+	"math"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+const output = `goos: linux
+goarch: amd64
+pkg: learning-go/seq
+cpu: Some CPU @ 2.00GHz
+BenchmarkMerge-8   	  512345	      2345 ns/op	     128 B/op	       2 allocs/op
+BenchmarkMerge-8   	  500000	      2400 ns/op	     128 B/op	       2 allocs/op
+BenchmarkSplit     	 1000000	      1024 ns/op
+--- FAIL: BenchmarkBroken
+BenchmarkBroken --- FAIL
+PASS
+ok  	learning-go/seq	3.210s
+pkg: learning-go/cache/lru
+BenchmarkGet-16    	10000000	       110.5 ns/op	       0 B/op	       0 allocs/op
+`
+
+func TestParse(t *testing.T) {
+	got, err := Parse(strings.NewReader(output))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Result{
+		{Name: "learning-go/seq.BenchmarkMerge", Iterations: 512345, NsPerOp: 2345, BytesPerOp: 128, AllocsPerOp: 2},
+		{Name: "learning-go/seq.BenchmarkMerge", Iterations: 500000, NsPerOp: 2400, BytesPerOp: 128, AllocsPerOp: 2},
+		{Name: "learning-go/seq.BenchmarkSplit", Iterations: 1000000, NsPerOp: 1024},
+		{Name: "learning-go/cache/lru.BenchmarkGet", Iterations: 10000000, NsPerOp: 110.5},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Parse =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestParseMalformed(t *testing.T) {
+	tests := []struct {
+		line    string
+		wantErr bool
+	}{
+		{"BenchmarkX-8  100  fast ns/op", true},
+		{"BenchmarkX-8  100  12 ns/op  many B/op", true},
+		{"BenchmarkX-8  lots  12 ns/op", false}, // no iteration count: not a result
+		{"BenchmarkX-8  100", false},            // too short: not a result
+		{"Benchmarks are fun, see below", false},
+	}
+	for _, tt := range tests {
+		got, err := Parse(strings.NewReader(tt.line + "\n"))
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Parse(%q) = %+v, want an error", tt.line, got)
+			}
+			continue
+		}
+		if err != nil || len(got) != 0 {
+			t.Errorf("Parse(%q) = %+v, %v; want no results", tt.line, got, err)
+		}
+	}
+}
+
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bench", "history.json")
+	s, err := Open(path)
+	if err != nil || len(s.Runs) != 0 {
+		t.Fatalf("Open of a missing file = %+v, %v; want an empty history", s, err)
+	}
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s.Record("abc123", []Result{{Name: "p.BenchmarkA", NsPerOp: 10}}, now)
+	s.Record("def456", []Result{{Name: "p.BenchmarkA", NsPerOp: 12}}, now)
+	// Recording a commit again replaces its run.
+	s.Record("abc123", []Result{{Name: "p.BenchmarkA", NsPerOp: 11}}, now.Add(time.Hour))
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err = Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Runs) != 2 {
+		t.Fatalf("reopened history has %d runs, want 2", len(s.Runs))
+	}
+	run, ok := s.Get("abc123")
+	if !ok || !run.Recorded.Equal(now.Add(time.Hour)) || run.Results[0].NsPerOp != 11 {
+		t.Errorf("Get(abc123) = %+v, %v; want the second recording", run, ok)
+	}
+	if _, ok := s.Get("fff000"); ok {
+		t.Errorf("Get of an unrecorded commit succeeded")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	base := []Result{
+		{Name: "A", NsPerOp: 100, AllocsPerOp: 1},
+		{Name: "A", NsPerOp: 300, AllocsPerOp: 1},
+		{Name: "A", NsPerOp: 200, AllocsPerOp: 1},
+		{Name: "B", NsPerOp: 50},
+		{Name: "Gone", NsPerOp: 1},
+	}
+	head := []Result{
+		{Name: "A", NsPerOp: 210, AllocsPerOp: 2},
+		{Name: "B", NsPerOp: 40, BytesPerOp: 16},
+		{Name: "New", NsPerOp: 1},
+	}
+	want := []Delta{
+		// The median of 100, 300 and 200 is 200: 5% slower is within 10%.
+		{Name: "A", Metric: NsPerOp, Old: 200, New: 210, Change: 0.05},
+		{Name: "A", Metric: AllocsPerOp, Old: 1, New: 2, Change: 1, Regression: true},
+		{Name: "B", Metric: NsPerOp, Old: 50, New: 40, Change: -0.2},
+		{Name: "B", Metric: BytesPerOp, Old: 0, New: 16, Change: math.Inf(1), Regression: true},
+	}
+	if got := Compare(base, head, 0.1); !slices.Equal(got, want) {
+		t.Errorf("Compare =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestMedianOfEven(t *testing.T) {
+	if got := median([]float64{4, 1, 3, 2}); got != 2.5 {
+		t.Errorf("median(4, 1, 3, 2) = %v, want 2.5", got)
+	}
+}
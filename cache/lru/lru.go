@@ -0,0 +1,239 @@
+// Package lru implements a generic least-recently-used cache.
+//
+// A Cache holds at most Capacity entries. Every Get or Put moves the entry
+// to the front of a doubly linked list, so the entry at the back is always
+// the one used longest ago, and it is evicted when a new key needs the
+// room. A map from key to list element makes every operation O(1).
+//
+//	c := lru.New[string, int](2)
+//	c.Put("a", 1)
+//	c.Put("b", 2)
+//	c.Get("a")    // "a" is now the most recently used
+//	c.Put("c", 3) // evicts "b"
+//
+// Entries may also expire: WithTTL sets a lifetime for every entry and
+// PutTTL one for a single entry. Expired entries are treated as missing and
+// removed when Get next looks at them or when they reach the back of the
+// list and a new entry needs the room.
+//
+// A Cache is not safe for concurrent use unless it was created with
+// WithLocking.
+package lru
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"learning-go/clock"
+)
+
+type options struct {
+	ttl   time.Duration
+	clock clock.Clock
+	lock  bool
+}
+
+// Option configures New.
+type Option func(*options)
+
+// WithTTL makes entries expire d after they were last Put. A zero or
+// negative d keeps entries until they are evicted, which is the default.
+func WithTTL(d time.Duration) Option {
+	return func(o *options) { o.ttl = d }
+}
+
+// WithClock sets the clock used to expire entries. Tests pass a
+// *clock.Fake to control expiry; the default is clock.Real.
+func WithClock(c clock.Clock) Option {
+	return func(o *options) { o.clock = c }
+}
+
+// WithLocking guards the cache with a sync.RWMutex so that it can be used
+// from several goroutines at once. Get and Put take the write lock, since
+// both reorder the list; Peek, Contains and Len take the read lock and may
+// run in parallel.
+func WithLocking() Option {
+	return func(o *options) { o.lock = true }
+}
+
+type entry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time // zero for no expiry
+}
+
+// Cache is an LRU cache from K to V. Create one with New.
+type Cache[K comparable, V any] struct {
+	capacity int
+	opts     options
+	mu       *sync.RWMutex // nil without WithLocking
+	order    *list.List    // front is the most recently used entry
+	items    map[K]*list.Element
+}
+
+// New returns an empty cache holding at most capacity entries. It panics if
+// capacity is not positive.
+func New[K comparable, V any](capacity int, opts ...Option) *Cache[K, V] {
+	if capacity <= 0 {
+		panic("lru: capacity must be positive")
+	}
+	c := &Cache[K, V]{
+		capacity: capacity,
+		opts:     options{clock: clock.Real},
+		order:    list.New(),
+		// The capacity is only a limit: a cache that may grow large, such
+		// as memo's unlimited one, starts small.
+		items: make(map[K]*list.Element, min(capacity, 1024)),
+	}
+	for _, opt := range opts {
+		opt(&c.opts)
+	}
+	if c.opts.lock {
+		c.mu = new(sync.RWMutex)
+	}
+	return c
+}
+
+func (c *Cache[K, V]) lock() {
+	if c.mu != nil {
+		c.mu.Lock()
+	}
+}
+
+func (c *Cache[K, V]) unlock() {
+	if c.mu != nil {
+		c.mu.Unlock()
+	}
+}
+
+func (c *Cache[K, V]) rlock() {
+	if c.mu != nil {
+		c.mu.RLock()
+	}
+}
+
+func (c *Cache[K, V]) runlock() {
+	if c.mu != nil {
+		c.mu.RUnlock()
+	}
+}
+
+// Capacity returns the most entries the cache holds.
+func (c *Cache[K, V]) Capacity() int {
+	return c.capacity
+}
+
+// Get returns the value for key and marks it as the most recently used.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.lock()
+	defer c.unlock()
+	el, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	e := el.Value.(*entry[K, V])
+	if c.expired(e) {
+		c.remove(el)
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(el)
+	return e.value, true
+}
+
+// Peek returns the value for key without marking it as used.
+func (c *Cache[K, V]) Peek(key K) (V, bool) {
+	c.rlock()
+	defer c.runlock()
+	if el, ok := c.items[key]; ok {
+		if e := el.Value.(*entry[K, V]); !c.expired(e) {
+			return e.value, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains reports whether key is in the cache and has not expired,
+// without marking it as used.
+func (c *Cache[K, V]) Contains(key K) bool {
+	_, ok := c.Peek(key)
+	return ok
+}
+
+// Put stores value under key with the TTL from WithTTL, marks it as the
+// most recently used and, if the cache was full, evicts the least recently
+// used entry. It reports whether an entry was evicted.
+func (c *Cache[K, V]) Put(key K, value V) (evicted bool) {
+	return c.PutTTL(key, value, c.opts.ttl)
+}
+
+// PutTTL is like Put but makes this entry expire after ttl, or never if ttl
+// is zero or negative.
+func (c *Cache[K, V]) PutTTL(key K, value V, ttl time.Duration) (evicted bool) {
+	c.lock()
+	defer c.unlock()
+	e := &entry[K, V]{key: key, value: value}
+	if ttl > 0 {
+		e.expires = c.opts.clock.Now().Add(ttl)
+	}
+	if el, ok := c.items[key]; ok {
+		el.Value = e
+		c.order.MoveToFront(el)
+		return false
+	}
+	c.items[key] = c.order.PushFront(e)
+	if c.order.Len() <= c.capacity {
+		return false
+	}
+	// Dropping an entry that had expired anyway does not count as an
+	// eviction.
+	oldest := c.order.Back()
+	expired := c.expired(oldest.Value.(*entry[K, V]))
+	c.remove(oldest)
+	return !expired
+}
+
+// Remove deletes key and reports whether it was present.
+func (c *Cache[K, V]) Remove(key K) bool {
+	c.lock()
+	defer c.unlock()
+	el, ok := c.items[key]
+	if ok {
+		c.remove(el)
+	}
+	return ok
+}
+
+// Len returns the number of entries, including expired ones that have not
+// been removed yet.
+func (c *Cache[K, V]) Len() int {
+	c.rlock()
+	defer c.runlock()
+	return c.order.Len()
+}
+
+// Keys returns the keys from the most to the least recently used,
+// including expired ones that have not been removed yet.
+func (c *Cache[K, V]) Keys() []K {
+	c.rlock()
+	defer c.runlock()
+	keys := make([]K, 0, c.order.Len())
+	for el := c.order.Front(); el != nil; el = el.Next() {
+		keys = append(keys, el.Value.(*entry[K, V]).key)
+	}
+	return keys
+}
+
+// expired reports whether e has outlived its TTL.
+func (c *Cache[K, V]) expired(e *entry[K, V]) bool {
+	return !e.expires.IsZero() && !c.opts.clock.Now().Before(e.expires)
+}
+
+// remove deletes el from the list and the map. The write lock must be held.
+func (c *Cache[K, V]) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.items, el.Value.(*entry[K, V]).key)
+}
@@ -0,0 +1,220 @@
+package lru
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"learning-go/clock"
+)
+
+func TestEvictsLeastRecentlyUsed(t *testing.T) {
+	c := New[string, int](2)
+	if c.Put("a", 1) || c.Put("b", 2) {
+		t.Fatal("Put evicted from a cache that was not full")
+	}
+	c.Get("a")
+	if !c.Put("c", 3) {
+		t.Error("Put into a full cache did not evict")
+	}
+	if c.Contains("b") {
+		t.Error("b was evicted last but is still there")
+	}
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Errorf(`Get("a") = %d, %v; want 1, true`, v, ok)
+	}
+	if got, want := c.Keys(), []string{"a", "c"}; !slices.Equal(got, want) {
+		t.Errorf("Keys() = %v, want %v", got, want)
+	}
+	if c.Len() != 2 || c.Capacity() != 2 {
+		t.Errorf("Len, Capacity = %d, %d; want 2, 2", c.Len(), c.Capacity())
+	}
+}
+
+func TestPeekDoesNotRefresh(t *testing.T) {
+	c := New[string, int](2)
+	c.Put("a", 1)
+	c.Put("b", 2)
+	if v, ok := c.Peek("a"); !ok || v != 1 {
+		t.Errorf(`Peek("a") = %d, %v; want 1, true`, v, ok)
+	}
+	c.Put("c", 3)
+	if c.Contains("a") {
+		t.Error("Peek moved a to the front, so b was evicted instead")
+	}
+}
+
+func TestPutExistingKey(t *testing.T) {
+	c := New[string, int](2)
+	c.Put("a", 1)
+	c.Put("b", 2)
+	if c.Put("a", 10) {
+		t.Error("updating a key evicted an entry")
+	}
+	if got, want := c.Keys(), []string{"a", "b"}; !slices.Equal(got, want) {
+		t.Errorf("Keys() = %v, want %v", got, want)
+	}
+	if v, _ := c.Get("a"); v != 10 {
+		t.Errorf(`Get("a") = %d, want 10`, v)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	c := New[int, string](3)
+	c.Put(1, "one")
+	c.Put(2, "two")
+	if !c.Remove(1) {
+		t.Error("Remove(1) = false for a present key")
+	}
+	if c.Remove(1) {
+		t.Error("Remove(1) = true a second time")
+	}
+	if _, ok := c.Get(1); ok || c.Len() != 1 {
+		t.Errorf("after Remove: Get found the key or Len() = %d", c.Len())
+	}
+}
+
+func TestTTL(t *testing.T) {
+	fake := clock.NewFake(time.Unix(0, 0))
+	c := New[string, int](3, WithTTL(time.Minute), WithClock(fake))
+	c.Put("a", 1)
+	c.PutTTL("b", 2, 0) // never expires
+	c.PutTTL("c", 3, 10*time.Second)
+
+	fake.Advance(10 * time.Second)
+	if c.Contains("c") {
+		t.Error("c outlived its own TTL")
+	}
+	if !c.Contains("a") {
+		t.Error("a expired before the default TTL")
+	}
+	// Expired entries stay counted until something removes them.
+	if c.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", c.Len())
+	}
+	if _, ok := c.Get("c"); ok || c.Len() != 2 {
+		t.Errorf("Get of an expired key: ok = %v, Len() = %d; want false, 2", ok, c.Len())
+	}
+
+	fake.Advance(50 * time.Second)
+	if _, ok := c.Get("a"); ok {
+		t.Error("a still there after a minute")
+	}
+	if v, ok := c.Get("b"); !ok || v != 2 {
+		t.Errorf(`Get("b") = %d, %v; want 2, true`, v, ok)
+	}
+}
+
+func TestPutOverExpiredIsNoEviction(t *testing.T) {
+	fake := clock.NewFake(time.Unix(0, 0))
+	c := New[int, int](1, WithClock(fake))
+	c.PutTTL(1, 1, time.Second)
+	fake.Advance(time.Second)
+	if c.Put(2, 2) {
+		t.Error("dropping an expired entry counted as an eviction")
+	}
+	if !c.Put(3, 3) {
+		t.Error("dropping a live entry did not count as an eviction")
+	}
+}
+
+func TestNewPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("New(0) did not panic")
+		}
+	}()
+	New[int, int](0)
+}
+
+// Run with -race: without WithLocking this test would report data races.
+func TestConcurrentUse(t *testing.T) {
+	const capacity = 64
+	c := New[int, int](capacity, WithLocking())
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 2000 {
+				k := (g*31 + i) % 200
+				switch i % 5 {
+				case 0:
+					c.Remove(k)
+				case 1:
+					c.Peek(k)
+				case 2:
+					c.Keys()
+				default:
+					if v, ok := c.Get(k); ok && v != k*k {
+						t.Errorf("Get(%d) = %d, want %d", k, v, k*k)
+						return
+					}
+					c.Put(k, k*k)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if n := c.Len(); n > capacity {
+		t.Errorf("Len() = %d, more than the capacity %d", n, capacity)
+	}
+	if n := len(c.Keys()); n != c.Len() {
+		t.Errorf("Keys has %d entries but Len() = %d", n, c.Len())
+	}
+}
+
+// syncMapCache is the simplest concurrent cache: a sync.Map with no bound
+// on its size. It is what the LRU is measured against.
+type syncMapCache struct{ m sync.Map }
+
+func (s *syncMapCache) Get(k int) (int, bool) {
+	v, ok := s.m.Load(k)
+	if !ok {
+		return 0, false
+	}
+	return v.(int), true
+}
+
+func (s *syncMapCache) Put(k, v int) bool {
+	s.m.Store(k, v)
+	return false
+}
+
+type cache interface {
+	Get(int) (int, bool)
+	Put(int, int) bool
+}
+
+func BenchmarkCache(b *testing.B) {
+	const keys = 1 << 12
+	for _, bc := range []struct {
+		name string
+		new  func() cache
+	}{
+		{"lru", func() cache { return New[int, int](keys/2, WithLocking()) }},
+		{"sync.Map", func() cache { return &syncMapCache{} }},
+	} {
+		for _, readPct := range []int{50, 90} {
+			b.Run(fmt.Sprintf("%s/reads=%d%%", bc.name, readPct), func(b *testing.B) {
+				c := bc.new()
+				b.RunParallel(func(pb *testing.PB) {
+					i := 0
+					for pb.Next() {
+						// Knuth's multiplicative hash scatters the keys; in
+						// uint32 it wraps the same way on every platform.
+						k := int(uint32(i) * 2654435761 % keys)
+						if i%100 < readPct {
+							c.Get(k)
+						} else {
+							c.Put(k, i)
+						}
+						i++
+					}
+				})
+			})
+		}
+	}
+}
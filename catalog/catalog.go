@@ -0,0 +1,168 @@
+// Package catalog finds every exercise in the repository's source.
+//
+// It parses each chapter package with go/doc and picks up the functions
+// named exerciseN together with their doc comments, which follow this
+// structure:
+//
+//	// Exercise 2: Define a string variable called message ...
+//	// (more description)
+//	//
+//	// Tags: strings, runes
+//	func exercise2() {
+//
+// The first sentence after "Exercise N:" becomes the title, the whole
+// comment the description, and the optional Tags line a list of tags.
+package catalog
+
+import (
+	"fmt"
+	"go/ast"
+	"go/doc"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Exercise is one catalog entry.
+type Exercise struct {
+	Chapter string `json:"chapter"`
+	// Package is the directory of the package, e.g. "chapter12/rpc".
+	Package     string   `json:"package"`
+	Number      int      `json:"number"`
+	Function    string   `json:"function"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags,omitempty"`
+	Hint        string   `json:"hint,omitempty"`
+	File        string   `json:"file"`
+}
+
+var (
+	exerciseFunc = regexp.MustCompile(`^exercise(\d+)$`)
+	exerciseDoc  = regexp.MustCompile(`(?s)^Exercise \d+(?: \([^)]*\))?:\s*(.*)$`)
+)
+
+// Scan walks every chapterN directory under root (including nested
+// packages) and collects the exercises it finds.
+func Scan(root string) ([]Exercise, error) {
+	var exercises []Exercise
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		chapter := strings.Split(filepath.ToSlash(rel), "/")[0]
+		if rel != "." && !strings.HasPrefix(chapter, "chapter") {
+			return filepath.SkipDir
+		}
+		found, err := scanDir(path, chapter, filepath.ToSlash(rel))
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		exercises = append(exercises, found...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(exercises, func(i, j int) bool {
+		a, b := exercises[i], exercises[j]
+		if a.Chapter != b.Chapter {
+			return chapterNumber(a.Chapter) < chapterNumber(b.Chapter)
+		}
+		if a.Package != b.Package {
+			return a.Package < b.Package
+		}
+		return a.Number < b.Number
+	})
+	return exercises, nil
+}
+
+func chapterNumber(chapter string) int {
+	n, _ := strconv.Atoi(strings.TrimPrefix(chapter, "chapter"))
+	return n
+}
+
+func scanDir(dir, chapter, pkgDir string) ([]Exercise, error) {
+	fset := token.NewFileSet()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []*ast.File
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	// AllDecls keeps unexported declarations, which is where exercises live.
+	pkg, err := doc.NewFromFiles(fset, files, dir, doc.AllDecls)
+	if err != nil {
+		return nil, err
+	}
+
+	var exercises []Exercise
+	for _, fn := range pkg.Funcs {
+		m := exerciseFunc.FindStringSubmatch(fn.Name)
+		if m == nil || fn.Doc == "" {
+			continue
+		}
+		number, _ := strconv.Atoi(m[1])
+		ex := Exercise{
+			Chapter:  chapter,
+			Package:  pkgDir,
+			Number:   number,
+			Function: fn.Name,
+			File:     filepath.ToSlash(fset.Position(fn.Decl.Pos()).Filename),
+		}
+		ex.Title, ex.Description, ex.Tags = parseDoc(pkg, fn.Doc)
+		exercises = append(exercises, ex)
+	}
+	return exercises, nil
+}
+
+// parseDoc splits an exercise doc comment into title, description and tags.
+func parseDoc(pkg *doc.Package, text string) (title, description string, tags []string) {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		if rest, ok := strings.CutPrefix(line, "Tags:"); ok {
+			for _, tag := range strings.Split(rest, ",") {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					tags = append(tags, tag)
+				}
+			}
+			continue
+		}
+		lines = append(lines, line)
+	}
+	body := strings.TrimSpace(strings.Join(lines, "\n"))
+	if m := exerciseDoc.FindStringSubmatch(body); m != nil {
+		body = m[1]
+	}
+	description = strings.Join(strings.Fields(body), " ")
+	title = pkg.Synopsis(description)
+	return title, description, tags
+}
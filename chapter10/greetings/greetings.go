@@ -0,0 +1,16 @@
+// Package greetings is a tiny library used to practice semantic import
+// versioning. This directory is v1 of the module. Its API is:
+//
+//	greetings.Hello(name string) string
+//
+// v2 (in the v2 subdirectory) changes that signature, which is a breaking
+// change, so it is published under the new module path
+// learning-go/chapter10/greetings/v2.
+package greetings
+
+import "fmt"
+
+// Hello returns an English greeting for name.
+func Hello(name string) string {
+	return fmt.Sprintf("Hello, %s!", name)
+}
@@ -0,0 +1,3 @@
+module learning-go/chapter10/greetings/v2
+
+go 1.23.1
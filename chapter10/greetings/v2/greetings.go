@@ -0,0 +1,30 @@
+// Package greetings is v2 of the greetings module.
+//
+// v2 breaks the v1 API: Hello now takes a language and returns an error
+// for languages it does not know. Because existing callers of v1 would fail
+// to compile, the module path gains a /v2 suffix. Importers choose a major
+// version explicitly, and both versions can be used in the same build.
+package greetings
+
+import (
+	"fmt"
+
+	"learning-go/chapter10/greetings/v2/i18n"
+)
+
+var catalog = i18n.NewCatalog("en")
+
+func init() {
+	catalog.Add("en", map[string]string{"hello": "Hello, %s!"})
+	catalog.Add("es", map[string]string{"hello": "¡Hola, %s!"})
+	catalog.Add("fa", map[string]string{"hello": "سلام، %s!"})
+}
+
+// Hello returns a greeting for name in the language identified by lang
+// (e.g. "en", "es", "fa").
+func Hello(name, lang string) (string, error) {
+	if _, ok := catalog.Lookup(lang, "hello"); !ok {
+		return "", fmt.Errorf("greetings: unsupported language %q", lang)
+	}
+	return catalog.Sprintf(lang, "hello", name), nil
+}
@@ -0,0 +1,162 @@
+// Package i18n holds translated messages in per-language catalogs and
+// picks the user's language from the environment.
+//
+// Messages are fmt format strings looked up by key. A key missing in the
+// requested language falls back to the catalog's fallback language, and a
+// key missing there too is returned as is, so an incomplete translation
+// degrades to English text instead of an error.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Catalog maps language and key to a format string. It is safe for
+// concurrent use.
+type Catalog struct {
+	fallback string
+
+	mu       sync.RWMutex
+	messages map[string]map[string]string
+}
+
+// NewCatalog returns an empty catalog that falls back to the fallback
+// language, e.g. "en".
+func NewCatalog(fallback string) *Catalog {
+	return &Catalog{fallback: fallback, messages: make(map[string]map[string]string)}
+}
+
+// Add adds messages (key to format string) for lang, replacing existing
+// keys.
+func (c *Catalog) Add(lang string, messages map[string]string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	m := c.messages[lang]
+	if m == nil {
+		m = make(map[string]string, len(messages))
+		c.messages[lang] = m
+	}
+	for k, v := range messages {
+		m[k] = v
+	}
+}
+
+// Languages returns the languages with at least one message, sorted.
+func (c *Catalog) Languages() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	langs := make([]string, 0, len(c.messages))
+	for lang := range c.messages {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// Has reports whether the catalog has any messages for lang.
+func (c *Catalog) Has(lang string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.messages[lang]) > 0
+}
+
+// Keys returns the keys lang has messages for, sorted, without the
+// fallback language's. Comparing the keys of two languages shows what is
+// left to translate.
+func (c *Catalog) Keys(lang string) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	keys := make([]string, 0, len(c.messages[lang]))
+	for k := range c.messages[lang] {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Lookup returns the format string for key in lang, without falling back.
+func (c *Catalog) Lookup(lang, key string) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	format, ok := c.messages[lang][key]
+	return format, ok
+}
+
+// Sprintf formats the message for key in lang with args, falling back to
+// the fallback language and then to the key itself.
+func (c *Catalog) Sprintf(lang, key string, args ...any) string {
+	format, ok := c.Lookup(lang, key)
+	if !ok {
+		if format, ok = c.Lookup(c.fallback, key); !ok {
+			format = key
+		}
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Printer formats messages in one language.
+type Printer struct {
+	catalog *Catalog
+	lang    string
+}
+
+// Printer returns a Printer for lang.
+func (c *Catalog) Printer(lang string) *Printer {
+	return &Printer{catalog: c, lang: lang}
+}
+
+// Lang returns the printer's language.
+func (p *Printer) Lang() string {
+	return p.lang
+}
+
+// Sprintf is Catalog.Sprintf in the printer's language.
+func (p *Printer) Sprintf(key string, args ...any) string {
+	return p.catalog.Sprintf(p.lang, key, args...)
+}
+
+// Normalize reduces a locale such as "fa_IR.UTF-8" or "en-US" to its
+// lowercase language code ("fa", "en"). "C" and "POSIX", which mean no
+// locale, become "".
+func Normalize(locale string) string {
+	lang, _, _ := strings.Cut(locale, ".")
+	lang, _, _ = strings.Cut(lang, "@")
+	lang, _, _ = strings.Cut(lang, "_")
+	lang, _, _ = strings.Cut(lang, "-")
+	lang = strings.ToLower(lang)
+	if lang == "c" || lang == "posix" {
+		return ""
+	}
+	return lang
+}
+
+// FromEnv returns the user's language from LC_ALL, LC_MESSAGES or LANG,
+// in that order of precedence as POSIX specifies, normalized with
+// Normalize. It returns "" if none of them names a language.
+func FromEnv() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(name); v != "" {
+			return Normalize(v)
+		}
+	}
+	return ""
+}
+
+// Choose returns the first of the candidates that the catalog has
+// messages for, or the fallback language. Pass an explicit choice (such as
+// a --lang flag) first and FromEnv() after it.
+func (c *Catalog) Choose(candidates ...string) string {
+	for _, lang := range candidates {
+		if lang = Normalize(lang); lang != "" && c.Has(lang) {
+			return lang
+		}
+	}
+	return c.fallback
+}
@@ -0,0 +1,103 @@
+package i18n
+
+import (
+	"slices"
+	"testing"
+)
+
+func catalog() *Catalog {
+	c := NewCatalog("en")
+	c.Add("en", map[string]string{"hello": "Hello, %s!", "bye": "Bye"})
+	c.Add("fa", map[string]string{"hello": "سلام %s!"})
+	return c
+}
+
+func TestSprintf(t *testing.T) {
+	c := catalog()
+	tests := []struct {
+		lang, key string
+		args      []any
+		want      string
+	}{
+		{"en", "hello", []any{"Ada"}, "Hello, Ada!"},
+		{"fa", "hello", []any{"Ada"}, "سلام Ada!"},
+		{"fa", "bye", nil, "Bye"},                    // falls back to English
+		{"de", "hello", []any{"Ada"}, "Hello, Ada!"}, // no German at all
+		{"en", "missing", nil, "missing"},            // the key itself
+	}
+	for _, tt := range tests {
+		if got := c.Sprintf(tt.lang, tt.key, tt.args...); got != tt.want {
+			t.Errorf("Sprintf(%q, %q, %v) = %q, want %q", tt.lang, tt.key, tt.args, got, tt.want)
+		}
+	}
+	if got := c.Printer("fa").Sprintf("hello", "Ada"); got != "سلام Ada!" {
+		t.Errorf("Printer(fa).Sprintf(hello) = %q", got)
+	}
+}
+
+func TestLookupDoesNotFallBack(t *testing.T) {
+	if _, ok := catalog().Lookup("fa", "bye"); ok {
+		t.Errorf("Lookup(fa, bye) found a message, want none")
+	}
+}
+
+func TestKeysAndLanguages(t *testing.T) {
+	c := catalog()
+	if got, want := c.Keys("en"), []string{"bye", "hello"}; !slices.Equal(got, want) {
+		t.Errorf("Keys(en) = %q, want %q", got, want)
+	}
+	if got := c.Keys("de"); len(got) != 0 {
+		t.Errorf("Keys(de) = %q, want none", got)
+	}
+	if got, want := c.Languages(), []string{"en", "fa"}; !slices.Equal(got, want) {
+		t.Errorf("Languages() = %q, want %q", got, want)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct{ locale, want string }{
+		{"fa_IR.UTF-8", "fa"},
+		{"en-US", "en"},
+		{"de_DE@euro", "de"},
+		{"FR", "fr"},
+		{"C", ""},
+		{"POSIX", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := Normalize(tt.locale); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.locale, got, tt.want)
+		}
+	}
+}
+
+func TestChoose(t *testing.T) {
+	c := catalog()
+	tests := []struct {
+		candidates []string
+		want       string
+	}{
+		{[]string{"fa", "en"}, "fa"},
+		{[]string{"de_DE.UTF-8", "fa_IR.UTF-8"}, "fa"},
+		{[]string{"", "de"}, "en"},
+		{nil, "en"},
+	}
+	for _, tt := range tests {
+		if got := c.Choose(tt.candidates...); got != tt.want {
+			t.Errorf("Choose(%q) = %q, want %q", tt.candidates, got, tt.want)
+		}
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "fa_IR.UTF-8")
+	t.Setenv("LANG", "en_US.UTF-8")
+	if got := FromEnv(); got != "fa" {
+		t.Errorf("FromEnv() with LC_MESSAGES=fa_IR.UTF-8 and LANG=en_US.UTF-8 = %q, want fa", got)
+	}
+	t.Setenv("LC_ALL", "de_DE")
+	if got := FromEnv(); got != "de" {
+		t.Errorf("FromEnv() with LC_ALL=de_DE = %q, want de", got)
+	}
+}
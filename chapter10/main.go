@@ -0,0 +1,41 @@
+package chapter10
+
+import (
+	"fmt"
+	"io"
+
+	"learning-go/chapter10/greetings"
+	greetingsv2 "learning-go/chapter10/greetings/v2"
+	"learning-go/registry"
+)
+
+func init() {
+	// Register each exercise with the runner (cmd/learn)
+	registry.Register("chapter10", "exercise1", exercise1)
+}
+
+// Exercise 1: Use two major versions of the same module in one program.
+// chapter10/greetings is v1 and chapter10/greetings/v2 is v2 with a
+// breaking change to Hello. The root go.mod requires both versions and
+// points them at the local directories with replace directives.
+func exercise1(w io.Writer) {
+	// v1: Hello(name) string
+	fmt.Fprintln(w, "v1:", greetings.Hello("Gopher"))
+
+	// v2: Hello(name, lang) (string, error)
+	for _, lang := range []string{"en", "es", "fa", "de"} {
+		msg, err := greetingsv2.Hello("Gopher", lang)
+		if err != nil {
+			fmt.Fprintln(w, "v2 error:", err)
+			continue
+		}
+		fmt.Fprintf(w, "v2 (%s): %s\n", lang, msg)
+	}
+
+	// Explanation:
+	// Both packages are named greetings, so v2 is imported under the alias
+	// greetingsv2. Go treats learning-go/chapter10/greetings and
+	// learning-go/chapter10/greetings/v2 as two different modules, which is
+	// why they can coexist: code still written against v1 keeps compiling
+	// while new code moves to v2 at its own pace.
+}
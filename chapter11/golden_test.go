@@ -0,0 +1,11 @@
+package chapter11
+
+import (
+	"testing"
+
+	"learning-go/testutil/goldentest"
+)
+
+func TestGolden(t *testing.T) {
+	goldentest.Run(t)
+}
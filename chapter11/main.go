@@ -0,0 +1,88 @@
+package chapter11
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"learning-go/chapter11/sysinfo"
+	"learning-go/registry"
+)
+
+func init() {
+	// Register each exercise with the runner (cmd/learn)
+	registry.Register("chapter11", "exercise1", exercise1)
+	registry.Register("chapter11", "exercise2", exercise2)
+}
+
+// Exercise 1: Use go generate to produce String, Parse and JSON methods
+// for an enum instead of writing them by hand, then check that every value
+// survives a round trip through its string and JSON forms.
+func exercise1(w io.Writer) {
+	// String() comes from the generated code, so fmt prints names
+	fmt.Fprintln(w, "Today is", Wednesday)
+
+	// Every constant must come back unchanged from Parse and from JSON
+	for day := Sunday; day <= Saturday; day++ {
+		parsed, err := ParseWeekday(day.String())
+		if err != nil || parsed != day {
+			fmt.Fprintln(w, "Parse round trip failed for", day, err)
+			continue
+		}
+
+		data, err := json.Marshal(day)
+		if err != nil {
+			fmt.Fprintln(w, "Marshal failed for", day, err)
+			continue
+		}
+		var decoded Weekday
+		if err := json.Unmarshal(data, &decoded); err != nil || decoded != day {
+			fmt.Fprintln(w, "JSON round trip failed for", day, err)
+			continue
+		}
+		fmt.Fprintf(w, "%-9s -> %s -> %v\n", day, data, decoded)
+	}
+
+	// Values without a constant and unknown names are handled too
+	fmt.Fprintln(w, "Unknown value:", Weekday(42))
+	if _, err := ParseWeekday("Funday"); err != nil {
+		fmt.Fprintln(w, "Parse error:", err)
+	}
+
+	// Explanation:
+	// The //go:generate comment in weekday.go runs cmd/enumgen, which
+	// type-checks this package, finds every Weekday constant and writes
+	// weekday_enum.go. Generated files are committed so the package builds
+	// without running go generate, but they must never be edited by hand:
+	// the "DO NOT EDIT" header tells tools and reviewers to regenerate instead.
+}
+
+// Exercise 2: Print the system uptime and the per-user config directory.
+// Both are computed differently on Linux, macOS and Windows, so the
+// sysinfo package provides one implementation per OS behind build
+// constraints, all satisfying the same Info interface.
+func exercise2(w io.Writer) {
+	info := sysinfo.New()
+	fmt.Fprintln(w, "Compiled for:", info.OS())
+
+	if uptime, err := info.Uptime(); err != nil {
+		fmt.Fprintln(w, "Uptime unavailable:", err)
+	} else {
+		fmt.Fprintln(w, "Uptime:", uptime.Round(time.Second))
+	}
+
+	if dir, err := info.ConfigDir(); err != nil {
+		fmt.Fprintln(w, "Config dir unavailable:", err)
+	} else {
+		fmt.Fprintln(w, "Config dir:", dir)
+	}
+
+	// Explanation:
+	// Only one of sysinfo_linux.go, sysinfo_darwin.go, sysinfo_windows.go
+	// and sysinfo_other.go is compiled into the binary. The _GOOS file name
+	// suffix works like a build constraint, and sysinfo_other.go uses an
+	// explicit //go:build line to cover everything else. Because all of
+	// them satisfy sysinfo.Info, this code never needs to know which one
+	// it got.
+}
@@ -0,0 +1,31 @@
+// Package sysinfo reports a few facts whose implementation differs per
+// operating system. The shared API lives in this file; each platform
+// provides newPlatform in its own file:
+//
+//   - sysinfo_linux.go, sysinfo_darwin.go and sysinfo_windows.go are picked
+//     by their file name suffix: a file named *_GOOS.go is only compiled
+//     when building for that GOOS, with no build tag needed.
+//   - sysinfo_other.go has no such suffix, so it uses an explicit
+//     //go:build line to cover every remaining system.
+//
+// Try `GOOS=windows go build ./chapter11/...` to compile the Windows
+// variant from any machine.
+package sysinfo
+
+import "time"
+
+// Info is the contract every platform implementation satisfies.
+type Info interface {
+	// OS names the implementation that was compiled in.
+	OS() string
+	// Uptime returns how long the system has been running.
+	Uptime() (time.Duration, error)
+	// ConfigDir returns where the platform conventionally keeps
+	// per-user configuration files.
+	ConfigDir() (string, error)
+}
+
+// New returns the Info implementation for the current platform.
+func New() Info {
+	return newPlatform()
+}
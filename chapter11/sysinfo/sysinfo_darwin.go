@@ -0,0 +1,50 @@
+package sysinfo
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+type darwinInfo struct{}
+
+func newPlatform() Info {
+	return darwinInfo{}
+}
+
+func (darwinInfo) OS() string {
+	return "darwin"
+}
+
+var bootSec = regexp.MustCompile(`sec = (\d+)`)
+
+// Uptime asks sysctl for the boot time, printed as
+// "{ sec = 1700000000, usec = 0 } ...", and subtracts it from now.
+func (darwinInfo) Uptime() (time.Duration, error) {
+	out, err := exec.Command("sysctl", "-n", "kern.boottime").Output()
+	if err != nil {
+		return 0, err
+	}
+	m := bootSec.FindSubmatch(out)
+	if m == nil {
+		return 0, fmt.Errorf("unexpected kern.boottime output %q", out)
+	}
+	secs, err := strconv.ParseInt(string(m[1]), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return time.Since(time.Unix(secs, 0)), nil
+}
+
+// ConfigDir returns ~/Library/Application Support, the macOS convention.
+func (darwinInfo) ConfigDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, "Library", "Application Support"), nil
+}
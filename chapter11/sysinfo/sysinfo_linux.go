@@ -0,0 +1,49 @@
+package sysinfo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type linuxInfo struct{}
+
+func newPlatform() Info {
+	return linuxInfo{}
+}
+
+func (linuxInfo) OS() string {
+	return "linux"
+}
+
+// Uptime reads /proc/uptime, whose first field is the uptime in seconds.
+func (linuxInfo) Uptime() (time.Duration, error) {
+	data, err := os.ReadFile("/proc/uptime")
+	if err != nil {
+		return 0, err
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("unexpected /proc/uptime contents %q", data)
+	}
+	secs, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, fmt.Errorf("parsing /proc/uptime: %w", err)
+	}
+	return time.Duration(secs * float64(time.Second)), nil
+}
+
+// ConfigDir follows the XDG convention: $XDG_CONFIG_HOME, or ~/.config.
+func (linuxInfo) ConfigDir() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config"), nil
+}
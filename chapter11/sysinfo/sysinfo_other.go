@@ -0,0 +1,35 @@
+//go:build !linux && !darwin && !windows
+
+package sysinfo
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+)
+
+type otherInfo struct{}
+
+func newPlatform() Info {
+	return otherInfo{}
+}
+
+func (otherInfo) OS() string {
+	return runtime.GOOS
+}
+
+// Uptime is not implemented for the remaining systems.
+func (otherInfo) Uptime() (time.Duration, error) {
+	return 0, errors.New("uptime is not supported on " + runtime.GOOS)
+}
+
+// ConfigDir falls back to ~/.config, which most other Unix systems use.
+func (otherInfo) ConfigDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config"), nil
+}
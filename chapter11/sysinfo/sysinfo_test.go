@@ -0,0 +1,53 @@
+package sysinfo
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// TestContract checks what every implementation promises, so it passes
+// whichever file the build picked.
+func TestContract(t *testing.T) {
+	info := New()
+	if got := info.OS(); got != runtime.GOOS {
+		t.Errorf("OS() = %q, want %q", got, runtime.GOOS)
+	}
+
+	up, err := info.Uptime()
+	switch {
+	case err != nil && isMainPlatform():
+		t.Errorf("Uptime() failed on %s: %v", runtime.GOOS, err)
+	case err == nil && up <= 0:
+		t.Errorf("Uptime() = %v, want a positive duration", up)
+	}
+
+	dir, err := info.ConfigDir()
+	if err != nil {
+		t.Skipf("ConfigDir: %v", err)
+	}
+	if !filepath.IsAbs(dir) {
+		t.Errorf("ConfigDir() = %q, want an absolute path", dir)
+	}
+}
+
+func TestConfigDirXDG(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("XDG_CONFIG_HOME is only honoured on linux")
+	}
+	want := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", want)
+	if got, err := New().ConfigDir(); err != nil || got != want {
+		t.Errorf("ConfigDir() = %q, %v; want %q", got, err, want)
+	}
+}
+
+// isMainPlatform reports whether the build has a real Uptime; elsewhere it
+// may return an error.
+func isMainPlatform() bool {
+	switch runtime.GOOS {
+	case "linux", "darwin", "windows":
+		return true
+	}
+	return false
+}
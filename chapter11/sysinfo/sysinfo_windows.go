@@ -0,0 +1,38 @@
+package sysinfo
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"time"
+)
+
+type windowsInfo struct{}
+
+func newPlatform() Info {
+	return windowsInfo{}
+}
+
+func (windowsInfo) OS() string {
+	return "windows"
+}
+
+var getTickCount64 = syscall.NewLazyDLL("kernel32.dll").NewProc("GetTickCount64")
+
+// Uptime calls GetTickCount64, which returns milliseconds since boot.
+func (windowsInfo) Uptime() (time.Duration, error) {
+	if err := getTickCount64.Find(); err != nil {
+		return 0, err
+	}
+	ms, _, _ := getTickCount64.Call()
+	return time.Duration(ms) * time.Millisecond, nil
+}
+
+// ConfigDir returns %AppData%, the roaming application data folder.
+func (windowsInfo) ConfigDir() (string, error) {
+	dir := os.Getenv("AppData")
+	if dir == "" {
+		return "", errors.New("%AppData% is not set")
+	}
+	return dir, nil
+}
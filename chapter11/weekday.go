@@ -0,0 +1,19 @@
+package chapter11
+
+// Weekday is an enum. Its String, ParseWeekday, MarshalJSON and
+// UnmarshalJSON methods are not written by hand: they live in
+// weekday_enum.go, which is produced by cmd/enumgen. After adding or
+// renaming a constant, run `go generate ./chapter11` to refresh it.
+//
+//go:generate go run learning-go/cmd/enumgen -type=Weekday
+type Weekday int
+
+const (
+	Sunday Weekday = iota
+	Monday
+	Tuesday
+	Wednesday
+	Thursday
+	Friday
+	Saturday
+)
@@ -0,0 +1,69 @@
+// Code generated by enumgen -type=Weekday; DO NOT EDIT.
+
+package chapter11
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// String returns the name of the Weekday constant.
+func (x Weekday) String() string {
+	switch x {
+	case Sunday:
+		return "Sunday"
+	case Monday:
+		return "Monday"
+	case Tuesday:
+		return "Tuesday"
+	case Wednesday:
+		return "Wednesday"
+	case Thursday:
+		return "Thursday"
+	case Friday:
+		return "Friday"
+	case Saturday:
+		return "Saturday"
+	}
+	return fmt.Sprintf("Weekday(%d)", int64(x))
+}
+
+// ParseWeekday returns the Weekday constant with the given name.
+func ParseWeekday(s string) (Weekday, error) {
+	switch s {
+	case "Sunday":
+		return Sunday, nil
+	case "Monday":
+		return Monday, nil
+	case "Tuesday":
+		return Tuesday, nil
+	case "Wednesday":
+		return Wednesday, nil
+	case "Thursday":
+		return Thursday, nil
+	case "Friday":
+		return Friday, nil
+	case "Saturday":
+		return Saturday, nil
+	}
+	return 0, fmt.Errorf("invalid Weekday %q", s)
+}
+
+// MarshalJSON encodes a Weekday as its name.
+func (x Weekday) MarshalJSON() ([]byte, error) {
+	return json.Marshal(x.String())
+}
+
+// UnmarshalJSON decodes a Weekday from its name.
+func (x *Weekday) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("Weekday should be a string, got %s", data)
+	}
+	v, err := ParseWeekday(s)
+	if err != nil {
+		return err
+	}
+	*x = v
+	return nil
+}
@@ -0,0 +1,47 @@
+package chapter11
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestWeekdayRoundTrip(t *testing.T) {
+	for d := Sunday; d <= Saturday; d++ {
+		parsed, err := ParseWeekday(d.String())
+		if err != nil || parsed != d {
+			t.Errorf("ParseWeekday(%q) = %v, %v; want %v", d.String(), parsed, err, d)
+		}
+
+		data, err := json.Marshal(d)
+		if err != nil {
+			t.Fatalf("Marshal(%v): %v", d, err)
+		}
+		if want := `"` + d.String() + `"`; string(data) != want {
+			t.Errorf("Marshal(%v) = %s, want %s", d, data, want)
+		}
+		var decoded Weekday
+		if err := json.Unmarshal(data, &decoded); err != nil || decoded != d {
+			t.Errorf("Unmarshal(%s) = %v, %v; want %v", data, decoded, err, d)
+		}
+	}
+}
+
+func TestWeekdayInvalid(t *testing.T) {
+	if got := Weekday(9).String(); got != "Weekday(9)" {
+		t.Errorf("Weekday(9).String() = %q", got)
+	}
+	if _, err := ParseWeekday("Funday"); err == nil {
+		t.Error("ParseWeekday(Funday) succeeded")
+	}
+	var d Weekday
+	for _, data := range []string{`"Funday"`, `3`} {
+		if err := json.Unmarshal([]byte(data), &d); err == nil {
+			t.Errorf("Unmarshal(%s) succeeded with %v", data, d)
+		}
+	}
+	// A struct field decodes through the same method.
+	var v struct{ Day Weekday }
+	if err := json.Unmarshal([]byte(`{"Day":"Friday"}`), &v); err != nil || v.Day != Friday {
+		t.Errorf("decoding a struct field = %v, %v", v.Day, err)
+	}
+}
@@ -0,0 +1,131 @@
+// Package aggregate fetches from several sources at once and merges what
+// they return. It is written twice, to compare: FetchAll uses
+// golang.org/x/sync/errgroup, and FetchAllWaitGroup does the same job by
+// hand with a sync.WaitGroup, a semaphore channel and a results channel.
+//
+//	results, err := aggregate.FetchAll(ctx, sources, 3)
+//	// results holds every source that finished, even if err != nil
+//
+// Both run at most limit fetches at a time, and both cancel the context of
+// the fetches still running as soon as one fails. Sources that succeeded
+// before that keep their results: a page can still show the parts that
+// loaded.
+package aggregate
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Source is one place to fetch a T from. Fetch must return promptly, with
+// ctx.Err(), when ctx is cancelled.
+type Source[T any] struct {
+	Name  string
+	Fetch func(ctx context.Context) (T, error)
+}
+
+// FetchAll runs the sources' fetches concurrently, at most limit at a time
+// (no limit if limit < 1). It returns the results of the sources that
+// succeeded, by name, and the first error, after which the remaining
+// fetches are cancelled and those not started yet never start.
+func FetchAll[T any](ctx context.Context, sources []Source[T], limit int) (map[string]T, error) {
+	g, ctx := errgroup.WithContext(ctx)
+	if limit >= 1 {
+		g.SetLimit(limit)
+	}
+	var mu sync.Mutex
+	results := make(map[string]T)
+	for _, src := range sources {
+		// Go blocks while limit fetches are running.
+		g.Go(func() error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			v, err := src.Fetch(ctx)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			results[src.Name] = v
+			mu.Unlock()
+			return nil
+		})
+	}
+	err := g.Wait()
+	return results, err
+}
+
+// result is what a FetchAllWaitGroup goroutine reports.
+type result[T any] struct {
+	name  string
+	value T
+}
+
+// FetchAllWaitGroup is FetchAll without errgroup, to show what errgroup
+// does: the WaitGroup waits, the semaphore channel limits, a sync.Once
+// keeps the first error and cancels the others, and the results come
+// back on a channel to the one goroutine that writes the map.
+func FetchAllWaitGroup[T any](ctx context.Context, sources []Source[T], limit int) (map[string]T, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if limit < 1 {
+		limit = max(len(sources), 1)
+	}
+	sem := make(chan struct{}, limit)
+	// Buffered for every source, so no goroutine ever waits to report.
+	out := make(chan result[T], len(sources))
+
+	var (
+		wg    sync.WaitGroup
+		once  sync.Once
+		first error
+	)
+	// fail records the first error. It must also be what cancels: if the
+	// failing goroutine cancelled first and recorded after, a fetch that
+	// saw the cancellation could record context.Canceled in its place.
+	fail := func(err error) {
+		once.Do(func() {
+			first = err
+			cancel()
+		})
+	}
+loop:
+	for _, src := range sources {
+		// Take a slot before starting the goroutine, as errgroup's Go
+		// does, so there are never more than limit goroutines.
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			fail(ctx.Err())
+			break loop
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := ctx.Err(); err != nil {
+				fail(err)
+				return
+			}
+			v, err := src.Fetch(ctx)
+			if err != nil {
+				fail(err)
+				return
+			}
+			out <- result[T]{src.Name, v}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	results := make(map[string]T)
+	for r := range out {
+		results[r.name] = r.value
+	}
+	// Every goroutine has returned, so reading first needs no lock.
+	return results, first
+}
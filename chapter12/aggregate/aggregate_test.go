@@ -0,0 +1,203 @@
+package aggregate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// impls are the two versions, which every test runs against.
+var impls = []struct {
+	name     string
+	fetchAll func(context.Context, []Source[int], int) (map[string]int, error)
+}{
+	{"errgroup", FetchAll[int]},
+	{"WaitGroup", FetchAllWaitGroup[int]},
+}
+
+// value returns a source that succeeds with v at once.
+func value(name string, v int) Source[int] {
+	return Source[int]{name, func(context.Context) (int, error) { return v, nil }}
+}
+
+// blocked returns a source that signals started, waits for its context
+// to be cancelled, and records that on canceled.
+func blocked(name string, started, canceled chan<- string) Source[int] {
+	return Source[int]{name, func(ctx context.Context) (int, error) {
+		started <- name
+		<-ctx.Done()
+		canceled <- name
+		return 0, ctx.Err()
+	}}
+}
+
+func TestAllSucceed(t *testing.T) {
+	for _, impl := range impls {
+		t.Run(impl.name, func(t *testing.T) {
+			var sources []Source[int]
+			want := make(map[string]int)
+			for i := range 10 {
+				name := fmt.Sprint("s", i)
+				sources = append(sources, value(name, i*i))
+				want[name] = i * i
+			}
+			got, err := impl.fetchAll(context.Background(), sources, 3)
+			if err != nil || !maps.Equal(got, want) {
+				t.Errorf("got %v, %v; want %v, nil", got, err, want)
+			}
+
+			got, err = impl.fetchAll(context.Background(), nil, 3)
+			if err != nil || len(got) != 0 {
+				t.Errorf("no sources: got %v, %v", got, err)
+			}
+		})
+	}
+}
+
+func TestLimit(t *testing.T) {
+	for _, impl := range impls {
+		for _, limit := range []int{1, 3} {
+			t.Run(fmt.Sprintf("%s/limit=%d", impl.name, limit), func(t *testing.T) {
+				var running, most atomic.Int32
+				src := func(ctx context.Context) (int, error) {
+					n := running.Add(1)
+					defer running.Add(-1)
+					for {
+						m := most.Load()
+						if n <= m || most.CompareAndSwap(m, n) {
+							break
+						}
+					}
+					time.Sleep(time.Millisecond)
+					return 0, nil
+				}
+				sources := make([]Source[int], 12)
+				for i := range sources {
+					sources[i] = Source[int]{fmt.Sprint(i), src}
+				}
+				if _, err := impl.fetchAll(context.Background(), sources, limit); err != nil {
+					t.Fatal(err)
+				}
+				if m := most.Load(); m > int32(limit) {
+					t.Errorf("%d fetches ran at once, limit %d", m, limit)
+				}
+			})
+		}
+	}
+}
+
+// With no limit every source must run at once: each one waits for all of
+// them to have started, which would never happen under a limit.
+func TestNoLimit(t *testing.T) {
+	for _, impl := range impls {
+		t.Run(impl.name, func(t *testing.T) {
+			const n = 8
+			var started atomic.Int32
+			all := make(chan struct{})
+			sources := make([]Source[int], n)
+			for i := range sources {
+				sources[i] = Source[int]{fmt.Sprint(i), func(ctx context.Context) (int, error) {
+					if started.Add(1) == n {
+						close(all)
+					}
+					select {
+					case <-all:
+						return 1, nil
+					case <-time.After(5 * time.Second):
+						return 0, errors.New("not every source was started")
+					}
+				}}
+			}
+			if _, err := impl.fetchAll(context.Background(), sources, 0); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestFirstErrorCancelsTheRest(t *testing.T) {
+	boom := errors.New("source down")
+	for _, impl := range impls {
+		t.Run(impl.name, func(t *testing.T) {
+			started, canceled := make(chan string, 2), make(chan string, 2)
+			okDone := make(chan struct{})
+			sources := []Source[int]{
+				{"ok", func(context.Context) (int, error) {
+					defer close(okDone)
+					return 42, nil
+				}},
+				blocked("slow1", started, canceled),
+				blocked("slow2", started, canceled),
+				// Fails only once ok has finished and both slow sources
+				// are running, so ok's result is certain to be kept and
+				// the slow ones certain to be cancelled rather than
+				// skipped.
+				{"bad", func(context.Context) (int, error) {
+					<-okDone
+					<-started
+					<-started
+					return 0, boom
+				}},
+			}
+			got, err := impl.fetchAll(context.Background(), sources, 0)
+			if !errors.Is(err, boom) {
+				t.Errorf("err = %v, want %v and not the cancellation it caused", err, boom)
+			}
+			if want := map[string]int{"ok": 42}; !maps.Equal(got, want) {
+				t.Errorf("partial results = %v, want %v", got, want)
+			}
+			if len(canceled) != 2 {
+				t.Errorf("%d of the 2 blocked fetches were cancelled", len(canceled))
+			}
+		})
+	}
+}
+
+// Under a limit of one, the sources after a failed one are never started.
+func TestNothingStartsAfterAnError(t *testing.T) {
+	boom := errors.New("first one fails")
+	for _, impl := range impls {
+		t.Run(impl.name, func(t *testing.T) {
+			var started atomic.Int32
+			sources := []Source[int]{{"bad", func(context.Context) (int, error) { return 0, boom }}}
+			for i := range 5 {
+				sources = append(sources, Source[int]{fmt.Sprint(i), func(context.Context) (int, error) {
+					started.Add(1)
+					return 1, nil
+				}})
+			}
+			_, err := impl.fetchAll(context.Background(), sources, 1)
+			if !errors.Is(err, boom) {
+				t.Errorf("err = %v, want %v", err, boom)
+			}
+			if n := started.Load(); n != 0 {
+				t.Errorf("%d fetches started after the error", n)
+			}
+		})
+	}
+}
+
+func TestParentCancelled(t *testing.T) {
+	for _, impl := range impls {
+		t.Run(impl.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			var started atomic.Int32
+			src := Source[int]{"s", func(context.Context) (int, error) {
+				started.Add(1)
+				return 1, nil
+			}}
+			got, err := impl.fetchAll(ctx, []Source[int]{src, src, src}, 2)
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("err = %v, want context.Canceled", err)
+			}
+			if len(got) != 0 || started.Load() != 0 {
+				t.Errorf("a cancelled context still ran %d fetches: %v", started.Load(), got)
+			}
+		})
+	}
+}
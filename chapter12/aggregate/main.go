@@ -0,0 +1,206 @@
+package aggregate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"learning-go/clock"
+	"learning-go/registry"
+	"learning-go/testutil/leak"
+)
+
+func init() {
+	// Register each exercise with the runner (cmd/learn)
+	registry.Register("chapter12/aggregate", "exercise1", exercise1)
+	registry.Register("chapter12/aggregate", "exercise2", exercise2)
+}
+
+// fetchFunc is FetchAll or FetchAllWaitGroup.
+type fetchFunc func(ctx context.Context, sources []Source[int], limit int) (map[string]int, error)
+
+// tracker records how each simulated fetch ended and how many ran at once.
+type tracker struct {
+	mu         sync.Mutex
+	ended      map[string]string
+	running    int
+	maxRunning int
+}
+
+// source returns a Source that takes d on clock c and then returns items,
+// or fails with fail if it is not nil.
+func (t *tracker) source(c clock.Clock, name string, d time.Duration, items int, fail error) Source[int] {
+	return Source[int]{Name: name, Fetch: func(ctx context.Context) (int, error) {
+		t.mu.Lock()
+		t.running++
+		t.maxRunning = max(t.maxRunning, t.running)
+		t.mu.Unlock()
+		ended, err := "done", error(nil)
+		select {
+		case <-c.After(d):
+			if fail != nil {
+				ended, err = "failed", fail
+			}
+		case <-ctx.Done():
+			ended, err = "cancelled", ctx.Err()
+		}
+		t.mu.Lock()
+		t.running--
+		t.ended[name] = ended
+		t.mu.Unlock()
+		if err != nil {
+			return 0, err
+		}
+		return items, nil
+	}}
+}
+
+// scenario is a set of sources and a script that moves the fake clock
+// while they run, one step at a time.
+type scenario struct {
+	name    string
+	limit   int
+	sources func(t *tracker, c clock.Clock) []Source[int]
+	// drive moves c; cancel cancels the caller's context.
+	drive func(c *clock.Fake, cancel context.CancelFunc)
+}
+
+var errUnavailable = errors.New("search: index unavailable")
+
+var scenarios = []scenario{
+	{
+		name:  "four sources of one second each, two at a time",
+		limit: 2,
+		sources: func(t *tracker, c clock.Clock) []Source[int] {
+			return []Source[int]{
+				t.source(c, "cache", time.Second, 12, nil),
+				t.source(c, "db", time.Second, 40, nil),
+				t.source(c, "search", time.Second, 7, nil),
+				t.source(c, "ads", time.Second, 3, nil),
+			}
+		},
+		drive: func(c *clock.Fake, cancel context.CancelFunc) {
+			c.BlockUntil(2) // cache and db
+			c.Advance(time.Second)
+			c.BlockUntil(2) // search and ads, in the freed slots
+			c.Advance(time.Second)
+		},
+	},
+	{
+		name:  "search fails after 2s, three at a time",
+		limit: 3,
+		sources: func(t *tracker, c clock.Clock) []Source[int] {
+			return []Source[int]{
+				t.source(c, "cache", 1*time.Second, 12, nil),
+				t.source(c, "db", 3*time.Second, 40, nil),
+				t.source(c, "search", 2*time.Second, 0, errUnavailable),
+				t.source(c, "ads", 5*time.Second, 3, nil),
+				t.source(c, "recs", 4*time.Second, 9, nil),
+			}
+		},
+		drive: func(c *clock.Fake, cancel context.CancelFunc) {
+			c.BlockUntil(3) // cache, db and search
+			c.Advance(time.Second)
+			c.BlockUntil(3) // db, search and ads, which took cache's slot
+			c.Advance(time.Second)
+		},
+	},
+	{
+		name:  "the caller gives up after 1.5s, one at a time",
+		limit: 1,
+		sources: func(t *tracker, c clock.Clock) []Source[int] {
+			return []Source[int]{
+				t.source(c, "cache", time.Second, 12, nil),
+				t.source(c, "db", time.Second, 40, nil),
+				t.source(c, "search", time.Second, 7, nil),
+			}
+		},
+		drive: func(c *clock.Fake, cancel context.CancelFunc) {
+			c.BlockUntil(1) // cache
+			c.Advance(time.Second)
+			c.BlockUntil(1) // db
+			c.Advance(time.Second / 2)
+			cancel()
+		},
+	},
+}
+
+// run runs every scenario with fetch and prints what happened to each
+// source.
+func run(w io.Writer, fetch fetchFunc) {
+	for _, sc := range scenarios {
+		c := clock.NewFake(time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC))
+		t := &tracker{ended: make(map[string]string)}
+		sources := sc.sources(t, c)
+		ctx, cancel := context.WithCancel(context.Background())
+
+		type outcome struct {
+			results map[string]int
+			err     error
+		}
+		done := make(chan outcome)
+		go func() {
+			results, err := fetch(ctx, sources, sc.limit)
+			done <- outcome{results, err}
+		}()
+		sc.drive(c, cancel)
+		out := <-done
+		cancel()
+
+		fmt.Fprintf(w, "%s:\n  results %v\n  error %v\n", sc.name, out.results, out.err)
+		for _, src := range sources {
+			ended, ok := t.ended[src.Name]
+			if !ok {
+				ended = "never started"
+			}
+			fmt.Fprintf(w, "  %-7s %s\n", src.Name, ended)
+		}
+		fmt.Fprintf(w, "  at most %d running at once\n", t.maxRunning)
+	}
+}
+
+// Exercise 1: Fetch from simulated sources with FetchAll, which uses an
+// errgroup.Group with SetLimit: once when they all succeed, once when one
+// fails and the others must be cancelled, and once when the caller cancels.
+// The sources run on a fake clock, so every run tells the same story.
+func exercise1(w io.Writer) {
+	run(w, FetchAll[int])
+
+	// Explanation:
+	// errgroup.WithContext returns a Group and a context that is cancelled
+	// the first time a function passed to Go returns an error; Wait waits
+	// for all of them and returns that first error. SetLimit caps how many
+	// run at once, by making Go itself wait for a free slot, so the loop
+	// that starts them is held back rather than piling up goroutines. When
+	// search fails, db and ads see ctx.Done and stop early, and recs, which
+	// was waiting for a slot, never begins: the function checks ctx before
+	// fetching. cache's result, from before the failure, is kept in a map
+	// behind a mutex, because the functions run on different goroutines,
+	// and returned with the error. The caller's own cancellation arrives
+	// through the same context.
+}
+
+// Exercise 2: Run the same scenarios with FetchAllWaitGroup, the version
+// built by hand from a sync.WaitGroup, a semaphore channel, a sync.Once
+// and a results channel, and check that no goroutine outlives the calls.
+func exercise2(w io.Writer) {
+	snap := leak.Take()
+	run(w, FetchAllWaitGroup[int])
+	fmt.Fprintln(w, "goroutines left:", len(snap.Leaked(leak.Timeout)))
+
+	// Explanation:
+	// The output matches exercise 1 line for line, and it takes more than
+	// twice the code to get there. Each piece stands in for part of
+	// errgroup: a buffered channel used as a semaphore replaces SetLimit,
+	// the WaitGroup replaces Wait, and a sync.Once keeps the first error
+	// and cancels the context in one step. Getting that step wrong is easy:
+	// if the failing goroutine cancelled before recording its error, a
+	// cancelled fetch could get its context.Canceled recorded first, and
+	// the real cause would be lost. Results come back on a channel with
+	// room for every source, so the map is written by one goroutine and
+	// needs no mutex, and a goroutine closes the channel once the
+	// WaitGroup is done, which ends the range loop.
+}
@@ -1,36 +1,796 @@
-package main
+package chapter12
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"learning-go/cache/lru"
+	"learning-go/clock"
+	"learning-go/concurrency/pipeline"
+	"learning-go/concurrency/pubsub"
+	"learning-go/concurrency/ratelimit"
+	"learning-go/config"
+	"learning-go/eventbus"
+	"learning-go/memo"
+	"learning-go/registry"
+	"learning-go/testutil/leak"
 )
 
-func putDataOnChannel(ch *chan int, value int) {
-	defer close(*ch)
-	*ch <- value
+func init() {
+	// Register each exercise with the runner (cmd/learn)
+	registry.Register("chapter12", "exercise1", exercise1)
+	registry.Register("chapter12", "exercise2", exercise2)
+	registry.Register("chapter12", "exercise3", exercise3)
+	registry.Register("chapter12", "exercise4", exercise4)
+	registry.Register("chapter12", "exercise5", exercise5)
+	registry.Register("chapter12", "exercise6", exercise6)
+	registry.Register("chapter12", "exercise7", exercise7)
+	registry.Register("chapter12", "exercise8", exercise8)
+	registry.Register("chapter12", "exercise9", exercise9)
+
+	// The pipeline and the broker build on goroutines that hand values
+	// over channels, and on directional channel types.
+	registry.Requires("chapter12", "exercise4", "chapter12/exercise1", "chapter12/exercise2")
+	registry.Requires("chapter12", "exercise7", "chapter12/exercise2")
+	registry.Requires("chapter12", "exercise8", "chapter12/exercise7")
+}
+
+// putDataOnChannel sends value on ch and then closes it. The parameter is
+// a send-only channel (chan<- int), so this function can send and close,
+// but the compiler stops it from ever receiving. A plain chan int converts
+// to chan<- int automatically, so there is no need to pass a *chan int.
+func putDataOnChannel(ch chan<- int, value int) {
+	defer close(ch)
+	ch <- value
 }
 
-func main() {
-	ch1 := make(chan int)
-	ch2 := make(chan int)
-	ch3 := make(chan int)
+// produce starts a goroutine that sends value and returns the channel as
+// receive-only (<-chan int). Callers can only read from it; sending and
+// closing stay the producer's job.
+func produce(value int) <-chan int {
+	ch := make(chan int)
+	go putDataOnChannel(ch, value)
+	return ch
+}
 
-	go putDataOnChannel(&ch1, 1)
-	go putDataOnChannel(&ch2, 2)
-	go putDataOnChannel(&ch3, 3)
+// sum receives every value from ch until it is closed.
+func sum(ch <-chan int) int {
+	total := 0
+	for v := range ch {
+		total += v
+	}
+	return total
+}
 
+// merge forwards every value from chans to a single channel and closes it
+// once all of them are closed. A WaitGroup counts the forwarding
+// goroutines, and one more goroutine waits for them and then closes the
+// output, so it is closed exactly once, after the last send.
+func merge(chans ...<-chan int) <-chan int {
+	out := make(chan int)
+	var wg sync.WaitGroup
+	for _, ch := range chans {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := range ch {
+				out <- v
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// selectWithDefault is this exercise's first version: it receives until
+// no channel has a value ready at that instant. It is kept to show the
+// leak it causes.
+func selectWithDefault(ch1, ch2, ch3 <-chan int) []int {
+	var got []int
 	for {
 		select {
-		case data := <-ch1:
-			fmt.Println(data)
-		case data := <-ch2:
-			fmt.Println(data)
-		case data := <-ch3:
-			fmt.Println(data)
+		case v := <-ch1:
+			got = append(got, v)
+		case v := <-ch2:
+			got = append(got, v)
+		case v := <-ch3:
+			got = append(got, v)
 		default:
-			return
+			return got
+		}
+	}
+}
+
+// Exercise 1: Read one value from each of three producer goroutines. First
+// use a select loop with a default case, and show with package
+// testutil/leak that it can give up before every goroutine has sent,
+// leaving the rest blocked forever. Then merge the channels with a
+// sync.WaitGroup and range over the result, and check nothing leaks.
+func exercise1(w io.Writer) {
+	snap := leak.Take()
+	ch1, ch2, ch3 := produce(1), produce(2), produce(3)
+	got := selectWithDefault(ch1, ch2, ch3)
+	blocked := snap.Leaked(50 * time.Millisecond)
+	fmt.Fprintln(w, "select with default:")
+	fmt.Fprintln(w, "  returned before all 3 values arrived:", len(got) < 3)
+	fmt.Fprintln(w, "  one producer left blocked per missing value:", len(blocked) == 3-len(got))
+	if len(blocked) > 0 {
+		fn, _, _ := strings.Cut(blocked[0].Top(), " (")
+		fmt.Fprintf(w, "  blocked in: [%s] %s\n", blocked[0].State, fn)
+	}
+	// Release them, so the leak is confined to this demonstration.
+	for _, ch := range []<-chan int{ch1, ch2, ch3} {
+		for range ch {
 		}
+	}
+
+	snap = leak.Take()
+	var values []int
+	for v := range merge(produce(1), produce(2), produce(3)) {
+		values = append(values, v)
+	}
+	slices.Sort(values)
+	fmt.Fprintln(w, "merge with a WaitGroup:")
+	fmt.Fprintln(w, "  received (sorted):", values)
+	fmt.Fprintln(w, "  leaked goroutines:", snap.Check())
+
+	// Explanation:
+	// The goroutines started by produce need a moment before their
+	// values are ready, and a default case runs whenever nothing is
+	// ready right now, so the first loop usually returns at once. Each
+	// producer then blocks forever on its unbuffered send; the garbage
+	// collector cannot free a blocked goroutine, so every call leaks.
+	// The leak package finds them by comparing goroutine IDs before and
+	// after. The fix is to let the channels, not the timing, say when
+	// the work is done: merge forwards every channel to one output, the
+	// WaitGroup learns when all forwarders are finished, and only then is
+	// the output closed, which ends the range loop. Each channel is still
+	// closed by its sender, the only side that knows no more values will
+	// come.
+}
+
+// Exercise 2: Show the mistakes that directional channel types turn into
+// compile errors, and the working code next to them.
+func exercise2(w io.Writer) {
+	// A receive-only channel returned by a producer can be read...
+	values := produce(42)
+	fmt.Fprintln(w, "received:", sum(values))
+
+	// ...but not written to or closed. Uncommenting these lines fails to
+	// compile:
+	//
+	//	values <- 1   // invalid operation: cannot send to receive-only channel <-chan int values
+	//	close(values) // invalid operation: cannot close receive-only channel values
+
+	// A send-only channel can be written to and closed...
+	results := make(chan int, 1)
+	var out chan<- int = results
+	out <- 7
+	close(out)
+	fmt.Fprintln(w, "sent and read back:", <-results)
+
+	// ...but not read from:
+	//
+	//	v := <-out // invalid operation: cannot receive from send-only channel chan<- int out
+
+	// And a directional channel never converts back to a bidirectional one:
+	//
+	//	var both chan int = values // cannot use values (variable of type <-chan int) as chan int value in variable declaration
+
+	// Explanation:
+	// Passing *chan int, as this chapter's first version did, gives the
+	// callee full control of the channel and adds a pointer for no reason:
+	// channels are already reference types. Directional types document who
+	// owns a channel: the producer holds chan<- and is responsible for
+	// closing it, consumers hold <-chan and can only receive. The compiler
+	// enforces the contract, so misuse is caught before the program runs.
+}
+
+// waitFor returns the next value from ch, or false after a second.
+func waitFor[T any](ch <-chan T) (T, bool) {
+	select {
+	case v := <-ch:
+		return v, true
+	case <-time.After(time.Second):
+		var zero T
+		return zero, false
+	}
+}
+
+// Exercise 3: Hot-reload a configuration file. Watch it with
+// config.Watch, keep several goroutines reading the active settings the
+// whole time, then rewrite the file, break it, and replace it by rename.
+// Print what each reload announced on the event bus and show that readers
+// only ever saw complete configurations.
+func exercise3(w io.Writer) {
+	dir, err := os.MkdirTemp("", "hot-reload-")
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config.json")
+	write := func(content string) {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			fmt.Fprintln(w, err)
+		}
+	}
+	write(`{"user": "ada", "timeout": "5s"}`)
+
+	snap := leak.Take()
+	bus := eventbus.New()
+	changes := make(chan config.Changed, 1)
+	failures := make(chan config.ReloadFailed, 1)
+	eventbus.Subscribe(bus, func(e config.Changed) { changes <- e })
+	eventbus.Subscribe(bus, func(e config.ReloadFailed) { failures <- e })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watcher, err := config.Watch(ctx, path, bus)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	fmt.Fprintf(w, "loaded:   user=%s timeout=%v\n", watcher.Current().User, time.Duration(watcher.Current().Timeout))
+
+	// Readers check that user and timeout always belong together: each
+	// version of the file below changes both, so a mix would mean a reader
+	// saw a half-applied update.
+	valid := map[string]config.Duration{
+		"ada":   config.Duration(5 * time.Second),
+		"grace": config.Duration(30 * time.Second),
+		"linus": config.Duration(time.Minute),
+	}
+	var torn atomic.Int64
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				c := watcher.Current()
+				if valid[c.User] != c.Timeout {
+					torn.Add(1)
+				}
+			}
+		}()
+	}
+
+	write(`{"user": "grace", "timeout": "30s"}`)
+	if e, ok := waitFor(changes); ok {
+		fmt.Fprintf(w, "changed:  user %s -> %s, timeout %v -> %v\n",
+			e.Old.User, e.New.User, time.Duration(e.Old.Timeout), time.Duration(e.New.Timeout))
+	} else {
+		fmt.Fprintln(w, "no reload after rewriting the file")
+	}
+
+	write(`{"user": "linus", "timeout": }`)
+	if e, ok := waitFor(failures); ok {
+		fmt.Fprintf(w, "rejected: %v\n", e.Err)
+		fmt.Fprintf(w, "          still user=%s timeout=%v\n", watcher.Current().User, time.Duration(watcher.Current().Timeout))
+	} else {
+		fmt.Fprintln(w, "no error reported for the broken file")
+	}
+
+	// Write the next version beside the file and rename it over the old
+	// one, the way editors save.
+	tmp := filepath.Join(dir, "config.json.tmp")
+	os.WriteFile(tmp, []byte(`{"user": "linus", "timeout": "1m"}`), 0o600)
+	os.Rename(tmp, path)
+	if e, ok := waitFor(changes); ok {
+		fmt.Fprintf(w, "renamed:  user %s -> %s, timeout %v -> %v\n",
+			e.Old.User, e.New.User, time.Duration(e.Old.Timeout), time.Duration(e.New.Timeout))
+	} else {
+		fmt.Fprintln(w, "no reload after replacing the file")
+	}
+
+	close(stop)
+	wg.Wait()
+	cancel()
+	<-watcher.Done()
+	fmt.Fprintf(w, "reads that saw a mixed configuration: %d\n", torn.Load())
+	fmt.Fprintln(w, "goroutines left after stopping the watcher:", len(snap.Leaked(leak.Timeout)))
+
+	// Explanation:
+	// The watcher never modifies a Config. Each reload parses the file
+	// into a brand new value and publishes it with a single
+	// atomic.Pointer.Swap, so readers call Current without any lock and
+	// always get one complete version, old or new, never a mix. Invalid
+	// files are rejected before the swap, which keeps the last good
+	// settings active. Subscribers learn about changes from the event
+	// bus instead of polling, and the watcher does not need to know who
+	// they are. Run it with "go run -race" to see that there are no data
+	// races either.
+}
+
+// Exercise 4: Square the numbers 1 to 100 with a pipeline from package
+// concurrency/pipeline (a generator, four parallel workers and a fan-in)
+// and check that no value is lost. Then stop reading after three results,
+// cancel the context, and check that every goroutine of the pipeline exits.
+func exercise4(w io.Writer) {
+	snap := leak.Take()
+	numbers := make([]int, 100)
+	for i := range numbers {
+		numbers[i] = i + 1
+	}
+	square := func(n int) int { return n * n }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	count, total := 0, 0
+	for s := range pipeline.Stage(ctx, pipeline.Generate(ctx, numbers...), 4, square) {
+		count++
+		total += s
+	}
+	cancel()
+	fmt.Fprintf(w, "received %d squares, sum %d (want 100, 338350)\n", count, total)
+	fmt.Fprintf(w, "goroutines left after the pipeline finished: %d\n", len(snap.Leaked(leak.Timeout)))
+
+	ctx, cancel = context.WithCancel(context.Background())
+	results := pipeline.Stage(ctx, pipeline.Generate(ctx, numbers...), 4, square)
+	for range 3 {
+		<-results
+	}
+	fmt.Fprintf(w, "stopped reading after 3 results with %d goroutines still running\n",
+		len(snap.Leaked(0)))
+	cancel()
+	fmt.Fprintf(w, "goroutines left after cancel: %d\n", len(snap.Leaked(leak.Timeout)))
+
+	// Explanation:
+	// Like merge in exercise 1, the consumer ranges until the channel is
+	// closed, and each stage closes its output only after its goroutines
+	// are done sending, so completion is signalled by the close, not
+	// guessed from timing. Stopping early
+	// is the other half: every send in the pipeline is a select that also
+	// waits on ctx.Done(), so after cancel the generator, the workers and
+	// the fan-in forwarders all return instead of blocking forever on a
+	// send nobody will receive.
+}
+
+// Exercise 5: Hammer one lru.Cache made with WithLocking from eight
+// goroutines mixing Get, Put, Peek and Remove, then check that no Get saw
+// a wrong value and that the cache never held more than its capacity. Run
+// it under "go run ./cmd/stress -race -runs 50 chapter12" to let the race
+// detector watch many interleavings.
+func exercise5(w io.Writer) {
+	const (
+		goroutines = 8
+		ops        = 20_000
+		keys       = 500
+		capacity   = 100
+	)
+	c := lru.New[int, int](capacity, lru.WithLocking())
+
+	var wrong, hits, overfull atomic.Int64
+	var wg sync.WaitGroup
+	for g := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range ops {
+				k := (i*7 + g*13) % keys
+				switch i % 10 {
+				case 0, 1, 2:
+					c.Put(k, k*k)
+				case 3:
+					c.Remove(k)
+				case 4:
+					if v, ok := c.Peek(k); ok && v != k*k {
+						wrong.Add(1)
+					}
+				default:
+					if v, ok := c.Get(k); ok {
+						hits.Add(1)
+						if v != k*k {
+							wrong.Add(1)
+						}
+					}
+				}
+				if c.Len() > capacity {
+					overfull.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	fmt.Fprintf(w, "%d goroutines x %d operations on %d keys, capacity %d\n", goroutines, ops, keys, capacity)
+	fmt.Fprintln(w, "some Gets hit:", hits.Load() > 0)
+	fmt.Fprintln(w, "values that did not match their key:", wrong.Load())
+	fmt.Fprintln(w, "times Len exceeded the capacity:", overfull.Load())
+	listed := 0
+	for _, k := range c.Keys() {
+		if v, ok := c.Peek(k); ok && v == k*k {
+			listed++
+		}
+	}
+	fmt.Fprintln(w, "every key in the recency list is in the map:", listed == c.Len())
+
+	// Explanation:
+	// Get is a read for the caller but a write for the cache, because it
+	// moves the entry to the front of the recency list, so it takes the
+	// write lock like Put; only Peek, Contains and Len can share the read
+	// lock. Without WithLocking two goroutines could relink the same list
+	// nodes at once and corrupt it; the race detector reports that on the
+	// first run even when the output happens to look right. The counts
+	// printed here are the invariants, and a stress run that repeats the
+	// exercise many times checks they hold under every schedule it tries.
+}
+
+// Exercise 6: Drive a token bucket and a leaky bucket, both allowing one
+// event per second, with a fake clock. Show the token bucket's burst after
+// a quiet spell, the leaky bucket's even spacing, a Wait that is woken by
+// advancing the clock, and a full queue.
+func exercise6(w io.Writer) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(start)
+	tb := ratelimit.NewTokenBucket(time.Second, 3, ratelimit.WithClock(fake))
+	lb := ratelimit.NewLeakyBucket(time.Second, 2, ratelimit.WithClock(fake))
+
+	// allowed calls Allow five times in a row and returns the pattern.
+	allowed := func(l ratelimit.Limiter) string {
+		var b strings.Builder
+		for range 5 {
+			if l.Allow() {
+				b.WriteByte('+')
+			} else {
+				b.WriteByte('.')
+			}
+		}
+		return b.String()
+	}
+	for _, step := range []time.Duration{0, time.Second, 500 * time.Millisecond, 10 * time.Second} {
+		fake.Advance(step)
+		fmt.Fprintf(w, "t=%-5s token bucket %s  leaky bucket %s\n",
+			fake.Since(start), allowed(tb), allowed(lb))
+	}
+
+	// Both buckets are empty now. A Wait blocks until the clock moves.
+	done := make(chan time.Duration)
+	go func() {
+		tb.Wait(context.Background())
+		done <- fake.Since(start)
+	}()
+	fake.BlockUntil(1)
+	fake.Advance(time.Second)
+	fmt.Fprintln(w, "token bucket Wait returned at t =", <-done)
+
+	// Take the leaky bucket's current slot, so the next callers must queue.
+	// Two fit in its queue; a third is turned away.
+	fmt.Fprintln(w, "leaky bucket Allow at t =", fake.Since(start), lb.Allow())
+	results := make(chan string, 3)
+	for range 2 {
+		go func() {
+			lb.Wait(context.Background())
+			results <- fake.Since(start).String()
+		}()
+	}
+	for lb.Waiting() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	fmt.Fprintln(w, "third leaky bucket Wait:", lb.Wait(context.Background()))
+	waited := []string{}
+	for range 2 {
+		fake.BlockUntil(1)
+		fake.Advance(time.Second)
+		waited = append(waited, <-results)
+	}
+	fmt.Fprintln(w, "queued callers went at t =", strings.Join(waited, " and "))
+
+	// Explanation:
+	// The token bucket starts full, so three events go through at once,
+	// then one per second as tokens come back; after ten quiet seconds it
+	// holds three tokens again, not ten, because the bucket's size caps the
+	// burst. The leaky bucket never lets two events through in the same
+	// second, whatever happened before, which smooths the load on whatever
+	// is behind it at the cost of making callers wait. Waiting callers get
+	// evenly spaced slots, and a full queue fails fast with ErrQueueFull
+	// rather than piling up goroutines. Neither limiter reads the time
+	// package directly, so a fake clock makes every line here the same on
+	// every run.
+}
+
+// Exercise 7: Publish to a pubsub.Broker whose subscriber does not read,
+// first with the Drop policy and then with Block. Show which messages a
+// slow subscriber gets under each, that cancelling releases a blocked
+// publisher, and that Close ends every subscriber's range loop.
+func exercise7(w io.Writer) {
+	snap := leak.Take()
+
+	drop := pubsub.New[int](pubsub.WithBuffer(2))
+	msgs, cancel := drop.Subscribe("ticks")
+	for i := 1; i <= 5; i++ {
+		drop.Publish("ticks", i)
+	}
+	cancel()
+	fmt.Fprintln(w, "drop: received", slices.Collect(chanValues(msgs)), "dropped", drop.Dropped())
+
+	block := pubsub.New[int](pubsub.WithBuffer(2), pubsub.WithPolicy(pubsub.Block))
+	msgs, cancel = block.Subscribe("ticks")
+	published := make(chan int)
+	go func() {
+		n := 0
+		for i := 1; i <= 6; i++ {
+			if block.Publish("ticks", i) == nil {
+				n++
+			}
+		}
+		published <- n
+	}()
+	got := []int{<-msgs, <-msgs}
+	// Wait for 3 and 4 to fill the buffer again. The publisher is then
+	// stuck on 5, and cancelling lets it go without anyone reading.
+	for len(msgs) < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	fmt.Fprintln(w, "block: read", got, "then cancelled; Publish calls that returned nil:", <-published)
+	fmt.Fprintln(w, "block: left in the buffer after cancel:", slices.Collect(chanValues(msgs)), "dropped", block.Dropped())
+
+	// Close ends every subscription on every topic.
+	news := pubsub.New[string](pubsub.WithBuffer(4))
+	var wg sync.WaitGroup
+	lines := make([]string, 3)
+	for i, topic := range []string{"go", "go", "rust"} {
+		ch, _ := news.Subscribe(topic)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var got []string
+			for msg := range ch {
+				got = append(got, msg)
+			}
+			lines[i] = fmt.Sprintf("subscriber %d (%s) got %q", i+1, topic, got)
+		}()
+	}
+	news.Publish("go", "generics")
+	news.Publish("rust", "traits")
+	news.Publish("go", "iterators")
+	news.Publish("zig", "comptime") // nobody listens
+	news.Close()
+	wg.Wait()
+	for _, line := range lines {
+		fmt.Fprintln(w, line)
+	}
+	fmt.Fprintln(w, "Publish after Close:", news.Publish("go", "late"))
+	fmt.Fprintln(w, "goroutines left:", len(snap.Leaked(leak.Timeout)))
+
+	// Explanation:
+	// Each subscriber is a buffered channel, so the broker has to decide
+	// what to do when a buffer is full. Drop keeps the publisher fast and
+	// loses messages for the slow subscriber only: it got 1 and 2, which
+	// filled its buffer, and 3 to 5 were counted as dropped. Block loses
+	// nothing but ties the publisher to its slowest subscriber; cancelling
+	// closes the subscriber's done channel, which a blocked Publish also
+	// selects on, so it returns instead of waiting forever. Messages 5 and
+	// 6 were never delivered, and that is not an error: the subscriber had
+	// left. Cancel and Close close channels under the write lock, while
+	// Publish sends under the read lock, so a send can never hit a closed
+	// channel, and what is still buffered is delivered before the range
+	// loop ends.
+}
+
+// chanValues returns an iterator over the values received from ch until it
+// is closed.
+func chanValues[T any](ch <-chan T) func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		for v := range ch {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Exercise 8: Hammer one broker from many publishers and subscribers at
+// once, with subscribers joining and cancelling as it runs, and check the
+// invariants. Run it with "go run ./cmd/stress -race -runs 50 chapter12"
+// to check them under many schedules with the race detector on.
+func exercise8(w io.Writer) {
+	const (
+		publishers  = 4
+		subscribers = 6
+		messages    = 2_000
+	)
+	snap := leak.Take()
+	b := pubsub.New[int](pubsub.WithBuffer(8), pubsub.WithPolicy(pubsub.Block))
+
+	// Long-lived subscribers, registered before anything is published,
+	// must see every message, and each publisher's messages in order.
+	var wrongOrder, received atomic.Int64
+	var subs sync.WaitGroup
+	for range subscribers {
+		ch, _ := b.Subscribe("load")
+		subs.Add(1)
+		go func() {
+			defer subs.Done()
+			last := make([]int, publishers)
+			for msg := range ch {
+				if msg < 0 {
+					continue // see below
+				}
+				p, seq := msg%publishers, msg/publishers
+				if seq < last[p] {
+					wrongOrder.Add(1)
+				}
+				last[p] = seq
+				received.Add(1)
+			}
+		}()
+	}
+
+	// Churners subscribe, read a little and cancel without draining,
+	// which must never block the publishers.
+	stop := make(chan struct{})
+	var churn sync.WaitGroup
+	for range 2 {
+		churn.Add(1)
+		go func() {
+			defer churn.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				ch, cancel := b.Subscribe("load")
+				<-ch
+				cancel()
+			}
+		}()
+	}
+
+	var pubs sync.WaitGroup
+	var failed atomic.Int64
+	for p := range publishers {
+		pubs.Add(1)
+		go func() {
+			defer pubs.Done()
+			for seq := range messages {
+				if b.Publish("load", seq*publishers+p) != nil {
+					failed.Add(1)
+				}
+			}
+		}()
+	}
+	pubs.Wait()
+	close(stop)
+	// A churner may be waiting for a message that will never come; extra
+	// publishes of -1, which the long-lived subscribers skip, let it through.
+	done := make(chan struct{})
+	go func() { churn.Wait(); close(done) }()
+	for waiting := true; waiting; {
+		select {
+		case <-done:
+			waiting = false
+		case <-time.After(time.Millisecond):
+			b.Publish("load", -1)
+		}
+	}
+	b.Close()
+	subs.Wait()
+
+	fmt.Fprintf(w, "%d publishers x %d messages, %d subscribers\n", publishers, messages, subscribers)
+	fmt.Fprintln(w, "failed publishes:", failed.Load())
+	fmt.Fprintln(w, "every subscriber got every message:", received.Load() >= publishers*messages*subscribers)
+	fmt.Fprintln(w, "messages out of order:", wrongOrder.Load())
+	fmt.Fprintln(w, "subscribers left after Close:", b.Subscribers("load"))
+	fmt.Fprintln(w, "goroutines left:", len(snap.Leaked(leak.Timeout)))
+
+	// Explanation:
+	// A channel delivers in order and the broker sends to each subscriber
+	// from the publishing goroutine, so messages from one publisher reach
+	// every subscriber in the order they were published, while messages
+	// from different publishers interleave however the scheduler likes.
+	// With Block nothing is lost, so each long-lived subscriber counts
+	// every message. Churning subscribers cancel while publishers may be
+	// blocked on them; the done channel releases those publishers, and
+	// the race detector confirms the channels are never closed while a
+	// send to them is in flight.
+}
+
+// fibCalls counts the calls made to the fib functions of exercise 9.
+var fibCalls atomic.Int64
+
+// slowFib is the textbook recursive Fibonacci: fib(n) computes fib(n-2)
+// twice, fib(n-3) three times and so on, an exponential number of calls.
+func slowFib(n int) int {
+	fibCalls.Add(1)
+	if n < 2 {
+		return n
+	}
+	return slowFib(n-1) + slowFib(n-2)
+}
+
+// Exercise 9: Speed up a recursive Fibonacci with memo.Func, then share one
+// slow API lookup between a hundred goroutines with memo.Memoize, let a
+// failed lookup be retried, and compare with memo.Group, which shares a
+// call while it runs but keeps nothing afterwards.
+func exercise9(w io.Writer) {
+	fibCalls.Store(0)
+	fmt.Fprintf(w, "slowFib(30) = %d in %d calls\n", slowFib(30), fibCalls.Load())
+
+	fibCalls.Store(0)
+	// fib(90) needs 62 bits: int64, since int has only 32 on some
+	// platforms.
+	var fib func(int) int64
+	fib = memo.Func(func(n int) int64 {
+		fibCalls.Add(1)
+		if n < 2 {
+			return int64(n)
+		}
+		return fib(n-1) + fib(n-2)
+	})
+	fmt.Fprintf(w, "memoized fib(30) = %d in %d calls\n", fib(30), fibCalls.Load())
+	fmt.Fprintf(w, "memoized fib(90) = %d in %d more\n", fib(90), fibCalls.Load()-31)
+
+	// The slow API: every lookup takes a while, and counts itself.
+	var lookups atomic.Int64
+	gate := make(chan struct{})
+	profile := memo.Memoize(func(user string) (string, error) {
+		n := lookups.Add(1)
+		<-gate
+		if user == "flaky" && n == 1 {
+			return "", fmt.Errorf("profile %s: service unavailable", user)
+		}
+		return fmt.Sprintf("profile of %s (lookup %d)", user, n), nil
+	})
+	var wg sync.WaitGroup
+	results := make([]string, 100)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = profile("gopher")
+		}()
+	}
+	close(gate) // let the lookup finish
+	wg.Wait()
+	fmt.Fprintf(w, "100 goroutines asked for gopher: %d lookup(s), all got %q: %v\n",
+		lookups.Load(), results[0], !slices.ContainsFunc(results, func(r string) bool { return r != results[0] }))
+
+	lookups.Store(0)
+	for range 3 {
+		p, err := profile("flaky")
+		fmt.Fprintf(w, "profile(flaky): %q, %v\n", p, err)
+	}
+	fmt.Fprintln(w, "lookups for flaky:", lookups.Load())
 
+	var g memo.Group[string, int64]
+	for range 2 {
+		n, err, shared := g.Do("gopher", func() (int64, error) { return lookups.Add(1), nil })
+		fmt.Fprintf(w, "Group.Do(gopher) one after the other: lookup %d, %v, shared %v\n", n, err, shared)
 	}
 
-	fmt.Println("here")
+	// Explanation:
+	// Memoizing trades memory for time: each result is kept in a map, so
+	// every fib(n) is computed once and the 2.7 million calls of slowFib
+	// become 31, and fib(90), which slowFib would never finish, needs only
+	// 60 more. The memoized function must be declared before it is
+	// assigned so it can call itself. Under concurrency a cache alone is
+	// not enough: a hundred goroutines that miss it at the same moment
+	// would start a hundred lookups. memo's functions put a single-flight
+	// Group in front of fn, so the first caller runs it while the others
+	// for the same key wait and share its result; the race detector,
+	// through cmd/stress -race, checks that the sharing is safe. Errors
+	// are shared but never cached, so the flaky lookup is retried and then
+	// served from the cache. A bare Group keeps nothing, which is what
+	// data that must be fresh needs: callers that overlap share one call,
+	// and a later caller gets a new one.
 }
@@ -0,0 +1,80 @@
+package chapter12
+
+import (
+	"slices"
+	"testing"
+
+	"learning-go/testutil/leak"
+)
+
+// The helpers' directions are part of their API: these fail to compile if
+// one of them goes back to a bidirectional channel or a *chan int.
+var (
+	_ func(chan<- int, int)          = putDataOnChannel
+	_ func(int) <-chan int           = produce
+	_ func(<-chan int) int           = sum
+	_ func(...<-chan int) <-chan int = merge
+)
+
+func TestProduceAndSum(t *testing.T) {
+	leak.Verify(t)
+	if got := sum(produce(7)); got != 7 {
+		t.Errorf("sum(produce(7)) = %d, want 7", got)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	leak.Verify(t)
+	var got []int
+	for v := range merge(produce(1), produce(2), produce(3)) {
+		got = append(got, v)
+	}
+	slices.Sort(got)
+	if want := []int{1, 2, 3}; !slices.Equal(got, want) {
+		t.Errorf("merge received %v, want %v", got, want)
+	}
+
+	// With nothing to merge, the output is closed at once.
+	if _, ok := <-merge(); ok {
+		t.Error("merge() sent a value")
+	}
+}
+
+func TestSelectWithDefault(t *testing.T) {
+	// Values that are already buffered are all received; the loop only
+	// gives up when nothing is ready.
+	chans := make([]chan int, 3)
+	for i := range chans {
+		chans[i] = make(chan int, 1)
+		chans[i] <- i
+	}
+	got := selectWithDefault(chans[0], chans[1], chans[2])
+	slices.Sort(got)
+	if want := []int{0, 1, 2}; !slices.Equal(got, want) {
+		t.Errorf("selectWithDefault = %v, want %v", got, want)
+	}
+	if got := selectWithDefault(make(chan int), make(chan int), make(chan int)); len(got) != 0 {
+		t.Errorf("selectWithDefault on empty channels = %v", got)
+	}
+}
+
+func TestChanValues(t *testing.T) {
+	ch := make(chan string, 3)
+	ch <- "a"
+	ch <- "b"
+	ch <- "c"
+	close(ch)
+	var got []string
+	for v := range chanValues(ch) {
+		got = append(got, v)
+		if v == "b" {
+			break
+		}
+	}
+	if want := []string{"a", "b"}; !slices.Equal(got, want) {
+		t.Errorf("ranged over %v, want %v", got, want)
+	}
+	if v := <-ch; v != "c" {
+		t.Errorf("breaking out consumed more than it yielded: next value %q", v)
+	}
+}
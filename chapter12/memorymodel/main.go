@@ -0,0 +1,126 @@
+// Package memorymodel demonstrates the Go memory model's "happens before"
+// rule with four ways of handing a value from one goroutine to another.
+//
+// Run it with the race detector:
+//
+//	go run -race ./cmd/learn run chapter12/memorymodel
+//
+// Only exercise1 is reported as a data race. The other three establish a
+// happens-before edge between the write and the read, so the reader is
+// guaranteed to see the value.
+package memorymodel
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"learning-go/registry"
+)
+
+func init() {
+	// Register each exercise with the runner (cmd/learn)
+	registry.Register("chapter12/memorymodel", "exercise1", exercise1)
+	registry.Register("chapter12/memorymodel", "exercise2", exercise2)
+	registry.Register("chapter12/memorymodel", "exercise3", exercise3)
+	registry.Register("chapter12/memorymodel", "exercise4", exercise4)
+}
+
+// Exercise 1 (incorrect): publish a value by setting a plain bool flag.
+// The writer sets data and then ready; the reader spins until it sees
+// ready and then reads data.
+func exercise1(w io.Writer) {
+	var data int
+	var ready bool
+
+	go func() {
+		data = 42
+		ready = true // RACE: plain write, read concurrently below
+	}()
+
+	// Bound the loop so the program cannot spin forever: without
+	// synchronization nothing guarantees the reader ever sees the write.
+	seen := false
+	for i := 0; i < 1_000_000; i++ {
+		if ready { // RACE: plain read of a variable written by another goroutine
+			seen = true
+			break
+		}
+		runtime.Gosched()
+	}
+	fmt.Fprintln(w, "unsynchronized flag: ready seen:", seen, "data:", data)
+
+	// Explanation:
+	// Even when this prints 42, the program is wrong. Without a
+	// happens-before edge the compiler and CPU may reorder the two writes,
+	// so a reader can observe ready == true and still read an old data, or
+	// never observe ready at all. The race detector flags these accesses.
+}
+
+// Exercise 2: publish the value by closing a channel. A close happens
+// before any receive that returns because the channel is closed.
+func exercise2(w io.Writer) {
+	var data int
+	done := make(chan struct{})
+
+	go func() {
+		data = 42
+		close(done)
+	}()
+
+	<-done
+	fmt.Fprintln(w, "channel: data:", data)
+}
+
+// Exercise 3: publish the value under a mutex. An Unlock happens before
+// every later Lock of the same mutex, so whichever goroutine locks second
+// sees everything the first one did while holding it.
+func exercise3(w io.Writer) {
+	var mu sync.Mutex
+	var data int
+	var ready bool
+
+	go func() {
+		mu.Lock()
+		data = 42
+		ready = true
+		mu.Unlock()
+	}()
+
+	for {
+		mu.Lock()
+		if ready {
+			fmt.Fprintln(w, "mutex: data:", data)
+			mu.Unlock()
+			return
+		}
+		mu.Unlock()
+		runtime.Gosched()
+	}
+}
+
+// Exercise 4: publish the value with an atomic flag. An atomic store that
+// is observed by an atomic load creates the same happens-before edge, so
+// the plain write to data before Store is visible after Load.
+func exercise4(w io.Writer) {
+	var data int
+	var ready atomic.Bool
+
+	go func() {
+		data = 42
+		ready.Store(true)
+	}()
+
+	for !ready.Load() {
+		runtime.Gosched()
+	}
+	fmt.Fprintln(w, "atomic: data:", data)
+
+	// Explanation:
+	// Channels, mutexes and atomics all give the reader a guarantee, which
+	// is why the race detector stays quiet for exercises 2 to 4. Prefer
+	// channels or mutexes; atomics are easy to get subtly wrong once more
+	// than one variable is involved.
+}
@@ -0,0 +1,111 @@
+package rpc
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrInsufficientFunds is returned by a withdrawal larger than the balance.
+var ErrInsufficientFunds = errors.New("rpc: insufficient funds")
+
+// account is a bank balance. lockedAccount and actorAccount implement it
+// in the two ways shared state can be protected.
+type account interface {
+	Deposit(amount int)
+	Withdraw(amount int) error
+	Balance() int
+}
+
+// lockedAccount is shared by every goroutine that uses it, and a mutex
+// makes them take turns.
+type lockedAccount struct {
+	mu      sync.Mutex
+	balance int
+}
+
+func (a *lockedAccount) Deposit(amount int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.balance += amount
+}
+
+func (a *lockedAccount) Withdraw(amount int) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if amount > a.balance {
+		return ErrInsufficientFunds
+	}
+	a.balance -= amount
+	return nil
+}
+
+func (a *lockedAccount) Balance() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.balance
+}
+
+// The messages an actorAccount understands. A deposit needs no answer, so
+// it is sent and forgotten; the others carry a reply channel, as in
+// Request.
+type (
+	depositMsg  struct{ amount int }
+	withdrawMsg struct {
+		amount int
+		reply  chan error
+	}
+	balanceMsg struct{ reply chan int }
+)
+
+// actorAccount is an actor: the balance is a local variable of one
+// goroutine, which changes it only in response to messages on its inbox.
+// Nothing is shared, so nothing needs a lock.
+type actorAccount struct {
+	inbox chan any
+}
+
+// newActorAccount starts the account's goroutine. Close stops it.
+func newActorAccount() *actorAccount {
+	a := &actorAccount{inbox: make(chan any)}
+	go func() {
+		balance := 0
+		for msg := range a.inbox {
+			switch m := msg.(type) {
+			case depositMsg:
+				balance += m.amount
+			case withdrawMsg:
+				if m.amount > balance {
+					m.reply <- ErrInsufficientFunds
+					continue
+				}
+				balance -= m.amount
+				m.reply <- nil
+			case balanceMsg:
+				m.reply <- balance
+			}
+		}
+	}()
+	return a
+}
+
+func (a *actorAccount) Deposit(amount int) {
+	a.inbox <- depositMsg{amount}
+}
+
+func (a *actorAccount) Withdraw(amount int) error {
+	reply := make(chan error, 1)
+	a.inbox <- withdrawMsg{amount, reply}
+	return <-reply
+}
+
+func (a *actorAccount) Balance() int {
+	reply := make(chan int, 1)
+	a.inbox <- balanceMsg{reply}
+	return <-reply
+}
+
+// Close stops the account's goroutine. The account must not be used
+// afterwards.
+func (a *actorAccount) Close() {
+	close(a.inbox)
+}
@@ -0,0 +1,195 @@
+// Package rpc implements a request/response protocol on top of channels:
+// every request carries its own reply channel, so a single server
+// goroutine can answer many callers without any shared state. Exercise 3
+// puts the same idea to work in an actor, next to a mutex.
+//
+//	go run ./cmd/learn run chapter12/rpc
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"learning-go/registry"
+	"learning-go/testutil/leak"
+)
+
+func init() {
+	// Register each exercise with the runner (cmd/learn)
+	registry.Register("chapter12/rpc", "exercise1", exercise1)
+	registry.Register("chapter12/rpc", "exercise2", exercise2)
+	registry.Register("chapter12/rpc", "exercise3", exercise3)
+	registry.Requires("chapter12/rpc", "exercise3", "chapter12/rpc/exercise1")
+}
+
+// Request is sent to the server. Reply is where the server sends the
+// answer; each caller makes its own, so answers can never get mixed up.
+type Request struct {
+	Args  Args
+	Reply chan Result
+}
+
+// Args are the inputs of a call.
+type Args struct {
+	A, B  int
+	Delay time.Duration // simulated processing time
+}
+
+// Result is the server's answer.
+type Result struct {
+	Sum   int
+	Calls int // how many requests the server has handled so far
+}
+
+// serve handles requests until the requests channel is closed. The call
+// counter is only touched by this goroutine, so it needs no mutex.
+func serve(requests <-chan Request) {
+	calls := 0
+	for req := range requests {
+		calls++
+		time.Sleep(req.Args.Delay)
+		// The reply channel is buffered, so this send never blocks, even if
+		// the caller has already given up and stopped listening.
+		req.Reply <- Result{Sum: req.Args.A + req.Args.B, Calls: calls}
+	}
+}
+
+// ErrTimeout is returned when the server does not answer in time.
+var ErrTimeout = errors.New("rpc: call timed out")
+
+// call sends a request and waits for the reply or for ctx to expire.
+func call(ctx context.Context, requests chan<- Request, args Args) (Result, error) {
+	// Capacity 1 is essential: without it, a server replying after the
+	// caller timed out would block forever on the send
+	req := Request{Args: args, Reply: make(chan Result, 1)}
+
+	select {
+	case requests <- req:
+	case <-ctx.Done():
+		return Result{}, ErrTimeout
+	}
+
+	select {
+	case res := <-req.Reply:
+		return res, nil
+	case <-ctx.Done():
+		return Result{}, ErrTimeout
+	}
+}
+
+// Exercise 1: Start one server goroutine and make several concurrent calls
+// to it. Every caller must receive its own answer.
+func exercise1(w io.Writer) {
+	snap := leak.Take()
+	requests := make(chan Request)
+	go serve(requests)
+
+	var wg sync.WaitGroup
+	results := make([]Result, 5)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			res, err := call(ctx, requests, Args{A: i, B: 10})
+			if err != nil {
+				fmt.Fprintln(w, "call failed:", err)
+				return
+			}
+			results[i] = res
+		}()
+	}
+	wg.Wait()
+
+	for i, res := range results {
+		fmt.Fprintf(w, "%d + 10 = %d\n", i, res.Sum)
+	}
+	close(requests)
+	fmt.Fprintln(w, "goroutines left after closing the server:", len(snap.Leaked(leak.Timeout)))
+
+	// Explanation:
+	// The server processes requests one at a time from a single channel,
+	// yet the callers run concurrently. Because each Request carries a
+	// private reply channel, the response for "3 + 10" can only ever reach
+	// the goroutine that asked for it.
+}
+
+// Exercise 2: Make a call that takes longer than the caller is willing to
+// wait, and show that the caller times out while the server keeps working.
+func exercise2(w io.Writer) {
+	snap := leak.Take()
+	requests := make(chan Request)
+	go serve(requests)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := call(ctx, requests, Args{A: 1, B: 2, Delay: 200 * time.Millisecond})
+	fmt.Fprintln(w, "slow call:", err)
+
+	// The server is not stuck: it finished the slow request, dropped the
+	// reply into the abandoned buffered channel, and answers the next call
+	ctx2, cancel2 := context.WithTimeout(context.Background(), time.Second)
+	defer cancel2()
+	res, err := call(ctx2, requests, Args{A: 20, B: 22})
+	fmt.Fprintln(w, "next call:", res.Sum, err, "- requests handled:", res.Calls)
+	close(requests)
+	fmt.Fprintln(w, "goroutines left after closing the server:", len(snap.Leaked(leak.Timeout)))
+
+	// Explanation:
+	// Compared with an actor, which owns its state and receives plain
+	// messages, this pattern adds the reply channel so the caller can wait
+	// for an answer like a function call. The timeout lives entirely on the
+	// caller's side in a select on ctx.Done(); the buffered reply channel
+	// is what keeps the server from leaking when nobody is listening.
+}
+
+// Exercise 3: Keep a bank balance two ways: in a struct guarded by a
+// sync.Mutex, and in an actor, a goroutine that owns the balance and
+// changes it only when a message arrives. Make 1000 concurrent deposits
+// into each, then withdraw too much and a little, and compare.
+func exercise3(w io.Writer) {
+	snap := leak.Take()
+	actor := newActorAccount()
+	accounts := []struct {
+		name string
+		account
+	}{
+		{"mutex", &lockedAccount{}},
+		{"actor", actor},
+	}
+	for _, a := range accounts {
+		var wg sync.WaitGroup
+		for range 50 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 20 {
+					a.Deposit(1)
+				}
+			}()
+		}
+		wg.Wait()
+		fmt.Fprintf(w, "%s: balance after 1000 deposits: %d\n", a.name, a.Balance())
+		fmt.Fprintf(w, "%s: withdraw 1500: %v\n", a.name, a.Withdraw(1500))
+		fmt.Fprintf(w, "%s: withdraw 400: %v, balance %d\n", a.name, a.Withdraw(400), a.Balance())
+	}
+	actor.Close()
+	fmt.Fprintln(w, "goroutines left after closing the actor:", len(snap.Leaked(leak.Timeout)))
+
+	// Explanation:
+	// Both give the same answers; they differ in who may touch the
+	// balance. With the mutex every goroutine changes it, one at a time.
+	// With the actor only its own goroutine does, and the others send it
+	// messages. A deposit needs no answer, so it is a plain message;
+	// Withdraw and Balance are calls like exercise 1's, with a reply
+	// channel. The inbox is unbuffered, so once every Deposit has returned
+	// the actor has taken every deposit, and handles them before the
+	// Balance message sent after them. The actor is easier to get right
+	// when a change takes several steps, since no lock can be forgotten;
+	// the mutex is cheaper, with no goroutine to start and stop.
+}
@@ -1,2 +1 @@
-package main
-
+package chapter12
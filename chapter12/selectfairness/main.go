@@ -0,0 +1,83 @@
+// Package selectfairness measures how select chooses between cases that
+// are all ready at the same time.
+//
+//	go run ./cmd/learn run chapter12/selectfairness
+package selectfairness
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"learning-go/registry"
+)
+
+func init() {
+	// Register each exercise with the runner (cmd/learn)
+	registry.Register("chapter12/selectfairness", "exercise1", exercise1)
+	registry.Register("chapter12/selectfairness", "exercise2", exercise2)
+}
+
+// tally runs a select over the given channels rounds times and counts
+// which case was chosen. Every channel must always be ready to receive.
+func tally(rounds int, a, b, c <-chan int) [3]int {
+	var wins [3]int
+	for i := 0; i < rounds; i++ {
+		select {
+		case <-a:
+			wins[0]++
+		case <-b:
+			wins[1]++
+		case <-c:
+			wins[2]++
+		}
+	}
+	return wins
+}
+
+// printDistribution prints one bar per case, scaled to its share of wins.
+func printDistribution(w io.Writer, wins [3]int) {
+	total := wins[0] + wins[1] + wins[2]
+	for i, n := range wins {
+		share := float64(n) / float64(total)
+		fmt.Fprintf(w, "case %d: %8d wins (%5.2f%%) %s\n", i+1, n, share*100, strings.Repeat("#", int(share*60)))
+	}
+}
+
+// Exercise 1: Select from three closed channels three million times.
+// A receive from a closed channel never blocks, so all three cases are
+// ready in every round. Print how often each case wins.
+func exercise1(w io.Writer) {
+	a, b, c := make(chan int), make(chan int), make(chan int)
+	close(a)
+	close(b)
+	close(c)
+
+	fmt.Fprintln(w, "Three always-ready cases:")
+	printDistribution(w, tally(3_000_000, a, b, c))
+
+	// Explanation:
+	// Each case wins about a third of the time. When several cases are
+	// ready, select picks one uniformly at random; the order in which the
+	// cases are written gives the first one no advantage. This is what
+	// prevents one busy channel from starving the others.
+}
+
+// Exercise 2: Repeat the experiment when only two channels are ready.
+// The third channel is never written to, so its case never wins.
+func exercise2(w io.Writer) {
+	a, b := make(chan int), make(chan int)
+	close(a)
+	close(b)
+	never := make(chan int)
+
+	fmt.Fprintln(w, "Two ready cases and one that never is:")
+	printDistribution(w, tally(3_000_000, a, b, never))
+
+	// Explanation:
+	// Only ready cases take part in the random choice. chapter12's main.go
+	// relies on this behaviour: its loop selects over three channels whose
+	// senders become ready at different times, and no particular channel is
+	// guaranteed to be read first. Code must never depend on the order in
+	// which select picks among ready cases.
+}
@@ -0,0 +1,150 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"time"
+
+	"learning-go/registry"
+	"learning-go/safe"
+	"learning-go/testutil/leak"
+)
+
+func init() {
+	// Register each exercise with the runner (cmd/learn)
+	registry.Register("chapter12/workerpool", "exercise1", exercise1)
+	registry.Register("chapter12/workerpool", "exercise2", exercise2)
+	registry.Requires("chapter12/workerpool", "exercise1", "chapter12/exercise1")
+	registry.Requires("chapter12/workerpool", "exercise2", "chapter12/workerpool/exercise1")
+}
+
+// Exercise 1: Square twelve numbers on a pool of three workers, with one
+// job that panics and one that returns an error. Collect the results as
+// they arrive, then print them in submission order and show that the pool
+// kept working after the panic.
+func exercise1(w io.Writer) {
+	snap := leak.Take()
+	p := NewPool(3)
+
+	// Read results while submitting: Submit blocks when the queue is full,
+	// so collecting them afterwards would deadlock.
+	var results []Result
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for r := range p.Results() {
+			results = append(results, r)
+		}
+	}()
+
+	for n := 1; n <= 12; n++ {
+		p.Submit(func(ctx context.Context) (any, error) {
+			// Later jobs finish first, so arrival order differs from
+			// submission order.
+			time.Sleep(time.Duration(13-n) * time.Millisecond)
+			switch n {
+			case 5:
+				panic("five is unlucky")
+			case 8:
+				return nil, errors.New("eight is not allowed")
+			}
+			return n * n, nil
+		})
+	}
+	if err := p.Shutdown(context.Background()); err != nil {
+		fmt.Fprintln(w, "shutdown:", err)
+	}
+	wg.Wait()
+
+	arrival := make([]int, len(results))
+	for i, r := range results {
+		arrival[i] = r.ID
+	}
+	fmt.Fprintf(w, "%d results, arrived in submission order: %v\n", len(results), slices.IsSorted(arrival))
+
+	slices.SortFunc(results, func(a, b Result) int { return a.ID - b.ID })
+	for _, r := range results {
+		var panicErr *safe.PanicError
+		switch {
+		case errors.As(r.Err, &panicErr):
+			fmt.Fprintf(w, "job %2d: recovered %v\n", r.ID, panicErr.Value)
+		case r.Err != nil:
+			fmt.Fprintf(w, "job %2d: error %v\n", r.ID, r.Err)
+		default:
+			fmt.Fprintf(w, "job %2d: %v\n", r.ID, r.Value)
+		}
+	}
+
+	_, err := p.Submit(func(context.Context) (any, error) { return nil, nil })
+	fmt.Fprintln(w, "submit after shutdown:", err)
+	fmt.Fprintln(w, "goroutines left after shutdown:", len(snap.Leaked(leak.Timeout)))
+
+	// Explanation:
+	// The workers all read from one queue channel, so whichever is free
+	// takes the next job, and results come back in the order jobs finish.
+	// Tagging each result with the ID Submit returned is what lets the
+	// caller put them back in order. Each job runs inside safe.SafeCall, so
+	// a panic becomes that job's error instead of killing the worker (or
+	// the whole program). Shutdown closes the queue; ranging workers drain
+	// what is left and exit, and the last one to exit closes Results, which
+	// ends the collecting loop.
+}
+
+// Exercise 2: Shut a pool down while its jobs are still running, once with
+// enough time for them to finish and once with a deadline that is too
+// short, and show how the jobs see the cancellation.
+func exercise2(w io.Writer) {
+	snap := leak.Take()
+	run := func(deadline time.Duration) {
+		p := NewPool(2)
+		var mu sync.Mutex
+		var finished, canceled int
+		go func() {
+			for range p.Results() {
+			}
+		}()
+		for range 4 {
+			p.Submit(func(ctx context.Context) (any, error) {
+				select {
+				case <-time.After(20 * time.Millisecond):
+					mu.Lock()
+					finished++
+					mu.Unlock()
+					return nil, nil
+				case <-ctx.Done():
+					mu.Lock()
+					canceled++
+					mu.Unlock()
+					return nil, ctx.Err()
+				}
+			})
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), deadline)
+		defer cancel()
+		err := p.Shutdown(ctx)
+		// Give canceled jobs a moment to return before counting.
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		defer mu.Unlock()
+		fmt.Fprintf(w, "deadline %v: Shutdown returned %v, %d jobs finished, %d saw the cancellation\n",
+			deadline, err, finished, canceled)
+	}
+	run(time.Second)
+	run(5 * time.Millisecond)
+	fmt.Fprintln(w, "goroutines left after both pools:", len(snap.Leaked(leak.Timeout)))
+
+	// Explanation:
+	// With time to spare, Shutdown drains the queue: four 20ms jobs on two
+	// workers take about 40ms, and Shutdown returns nil once all are done.
+	// With a 5ms deadline, Shutdown stops waiting and cancels the context
+	// it passes to every job. The two running jobs notice it in their
+	// select and return early; the two still queued are dropped without
+	// running. A job that ignored ctx would keep its worker busy until it
+	// finished on its own, which is why long jobs should always watch it.
+}
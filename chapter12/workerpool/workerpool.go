@@ -0,0 +1,176 @@
+// Package workerpool runs jobs on a fixed number of goroutines and
+// delivers their results on a channel.
+//
+//	p := workerpool.NewPool(4)
+//	go func() {
+//		for r := range p.Results() {
+//			fmt.Println(r.ID, r.Value, r.Err)
+//		}
+//	}()
+//	p.Submit(func(ctx context.Context) (any, error) { return 42, nil })
+//	p.Shutdown(ctx)
+//
+// Results arrive in completion order, not submission order; each carries
+// the ID Submit returned, so callers can match them up. A job that panics
+// does not take its worker down: the panic is returned as the job's error
+// (a *safe.PanicError) and the worker moves on to the next job.
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"learning-go/safe"
+)
+
+// ErrClosed is returned by Submit after Shutdown has been called.
+var ErrClosed = errors.New("workerpool: pool is shut down")
+
+// Job is a unit of work. ctx is canceled if Shutdown gives up waiting, so
+// long jobs should watch it.
+type Job func(ctx context.Context) (any, error)
+
+// Result is the outcome of one job.
+type Result struct {
+	// ID is the value Submit returned for the job.
+	ID    int
+	Value any
+	Err   error
+}
+
+type task struct {
+	id  int
+	job Job
+}
+
+// Pool is a fixed set of workers. Create one with NewPool.
+type Pool struct {
+	tasks   chan task
+	results chan Result
+	done    chan struct{}
+
+	// ctx is passed to every job; cancel aborts jobs still running when
+	// Shutdown's deadline passes.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// quit is closed by Shutdown and releases Submits blocked on a full
+	// queue. tasks itself is closed only once senders, the Submits that
+	// got in before Shutdown, have all returned. mu guards closed and
+	// adding to senders, but is never held while a Submit blocks.
+	quit    chan struct{}
+	senders sync.WaitGroup
+	mu      sync.RWMutex
+	closed  bool
+	nextID  atomic.Int64
+}
+
+// NewPool starts a pool with the given number of workers (at least one).
+// Up to workers jobs can wait in the queue before Submit blocks.
+func NewPool(workers int) *Pool {
+	workers = max(workers, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &Pool{
+		tasks:   make(chan task, workers),
+		results: make(chan Result, workers),
+		done:    make(chan struct{}),
+		quit:    make(chan struct{}),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+
+	// Jobs are already recovered one by one in work, so SafeGo only
+	// guards the pool's own code: if that ever panics, the worker is
+	// logged and lost, and the rest of the program keeps running.
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		safe.SafeGo(func() {
+			defer wg.Done()
+			p.work()
+		})
+	}
+	go func() {
+		wg.Wait()
+		close(p.results)
+		cancel()
+		close(p.done)
+	}()
+	return p
+}
+
+func (p *Pool) work() {
+	for t := range p.tasks {
+		if p.ctx.Err() != nil {
+			// Shutdown gave up: drop what is left in the queue unrun.
+			continue
+		}
+		r := Result{ID: t.id}
+		r.Err = safe.SafeCall(func() error {
+			var err error
+			r.Value, err = t.job(p.ctx)
+			return err
+		})
+		select {
+		case p.results <- r:
+		case <-p.ctx.Done():
+			// Shutdown gave up; nobody is guaranteed to read any more.
+		}
+	}
+}
+
+// Submit queues job and returns the ID its Result will carry. It blocks
+// while the queue is full, so the results must be read concurrently. It
+// returns ErrClosed once Shutdown has been called, including when it was
+// still waiting for room in the queue.
+func (p *Pool) Submit(job Job) (int, error) {
+	p.mu.RLock()
+	if p.closed {
+		p.mu.RUnlock()
+		return 0, ErrClosed
+	}
+	p.senders.Add(1)
+	p.mu.RUnlock()
+	defer p.senders.Done()
+
+	id := int(p.nextID.Add(1))
+	select {
+	case p.tasks <- task{id: id, job: job}:
+		return id, nil
+	case <-p.quit:
+		return 0, ErrClosed
+	}
+}
+
+// Results returns the channel results are delivered on. It is closed after
+// Shutdown, once every queued job has finished.
+func (p *Pool) Results() <-chan Result {
+	return p.results
+}
+
+// Shutdown stops accepting jobs and waits for the queued and running ones
+// to finish, draining the queue. If ctx is done first, the jobs' context
+// is canceled, and Shutdown returns ctx.Err() without waiting further.
+// Calling Shutdown more than once is safe.
+func (p *Pool) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.quit)
+		go func() {
+			p.senders.Wait()
+			close(p.tasks)
+		}()
+	}
+	p.mu.Unlock()
+
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		p.cancel()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,183 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"learning-go/safe"
+)
+
+// collect reads p's results until the channel closes and returns them
+// through the returned function, which waits for that.
+func collect(p *Pool) func() []Result {
+	var results []Result
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for r := range p.Results() {
+			results = append(results, r)
+		}
+	}()
+	return func() []Result {
+		wg.Wait()
+		return results
+	}
+}
+
+func TestResultsMatchIDs(t *testing.T) {
+	p := NewPool(4)
+	wait := collect(p)
+
+	want := make(map[int]int)
+	for n := range 50 {
+		id, err := p.Submit(func(context.Context) (any, error) {
+			// Later jobs finish first.
+			time.Sleep(time.Duration(50-n) * 100 * time.Microsecond)
+			return n * n, nil
+		})
+		if err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+		want[id] = n * n
+	}
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	results := wait()
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(results), len(want))
+	}
+	seen := make(map[int]bool)
+	for _, r := range results {
+		if seen[r.ID] {
+			t.Errorf("result for job %d delivered twice", r.ID)
+		}
+		seen[r.ID] = true
+		if r.Err != nil || r.Value != want[r.ID] {
+			t.Errorf("job %d: got %v, %v; want %d", r.ID, r.Value, r.Err, want[r.ID])
+		}
+	}
+}
+
+func TestPanicAndErrorAreResults(t *testing.T) {
+	p := NewPool(1)
+	wait := collect(p)
+	boom := errors.New("boom")
+	p.Submit(func(context.Context) (any, error) { panic("oops") })
+	p.Submit(func(context.Context) (any, error) { return nil, boom })
+	p.Submit(func(context.Context) (any, error) { return "after", nil })
+	p.Shutdown(context.Background())
+
+	results := wait()
+	slices.SortFunc(results, func(a, b Result) int { return a.ID - b.ID })
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3: the panic must not stop the only worker", len(results))
+	}
+	var panicErr *safe.PanicError
+	if !errors.As(results[0].Err, &panicErr) || panicErr.Value != "oops" {
+		t.Errorf("panicking job: err = %v, want a *safe.PanicError for oops", results[0].Err)
+	}
+	if !errors.Is(results[1].Err, boom) {
+		t.Errorf("failing job: err = %v, want %v", results[1].Err, boom)
+	}
+	if results[2].Value != "after" {
+		t.Errorf("job after the panic: value = %v, want after", results[2].Value)
+	}
+}
+
+func TestShutdownDrainsQueue(t *testing.T) {
+	p := NewPool(2)
+	wait := collect(p)
+	release := make(chan struct{})
+	for range 4 {
+		p.Submit(func(context.Context) (any, error) {
+			<-release
+			return nil, nil
+		})
+	}
+	close(release)
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if got := len(wait()); got != 4 {
+		t.Errorf("got %d results, want all 4 queued jobs run", got)
+	}
+	if _, err := p.Submit(func(context.Context) (any, error) { return nil, nil }); !errors.Is(err, ErrClosed) {
+		t.Errorf("Submit after Shutdown = %v, want ErrClosed", err)
+	}
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Errorf("second Shutdown = %v, want nil", err)
+	}
+}
+
+func TestShutdownDeadlineCancelsJobs(t *testing.T) {
+	p := NewPool(2)
+	wait := collect(p)
+	for range 4 {
+		p.Submit(func(ctx context.Context) (any, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := p.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Shutdown = %v, want context.DeadlineExceeded", err)
+	}
+	for _, r := range wait() {
+		if !errors.Is(r.Err, context.Canceled) {
+			t.Errorf("job %d: err = %v, want context.Canceled", r.ID, r.Err)
+		}
+	}
+}
+
+// Nobody reads the results here, so the workers and then the queue fill
+// up and a further Submit blocks. Shutdown must still honor its deadline
+// and release that Submit.
+func TestShutdownWithUnreadResults(t *testing.T) {
+	p := NewPool(1)
+	// One job running, one result buffered, one job queued.
+	for range 3 {
+		if _, err := p.Submit(func(context.Context) (any, error) { return nil, nil }); err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+	}
+	blocked := make(chan error)
+	go func() {
+		_, err := p.Submit(func(context.Context) (any, error) { return nil, nil })
+		blocked <- err
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	shutdown := make(chan error)
+	go func() { shutdown <- p.Shutdown(ctx) }()
+
+	select {
+	case err := <-shutdown:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Shutdown = %v, want context.DeadlineExceeded", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Shutdown ignored its deadline")
+	}
+	select {
+	case err := <-blocked:
+		// The blocked Submit may have got in as the queue drained.
+		if err != nil && !errors.Is(err, ErrClosed) {
+			t.Errorf("blocked Submit = %v, want nil or ErrClosed", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Submit still blocked after Shutdown")
+	}
+	// Once the deadline has passed the workers stop delivering, so the
+	// results close without anyone having read them.
+	for range p.Results() {
+	}
+}
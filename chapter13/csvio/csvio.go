@@ -0,0 +1,218 @@
+// Package csvio imports and exports employees as CSV, and counts words in
+// text read line by line. It is chapter 13's tour of encoding/csv and
+// bufio: quoted fields, rows that fail validation without stopping the
+// import, a csv.Writer that must be flushed, and a bufio.Scanner whose
+// memory use does not grow with the file.
+//
+//	f, _ := os.Open("employees.csv")
+//	list, bad, err := csvio.Load(f)
+//	// list holds the valid rows, bad says what was wrong with the others
+package csvio
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"learning-go/chapter7/employees"
+	"learning-go/errs"
+)
+
+// Header is the first row Export writes. Load accepts these columns in any
+// order, ignoring case and any extra columns.
+var Header = []string{"id", "name", "salary"}
+
+// ErrMissingColumn means the header row lacks one of the Header columns.
+var ErrMissingColumn = errors.New("missing column")
+
+// RowError describes a row that Load skipped.
+type RowError struct {
+	// Line is where the row starts in the input, counting the header as 1.
+	Line int
+	// Record holds the fields as read, or nil if the row could not be
+	// parsed as CSV at all.
+	Record []string
+	Err    error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+// Unwrap lets errors.As find the *errs.ValidationError or *csv.ParseError
+// underneath.
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// Load reads employees from CSV with a header row. Rows that are malformed
+// or fail validation are returned as RowErrors while the rest are still
+// imported. The error is non-nil only if the input cannot be read or the
+// header is unusable.
+func Load(r io.Reader) ([]employees.Employee, []*RowError, error) {
+	cr := csv.NewReader(r)
+	// Check the number of fields per row here rather than in the reader,
+	// so a short row is reported like any other invalid one.
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, nil, errs.Wrap(err, "csvio: reading header")
+	}
+	col := make(map[string]int, len(header))
+	for i, name := range header {
+		col[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range Header {
+		if _, ok := col[name]; !ok {
+			return nil, nil, fmt.Errorf("csvio: header: %w %q", ErrMissingColumn, name)
+		}
+	}
+
+	var (
+		list []employees.Employee
+		bad  []*RowError
+		seen = make(map[int]int) // ID to the line it was first seen on
+	)
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			bad = append(bad, &RowError{Line: parseErr.StartLine, Err: parseErr.Err})
+			continue
+		}
+		if err != nil {
+			return list, bad, errs.Wrap(err, "csvio: reading")
+		}
+
+		line, _ := cr.FieldPos(0)
+		e, err := parse(rec, col)
+		if err == nil {
+			if first, dup := seen[e.ID]; dup {
+				err = errs.Invalid("id", "%d already used on line %d", e.ID, first)
+			}
+		}
+		if err != nil {
+			bad = append(bad, &RowError{Line: line, Record: rec, Err: err})
+			continue
+		}
+		seen[e.ID] = line
+		list = append(list, e)
+	}
+	return list, bad, nil
+}
+
+// parse validates one row and converts it to an Employee.
+func parse(rec []string, col map[string]int) (employees.Employee, error) {
+	field := func(name string) (string, bool) {
+		i := col[name]
+		if i >= len(rec) {
+			return "", false
+		}
+		return strings.TrimSpace(rec[i]), true
+	}
+
+	var e employees.Employee
+	id, ok := field("id")
+	if !ok {
+		return e, errs.Invalid("id", "column missing from row")
+	}
+	n, err := strconv.Atoi(id)
+	if err != nil || n <= 0 {
+		return e, errs.Invalid("id", "%q is not a positive whole number", id)
+	}
+	e.ID = n
+
+	if e.Name, ok = field("name"); !ok || e.Name == "" {
+		return e, errs.Invalid("name", "must not be empty")
+	}
+
+	salary, ok := field("salary")
+	if !ok {
+		return e, errs.Invalid("salary", "column missing from row")
+	}
+	if e.Salary, err = strconv.Atoi(salary); err != nil || e.Salary < 0 {
+		return e, errs.Invalid("salary", "%q is not a non-negative whole number", salary)
+	}
+	return e, nil
+}
+
+// Export writes list as CSV, starting with Header.
+func Export(w io.Writer, list []employees.Employee) error {
+	cw := csv.NewWriter(w)
+	cw.Write(Header)
+	for _, e := range list {
+		cw.Write([]string{strconv.Itoa(e.ID), e.Name, strconv.Itoa(e.Salary)})
+	}
+	// csv.Writer buffers; nothing may have reached w until Flush, and a
+	// write error is only reported by Error afterwards.
+	cw.Flush()
+	return errs.Wrap(cw.Error(), "csvio: export")
+}
+
+// WriteErrors writes bad as CSV with the columns line, error and then the
+// fields of the rejected row, so the file can be fixed and imported again.
+func WriteErrors(w io.Writer, bad []*RowError) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"line", "error", "record"})
+	for _, e := range bad {
+		row := append([]string{strconv.Itoa(e.Line), e.Err.Error()}, e.Record...)
+		cw.Write(row)
+	}
+	cw.Flush()
+	return errs.Wrap(cw.Error(), "csvio: writing errors")
+}
+
+// maxLine is the longest line CountWords accepts. The Scanner's default of
+// 64 KiB is too small for some machine-written files.
+const maxLine = 1 << 20
+
+// CountWords reads r line by line and counts each word, ignoring case and
+// punctuation. It holds one line in memory at a time, plus the counts.
+func CountWords(r io.Reader) (map[string]int, error) {
+	counts := make(map[string]int)
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), maxLine)
+	for sc.Scan() {
+		words := strings.FieldsFunc(sc.Text(), func(c rune) bool {
+			return !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '\''
+		})
+		for _, word := range words {
+			if word = strings.Trim(word, "'"); word != "" {
+				counts[strings.ToLower(word)]++
+			}
+		}
+	}
+	// Scan returns false both at the end and on an error; Err tells which.
+	return counts, errs.Wrap(sc.Err(), "csvio: counting words")
+}
+
+// WordCount is one entry of Top.
+type WordCount struct {
+	Word  string
+	Count int
+}
+
+// Top returns the n most frequent words in counts, most frequent first and
+// alphabetically among equals.
+func Top(counts map[string]int, n int) []WordCount {
+	list := make([]WordCount, 0, len(counts))
+	for word, c := range counts {
+		list = append(list, WordCount{word, c})
+	}
+	slices.SortFunc(list, func(a, b WordCount) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), strings.Compare(a.Word, b.Word))
+	})
+	return list[:min(n, len(list))]
+}
@@ -0,0 +1,152 @@
+package csvio
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"learning-go/chapter7/employees"
+	"learning-go/errs"
+)
+
+// tempFile writes content to name in a fresh temporary directory and
+// returns its path.
+func tempFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// load opens path and loads employees from it.
+func load(t *testing.T, path string) ([]employees.Employee, []*RowError) {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	list, bad, err := Load(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return list, bad
+}
+
+func TestLoadSample(t *testing.T) {
+	list, bad := load(t, tempFile(t, "employees.csv", sampleCSV))
+
+	var ids []int
+	for _, e := range list {
+		ids = append(ids, e.ID)
+	}
+	if want := []int{1, 2, 3, 9, 11, 13}; !slices.Equal(ids, want) {
+		t.Errorf("imported IDs %v, want %v", ids, want)
+	}
+	if list[2].Name != "Hopper, Grace" || list[3].Name != `Robert "Bob" Griesemer` || list[4].Name != "Frances\nAllen" {
+		t.Errorf("quoted names came out as %q, %q, %q", list[2].Name, list[3].Name, list[4].Name)
+	}
+
+	var lines []int
+	for _, e := range bad {
+		lines = append(lines, e.Line)
+	}
+	if want := []int{5, 6, 7, 8, 9, 10, 12, 15}; !slices.Equal(lines, want) {
+		t.Errorf("rejected lines %v, want %v", lines, want)
+	}
+	var invalid *errs.ValidationError
+	if !errors.As(bad[3], &invalid) || invalid.Field != "id" || !strings.Contains(invalid.Reason, "line 3") {
+		t.Errorf("duplicate ID error = %v", bad[3])
+	}
+	last := bad[len(bad)-1]
+	if last.Record != nil || !errors.Is(last, csv.ErrBareQuote) {
+		t.Errorf("bare quote error = %v (record %q); want csv.ErrBareQuote, no record", last, last.Record)
+	}
+}
+
+func TestLoadHeader(t *testing.T) {
+	list, bad := load(t, tempFile(t, "reordered.csv", "Salary, NAME ,extra,ID\n100,Ann,x,4\n"))
+	if len(bad) != 0 || !slices.Equal(list, []employees.Employee{{ID: 4, Name: "Ann", Salary: 100}}) {
+		t.Errorf("reordered header: %v, %v", list, bad)
+	}
+
+	_, _, err := Load(strings.NewReader("id,name\n1,Ann\n"))
+	if !errors.Is(err, ErrMissingColumn) {
+		t.Errorf("header without salary: err = %v, want ErrMissingColumn", err)
+	}
+	if _, _, err := Load(strings.NewReader("")); err == nil {
+		t.Error("empty input loaded without error")
+	}
+}
+
+func TestExportRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	list, bad := load(t, tempFile(t, "employees.csv", sampleCSV))
+
+	clean := filepath.Join(dir, "clean.csv")
+	if err := writeFile(clean, func(w io.Writer) error { return Export(w, list) }); err != nil {
+		t.Fatal(err)
+	}
+	again, rejected := load(t, clean)
+	if len(rejected) != 0 || !slices.Equal(again, list) {
+		t.Errorf("re-import of the export: %v rejected, %v\nwant %v", rejected, again, list)
+	}
+
+	errorsFile := filepath.Join(dir, "employees.errors.csv")
+	if err := writeFile(errorsFile, func(w io.Writer) error { return WriteErrors(w, bad) }); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(errorsFile)
+	// Rows carry the rejected record's fields, however many it had.
+	cr := csv.NewReader(strings.NewReader(string(data)))
+	cr.FieldsPerRecord = -1
+	rows, err := cr.ReadAll()
+	if err != nil {
+		t.Fatalf("errors file is not valid CSV: %v", err)
+	}
+	if len(rows) != len(bad)+1 || !slices.Equal(rows[0][:2], []string{"line", "error"}) {
+		t.Fatalf("errors file has %d rows, header %q", len(rows), rows[0])
+	}
+	// Each rejected row is written back after its line and error.
+	if got := rows[1]; got[0] != "5" || !slices.Equal(got[2:], []string{"x4", "Alan Turing", "5900"}) {
+		t.Errorf("first error row = %q", got)
+	}
+}
+
+func TestCountWords(t *testing.T) {
+	path := tempFile(t, "notes.txt", "The cat's hat.\nTHE 'cat' sat -- the end\n\n"+strings.Repeat("x", 100_000)+"\n")
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	counts, err := CountWords(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := Top(counts, 3)
+	want := []WordCount{{"the", 3}, {"cat", 1}, {"cat's", 1}}
+	if !slices.Equal(got, want) {
+		t.Errorf("Top 3 = %v, want %v", got, want)
+	}
+	if counts[strings.Repeat("x", 100_000)] != 1 {
+		t.Error("a line longer than the Scanner's default buffer was not counted")
+	}
+	if n := len(Top(counts, 100)); n != len(counts) {
+		t.Errorf("Top(100) gave %d of %d words", n, len(counts))
+	}
+}
+
+func TestCountWordsLineTooLong(t *testing.T) {
+	_, err := CountWords(strings.NewReader(strings.Repeat("x", maxLine+1)))
+	if err == nil {
+		t.Error("a line over maxLine was accepted")
+	}
+}
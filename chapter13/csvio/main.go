@@ -0,0 +1,287 @@
+package csvio
+
+import (
+	"bufio"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"learning-go/chapter7/employees"
+	"learning-go/errs"
+	"learning-go/registry"
+)
+
+func init() {
+	// Register each exercise with the runner (cmd/learn)
+	registry.Register("chapter13/csvio", "exercise1", exercise1)
+	registry.Register("chapter13/csvio", "exercise2", exercise2)
+	registry.Register("chapter13/csvio", "exercise3", exercise3)
+}
+
+// The sample files are embedded so the exercises find them from any
+// working directory; each exercise copies them to a temporary directory
+// and works on real files from there.
+var (
+	//go:embed testdata/employees.csv
+	sampleCSV string
+	//go:embed testdata/notes.txt
+	sampleText string
+)
+
+// printFile copies the file at path to w, indented.
+func printFile(w io.Writer, path string) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	for _, line := range strings.SplitAfter(strings.TrimSuffix(string(data), "\n"), "\n") {
+		fmt.Fprint(w, "  ", line)
+	}
+	fmt.Fprintln(w)
+}
+
+// Exercise 1: Import the sample employees.csv, which has quoted fields and
+// eight bad rows of different kinds. Keep the valid rows, write the
+// rejected ones with their reasons to an errors file, export the valid
+// ones to a clean file and import that again.
+func exercise1(w io.Writer) {
+	dir, err := os.MkdirTemp("", "csvio-")
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	input := filepath.Join(dir, "employees.csv")
+	if err := os.WriteFile(input, []byte(sampleCSV), 0o644); err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+
+	f, err := os.Open(input)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	list, bad, err := Load(f)
+	f.Close()
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	fmt.Fprintf(w, "imported %d employees, rejected %d rows\n", len(list), len(bad))
+	for _, e := range list {
+		fmt.Fprintf(w, "  %2d %-26q %d\n", e.ID, e.Name, e.Salary)
+	}
+
+	invalid, malformed := 0, 0
+	for _, e := range bad {
+		var ve *errs.ValidationError
+		if errors.As(e, &ve) {
+			invalid++
+		} else {
+			malformed++
+		}
+	}
+	fmt.Fprintf(w, "%d rows failed validation and %d could not be parsed as CSV\n", invalid, malformed)
+
+	errorsFile := filepath.Join(dir, "employees.errors.csv")
+	if err := writeFile(errorsFile, func(fw io.Writer) error { return WriteErrors(fw, bad) }); err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	fmt.Fprintln(w, "employees.errors.csv:")
+	printFile(w, errorsFile)
+
+	clean := filepath.Join(dir, "clean.csv")
+	if err := writeFile(clean, func(fw io.Writer) error { return Export(fw, list) }); err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	fmt.Fprintln(w, "clean.csv:")
+	printFile(w, clean)
+
+	f, err = os.Open(clean)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	defer f.Close()
+	again, bad, err := Load(f)
+	fmt.Fprintf(w, "re-imported clean.csv: same employees %v, rejected %d, error %v\n",
+		reflect.DeepEqual(again, list), len(bad), err)
+
+	// Explanation:
+	// csv.Reader handles the quoting rules: a quoted field may contain
+	// commas, doubled quotes and even newlines, which is why RowError
+	// records the line a row starts on from FieldPos rather than counting
+	// lines. A quote in the middle of an unquoted field is a
+	// *csv.ParseError, and Read can carry on with the next row after one,
+	// so bad input costs one row instead of the whole import. Number and
+	// presence checks are the program's job; they return an
+	// *errs.ValidationError, so errors.As tells a bad value from bad CSV. The
+	// errors file keeps the original fields next to the reason, ready to
+	// be corrected and imported again.
+}
+
+// writeFile creates path, lets write fill it and reports the first error,
+// including the one from Close, which is where a full disk may show up.
+func writeFile(path string, write func(io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return errs.Wrap(f.Close(), "closing %s", filepath.Base(path))
+}
+
+// Exercise 2: Import a file whose columns are in a different order, with
+// an extra column, and one that lacks a column. Export names that need
+// quoting, and show what is left in a file when a csv.Writer is never
+// flushed.
+func exercise2(w io.Writer) {
+	reordered := "Salary,Team,ID,Name\n5200,compilers,1,Ada Lovelace\n"
+	list, bad, err := Load(strings.NewReader(reordered))
+	fmt.Fprintf(w, "reordered columns: %v, rejected %d, error %v\n", list, len(bad), err)
+
+	_, _, err = Load(strings.NewReader("id,name\n1,Ada\n"))
+	fmt.Fprintln(w, "missing column:", err)
+	fmt.Fprintln(w, "  errors.Is ErrMissingColumn:", errors.Is(err, ErrMissingColumn))
+
+	tricky := []employees.Employee{
+		{ID: 1, Name: "Hopper, Grace", Salary: 6100},
+		{ID: 2, Name: `Robert "Bob" Griesemer`, Salary: 5800},
+		{ID: 3, Name: "Frances\nAllen", Salary: 6000},
+		{ID: 4, Name: " Ken", Salary: 5000},
+	}
+	var out strings.Builder
+	if err := Export(&out, tricky); err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	fmt.Fprintf(w, "exported names that need quoting:\n%s", out.String())
+	back, _, _ := Load(strings.NewReader(out.String()))
+	fmt.Fprintln(w, "  names survive a round trip:", back[0].Name == tricky[0].Name &&
+		back[1].Name == tricky[1].Name && back[2].Name == tricky[2].Name)
+	fmt.Fprintf(w, "  but the leading space does not: %q\n", back[3].Name)
+
+	dir, err := os.MkdirTemp("", "csvio-")
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "unflushed.csv")
+	f, err := os.Create(path)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	cw := csv.NewWriter(f)
+	cw.Write(Header)
+	cw.Write([]string{"1", "Ada Lovelace", "5200"})
+	f.Close() // without cw.Flush()
+	info, _ := os.Stat(path)
+	fmt.Fprintf(w, "file closed without Flush: %d bytes\n", info.Size())
+
+	// Explanation:
+	// Looking columns up by header name instead of position lets a
+	// spreadsheet reorder or add columns without breaking the import, and
+	// a missing column is caught once, before any row is read, instead of
+	// as an error on every row. csv.Writer quotes a field when it contains
+	// a comma, a quote, a newline or leading space, so any string survives
+	// being written; this Load trims fields, though, which is the cost of
+	// accepting hand-edited files. Both csv.Writer and bufio.Writer hold
+	// data in memory until Flush: close the file without it and what was
+	// written never reaches the disk, with no error to say so.
+}
+
+// Exercise 3: Write a file of several megabytes by repeating the sample
+// text, count its words line by line with a bufio.Scanner, and print the
+// most frequent ones. Then feed the Scanner a line longer than its default
+// buffer.
+func exercise3(w io.Writer) {
+	const copies = 5000
+	dir, err := os.MkdirTemp("", "csvio-")
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "notes.txt")
+	err = writeFile(path, func(fw io.Writer) error {
+		bw := bufio.NewWriter(fw)
+		for range copies {
+			bw.WriteString(sampleText)
+		}
+		return bw.Flush()
+	})
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	info, _ := os.Stat(path)
+	fmt.Fprintf(w, "wrote %d copies of the sample, %d MiB\n", copies, info.Size()>>20)
+
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	defer f.Close()
+	counts, err := CountWords(f)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	once, _ := CountWords(strings.NewReader(sampleText))
+	fmt.Fprintf(w, "%d distinct words, each counted %d times as often as in one copy: %v\n",
+		len(counts), copies, sameRatio(counts, once, copies))
+	fmt.Fprintln(w, "most frequent:")
+	for _, wc := range Top(counts, 5) {
+		fmt.Fprintf(w, "  %-8s %d\n", wc.Word, wc.Count)
+	}
+
+	long := strings.Repeat("word ", 20_000) // 100,000 bytes on one line
+	sc := bufio.NewScanner(strings.NewReader(long))
+	for sc.Scan() {
+	}
+	fmt.Fprintln(w, "default Scanner on a 100 KB line:", sc.Err())
+	fmt.Fprintln(w, "  errors.Is bufio.ErrTooLong:", errors.Is(sc.Err(), bufio.ErrTooLong))
+	counts, err = CountWords(strings.NewReader(long))
+	fmt.Fprintf(w, "CountWords with a larger buffer: %d words, error %v\n", counts["word"], err)
+
+	// Explanation:
+	// A Scanner fills a buffer from the file and hands back one line at a
+	// time, so counting words in a file of any size takes memory for the
+	// longest line and the map of counts, never for the whole file.
+	// Writing the file goes through a bufio.Writer for the same reason in
+	// reverse: thousands of small writes become a few large ones. The
+	// default buffer caps a line at 64 KiB and Scan then stops with
+	// bufio.ErrTooLong, which is easy to miss because Scan just returns
+	// false, the same as at the end of the file; always check Err, and
+	// call Buffer if lines may be longer.
+}
+
+// sameRatio reports whether every word in counts appears exactly k times as
+// often as in base.
+func sameRatio(counts, base map[string]int, k int) bool {
+	if len(counts) != len(base) {
+		return false
+	}
+	for word, n := range base {
+		if counts[word] != k*n {
+			return false
+		}
+	}
+	return true
+}
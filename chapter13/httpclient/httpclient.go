@@ -0,0 +1,224 @@
+// Package httpclient is the client side of chapter 13's HTTP exercises: a
+// Fetcher that wraps http.Client with a timeout per attempt, retries with
+// exponential backoff when a server fails with a 5xx status, fetches many
+// URLs with a bounded number of requests in flight, and can hold its
+// request rate under a limit.
+//
+//	f := httpclient.New(httpclient.WithTimeout(2*time.Second), httpclient.WithRetries(3))
+//	resps, err := f.FetchAll(ctx, urls, 4)
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"learning-go/clock"
+	"learning-go/errs"
+)
+
+// maxBodyBytes limits how much of a response body is read.
+const maxBodyBytes = 10 << 20
+
+// StatusError is returned for a response whose status is not 2xx.
+type StatusError struct {
+	URL  string
+	Code int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("GET %s: %d %s", e.URL, e.Code, http.StatusText(e.Code))
+}
+
+// Retryable reports whether the request may succeed if it is sent again:
+// true for server errors, false for client errors such as 404.
+func (e *StatusError) Retryable() bool {
+	return e.Code >= 500
+}
+
+// Response is a successful response, read in full.
+type Response struct {
+	URL    string
+	Status int
+	Body   []byte
+	// Attempts is how many requests it took, 1 if the first succeeded.
+	Attempts int
+}
+
+// Fetcher sends GET requests. Create one with New; it is safe for
+// concurrent use.
+type Fetcher struct {
+	client     *http.Client
+	timeout    time.Duration
+	retries    int
+	backoff    time.Duration
+	maxBackoff time.Duration
+	clock      clock.Clock
+	limiter    Limiter
+}
+
+// Limiter is the part of a rate limiter that Fetcher needs. Both limiters
+// in package concurrency/ratelimit satisfy it.
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+// Option configures New.
+type Option func(*Fetcher)
+
+// WithClient sets the http.Client requests are sent with. The default is a
+// new client with no timeout of its own, since each attempt has one.
+func WithClient(c *http.Client) Option {
+	return func(f *Fetcher) { f.client = c }
+}
+
+// WithTimeout limits each attempt to d. The default is 10 seconds; zero
+// or negative means no limit beyond the caller's context.
+func WithTimeout(d time.Duration) Option {
+	return func(f *Fetcher) { f.timeout = d }
+}
+
+// WithRetries sets how many times a failed request is sent again, so a
+// request is attempted at most n+1 times. The default is 3.
+func WithRetries(n int) Option {
+	return func(f *Fetcher) { f.retries = max(n, 0) }
+}
+
+// WithBackoff sets the wait before the first retry, which doubles before
+// each further retry up to limit. The default is 100ms up to 5s.
+func WithBackoff(base, limit time.Duration) Option {
+	return func(f *Fetcher) { f.backoff, f.maxBackoff = base, limit }
+}
+
+// WithClock sets the clock used to wait between retries. The default is
+// clock.Real.
+func WithClock(c clock.Clock) Option {
+	return func(f *Fetcher) { f.clock = c }
+}
+
+// WithLimiter makes every attempt, retries included, wait for l first, so
+// that a Fetcher shared by many goroutines stays under one request rate.
+// The default is no limit.
+func WithLimiter(l Limiter) Option {
+	return func(f *Fetcher) { f.limiter = l }
+}
+
+// New returns a Fetcher configured by opts.
+func New(opts ...Option) *Fetcher {
+	f := &Fetcher{
+		client:     &http.Client{},
+		timeout:    10 * time.Second,
+		retries:    3,
+		backoff:    100 * time.Millisecond,
+		maxBackoff: 5 * time.Second,
+		clock:      clock.Real,
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// Fetch gets url, retrying when the server responds with a 5xx status or
+// an attempt times out. It stops early if ctx is done. The error from the
+// last attempt is returned, wrapped with the number of attempts made.
+func (f *Fetcher) Fetch(ctx context.Context, url string) (*Response, error) {
+	wait := f.backoff
+	for attempt := 1; ; attempt++ {
+		if f.limiter != nil {
+			if err := f.limiter.Wait(ctx); err != nil {
+				return nil, errs.Wrap(err, "waiting to send attempt %d", attempt)
+			}
+		}
+		resp, err := f.attempt(ctx, url)
+		if err == nil {
+			resp.Attempts = attempt
+			return resp, nil
+		}
+		if attempt > f.retries || !f.retryable(ctx, err) {
+			return nil, errs.Wrap(err, "%d attempt(s)", attempt)
+		}
+		select {
+		case <-f.clock.After(wait):
+		case <-ctx.Done():
+			return nil, errs.Wrap(ctx.Err(), "%d attempt(s), last: %v", attempt, err)
+		}
+		wait = min(2*wait, f.maxBackoff)
+	}
+}
+
+// attempt sends one request with its own timeout.
+func (f *Fetcher) attempt(ctx context.Context, url string) (*Response, error) {
+	if f.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, f.timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	// Read the body even on failure, so the connection can be reused.
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodyBytes))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, &StatusError{URL: url, Code: resp.StatusCode}
+	}
+	return &Response{URL: url, Status: resp.StatusCode, Body: body}, nil
+}
+
+// retryable reports whether a failed attempt is worth repeating.
+func (f *Fetcher) retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		// The caller gave up, not just this attempt.
+		return false
+	}
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Retryable()
+	}
+	return errors.Is(err, context.DeadlineExceeded)
+}
+
+// FetchAll fetches every URL with at most concurrency requests in flight.
+// The responses are in the same order as urls, with nil for each URL that
+// failed. If any failed, the error is an *errs.MultiError holding one
+// error per failed URL, in the order of urls.
+func (f *Fetcher) FetchAll(ctx context.Context, urls []string, concurrency int) ([]*Response, error) {
+	resps := make([]*Response, len(urls))
+	failures := make([]error, len(urls))
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range max(concurrency, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				resps[i], failures[i] = f.Fetch(ctx, urls[i])
+			}
+		}()
+	}
+	for i := range urls {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	var c errs.Collector
+	for _, err := range failures {
+		c.Add(err)
+	}
+	return resps, c.Err()
+}
@@ -0,0 +1,206 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"learning-go/errs"
+)
+
+// fast returns a Fetcher that waits only a millisecond between attempts.
+func fast(opts ...Option) *Fetcher {
+	return New(append([]Option{WithBackoff(time.Millisecond, 4*time.Millisecond)}, opts...)...)
+}
+
+// failing serves codes in turn, one per request, then 200 "ok".
+func failing(codes ...int) (*httptest.Server, *atomic.Int32) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if n := int(calls.Add(1)); n <= len(codes) {
+			w.WriteHeader(codes[n-1])
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	return srv, &calls
+}
+
+func TestRetriesServerErrors(t *testing.T) {
+	srv, calls := failing(500, 503)
+	defer srv.Close()
+
+	resp, err := fast().Fetch(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(resp.Body) != "ok" || resp.Attempts != 3 || calls.Load() != 3 {
+		t.Errorf("body %q after %d attempts (%d calls), want ok after 3", resp.Body, resp.Attempts, calls.Load())
+	}
+}
+
+func TestGivesUpAfterRetries(t *testing.T) {
+	srv, calls := failing(500, 500, 500, 500)
+	defer srv.Close()
+
+	_, err := fast(WithRetries(2)).Fetch(context.Background(), srv.URL)
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.Code != 500 {
+		t.Fatalf("err = %v, want a 500 StatusError", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("%d calls, want 1 plus 2 retries", calls.Load())
+	}
+}
+
+func TestNoRetryOnClientError(t *testing.T) {
+	srv, calls := failing(404)
+	defer srv.Close()
+
+	_, err := fast().Fetch(context.Background(), srv.URL)
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.Code != 404 || statusErr.Retryable() {
+		t.Fatalf("err = %v, want a 404 that is not retryable", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("a 404 was tried %d times", calls.Load())
+	}
+}
+
+func TestPerAttemptTimeout(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			// Hang until the client gives up on this attempt.
+			<-r.Context().Done()
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	resp, err := fast(WithTimeout(20*time.Millisecond)).Fetch(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Attempts != 2 {
+		t.Errorf("Attempts = %d, want 2: the timed-out attempt must be retried", resp.Attempts)
+	}
+}
+
+func TestCancelStopsRetrying(t *testing.T) {
+	srv, calls := failing(500, 500, 500, 500)
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	f := New(WithBackoff(time.Hour, time.Hour))
+	done := make(chan error)
+	go func() {
+		_, err := f.Fetch(ctx, srv.URL)
+		done <- err
+	}()
+	for calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("err = %v, want context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Fetch kept waiting out its backoff after cancel")
+	}
+}
+
+func TestFetchAllBoundsConcurrency(t *testing.T) {
+	const limit = 3
+	var inFlight, peak atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		w.Write([]byte(r.URL.Path))
+	}))
+	defer srv.Close()
+
+	urls := make([]string, 12)
+	for i := range urls {
+		urls[i] = srv.URL + "/" + string(rune('a'+i))
+	}
+	resps, err := fast().FetchAll(context.Background(), urls, limit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range resps {
+		if want := "/" + string(rune('a'+i)); string(r.Body) != want {
+			t.Errorf("resps[%d] = %q, want %q: results must stay in URL order", i, r.Body, want)
+		}
+	}
+	if p := peak.Load(); p > limit {
+		t.Errorf("%d requests ran at once, want at most %d", p, limit)
+	}
+}
+
+func TestFetchAllCollectsErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/bad") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	urls := []string{srv.URL + "/good", srv.URL + "/bad1", srv.URL + "/good", srv.URL + "/bad2"}
+	resps, err := fast().FetchAll(context.Background(), urls, 2)
+	var multi *errs.MultiError
+	if !errors.As(err, &multi) || len(multi.Errs) != 2 {
+		t.Fatalf("err = %v, want two errors", err)
+	}
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.Code != 404 {
+		t.Errorf("errors.As cannot find the StatusError in %v", err)
+	}
+	if resps[0] == nil || resps[2] == nil || resps[1] != nil || resps[3] != nil {
+		t.Errorf("resps = %v, want results only for the good URLs", resps)
+	}
+}
+
+// countingLimiter counts the attempts that asked to go.
+type countingLimiter struct {
+	mu sync.Mutex
+	n  int
+}
+
+func (l *countingLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.n++
+	return ctx.Err()
+}
+
+func TestLimiterGatesEveryAttempt(t *testing.T) {
+	srv, _ := failing(502)
+	defer srv.Close()
+
+	var l countingLimiter
+	if _, err := fast(WithLimiter(&l)).Fetch(context.Background(), srv.URL); err != nil {
+		t.Fatal(err)
+	}
+	if l.n != 2 {
+		t.Errorf("limiter waited %d times, want once per attempt (2)", l.n)
+	}
+}
@@ -0,0 +1,295 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"learning-go/concurrency/ratelimit"
+	"learning-go/errs"
+	"learning-go/registry"
+	"learning-go/testutil/leak"
+)
+
+func init() {
+	// Register each exercise with the runner (cmd/learn)
+	registry.Register("chapter13/httpclient", "exercise1", exercise1)
+	registry.Register("chapter13/httpclient", "exercise2", exercise2)
+	registry.Register("chapter13/httpclient", "exercise3", exercise3)
+	registry.Register("chapter13/httpclient", "exercise4", exercise4)
+	registry.Requires("chapter13/httpclient", "exercise4", "chapter12/exercise6")
+}
+
+// flaky is a test server whose /flaky/{n} endpoint fails with 503 until it
+// has been called n times, counting each query string separately.
+// /missing is always 404, /broken always 500.
+type flaky struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (f *flaky) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	f.calls[r.URL.RequestURI()]++
+	calls := f.calls[r.URL.RequestURI()]
+	f.mu.Unlock()
+
+	switch {
+	case r.URL.Path == "/missing":
+		http.NotFound(w, r)
+	case r.URL.Path == "/broken":
+		http.Error(w, "broken", http.StatusInternalServerError)
+	case strings.HasPrefix(r.URL.Path, "/flaky/"):
+		n, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/flaky/"))
+		if calls < n {
+			http.Error(w, "try again", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintf(w, "ok after %d calls", calls)
+	}
+}
+
+// local replaces a test server's random address in err with "SERVER", so
+// the output is the same on every run.
+func local(err error, srv *httptest.Server) string {
+	return strings.ReplaceAll(fmt.Sprint(err), srv.URL, "SERVER")
+}
+
+// Exercise 1: Fetch from a test server that fails with 503 a few times
+// before it succeeds, and show how many attempts the Fetcher needed and
+// how long it backed off. Then fetch a URL that always fails with 500 and
+// one that is 404, which is not retried.
+func exercise1(w io.Writer) {
+	srv := httptest.NewServer(&flaky{calls: make(map[string]int)})
+	defer srv.Close()
+
+	f := New(WithRetries(3), WithBackoff(10*time.Millisecond, time.Second))
+	ctx := context.Background()
+
+	start := time.Now()
+	resp, err := f.Fetch(ctx, srv.URL+"/flaky/3")
+	elapsed := time.Since(start)
+	if err == nil {
+		fmt.Fprintf(w, "/flaky/3: %d %q after %d attempts\n", resp.Status, resp.Body, resp.Attempts)
+	}
+	// Two retries: 10ms then 20ms of backoff.
+	fmt.Fprintln(w, "  waited at least 30ms:", elapsed >= 30*time.Millisecond)
+
+	for _, path := range []string{"/flaky/10", "/broken", "/missing"} {
+		_, err := f.Fetch(ctx, srv.URL+path)
+		fmt.Fprintf(w, "%s: %s\n", path, local(err, srv))
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) {
+			fmt.Fprintf(w, "  status %d, retryable: %v\n", statusErr.Code, statusErr.Retryable())
+		}
+	}
+
+	// Explanation:
+	// A 5xx status means the server failed, often briefly (a restart, an
+	// overloaded backend), so sending the same request again may work. A
+	// 4xx means the request itself is wrong and will fail every time, so
+	// /missing is attempted once. Doubling the wait before each retry,
+	// exponential backoff, gives a struggling server more room each time
+	// instead of hammering it. The error is a *StatusError, so callers
+	// can use errors.As to find the status code behind the wrapping.
+}
+
+// Exercise 2: Give each attempt a timeout and fetch from a server that is
+// too slow, then cancel the caller's context while the Fetcher is waiting
+// to retry.
+func exercise2(w io.Writer) {
+	release := make(chan struct{})
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		switch r.URL.Path {
+		case "/slow":
+			select {
+			case <-r.Context().Done():
+			case <-release:
+			}
+		case "/unavailable":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	f := New(WithTimeout(50*time.Millisecond), WithRetries(2), WithBackoff(10*time.Millisecond, time.Second))
+	_, err := f.Fetch(context.Background(), srv.URL+"/slow")
+	fmt.Fprintln(w, "slow server:", local(err, srv))
+	fmt.Fprintln(w, "  errors.Is DeadlineExceeded:", errors.Is(err, context.DeadlineExceeded))
+	fmt.Fprintln(w, "  requests sent:", calls.Load())
+
+	calls.Store(0)
+	f = New(WithRetries(5), WithBackoff(time.Second, time.Second))
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = f.Fetch(ctx, srv.URL+"/unavailable")
+	fmt.Fprintln(w, "cancelled while backing off:", local(err, srv))
+	fmt.Fprintln(w, "  returned before the 1s backoff:", time.Since(start) < time.Second)
+	fmt.Fprintln(w, "  requests sent:", calls.Load())
+
+	// Explanation:
+	// Each attempt gets its own context.WithTimeout derived from the
+	// caller's, so one slow response cannot use up the whole budget: the
+	// attempt is abandoned after 50ms and retried. Cancelling the context
+	// makes http.Client close the connection, and the handler sees its
+	// request context end too. The wait between attempts also selects on
+	// ctx.Done, so a caller that gives up is never kept waiting for a
+	// retry it no longer wants. Note the difference: an attempt's own
+	// timeout is retried, the caller's deadline is not.
+}
+
+// inFlight counts concurrent requests and remembers the most seen at once.
+type inFlight struct {
+	now, peak atomic.Int32
+}
+
+func (c *inFlight) wrap(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := c.now.Add(1)
+		for {
+			p := c.peak.Load()
+			if n <= p || c.peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		h.ServeHTTP(w, r)
+		c.now.Add(-1)
+	})
+}
+
+// Exercise 3: Fetch twelve URLs, two of which fail, with at most three
+// requests in flight. Check the limit on the server side, print the
+// responses in order, and go through the aggregated errors.
+func exercise3(w io.Writer) {
+	var counter inFlight
+	srv := httptest.NewServer(counter.wrap(&flaky{calls: make(map[string]int)}))
+	defer srv.Close()
+	snap := leak.Take()
+
+	var urls []string
+	for i := range 12 {
+		switch i {
+		case 4:
+			urls = append(urls, srv.URL+"/missing")
+		case 9:
+			urls = append(urls, srv.URL+"/broken")
+		default:
+			urls = append(urls, fmt.Sprintf("%s/flaky/%d?id=%d", srv.URL, i%3+1, i))
+		}
+	}
+
+	f := New(WithRetries(2), WithBackoff(5*time.Millisecond, time.Second))
+	resps, err := f.FetchAll(context.Background(), urls, 3)
+	for i, resp := range resps {
+		if resp == nil {
+			fmt.Fprintf(w, "%2d: failed\n", i)
+			continue
+		}
+		fmt.Fprintf(w, "%2d: %s %q (%d attempts)\n", i, strings.TrimPrefix(resp.URL, srv.URL), resp.Body, resp.Attempts)
+	}
+	fmt.Fprintln(w, "most requests in flight:", counter.peak.Load())
+
+	var multi *errs.MultiError
+	if errors.As(err, &multi) {
+		fmt.Fprintf(w, "%d URLs failed:\n", len(multi.Errs))
+		for _, e := range multi.Errs {
+			fmt.Fprintln(w, "  -", local(e, srv))
+		}
+	}
+	// Each idle keep-alive connection keeps a reading and a writing
+	// goroutine on the client and one on the server until it is closed;
+	// those are not FetchAll's.
+	idle := []string{"net/http.(*persistConn)", "net/http.(*conn).serve"}
+	fmt.Fprintln(w, "FetchAll goroutines left:", len(snap.Leaked(leak.Timeout, idle...)))
+
+	// Explanation:
+	// FetchAll starts exactly concurrency workers that take URL indexes
+	// from one channel, so no matter how many URLs there are, at most that
+	// many requests are in flight; the server never saw more than three.
+	// Each worker writes only its own slots of the result slices, so they
+	// need no lock, and the responses come back in input order although
+	// they finished in any order. Failures do not stop the others: every
+	// error is kept and returned together in an *errs.MultiError.
+}
+
+// Exercise 4: Share one token bucket between all of FetchAll's workers and
+// show that a burst goes out at once and the rest at the bucket's rate,
+// retries included. Then put a leaky bucket with a short queue in front of
+// the same server and count the requests it turns away.
+func exercise4(w io.Writer) {
+	srv := httptest.NewServer(&flaky{calls: make(map[string]int)})
+	defer srv.Close()
+
+	const every = 20 * time.Millisecond
+	var urls []string
+	for i := range 6 {
+		urls = append(urls, fmt.Sprintf("%s/flaky/%d?id=%d", srv.URL, i%2+1, i))
+	}
+	// Three of the URLs need a retry, so nine requests are sent in all.
+	tb := ratelimit.NewTokenBucket(every, 3)
+	f := New(WithRetries(1), WithBackoff(time.Millisecond, time.Millisecond), WithLimiter(tb))
+	start := time.Now()
+	resps, err := f.FetchAll(context.Background(), urls, len(urls))
+	elapsed := time.Since(start)
+	attempts := 0
+	for _, resp := range resps {
+		if resp != nil {
+			attempts += resp.Attempts
+		}
+	}
+	fmt.Fprintf(w, "token bucket: %d URLs, %d requests, error %v\n", len(urls), attempts, err)
+	fmt.Fprintf(w, "  took at least %d intervals for the requests after the burst of 3: %v\n",
+		attempts-3, elapsed >= time.Duration(attempts-3)*every)
+
+	lb := ratelimit.NewLeakyBucket(100*time.Millisecond, 1)
+	f = New(WithLimiter(lb))
+	resps, err = f.FetchAll(context.Background(), urls[:4], 4)
+	sent := 0
+	for _, resp := range resps {
+		if resp != nil {
+			sent++
+		}
+	}
+	fmt.Fprintln(w, "leaky bucket with a queue of 1, 4 URLs at once: sent", sent)
+	var multi *errs.MultiError
+	if errors.As(err, &multi) {
+		for _, e := range multi.Errs {
+			fmt.Fprintln(w, "  -", local(e, srv))
+		}
+		fmt.Fprintln(w, "  errors.Is ErrQueueFull:", errors.Is(err, ratelimit.ErrQueueFull))
+	}
+
+	slow := ratelimit.NewLeakyBucket(time.Hour, 1)
+	slow.Allow() // the next slot is an hour away
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = New(WithLimiter(slow)).Fetch(ctx, srv.URL+"/missing")
+	fmt.Fprintln(w, "deadline while waiting for a slot:", err)
+	fmt.Fprintln(w, "  callers still waiting:", slow.Waiting())
+
+	// Explanation:
+	// Fetcher waits on the limiter before every attempt, so a retry costs
+	// a token like any other request and all the workers draw from the one
+	// bucket: the server sees the rate the limiter allows however many
+	// workers there are. The token bucket lets the first three requests go
+	// at once and then one per interval. The leaky bucket sends the first
+	// request straight away, holds one caller for the next slot and fails
+	// the rest at once with ErrQueueFull, which FetchAll reports like any
+	// other error. A caller whose context ends while it waits gives its
+	// slot back and gets the context's error. Fetcher only needs Wait, so
+	// it declares its own one-method Limiter interface instead of
+	// importing ratelimit's.
+}
@@ -0,0 +1,255 @@
+// Package httpserver is a small REST API for chapter 7's Employee, built
+// on nothing but net/http:
+//
+//	GET    /employees       all employees, ordered by ID
+//	POST   /employees       create one; the server assigns the ID
+//	GET    /employees/{id}  one employee
+//	PUT    /employees/{id}  replace one
+//	DELETE /employees/{id}  delete one
+//
+// Requests and responses are JSON, and errors are {"error": "..."} with a
+// matching status code. Logging and Recover are middleware that wrap any
+// http.Handler, and Serve runs a server until its context is cancelled,
+// then shuts it down gracefully.
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"learning-go/chapter7/employees"
+	"learning-go/validate"
+)
+
+// maxBodyBytes limits the size of a request body.
+const maxBodyBytes = 1 << 20
+
+// Store holds employees in memory. It is safe for concurrent use.
+type Store struct {
+	mu     sync.RWMutex
+	byID   map[int]employees.Employee
+	nextID int
+}
+
+// NewStore returns a store holding seed. New employees get IDs after the
+// largest one in seed.
+func NewStore(seed []employees.Employee) *Store {
+	s := &Store{byID: make(map[int]employees.Employee, len(seed)), nextID: 1}
+	for _, e := range seed {
+		s.byID[e.ID] = e
+		s.nextID = max(s.nextID, e.ID+1)
+	}
+	return s
+}
+
+// List returns every employee ordered by ID.
+func (s *Store) List() []employees.Employee {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	list := make([]employees.Employee, 0, len(s.byID))
+	for _, e := range s.byID {
+		list = append(list, e)
+	}
+	slices.SortFunc(list, func(a, b employees.Employee) int { return a.ID - b.ID })
+	return list
+}
+
+// Get returns one employee, or employees.ErrNotFound.
+func (s *Store) Get(id int) (employees.Employee, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	e, ok := s.byID[id]
+	if !ok {
+		return employees.Employee{}, employees.ErrNotFound
+	}
+	return e, nil
+}
+
+// Create stores e under a new ID and returns it with the ID set.
+func (s *Store) Create(e employees.Employee) employees.Employee {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e.ID = s.nextID
+	s.nextID++
+	s.byID[e.ID] = e
+	return e
+}
+
+// Update replaces the employee with e's ID, or returns
+// employees.ErrNotFound.
+func (s *Store) Update(e employees.Employee) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.byID[e.ID]; !ok {
+		return employees.ErrNotFound
+	}
+	s.byID[e.ID] = e
+	return nil
+}
+
+// Delete removes one employee, or returns employees.ErrNotFound.
+func (s *Store) Delete(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.byID[id]; !ok {
+		return employees.ErrNotFound
+	}
+	delete(s.byID, id)
+	return nil
+}
+
+// NewHandler returns the API for s. It has no middleware; wrap it with
+// Logging and Recover.
+func NewHandler(s *Store) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /employees", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, s.List())
+	})
+	mux.HandleFunc("POST /employees", func(w http.ResponseWriter, r *http.Request) {
+		var e employees.Employee
+		if !readEmployee(w, r, &e) {
+			return
+		}
+		e = s.Create(e)
+		w.Header().Set("Location", "/employees/"+strconv.Itoa(e.ID))
+		writeJSON(w, http.StatusCreated, e)
+	})
+	mux.HandleFunc("GET /employees/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, ok := pathID(w, r)
+		if !ok {
+			return
+		}
+		e, err := s.Get(id)
+		if err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, e)
+	})
+	mux.HandleFunc("PUT /employees/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, ok := pathID(w, r)
+		if !ok {
+			return
+		}
+		var e employees.Employee
+		if !readEmployee(w, r, &e) {
+			return
+		}
+		if e.ID != 0 && e.ID != id {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("body has id %d, URL has %d", e.ID, id))
+			return
+		}
+		e.ID = id
+		if err := s.Update(e); err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, e)
+	})
+	mux.HandleFunc("DELETE /employees/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, ok := pathID(w, r)
+		if !ok {
+			return
+		}
+		if err := s.Delete(id); err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return mux
+}
+
+// pathID parses the {id} wildcard, writing a 400 response if it is not a
+// number.
+func pathID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, errors.New("id must be a number"))
+		return 0, false
+	}
+	return id, true
+}
+
+// employeeRequest is the body of a POST or PUT. Its rules are checked
+// with package validate.
+type employeeRequest struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name" validate:"required,max=100"`
+	Salary int    `json:"salary" validate:"min=0"`
+}
+
+// readEmployee decodes and validates the request body into e, writing an
+// error response and returning false if it is not a valid employee.
+func readEmployee(w http.ResponseWriter, r *http.Request, e *employees.Employee) bool {
+	if ct := r.Header.Get("Content-Type"); ct != "" && !strings.HasPrefix(ct, "application/json") {
+		writeError(w, http.StatusUnsupportedMediaType, fmt.Errorf("content type %q is not application/json", ct))
+		return false
+	}
+	var req employeeRequest
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid body: %w", err))
+		return false
+	}
+	// A name of only spaces is as empty as no name.
+	req.Name = strings.TrimSpace(req.Name)
+	if err := validate.Struct(req); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return false
+	}
+	*e = employees.Employee{ID: req.ID, Name: req.Name, Salary: req.Salary}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// Serve serves h on ln until ctx is cancelled, then shuts the server down:
+// it stops accepting connections and waits up to grace for requests in
+// flight to finish. It returns nil after a clean shutdown.
+func Serve(ctx context.Context, ln net.Listener, h http.Handler, grace time.Duration) error {
+	srv := &http.Server{
+		Handler:           h,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	errc := make(chan error, 1)
+	go func() {
+		errc <- srv.Serve(ln)
+	}()
+
+	select {
+	case err := <-errc:
+		// The server failed before anyone asked it to stop.
+		return err
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), grace)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		srv.Close()
+		return fmt.Errorf("shutting down: %w", err)
+	}
+	// Serve returns ErrServerClosed as soon as Shutdown is called.
+	if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
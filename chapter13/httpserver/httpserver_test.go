@@ -0,0 +1,230 @@
+package httpserver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"learning-go/chapter7/employees"
+	"learning-go/clock"
+	"learning-go/testutil/leak"
+)
+
+// do sends a request to h and returns the response status and body.
+func do(t *testing.T, h http.Handler, method, path, body string) (int, string) {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec.Code, strings.TrimSpace(rec.Body.String())
+}
+
+func newHandler() http.Handler {
+	return NewHandler(NewStore([]employees.Employee{
+		{ID: 1, Name: "Ada", Salary: 5000},
+		{ID: 2, Name: "Grace", Salary: 6000},
+	}))
+}
+
+func TestCRUD(t *testing.T) {
+	h := newHandler()
+	steps := []struct {
+		method, path, body string
+		status             int
+		want               string
+	}{
+		{"GET", "/employees", "", 200, `[{"id":1,"name":"Ada","salary":5000},{"id":2,"name":"Grace","salary":6000}]`},
+		{"GET", "/employees/2", "", 200, `{"id":2,"name":"Grace","salary":6000}`},
+		{"POST", "/employees", `{"name":"Linus","salary":4000}`, 201, `{"id":3,"name":"Linus","salary":4000}`},
+		{"PUT", "/employees/3", `{"name":"Linus","salary":4500}`, 200, `{"id":3,"name":"Linus","salary":4500}`},
+		{"GET", "/employees/3", "", 200, `{"id":3,"name":"Linus","salary":4500}`},
+		{"DELETE", "/employees/1", "", 204, ``},
+		{"GET", "/employees/1", "", 404, `{"error":"employee not found"}`},
+		{"DELETE", "/employees/1", "", 404, `{"error":"employee not found"}`},
+		{"GET", "/employees", "", 200, `[{"id":2,"name":"Grace","salary":6000},{"id":3,"name":"Linus","salary":4500}]`},
+	}
+	for _, s := range steps {
+		status, body := do(t, h, s.method, s.path, s.body)
+		if status != s.status || body != s.want {
+			t.Errorf("%s %s: %d %s\nwant %d %s", s.method, s.path, status, body, s.status, s.want)
+		}
+	}
+}
+
+func TestPostSetsLocation(t *testing.T) {
+	req := httptest.NewRequest("POST", "/employees", strings.NewReader(`{"name":"Rob","salary":1}`))
+	rec := httptest.NewRecorder()
+	newHandler().ServeHTTP(rec, req)
+	if loc := rec.Header().Get("Location"); loc != "/employees/3" {
+		t.Errorf("Location = %q", loc)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+}
+
+func TestBadRequests(t *testing.T) {
+	h := newHandler()
+	tests := []struct {
+		name, method, path, body string
+		status                   int
+	}{
+		{"id not a number", "GET", "/employees/abc", "", 400},
+		{"malformed JSON", "POST", "/employees", `{"name":`, 400},
+		{"unknown field", "POST", "/employees", `{"name":"A","age":3}`, 400},
+		{"empty name", "POST", "/employees", `{"name":"   ","salary":1}`, 422},
+		{"negative salary", "POST", "/employees", `{"name":"A","salary":-1}`, 422},
+		{"long name", "POST", "/employees", `{"name":"` + strings.Repeat("x", 101) + `"}`, 422},
+		{"id mismatch", "PUT", "/employees/1", `{"id":2,"name":"A"}`, 400},
+		{"update missing", "PUT", "/employees/99", `{"name":"A"}`, 404},
+		{"wrong method", "PATCH", "/employees/1", `{}`, 405},
+	}
+	for _, tt := range tests {
+		status, body := do(t, h, tt.method, tt.path, tt.body)
+		if status != tt.status {
+			t.Errorf("%s: status %d, want %d (%s)", tt.name, status, tt.status, body)
+		}
+		if status != 405 && !strings.HasPrefix(body, `{"error":`) {
+			t.Errorf("%s: body %s is not a JSON error", tt.name, body)
+		}
+	}
+
+	req := httptest.NewRequest("POST", "/employees", strings.NewReader(`name=A`))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("form body: status %d, want 415", rec.Code)
+	}
+}
+
+// logger records what is logged.
+type logger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *logger) Printf(format string, args ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, fmt.Sprintf(format, args...))
+}
+
+func TestMiddleware(t *testing.T) {
+	var log logger
+	clk := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	mux := http.NewServeMux()
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		clk.Advance(250 * time.Millisecond)
+		io.WriteString(w, "done")
+	})
+	mux.HandleFunc("/panic", func(w http.ResponseWriter, r *http.Request) {
+		panic("handler bug")
+	})
+	h := Chain(mux, Logging(&log, clk), Recover(&log))
+
+	if status, body := do(t, h, "GET", "/slow", ""); status != 200 || body != "done" {
+		t.Errorf("/slow: %d %s", status, body)
+	}
+	if status, body := do(t, h, "GET", "/panic", ""); status != 500 || body != `{"error":"internal error"}` {
+		t.Errorf("/panic: %d %s", status, body)
+	}
+
+	if len(log.lines) != 3 {
+		t.Fatalf("logged %d lines, want 3: %q", len(log.lines), log.lines)
+	}
+	if want := "GET /slow 200 4B 250ms"; log.lines[0] != want {
+		t.Errorf("log line %q, want %q", log.lines[0], want)
+	}
+	if !strings.HasPrefix(log.lines[1], "panic serving GET /panic: handler bug") {
+		t.Errorf("panic not logged: %q", log.lines[1])
+	}
+	// Logging is outermost, so it sees the 500 Recover wrote.
+	if !strings.HasPrefix(log.lines[2], "GET /panic 500 ") {
+		t.Errorf("log line %q does not show the recovered 500", log.lines[2])
+	}
+}
+
+func TestServeShutsDownGracefully(t *testing.T) {
+	leak.Verify(t)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	started := make(chan struct{})
+	release := make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		io.WriteString(w, "finished")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- Serve(ctx, ln, mux, 5*time.Second) }()
+
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	got := make(chan string, 1)
+	go func() {
+		resp, err := client.Get("http://" + ln.Addr().String() + "/")
+		if err != nil {
+			got <- err.Error()
+			return
+		}
+		defer resp.Body.Close()
+		b, _ := io.ReadAll(resp.Body)
+		got <- string(b)
+	}()
+
+	<-started
+	cancel()
+	// Shutdown waits for the request in flight before Serve returns.
+	select {
+	case err := <-served:
+		t.Fatalf("Serve returned %v with a request in flight", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+	if body := <-got; body != "finished" {
+		t.Errorf("request in flight got %q", body)
+	}
+	if err := <-served; err != nil {
+		t.Errorf("Serve = %v, want nil", err)
+	}
+}
+
+func TestStoreConcurrent(t *testing.T) {
+	s := NewStore(nil)
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			e := s.Create(employees.Employee{Name: fmt.Sprint("e", i)})
+			s.Get(e.ID)
+			s.List()
+		}()
+	}
+	wg.Wait()
+	list := s.List()
+	if len(list) != 20 {
+		t.Fatalf("List has %d employees, want 20", len(list))
+	}
+	for i, e := range list {
+		if e.ID != i+1 {
+			t.Errorf("IDs are not unique and sequential: %v", list)
+			break
+		}
+	}
+}
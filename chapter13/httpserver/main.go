@@ -0,0 +1,196 @@
+package httpserver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"syscall"
+	"time"
+
+	"learning-go/chapter7/employees"
+	"learning-go/clock"
+	"learning-go/registry"
+)
+
+func init() {
+	// Register each exercise with the runner (cmd/learn)
+	registry.Register("chapter13/httpserver", "exercise1", exercise1)
+	registry.Register("chapter13/httpserver", "exercise2", exercise2)
+	registry.Register("chapter13/httpserver", "exercise3", exercise3)
+}
+
+// firstLine is a logger that prints only the first line of each message,
+// leaving out the stack traces Recover logs, which differ between runs.
+type firstLine struct {
+	w io.Writer
+}
+
+func (l firstLine) Printf(format string, args ...any) {
+	msg, _, _ := strings.Cut(fmt.Sprintf(format, args...), "\n")
+	fmt.Fprintln(l.w, "  log:", msg)
+}
+
+// Exercise 1: Drive the employee API through httptest.NewRecorder: create,
+// read, update and delete an employee, and send the requests a client gets
+// wrong (a bad ID, an unknown field, an empty name, a missing employee).
+// Print each status code and response body.
+func exercise1(w io.Writer) {
+	logger := firstLine{w}
+	// A fake clock that never moves makes every logged duration 0s.
+	fake := clock.NewFake(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+	h := Chain(NewHandler(NewStore(employees.Seed)), Logging(logger, fake), Recover(logger))
+
+	requests := []struct{ method, path, body string }{
+		{"GET", "/employees", ""},
+		{"POST", "/employees", `{"name": "Barbara", "salary": 5500}`},
+		{"GET", "/employees/4", ""},
+		{"PUT", "/employees/4", `{"name": "Barbara", "salary": 5800}`},
+		{"DELETE", "/employees/2", ""},
+		{"GET", "/employees", ""},
+		{"GET", "/employees/two", ""},
+		{"GET", "/employees/2", ""},
+		{"POST", "/employees", `{"name": "Ken", "salry": 4000}`},
+		{"POST", "/employees", `{"name": " ", "salary": 4000}`},
+		{"PUT", "/employees/4", `{"id": 5, "name": "Barbara"}`},
+		{"PATCH", "/employees/4", `{}`},
+	}
+	for _, req := range requests {
+		r := httptest.NewRequest(req.method, req.path, strings.NewReader(req.body))
+		if req.body != "" {
+			r.Header.Set("Content-Type", "application/json")
+		}
+		rec := httptest.NewRecorder()
+		fmt.Fprintf(w, "%s %s %s\n", req.method, req.path, req.body)
+		h.ServeHTTP(rec, r)
+		fmt.Fprintf(w, "  %d %s\n", rec.Code, strings.TrimSpace(rec.Body.String()))
+		if loc := rec.Header().Get("Location"); loc != "" {
+			fmt.Fprintf(w, "  Location: %s\n", loc)
+		}
+	}
+
+	// Explanation:
+	// Since Go 1.22, ServeMux patterns can name a method and wildcards, so
+	// "GET /employees/{id}" routes by both, r.PathValue("id") reads the
+	// wildcard, and a request with the wrong method gets 405 Method Not
+	// Allowed without any code of ours. httptest.NewRecorder is a
+	// ResponseWriter that just records what the handler wrote, so a
+	// handler can be tested by calling ServeHTTP directly, without a
+	// network. Middleware is an ordinary function from http.Handler to
+	// http.Handler; Logging wraps the ResponseWriter to learn the status
+	// code the handler chose.
+}
+
+// Exercise 2: Serve a handler that panics, first bare and then wrapped in
+// Recover, and compare what the client gets back.
+func exercise2(w io.Writer) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /panic", func(w http.ResponseWriter, r *http.Request) {
+		var e *employees.Employee
+		io.WriteString(w, e.Name) // nil pointer dereference
+	})
+
+	for _, tc := range []struct {
+		name string
+		h    http.Handler
+	}{
+		{"without Recover", mux},
+		{"with Recover", Recover(firstLine{w})(mux)},
+	} {
+		srv := httptest.NewUnstartedServer(tc.h)
+		// net/http logs recovered panics with a stack trace; hide them.
+		srv.Config.ErrorLog = log.New(io.Discard, "", 0)
+		srv.Start()
+
+		fmt.Fprintln(w, tc.name+":")
+		resp, err := srv.Client().Get(srv.URL + "/panic")
+		if err != nil {
+			fmt.Fprintln(w, "  request failed:", errors.Is(err, io.EOF))
+		} else {
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			fmt.Fprintf(w, "  %s %s\n", resp.Status, bytes.TrimSpace(body))
+		}
+		srv.Close()
+	}
+
+	// Explanation:
+	// The server runs each request in its own goroutine and recovers
+	// panics there, so a panicking handler never crashes the program, but
+	// all it can do is log the panic and close the connection: the client
+	// sees the connection end (io.EOF) with no response at all. Recover
+	// catches the panic one level closer to the handler, while the
+	// response can still be written, and turns it into a proper 500 with
+	// a JSON body. It lets http.ErrAbortHandler through, since that panic
+	// is how a handler deliberately drops a connection.
+}
+
+// Exercise 3: Run the API on a real listener with Serve, start a slow
+// request, and cancel the server's context while the request is in flight,
+// as a SIGINT would. Show that the request still completes, Serve returns
+// nil and new connections are refused.
+func exercise3(w io.Writer) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	addr := ln.Addr().String()
+
+	started := make(chan struct{})
+	mux := http.NewServeMux()
+	mux.Handle("/", NewHandler(NewStore(employees.Seed)))
+	mux.HandleFunc("GET /slow", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		fmt.Fprintln(w, "slow request done")
+	})
+
+	ctx, stop := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- Serve(ctx, ln, mux, 5*time.Second)
+	}()
+
+	type result struct {
+		body string
+		err  error
+	}
+	slow := make(chan result, 1)
+	go func() {
+		resp, err := http.Get("http://" + addr + "/slow")
+		if err != nil {
+			slow <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		slow <- result{string(body), err}
+	}()
+
+	<-started
+	fmt.Fprintln(w, "slow request in flight, stopping the server")
+	stop()
+
+	r := <-slow
+	fmt.Fprintf(w, "slow request: %q %v\n", r.body, r.err)
+	fmt.Fprintln(w, "Serve returned:", <-served)
+	_, err = http.Get("http://" + addr + "/employees")
+	fmt.Fprintln(w, "new request refused:", errors.Is(err, syscall.ECONNREFUSED))
+
+	// Explanation:
+	// Shutdown closes the listener so no new connections are accepted,
+	// then waits for the requests already running to finish before
+	// returning; Close, by contrast, drops them mid-response. Serve ties
+	// Shutdown to a context, so the caller decides what stops the server.
+	// cmd/employeeserver passes a context from signal.NotifyContext, which
+	// is cancelled on Ctrl-C; here the exercise cancels it by hand. The
+	// grace period bounds the wait so a stuck request cannot keep the
+	// process alive forever.
+}
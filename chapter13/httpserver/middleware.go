@@ -0,0 +1,87 @@
+package httpserver
+
+import (
+	"net/http"
+	"runtime/debug"
+
+	"learning-go/chapter7/employees"
+	"learning-go/clock"
+)
+
+// Middleware wraps a handler with behaviour of its own, such as logging,
+// before or after calling it.
+type Middleware func(http.Handler) http.Handler
+
+// Chain wraps h with each middleware in turn, so the first one listed is
+// the outermost and sees every request first.
+func Chain(h http.Handler, mw ...Middleware) http.Handler {
+	for i := len(mw) - 1; i >= 0; i-- {
+		h = mw[i](h)
+	}
+	return h
+}
+
+// statusRecorder remembers the status code and body size a handler wrote.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += n
+	return n, err
+}
+
+// Unwrap lets http.ResponseController reach the original ResponseWriter.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// Logging logs one line per request with its method, path, status, size
+// and duration, timed with c.
+func Logging(log employees.Logger, c clock.Clock) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := c.Now()
+			rec := &statusRecorder{ResponseWriter: w}
+			next.ServeHTTP(rec, r)
+			log.Printf("%s %s %d %dB %v", r.Method, r.URL.Path, rec.status, rec.bytes, c.Since(start))
+		})
+	}
+}
+
+// Recover turns a panic in a handler into a 500 response, logging the
+// panic and its stack, so one bad request cannot take down the server.
+// net/http would recover the panic too, but it drops the connection
+// without a response.
+func Recover(log employees.Logger) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				v := recover()
+				if v == nil {
+					return
+				}
+				if v == http.ErrAbortHandler {
+					// The handler asked for the connection to be dropped.
+					panic(v)
+				}
+				log.Printf("panic serving %s %s: %v\n%s", r.Method, r.URL.Path, v, debug.Stack())
+				writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
+			}()
+			next.ServeHTTP(w, r)
+		})
+	}
+}
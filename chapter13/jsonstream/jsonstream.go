@@ -0,0 +1,159 @@
+// Package jsonstream reads and writes employee records as NDJSON:
+// newline-delimited JSON, one object per line. It is chapter 13's tour of
+// encoding/json: struct tags, embedded structs, a type with its own
+// MarshalJSON and UnmarshalJSON, pointer fields for a partial update, and
+// a json.Decoder that processes a large file one record at a time.
+//
+//	f, _ := os.Open("employees.ndjson")
+//	err := jsonstream.Stream(f, func(r jsonstream.Record) error {
+//		fmt.Println(r.Name, r.Hired)
+//		return nil
+//	})
+package jsonstream
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"learning-go/chapter7/employees"
+	"learning-go/errs"
+	"learning-go/ptr"
+)
+
+// Date is a calendar day. It is encoded in JSON as "2006-01-02" instead of
+// time.Time's full RFC 3339 timestamp.
+type Date struct {
+	time.Time
+}
+
+// NewDate returns the given day.
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// String returns the date as "2006-01-02".
+func (d Date) String() string {
+	return d.Format(time.DateOnly)
+}
+
+// MarshalJSON implements json.Marshaler. The zero Date is encoded as null.
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts null, which leaves
+// the Date unchanged, as json does for every other type.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("date must be a string: %w", err)
+	}
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return err
+	}
+	d.Time = t
+	return nil
+}
+
+// Record is one line of an employees file. The embedded Employee's fields
+// are encoded as if they were Record's own: {"id":1,"name":...,"hired":...}.
+type Record struct {
+	employees.Employee
+	Hired   Date     `json:"hired"`
+	Manager int      `json:"manager,omitempty"`
+	Skills  []string `json:"skills,omitempty"`
+}
+
+var (
+	firstNames = []string{"Ada", "Grace", "Linus", "Barbara", "Ken", "Margaret", "Dennis", "Frances", "Rob", "Radia"}
+	lastNames  = []string{"Lovelace", "Hopper", "Torvalds", "Liskov", "Thompson", "Hamilton", "Ritchie", "Allen", "Pike", "Perlman"}
+	skills     = []string{"go", "sql", "kubernetes", "rust", "networking", "security"}
+	firstHire  = NewDate(2010, time.January, 1)
+)
+
+// Sample returns the i'th record of the sample data. The data is made up
+// but fixed: the same i always gives the same record.
+func Sample(i int) Record {
+	r := Record{
+		Employee: employees.Employee{
+			ID:     i + 1,
+			Name:   firstNames[i%len(firstNames)] + " " + lastNames[i/len(firstNames)%len(lastNames)],
+			Salary: 3000 + i*7919%7000,
+		},
+		Hired: Date{firstHire.AddDate(0, 0, i*37%5000)},
+	}
+	if i > 0 {
+		r.Manager = i/10 + 1
+	}
+	for j, s := range skills {
+		if (i>>j)&1 == 1 {
+			r.Skills = append(r.Skills, s)
+		}
+	}
+	return r
+}
+
+// Generate writes n sample records to w, one JSON object per line.
+func Generate(w io.Writer, n int) error {
+	bw := bufio.NewWriter(w)
+	// Encode writes a newline after each value, which is exactly NDJSON.
+	enc := json.NewEncoder(bw)
+	for i := range n {
+		if err := enc.Encode(Sample(i)); err != nil {
+			return errs.Wrap(err, "record %d", i+1)
+		}
+	}
+	return bw.Flush()
+}
+
+// Stream decodes the records in r one at a time and calls fn for each, so
+// only one record is in memory at once however large the input is. It
+// stops at the first error, from the input or from fn.
+func Stream(r io.Reader, fn func(Record) error) error {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	for n := 1; ; n++ {
+		var rec Record
+		err := dec.Decode(&rec)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return errs.Wrap(err, "record %d (byte %d)", n, dec.InputOffset())
+		}
+		if err := fn(rec); err != nil {
+			return err
+		}
+	}
+}
+
+// Patch is a partial update of a Record. Every field is a pointer, so a
+// field missing from the JSON (nil) can be told apart from one set to its
+// zero value, such as "manager": 0 to remove a manager. omitempty leaves
+// nil fields out when a Patch is encoded.
+type Patch struct {
+	Name    *string   `json:"name,omitempty"`
+	Salary  *int      `json:"salary,omitempty"`
+	Manager *int      `json:"manager,omitempty"`
+	Skills  *[]string `json:"skills,omitempty"`
+}
+
+// Apply returns r with the fields set in p replaced.
+func (p Patch) Apply(r Record) Record {
+	r.Name = ptr.Deref(p.Name, r.Name)
+	r.Salary = ptr.Deref(p.Salary, r.Salary)
+	r.Manager = ptr.Deref(p.Manager, r.Manager)
+	r.Skills = ptr.Deref(p.Skills, r.Skills)
+	return r
+}
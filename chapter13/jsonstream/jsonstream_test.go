@@ -0,0 +1,120 @@
+package jsonstream
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"learning-go/ptr"
+)
+
+func TestDateJSON(t *testing.T) {
+	d := NewDate(2024, time.February, 29)
+	data, err := json.Marshal(d)
+	if err != nil || string(data) != `"2024-02-29"` {
+		t.Fatalf("Marshal = %s, %v", data, err)
+	}
+	var back Date
+	if err := json.Unmarshal(data, &back); err != nil || !back.Equal(d.Time) {
+		t.Errorf("Unmarshal(%s) = %v, %v", data, back, err)
+	}
+
+	if data, _ := json.Marshal(Date{}); string(data) != "null" {
+		t.Errorf("zero Date marshals as %s, want null", data)
+	}
+	kept := d
+	if err := json.Unmarshal([]byte("null"), &kept); err != nil || kept != d {
+		t.Errorf("null changed the Date to %v (err %v)", kept, err)
+	}
+	for _, bad := range []string{`20240229`, `"2024-13-01"`, `"29/02/2024"`} {
+		if err := json.Unmarshal([]byte(bad), &back); err == nil {
+			t.Errorf("Unmarshal(%s) succeeded", bad)
+		}
+	}
+}
+
+func TestRecordFieldNames(t *testing.T) {
+	r := Sample(0)
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The embedded Employee is flattened, and an empty manager and
+	// skills are left out.
+	want := `{"id":1,"name":"Ada Lovelace","salary":3000,"hired":"2010-01-01"}`
+	if string(data) != want {
+		t.Errorf("Marshal(Sample(0)) =\n%s\nwant\n%s", data, want)
+	}
+}
+
+func TestGenerateStreamRoundTrip(t *testing.T) {
+	const n = 500
+	var buf bytes.Buffer
+	if err := Generate(&buf, n); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(buf.String(), "\n"); lines != n {
+		t.Fatalf("Generate wrote %d lines, want %d", lines, n)
+	}
+	i := 0
+	err := Stream(&buf, func(r Record) error {
+		if want := Sample(i); !reflect.DeepEqual(r, want) {
+			t.Fatalf("record %d = %+v, want %+v", i, r, want)
+		}
+		i++
+		return nil
+	})
+	if err != nil || i != n {
+		t.Errorf("Stream read %d records, err %v", i, err)
+	}
+}
+
+func TestStreamErrors(t *testing.T) {
+	good := `{"id":1,"name":"Ada","salary":1,"hired":"2020-01-01"}` + "\n"
+	tests := []struct {
+		name, in, want string
+	}{
+		{"unknown field", good + `{"id":2,"nickname":"x"}`, "record 2"},
+		{"bad date", good + good + `{"id":3,"hired":"soon"}`, "record 3"},
+		{"truncated", good + `{"id":2,`, "record 2"},
+	}
+	for _, tt := range tests {
+		n := 0
+		err := Stream(strings.NewReader(tt.in), func(Record) error { n++; return nil })
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: err = %v, want it to name %s", tt.name, err, tt.want)
+		}
+		if n != strings.Count(tt.in, good) {
+			t.Errorf("%s: fn saw %d records before the error", tt.name, n)
+		}
+	}
+
+	stop := errors.New("stop")
+	err := Stream(strings.NewReader(good+good), func(Record) error { return stop })
+	if err != stop {
+		t.Errorf("fn's error came back as %v", err)
+	}
+}
+
+func TestPatch(t *testing.T) {
+	r := Sample(13) // has a manager and skills
+	var p Patch
+	if err := json.Unmarshal([]byte(`{"salary": 9000, "manager": 0}`), &p); err != nil {
+		t.Fatal(err)
+	}
+	got := p.Apply(r)
+	want := r
+	want.Salary, want.Manager = 9000, 0
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Apply = %+v, want %+v", got, want)
+	}
+
+	data, _ := json.Marshal(Patch{Name: ptr.To("Ann")})
+	if string(data) != `{"name":"Ann"}` {
+		t.Errorf("Marshal(Patch) = %s; unset fields must be left out", data)
+	}
+}
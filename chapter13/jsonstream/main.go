@@ -0,0 +1,250 @@
+package jsonstream
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+
+	"learning-go/chapter7/employees"
+	"learning-go/ptr"
+	"learning-go/registry"
+)
+
+func init() {
+	// Register each exercise with the runner (cmd/learn)
+	registry.Register("chapter13/jsonstream", "exercise1", exercise1)
+	registry.Register("chapter13/jsonstream", "exercise2", exercise2)
+	registry.Register("chapter13/jsonstream", "exercise3", exercise3)
+	registry.Register("chapter13/jsonstream", "exercise4", exercise4)
+}
+
+// Exercise 1: Marshal and unmarshal chapter 7's Employee, whose struct
+// tags give its fields lower-case JSON names. Show what happens to unknown
+// and missing fields, and how DisallowUnknownFields makes the first an
+// error.
+func exercise1(w io.Writer) {
+	ada := employees.Employee{ID: 1, Name: "Ada", Salary: 5000}
+	data, err := json.Marshal(ada)
+	fmt.Fprintf(w, "json.Marshal:       %s %v\n", data, err)
+	indented, _ := json.MarshalIndent(ada, "", "  ")
+	fmt.Fprintf(w, "json.MarshalIndent:\n%s\n", indented)
+
+	inputs := []string{
+		`{"id": 2, "name": "Grace", "salary": 6000}`,
+		`{"ID": 3, "NAME": "Linus"}`,
+		`{"id": 4, "name": "Ken", "salary": 4000, "office": "B2"}`,
+		`{"id": "5"}`,
+	}
+	for _, in := range inputs {
+		var e employees.Employee
+		err := json.Unmarshal([]byte(in), &e)
+		fmt.Fprintf(w, "%s\n  Unmarshal: %+v %v\n", in, e, err)
+
+		dec := json.NewDecoder(strings.NewReader(in))
+		dec.DisallowUnknownFields()
+		e = employees.Employee{}
+		err = dec.Decode(&e)
+		fmt.Fprintf(w, "  strict:    %+v %v\n", e, err)
+	}
+
+	// Explanation:
+	// Marshal uses the name in each field's json tag, and only exported
+	// fields are encoded at all. Unmarshal matches keys to fields by tag,
+	// ignoring case, which is why "ID" and "NAME" still fill the struct. A
+	// key missing from the input leaves the field at its zero value, and a
+	// key with no matching field is silently dropped, so a typo in a
+	// config file goes unnoticed. A Decoder with DisallowUnknownFields
+	// reports it instead. A value of the wrong type is an error either way,
+	// reported as a *json.UnmarshalTypeError naming the field.
+}
+
+// Exercise 2: Give a Date type its own MarshalJSON and UnmarshalJSON so it
+// is encoded as "2006-01-02", then round-trip records containing it,
+// including one with a zero Date and one with an invalid date.
+func exercise2(w io.Writer) {
+	hired := NewDate(2015, time.March, 9)
+	asTime, _ := json.Marshal(hired.Time)
+	asDate, _ := json.Marshal(hired)
+	fmt.Fprintf(w, "time.Time: %s\nDate:      %s\n\n", asTime, asDate)
+
+	records := []Record{
+		Sample(0),
+		Sample(13),
+		{Employee: employees.Employee{ID: 99, Name: "New Hire"}},
+	}
+	for _, r := range records {
+		data, err := json.Marshal(r)
+		if err != nil {
+			fmt.Fprintln(w, "marshal:", err)
+			continue
+		}
+		var back Record
+		err = json.Unmarshal(data, &back)
+		fmt.Fprintf(w, "%s\n  round trip equal: %v %v\n", data, reflect.DeepEqual(r, back), err)
+	}
+
+	var r Record
+	err := json.Unmarshal([]byte(`{"id": 7, "hired": "2015-02-30"}`), &r)
+	fmt.Fprintln(w, "\ninvalid date:", err)
+	err = json.Unmarshal([]byte(`{"id": 7, "hired": 20150209}`), &r)
+	fmt.Fprintln(w, "date as number:", err)
+
+	// Explanation:
+	// json.Marshal checks whether a value implements json.Marshaler and, if
+	// so, uses its output verbatim; Unmarshal does the same with
+	// json.Unmarshaler. Date embeds time.Time, so it would otherwise
+	// inherit time.Time's methods and its long timestamp format. Defining
+	// the methods on Date overrides them. Note the receivers: MarshalJSON
+	// has a value receiver so both Date and *Date use it, while
+	// UnmarshalJSON needs a pointer to change the Date. The embedded
+	// Employee's fields appear at the top level of the object, manager
+	// and skills disappear when empty thanks to omitempty, and errors
+	// from UnmarshalJSON come back from json.Unmarshal unchanged.
+}
+
+// liveHeap returns the bytes of heap memory still in use after a garbage
+// collection, so garbage waiting to be collected is not counted.
+func liveHeap() uint64 {
+	runtime.GC()
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return m.HeapAlloc
+}
+
+// growth returns how much the live heap has grown since base.
+func growth(base uint64) uint64 {
+	if h := liveHeap(); h > base {
+		return h - base
+	}
+	return 0
+}
+
+// Exercise 3: Generate a multi-megabyte NDJSON file of employees, then
+// process it record by record with a json.Decoder, measuring how much
+// memory stays in use. Compare that with reading the whole file and
+// decoding every record into a slice.
+func exercise3(w io.Writer) {
+	const n = 100_000
+	f, err := os.CreateTemp("", "employees-*.ndjson")
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+	if err := Generate(f, n); err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	info, _ := f.Stat()
+	size := uint64(info.Size())
+	fmt.Fprintf(w, "wrote %d records, %d MiB\n", n, size>>20)
+
+	// Streaming: one record at a time, a running total per hiring year.
+	f.Seek(0, io.SeekStart)
+	base := liveHeap()
+	var peak uint64
+	count, byYear := 0, make(map[int]int)
+	err = Stream(f, func(r Record) error {
+		if !reflect.DeepEqual(r, Sample(count)) {
+			return fmt.Errorf("record %d did not round-trip", count+1)
+		}
+		count++
+		byYear[r.Hired.Year()]++
+		if count%20_000 == 0 {
+			peak = max(peak, growth(base))
+		}
+		return nil
+	})
+	fmt.Fprintf(w, "streamed %d records, %d hired in 2010, error: %v\n", count, byYear[2010], err)
+	fmt.Fprintf(w, "  memory in use stayed under 1 MiB: %v\n", peak < 1<<20)
+
+	// Everything at once: the whole file, then every record.
+	f.Seek(0, io.SeekStart)
+	base = liveHeap()
+	data, _ := io.ReadAll(f)
+	all := make([]Record, 0, n)
+	var errAll error
+	for _, line := range bytes.Split(bytes.TrimSpace(data), []byte("\n")) {
+		var r Record
+		if errAll = json.Unmarshal(line, &r); errAll != nil {
+			break
+		}
+		all = append(all, r)
+	}
+	used := growth(base)
+	fmt.Fprintf(w, "loaded %d records at once, error: %v\n", len(all), errAll)
+	fmt.Fprintf(w, "  memory in use exceeded the file size: %v\n", used > size)
+	runtime.KeepAlive(data)
+	runtime.KeepAlive(all)
+
+	// A corrupt line is reported with its position.
+	bad := `{"id": 1, "name": "Ada", "hired": "2015-03-09"}` + "\n" + `{"id": 2, "name": "Grace" "hired": "2015-03-09"}` + "\n"
+	err = Stream(strings.NewReader(bad), func(Record) error { return nil })
+	fmt.Fprintln(w, "corrupt input:", err)
+	var syntax *json.SyntaxError
+	fmt.Fprintln(w, "  is a *json.SyntaxError:", errors.As(err, &syntax))
+
+	// Explanation:
+	// A json.Decoder reads from an io.Reader through a small buffer and
+	// decodes one value per Decode call, so the memory it needs depends on
+	// the size of one record, not of the file. io.ReadAll followed by
+	// decoding every line holds both the raw bytes and every decoded
+	// record at once, more than the file's size. NDJSON suits streaming
+	// because every line is a complete value; a single top-level array
+	// can be streamed too, with dec.Token to step past the opening
+	// bracket and dec.More to loop over the elements. Stream wraps decode
+	// errors with the record number and InputOffset so a bad line in a
+	// large file can be found.
+}
+
+// Exercise 4: Apply partial updates, decoded into a Patch whose fields are
+// all pointers, to a sample record. Show that a missing field and a field
+// set to its zero value are different, and build a Patch in code with
+// ptr.To.
+func exercise4(w io.Writer) {
+	r := Sample(23)
+	fmt.Fprintf(w, "before: %s, salary %d, manager %d, skills %q\n", r.Name, r.Salary, r.Manager, r.Skills)
+
+	inputs := []string{
+		`{"salary": 6500}`,
+		`{"manager": 0, "skills": []}`,
+		`{}`,
+	}
+	for _, in := range inputs {
+		var p Patch
+		if err := json.Unmarshal([]byte(in), &p); err != nil {
+			fmt.Fprintln(w, err)
+			continue
+		}
+		r = p.Apply(r)
+		fmt.Fprintf(w, "%-28s salary %d, manager %d, skills %q\n", in, r.Salary, r.Manager, r.Skills)
+	}
+
+	// &"Grace Hopper" and &6000 do not compile; ptr.To takes the address
+	// of a copy.
+	p := Patch{Name: ptr.To("Grace Hopper"), Salary: ptr.To(7000)}
+	data, _ := json.Marshal(p)
+	fmt.Fprintf(w, "built in code: %s\n", data)
+	fmt.Fprintf(w, "  name %q, manager %d (-1 for unset)\n",
+		ptr.Deref(p.Name, "(unchanged)"), ptr.Deref(p.Manager, -1))
+	r = p.Apply(r)
+	fmt.Fprintf(w, "after: %s, salary %d\n", r.Name, r.Salary)
+
+	// Explanation:
+	// With plain int and []string fields, {"manager": 0} and {} would
+	// decode to the same struct, and an update could not remove a manager
+	// or clear the skills. A pointer field is nil when the key is missing
+	// and points to the decoded value, zero or not, when it is present,
+	// so Apply can replace exactly the fields the input mentioned. Going
+	// the other way, omitempty leaves out nil pointers but keeps pointers
+	// to zero values. ptr.To and ptr.Deref are the two helpers that make
+	// such structs bearable to fill in and read back.
+}
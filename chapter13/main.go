@@ -0,0 +1,278 @@
+package chapter13
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing/fstest"
+
+	"learning-go/registry"
+)
+
+func init() {
+	// Register each exercise with the runner (cmd/learn)
+	registry.Register("chapter13", "exercise1", exercise1)
+	registry.Register("chapter13", "exercise2", exercise2)
+	registry.Register("chapter13", "exercise3", exercise3)
+	registry.Register("chapter13", "exercise4", exercise4)
+}
+
+// Exercise 1: Compare package path with package path/filepath on the same
+// inputs, including Windows-style backslash paths, and print the results
+// side by side.
+func exercise1(w io.Writer) {
+	fmt.Fprintf(w, "filepath.Separator on this system: %q\n\n", filepath.Separator)
+
+	inputs := []string{
+		"docs/chapter13/../chapter12/main.go",
+		`docs\chapter13\..\chapter12\main.go`,
+		"/usr/local//go/",
+	}
+	for _, in := range inputs {
+		fmt.Fprintf(w, "input %s\n", in)
+		fmt.Fprintf(w, "  path.Clean     %s\n", path.Clean(in))
+		fmt.Fprintf(w, "  filepath.Clean %s\n", filepath.Clean(in))
+		fmt.Fprintf(w, "  path.Base      %s\n", path.Base(in))
+		fmt.Fprintf(w, "  filepath.Base  %s\n", filepath.Base(in))
+	}
+
+	fmt.Fprintln(w)
+	native := filepath.Join("chapter13", "testdata", "notes.txt")
+	fmt.Fprintf(w, "filepath.Join:           %q\n", native)
+	fmt.Fprintf(w, "filepath.ToSlash:        %q\n", filepath.ToSlash(native))
+	fmt.Fprintf(w, "filepath.FromSlash(a/b): %q\n", filepath.FromSlash("a/b"))
+
+	// Explanation:
+	// Package path always uses forward slashes. It is for slash-separated
+	// paths that are not file system paths of the host: URLs, io/fs names,
+	// archive entries. Package filepath uses the host's separator, so on
+	// Unix a backslash is just another character in a file name (the
+	// second input stays one long element), while on Windows filepath
+	// would split it and clean the ".." away; package path never does.
+	// Use filepath for anything passed to os, path for everything else,
+	// and ToSlash/FromSlash to cross between the two.
+}
+
+// sampleFS is an in-memory file system. io/fs names always use forward
+// slashes, whatever the host, so code written against fs.FS behaves the
+// same on every platform.
+var sampleFS = fstest.MapFS{
+	"chapter3/main.go":                  {Data: []byte("package main")},
+	"chapter3/chapter-03-summary.md":    {Data: []byte("# Chapter 3")},
+	"chapter12/main.go":                 {Data: []byte("package main")},
+	"chapter12/rpc/main.go":             {Data: []byte("package main")},
+	"chapter12/testdata/golden.txt":     {Data: []byte("42")},
+	"chapter12/chapter-12-summary.md":   {Data: []byte("# Chapter 12")},
+	"vendor/example.com/lib/lib.go":     {Data: []byte("package lib")},
+	"README.md":                         {Data: []byte("# Learning Go")},
+	"chapter12/selectfairness/main.go":  {Data: []byte("package main")},
+	"chapter12/selectfairness/notes.md": {Data: []byte("notes")},
+}
+
+// Exercise 2: Walk a directory tree with fs.WalkDir, skipping vendor and
+// testdata directories, and list the Go files found. Then do the same on a
+// real directory with filepath.WalkDir.
+func exercise2(w io.Writer) {
+	var found []string
+	err := fs.WalkDir(sampleFS, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && (d.Name() == "vendor" || d.Name() == "testdata") {
+			return fs.SkipDir
+		}
+		if !d.IsDir() && path.Ext(p) == ".go" {
+			found = append(found, p)
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintln(w, "walk:", err)
+		return
+	}
+	fmt.Fprintln(w, "Go files in the in-memory tree:")
+	for _, p := range found {
+		fmt.Fprintln(w, "  "+p)
+	}
+
+	dir, err := os.MkdirTemp("", "walk-")
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	for name, f := range sampleFS {
+		// MapFS names are slash-separated; FromSlash makes them native.
+		full := filepath.Join(dir, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(full), 0o755)
+		os.WriteFile(full, f.Data, 0o644)
+	}
+
+	var onDisk []string
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && (d.Name() == "vendor" || d.Name() == "testdata") {
+			return filepath.SkipDir
+		}
+		if !d.IsDir() && filepath.Ext(p) == ".go" {
+			rel, _ := filepath.Rel(dir, p)
+			onDisk = append(onDisk, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintln(w, "walk:", err)
+		return
+	}
+	fmt.Fprintf(w, "same files on disk: %v\n", strings.Join(onDisk, ", ") == strings.Join(found, ", "))
+
+	// Explanation:
+	// fs.WalkDir and filepath.WalkDir visit entries in lexical order and
+	// pass a DirEntry, which answers IsDir without an extra stat call per
+	// file (the older filepath.Walk stats everything). Returning SkipDir
+	// from a directory skips its contents. The fs version works on any
+	// fs.FS (embedded files, zip archives, test fixtures) with slash paths;
+	// the filepath version works on the host's disk with native paths, so
+	// Rel followed by ToSlash is needed to compare the two.
+}
+
+// Exercise 3: Match files with glob patterns, using fs.Glob on the
+// in-memory tree and path.Match on single names, and show what * and **
+// do and do not match.
+func exercise3(w io.Writer) {
+	patterns := []string{"*.md", "chapter12/*.go", "chapter*/main.go", "chapter12/*/*.go", "chapter1?/main.go"}
+	for _, pattern := range patterns {
+		matches, err := fs.Glob(sampleFS, pattern)
+		if err != nil {
+			fmt.Fprintln(w, pattern, err)
+			continue
+		}
+		fmt.Fprintf(w, "%-20s %v\n", pattern, matches)
+	}
+
+	fmt.Fprintln(w)
+	names := []struct{ pattern, name string }{
+		{"*.go", "main.go"},
+		{"*.go", "rpc/main.go"},
+		{"**/*.go", "chapter12/rpc/main.go"},
+		{"*/*/*.go", "chapter12/rpc/main.go"},
+		{"[a-z]*.md", "README.md"},
+		{"[^a-z]*.md", "README.md"},
+	}
+	for _, n := range names {
+		ok, err := path.Match(n.pattern, n.name)
+		fmt.Fprintf(w, "path.Match(%q, %q) = %v %v\n", n.pattern, n.name, ok, errOrEmpty(err))
+	}
+
+	_, err := path.Match("[", "x")
+	fmt.Fprintf(w, "a malformed pattern reports %v\n", err)
+
+	// Explanation:
+	// A * matches any run of characters except the separator, so it never
+	// crosses into a subdirectory, and ** is not special: it is just two
+	// stars, matching exactly one path element like one star does. To
+	// match at any depth, walk the tree and call path.Match on each name.
+	// Character classes negate with ^, not the shell's !, and matching is
+	// case sensitive. Only a malformed pattern is an error; a pattern that
+	// matches nothing just returns no results.
+}
+
+func errOrEmpty(err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+// ErrEscapes is returned by SafeJoin for names that would leave the root.
+var ErrEscapes = errors.New("path escapes the root directory")
+
+// SafeJoin joins a user-supplied name onto root and returns an error if
+// the result would not be inside root. Both slash styles are treated as
+// separators on every platform, so a name that is rejected on Windows is
+// rejected on Unix too, and absolute paths and ".." escapes are refused.
+func SafeJoin(root, name string) (string, error) {
+	slashed := strings.ReplaceAll(name, `\`, "/")
+	if strings.HasPrefix(slashed, "/") || filepath.VolumeName(name) != "" || strings.Contains(slashed, ":") {
+		return "", fmt.Errorf("%q: %w", name, ErrEscapes)
+	}
+	cleaned := path.Clean(slashed)
+	if !fs.ValidPath(cleaned) {
+		return "", fmt.Errorf("%q: %w", name, ErrEscapes)
+	}
+	return filepath.Join(root, filepath.FromSlash(cleaned)), nil
+}
+
+// Exercise 4: Write SafeJoin, which joins an uploaded file name onto a
+// root directory and rejects names that would escape it. Check it against
+// names in both slash styles and confirm every accepted path can be
+// opened through an os.DirFS of the root.
+func exercise4(w io.Writer) {
+	root, err := os.MkdirTemp("", "uploads-")
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	defer os.RemoveAll(root)
+
+	cases := []struct {
+		name string
+		ok   bool
+	}{
+		{"notes.txt", true},
+		{"chapter3/notes.txt", true},
+		{`chapter3\notes.txt`, true},
+		{"chapter3/../notes.txt", true},
+		{"../secret.txt", false},
+		{`..\secret.txt`, false},
+		{"chapter3/../../secret.txt", false},
+		{`chapter3\..\..\secret.txt`, false},
+		{"/etc/passwd", false},
+		{`C:\Windows\win.ini`, false},
+		{`\\server\share\file`, false},
+	}
+
+	root, _ = filepath.EvalSymlinks(root)
+	fsys := os.DirFS(root)
+	passed := 0
+	for _, c := range cases {
+		joined, err := SafeJoin(root, c.name)
+		status := "ok  "
+		if (err == nil) != c.ok {
+			status = "FAIL"
+		} else {
+			passed++
+		}
+		if err != nil {
+			fmt.Fprintf(w, "%s %-26s rejected: %v\n", status, c.name, errors.Is(err, ErrEscapes))
+			continue
+		}
+
+		// Create the file, then open it through the fs.FS using the
+		// slash-separated name relative to the root.
+		os.MkdirAll(filepath.Dir(joined), 0o755)
+		os.WriteFile(joined, []byte("hi"), 0o644)
+		rel, _ := filepath.Rel(root, joined)
+		_, openErr := fs.ReadFile(fsys, filepath.ToSlash(rel))
+		fmt.Fprintf(w, "%s %-26s -> %s (readable via fs.FS: %v)\n", status, c.name, filepath.ToSlash(rel), openErr == nil)
+	}
+	fmt.Fprintf(w, "%d/%d cases behaved as expected\n", passed, len(cases))
+
+	// Explanation:
+	// filepath.Join cleans its result, which resolves ".." instead of
+	// stopping it: Join("/uploads", "../secret.txt") is "/secret.txt".
+	// Cleaning the name first and then checking it with fs.ValidPath
+	// rejects anything that still starts with "..", is absolute, or is
+	// empty. Converting backslashes first matters on Unix, where a name
+	// like `..\..\secret` would otherwise be one harmless-looking element
+	// that becomes an escape as soon as the same code runs on Windows.
+	// Since Go 1.24, os.Root does this at the file system level and also
+	// refuses symlinks that point outside the root.
+}
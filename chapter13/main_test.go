@@ -0,0 +1,82 @@
+package chapter13
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestSafeJoin(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "uploads")
+	tests := []struct {
+		name string
+		want string // slash-separated, relative to root; "" if rejected
+	}{
+		{"notes.txt", "notes.txt"},
+		{"chapter3/notes.txt", "chapter3/notes.txt"},
+		{`chapter3\notes.txt`, "chapter3/notes.txt"},
+		{"chapter3/./notes.txt", "chapter3/notes.txt"},
+		{"chapter3/../notes.txt", "notes.txt"},
+		{`chapter3\..\notes.txt`, "notes.txt"},
+		{"../secret.txt", ""},
+		{`..\secret.txt`, ""},
+		{"chapter3/../../secret.txt", ""},
+		{`chapter3\..\..\secret.txt`, ""},
+		{"/etc/passwd", ""},
+		{`\etc\passwd`, ""},
+		{`C:\Windows\win.ini`, ""},
+		{"C:notes.txt", ""},
+		{`\\server\share\file`, ""},
+	}
+	uploads := fstest.MapFS{}
+	for _, tt := range tests {
+		got, err := SafeJoin(root, tt.name)
+		if tt.want == "" {
+			if !errors.Is(err, ErrEscapes) {
+				t.Errorf("SafeJoin(%q) = %q, %v; want ErrEscapes", tt.name, got, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("SafeJoin(%q) = %v", tt.name, err)
+			continue
+		}
+		rel, err := filepath.Rel(root, got)
+		if err != nil || filepath.ToSlash(rel) != tt.want {
+			t.Errorf("SafeJoin(%q) = %q, want %s under the root", tt.name, got, tt.want)
+			continue
+		}
+		uploads[filepath.ToSlash(rel)] = &fstest.MapFile{Data: []byte(tt.name)}
+	}
+
+	// Every accepted name is a valid io/fs path, whichever slashes it was
+	// written with, so it can be opened through an fs.FS of the root.
+	for name := range uploads {
+		if !fs.ValidPath(name) {
+			t.Errorf("%q is not a valid io/fs path", name)
+		}
+		if _, err := fs.ReadFile(uploads, name); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestSampleFS(t *testing.T) {
+	if err := fstest.TestFS(sampleFS, "chapter12/rpc/main.go", "README.md"); err != nil {
+		t.Fatal(err)
+	}
+	matches, err := fs.Glob(sampleFS, "chapter12/*/main.go")
+	if want := []string{"chapter12/rpc/main.go", "chapter12/selectfairness/main.go"}; err != nil || !slices.Equal(matches, want) {
+		t.Errorf("Glob = %q, %v; want %q", matches, err, want)
+	}
+	// io/fs names never contain backslashes, on any platform.
+	for name := range sampleFS {
+		if strings.Contains(name, `\`) || !fs.ValidPath(name) {
+			t.Errorf("sampleFS holds %q, not a valid io/fs path", name)
+		}
+	}
+}
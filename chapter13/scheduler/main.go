@@ -0,0 +1,269 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"learning-go/clock"
+	"learning-go/registry"
+	"learning-go/testutil/leak"
+)
+
+func init() {
+	// Register each exercise with the runner (cmd/learn)
+	registry.Register("chapter13/scheduler", "exercise1", exercise1)
+	registry.Register("chapter13/scheduler", "exercise2", exercise2)
+	registry.Register("chapter13/scheduler", "exercise3", exercise3)
+}
+
+// produce sends n events on a new channel, one every gap, and closes it,
+// or stops early once done is closed.
+func produce(n int, gap time.Duration, done <-chan struct{}) <-chan int {
+	events := make(chan int)
+	go func() {
+		defer close(events)
+		for i := range n {
+			time.Sleep(gap)
+			select {
+			case events <- i:
+			case <-done:
+				return
+			}
+		}
+	}()
+	return events
+}
+
+// timeoutPerMessage reads events until they end or timeout passes. It
+// calls time.After in the loop, so every message starts a new timeout.
+func timeoutPerMessage(events <-chan int, timeout time.Duration) (received int, timedOut bool) {
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				return received, false
+			}
+			received++
+		case <-time.After(timeout):
+			return received, true
+		}
+	}
+}
+
+// timeoutOverall is timeoutPerMessage with one timer made before the loop,
+// so timeout bounds the whole read.
+func timeoutOverall(events <-chan int, timeout time.Duration) (received int, timedOut bool) {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				return received, false
+			}
+			received++
+		case <-deadline.C:
+			return received, true
+		}
+	}
+}
+
+// Exercise 1: Stop and Reset a time.Timer, then read 20 messages sent 10ms
+// apart with a 100ms timeout written two ways: time.After inside the loop,
+// and one timer made before it. Finally let a ticker run on a fake clock
+// while nobody reads it.
+func exercise1(w io.Writer) {
+	t := time.NewTimer(time.Hour)
+	fmt.Fprintln(w, "Stop before it fired:", t.Stop())
+	t.Reset(time.Millisecond)
+	<-t.C
+	fmt.Fprintln(w, "fired after Reset")
+	fmt.Fprintln(w, "Stop after it fired:", t.Stop())
+
+	const n = 20
+	for _, read := range []struct {
+		name string
+		fn   func(<-chan int, time.Duration) (int, bool)
+	}{
+		{"time.After in the loop", timeoutPerMessage},
+		{"one timer before the loop", timeoutOverall},
+	} {
+		done := make(chan struct{})
+		received, timedOut := read.fn(produce(n, 10*time.Millisecond, done), 100*time.Millisecond)
+		close(done)
+		fmt.Fprintf(w, "%s: read all %d: %t, timed out: %t\n", read.name, n, received == n, timedOut)
+	}
+
+	c := clock.NewFake(time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC))
+	ticker := c.NewTicker(time.Second)
+	c.Advance(5 * time.Second)
+	ticks := 0
+	for drained := false; !drained; {
+		select {
+		case <-ticker.C():
+			ticks++
+		default:
+			drained = true
+		}
+	}
+	ticker.Stop()
+	fmt.Fprintln(w, "ticks waiting after 5s unread:", ticks)
+
+	// Explanation:
+	// Stop reports whether it stopped the timer before it fired, and Reset
+	// rearms it, so one Timer can serve many waits. time.After is a Timer
+	// too, made fresh on every call: inside a select in a loop, each
+	// message starts a new 100ms wait, and the 10ms gaps never let one
+	// finish. It is an idle timeout, which is right for "hang up if the
+	// peer goes quiet" and wrong for "give up after 100ms". A single timer
+	// made before the loop bounds the whole read. Before Go 1.23, every
+	// pending time.After also held its timer until it fired; timers are now
+	// collected once nothing refers to them, but the meaning still changes.
+	// A Ticker's channel holds one tick: a receiver that falls behind gets
+	// one tick, not a burst of five to catch up, and a job driven by a
+	// ticker runs less often rather than piling up.
+}
+
+// Exercise 2: Look at the monotonic clock reading time.Now carries, see
+// which operations keep it and which strip it, and compare times with ==
+// and Equal.
+func exercise2(w io.Writer) {
+	now := time.Now()
+	fmt.Fprintln(w, "time.Now has a monotonic reading:", strings.Contains(now.String(), "m="))
+	wall := now.Round(0)
+	fmt.Fprintln(w, "after Round(0):", strings.Contains(wall.String(), "m="))
+	fmt.Fprintln(w, "after Add:", strings.Contains(now.Add(time.Hour).String(), "m="))
+
+	data, _ := json.Marshal(now)
+	var decoded time.Time
+	_ = json.Unmarshal(data, &decoded)
+	fmt.Fprintln(w, "after a JSON round trip:", strings.Contains(decoded.String(), "m="))
+	fmt.Fprintln(w, "now == decoded:", now == decoded)
+	fmt.Fprintln(w, "now.Equal(decoded):", now.Equal(decoded))
+	fmt.Fprintln(w, "now == now.UTC():", now == now.UTC())
+	fmt.Fprintln(w, "now.Equal(now.UTC()):", now.Equal(now.UTC()))
+	fmt.Fprintln(w, "time.Since(now) >= 0:", time.Since(now) >= 0)
+
+	// Explanation:
+	// A Time from time.Now holds two readings: the wall clock, which is the
+	// date and time of day and can jump when NTP corrects it or someone
+	// changes the system time, and a monotonic clock, which only moves
+	// forward and means nothing outside this process. Sub, Since, Until,
+	// Before and After use the monotonic readings when both times have one,
+	// so an elapsed time can never come out negative, even if the wall
+	// clock was set back an hour in between. Add keeps the reading; Round(0)
+	// strips it on purpose, and so does everything that leaves the process,
+	// such as JSON or a database. == compares the whole struct, monotonic
+	// reading and Location included, so it is false for the same instant
+	// decoded from JSON or shown in UTC. Compare times with Equal, and do
+	// not use them as map keys without Round(0) and a fixed Location. The
+	// scheduler in this package works on wall-clock times, since "06:30
+	// every day" is a wall-clock idea, and waits on durations computed from
+	// them.
+}
+
+var errBackupFull = errors.New("backup: disk full")
+
+// Exercise 3: Run a Scheduler on a fake clock from 05:50 to 06:40, with a
+// job every 10 minutes, a daily report at 06:30, a backup that runs twice
+// and fails the second time, a cleanup that panics, and an import every 15
+// minutes whose first run takes 22 minutes, then cancel it.
+func exercise3(w io.Writer) {
+	snap := leak.Take()
+	start := time.Date(2024, time.March, 4, 5, 50, 0, 0, time.UTC)
+	c := clock.NewFake(start)
+	s := New(WithClock(c))
+
+	release := make(chan struct{})
+	backups, imports := 0, 0
+	s.Every("flush", 10*time.Minute, func(ctx context.Context) error { return nil })
+	s.Schedule("report", Daily(6, 30), func(ctx context.Context) error { return nil })
+	s.Schedule("backup", Times(2, Interval(15*time.Minute)), func(ctx context.Context) error {
+		if backups++; backups == 2 {
+			return errBackupFull
+		}
+		return nil
+	})
+	s.Schedule("cleanup", Times(1, Daily(6, 15)), func(ctx context.Context) error {
+		var cache map[string]int
+		cache["stale"] = 0 // assignment to entry in nil map
+		return nil
+	})
+	s.Every("import", 15*time.Minute, func(ctx context.Context) error {
+		if imports++; imports == 1 {
+			<-release
+		}
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+	go func() { runErr <- s.Run(ctx) }()
+
+	// Move the clock from one moment something happens to the next, once
+	// the scheduler is waiting again, and read the results of the runs due
+	// then before moving on, so the clock never moves while a quick run is
+	// going and every run starts exactly when it is due.
+	var results []Result
+	for _, step := range []struct {
+		hour, minute int
+		results      int
+	}{
+		{6, 0, 1},  // flush
+		{6, 5, 1},  // backup; import starts and waits for release
+		{6, 10, 1}, // flush
+		{6, 15, 1}, // cleanup
+		{6, 20, 3}, // backup, flush, import skipped
+		{6, 27, 1}, // import, released
+		{6, 30, 2}, // flush, report
+		{6, 35, 1}, // import
+		{6, 40, 1}, // flush
+	} {
+		at := time.Date(2024, time.March, 4, step.hour, step.minute, 0, 0, time.UTC)
+		c.BlockUntil(1)
+		c.Set(at)
+		if step.hour == 6 && step.minute == 27 {
+			close(release)
+		}
+		for range step.results {
+			results = append(results, <-s.Results())
+		}
+	}
+	cancel()
+	err := <-runErr
+	for r := range s.Results() {
+		results = append(results, r)
+	}
+
+	slices.SortFunc(results, func(a, b Result) int {
+		if n := a.Start.Compare(b.Start); n != 0 {
+			return n
+		}
+		return strings.Compare(a.Job, b.Job)
+	})
+	for _, r := range results {
+		fmt.Fprintln(w, r)
+	}
+	fmt.Fprintln(w, "Run returned:", err)
+	fmt.Fprintln(w, "goroutines left:", len(snap.Leaked(leak.Timeout)))
+
+	// Explanation:
+	// Run keeps one wait going, on clock.After, for whichever job is due
+	// first, and starts every due job on its own goroutine when it wakes, so
+	// a slow job never delays the others. The import started at 06:05 was
+	// still running at 06:20, so that run was skipped and reported rather
+	// than started a second time; running the same import twice at once is
+	// rarely what anyone wants. The cleanup's panic came back as an error
+	// through safe.SafeCall instead of taking the scheduler down, and the
+	// backup stopped after two runs because Times returned the zero time.
+	// Cancelling ctx stopped Run, which waited for the runs in progress and
+	// closed Results. The fake clock made fifty minutes take a moment and
+	// print the same lines every time; results are sorted because runs due
+	// at the same minute report in any order.
+}
@@ -0,0 +1,275 @@
+// Package scheduler runs jobs on a schedule, like a small cron inside the
+// program. A job runs every fixed interval or at the times a next-run
+// function returns, and every run is reported on a results channel:
+//
+//	s := scheduler.New()
+//	s.Every("flush", time.Minute, flush)
+//	s.Schedule("report", scheduler.Daily(6, 30), report)
+//	go func() {
+//		for r := range s.Results() {
+//			log.Println(r)
+//		}
+//	}()
+//	err := s.Run(ctx) // until ctx is cancelled
+//
+// The scheduler reads the time through a clock.Clock, so code using it can
+// be tested with a *clock.Fake: a day of schedules runs in a moment.
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"learning-go/clock"
+	"learning-go/safe"
+)
+
+// ErrRunning is returned by Every and Schedule once Run has been called,
+// and by a second call to Run.
+var ErrRunning = errors.New("scheduler: already running")
+
+// NextFunc returns when a job should run next, given when it last ran (or
+// when Run started, for the first run). It must return a time after last,
+// or the zero time to stop scheduling the job.
+type NextFunc func(last time.Time) time.Time
+
+// Interval returns a NextFunc that runs a job every d, starting d after Run
+// starts.
+func Interval(d time.Duration) NextFunc {
+	return func(last time.Time) time.Time { return last.Add(d) }
+}
+
+// Daily returns a NextFunc that runs a job every day at hour:minute, in the
+// location of the times it is given.
+func Daily(hour, minute int) NextFunc {
+	return func(last time.Time) time.Time {
+		y, m, d := last.Date()
+		next := time.Date(y, m, d, hour, minute, 0, 0, last.Location())
+		if !next.After(last) {
+			next = time.Date(y, m, d+1, hour, minute, 0, 0, last.Location())
+		}
+		return next
+	}
+}
+
+// Times returns a NextFunc that runs a job at most n times, as next says.
+func Times(n int, next NextFunc) NextFunc {
+	return func(last time.Time) time.Time {
+		if n <= 0 {
+			return time.Time{}
+		}
+		n--
+		return next(last)
+	}
+}
+
+// Result describes one run of a job.
+type Result struct {
+	Job string
+	// Due is when the run was scheduled, and Start when it began; they
+	// differ if the scheduler woke up late.
+	Due, Start time.Time
+	Duration   time.Duration
+	Err        error
+	// Skipped is set, and the job was not run, if the previous run was
+	// still going at Due.
+	Skipped bool
+}
+
+func (r Result) String() string {
+	switch {
+	case r.Skipped:
+		return fmt.Sprintf("%s: skipped at %s, still running", r.Job, r.Due.Format(time.TimeOnly))
+	case r.Err != nil:
+		return fmt.Sprintf("%s: failed at %s after %v: %v", r.Job, r.Start.Format(time.TimeOnly), r.Duration, r.Err)
+	}
+	return fmt.Sprintf("%s: ran at %s in %v", r.Job, r.Start.Format(time.TimeOnly), r.Duration)
+}
+
+// Option configures a Scheduler.
+type Option func(*options)
+
+type options struct {
+	clock  clock.Clock
+	buffer int
+}
+
+// WithClock sets the clock the scheduler waits on. The default is
+// clock.Real.
+func WithClock(c clock.Clock) Option {
+	return func(o *options) { o.clock = c }
+}
+
+// WithBuffer sets the capacity of the results channel. Reporting a run
+// waits while the channel is full, so read Results promptly or make the
+// buffer large. The default is 16.
+func WithBuffer(n int) Option {
+	return func(o *options) { o.buffer = max(n, 0) }
+}
+
+func newOptions(opts []Option) options {
+	o := options{clock: clock.Real, buffer: 16}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+type job struct {
+	name    string
+	next    NextFunc
+	fn      func(ctx context.Context) error
+	due     time.Time // zero once the job has no more runs
+	running bool      // guarded by Scheduler.mu
+}
+
+// Scheduler runs jobs on their schedules. Register jobs with Every and
+// Schedule, then call Run.
+type Scheduler struct {
+	opts    options
+	results chan Result
+
+	mu      sync.Mutex
+	jobs    []*job
+	started bool
+}
+
+// New returns a Scheduler with no jobs.
+func New(opts ...Option) *Scheduler {
+	o := newOptions(opts)
+	return &Scheduler{opts: o, results: make(chan Result, o.buffer)}
+}
+
+// Results returns the channel every run is reported on. It is closed when
+// Run returns.
+func (s *Scheduler) Results() <-chan Result {
+	return s.results
+}
+
+// Every registers fn to run every d.
+func (s *Scheduler) Every(name string, d time.Duration, fn func(ctx context.Context) error) error {
+	if d <= 0 {
+		return fmt.Errorf("scheduler: job %s: interval %v is not positive", name, d)
+	}
+	return s.Schedule(name, Interval(d), fn)
+}
+
+// Schedule registers fn to run at the times next returns.
+func (s *Scheduler) Schedule(name string, next NextFunc, fn func(ctx context.Context) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started {
+		return ErrRunning
+	}
+	s.jobs = append(s.jobs, &job{name: name, next: next, fn: fn})
+	return nil
+}
+
+// Run runs the jobs until ctx is cancelled or no job has a run left. Each
+// run gets its own goroutine and a context that is cancelled when Run is
+// stopping; Run waits for the runs in progress, closes Results and returns
+// ctx's error, or nil if the jobs ran out.
+//
+// A run that is still going when its job is due again is not doubled up:
+// the new run is skipped and reported as Skipped. Runs missed because the
+// scheduler was late, such as after the machine slept, are not made up.
+func (s *Scheduler) Run(ctx context.Context) error {
+	s.mu.Lock()
+	if s.started {
+		s.mu.Unlock()
+		return ErrRunning
+	}
+	s.started = true
+	now := s.opts.clock.Now()
+	for _, j := range s.jobs {
+		j.due = j.next(now)
+	}
+	s.mu.Unlock()
+
+	var wg sync.WaitGroup
+	defer close(s.results)
+	defer wg.Wait()
+
+	for {
+		next := s.earliest()
+		if next == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.opts.clock.After(next.due.Sub(s.opts.clock.Now())):
+		}
+
+		now := s.opts.clock.Now()
+		for _, j := range s.jobs {
+			if j.due.IsZero() || j.due.After(now) {
+				continue
+			}
+			s.start(ctx, &wg, j, now)
+			// Skip the runs that were missed while the scheduler was late.
+			due := j.next(j.due)
+			for !due.IsZero() && !due.After(now) {
+				due = j.next(due)
+			}
+			j.due = due
+		}
+	}
+}
+
+// earliest returns the job due first, or nil if no job has a run left.
+func (s *Scheduler) earliest() *job {
+	var first *job
+	for _, j := range s.jobs {
+		if !j.due.IsZero() && (first == nil || j.due.Before(first.due)) {
+			first = j
+		}
+	}
+	return first
+}
+
+// start runs j on a new goroutine, unless its previous run is still going.
+func (s *Scheduler) start(ctx context.Context, wg *sync.WaitGroup, j *job, now time.Time) {
+	r := Result{Job: j.name, Due: j.due, Start: now}
+	s.mu.Lock()
+	busy := j.running
+	j.running = true
+	s.mu.Unlock()
+	if busy {
+		r.Skipped = true
+		s.report(ctx, r)
+		return
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// A panicking job fails its run instead of the program.
+		r.Err = safe.SafeCall(func() error { return j.fn(ctx) })
+		r.Duration = s.opts.clock.Since(r.Start)
+		s.mu.Lock()
+		j.running = false
+		s.mu.Unlock()
+		s.report(ctx, r)
+	}()
+}
+
+// report sends r on the results channel, giving up if ctx is cancelled
+// while the channel is full.
+func (s *Scheduler) report(ctx context.Context, r Result) {
+	// A select picks at random among ready cases, so when there is room
+	// and ctx is already cancelled, the first select makes sure the result
+	// is still delivered.
+	select {
+	case s.results <- r:
+		return
+	default:
+	}
+	select {
+	case s.results <- r:
+	case <-ctx.Done():
+	}
+}
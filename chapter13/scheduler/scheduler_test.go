@@ -0,0 +1,229 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"learning-go/clock"
+)
+
+var epoch = time.Date(2024, time.March, 4, 6, 0, 0, 0, time.UTC)
+
+func at(hour, minute int) time.Time {
+	return time.Date(2024, time.March, 4, hour, minute, 0, 0, time.UTC)
+}
+
+func TestNextFuncs(t *testing.T) {
+	tests := []struct {
+		name       string
+		next       NextFunc
+		last, want time.Time
+	}{
+		{"Interval", Interval(90 * time.Second), epoch, epoch.Add(90 * time.Second)},
+		{"Daily later today", Daily(6, 30), at(6, 0), at(6, 30)},
+		{"Daily already passed", Daily(6, 30), at(7, 0), at(6, 30).AddDate(0, 0, 1)},
+		{"Daily exactly now", Daily(6, 30), at(6, 30), at(6, 30).AddDate(0, 0, 1)},
+		{"Daily across a month", Daily(1, 0), time.Date(2024, time.January, 31, 2, 0, 0, 0, time.UTC),
+			time.Date(2024, time.February, 1, 1, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		if got := tt.next(tt.last); !got.Equal(tt.want) {
+			t.Errorf("%s(%v) = %v, want %v", tt.name, tt.last, got, tt.want)
+		}
+	}
+
+	twice := Times(2, Interval(time.Minute))
+	for i, want := range []time.Time{epoch.Add(time.Minute), epoch.Add(time.Minute), {}} {
+		if got := twice(epoch); !got.Equal(want) {
+			t.Errorf("Times(2) call %d = %v, want %v", i+1, got, want)
+		}
+	}
+}
+
+// running is a Scheduler whose Run was started on a fake clock.
+type running struct {
+	*Scheduler
+	clock  *clock.Fake
+	cancel context.CancelFunc
+	done   chan struct{} // closed when Run has returned err
+	err    error
+}
+
+// start runs s until the test ends or stop is called.
+func start(t *testing.T, s *Scheduler, c *clock.Fake) *running {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &running{Scheduler: s, clock: c, cancel: cancel, done: make(chan struct{})}
+	go func() {
+		r.err = s.Run(ctx)
+		close(r.done)
+	}()
+	t.Cleanup(func() { r.stop() })
+	return r
+}
+
+// stop cancels Run, drains Results and returns Run's error. It can be
+// called more than once.
+func (r *running) stop() error {
+	r.cancel()
+	for range r.Results() {
+	}
+	<-r.done
+	return r.err
+}
+
+// moveTo waits until Run is waiting for the next run, then sets the clock
+// to t.
+func (r *running) moveTo(t time.Time) {
+	r.clock.BlockUntil(1)
+	r.clock.Set(t)
+}
+
+// next returns the next result, failing the test if none comes.
+func (r *running) next(t *testing.T) Result {
+	t.Helper()
+	select {
+	case res, ok := <-r.Results():
+		if !ok {
+			t.Fatal("Results closed early")
+		}
+		return res
+	case <-time.After(5 * time.Second):
+		t.Fatal("no result")
+	}
+	panic("unreachable")
+}
+
+func TestRunsOnSchedule(t *testing.T) {
+	c := clock.NewFake(epoch)
+	s := New(WithClock(c))
+	if err := s.Every("tick", time.Minute, func(context.Context) error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	r := start(t, s, c)
+	for i := 1; i <= 3; i++ {
+		due := epoch.Add(time.Duration(i) * time.Minute)
+		r.moveTo(due)
+		res := r.next(t)
+		if res.Job != "tick" || !res.Due.Equal(due) || !res.Start.Equal(due) || res.Err != nil || res.Skipped {
+			t.Errorf("run %d = %+v, want tick due and started at %v", i, res, due)
+		}
+	}
+	if err := r.stop(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Run = %v after cancel, want context.Canceled", err)
+	}
+}
+
+func TestLateWakeUpSkipsMissedRuns(t *testing.T) {
+	c := clock.NewFake(epoch)
+	s := New(WithClock(c))
+	s.Every("tick", time.Minute, func(context.Context) error { return nil })
+	r := start(t, s, c)
+
+	late := epoch.Add(3*time.Minute + 30*time.Second)
+	r.moveTo(late)
+	res := r.next(t)
+	if !res.Due.Equal(epoch.Add(time.Minute)) || !res.Start.Equal(late) {
+		t.Errorf("late run = %+v, want due 06:01 and started 06:03:30", res)
+	}
+	// The runs due at 06:02 and 06:03 are dropped, not made up.
+	r.moveTo(epoch.Add(4 * time.Minute))
+	if res := r.next(t); !res.Due.Equal(epoch.Add(4 * time.Minute)) {
+		t.Errorf("next run due %v, want 06:04", res.Due)
+	}
+}
+
+func TestOverlappingRunIsSkipped(t *testing.T) {
+	c := clock.NewFake(epoch)
+	s := New(WithClock(c))
+	release := make(chan struct{})
+	s.Every("slow", time.Minute, func(context.Context) error {
+		<-release
+		return nil
+	})
+	r := start(t, s, c)
+
+	r.moveTo(epoch.Add(time.Minute))
+	r.moveTo(epoch.Add(2 * time.Minute))
+	if res := r.next(t); !res.Skipped || !res.Due.Equal(epoch.Add(2*time.Minute)) {
+		t.Errorf("run due while the first was going = %+v, want it skipped", res)
+	}
+	c.Advance(30 * time.Second)
+	close(release)
+	if res := r.next(t); res.Skipped || !res.Start.Equal(epoch.Add(time.Minute)) || res.Duration != 90*time.Second {
+		t.Errorf("first run = %+v, want it started 06:01 and taking 90s", res)
+	}
+}
+
+func TestFailuresAreReported(t *testing.T) {
+	c := clock.NewFake(epoch)
+	s := New(WithClock(c))
+	boom := errors.New("disk full")
+	s.Schedule("fails", Times(1, Interval(time.Minute)), func(context.Context) error { return boom })
+	s.Schedule("panics", Times(1, Interval(2*time.Minute)), func(context.Context) error {
+		panic("job bug")
+	})
+	r := start(t, s, c)
+
+	r.moveTo(epoch.Add(time.Minute))
+	if res := r.next(t); !errors.Is(res.Err, boom) {
+		t.Errorf("failing run reported %v, want %v", res.Err, boom)
+	}
+	r.moveTo(epoch.Add(2 * time.Minute))
+	if res := r.next(t); res.Err == nil {
+		t.Error("a panicking run was reported as a success")
+	}
+	// Both jobs have run out, so Run returns nil by itself.
+	select {
+	case <-r.done:
+		if r.err != nil {
+			t.Errorf("Run = %v once the jobs ran out, want nil", r.err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return once the jobs ran out")
+	}
+	if _, ok := <-r.Results(); ok {
+		t.Error("Results still open after Run returned")
+	}
+}
+
+func TestCancelStopsRunningJobs(t *testing.T) {
+	c := clock.NewFake(epoch)
+	s := New(WithClock(c))
+	started := make(chan struct{})
+	s.Every("waits", time.Minute, func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	r := start(t, s, c)
+	r.moveTo(epoch.Add(time.Minute))
+	<-started
+	r.cancel()
+	// Run waits for the job, whose result still arrives before Results
+	// closes.
+	if res := r.next(t); !errors.Is(res.Err, context.Canceled) {
+		t.Errorf("cancelled run reported %v, want context.Canceled", res.Err)
+	}
+	if err := r.stop(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Run = %v, want context.Canceled", err)
+	}
+}
+
+func TestRegisterWhileRunning(t *testing.T) {
+	c := clock.NewFake(epoch)
+	s := New(WithClock(c))
+	if err := s.Every("bad", 0, nil); err == nil {
+		t.Error("Every accepted a zero interval")
+	}
+	s.Every("tick", time.Minute, func(context.Context) error { return nil })
+	start(t, s, c)
+	c.BlockUntil(1)
+	if err := s.Every("late", time.Minute, nil); !errors.Is(err, ErrRunning) {
+		t.Errorf("Every after Run = %v, want ErrRunning", err)
+	}
+	if err := s.Run(context.Background()); !errors.Is(err, ErrRunning) {
+		t.Errorf("second Run = %v, want ErrRunning", err)
+	}
+}
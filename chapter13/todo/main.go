@@ -0,0 +1,150 @@
+package todo
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"learning-go/clock"
+	"learning-go/errs"
+	"learning-go/registry"
+)
+
+func init() {
+	// Register each exercise with the runner (cmd/learn)
+	registry.Register("chapter13/todo", "exercise1", exercise1)
+	registry.Register("chapter13/todo", "exercise2", exercise2)
+}
+
+// session runs command lines against the list in dir, with a clock that
+// moves an hour per command, and prints each one like a shell transcript.
+// The temporary directory is printed as $TMP so the output is the same on
+// every run.
+type session struct {
+	w     io.Writer
+	dir   string
+	clock *clock.Fake
+}
+
+func (s *session) run(args ...string) error {
+	fmt.Fprintln(s.w, "$ todo", strings.Join(args, " "))
+	args = append([]string{"-file", filepath.Join(s.dir, "todo.json")}, args...)
+	err := Run(args, s.w, WithClock(s.clock))
+	if err != nil {
+		msg := strings.ReplaceAll(err.Error(), s.dir, "$TMP")
+		fmt.Fprintf(s.w, "error (exit %d): %s\n", errs.ExitCode(err), msg)
+	}
+	s.clock.Advance(time.Hour)
+	return err
+}
+
+func newSession(w io.Writer) (*session, error) {
+	dir, err := os.MkdirTemp("", "todo-")
+	if err != nil {
+		return nil, err
+	}
+	start := time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC)
+	return &session{w: w, dir: dir, clock: clock.NewFake(start)}, nil
+}
+
+// Exercise 1: Drive the todo command with valid and invalid command
+// lines: subcommands, flags before and after the subcommand, and
+// arguments that must be rejected before the list is touched.
+func exercise1(w io.Writer) {
+	s, err := newSession(w)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	defer os.RemoveAll(s.dir)
+
+	s.run("add", "Read", "chapter", "13")
+	s.run("add", "Write the todo exercise")
+	s.run("add", "Run go vet")
+	s.run("list")
+	s.run("done", "2")
+	s.run("list", "--format=json")
+	s.run("list", "-all")
+	s.run("add")
+	s.run("add", "-all", "tasks")
+	s.run("list", "--format=yaml")
+	s.run("done", "1", "x")
+	s.run("done", "9")
+	s.run("archive")
+	s.run("-verbose", "list")
+	s.run("list", "-all")
+
+	// Explanation:
+	// The flag package parses one set of flags at a time, so subcommands
+	// use a FlagSet each: the top-level set parses -file and stops at the
+	// first argument that is not a flag, the subcommand's name, and
+	// Args() holds the rest for the subcommand's own set. Both use
+	// ContinueOnError and discard their output, so a bad flag comes back
+	// as an error instead of the default ExitOnError's os.Exit, which
+	// would end the exercise, and the program. Each error is an
+	// *errs.ValidationError, which errs.ExitCode maps to exit status 2,
+	// the convention for usage errors; a missing task is a different kind
+	// of error and exits with 1. Every argument is checked before the
+	// file is loaded, so "done 1 x" leaves task 1 alone.
+}
+
+// Exercise 2: Check that the list survives between runs: look at the
+// JSON file the commands write, start from a missing directory, and see
+// what happens when the file is not valid JSON.
+func exercise2(w io.Writer) {
+	s, err := newSession(w)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	defer os.RemoveAll(s.dir)
+
+	// The list is in a directory that does not exist yet; Save creates it.
+	path := filepath.Join(s.dir, "config", "learning-go", "todo.json")
+	s.dir = filepath.Dir(path)
+	s.run("add", "Buy milk")
+	s.run("add", "Call the plumber")
+	s.run("done", "1")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	fmt.Fprintf(w, "%s holds:\n%s", filepath.Base(path), data)
+
+	l, err := Load(path)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	fmt.Fprintf(w, "loaded back: %d tasks, #1 done %v, #2 added %s\n",
+		len(l.Tasks), l.Tasks[0].Done, l.Tasks[1].Added.Format(time.Kitchen))
+	s.run("add", "Water the plants")
+
+	missing, err := Load(filepath.Join(s.dir, "missing.json"))
+	fmt.Fprintln(w, "a missing file loads as", len(missing.Tasks), "tasks, error:", err)
+
+	if err := os.WriteFile(path, []byte(`{"tasks": [{"id": 1,`), 0o644); err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	s.run("list")
+	s.run("add", "Fix the file")
+	data, _ = os.ReadFile(path)
+	fmt.Fprintf(w, "the broken file is left as it was: %s\n", data)
+
+	// Explanation:
+	// Load treats a missing file as an empty list, so a first run needs no
+	// setup, and Save creates the directory the list lives in, as it would
+	// under os.UserConfigDir on a new machine. Save writes a temporary
+	// file and renames it over the old one, which on one file system is
+	// atomic: a crash leaves either the old list or the new one, never
+	// half of each. time.Time marshals as RFC 3339 text, so the dates
+	// survive the round trip. A file that is not valid JSON is an error
+	// wrapped with the file's name, rather than a list that would be saved
+	// back empty; the command stops before changing anything and the file
+	// is kept for the user to repair.
+}
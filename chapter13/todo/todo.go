@@ -0,0 +1,280 @@
+// Package todo is a small to-do list kept in a JSON file, and the command
+// line that drives it. It is chapter 13's tour of the flag package: a
+// FlagSet per subcommand, flags that are validated before anything is
+// read from disk, and errors that tell a usage mistake apart from a
+// failure.
+//
+// The command logic lives here rather than in cmd/todo so that it can be
+// run with any arguments, output and clock, and against a file in a
+// temporary directory:
+//
+//	err := todo.Run([]string{"-file", path, "add", "Read chapter 13"}, os.Stdout)
+package todo
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"learning-go/clock"
+	"learning-go/errs"
+	"learning-go/report"
+)
+
+// Usage describes the command line Run accepts.
+const Usage = `usage:
+  todo [-file path] add title...
+  todo [-file path] list [-all] [-format table|json]
+  todo [-file path] done id...
+`
+
+// ErrNotFound means no task has the given ID.
+var ErrNotFound = errors.New("no such task")
+
+// Task is one entry of the list.
+type Task struct {
+	ID    int       `json:"id"`
+	Title string    `json:"title"`
+	Done  bool      `json:"done"`
+	Added time.Time `json:"added"`
+}
+
+// List is the to-do list as it is stored. The zero value is an empty list.
+type List struct {
+	Tasks []Task `json:"tasks"`
+}
+
+// Add appends a task and returns it. IDs start at 1 and are never reused.
+func (l *List) Add(title string, added time.Time) Task {
+	id := 1
+	if n := len(l.Tasks); n > 0 {
+		id = l.Tasks[n-1].ID + 1
+	}
+	t := Task{ID: id, Title: title, Added: added}
+	l.Tasks = append(l.Tasks, t)
+	return t
+}
+
+// Complete marks the task with the given ID as done and returns it.
+// Completing a task twice is not an error.
+func (l *List) Complete(id int) (Task, error) {
+	i := slices.IndexFunc(l.Tasks, func(t Task) bool { return t.ID == id })
+	if i < 0 {
+		return Task{}, fmt.Errorf("task %d: %w", id, ErrNotFound)
+	}
+	l.Tasks[i].Done = true
+	return l.Tasks[i], nil
+}
+
+// Load reads the list stored at path. A missing file is an empty list, so
+// the first add needs no setup.
+func Load(path string) (*List, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &List{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var l List
+	if err := json.Unmarshal(data, &l); err != nil {
+		return nil, errs.Wrap(err, "reading %s", path)
+	}
+	return &l, nil
+}
+
+// Save writes l to path, creating its directory if needed.
+func Save(path string, l *List) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	// Write to a temporary file and rename it, so a crash mid-write never
+	// leaves a truncated list behind.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// DefaultPath returns where the list is kept when -file is not given:
+// todo.json in a learning-go directory under the user's config directory.
+func DefaultPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "todo.json"
+	}
+	return filepath.Join(dir, "learning-go", "todo.json")
+}
+
+// Option configures Run.
+type Option func(*options)
+
+type options struct {
+	clock clock.Clock
+}
+
+// WithClock sets the clock that dates new tasks. The default is
+// clock.Real.
+func WithClock(c clock.Clock) Option {
+	return func(o *options) { o.clock = c }
+}
+
+func newOptions(opts []Option) options {
+	o := options{clock: clock.Real}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Run runs the command line args, without the program name, and writes
+// its output to w. Mistakes in the arguments are returned as
+// *errs.ValidationError, so errs.ExitCode maps them to errs.ExitUsage.
+func Run(args []string, w io.Writer, opts ...Option) error {
+	o := newOptions(opts)
+	global := flag.NewFlagSet("todo", flag.ContinueOnError)
+	global.SetOutput(io.Discard)
+	path := global.String("file", DefaultPath(), "file holding the list")
+	if err := global.Parse(args); err != nil {
+		return errs.Invalid("flags", "%v", err)
+	}
+	args = global.Args()
+	if len(args) == 0 {
+		return errs.Invalid("command", "missing; use add, list or done")
+	}
+	switch args[0] {
+	case "add":
+		return add(*path, args[1:], w, o)
+	case "list":
+		return list(*path, args[1:], w)
+	case "done":
+		return done(*path, args[1:], w)
+	case "help":
+		fmt.Fprint(w, Usage)
+		return nil
+	}
+	return errs.Invalid("command", "unknown command %q", args[0])
+}
+
+func add(path string, args []string, w io.Writer, o options) error {
+	// add has no flags of its own, but parsing still rejects a mistyped
+	// one such as "add -all" instead of saving "-all" as a title.
+	fs := flag.NewFlagSet("add", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	if err := fs.Parse(args); err != nil {
+		return errs.Invalid("flags", "%v", err)
+	}
+	title := strings.Join(fs.Args(), " ")
+	if strings.TrimSpace(title) == "" {
+		return errs.Invalid("title", "add needs a title")
+	}
+
+	l, err := Load(path)
+	if err != nil {
+		return err
+	}
+	t := l.Add(title, o.clock.Now())
+	if err := Save(path, l); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "added #%d: %s\n", t.ID, t.Title)
+	return nil
+}
+
+func list(path string, args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	all := fs.Bool("all", false, "include tasks that are done")
+	format := fs.String("format", "table", "output format: table or json")
+	if err := fs.Parse(args); err != nil {
+		return errs.Invalid("flags", "%v", err)
+	}
+	if fs.NArg() > 0 {
+		return errs.Invalid("arguments", "list takes no arguments, got %q", fs.Args())
+	}
+	if *format != "table" && *format != "json" {
+		return errs.Invalid("format", "%q is not table or json", *format)
+	}
+
+	l, err := Load(path)
+	if err != nil {
+		return err
+	}
+	tasks := make([]Task, 0, len(l.Tasks))
+	for _, t := range l.Tasks {
+		if *all || !t.Done {
+			tasks = append(tasks, t)
+		}
+	}
+
+	if *format == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(tasks)
+	}
+	if len(tasks) == 0 {
+		fmt.Fprintln(w, "nothing to do")
+		return nil
+	}
+	t := report.Table{
+		Headers: []string{"ID", "Done", "Task", "Added"},
+		Align:   []report.Align{report.Right},
+	}
+	for _, task := range tasks {
+		mark := ""
+		if task.Done {
+			mark = "✓"
+		}
+		t.AddRow(task.ID, mark, task.Title, task.Added.Format("2006-01-02 15:04"))
+	}
+	return t.Render(w)
+}
+
+func done(path string, args []string, w io.Writer) error {
+	if len(args) == 0 {
+		return errs.Invalid("id", "done needs at least one task ID")
+	}
+	// Parse every ID before changing anything, so "done 1 x" marks nothing.
+	ids := make([]int, len(args))
+	for i, arg := range args {
+		id, err := strconv.Atoi(arg)
+		if err != nil || id < 1 {
+			return errs.Invalid("id", "%q is not a task ID", arg)
+		}
+		ids[i] = id
+	}
+
+	l, err := Load(path)
+	if err != nil {
+		return err
+	}
+	var completed []Task
+	for _, id := range ids {
+		t, err := l.Complete(id)
+		if err != nil {
+			return err
+		}
+		completed = append(completed, t)
+	}
+	if err := Save(path, l); err != nil {
+		return err
+	}
+	for _, t := range completed {
+		fmt.Fprintf(w, "done #%d: %s\n", t.ID, t.Title)
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package todo
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"learning-go/clock"
+	"learning-go/errs"
+)
+
+var start = time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+
+// cli runs todo commands against a list file in a temporary directory,
+// with a fake clock that moves on an hour after every command.
+type cli struct {
+	t     *testing.T
+	path  string
+	clock *clock.Fake
+}
+
+func newCLI(t *testing.T) *cli {
+	return &cli{t: t, path: filepath.Join(t.TempDir(), "todo.json"), clock: clock.NewFake(start)}
+}
+
+// run runs args and returns the output and error.
+func (c *cli) run(args ...string) (string, error) {
+	var out strings.Builder
+	err := Run(append([]string{"-file", c.path}, args...), &out, WithClock(c.clock))
+	c.clock.Advance(time.Hour)
+	return out.String(), err
+}
+
+// must runs args and fails the test if they return an error.
+func (c *cli) must(args ...string) string {
+	c.t.Helper()
+	out, err := c.run(args...)
+	if err != nil {
+		c.t.Fatalf("todo %s: %v", strings.Join(args, " "), err)
+	}
+	return out
+}
+
+// listJSON returns the tasks "list -format json" prints.
+func (c *cli) listJSON(flags ...string) []Task {
+	c.t.Helper()
+	var tasks []Task
+	out := c.must(append([]string{"list", "-format", "json"}, flags...)...)
+	if err := json.Unmarshal([]byte(out), &tasks); err != nil {
+		c.t.Fatalf("list -format json printed invalid JSON: %v\n%s", err, out)
+	}
+	return tasks
+}
+
+func TestAddListDone(t *testing.T) {
+	c := newCLI(t)
+	if out := c.must("list"); out != "nothing to do\n" {
+		t.Errorf("list on a missing file = %q", out)
+	}
+	if out := c.must("add", "Read", "chapter", "13"); out != "added #1: Read chapter 13\n" {
+		t.Errorf("add = %q", out)
+	}
+	c.must("add", "Write tests")
+	c.must("add", "Run go vet")
+
+	if out := c.must("done", "1", "3"); out != "done #1: Read chapter 13\ndone #3: Run go vet\n" {
+		t.Errorf("done = %q", out)
+	}
+	open := c.listJSON()
+	if len(open) != 1 || open[0].ID != 2 || open[0].Title != "Write tests" || open[0].Done {
+		t.Errorf("open tasks = %+v, want only #2", open)
+	}
+	if !open[0].Added.Equal(start.Add(2 * time.Hour)) {
+		t.Errorf("#2 added at %v, want the fake clock's %v", open[0].Added, start.Add(2*time.Hour))
+	}
+	all := c.listJSON("-all")
+	if len(all) != 3 || !all[0].Done || all[1].Done || !all[2].Done {
+		t.Errorf("list -all = %+v", all)
+	}
+
+	table := c.must("list", "-all")
+	for _, want := range []string{"Read chapter 13", "✓", "2024-03-04 10:00"} {
+		if !strings.Contains(table, want) {
+			t.Errorf("table does not contain %q:\n%s", want, table)
+		}
+	}
+}
+
+func TestIDsAreNotReused(t *testing.T) {
+	var l List
+	l.Add("a", start)
+	l.Add("b", start)
+	l.Tasks = l.Tasks[1:] // as if #1 had been deleted by hand
+	if got := l.Add("c", start); got.ID != 3 {
+		t.Errorf("Add after removing #1 gave ID %d, want 3", got.ID)
+	}
+	if _, err := l.Complete(1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Complete(1) = %v, want ErrNotFound", err)
+	}
+}
+
+func TestUsageErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{},
+		{"remove", "1"},
+		{"-verbose", "list"},
+		{"add"},
+		{"add", "  "},
+		{"add", "-all", "title"},
+		{"list", "extra"},
+		{"list", "-format", "xml"},
+		{"list", "-all=maybe"},
+		{"done"},
+		{"done", "x"},
+		{"done", "0"},
+	} {
+		c := newCLI(t)
+		_, err := c.run(args...)
+		var invalid *errs.ValidationError
+		if !errors.As(err, &invalid) || errs.ExitCode(err) != errs.ExitUsage {
+			t.Errorf("todo %q = %v, want a usage error", args, err)
+		}
+		if _, statErr := os.Stat(c.path); !errors.Is(statErr, os.ErrNotExist) {
+			t.Errorf("todo %q wrote the list file despite the usage error", args)
+		}
+	}
+}
+
+func TestDoneChangesNothingOnError(t *testing.T) {
+	c := newCLI(t)
+	c.must("add", "one")
+	c.must("add", "two")
+	for _, args := range [][]string{{"done", "1", "x"}, {"done", "2", "9"}} {
+		if _, err := c.run(args...); err == nil {
+			t.Errorf("todo %q succeeded", args)
+		}
+	}
+	if _, err := c.run("done", "9"); !errors.Is(err, ErrNotFound) || errs.ExitCode(err) != errs.ExitFailure {
+		t.Errorf("done 9 = %v, want ErrNotFound with a failure exit code", err)
+	}
+	for _, task := range c.listJSON("-all") {
+		if task.Done {
+			t.Errorf("#%d was marked done by a command that failed", task.ID)
+		}
+	}
+}
+
+func TestHelp(t *testing.T) {
+	c := newCLI(t)
+	if out := c.must("help"); out != Usage {
+		t.Errorf("help printed %q", out)
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "dir", "todo.json")
+	l := &List{}
+	l.Add("persist me", start)
+	if err := Save(path, l); err != nil {
+		t.Fatalf("Save into a missing directory: %v", err)
+	}
+	if _, err := os.Stat(path + ".tmp"); !errors.Is(err, os.ErrNotExist) {
+		t.Error("Save left its temporary file behind")
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Tasks) != 1 || got.Tasks[0] != l.Tasks[0] {
+		t.Errorf("Load = %+v, want %+v", got.Tasks, l.Tasks)
+	}
+}
+
+func TestLoadCorrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "todo.json")
+	if err := os.WriteFile(path, []byte(`{"tasks": [`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), path) {
+		t.Errorf("Load of a truncated file = %v, want an error naming the file", err)
+	}
+	c := &cli{t: t, path: path, clock: clock.NewFake(start)}
+	if _, err := c.run("add", "x"); err == nil {
+		t.Error("add over a corrupt file succeeded and would overwrite it")
+	}
+}
+
+func TestDefaultPath(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	t.Setenv("AppData", dir)
+	got := DefaultPath()
+	if !strings.HasPrefix(got, dir) || filepath.Base(got) != "todo.json" {
+		t.Errorf("DefaultPath() = %q, want todo.json under %s", got, dir)
+	}
+}
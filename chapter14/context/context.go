@@ -0,0 +1,72 @@
+// Package context is chapter 14's tour of package context: cancelling
+// work, giving it a deadline, and carrying request-scoped values such as a
+// request ID from an HTTP middleware down to the code that logs.
+//
+// The package shares its name with the standard library's so that its
+// registry name reads chapter14/context; importers would need to rename
+// one of the two, but only the runner imports it.
+package context
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Work simulates a long-running job of the given number of steps, each
+// taking step. It checks ctx before every step and returns ctx.Err() as
+// soon as the context is cancelled or its deadline passes, after calling
+// done with the number of steps it finished. It returns nil if it finishes
+// every step.
+func Work(ctx context.Context, steps int, step time.Duration, done func(finished int)) error {
+	t := time.NewTimer(step)
+	defer t.Stop()
+	for i := 0; i < steps; i++ {
+		select {
+		case <-ctx.Done():
+			done(i)
+			return ctx.Err()
+		case <-t.C:
+			t.Reset(step)
+		}
+	}
+	done(steps)
+	return nil
+}
+
+// requestIDKey is the key under which WithRequestID stores the ID. It is
+// an unexported type, so no other package can build an equal key and read
+// or overwrite the value by accident.
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx carrying id.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the ID stored by WithRequestID, or false if there is
+// none.
+func RequestID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok
+}
+
+// RequestIDHeader is the header a request ID is read from and written to.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestIDs is middleware that gives every request an ID: the one in its
+// X-Request-ID header if it has one, otherwise one from newID. The ID is
+// stored in the request's context for handlers to read with RequestID, and
+// echoed in the response header.
+func RequestIDs(newID func() string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(RequestIDHeader)
+			if id == "" {
+				id = newID()
+			}
+			w.Header().Set(RequestIDHeader, id)
+			next.ServeHTTP(w, r.WithContext(WithRequestID(r.Context(), id)))
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package context
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"learning-go/testutil/leak"
+)
+
+func TestWorkFinishes(t *testing.T) {
+	var finished int
+	err := Work(context.Background(), 3, time.Millisecond, func(n int) { finished = n })
+	if err != nil || finished != 3 {
+		t.Errorf("Work = %v after %d steps, want nil after 3", err, finished)
+	}
+}
+
+// Cancelling must stop every worker promptly, not after its remaining
+// steps; leak.Verify fails the test if any goroutine is left.
+func TestCancelStopsWorkers(t *testing.T) {
+	leak.Verify(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	results := make(chan error, 10)
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results <- Work(ctx, 1000, 10*time.Millisecond, func(n int) {
+				if n >= 1000 {
+					t.Errorf("a worker finished all %d steps despite the cancel", n)
+				}
+			})
+		}()
+	}
+	time.Sleep(25 * time.Millisecond)
+	start := time.Now()
+	cancel()
+	wg.Wait()
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("workers took %v to stop", d)
+	}
+	close(results)
+	for err := range results {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Work = %v, want context.Canceled", err)
+		}
+	}
+}
+
+func TestDeadline(t *testing.T) {
+	leak.Verify(t)
+	for name, newCtx := range map[string]func() (context.Context, context.CancelFunc){
+		"WithTimeout": func() (context.Context, context.CancelFunc) {
+			return context.WithTimeout(context.Background(), 30*time.Millisecond)
+		},
+		"WithDeadline": func() (context.Context, context.CancelFunc) {
+			return context.WithDeadline(context.Background(), time.Now().Add(30*time.Millisecond))
+		},
+	} {
+		ctx, cancel := newCtx()
+		var finished int
+		err := Work(ctx, 100, 10*time.Millisecond, func(n int) { finished = n })
+		cancel()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("%s: Work = %v, want context.DeadlineExceeded", name, err)
+		}
+		if finished == 0 || finished >= 10 {
+			t.Errorf("%s: %d steps done before a 30ms deadline at 10ms each", name, finished)
+		}
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	ctx := context.Background()
+	if _, ok := RequestID(ctx); ok {
+		t.Error("RequestID found an ID in an empty context")
+	}
+	ctx = WithRequestID(ctx, "abc")
+	// A string key equal to the type's name must not reach the value.
+	ctx = context.WithValue(ctx, "requestIDKey", "spoofed")
+	if id, ok := RequestID(ctx); !ok || id != "abc" {
+		t.Errorf("RequestID = %q, %v; want abc", id, ok)
+	}
+	child, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if id, _ := RequestID(child); id != "abc" {
+		t.Errorf("derived context lost the ID: %q", id)
+	}
+}
+
+func TestRequestIDsMiddleware(t *testing.T) {
+	n := 0
+	newID := func() string { n++; return "gen-" + string(rune('0'+n)) }
+	h := RequestIDs(newID)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, _ := RequestID(r.Context())
+		w.Write([]byte(id))
+	}))
+
+	for _, tt := range []struct{ header, want string }{
+		{"", "gen-1"},
+		{"from-client", "from-client"},
+		{"", "gen-2"},
+	} {
+		req := httptest.NewRequest("GET", "/", nil)
+		if tt.header != "" {
+			req.Header.Set(RequestIDHeader, tt.header)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Body.String() != tt.want || rec.Header().Get(RequestIDHeader) != tt.want {
+			t.Errorf("header %q: handler saw %q, response header %q; want %q",
+				tt.header, rec.Body.String(), rec.Header().Get(RequestIDHeader), tt.want)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package context
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"learning-go/registry"
+	"learning-go/testutil/leak"
+)
+
+func init() {
+	// Register each exercise with the runner (cmd/learn)
+	registry.Register("chapter14/context", "exercise1", exercise1)
+	registry.Register("chapter14/context", "exercise2", exercise2)
+	registry.Register("chapter14/context", "exercise3", exercise3)
+	registry.Register("chapter14/context", "exercise4", exercise4)
+	registry.Requires("chapter14/context", "exercise1", "chapter12/exercise1")
+	registry.Requires("chapter14/context", "exercise4", "chapter13/httpserver/exercise1")
+}
+
+// Exercise 1: Start five workers with one context from context.WithCancel,
+// let them run for a moment, then cancel it. Check that every worker
+// returned context.Canceled before finishing its job and that no goroutine
+// is left behind.
+func exercise1(w io.Writer) {
+	const workers, steps = 5, 1000
+	snap := leak.Take()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	type result struct {
+		finished int
+		err      error
+	}
+	results := make(chan result, workers)
+	for range workers {
+		go func() {
+			var r result
+			r.err = Work(ctx, steps, time.Millisecond, func(n int) { r.finished = n })
+			results <- r
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	fmt.Fprintf(w, "running: %d worker goroutines\n", len(snap.Leaked(0)))
+	cancel()
+
+	stopped := 0
+	timeout := time.After(time.Second)
+	for range workers {
+		select {
+		case r := <-results:
+			if errors.Is(r.err, context.Canceled) && r.finished < steps {
+				stopped++
+			}
+		case <-timeout:
+		}
+	}
+	fmt.Fprintf(w, "workers that stopped early with context.Canceled: %d of %d\n", stopped, workers)
+	fmt.Fprintf(w, "goroutines left after cancel: %d\n", len(snap.Leaked(leak.Timeout)))
+	fmt.Fprintln(w, "ctx.Err() after cancel:", ctx.Err())
+	cancel() // calling it again does nothing
+
+	// Explanation:
+	// Cancelling a context closes its Done channel, and a closed channel
+	// is ready for every receiver at once, so a single cancel reaches all
+	// five workers. Cancellation is cooperative: nothing kills a
+	// goroutine from outside, Work stops only because it selects on
+	// ctx.Done() between steps. A worker that never checked would keep
+	// running, which is why the exercise counts goroutines instead of
+	// trusting the call. Always call cancel, usually with defer, even when
+	// the work finishes on its own; it releases the context's resources.
+}
+
+// Exercise 2: Run the same job under context.WithTimeout and
+// context.WithDeadline, and show that a child context cannot outlive its
+// parent's deadline. Use WithTimeoutCause to say why the time ran out.
+func exercise2(w io.Writer) {
+	var finished int
+	record := func(n int) { finished = n }
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := Work(ctx, 10, 40*time.Millisecond, record)
+	fmt.Fprintf(w, "WithTimeout(100ms), 10 steps of 40ms: %v after %d steps\n", err, finished)
+	fmt.Fprintln(w, "  errors.Is DeadlineExceeded:", errors.Is(err, context.DeadlineExceeded))
+
+	past := time.Now().Add(-time.Second)
+	ctx2, cancel2 := context.WithDeadline(context.Background(), past)
+	defer cancel2()
+	err = Work(ctx2, 10, 20*time.Millisecond, record)
+	fmt.Fprintf(w, "WithDeadline in the past: %v after %d steps\n", err, finished)
+
+	parent, cancelParent := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancelParent()
+	child, cancelChild := context.WithTimeout(parent, time.Hour)
+	defer cancelChild()
+	pd, _ := parent.Deadline()
+	cd, _ := child.Deadline()
+	fmt.Fprintln(w, "child asked for an hour, deadline is the parent's:", cd.Equal(pd))
+	<-child.Done()
+	fmt.Fprintln(w, "  child done with:", child.Err())
+
+	errSlow := errors.New("inventory service too slow")
+	ctx3, cancel3 := context.WithTimeoutCause(context.Background(), 10*time.Millisecond, errSlow)
+	defer cancel3()
+	<-ctx3.Done()
+	fmt.Fprintf(w, "WithTimeoutCause: Err() = %v, Cause() = %v\n", ctx3.Err(), context.Cause(ctx3))
+
+	// Explanation:
+	// WithTimeout(d) is WithDeadline(time.Now().Add(d)): both close Done at
+	// a point in time and make Err return DeadlineExceeded. A deadline
+	// already in the past is done before the first step. A child can
+	// shorten its parent's deadline but never extend it, since the child
+	// is cancelled whenever its parent is, so an inner function cannot
+	// give itself more time than its caller allowed. Err only says that
+	// time ran out; a cause passed to WithTimeoutCause, read back with
+	// context.Cause, says why.
+}
+
+// userKey is the typed key a second package might use for its own value.
+type userKey struct{}
+
+// Exercise 3: Store values in a context with WithValue, first under plain
+// string keys from two packages that collide, then under unexported struct
+// keys that cannot. Read them back with type assertions.
+func exercise3(w io.Writer) {
+	// Two packages both choose the string "id" as their key.
+	ctx := context.WithValue(context.Background(), "id", "request-42")
+	ctx = context.WithValue(ctx, "id", "user-7")
+	fmt.Fprintln(w, "string keys, value for \"id\":", ctx.Value("id"))
+
+	ctx = WithRequestID(context.Background(), "request-42")
+	ctx = context.WithValue(ctx, userKey{}, "user-7")
+	id, ok := RequestID(ctx)
+	user, _ := ctx.Value(userKey{}).(string)
+	fmt.Fprintf(w, "typed keys: request ID %q (%v), user %q\n", id, ok, user)
+
+	_, ok = RequestID(context.Background())
+	fmt.Fprintln(w, "no request ID in a bare context:", !ok)
+
+	// Explanation:
+	// Value looks the key up by ==, from the newest context back to the
+	// oldest, so the second "id" hides the first and one package silently
+	// reads the other's value; linters such as staticcheck flag keys of
+	// built-in types for this reason. A key of an unexported type can only be created inside its
+	// own package, so each package's values are safe from all the others.
+	// The usual pattern, used by WithRequestID and RequestID, hides the
+	// key entirely behind a setter and a getter that does the type
+	// assertion, so callers never see an interface{}.
+}
+
+// logf formats a log line prefixed with the request ID from ctx, the way
+// code deep in a call stack can log without being passed the ID.
+func logf(ctx context.Context, w io.Writer, format string, args ...any) {
+	id, ok := RequestID(ctx)
+	if !ok {
+		id = "-"
+	}
+	fmt.Fprintf(w, "  [%s] %s\n", id, fmt.Sprintf(format, args...))
+}
+
+// Exercise 4: Add a request ID to every request with middleware, read it
+// in the handler and in a helper two calls below it, and pass it on to a
+// downstream service in the outgoing request's header.
+func exercise4(w io.Writer) {
+	downstream := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(rw, "stock for %s", r.Header.Get(RequestIDHeader))
+	}))
+	defer downstream.Close()
+
+	lookupStock := func(ctx context.Context) string {
+		logf(ctx, w, "calling the inventory service")
+		// The context cancels the outgoing request along with the incoming
+		// one, but it does not cross the network: the ID must be copied
+		// into a header.
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, downstream.URL, nil)
+		if id, ok := RequestID(ctx); ok {
+			req.Header.Set(RequestIDHeader, id)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err.Error()
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+	handler := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		logf(r.Context(), w, "handling %s", r.URL.Path)
+		fmt.Fprint(rw, lookupStock(r.Context()))
+	})
+
+	var n atomic.Int64
+	newID := func() string { return "req-" + strconv.FormatInt(n.Add(1), 10) }
+	h := RequestIDs(newID)(handler)
+
+	for _, given := range []string{"", "", "from-client-9"} {
+		r := httptest.NewRequest(http.MethodGet, "/stock", nil)
+		if given != "" {
+			r.Header.Set(RequestIDHeader, given)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, r)
+		fmt.Fprintf(w, "response %q, %s: %s\n", rec.Body, RequestIDHeader, rec.Header().Get(RequestIDHeader))
+	}
+
+	// Explanation:
+	// A handler has no parameter for request-scoped data, but every
+	// *http.Request has a context. The middleware stores the ID in a new
+	// context with WithRequestID and passes the handler r.WithContext,
+	// and from then on anything that receives the context, such as logf
+	// two calls down, can read it. Contexts stop at the process boundary,
+	// so the ID travels to the next service as a header, where the same
+	// middleware would pick it up. Values are for data like this that
+	// describes the request; ordinary parameters should stay parameters.
+}
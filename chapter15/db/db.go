@@ -0,0 +1,293 @@
+// Package db stores employees in SQLite through database/sql. It is the
+// database half of chapter 15's testing story: a Repository with the usual
+// create, read, update and delete methods, built so that it can be tested
+// against a throwaway in-memory database.
+//
+//	repo, err := db.Open(ctx, ":memory:")
+//	e, err := repo.Create(ctx, employees.Employee{Name: "Ada", Salary: 4200})
+//
+// The schema is created and upgraded by Open, statements are prepared once
+// and reused, and the database's constraints are the last word on what is
+// valid: a violation comes back as ErrDuplicate or ErrConstraint.
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"learning-go/chapter7/employees"
+	"learning-go/errs"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+var (
+	// ErrNotFound is returned for an unknown employee ID. It is the same
+	// error as employees.ErrNotFound, so the HTTP layer's checks still work.
+	ErrNotFound = employees.ErrNotFound
+	// ErrDuplicate means the ID or the name is already taken.
+	ErrDuplicate = errors.New("duplicate employee")
+	// ErrConstraint means a value broke one of the table's checks, such
+	// as an empty name or a negative salary.
+	ErrConstraint = errors.New("constraint violated")
+)
+
+// migrations upgrade the schema one version at a time; migrations[i]
+// takes it from version i to i+1. The version is kept in SQLite's
+// user_version, so Open knows which ones a database still needs. Never
+// edit one that has shipped: add a new one instead.
+var migrations = []string{
+	`CREATE TABLE employees (
+		id     INTEGER PRIMARY KEY,
+		name   TEXT    NOT NULL CHECK (name <> ''),
+		salary INTEGER NOT NULL CHECK (salary >= 0)
+	)`,
+	// Reports refer to people by name, so names must be unique.
+	`CREATE UNIQUE INDEX employees_name ON employees (name)`,
+}
+
+// Repository stores employees in a SQLite database. It is safe for
+// concurrent use.
+type Repository struct {
+	db *sql.DB
+
+	create, get, list, update, del *sql.Stmt
+}
+
+// Open opens (creating if needed) the database at path, brings its schema
+// up to date and prepares the repository's statements. Use ":memory:"
+// for a database that lives as long as the Repository.
+func Open(ctx context.Context, path string) (*Repository, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	// Every connection to ":memory:" gets a database of its own, and
+	// SQLite allows one writer at a time anyway, so one connection keeps
+	// the data in one place and turns "database is locked" into waiting.
+	db.SetMaxOpenConns(1)
+	r := &Repository{db: db}
+	if err := r.init(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Repository) init(ctx context.Context) error {
+	if err := migrate(ctx, r.db, len(migrations)); err != nil {
+		return err
+	}
+	for _, p := range []struct {
+		stmt **sql.Stmt
+		sql  string
+	}{
+		{&r.create, `INSERT INTO employees (id, name, salary) VALUES (?, ?, ?)`},
+		{&r.get, `SELECT id, name, salary FROM employees WHERE id = ?`},
+		{&r.list, `SELECT id, name, salary FROM employees ORDER BY id`},
+		{&r.update, `UPDATE employees SET name = ?, salary = ? WHERE id = ?`},
+		{&r.del, `DELETE FROM employees WHERE id = ?`},
+	} {
+		stmt, err := r.db.PrepareContext(ctx, p.sql)
+		if err != nil {
+			return errs.Wrap(err, "db: preparing %q", p.sql)
+		}
+		*p.stmt = stmt
+	}
+	return nil
+}
+
+// Close closes the prepared statements and the database.
+func (r *Repository) Close() error {
+	for _, stmt := range []*sql.Stmt{r.create, r.get, r.list, r.update, r.del} {
+		if stmt != nil {
+			stmt.Close()
+		}
+	}
+	return r.db.Close()
+}
+
+// version returns the schema version of db.
+func version(ctx context.Context, db *sql.DB) (int, error) {
+	var v int
+	err := db.QueryRowContext(ctx, `PRAGMA user_version`).Scan(&v)
+	return v, err
+}
+
+// migrate applies the migrations db lacks, up to version target, each in
+// its own transaction together with the version bump, so a failed
+// migration leaves the database at the previous version.
+func migrate(ctx context.Context, db *sql.DB, target int) error {
+	current, err := version(ctx, db)
+	if err != nil {
+		return errs.Wrap(err, "db: reading schema version")
+	}
+	if current > len(migrations) {
+		return fmt.Errorf("db: schema version %d is newer than this program's %d", current, len(migrations))
+	}
+	for v := current; v < target; v++ {
+		err := inTx(ctx, db, func(tx *sql.Tx) error {
+			if _, err := tx.ExecContext(ctx, migrations[v]); err != nil {
+				return err
+			}
+			// PRAGMA takes no parameters, so the version is formatted in;
+			// it is an int, so this cannot inject anything.
+			_, err := tx.ExecContext(ctx, fmt.Sprintf(`PRAGMA user_version = %d`, v+1))
+			return err
+		})
+		if err != nil {
+			return errs.Wrap(err, "db: migrating to version %d", v+1)
+		}
+	}
+	return nil
+}
+
+// inTx runs fn in a transaction. It commits if fn returns nil and rolls
+// back if fn returns an error or panics.
+func inTx(ctx context.Context, db *sql.DB, fn func(*sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	// Rollback after a successful Commit does nothing, so deferring it
+	// covers every early return and panic in fn.
+	defer tx.Rollback()
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Version returns the schema version of the database.
+func (r *Repository) Version(ctx context.Context) (int, error) {
+	return version(ctx, r.db)
+}
+
+// Create inserts e and returns it with its ID. A zero ID lets the database
+// choose the next one.
+func (r *Repository) Create(ctx context.Context, e employees.Employee) (employees.Employee, error) {
+	return create(ctx, r.create, e)
+}
+
+func create(ctx context.Context, stmt *sql.Stmt, e employees.Employee) (employees.Employee, error) {
+	// A nil id makes SQLite pick one, as it does for an INTEGER PRIMARY
+	// KEY column that is left out.
+	var id any
+	if e.ID != 0 {
+		id = e.ID
+	}
+	res, err := stmt.ExecContext(ctx, id, e.Name, e.Salary)
+	if err != nil {
+		return employees.Employee{}, constraintError(err)
+	}
+	newID, err := res.LastInsertId()
+	if err != nil {
+		return employees.Employee{}, err
+	}
+	e.ID = int(newID)
+	return e, nil
+}
+
+// CreateAll inserts every employee in list, or none of them: if one
+// insert fails, the ones before it are rolled back.
+func (r *Repository) CreateAll(ctx context.Context, list []employees.Employee) ([]employees.Employee, error) {
+	created := make([]employees.Employee, 0, len(list))
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		// The prepared statement belongs to the database; tx.Stmt returns
+		// a copy that runs inside the transaction.
+		stmt := tx.StmtContext(ctx, r.create)
+		defer stmt.Close()
+		for i, e := range list {
+			e, err := create(ctx, stmt, e)
+			if err != nil {
+				return errs.Wrap(err, "employee %d of %d", i+1, len(list))
+			}
+			created = append(created, e)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// GetByID returns the employee with the given ID.
+func (r *Repository) GetByID(ctx context.Context, id int) (employees.Employee, error) {
+	var e employees.Employee
+	err := r.get.QueryRowContext(ctx, id).Scan(&e.ID, &e.Name, &e.Salary)
+	if errors.Is(err, sql.ErrNoRows) {
+		return employees.Employee{}, fmt.Errorf("id %d: %w", id, ErrNotFound)
+	}
+	return e, err
+}
+
+// List returns every employee in ID order.
+func (r *Repository) List(ctx context.Context) ([]employees.Employee, error) {
+	rows, err := r.list.QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []employees.Employee
+	for rows.Next() {
+		var e employees.Employee
+		if err := rows.Scan(&e.ID, &e.Name, &e.Salary); err != nil {
+			return nil, err
+		}
+		list = append(list, e)
+	}
+	return list, rows.Err()
+}
+
+// Update replaces the name and salary of the employee with e's ID.
+func (r *Repository) Update(ctx context.Context, e employees.Employee) error {
+	res, err := r.update.ExecContext(ctx, e.Name, e.Salary, e.ID)
+	if err != nil {
+		return constraintError(err)
+	}
+	return mustAffect(res, e.ID)
+}
+
+// Delete removes the employee with the given ID.
+func (r *Repository) Delete(ctx context.Context, id int) error {
+	res, err := r.del.ExecContext(ctx, id)
+	if err != nil {
+		return err
+	}
+	return mustAffect(res, id)
+}
+
+// mustAffect turns an UPDATE or DELETE that matched no row into
+// ErrNotFound; SQL itself does not treat that as an error.
+func mustAffect(res sql.Result, id int) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("id %d: %w", id, ErrNotFound)
+	}
+	return nil
+}
+
+// constraintError wraps a constraint violation reported by SQLite in
+// ErrDuplicate or ErrConstraint, keeping the driver's error, with the
+// name of the failed constraint, in the chain.
+func constraintError(err error) error {
+	var se *sqlite.Error
+	if !errors.As(err, &se) {
+		return err
+	}
+	switch se.Code() {
+	case sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY, sqlite3.SQLITE_CONSTRAINT_UNIQUE:
+		return fmt.Errorf("%w: %w", ErrDuplicate, err)
+	case sqlite3.SQLITE_CONSTRAINT_CHECK, sqlite3.SQLITE_CONSTRAINT_NOTNULL:
+		return fmt.Errorf("%w: %w", ErrConstraint, err)
+	}
+	return err
+}
@@ -0,0 +1,234 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+
+	"learning-go/chapter7/employees"
+
+	"modernc.org/sqlite"
+)
+
+// newRepo returns a repository on an in-memory database that is closed
+// when the test ends.
+func newRepo(t *testing.T) *Repository {
+	t.Helper()
+	r, err := Open(context.Background(), ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { r.Close() })
+	return r
+}
+
+func TestCRUD(t *testing.T) {
+	ctx := context.Background()
+	r := newRepo(t)
+
+	ada, err := r.Create(ctx, employees.Employee{Name: "Ada", Salary: 4200})
+	if err != nil || ada.ID != 1 {
+		t.Fatalf("Create = %+v, %v; want ID 1", ada, err)
+	}
+	ken, err := r.Create(ctx, employees.Employee{ID: 10, Name: "Ken", Salary: 3900})
+	if err != nil || ken.ID != 10 {
+		t.Fatalf("Create with ID 10 = %+v, %v", ken, err)
+	}
+	// After an explicit ID, the database carries on from the largest.
+	if rob, _ := r.Create(ctx, employees.Employee{Name: "Rob", Salary: 3000}); rob.ID != 11 {
+		t.Errorf("next chosen ID = %d, want 11", rob.ID)
+	}
+
+	if got, err := r.GetByID(ctx, 10); err != nil || got != ken {
+		t.Errorf("GetByID(10) = %+v, %v; want %+v", got, err, ken)
+	}
+	ken.Salary = 4100
+	if err := r.Update(ctx, ken); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if err := r.Delete(ctx, 11); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	list, err := r.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []employees.Employee{ada, ken}; !slices.Equal(list, want) {
+		t.Errorf("List = %+v, want %+v", list, want)
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	ctx := context.Background()
+	r := newRepo(t)
+	for name, err := range map[string]error{
+		"GetByID": func() error { _, err := r.GetByID(ctx, 7); return err }(),
+		"Update":  r.Update(ctx, employees.Employee{ID: 7, Name: "X", Salary: 1}),
+		"Delete":  r.Delete(ctx, 7),
+	} {
+		if !errors.Is(err, ErrNotFound) || !errors.Is(err, employees.ErrNotFound) {
+			t.Errorf("%s of a missing ID = %v, want ErrNotFound", name, err)
+		}
+	}
+	if list, err := r.List(ctx); err != nil || len(list) != 0 {
+		t.Errorf("List of an empty table = %v, %v", list, err)
+	}
+}
+
+func TestConstraintViolations(t *testing.T) {
+	ctx := context.Background()
+	r := newRepo(t)
+	ada, _ := r.Create(ctx, employees.Employee{Name: "Ada", Salary: 4200})
+	ken, _ := r.Create(ctx, employees.Employee{Name: "Ken", Salary: 3900})
+
+	tests := []struct {
+		name string
+		run  func() error
+		want error
+	}{
+		{"empty name", func() error {
+			_, err := r.Create(ctx, employees.Employee{Name: "", Salary: 1})
+			return err
+		}, ErrConstraint},
+		{"negative salary", func() error {
+			_, err := r.Create(ctx, employees.Employee{Name: "Rob", Salary: -1})
+			return err
+		}, ErrConstraint},
+		{"taken ID", func() error {
+			_, err := r.Create(ctx, employees.Employee{ID: ada.ID, Name: "Rob", Salary: 1})
+			return err
+		}, ErrDuplicate},
+		{"taken name", func() error {
+			_, err := r.Create(ctx, employees.Employee{Name: "Ada", Salary: 1})
+			return err
+		}, ErrDuplicate},
+		{"update to a taken name", func() error {
+			return r.Update(ctx, employees.Employee{ID: ken.ID, Name: "Ada", Salary: 1})
+		}, ErrDuplicate},
+		{"update to a negative salary", func() error {
+			return r.Update(ctx, employees.Employee{ID: ken.ID, Name: "Ken", Salary: -5})
+		}, ErrConstraint},
+	}
+	for _, tt := range tests {
+		err := tt.run()
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.want)
+			continue
+		}
+		// The driver's error, which names the constraint, stays reachable.
+		var se *sqlite.Error
+		if !errors.As(err, &se) {
+			t.Errorf("%s: %v does not wrap the *sqlite.Error", tt.name, err)
+		}
+	}
+
+	// None of the failed writes changed anything.
+	list, _ := r.List(ctx)
+	if want := []employees.Employee{ada, ken}; !slices.Equal(list, want) {
+		t.Errorf("after the violations List = %+v, want %+v", list, want)
+	}
+}
+
+func TestCreateAllRollsBack(t *testing.T) {
+	ctx := context.Background()
+	r := newRepo(t)
+	batch := []employees.Employee{{Name: "Ada", Salary: 1}, {Name: "Ken", Salary: 2}, {Name: "Ada", Salary: 3}}
+	_, err := r.CreateAll(ctx, batch)
+	if !errors.Is(err, ErrDuplicate) {
+		t.Fatalf("CreateAll with a duplicate = %v, want ErrDuplicate", err)
+	}
+	if list, _ := r.List(ctx); len(list) != 0 {
+		t.Errorf("a failed CreateAll left %+v behind", list)
+	}
+
+	created, err := r.CreateAll(ctx, batch[:2])
+	if err != nil || len(created) != 2 || created[1].ID != 2 {
+		t.Fatalf("CreateAll = %+v, %v", created, err)
+	}
+	if list, _ := r.List(ctx); !slices.Equal(list, created) {
+		t.Errorf("List = %+v, want %+v", list, created)
+	}
+}
+
+// Run with -race: the repository is shared by many goroutines.
+func TestConcurrentCreate(t *testing.T) {
+	ctx := context.Background()
+	r := newRepo(t)
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := r.Create(ctx, employees.Employee{Name: fmt.Sprint("e", i), Salary: i}); err != nil {
+				t.Errorf("Create: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if list, _ := r.List(ctx); len(list) != 20 {
+		t.Errorf("got %d employees, want 20", len(list))
+	}
+}
+
+func TestMigrations(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "employees.db")
+
+	// A database from a release that knew only the first migration, with
+	// data the second one, the unique index, rejects.
+	old, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer old.Close()
+	if err := migrate(ctx, old, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := old.ExecContext(ctx, `INSERT INTO employees (name, salary) VALUES ('Ada', 1), ('Ada', 2)`); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Open(ctx, path); err == nil {
+		t.Fatal("Open migrated a table holding duplicate names")
+	}
+	if v, _ := version(ctx, old); v != 1 {
+		t.Errorf("after the failed migration, version = %d, want it left at 1", v)
+	}
+
+	if _, err := old.ExecContext(ctx, `UPDATE employees SET name = 'Ada L' WHERE salary = 2`); err != nil {
+		t.Fatal(err)
+	}
+	r, err := Open(ctx, path)
+	if err != nil {
+		t.Fatalf("Open after fixing the data: %v", err)
+	}
+	defer r.Close()
+	if v, _ := r.Version(ctx); v != len(migrations) {
+		t.Errorf("Version() = %d, want %d", v, len(migrations))
+	}
+	if list, _ := r.List(ctx); len(list) != 2 {
+		t.Errorf("migration lost data: %+v", list)
+	}
+}
+
+func TestNewerSchemaRefused(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "employees.db")
+	future, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer future.Close()
+	if _, err := future.ExecContext(ctx, fmt.Sprintf(`PRAGMA user_version = %d`, len(migrations)+1)); err != nil {
+		t.Fatal(err)
+	}
+	if r, err := Open(ctx, path); err == nil {
+		r.Close()
+		t.Error("Open accepted a schema newer than it knows")
+	}
+}
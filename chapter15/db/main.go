@@ -0,0 +1,215 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"learning-go/chapter7/employees"
+	"learning-go/registry"
+)
+
+func init() {
+	// Register each exercise with the runner (cmd/learn)
+	registry.Register("chapter15/db", "exercise1", exercise1)
+	registry.Register("chapter15/db", "exercise2", exercise2)
+	registry.Register("chapter15/db", "exercise3", exercise3)
+}
+
+// printAll lists every employee in r, indented.
+func printAll(ctx context.Context, w io.Writer, r *Repository) {
+	list, err := r.List(ctx)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	fmt.Fprintf(w, "%d employees\n", len(list))
+	for _, e := range list {
+		fmt.Fprintf(w, "  %2d %-16s %6d\n", e.ID, e.Name, e.Salary)
+	}
+}
+
+// Exercise 1: Open an in-memory database and create, read, update and
+// delete employees through the Repository, including the IDs that do not
+// exist.
+func exercise1(w io.Writer) {
+	ctx := context.Background()
+	r, err := Open(ctx, ":memory:")
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	defer r.Close()
+	v, _ := r.Version(ctx)
+	fmt.Fprintln(w, "schema version:", v)
+
+	for _, e := range []employees.Employee{
+		{Name: "Ada Lovelace", Salary: 4200},
+		{Name: "Grace Hopper", Salary: 5100},
+		{ID: 10, Name: "Ken Thompson", Salary: 4800},
+		{Name: "Rob Pike", Salary: 4700},
+	} {
+		created, err := r.Create(ctx, e)
+		if err != nil {
+			fmt.Fprintln(w, err)
+			return
+		}
+		fmt.Fprintf(w, "created %+v\n", created)
+	}
+
+	e, err := r.GetByID(ctx, 2)
+	fmt.Fprintf(w, "GetByID(2): %+v %v\n", e, err)
+	e.Salary += 500
+	fmt.Fprintln(w, "Update a raise for #2:", r.Update(ctx, e))
+	fmt.Fprintln(w, "Delete(10):", r.Delete(ctx, 10))
+	printAll(ctx, w, r)
+
+	_, err = r.GetByID(ctx, 10)
+	fmt.Fprintln(w, "GetByID(10):", err, errors.Is(err, ErrNotFound))
+	err = r.Update(ctx, employees.Employee{ID: 99, Name: "Nobody"})
+	fmt.Fprintln(w, "Update(99):", err, errors.Is(err, ErrNotFound))
+	err = r.Delete(ctx, 10)
+	fmt.Fprintln(w, "Delete(10) again:", err, errors.Is(err, employees.ErrNotFound))
+
+	// Explanation:
+	// database/sql is a pool of connections to whatever driver was
+	// imported for its side effect, here the pure-Go SQLite driver, so the
+	// program needs no C compiler. Each ":memory:" connection is a new
+	// empty database, which is why Open limits the pool to one: it makes
+	// an in-memory database a fast, private fixture for tests. The
+	// statements are prepared once in Open and reused, so SQLite parses
+	// each only once, and ? placeholders pass values separately from the
+	// SQL, which rules out injection. A SELECT that finds nothing is
+	// sql.ErrNoRows, but an UPDATE or DELETE that matches nothing is not
+	// an error at all; RowsAffected is how the Repository notices and
+	// returns ErrNotFound. SQLite picks the next ID after the largest in
+	// use, so the employee created after #10 got 11.
+}
+
+// Exercise 2: Break each of the table's constraints, then insert a batch
+// with one bad employee in the middle and check that the transaction
+// leaves the table as it was.
+func exercise2(w io.Writer) {
+	ctx := context.Background()
+	r, err := Open(ctx, ":memory:")
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	defer r.Close()
+
+	if _, err := r.Create(ctx, employees.Employee{ID: 1, Name: "Ada Lovelace", Salary: 4200}); err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	for _, e := range []employees.Employee{
+		{ID: 1, Name: "Someone Else", Salary: 3000},
+		{Name: "Ada Lovelace", Salary: 3000},
+		{Name: "", Salary: 3000},
+		{Name: "Linus Torvalds", Salary: -1},
+	} {
+		_, err := r.Create(ctx, e)
+		fmt.Fprintf(w, "Create(%+v):\n  %v\n  duplicate %v, constraint %v\n",
+			e, err, errors.Is(err, ErrDuplicate), errors.Is(err, ErrConstraint))
+	}
+	err = r.Update(ctx, employees.Employee{ID: 1, Name: "Ada Lovelace", Salary: -100})
+	fmt.Fprintln(w, "Update with a negative salary:", errors.Is(err, ErrConstraint))
+
+	batch := []employees.Employee{
+		{Name: "Grace Hopper", Salary: 5100},
+		{Name: "Ken Thompson", Salary: 4800},
+		{Name: "Grace Hopper", Salary: 4700},
+		{Name: "Rob Pike", Salary: 4700},
+	}
+	_, err = r.CreateAll(ctx, batch)
+	fmt.Fprintln(w, "CreateAll with a duplicate:", err)
+	printAll(ctx, w, r)
+
+	batch[2].Name = "Robert Griesemer"
+	created, err := r.CreateAll(ctx, batch)
+	fmt.Fprintln(w, "CreateAll fixed:", len(created), "created,", err)
+	printAll(ctx, w, r)
+
+	// Explanation:
+	// The table's PRIMARY KEY, UNIQUE index and CHECK clauses hold however
+	// the data arrives, from this program or any other, so they are the
+	// last line of defence even when the code validates first. The driver
+	// reports a violation as a *sqlite.Error whose extended code says
+	// which kind it was; constraintError turns the code into ErrDuplicate
+	// or ErrConstraint with the %w verb used twice, so callers can test for
+	// either with errors.Is and still read SQLite's message naming the
+	// column. CreateAll runs its inserts in one transaction: the third
+	// insert fails, fn returns the error, and the deferred Rollback undoes
+	// the first two, so the batch is all or nothing.
+}
+
+// Exercise 3: Upgrade a database file left at schema version 1 by an older
+// release. Watch the missing migration fail on the old data and roll back,
+// fix the data, and check that Open then applies only that migration and
+// that reopening changes nothing.
+func exercise3(w io.Writer) {
+	ctx := context.Background()
+	dir, err := os.MkdirTemp("", "db-")
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "employees.db")
+
+	// An older release of the program knew only the first migration.
+	old, err := sql.Open("sqlite", path)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	err = migrate(ctx, old, 1)
+	if err == nil {
+		_, err = old.ExecContext(ctx,
+			`INSERT INTO employees (name, salary) VALUES ('Ada Lovelace', 4200), ('Ada Lovelace', 3900)`)
+	}
+	v, _ := version(ctx, old)
+	old.Close()
+	fmt.Fprintln(w, "old database at version", v, "with a duplicate name, error:", err)
+
+	// The duplicate makes migration 2, the unique index, fail: Open
+	// reports it, and the database stays at version 1.
+	_, err = Open(ctx, path)
+	fmt.Fprintln(w, "Open:", err)
+	old, _ = sql.Open("sqlite", path)
+	v, _ = version(ctx, old)
+	fmt.Fprintln(w, "still at version", v)
+	_, err = old.ExecContext(ctx, `DELETE FROM employees WHERE salary = 3900`)
+	old.Close()
+	fmt.Fprintln(w, "removed the duplicate:", err)
+
+	for i := range 2 {
+		r, err := Open(ctx, path)
+		if err != nil {
+			fmt.Fprintln(w, err)
+			return
+		}
+		v, _ := r.Version(ctx)
+		fmt.Fprintf(w, "open %d: version %d\n", i+1, v)
+		if i == 0 {
+			r.Create(ctx, employees.Employee{Name: "Grace Hopper", Salary: 5100})
+		}
+		printAll(ctx, w, r)
+		r.Close()
+	}
+
+	// Explanation:
+	// The migrations are a list that only grows, and SQLite's user_version
+	// records how many of them a database has had, so Open can run on a new
+	// file, an old one or an up-to-date one and apply exactly what is
+	// missing. Each migration runs in a transaction with its version bump:
+	// SQLite's schema changes are transactional, so the failed unique index
+	// left neither an index nor a new version behind, and the migration
+	// could be retried once the data was fixed. A program that finds a
+	// version newer than it knows refuses to run rather than guess at the
+	// schema.
+}
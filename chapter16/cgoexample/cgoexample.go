@@ -0,0 +1,8 @@
+// Package cgoexample shows how Go calls into C with cgo, and how to keep a
+// package buildable when cgo is unavailable.
+//
+// greeting_cgo.go is only compiled when cgo is enabled (the cgo build tag
+// is set automatically when CGO_ENABLED=1 and a C compiler is found).
+// greeting_nocgo.go has the opposite constraint and implements the same
+// functions in pure Go. Build with CGO_ENABLED=0 to get the fallback.
+package cgoexample
@@ -0,0 +1,24 @@
+package cgoexample
+
+import "testing"
+
+// TestGreeting runs against whichever file the build picked; run it with
+// CGO_ENABLED=0 as well to cover the fallback.
+func TestGreeting(t *testing.T) {
+	want := map[string]string{
+		"cgo":     "Hello from C, Gopher!",
+		"pure Go": "Hello from Go, Gopher!",
+	}[Implementation()]
+	if want == "" {
+		t.Fatalf("Implementation() = %q, want cgo or pure Go", Implementation())
+	}
+	if got := Greeting("Gopher"); got != want {
+		t.Errorf("Greeting(Gopher) = %q, want %q", got, want)
+	}
+	// The C side sizes its buffer with snprintf, so long and non-ASCII
+	// names must come back whole.
+	long := "Gopher, 日本, and a name long enough to outgrow any fixed buffer"
+	if got := Greeting(long); got != want[:len(want)-len("Gopher!")]+long+"!" {
+		t.Errorf("Greeting(%q) = %q", long, got)
+	}
+}
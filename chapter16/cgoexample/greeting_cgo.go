@@ -0,0 +1,44 @@
+//go:build cgo
+
+package cgoexample
+
+/*
+#include <stdio.h>
+#include <stdlib.h>
+
+// greet writes a greeting into a newly malloc'ed buffer.
+// The caller owns the result and must free it.
+static char* greet(const char* name) {
+	size_t n = snprintf(NULL, 0, "Hello from C, %s!", name) + 1;
+	char* buf = malloc(n);
+	if (buf != NULL) {
+		snprintf(buf, n, "Hello from C, %s!", name);
+	}
+	return buf;
+}
+*/
+import "C"
+
+import "unsafe"
+
+// Implementation reports which version of the package was compiled in.
+func Implementation() string {
+	return "cgo"
+}
+
+// Greeting builds a greeting for name by calling the C function greet.
+func Greeting(name string) string {
+	// C.CString copies the Go string into C memory. Go's garbage collector
+	// does not manage that memory, so it has to be freed explicitly.
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	cGreeting := C.greet(cName)
+	if cGreeting == nil {
+		return ""
+	}
+	defer C.free(unsafe.Pointer(cGreeting))
+
+	// C.GoString copies the C string back into a Go string.
+	return C.GoString(cGreeting)
+}
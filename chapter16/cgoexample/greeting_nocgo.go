@@ -0,0 +1,15 @@
+//go:build !cgo
+
+package cgoexample
+
+// Implementation reports which version of the package was compiled in.
+func Implementation() string {
+	return "pure Go"
+}
+
+// Greeting builds a greeting for name. This is the fallback used when cgo
+// is disabled; it says Go rather than C, so the output shows which file
+// was compiled in.
+func Greeting(name string) string {
+	return "Hello from Go, " + name + "!"
+}
@@ -0,0 +1,105 @@
+// Package layout reports how the compiler lays out a struct in memory:
+// the offset, size and alignment of every field and the padding inserted
+// between them. The numbers are the same ones unsafe.Offsetof,
+// unsafe.Sizeof and unsafe.Alignof return, read through reflection so that
+// any struct can be inspected without naming its fields.
+package layout
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Field describes one struct field.
+type Field struct {
+	Name   string
+	Type   string
+	Offset uintptr
+	Size   uintptr
+	Align  uintptr
+	// Padding is the number of unused bytes after this field, before the
+	// next field starts (or before the end of the struct for the last one).
+	Padding uintptr
+}
+
+// Layout describes a whole struct.
+type Layout struct {
+	Type   string
+	Size   uintptr
+	Align  uintptr
+	Fields []Field
+}
+
+// Padding returns the total number of wasted bytes in the struct.
+func (l Layout) Padding() uintptr {
+	var total uintptr
+	for _, f := range l.Fields {
+		total += f.Padding
+	}
+	return total
+}
+
+// Of returns the layout of v, which must be a struct or a pointer to one.
+func Of(v any) (Layout, error) {
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return Layout{}, fmt.Errorf("layout: %T is not a struct", v)
+	}
+
+	l := Layout{Type: t.String(), Size: t.Size(), Align: uintptr(t.Align())}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		l.Fields = append(l.Fields, Field{
+			Name:   sf.Name,
+			Type:   sf.Type.String(),
+			Offset: sf.Offset,
+			Size:   sf.Type.Size(),
+			Align:  uintptr(sf.Type.Align()),
+		})
+	}
+	for i := range l.Fields {
+		end := l.Size
+		if i+1 < len(l.Fields) {
+			end = l.Fields[i+1].Offset
+		}
+		l.Fields[i].Padding = end - (l.Fields[i].Offset + l.Fields[i].Size)
+	}
+	return l, nil
+}
+
+// OptimalSize returns the size the struct would have if its fields were
+// reordered from largest to smallest alignment, which minimizes padding.
+func (l Layout) OptimalSize() uintptr {
+	fields := append([]Field(nil), l.Fields...)
+	sort.SliceStable(fields, func(i, j int) bool { return fields[i].Align > fields[j].Align })
+
+	var offset uintptr
+	for _, f := range fields {
+		offset = alignUp(offset, f.Align)
+		offset += f.Size
+	}
+	return alignUp(offset, l.Align)
+}
+
+func alignUp(n, align uintptr) uintptr {
+	if align == 0 {
+		return n
+	}
+	return (n + align - 1) / align * align
+}
+
+// String renders the layout as a table with one row per field.
+func (l Layout) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s: size %d, align %d, padding %d\n", l.Type, l.Size, l.Align, l.Padding())
+	fmt.Fprintf(&sb, "  %-12s %-10s %6s %4s %5s %7s\n", "field", "type", "offset", "size", "align", "padding")
+	for _, f := range l.Fields {
+		fmt.Fprintf(&sb, "  %-12s %-10s %6d %4d %5d %7d\n", f.Name, f.Type, f.Offset, f.Size, f.Align, f.Padding)
+	}
+	return sb.String()
+}
@@ -0,0 +1,101 @@
+package layout
+
+import (
+	"runtime"
+	"testing"
+	"unsafe"
+)
+
+type padded struct {
+	active   bool
+	id       int64
+	level    int8
+	salary   int64
+	remote   bool
+	position int32
+}
+
+type packed struct {
+	id       int64
+	salary   int64
+	position int32
+	level    int8
+	active   bool
+	remote   bool
+}
+
+// sizes are the expected layouts per GOARCH. On 32-bit platforms int64 is
+// only 4-byte aligned, so padded shrinks and both structs align to 4.
+type sizes struct {
+	paddedSize, paddedPadding, packedSize, align uintptr
+}
+
+var want64 = sizes{paddedSize: 40, paddedPadding: 17, packedSize: 24, align: 8}
+var want32 = sizes{paddedSize: 32, paddedPadding: 9, packedSize: 24, align: 4}
+
+var byArch = map[string]sizes{
+	"amd64": want64, "arm64": want64, "ppc64": want64, "ppc64le": want64,
+	"riscv64": want64, "s390x": want64, "loong64": want64, "mips64": want64,
+	"mips64le": want64, "wasm": want64,
+	"386": want32, "arm": want32, "mips": want32, "mipsle": want32,
+}
+
+func TestSizesPerArch(t *testing.T) {
+	want, ok := byArch[runtime.GOARCH]
+	if !ok {
+		t.Skipf("no expected sizes for GOARCH=%s", runtime.GOARCH)
+	}
+	p, err := Of(padded{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, err := Of(&packed{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Size != want.paddedSize || p.Padding() != want.paddedPadding || p.Align != want.align {
+		t.Errorf("padded: size %d, padding %d, align %d; want %d, %d, %d",
+			p.Size, p.Padding(), p.Align, want.paddedSize, want.paddedPadding, want.align)
+	}
+	if q.Size != want.packedSize {
+		t.Errorf("packed: size %d, want %d", q.Size, want.packedSize)
+	}
+	if got := p.OptimalSize(); got != want.packedSize {
+		t.Errorf("padded.OptimalSize() = %d, want %d", got, want.packedSize)
+	}
+}
+
+// TestMatchesUnsafe holds on every architecture: reflection and unsafe
+// must agree.
+func TestMatchesUnsafe(t *testing.T) {
+	var v padded
+	l, err := Of(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	offsets := []uintptr{
+		unsafe.Offsetof(v.active), unsafe.Offsetof(v.id), unsafe.Offsetof(v.level),
+		unsafe.Offsetof(v.salary), unsafe.Offsetof(v.remote), unsafe.Offsetof(v.position),
+	}
+	if l.Size != unsafe.Sizeof(v) || l.Align != unsafe.Alignof(v) {
+		t.Errorf("size %d align %d, unsafe says %d and %d", l.Size, l.Align, unsafe.Sizeof(v), unsafe.Alignof(v))
+	}
+	var total uintptr
+	for i, f := range l.Fields {
+		if f.Offset != offsets[i] {
+			t.Errorf("%s at offset %d, unsafe says %d", f.Name, f.Offset, offsets[i])
+		}
+		total += f.Size + f.Padding
+	}
+	if total+l.Fields[0].Offset != l.Size {
+		t.Errorf("fields and padding add up to %d, want size %d", total, l.Size)
+	}
+}
+
+func TestOfRejectsNonStructs(t *testing.T) {
+	for _, v := range []any{nil, 3, new(int), []padded{}} {
+		if _, err := Of(v); err == nil {
+			t.Errorf("Of(%T) succeeded", v)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package chapter16
+
+import (
+	"fmt"
+	"io"
+	"unsafe"
+
+	"learning-go/chapter16/cgoexample"
+	"learning-go/chapter16/layout"
+	"learning-go/registry"
+)
+
+func init() {
+	// Register each exercise with the runner (cmd/learn)
+	registry.Register("chapter16", "exercise1", exercise1)
+	registry.Register("chapter16", "exercise2", exercise2)
+}
+
+// Exercise 1: Call a C function from Go with cgo, and fall back to a pure
+// Go implementation when cgo is disabled.
+func exercise1(w io.Writer) {
+	fmt.Fprintln(w, "Implementation:", cgoexample.Implementation())
+	fmt.Fprintln(w, cgoexample.Greeting("Gopher"))
+
+	// Explanation:
+	// Run this once normally and once with CGO_ENABLED=0. The first run
+	// calls into C (C.CString, C.GoString and C.free move data across the
+	// boundary) and greets from C; the second uses the //go:build !cgo
+	// fallback file and greets from Go. Keeping a fallback means the module
+	// still builds for targets without a C toolchain.
+}
+
+// Exercise 2: Print the memory layout of an Employee-like struct whose
+// fields are declared in an unlucky order, then reorder the fields from
+// largest to smallest and compare the sizes.
+func exercise2(w io.Writer) {
+	// Small fields between large ones force the compiler to insert padding
+	// so that every int64 starts on an 8-byte boundary.
+	type PaddedEmployee struct {
+		active   bool
+		id       int64
+		level    int8
+		salary   int64
+		remote   bool
+		position int32
+	}
+
+	// The same fields, largest first. Only the final few bytes of padding
+	// remain, to round the size up to the struct's alignment.
+	type PackedEmployee struct {
+		id       int64
+		salary   int64
+		position int32
+		level    int8
+		active   bool
+		remote   bool
+	}
+
+	padded, _ := layout.Of(PaddedEmployee{})
+	packed, _ := layout.Of(PackedEmployee{})
+	fmt.Fprint(w, padded)
+	fmt.Fprint(w, packed)
+	fmt.Fprintf(w, "Optimal size for PaddedEmployee: %d bytes\n", padded.OptimalSize())
+
+	// The reflection results match what the unsafe package reports
+	var e PaddedEmployee
+	fmt.Fprintln(w, "unsafe.Sizeof:", unsafe.Sizeof(e),
+		"unsafe.Alignof(id):", unsafe.Alignof(e.id),
+		"unsafe.Offsetof(salary):", unsafe.Offsetof(e.salary))
+
+	// Explanation:
+	// On 64-bit platforms PaddedEmployee takes 40 bytes but PackedEmployee
+	// only 24, even though both hold exactly the same data. On 32-bit
+	// platforms such as GOARCH=386, int64 is only 4-byte aligned, so the
+	// numbers are smaller and the difference shrinks. Ordering fields by
+	// decreasing alignment is an easy win for structs stored in large slices.
+}
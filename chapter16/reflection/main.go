@@ -0,0 +1,186 @@
+package reflection
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"learning-go/registry"
+	"learning-go/validate"
+)
+
+func init() {
+	// Register each exercise with the runner (cmd/learn)
+	registry.Register("chapter16/reflection", "exercise1", exercise1)
+	registry.Register("chapter16/reflection", "exercise2", exercise2)
+	registry.Register("chapter16/reflection", "exercise3", exercise3)
+	registry.Register("chapter16/reflection", "exercise4", exercise4)
+}
+
+// Address is nested inside Employee to show that rules are checked at
+// every level.
+type Address struct {
+	City string `validate:"required"`
+	Zip  string `validate:"min=5,max=5"`
+}
+
+// Employee has rules on exported and unexported fields, a nested struct, a
+// pointer to a struct and a slice.
+type Employee struct {
+	Name    string   `validate:"required,min=3"`
+	Email   string   `validate:"required,email"`
+	Age     int      `validate:"min=18,max=70"`
+	Skills  []string `validate:"max=3"`
+	Home    Address
+	Office  *Address
+	badge   string `validate:"required"`
+	Manager *Employee
+}
+
+// Exercise 1: Validate employees against their struct tags: a valid one, a
+// pointer to one with several mistakes, including in nested and unexported
+// fields, and inputs that cannot be validated at all.
+func exercise1(w io.Writer) {
+	ada := Employee{
+		Name: "Ada Lovelace", Email: "ada@example.com", Age: 36,
+		Home:  Address{City: "London", Zip: "10001"},
+		badge: "A-1",
+	}
+	fmt.Fprintln(w, "valid employee:", Validate(ada))
+
+	bob := &Employee{
+		Name: "Bo", Email: "bob at example", Age: 17,
+		Skills: []string{"go", "sql", "k8s", "rust"},
+		Office: &Address{Zip: "123"},
+	}
+	err := Validate(bob)
+	var verrs validate.Errors
+	if errors.As(err, &verrs) {
+		fmt.Fprintf(w, "pointer to an employee with %d violations:\n", len(verrs))
+		for _, fe := range verrs {
+			fmt.Fprintf(w, "  %-12s %-16s %s\n", fe.Path, fe.Rule, fe.Message)
+		}
+	}
+
+	fmt.Fprintln(w, "not a struct:", Validate(42))
+	fmt.Fprintln(w, "  errors.Is ErrNotStruct:", errors.Is(Validate("x"), validate.ErrNotStruct))
+	type Bad struct {
+		N int `validate:"min=ten"`
+	}
+	fmt.Fprintln(w, "malformed tag:", Validate(Bad{}))
+
+	// Explanation:
+	// reflect.TypeOf gives the struct's fields and their tags, and
+	// reflect.ValueOf the values to check them against; Field(i).Tag.Lookup
+	// is how any tag-driven library, encoding/json included, reads its
+	// options. The validator follows pointers with Elem, so a pointer to a
+	// struct and a *Address field are checked like values, and a nil
+	// pointer is simply skipped. Reading an unexported field such as badge
+	// is allowed; only Interface() and setting it are not, so rules that
+	// look at the value through Len, Int or IsZero work on it too. A
+	// malformed rule is a programming error, reported as a plain error
+	// rather than as a violation.
+}
+
+// Exercise 2: Pretty-print values with Dump and compare it with %v and %+v:
+// nested structs, slices, maps with sorted keys, nil pointers, interfaces
+// and unexported fields.
+func exercise2(w io.Writer) {
+	e := Employee{
+		Name: "Grace", Email: "grace@example.com", Age: 45,
+		Skills: []string{"cobol"},
+		Home:   Address{City: "Arlington", Zip: "22201"},
+		badge:  "G-7",
+	}
+	fmt.Fprintf(w, "%%v:  %v\n", e)
+	fmt.Fprintf(w, "%%+v: %+v\n", e.Home)
+	fmt.Fprintln(w, "Dump:", Dump(e))
+
+	teams := map[string][]any{
+		"compilers": {"grace", 2, nil},
+		"analytics": {&Address{City: "Paris"}},
+	}
+	fmt.Fprintln(w, "Dump of a map:", Dump(teams))
+
+	// Explanation:
+	// %v prints values without types or field names, and a non-nil
+	// pointer inside a struct only as an address; Dump names every field,
+	// shows each value's type and follows the pointers it meets. It switches on Value.Kind, the small
+	// fixed set of shapes types come in, rather than on types, which is
+	// what lets one function print types it has never seen. Elements of
+	// a []any are interfaces, and Dump looks through them with Elem to
+	// the dynamic type they hold. Map iteration order is random, so the
+	// keys are sorted to keep the output stable.
+}
+
+// Node is a doubly linked list node: following Next and then Prev leads
+// back to where you started.
+type Node struct {
+	Value int `validate:"min=0"`
+	Next  *Node
+	Prev  *Node
+}
+
+// Exercise 3: Build values that point back to themselves, an employee who
+// is their own manager and a two-node doubly linked list, and show that
+// Validate and Dump both finish.
+func exercise3(w io.Writer) {
+	boss := &Employee{Name: "Ken", Email: "ken@example.com", Age: 70, badge: "K"}
+	boss.Manager = boss
+	fmt.Fprintln(w, "self-managed employee, Validate:", Validate(boss))
+	fmt.Fprintln(w, "self-managed employee, Dump:", Dump(boss))
+
+	a, b := &Node{Value: 1}, &Node{Value: -2}
+	a.Next, b.Prev = b, a
+	a.Prev, b.Next = b, a // a ring of two
+	fmt.Fprintln(w, "ring, Validate:", Validate(a))
+	fmt.Fprintln(w, "ring, Dump:", Dump(a))
+
+	// Explanation:
+	// A naive walk recurses forever on these values and dies with a stack
+	// overflow. Both tools remember the pointers they have followed, by the
+	// address Value.Pointer returns: the validator checks each struct once,
+	// which is enough for validation, while Dump tracks only the pointers
+	// on the current path, so a node reached twice by different routes is
+	// still printed both times and only a real loop becomes <cycle>.
+	// Cycles need pointers, maps, slices or interfaces; a struct cannot
+	// contain itself by value.
+}
+
+// Exercise 4: Use reflect directly: tell a Type from its Kind, read struct
+// tags, find out which fields can be read with Interface and which can be
+// set, and set one through a pointer.
+func exercise4(w io.Writer) {
+	type Celsius float64
+	for _, v := range []any{Celsius(21.5), 21.5, []Celsius{}, &Node{}, Employee{}} {
+		t := reflect.TypeOf(v)
+		fmt.Fprintf(w, "type %-22s kind %s\n", t, t.Kind())
+	}
+
+	t := reflect.TypeOf(Employee{})
+	for _, name := range []string{"Name", "badge", "Home"} {
+		f, _ := t.FieldByName(name)
+		fmt.Fprintf(w, "field %-5s exported %-5v tag %q\n", f.Name, f.IsExported(), f.Tag.Get("validate"))
+	}
+
+	e := Employee{Name: "Dennis"}
+	v := reflect.ValueOf(e)
+	fmt.Fprintln(w, "from a value: Name CanSet", v.FieldByName("Name").CanSet(),
+		"| badge CanInterface", v.FieldByName("badge").CanInterface())
+	pv := reflect.ValueOf(&e).Elem()
+	fmt.Fprintln(w, "through a pointer: Name CanSet", pv.FieldByName("Name").CanSet(),
+		"| badge CanSet", pv.FieldByName("badge").CanSet())
+	pv.FieldByName("Name").SetString("Dennis Ritchie")
+	fmt.Fprintln(w, "after SetString:", e.Name)
+
+	// Explanation:
+	// A Type is the exact named type, such as reflection.Celsius, and its
+	// Kind is the underlying category, float64; code that walks arbitrary
+	// values switches on Kind. reflect.ValueOf(e) holds a copy of e, so
+	// changing it could never reach e and nothing in it is settable. A
+	// Value obtained from a pointer with Elem is addressable, and its
+	// exported fields can be set. Unexported fields can be read with
+	// methods such as String but not set or turned back into an any, which
+	// keeps reflection from breaking a package's encapsulation.
+}
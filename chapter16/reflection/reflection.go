@@ -0,0 +1,44 @@
+// Package reflection is chapter 16's tour of the reflect package through
+// two tools that work on values whose types they have never seen: a
+// validator driven by struct tags and a pretty-printer.
+//
+//	type Employee struct {
+//		Name  string `validate:"required,min=3"`
+//		Email string `validate:"email"`
+//	}
+//	err := reflection.Validate(Employee{Name: "Al"})
+//	fmt.Println(err)            // Name: length must be at least 3
+//	fmt.Println(reflection.Dump(Employee{Name: "Ada"}))
+//
+// The walking itself lives in the validate and dump packages, which the
+// rest of the module uses too; this package picks the settings that suit
+// the exercises.
+package reflection
+
+import (
+	"learning-go/dump"
+	"learning-go/validate"
+)
+
+// Validate checks v, a struct or a pointer to one, against the rules in its
+// `validate` struct tags and returns a validate.Errors listing every
+// violation, or nil. See the validate package for the rules.
+func Validate(v any) error {
+	return validate.Struct(v)
+}
+
+// printer is dump.Default without addresses, so the same value prints the
+// same way on every run.
+var printer = func() dump.Config {
+	c := dump.Default
+	c.OmitAddresses = true
+	return c
+}()
+
+// Dump returns a multi-line description of v that shows the type of every
+// value and the name of every field, exported or not, follows pointers,
+// sorts map keys and marks pointers back to a value being printed as
+// <cycle>.
+func Dump(v any) string {
+	return printer.Dump(v)
+}
@@ -0,0 +1,100 @@
+package reflection
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"learning-go/validate"
+)
+
+type address struct {
+	City string `validate:"required"`
+}
+
+type person struct {
+	Name    string `validate:"required,min=3"`
+	email   string `validate:"email"`
+	Home    *address
+	Friends []*person
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		v    any
+		want string
+	}{
+		{"valid", person{Name: "Ada", Home: &address{"London"}}, ""},
+		{"pointer to struct", &person{Name: "Al"}, "Name: length must be at least 3"},
+		{"unexported field", person{Name: "Ada", email: "nope"}, "email: "},
+		{"through a pointer", person{Name: "Ada", Home: &address{}}, "Home.City: is required"},
+		{"nil pointer skipped", person{Name: "Ada", Home: nil}, ""},
+		{"slice of pointers", person{Name: "Ada", Friends: []*person{{Name: "Bo"}}}, "Friends[0].Name: "},
+	}
+	for _, tt := range tests {
+		err := Validate(tt.v)
+		switch {
+		case tt.want == "" && err != nil:
+			t.Errorf("%s: Validate = %v, want nil", tt.name, err)
+		case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+			t.Errorf("%s: Validate = %v, want it to contain %q", tt.name, err, tt.want)
+		}
+	}
+	if err := Validate(42); !errors.Is(err, validate.ErrNotStruct) {
+		t.Errorf("Validate(42) = %v, want ErrNotStruct", err)
+	}
+}
+
+func TestValidateCycle(t *testing.T) {
+	a := &person{Name: "Ann"}
+	b := &person{Name: "X", Friends: []*person{a}}
+	a.Friends = []*person{b, a}
+	err := Validate(a)
+	var errs validate.Errors
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Path != "Friends[0].Name" {
+		t.Errorf("Validate of a cycle = %v, want b's name reported once", err)
+	}
+}
+
+func TestDump(t *testing.T) {
+	type node struct {
+		Name   string
+		secret int
+		Next   *node
+		Tags   map[string]int
+		Items  []int
+	}
+	n := &node{Name: "a", secret: 3, Tags: map[string]int{"z": 1, "a": 2}, Items: []int{1, 2}}
+	n.Next = n
+	want := `(*reflection.node) <ptr> -> (reflection.node) {
+  Name: (string) "a"
+  secret: (int) 3
+  Next: (*reflection.node) <ptr> <cycle>
+  Tags: (map[string]int) {
+    (string) "a": (int) 2
+    (string) "z": (int) 1
+  }
+  Items: ([]int) [
+    0: (int) 1
+    1: (int) 2
+  ]
+}`
+	if got := Dump(n); got != want {
+		t.Errorf("Dump =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestDumpNil(t *testing.T) {
+	v := struct {
+		P *address
+		I any
+	}{}
+	want := `(struct { P *reflection.address; I interface {} }) {
+  P: (*reflection.address) nil
+  I: (interface {}) nil
+}`
+	if got := Dump(v); got != want {
+		t.Errorf("Dump =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,294 @@
+// Package chat is a line-based chat server over TCP. Anyone can connect
+// with nc or telnet; every line a client sends is passed on to everyone
+// else, except for two commands:
+//
+//	/nick name   change your name
+//	/quit        leave
+//
+// One hub goroutine owns the list of clients and their names, and every
+// other goroutine talks to it through channels, so no map is ever shared
+// and there are no locks. Each connection has a reader goroutine, which
+// turns lines into events for the hub, and a writer goroutine, which
+// drains the client's outgoing queue into the socket.
+//
+//	ln, _ := net.Listen("tcp", "localhost:9000")
+//	err := chat.Serve(ctx, ln) // returns when ctx is cancelled
+package chat
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+)
+
+// Option configures Serve.
+type Option func(*options)
+
+type options struct {
+	buffer int
+}
+
+// WithBuffer sets how many lines may wait to be written to a client. The
+// hub never waits for a client: one whose queue is full is disconnected,
+// so a stalled reader cannot hold up everybody else. The default is 64.
+func WithBuffer(n int) Option {
+	return func(o *options) { o.buffer = max(n, 1) }
+}
+
+func newOptions(opts []Option) options {
+	o := options{buffer: 64}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// client is one connection. name and the out channel belong to the hub:
+// only the hub goroutine reads or writes name and sends on or closes out.
+type client struct {
+	conn net.Conn
+	name string
+	out  chan string
+}
+
+// eventKind says what an event asks the hub to do.
+type eventKind int
+
+const (
+	join eventKind = iota
+	line
+	leave
+)
+
+type event struct {
+	kind   eventKind
+	client *client
+	text   string
+}
+
+// server is the state shared by the goroutines of one Serve call.
+type server struct {
+	opts   options
+	events chan event
+	done   chan struct{} // closed when the hub stops
+	wg     sync.WaitGroup
+}
+
+// Serve accepts connections on ln and runs the chat until ctx is
+// cancelled. Then it tells every client the server is shutting down,
+// closes their connections and ln, waits for all of its goroutines to
+// finish, and returns nil. Any other error from ln.Accept shuts the chat
+// down the same way and is returned.
+func Serve(ctx context.Context, ln net.Listener, opts ...Option) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	s := &server{
+		opts:   newOptions(opts),
+		events: make(chan event),
+		done:   make(chan struct{}),
+	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.runHub(ctx)
+	}()
+	// Accept blocks until a client connects; closing the listener is the
+	// only way to interrupt it.
+	stop := context.AfterFunc(ctx, func() { ln.Close() })
+	defer stop()
+
+	var err error
+	for {
+		conn, acceptErr := ln.Accept()
+		if acceptErr != nil {
+			if ctx.Err() == nil {
+				err = acceptErr
+			}
+			break
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handle(conn)
+		}()
+	}
+	cancel()
+	s.wg.Wait()
+	return err
+}
+
+// handle runs one connection: it starts the writer, joins the hub and then
+// reads lines until the connection closes.
+func (s *server) handle(conn net.Conn) {
+	c := &client{conn: conn, out: make(chan string, s.opts.buffer)}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.write(c)
+	}()
+	if !s.send(event{kind: join, client: c}) {
+		// The hub has stopped and will never close out; close it here so
+		// the writer closes the connection.
+		close(c.out)
+		return
+	}
+	sc := bufio.NewScanner(conn)
+	for sc.Scan() {
+		if !s.send(event{kind: line, client: c, text: sc.Text()}) {
+			return
+		}
+	}
+	s.send(event{kind: leave, client: c})
+}
+
+// send hands e to the hub. It returns false if the hub has stopped.
+func (s *server) send(e event) bool {
+	select {
+	case s.events <- e:
+		return true
+	case <-s.done:
+		return false
+	}
+}
+
+// write copies c's outgoing lines to its connection until the hub closes
+// out, and then closes the connection, which also ends handle's reads.
+func (s *server) write(c *client) {
+	w := bufio.NewWriter(c.conn)
+	for msg := range c.out {
+		w.WriteString(msg + "\n")
+		// Flush only when nothing else is queued: one write system call
+		// for a burst of lines.
+		if len(c.out) == 0 {
+			if err := w.Flush(); err != nil {
+				break
+			}
+		}
+	}
+	w.Flush()
+	c.conn.Close()
+	// After an error, out may still be open; drain it so the hub, which
+	// never blocks on out, and this goroutine agree that c is gone.
+	for range c.out {
+	}
+}
+
+// hub is the state owned by the hub goroutine; nothing else touches it.
+type hub struct {
+	clients map[*client]bool
+	names   map[string]*client
+	guests  int
+}
+
+// runHub runs the hub until ctx is cancelled.
+func (s *server) runHub(ctx context.Context) {
+	defer close(s.done)
+	h := &hub{clients: make(map[*client]bool), names: make(map[string]*client)}
+	for {
+		select {
+		case <-ctx.Done():
+			h.shutdown()
+			return
+		case e := <-s.events:
+			c := e.client
+			switch {
+			case e.kind == join:
+				h.join(c)
+			case !h.clients[c]:
+				// A line or leave that was on its way when c was dropped.
+			case e.kind == leave:
+				h.drop(c, "disconnected")
+			case strings.HasPrefix(e.text, "/"):
+				h.command(c, strings.Fields(e.text))
+			case strings.TrimSpace(e.text) != "":
+				h.broadcast(c, c.name+": "+e.text)
+			}
+		}
+	}
+}
+
+// deliver queues msg for c without blocking, and drops c if its queue is
+// full.
+func (h *hub) deliver(c *client, msg string) {
+	select {
+	case c.out <- msg:
+	default:
+		h.drop(c, "too slow")
+	}
+}
+
+// broadcast delivers msg to every client but from.
+func (h *hub) broadcast(from *client, msg string) {
+	for c := range h.clients {
+		if c != from {
+			h.deliver(c, msg)
+		}
+	}
+}
+
+// drop removes c and closes its queue, which makes its writer close the
+// connection once the queue is drained.
+func (h *hub) drop(c *client, reason string) {
+	if !h.clients[c] {
+		return
+	}
+	delete(h.clients, c)
+	delete(h.names, c.name)
+	close(c.out)
+	h.broadcast(nil, fmt.Sprintf("* %s left (%s)", c.name, reason))
+}
+
+// join names c guestN and announces it.
+func (h *hub) join(c *client) {
+	for c.name == "" || h.names[c.name] != nil {
+		h.guests++
+		c.name = fmt.Sprintf("guest%d", h.guests)
+	}
+	h.clients[c], h.names[c.name] = true, c
+	h.deliver(c, fmt.Sprintf("* welcome, %s! /nick name to change your name, /quit to leave", c.name))
+	h.broadcast(c, fmt.Sprintf("* %s joined", c.name))
+}
+
+// command runs the command in fields, whose first element starts with /.
+func (h *hub) command(c *client, fields []string) {
+	switch fields[0] {
+	case "/quit":
+		h.deliver(c, "* bye")
+		h.drop(c, "quit")
+	case "/nick":
+		if len(fields) != 2 || len(fields[1]) > 20 {
+			h.deliver(c, "! usage: /nick name (one word, at most 20 bytes)")
+			return
+		}
+		name := fields[1]
+		if other := h.names[name]; other != nil {
+			if other != c {
+				h.deliver(c, fmt.Sprintf("! %s is taken", name))
+			}
+			return
+		}
+		old := c.name
+		delete(h.names, old)
+		c.name, h.names[name] = name, c
+		h.deliver(c, fmt.Sprintf("* you are now %s", name))
+		h.broadcast(c, fmt.Sprintf("* %s is now %s", old, name))
+	default:
+		h.deliver(c, fmt.Sprintf("! unknown command %s", fields[0]))
+	}
+}
+
+// shutdown says goodbye to every client and closes its queue.
+func (h *hub) shutdown() {
+	for c := range h.clients {
+		// Best effort: a client with a full queue misses it.
+		select {
+		case c.out <- "* server shutting down":
+		default:
+		}
+		close(c.out)
+	}
+	clear(h.clients)
+}
@@ -0,0 +1,276 @@
+package chat
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// peer is a test client. Every read has a deadline, so a missing message
+// fails the test instead of hanging it.
+type peer struct {
+	t    *testing.T
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+func newPeer(t *testing.T, conn net.Conn) *peer {
+	t.Cleanup(func() { conn.Close() })
+	return &peer{t: t, conn: conn, r: bufio.NewReader(conn)}
+}
+
+func dialPeer(t *testing.T, addr string) *peer {
+	t.Helper()
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newPeer(t, conn)
+}
+
+func (p *peer) send(text string) {
+	p.t.Helper()
+	if _, err := fmt.Fprintln(p.conn, text); err != nil {
+		p.t.Fatalf("sending %q: %v", text, err)
+	}
+}
+
+// expect fails the test unless the next lines p receives are want.
+func (p *peer) expect(want ...string) {
+	p.t.Helper()
+	for _, w := range want {
+		p.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		got, err := p.r.ReadString('\n')
+		if err != nil {
+			p.t.Fatalf("waiting for %q: %v", w, err)
+		}
+		if got = got[:len(got)-1]; got != w {
+			p.t.Fatalf("got %q, want %q", got, w)
+		}
+	}
+}
+
+// expectClosed fails the test unless the server has closed p's
+// connection.
+func (p *peer) expectClosed() {
+	p.t.Helper()
+	p.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if line, err := p.r.ReadString('\n'); err != io.EOF {
+		p.t.Fatalf("read %q, %v; want the connection closed", line, err)
+	}
+}
+
+// serve runs Serve on a localhost port until the test ends, and checks
+// that it then returns nil.
+func serve(t *testing.T, opts ...Option) string {
+	t.Helper()
+	addr, stop, served, err := start(opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		stop()
+		if err := <-served; err != nil {
+			t.Errorf("Serve = %v", err)
+		}
+	})
+	return addr
+}
+
+func welcome(name string) string {
+	return "* welcome, " + name + "! /nick name to change your name, /quit to leave"
+}
+
+// joinAll connects n peers one after another, so they are guest1 to guestn,
+// and consumes the welcome and join announcements.
+func joinAll(t *testing.T, addr string, n int) []*peer {
+	var peers []*peer
+	for i := 1; i <= n; i++ {
+		p := dialPeer(t, addr)
+		p.expect(welcome(fmt.Sprint("guest", i)))
+		for _, earlier := range peers {
+			earlier.expect(fmt.Sprintf("* guest%d joined", i))
+		}
+		peers = append(peers, p)
+	}
+	return peers
+}
+
+func TestBroadcastFanOut(t *testing.T) {
+	peers := joinAll(t, serve(t), 4)
+	peers[0].send("hello, all")
+	for _, p := range peers[1:] {
+		p.expect("guest1: hello, all")
+	}
+	peers[2].send("hi guest1")
+	for i, p := range peers {
+		if i != 2 {
+			p.expect("guest3: hi guest1")
+		}
+	}
+	// Blank lines are not broadcast. If either had been, guest1 would see
+	// it before this.
+	peers[1].send("   ")
+	peers[1].send("")
+	peers[1].send("still here")
+	peers[0].expect("guest2: still here")
+}
+
+func TestNick(t *testing.T) {
+	addr := serve(t)
+	peers := joinAll(t, addr, 2)
+	a, b := peers[0], peers[1]
+
+	a.send("/nick ada")
+	a.expect("* you are now ada")
+	b.expect("* guest1 is now ada")
+	a.send("hi")
+	b.expect("ada: hi")
+
+	b.send("/nick ada")
+	b.expect("! ada is taken")
+	b.send("/nick two words")
+	b.send("/nick " + "a_name_longer_than_twenty")
+	b.expect("! usage: /nick name (one word, at most 20 bytes)", "! usage: /nick name (one word, at most 20 bytes)")
+	b.send("/shout hi")
+	b.expect("! unknown command /shout")
+
+	// A freed name can be taken; a new guest never gets a taken name.
+	a.send("/nick guest3")
+	a.expect("* you are now guest3")
+	b.expect("* ada is now guest3")
+	c := dialPeer(t, addr)
+	c.expect(welcome("guest4"))
+}
+
+func TestQuit(t *testing.T) {
+	peers := joinAll(t, serve(t), 3)
+	peers[1].send("/quit")
+	peers[1].expect("* bye")
+	peers[1].expectClosed()
+	peers[0].expect("* guest2 left (quit)")
+	peers[2].expect("* guest2 left (quit)")
+
+	// Hanging up without /quit is announced too.
+	peers[2].conn.Close()
+	peers[0].expect("* guest3 left (disconnected)")
+}
+
+func TestShutdown(t *testing.T) {
+	addr, stop, served, err := start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	peers := joinAll(t, addr, 2)
+	stop()
+	for _, p := range peers {
+		p.expect("* server shutting down")
+		p.expectClosed()
+	}
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Errorf("Serve = %v, want nil after cancel", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve did not return after cancel")
+	}
+	if _, err := net.Dial("tcp", addr); err == nil {
+		t.Error("the listener still accepts connections")
+	}
+}
+
+// pipeListener is a net.Listener whose connections are net.Pipes. A pipe
+// has no buffer, so a client that does not read blocks the server's
+// writes at once, which a TCP socket would hide behind its buffers.
+type pipeListener struct {
+	conns  chan net.Conn
+	closed chan struct{}
+	once   sync.Once
+}
+
+func newPipeListener() *pipeListener {
+	return &pipeListener{conns: make(chan net.Conn), closed: make(chan struct{})}
+}
+
+func (l *pipeListener) Accept() (net.Conn, error) {
+	select {
+	case c := <-l.conns:
+		return c, nil
+	case <-l.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+func (l *pipeListener) Close() error {
+	l.once.Do(func() { close(l.closed) })
+	return nil
+}
+
+func (l *pipeListener) Addr() net.Addr { return pipeAddr{} }
+
+func (l *pipeListener) dial(t *testing.T) *peer {
+	client, server := net.Pipe()
+	l.conns <- server
+	return newPeer(t, client)
+}
+
+type pipeAddr struct{}
+
+func (pipeAddr) Network() string { return "pipe" }
+func (pipeAddr) String() string  { return "pipe" }
+
+func TestSlowClientDropped(t *testing.T) {
+	ln := newPipeListener()
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- Serve(ctx, ln, WithBuffer(1)) }()
+	// Cleanups run last first, so the peers hang up before this runs;
+	// nobody reads the goodbye, and it would block a pipe forever.
+	t.Cleanup(func() {
+		cancel()
+		if err := <-served; err != nil {
+			t.Errorf("Serve = %v", err)
+		}
+	})
+
+	slow := ln.dial(t)
+	// slow never reads. A pipe write returns once the server has read it,
+	// and the server reads the second line only after handing the first
+	// to the hub, so by then slow has joined. Blank lines are not
+	// broadcast, so nothing reaches fast later on.
+	slow.send("")
+	slow.send("")
+
+	fast := ln.dial(t)
+	fast.expect(welcome("guest2"))
+	// slow's writer is stuck on the welcome, and its one-line queue holds
+	// "guest2 joined", so this message does not fit.
+	fast.send("anyone there?")
+	fast.expect("* guest1 left (too slow)")
+
+	// Closing slow's end unblocks its writer; the hub has already moved on.
+	slow.conn.Close()
+	fast.send("still works")
+}
+
+func TestAcceptError(t *testing.T) {
+	boom := errors.New("accept failed")
+	err := Serve(context.Background(), failingListener{boom})
+	if !errors.Is(err, boom) {
+		t.Errorf("Serve = %v, want %v", err, boom)
+	}
+}
+
+// failingListener fails every Accept with err.
+type failingListener struct{ err error }
+
+func (l failingListener) Accept() (net.Conn, error) { return nil, l.err }
+func (l failingListener) Close() error              { return nil }
+func (l failingListener) Addr() net.Addr            { return pipeAddr{} }
@@ -0,0 +1,229 @@
+package chat
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+
+	"learning-go/registry"
+	"learning-go/testutil/leak"
+)
+
+func init() {
+	// Register each exercise with the runner (cmd/learn)
+	registry.Register("chapter17/chat", "exercise1", exercise1)
+	registry.Register("chapter17/chat", "exercise2", exercise2)
+}
+
+// start runs Serve on a free localhost port. Cancelling the returned
+// context stops it; the channel then receives Serve's error.
+func start(opts ...Option) (addr string, stop context.CancelFunc, served <-chan error, err error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", nil, nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error, 1)
+	go func() { errc <- Serve(ctx, ln, opts...) }()
+	return ln.Addr().String(), cancel, errc, nil
+}
+
+// user is one chat client, as nc would be, over a real TCP connection.
+type user struct {
+	label string
+	w     io.Writer
+	conn  net.Conn
+	lines *bufio.Scanner
+}
+
+func connect(w io.Writer, addr, label string) (*user, error) {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return &user{label: label, w: w, conn: conn, lines: bufio.NewScanner(conn)}, nil
+}
+
+func (u *user) say(text string) {
+	fmt.Fprintf(u.w, "%s > %s\n", u.label, text)
+	fmt.Fprintln(u.conn, text)
+}
+
+// expect reads and prints the next n lines u receives. Waiting for each
+// message before the next step is what makes the transcript the same on
+// every run, though every client is served by its own goroutines.
+func (u *user) expect(n int) {
+	u.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for range n {
+		if !u.lines.Scan() {
+			fmt.Fprintf(u.w, "%s: no message: %v\n", u.label, u.lines.Err())
+			return
+		}
+		fmt.Fprintf(u.w, "%s < %s\n", u.label, u.lines.Text())
+	}
+}
+
+// expectClosed checks that the server closed u's connection.
+func (u *user) expectClosed() {
+	u.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if u.lines.Scan() {
+		fmt.Fprintf(u.w, "%s: unexpected %q\n", u.label, u.lines.Text())
+		return
+	}
+	fmt.Fprintf(u.w, "%s: connection closed by the server (err %v)\n", u.label, u.lines.Err())
+}
+
+// Exercise 1: Start the chat server on a real socket, connect three
+// clients, and follow one conversation: joins, renames, a taken name, a
+// message fanned out to the others, an unknown command, /quit, a dropped
+// connection and finally a server shutdown.
+func exercise1(w io.Writer) {
+	addr, stop, served, err := start()
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	defer stop()
+
+	var users []*user
+	for _, label := range []string{"A", "B", "C"} {
+		u, err := connect(w, addr, label)
+		if err != nil {
+			fmt.Fprintln(w, err)
+			return
+		}
+		u.expect(1)
+		for _, other := range users {
+			other.expect(1)
+		}
+		users = append(users, u)
+	}
+	a, b, c := users[0], users[1], users[2]
+
+	a.say("/nick ada")
+	a.expect(1)
+	b.expect(1)
+	c.expect(1)
+	b.say("/nick ada")
+	b.expect(1)
+	b.say("/nick grace")
+	b.expect(1)
+	a.expect(1)
+	c.expect(1)
+
+	a.say("hello, everyone")
+	b.expect(1)
+	c.expect(1)
+	c.say("/shout hi")
+	c.expect(1)
+
+	c.say("/quit")
+	c.expect(1)
+	c.expectClosed()
+	a.expect(1)
+	b.expect(1)
+
+	b.conn.Close()
+	a.expect(1)
+
+	stop()
+	a.expect(1)
+	a.expectClosed()
+	fmt.Fprintln(w, "Serve returned:", <-served)
+
+	// Explanation:
+	// Every connection gets two goroutines: a reader that turns lines into
+	// events for the hub, and a writer that empties the client's queue
+	// into the socket. The hub is a single goroutine that owns the clients
+	// and the names in plain maps, so two /nick commands can never race
+	// for the same name and nothing needs a lock: whoever else wants to
+	// change them sends an event on the hub's channel. A message never
+	// goes back to its sender, which is why A sees nothing when it speaks.
+	// Dropping a client, for /quit or a closed connection, closes its
+	// queue; the writer sends what is left, like "* bye", and closes the
+	// connection. Cancelling the context makes Serve close the listener,
+	// which is the only way to stop a blocked Accept, and the hub says
+	// goodbye to everyone.
+}
+
+// Exercise 2: Connect ten clients, have one of them send 200 messages in
+// rounds of 20 and check that each of the other nine receives all of them,
+// in order; then shut the server down and check that none of its
+// goroutines is left.
+func exercise2(w io.Writer) {
+	snap := leak.Take()
+	addr, stop, served, err := start()
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+
+	const clients, messages = 10, 200
+	users := make([]*user, clients)
+	for i := range users {
+		u, err := connect(io.Discard, addr, strconv.Itoa(i))
+		if err != nil {
+			fmt.Fprintln(w, err)
+			stop()
+			return
+		}
+		u.expect(1) // the welcome
+		// Every user already connected is told about the new one.
+		for _, other := range users[:i] {
+			other.expect(1)
+		}
+		users[i] = u
+	}
+
+	// The sender writes in rounds of 20 and waits for everyone to read
+	// each round, as people typing would, rather than flooding the hub.
+	const round = 20
+	sender, inOrder := users[0], make([]int, clients)
+	for start := 0; start < messages; start += round {
+		for i := start; i < start+round; i++ {
+			fmt.Fprintf(sender.conn, "message %d\n", i)
+		}
+		for r, u := range users[1:] {
+			u.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+			for i := start; i < start+round && u.lines.Scan(); i++ {
+				if u.lines.Text() == fmt.Sprintf("guest1: message %d", i) {
+					inOrder[r+1]++
+				}
+			}
+		}
+	}
+	complete := 0
+	for _, n := range inOrder[1:] {
+		if n == messages {
+			complete++
+		}
+	}
+	fmt.Fprintf(w, "%d of %d receivers got all %d messages in order\n", complete, clients-1, messages)
+
+	stop()
+	fmt.Fprintln(w, "Serve returned:", <-served)
+	for _, u := range users {
+		u.conn.Close()
+	}
+	fmt.Fprintln(w, "server goroutines left:", len(snap.Leaked(leak.Timeout)))
+
+	// Explanation:
+	// The hub handles one event at a time, so every receiver's queue gets
+	// the 200 messages in the order the sender wrote them, and each
+	// writer goroutine keeps that order on its socket: fan-out through one
+	// goroutine gives a single order that everyone agrees on. The hub
+	// never blocks on a receiver; a queue that fills up, because a client
+	// stopped reading, gets that client dropped instead of stalling the
+	// chat for the other nine. The same rule drops clients that merely
+	// fall behind a flood: sent in one go, the 200 messages outrun the
+	// writers on a busy machine, which is why the sender here waits after
+	// every 20, well under the queue's 64. On shutdown every goroutine has a way out:
+	// the hub returns on cancellation, writers return when their queue is
+	// closed, and readers when their connection is, and Serve waits for
+	// all of them before it returns, which is what the leak check
+	// confirms.
+}
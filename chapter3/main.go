@@ -1,14 +1,23 @@
-package main
+package chapter3
 
 import (
 	"fmt"
+	"io"
+
+	"learning-go/chapter3/sliceviz"
+	"learning-go/chapter3/tracegrow"
+	"learning-go/dump"
+	"learning-go/registry"
+	"learning-go/runestr"
 )
 
-func main() {
-	// Call the functions to execute each exercise
-	exercise1()
-	exercise2()
-	exercise3()
+func init() {
+	// Register each exercise with the runner (cmd/learn)
+	registry.Register("chapter3", "exercise1", exercise1)
+	registry.Register("chapter3", "exercise2", exercise2)
+	registry.Register("chapter3", "exercise3", exercise3)
+	registry.Register("chapter3", "exercise4", exercise4)
+	registry.Register("chapter3", "exercise5", exercise5)
 }
 
 // Exercise 1: Define a variable named greetings of type slice of strings
@@ -17,7 +26,7 @@ func main() {
 // a second subslice with the second, third, and fourth values;
 // and a third subslice with the fourth and fifth values.
 // Print out all four slices.
-func exercise1() {
+func exercise1(w io.Writer) {
 	greetings := []string{"Hello", "Hola", "नमस्कार", "こんにちは", "Привіт"}
 
 	// Create a subslice with the first two elements
@@ -30,10 +39,10 @@ func exercise1() {
 	slice3 := greetings[3:]
 
 	// Print the original slice and the three subslices
-	fmt.Println("Original slice:", greetings)
-	fmt.Println("Subslice 1:", slice1)
-	fmt.Println("Subslice 2:", slice2)
-	fmt.Println("Subslice 3:", slice3)
+	fmt.Fprintln(w, "Original slice:", greetings)
+	fmt.Fprintln(w, "Subslice 1:", slice1)
+	fmt.Fprintln(w, "Subslice 2:", slice2)
+	fmt.Fprintln(w, "Subslice 3:", slice3)
 
 	// Explanation:
 	// We defined the 'greetings' slice with five international greetings.
@@ -46,15 +55,28 @@ func exercise1() {
 
 // Exercise 2: Define a string variable called message with the value "Hi 😘 and 😊 "
 // and print the fourth rune in it as a character, not a number.
-func exercise2() {
+func exercise2(w io.Writer) {
 	message := "Hi 😘 and 😊 "
-	// Print the fourth rune (index 3) as a character using %c format specifier
-	fmt.Printf("Fourth rune: %c\n", message[3])
+
+	// message[3] would be the fourth byte, which is only the first byte of
+	// the 4-byte encoding of 😘. runestr.RuneAt counts runes instead.
+	fourth, ok := runestr.RuneAt(message, 3)
+	if !ok {
+		fmt.Fprintln(w, "message has fewer than four runes")
+		return
+	}
+	// Print the fourth rune as a character using %c format specifier
+	fmt.Fprintf(w, "Fourth rune: %c\n", fourth)
+	fmt.Fprintf(w, "Fourth byte: %#x (not a character on its own)\n", message[3])
 
 	// Explanation:
 	// We defined a string 'message' with the value "Hi 😘 and 😊 ".
-	// We accessed the fourth rune (index 3) of the string and printed it
-	// as a character using the %c format specifier.
+	// Indexing a string returns bytes, and emoji take several bytes in UTF-8,
+	// so message[3] is not the fourth character. runestr.RuneAt walks the
+	// string rune by rune and returns the fourth rune, 😘, which we print
+	// as a character using the %c format specifier. The exercises in
+	// chapter3/runes take this further, down to characters made of
+	// several runes.
 }
 
 // Exercise 3: Define a struct called Employee with three fields:
@@ -64,7 +86,7 @@ func exercise2() {
 // style without names, the second using the struct literal style with names, and
 // the third with a var declaration. Use dot notation to populate the fields in the
 // third struct. Print out all three structs.
-func exercise3() {
+func exercise3(w io.Writer) {
 	type Employee struct {
 		firstName string
 		lastName  string
@@ -87,10 +109,11 @@ func exercise3() {
 	emp3.lastName = "Johnson"
 	emp3.id = 3
 
-	// Print all three Employee instances
-	fmt.Println("Employee 1:", emp1)
-	fmt.Println("Employee 2:", emp2)
-	fmt.Println("Employee 3:", emp3)
+	// Print all three Employee instances.
+	// dump.Dump shows the field names and types, which %v leaves out.
+	fmt.Fprintln(w, "Employee 1:", dump.Dump(emp1))
+	fmt.Fprintln(w, "Employee 2:", dump.Dump(emp2))
+	fmt.Fprintln(w, "Employee 3:", dump.Dump(emp3))
 
 	// Explanation:
 	// We defined the 'Employee' struct with fields 'firstName', 'lastName', and 'id'.
@@ -100,3 +123,76 @@ func exercise3() {
 	// - 'emp3' using 'var' declaration and dot notation for field assignment.
 	// All three instances were printed to verify their values.
 }
+
+// Exercise 4: Make the shared backing array of a slice and its subslices
+// visible. Print the data pointer, length and capacity of each slice, then
+// append to a subslice and watch what happens to the original.
+func exercise4(w io.Writer) {
+	original := []int{1, 2, 3, 4, 5}
+	sub := original[1:3]
+
+	fmt.Fprintln(w, "Before append:")
+	fmt.Fprintln(w, sliceviz.Describe("original", original))
+	fmt.Fprintln(w, sliceviz.Describe("sub", sub))
+	fmt.Fprintln(w, "Share memory:", sliceviz.SharesMemory(original, sub))
+
+	// sub has len 2 but cap 4, so append writes into original's array
+	sub = append(sub, 99)
+
+	fmt.Fprintln(w, "After append within capacity:")
+	fmt.Fprintln(w, sliceviz.Describe("original", original))
+	fmt.Fprintln(w, sliceviz.Describe("sub", sub))
+
+	// Appending past the capacity makes Go allocate a new array and copy
+	sub = append(sub, 100, 101, 102)
+
+	fmt.Fprintln(w, "After append beyond capacity:")
+	fmt.Fprintln(w, sliceviz.Describe("original", original))
+	fmt.Fprintln(w, sliceviz.Describe("sub", sub))
+	fmt.Fprintln(w, "Share memory:", sliceviz.SharesMemory(original, sub))
+
+	// A full slice expression limits the capacity, so the first append
+	// already copies and the original is left alone
+	safe := original[1:3:3]
+	safe = append(safe, 42)
+	fmt.Fprintln(w, "With a full slice expression:")
+	fmt.Fprintln(w, sliceviz.Describe("original", original))
+	fmt.Fprintln(w, sliceviz.Describe("safe", safe))
+
+	// Explanation:
+	// sub starts 8 bytes after original (one int) and both report the same
+	// array until the second append exceeds sub's capacity. The first append
+	// overwrote original[3] with 99 because the two slices shared memory.
+	// After reallocation sub's data pointer changes and the slices are
+	// independent. original[1:3:3] sets the capacity to 2, which forces the
+	// copy up front and is the usual way to hand out a subslice safely.
+}
+
+// Exercise 5: Trace every capacity change while appending 2,000 elements,
+// once with 1-byte elements and once with 128-byte elements, and compare
+// how the capacity grows.
+func exercise5(w io.Writer) {
+	type record [128]byte
+
+	small := tracegrow.Grow[byte](2000)
+	large := tracegrow.Grow[record](2000)
+
+	fmt.Fprintf(w, "byte elements: %d reallocations\n", len(small))
+	if err := tracegrow.WriteCSV(w, small); err != nil {
+		fmt.Fprintln(w, "writing CSV:", err)
+	}
+	fmt.Fprintf(w, "128-byte elements: %d reallocations\n", len(large))
+	if err := tracegrow.WriteCSV(w, large); err != nil {
+		fmt.Fprintln(w, "writing CSV:", err)
+	}
+
+	// Explanation:
+	// append roughly doubles small slices and grows large ones by about
+	// 25% per step, but the exact capacities depend on the element size:
+	// the runtime rounds every allocation up to one of its memory size
+	// classes and then uses all of that space. That is why the byte slice
+	// jumps from 0 straight to 8 while the slice of 128-byte records starts
+	// at 1, and why the two columns of capacities do not follow the same
+	// sequence. Use tracegrow.WriteJSON instead to feed the trace to
+	// another tool.
+}
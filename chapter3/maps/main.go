@@ -0,0 +1,129 @@
+package maps
+
+import (
+	_ "embed"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"learning-go/datastructures/collections"
+	"learning-go/registry"
+)
+
+func init() {
+	// Register each exercise with the runner (cmd/learn)
+	registry.Register("chapter3/maps", "exercise1", exercise1)
+	registry.Register("chapter3/maps", "exercise2", exercise2)
+	registry.Register("chapter3/maps", "exercise3", exercise3)
+}
+
+// The sample inputs are embedded so the exercises find them from any
+// working directory.
+var (
+	//go:embed testdata/gettysburg.txt
+	sampleSpeech string
+	//go:embed testdata/words.txt
+	sampleWords string
+)
+
+// Exercise 1: Find which Go features two learners have covered with
+// collections.Set, a set built on map[T]struct{}: what both know, what
+// either knows, what only one knows, and whether one has covered
+// everything the other has. Show first the map behaviour the set relies
+// on.
+func exercise1(w io.Writer) {
+	m := map[string]struct{}{"maps": {}}
+	_, ok := m["maps"]
+	_, missing := m["slices"]
+	fmt.Fprintln(w, "comma ok for a present and a missing key:", ok, missing)
+	m["maps"] = struct{}{} // a second insert of the same key changes nothing
+	delete(m, "slices")    // deleting a missing key is not an error
+	fmt.Fprintln(w, "len after inserting twice and deleting a missing key:", len(m))
+
+	ana := collections.NewSet("maps", "slices", "structs", "generics", "channels")
+	ben := collections.NewSet("maps", "slices", "pointers", "channels")
+	basics := collections.NewSet("maps", "slices")
+	fmt.Fprintln(w, "ana:", ana)
+	fmt.Fprintln(w, "ben:", ben)
+	fmt.Fprintln(w, "both:", ana.Intersection(ben))
+	fmt.Fprintln(w, "either:", ana.Union(ben))
+	fmt.Fprintln(w, "only ana:", ana.Difference(ben))
+	fmt.Fprintln(w, "exactly one of them:", ana.SymmetricDifference(ben))
+	fmt.Fprintln(w, "basics covered by both:", basics.SubsetOf(ana.Intersection(ben)))
+	fmt.Fprintln(w, "(A-B) ∪ (B-A) equals the symmetric difference:",
+		ana.Difference(ben).Union(ben.Difference(ana)).Equal(ana.SymmetricDifference(ben)))
+	fmt.Fprintln(w, "sorted union:", collections.Sorted(ana.Union(ben)))
+
+	// Explanation:
+	// Looking up a missing key returns the zero value, so the comma-ok
+	// form is how to tell "absent" from "present with a zero value". A
+	// map's keys are unique, which makes a map with empty values a set:
+	// struct{} takes no memory, unlike bool, and cannot be mistaken for a
+	// flag that might be false. Every set operation is a loop over one set
+	// with O(1) lookups in the other. Ranging over a map visits keys in a
+	// different order each run, so Set's String sorts them and Sorted
+	// returns a sorted slice whenever the order matters.
+}
+
+// Exercise 2: Count the words of the Gettysburg Address read from an
+// io.Reader and print the ten most frequent. Words with the same count
+// must come out in the same order every run.
+func exercise2(w io.Writer) {
+	freq, err := CountWords(strings.NewReader(sampleSpeech))
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	fmt.Fprintln(w, "distinct words:", freq.Len())
+	for i, wc := range freq.Top(10) {
+		fmt.Fprintf(w, "%2d. %-8s %d\n", i+1, wc.Word, wc.Count)
+	}
+	fmt.Fprintln(w, `count of "nation":`, freq.Count("nation"), `count of "gopher":`, freq.Count("gopher"))
+	fmt.Fprintln(w, "same top 10 from a second count:",
+		slices.Equal(freq.Top(10), must(CountWords(strings.NewReader(sampleSpeech))).Top(10)))
+
+	// Explanation:
+	// The map does the counting: counts[word]++ works for a new word
+	// because a missing key reads as 0. Sorting by count alone is not
+	// enough, because words with equal counts would come out in whatever
+	// order the map was ranged in, which changes from run to run. So
+	// Frequencies also keeps each word in a slice the first time it is
+	// seen, and Top sorts that slice with a stable sort: ties stay in
+	// order of first appearance, so "to" comes before "here", and
+	// "nation" before "can" and "of". Reading through a bufio.Scanner
+	// means the input can be a file or a network stream of any size, not
+	// just a string.
+}
+
+// must returns v, and panics if err is not nil. It keeps an exercise short
+// where the error was already checked once.
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exercise 3: Group the sample words into anagrams, ignoring case, and
+// print the largest groups first, and groups of the same size in the order
+// their first word appears.
+func exercise3(w io.Writer) {
+	words := strings.Fields(sampleWords)
+	groups := GroupAnagrams(words)
+	slices.SortStableFunc(groups, func(a, b []string) int { return len(b) - len(a) })
+	for _, g := range groups {
+		fmt.Fprintf(w, "%-6s %s\n", anagramKey(g[0]), strings.Join(g, " "))
+	}
+	fmt.Fprintf(w, "%d words in %d groups\n", len(words), len(groups))
+
+	// Explanation:
+	// Two words are anagrams when they have the same letters, so sorting
+	// the letters gives a key that all anagrams share, and a map from key
+	// to group collects them in one pass. The map stores each group's
+	// index in a slice of groups, rather than the group itself: appending
+	// may move a group's backing array, and a slice stored as a map value
+	// would have to be written back after every append. The slice of
+	// groups also keeps the order of first appearance, which ranging over
+	// the map would not.
+}
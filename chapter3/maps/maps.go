@@ -0,0 +1,114 @@
+// Package maps is chapter 3's exercises on maps: a set built on a map, a
+// word-frequency analyzer and an anagram grouper. Each needs a map for
+// fast lookups, and each also needs something a map cannot give, a stable
+// order, which the code here keeps in a slice next to the map.
+package maps
+
+import (
+	"bufio"
+	"cmp"
+	"io"
+	"slices"
+	"strings"
+	"unicode"
+
+	"learning-go/errs"
+)
+
+// WordCount is a word and how many times it occurred.
+type WordCount struct {
+	Word  string
+	Count int
+}
+
+// Frequencies counts words. The zero value is ready to use.
+type Frequencies struct {
+	counts map[string]int
+	order  []string // every word once, in the order it first occurred
+}
+
+// Add counts one occurrence of word.
+func (f *Frequencies) Add(word string) {
+	if f.counts == nil {
+		f.counts = make(map[string]int)
+	}
+	// A missing key reads as 0, so the first occurrence needs no special
+	// case for the count, only for the order.
+	if _, seen := f.counts[word]; !seen {
+		f.order = append(f.order, word)
+	}
+	f.counts[word]++
+}
+
+// Count returns how many times word occurred.
+func (f *Frequencies) Count(word string) int {
+	return f.counts[word]
+}
+
+// Len returns the number of distinct words.
+func (f *Frequencies) Len() int {
+	return len(f.order)
+}
+
+// Top returns the n most frequent words, most frequent first. Words with
+// the same count keep the order in which they first occurred, so the
+// result is the same on every run even though ranging over the map is not.
+func (f *Frequencies) Top(n int) []WordCount {
+	list := make([]WordCount, len(f.order))
+	for i, word := range f.order {
+		list[i] = WordCount{word, f.counts[word]}
+	}
+	slices.SortStableFunc(list, func(a, b WordCount) int {
+		return cmp.Compare(b.Count, a.Count)
+	})
+	return list[:min(max(n, 0), len(list))]
+}
+
+// CountWords reads r and counts its words, ignoring case. A word is a run
+// of letters, digits and apostrophes inside it, so "don't" is one word.
+func CountWords(r io.Reader) (*Frequencies, error) {
+	f := &Frequencies{}
+	sc := bufio.NewScanner(r)
+	sc.Split(bufio.ScanWords)
+	for sc.Scan() {
+		words := strings.FieldsFunc(sc.Text(), func(c rune) bool {
+			return !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '\''
+		})
+		for _, word := range words {
+			if word = strings.Trim(word, "'"); word != "" {
+				f.Add(strings.ToLower(word))
+			}
+		}
+	}
+	return f, errs.Wrap(sc.Err(), "maps: counting words")
+}
+
+// anagramKey returns the letters of word, lowercased and sorted, so that
+// two words are anagrams exactly when their keys are equal.
+func anagramKey(word string) string {
+	letters := []rune(strings.ToLower(word))
+	slices.Sort(letters)
+	return string(letters)
+}
+
+// GroupAnagrams groups words that are anagrams of each other, ignoring
+// case. Groups are in the order their first word appears in words, and
+// words keep their order within a group. Duplicates are kept.
+func GroupAnagrams(words []string) [][]string {
+	// The map finds a word's group in O(1); the index into groups, rather
+	// than the group itself, is stored so the map needs no updating when
+	// a group's slice grows.
+	index := make(map[string]int)
+	var groups [][]string
+	for _, word := range words {
+		key := anagramKey(word)
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], word)
+	}
+	return groups
+}
@@ -0,0 +1,95 @@
+package maps
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestCountWords(t *testing.T) {
+	freq, err := CountWords(strings.NewReader(`Don't panic. DON'T! 'quoted' words,
+and go1.23 -- go, "Go" and more`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for word, want := range map[string]int{
+		"don't":  2, // case is ignored, the inner apostrophe kept
+		"quoted": 1, // outer apostrophes are quotes, not part of the word
+		"go":     2,
+		"go1":    1, // the dot splits "go1.23"
+		"23":     1,
+		"and":    2,
+		"--":     0,
+		"":       0,
+	} {
+		if got := freq.Count(word); got != want {
+			t.Errorf("Count(%q) = %d, want %d", word, got, want)
+		}
+	}
+	if got := freq.Len(); got != 9 {
+		t.Errorf("Len() = %d, want 9", got)
+	}
+}
+
+func TestCountWordsReadError(t *testing.T) {
+	boom := errors.New("disk gone")
+	if _, err := CountWords(iotest.ErrReader(boom)); !errors.Is(err, boom) {
+		t.Errorf("CountWords = %v, want it to wrap %v", err, boom)
+	}
+}
+
+func TestTopBreaksTiesByFirstAppearance(t *testing.T) {
+	var f Frequencies
+	for _, w := range strings.Fields("c b a b c d c a e") {
+		f.Add(w)
+	}
+	want := []WordCount{{"c", 3}, {"b", 2}, {"a", 2}, {"d", 1}, {"e", 1}}
+	// Map iteration order differs from run to run; Top must not.
+	for range 20 {
+		if got := f.Top(10); !slices.Equal(got, want) {
+			t.Fatalf("Top(10) = %v, want %v", got, want)
+		}
+	}
+	for n, want := range map[int][]WordCount{
+		-1: {},
+		0:  {},
+		2:  want[:2],
+		5:  want,
+	} {
+		if got := f.Top(n); !slices.Equal(got, want) {
+			t.Errorf("Top(%d) = %v, want %v", n, got, want)
+		}
+	}
+}
+
+func TestZeroFrequencies(t *testing.T) {
+	var f Frequencies
+	if f.Len() != 0 || f.Count("x") != 0 || len(f.Top(3)) != 0 {
+		t.Errorf("zero Frequencies: Len %d, Count %d, Top %v", f.Len(), f.Count("x"), f.Top(3))
+	}
+}
+
+func TestGroupAnagrams(t *testing.T) {
+	tests := []struct {
+		words []string
+		want  [][]string
+	}{
+		{nil, nil},
+		{
+			[]string{"listen", "google", "Silent", "enlist", "gogole", "cat", "act", "listen"},
+			[][]string{{"listen", "Silent", "enlist", "listen"}, {"google", "gogole"}, {"cat", "act"}},
+		},
+		{[]string{"a", "b", "A"}, [][]string{{"a", "A"}, {"b"}}},
+		// Letters are runes, so é does not split into bytes that could
+		// match some other word.
+		{[]string{"été", "éét", "tee"}, [][]string{{"été", "éét"}, {"tee"}}},
+	}
+	for _, tt := range tests {
+		got := GroupAnagrams(tt.words)
+		if !slices.EqualFunc(got, tt.want, slices.Equal) {
+			t.Errorf("GroupAnagrams(%q) = %q, want %q", tt.words, got, tt.want)
+		}
+	}
+}
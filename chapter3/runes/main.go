@@ -0,0 +1,151 @@
+// Package runes is the follow-up to chapter 3's exercise 2, which showed
+// that message[3] is a byte of an emoji, not the fourth character. These
+// exercises take a string apart byte by byte, rune by rune and grapheme
+// by grapheme, and check the rune-safe helpers in package runestr on
+// emoji and combining characters.
+package runes
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"learning-go/registry"
+	"learning-go/runestr"
+)
+
+func init() {
+	// Register each exercise with the runner (cmd/learn)
+	registry.Register("chapter3/runes", "exercise1", exercise1)
+	registry.Register("chapter3/runes", "exercise2", exercise2)
+	registry.Register("chapter3/runes", "exercise3", exercise3)
+}
+
+// Exercise 1: Walk "Hi 😘 and 😊 " three ways: by index, which yields
+// bytes; with range, which yields runes and their byte offsets; and with
+// utf8.DecodeRuneInString, which is what range does under the hood.
+func exercise1(w io.Writer) {
+	message := "Hi 😘 and 😊 "
+	fmt.Fprintf(w, "%q: %d bytes, %d runes\n", message, len(message), utf8.RuneCountInString(message))
+
+	fmt.Fprint(w, "by index:")
+	for i := 0; i < len(message); i++ {
+		fmt.Fprintf(w, " %02x", message[i])
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprint(w, "with range:")
+	for offset, r := range message {
+		fmt.Fprintf(w, " %d:%c", offset, r)
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, "with utf8.DecodeRuneInString:")
+	for rest, offset := message, 0; rest != ""; {
+		r, size := utf8.DecodeRuneInString(rest)
+		if size > 1 {
+			fmt.Fprintf(w, "  offset %2d: %c %U, %d bytes\n", offset, r, r, size)
+		}
+		rest = rest[size:]
+		offset += size
+	}
+
+	// Invalid UTF-8 does not stop either loop.
+	broken := "a\xffb"
+	fmt.Fprint(w, "range over \"a\\xffb\":")
+	for offset, r := range broken {
+		fmt.Fprintf(w, " %d:%U", offset, r)
+	}
+	fmt.Fprintln(w)
+	r, size := utf8.DecodeRuneInString(broken[1:])
+	fmt.Fprintf(w, "DecodeRuneInString(\"\\xffb\"): %U, size %d\n", r, size)
+
+	// Explanation:
+	// A string is a read-only slice of bytes, and indexing it gives bytes.
+	// Only ASCII characters are one byte long in UTF-8; 😘 takes four, so
+	// a loop over indexes sees f0 9f 98 98 where a reader sees one
+	// character. range decodes the string instead: each iteration yields
+	// the byte offset where a rune starts and the rune itself, so the
+	// offsets jump by four at each emoji. utf8.DecodeRuneInString does the
+	// same for one rune at a time and also returns its size, which is how
+	// to step through a string by hand. A byte that is not valid UTF-8
+	// decodes as U+FFFD, the replacement character, with a size of 1, so
+	// both loops skip exactly that byte and carry on.
+}
+
+// samples are strings whose bytes, runes and visible characters differ.
+var samples = []struct {
+	name, s string
+}{
+	{"ascii", "gopher"},
+	{"precomposed é", "caf\u00e9"},
+	{"e + combining accent", "cafe\u0301"},
+	{"emoji", "Hi 😘 and 😊"},
+	{"skin tone", "👍🏽 ok"},
+	{"family (ZWJ)", "👨\u200d👩\u200d👧!"},
+	{"flags", "🇯🇵🇫🇷"},
+}
+
+// Exercise 2: Measure each sample in bytes, runes and graphemes, and
+// compare what the byte-based and rune-based ways of taking the second
+// character, reversing and truncating do to it.
+func exercise2(w io.Writer) {
+	for _, sample := range samples {
+		s := sample.s
+		r, _ := runestr.RuneAt(s, 1)
+		fmt.Fprintf(w, "%s %q\n", sample.name, s)
+		fmt.Fprintf(w, "  bytes %d, runes %d, graphemes %d\n", len(s), runestr.RuneLen(s), runestr.GraphemeLen(s))
+		fmt.Fprintf(w, "  s[1] %#x, RuneAt(s, 1) %q\n", s[1], r)
+		fmt.Fprintf(w, "  Reverse %q, ReverseGraphemes %q\n", runestr.Reverse(s), runestr.ReverseGraphemes(s))
+		fmt.Fprintf(w, "  s[:3] valid UTF-8: %v, Truncate(s, 3) %q, TruncateGraphemes(s, 3) %q\n",
+			utf8.ValidString(s[:min(3, len(s))]), runestr.Truncate(s, 3), runestr.TruncateGraphemes(s, 3))
+	}
+
+	// Explanation:
+	// The two cafés look the same but are not equal strings: é can be one
+	// rune, U+00E9, or an e followed by U+0301, a combining accent. Rune
+	// functions handle the first and break the second: Reverse moves the
+	// accent in front of the e it belonged to. Emoji are single runes but
+	// several bytes, so s[1] and s[:3] land in the middle of them; skin
+	// tones, families and flags are several runes drawn as one character,
+	// so only the grapheme functions keep them whole. Counting "characters"
+	// in a string always means choosing one of these three units.
+}
+
+// Exercise 3: Check properties that must hold for every sample and every
+// length: reversing twice gives the original, truncating never produces
+// invalid UTF-8 or a string longer than asked for, and truncated strings
+// are prefixes of the original.
+func exercise3(w io.Writer) {
+	checks, failures := 0, 0
+	check := func(ok bool, format string, args ...any) {
+		checks++
+		if !ok {
+			failures++
+			fmt.Fprintf(w, "FAIL "+format+"\n", args...)
+		}
+	}
+	for _, sample := range samples {
+		s := sample.s
+		check(runestr.Reverse(runestr.Reverse(s)) == s, "%s: Reverse twice", sample.name)
+		check(runestr.ReverseGraphemes(runestr.ReverseGraphemes(s)) == s, "%s: ReverseGraphemes twice", sample.name)
+		check(strings.Join(runestr.Graphemes(s), "") == s, "%s: Graphemes joined", sample.name)
+		for n := 0; n <= runestr.RuneLen(s)+1; n++ {
+			t := runestr.Truncate(s, n)
+			check(utf8.ValidString(t), "%s: Truncate(%d) is valid UTF-8", sample.name, n)
+			check(runestr.RuneLen(t) == min(n, runestr.RuneLen(s)), "%s: Truncate(%d) length", sample.name, n)
+			check(strings.HasPrefix(s, t), "%s: Truncate(%d) is a prefix", sample.name, n)
+			g := runestr.TruncateGraphemes(s, n)
+			check(runestr.GraphemeLen(g) == min(n, runestr.GraphemeLen(s)), "%s: TruncateGraphemes(%d) length", sample.name, n)
+			check(strings.HasPrefix(s, g), "%s: TruncateGraphemes(%d) is a prefix", sample.name, n)
+		}
+	}
+	fmt.Fprintf(w, "%d checks, %d failures\n", checks, failures)
+
+	// Explanation:
+	// Example outputs show what a function does to a few inputs; properties
+	// say what must be true for all of them, and checking them over every
+	// length of every sample covers the boundaries (0, the exact length,
+	// past the end) where off-by-one errors in rune counting hide.
+}
@@ -0,0 +1,52 @@
+// Package sliceviz makes a slice's hidden header visible: the pointer to
+// its backing array, its length and its capacity. Printing these next to
+// each other shows when two slices share memory, and when append quietly
+// moves a slice to a new array.
+package sliceviz
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// Header mirrors the three words Go stores for every slice value.
+type Header struct {
+	Data uintptr // address of the first element
+	Len  int
+	Cap  int
+}
+
+// HeaderOf returns the header of s. unsafe.SliceData replaces the old
+// reflect.SliceHeader trick for reading the data pointer.
+func HeaderOf[T any](s []T) Header {
+	return Header{
+		Data: uintptr(unsafe.Pointer(unsafe.SliceData(s))),
+		Len:  len(s),
+		Cap:  cap(s),
+	}
+}
+
+// end returns the address just past the last element the slice could grow
+// into without reallocating.
+func end[T any](h Header) uintptr {
+	var zero T
+	return h.Data + uintptr(h.Cap)*unsafe.Sizeof(zero)
+}
+
+// SharesMemory reports whether a and b use overlapping parts of the same
+// backing array, counting capacity as well as length, because that is the
+// region an append can write to.
+func SharesMemory[T any](a, b []T) bool {
+	ha, hb := HeaderOf(a), HeaderOf(b)
+	if ha.Cap == 0 || hb.Cap == 0 {
+		return false
+	}
+	return ha.Data < end[T](hb) && hb.Data < end[T](ha)
+}
+
+// Describe formats s as a single line: its name, data pointer, length,
+// capacity and contents.
+func Describe[T any](name string, s []T) string {
+	h := HeaderOf(s)
+	return fmt.Sprintf("%-8s data=%#x len=%d cap=%d %v", name, h.Data, h.Len, h.Cap, s)
+}
@@ -0,0 +1,96 @@
+// Package tracegrow records how a slice's capacity grows as values are
+// appended to it. Every time append has to reallocate, a Tracer logs the
+// old and new capacity and whether the backing array moved, so the growth
+// strategy of the runtime can be inspected and exported as CSV or JSON.
+package tracegrow
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"unsafe"
+)
+
+// Event is one capacity change.
+type Event struct {
+	// Len is the length of the slice right after the growing append.
+	Len    int  `json:"len"`
+	OldCap int  `json:"old_cap"`
+	NewCap int  `json:"new_cap"`
+	Moved  bool `json:"moved"` // the data was copied to a new array
+}
+
+// Tracer wraps a slice and records an Event whenever appending to it
+// changes its capacity. The zero value is ready to use.
+type Tracer[T any] struct {
+	s      []T
+	events []Event
+}
+
+// Append appends values to the traced slice, one at a time, so that every
+// reallocation is observed separately.
+func (t *Tracer[T]) Append(values ...T) {
+	for _, v := range values {
+		oldCap := cap(t.s)
+		oldData := unsafe.SliceData(t.s)
+		t.s = append(t.s, v)
+		if cap(t.s) != oldCap {
+			t.events = append(t.events, Event{
+				Len:    len(t.s),
+				OldCap: oldCap,
+				NewCap: cap(t.s),
+				Moved:  oldData != nil && unsafe.SliceData(t.s) != oldData,
+			})
+		}
+	}
+}
+
+// Slice returns the traced slice.
+func (t *Tracer[T]) Slice() []T {
+	return t.s
+}
+
+// Events returns the capacity changes recorded so far.
+func (t *Tracer[T]) Events() []Event {
+	return t.events
+}
+
+// Grow appends n zero values of T to an empty slice and returns the
+// recorded events. It is a shortcut for comparing element types.
+func Grow[T any](n int) []Event {
+	var t Tracer[T]
+	var zero T
+	for i := 0; i < n; i++ {
+		t.Append(zero)
+	}
+	return t.Events()
+}
+
+// WriteCSV writes events as CSV with a header row.
+func WriteCSV(w io.Writer, events []Event) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"len", "old_cap", "new_cap", "moved"}); err != nil {
+		return err
+	}
+	for _, e := range events {
+		record := []string{
+			strconv.Itoa(e.Len),
+			strconv.Itoa(e.OldCap),
+			strconv.Itoa(e.NewCap),
+			strconv.FormatBool(e.Moved),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes events as an indented JSON array.
+func WriteJSON(w io.Writer, events []Event) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(events)
+}
@@ -0,0 +1,108 @@
+// Package escape holds pairs of functions that do the same work, where the
+// first of each pair keeps its data on the stack and the second forces it
+// to escape to the heap. chapter6 compiles this package with
+// -gcflags=-m to show the compiler's decisions and measures the
+// allocations of each function.
+//
+// Every function is marked //go:noinline. Inlining would let the compiler
+// analyze each call in its caller's context and hide the difference.
+package escape
+
+import "fmt"
+
+// Point is a small value type used by the examples.
+type Point struct {
+	X, Y int
+}
+
+// NewPointValue returns a Point by value. The caller gets a copy, so the
+// local variable can live on the stack.
+//
+//go:noinline
+func NewPointValue(x, y int) Point {
+	p := Point{X: x, Y: y}
+	return p
+}
+
+// NewPointPointer returns a pointer to a local Point. The Point must
+// outlive the function call, so it is moved to the heap.
+//
+//go:noinline
+func NewPointPointer(x, y int) *Point {
+	p := Point{X: x, Y: y}
+	return &p
+}
+
+// FixedBuffer uses a buffer whose size is a constant known at compile
+// time, so it can be allocated on the stack.
+//
+//go:noinline
+func FixedBuffer() int {
+	buf := make([]byte, 64)
+	for i := range buf {
+		buf[i] = byte(i)
+	}
+	return int(buf[63])
+}
+
+// DynamicBuffer uses a buffer whose size is only known at run time. The
+// buffer is not kept after the call, so -gcflags=-m reports that it does
+// not escape. That does not put it on the stack: a stack frame's size is
+// fixed at compile time, so the compiler reserves only a small buffer (32
+// bytes) in the frame, and make allocates on the heap when n is larger.
+// It returns 0 if n is not positive.
+//
+//go:noinline
+func DynamicBuffer(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	buf := make([]byte, n)
+	for i := range buf {
+		buf[i] = byte(i)
+	}
+	return int(buf[n-1])
+}
+
+// SumFields reads the fields of p directly instead of passing p itself.
+// The sum still goes to fmt.Sprintf as an any, so -gcflags=-m reports
+// that p.X + p.Y escapes to the heap. It does not allocate for a sum
+// below 256, because the runtime converts those to interfaces from a
+// preallocated table.
+//
+//go:noinline
+func SumFields(p Point) string {
+	return fmt.Sprintf("%d", p.X+p.Y)
+}
+
+// SumViaInterface passes p to fmt.Sprint as an any. Converting a
+// non-pointer value to an interface that escapes copies it to the heap.
+//
+//go:noinline
+func SumViaInterface(p Point) string {
+	return fmt.Sprint(p)
+}
+
+// CountLocal increments a local counter inside a loop. The counter stays
+// on the stack.
+//
+//go:noinline
+func CountLocal(times int) int {
+	n := 0
+	for i := 0; i < times; i++ {
+		n++
+	}
+	return n
+}
+
+// CountWithClosure returns a closure that captures a local counter. The
+// closure outlives the call, so the counter is moved to the heap.
+//
+//go:noinline
+func CountWithClosure() func() int {
+	n := 0
+	return func() int {
+		n++
+		return n
+	}
+}
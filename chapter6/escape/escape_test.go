@@ -0,0 +1,18 @@
+package escape
+
+import "testing"
+
+func TestDynamicBuffer(t *testing.T) {
+	tests := []struct{ n, want int }{
+		{-1, 0},
+		{0, 0},
+		{1, 0},
+		{64, 63},
+		{300, 43}, // byte(299)
+	}
+	for _, tt := range tests {
+		if got := DynamicBuffer(tt.n); got != tt.want {
+			t.Errorf("DynamicBuffer(%d) = %d, want %d", tt.n, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,461 @@
+package chapter6
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"math"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"learning-go/chapter6/escape"
+	"learning-go/ptr"
+	"learning-go/randsource"
+	"learning-go/registry"
+	"learning-go/safe"
+)
+
+func init() {
+	// Register each exercise with the runner (cmd/learn)
+	registry.Register("chapter6", "exercise1", exercise1)
+	registry.Register("chapter6", "exercise2", exercise2)
+	registry.Register("chapter6", "exercise3", exercise3)
+	registry.Register("chapter6", "exercise4", exercise4)
+	registry.Register("chapter6", "exercise5", exercise5)
+}
+
+// escapeLine matches the lines of -gcflags=-m output we care about, e.g.
+// "chapter6/escape/escape.go:32:2: moved to heap: p".
+var escapeLine = regexp.MustCompile(`^(.*\.go):(\d+):\d+: (.*(?:escapes to heap|moved to heap|does not escape).*)$`)
+
+// Exercise 1: Ask the compiler which values in the escape package stay on
+// the stack and which escape to the heap, then confirm the decisions by
+// counting the allocations each function makes per call.
+func exercise1(w io.Writer) {
+	// -gcflags=-m makes the compiler print its escape analysis decisions.
+	// They are written to stderr, which CombinedOutput captures.
+	out, err := exec.Command("go", "build", "-gcflags=-m", "learning-go/chapter6/escape").CombinedOutput()
+	if err != nil {
+		fmt.Fprintln(w, "go build failed:", err)
+		fmt.Fprint(w, string(out))
+		return
+	}
+
+	decisions := map[int][]string{}
+	var file string
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		m := escapeLine.FindStringSubmatch(scanner.Text())
+		if m == nil {
+			continue
+		}
+		file = m[1]
+		line, _ := strconv.Atoi(m[2])
+		decisions[line] = append(decisions[line], m[3])
+	}
+
+	// Map line numbers back to the function they belong to.
+	funcs, err := functionsByLine(file)
+	if err != nil {
+		fmt.Fprintln(w, "parsing", file, "failed:", err)
+		return
+	}
+
+	byFunc := map[string][]string{}
+	for line, msgs := range decisions {
+		name := funcs(line)
+		byFunc[name] = append(byFunc[name], msgs...)
+	}
+
+	// Allocations per call, measured with the same helper benchmarks use.
+	allocs := []struct {
+		name string
+		fn   func()
+	}{
+		{"NewPointValue", func() { _ = escape.NewPointValue(1, 2) }},
+		{"NewPointPointer", func() { _ = escape.NewPointPointer(1, 2) }},
+		{"FixedBuffer", func() { _ = escape.FixedBuffer() }},
+		{"DynamicBuffer", func() { _ = escape.DynamicBuffer(64) }},
+		{"SumFields", func() { _ = escape.SumFields(escape.Point{X: 1, Y: 2}) }},
+		{"SumViaInterface", func() { _ = escape.SumViaInterface(escape.Point{X: 1, Y: 2}) }},
+		{"CountLocal", func() { _ = escape.CountLocal(10) }},
+		{"CountWithClosure", func() { _ = escape.CountWithClosure() }},
+	}
+
+	for _, a := range allocs {
+		fmt.Fprintf(w, "%-17s allocs/op: %.0f\n", a.name, testing.AllocsPerRun(100, a.fn))
+		msgs := byFunc[a.name]
+		sort.Strings(msgs)
+		for _, msg := range msgs {
+			fmt.Fprintln(w, "    compiler:", msg)
+		}
+	}
+
+	// Explanation:
+	// Each pair does the same work, but the second one allocates. Returning
+	// a pointer to a local, storing a value in an interface that leaves the
+	// function, and capturing a variable in a returned closure all make the
+	// compiler move data to the heap ("moved to heap" / "escapes to heap").
+	// Two results are worth a closer look:
+	// - DynamicBuffer's buffer "does not escape", yet it still allocates:
+	//   the stack frame size is fixed at compile time, so the frame only
+	//   has room for a small buffer (32 bytes). The 64 bytes asked for
+	//   here, known only at run time, go to the heap anyway.
+	// - SumFields reports that p.X + p.Y escapes, but allocates nothing:
+	//   the runtime has preallocated interface values for small integers.
+	// Heap allocations cost time and give the garbage collector work, so
+	// they are worth checking with -gcflags=-m in hot code.
+}
+
+// functionsByLine parses a Go file and returns a lookup from a line number
+// to the name of the function declared around it.
+func functionsByLine(path string) (func(line int) string, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filepath.Clean(path), nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	type span struct {
+		name       string
+		start, end int
+	}
+	var spans []span
+	for _, decl := range f.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok {
+			spans = append(spans, span{
+				name:  fn.Name.Name,
+				start: fset.Position(fn.Pos()).Line,
+				end:   fset.Position(fn.End()).Line,
+			})
+		}
+	}
+	return func(line int) string {
+		for _, s := range spans {
+			if line >= s.start && line <= s.end {
+				return s.name
+			}
+		}
+		return ""
+	}, nil
+}
+
+// gcRun is the outcome of running the allocation workload once.
+type gcRun struct {
+	label  string
+	cycles uint32
+	pause  time.Duration
+	peak   uint64
+}
+
+// churn allocates total bytes in 1 KiB chunks while keeping the most
+// recent liveBytes reachable, so the heap always holds some live data
+// and the collector has both garbage and survivors to deal with.
+func churn(total, liveBytes int) uint64 {
+	const chunk = 1 << 10
+	live := make([][]byte, liveBytes/chunk)
+	var ms runtime.MemStats
+	var peak uint64
+	for i := 0; i < total/chunk; i++ {
+		live[i%len(live)] = make([]byte, chunk)
+		if i%4096 == 0 {
+			runtime.ReadMemStats(&ms)
+			peak = max(peak, ms.HeapAlloc)
+		}
+	}
+	runtime.KeepAlive(live)
+	return peak
+}
+
+// measureGC runs the workload with the given GOGC percentage and memory
+// limit and reports how many collections it triggered.
+func measureGC(label string, gcPercent int, memLimit int64) gcRun {
+	// Both setters return the previous value, which we restore afterwards
+	oldPercent := debug.SetGCPercent(gcPercent)
+	oldLimit := debug.SetMemoryLimit(memLimit)
+	defer debug.SetGCPercent(oldPercent)
+	defer debug.SetMemoryLimit(oldLimit)
+
+	// Start every run from a freshly collected heap
+	runtime.GC()
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	peak := churn(256<<20, 16<<20)
+
+	runtime.ReadMemStats(&after)
+	return gcRun{
+		label:  label,
+		cycles: after.NumGC - before.NumGC,
+		pause:  time.Duration(after.PauseTotalNs - before.PauseTotalNs),
+		peak:   peak,
+	}
+}
+
+// Exercise 2: Allocate 256 MiB in a loop (with 16 MiB of it kept live)
+// under different garbage collector settings, and chart how many GC cycles
+// each setting triggers, how long the collector paused the program and how
+// large the heap grew.
+func exercise2(w io.Writer) {
+	runs := []gcRun{
+		measureGC("GOGC=25", 25, math.MaxInt64),
+		measureGC("GOGC=100", 100, math.MaxInt64),
+		measureGC("GOGC=400", 400, math.MaxInt64),
+		// GOGC=off with a limit: the collector only runs when the heap
+		// approaches the memory limit
+		measureGC("GOGC=off, limit=64MiB", -1, 64<<20),
+	}
+
+	var most uint32
+	for _, r := range runs {
+		most = max(most, r.cycles)
+	}
+	for _, r := range runs {
+		bar := 0
+		if most > 0 {
+			bar = int(r.cycles * 40 / most)
+		}
+		fmt.Fprintf(w, "%-22s %-40s %3d cycles, paused %8v, peak heap %3d MiB\n",
+			r.label, strings.Repeat("#", bar), r.cycles, r.pause.Round(time.Microsecond), r.peak>>20)
+	}
+
+	// Explanation:
+	// GOGC sets how much the heap may grow, relative to the live data left
+	// after the last collection, before the next cycle starts. A low value
+	// collects often and keeps the heap small; a high value collects rarely
+	// but lets the heap grow. GOMEMLIMIT (debug.SetMemoryLimit) adds a soft
+	// ceiling: with GOGC=off the collector does nothing until the heap gets
+	// close to the limit. The same settings can be applied without code
+	// changes through the GOGC and GOMEMLIMIT environment variables.
+}
+
+// Account is a bank account for the pointer exercises.
+type Account struct {
+	Owner   string
+	Balance int
+	History []int
+}
+
+// DepositCopy has a value receiver: it changes a copy of the account,
+// which is thrown away when it returns.
+func (a Account) DepositCopy(amount int) {
+	a.Balance += amount
+	a.History = append(a.History, amount)
+}
+
+// Deposit has a pointer receiver, so it changes the caller's account.
+func (a *Account) Deposit(amount int) {
+	a.Balance += amount
+	a.History = append(a.History, amount)
+}
+
+// Exercise 3: Try to change an Account through a value and through a
+// pointer: as a function parameter, as a method receiver, in a range loop
+// over a slice, and as a map value. Print which changes stick.
+func exercise3(w io.Writer) {
+	byValue := func(a Account) { a.Balance = 100 }
+	byPointer := func(a *Account) { a.Balance = 100 }
+
+	a := Account{Owner: "ada"}
+	byValue(a)
+	fmt.Fprintln(w, "after a function taking Account:  ", a.Balance)
+	byPointer(&a)
+	fmt.Fprintln(w, "after a function taking *Account: ", a.Balance)
+
+	a.DepositCopy(50)
+	fmt.Fprintln(w, "after DepositCopy(50), value receiver:  ", a.Balance, a.History)
+	a.Deposit(50) // Go takes &a automatically because a is addressable
+	fmt.Fprintln(w, "after Deposit(50), pointer receiver:    ", a.Balance, a.History)
+
+	accounts := []Account{{Owner: "ada"}, {Owner: "ken"}}
+	for _, acc := range accounts {
+		acc.Deposit(10) // acc is a copy of the element
+	}
+	fmt.Fprintln(w, "range by value, Deposit(10) on each:", accounts[0].Balance, accounts[1].Balance)
+	for i := range accounts {
+		accounts[i].Deposit(10)
+	}
+	fmt.Fprintln(w, "range by index, Deposit(10) on each:", accounts[0].Balance, accounts[1].Balance)
+
+	// m["ada"].Balance = 1 does not compile: map values are not
+	// addressable. Read, change and store back, or keep pointers.
+	byOwner := map[string]Account{"ada": {Owner: "ada"}}
+	acc := byOwner["ada"]
+	acc.Deposit(20)
+	fmt.Fprintln(w, "map of values, changed copy:", byOwner["ada"].Balance)
+	byOwner["ada"] = acc
+	fmt.Fprintln(w, "map of values, stored back: ", byOwner["ada"].Balance)
+	pointers := map[string]*Account{"ada": {Owner: "ada"}}
+	pointers["ada"].Deposit(20)
+	fmt.Fprintln(w, "map of pointers:            ", pointers["ada"].Balance)
+
+	// Copying a struct copies its slice header, not the slice's elements.
+	b := Account{Owner: "ken", History: make([]int, 1, 4)}
+	c := b
+	c.History[0] = 99
+	fmt.Fprintln(w, "copy shares the History array:", b.History, c.History)
+
+	// Explanation:
+	// Go passes everything by value: a function or method gets a copy of
+	// its argument, so changing a struct parameter changes only the copy.
+	// Passing a pointer copies the address instead, and changes through it
+	// reach the original. The same rule explains the range loop, whose
+	// variable is a copy of each element, and the map, whose values cannot
+	// be addressed because the map may move them as it grows. A struct
+	// copy is shallow: fields that are slices, maps or pointers still
+	// refer to the same underlying data as the original.
+}
+
+// point is 24 bytes, so that the slice of values and the slice of
+// pointers differ in how they are laid out, not in how much they copy.
+type point struct {
+	X, Y, Z int
+}
+
+// Exercise 4: Sum the X fields of a million points stored as a []point
+// and as a []*point, with the pointers first in allocation order and then
+// shuffled, and compare the time per element.
+func exercise4(w io.Writer) {
+	const n = 1 << 20
+	values := make([]point, n)
+	pointers := make([]*point, n)
+	for i := range values {
+		values[i] = point{X: i}
+		pointers[i] = &point{X: i}
+	}
+	shuffled := slices.Clone(pointers)
+	r := randsource.New("chapter6/pointers")
+	r.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+
+	var sum int
+	perElement := func(res testing.BenchmarkResult) float64 {
+		return float64(res.T.Nanoseconds()) / float64(res.N) / n
+	}
+	results := []struct {
+		name string
+		ns   float64
+	}{
+		{"[]point", perElement(testing.Benchmark(func(b *testing.B) {
+			for range b.N {
+				for i := range values {
+					sum += values[i].X
+				}
+			}
+		}))},
+		{"[]*point, allocation order", perElement(testing.Benchmark(func(b *testing.B) {
+			for range b.N {
+				for _, p := range pointers {
+					sum += p.X
+				}
+			}
+		}))},
+		{"[]*point, shuffled", perElement(testing.Benchmark(func(b *testing.B) {
+			for range b.N {
+				for _, p := range shuffled {
+					sum += p.X
+				}
+			}
+		}))},
+	}
+	for _, res := range results {
+		fmt.Fprintf(w, "%-28s %6.2f ns/element (%.1fx)\n", res.name, res.ns, res.ns/results[0].ns)
+	}
+	runtime.KeepAlive(sum)
+
+	// Explanation:
+	// A []point keeps the points next to each other, so the loop reads
+	// memory in order and the CPU prefetches the next cache line before it
+	// is needed. A []*point adds a step: load the pointer, then load the
+	// point it refers to. While the points happen to sit in memory in the
+	// order they were allocated the cost is small, but shuffled, every
+	// load lands somewhere unpredictable and the loop waits on memory.
+	// Pointers also give the garbage collector more to trace. Store values
+	// unless elements must be shared or are too large to copy.
+}
+
+// node is a linked-list node whose methods work on a nil *node, which
+// stands for the empty list.
+type node struct {
+	val  int
+	next *node
+}
+
+// Len returns the length of the list starting at n, 0 for a nil list.
+func (n *node) Len() int {
+	if n == nil {
+		return 0
+	}
+	return 1 + n.next.Len()
+}
+
+// validationError is an error type whose methods have a pointer receiver.
+type validationError struct{ field string }
+
+func (e *validationError) Error() string { return e.field + " is invalid" }
+
+// validate returns a nil *validationError as an error, which is a bug: the
+// interface it returns is not nil.
+func validate(ok bool) error {
+	var err *validationError
+	if !ok {
+		err = &validationError{field: "balance"}
+	}
+	return err
+}
+
+// Exercise 5: Run into the common nil-pointer mistakes: dereferencing a
+// nil pointer, calling a method on one, writing to a nil map inside a
+// struct, and returning a nil pointer as an error. Recover the panics with
+// safe.SafeCall and print them.
+func exercise5(w io.Writer) {
+	var a *Account
+	fmt.Fprintln(w, "read a nil *Account:", safe.SafeCall(func() error {
+		_ = a.Balance
+		return nil
+	}))
+	fmt.Fprintln(w, "Deposit on a nil *Account:", safe.SafeCall(func() error {
+		a.Deposit(1)
+		return nil
+	}))
+	fmt.Fprintln(w, "nil-safe read with ptr.Deref:", ptr.Deref(a, Account{Owner: "nobody"}).Owner)
+
+	var list *node
+	fmt.Fprintln(w, "Len of a nil *node:", list.Len())
+	list = &node{1, &node{2, nil}}
+	fmt.Fprintln(w, "Len of a two-node list:", list.Len())
+
+	type registry struct{ accounts map[string]*Account }
+	var reg registry
+	fmt.Fprintln(w, "read from a nil map:", reg.accounts["ada"])
+	fmt.Fprintln(w, "write to a nil map:", safe.SafeCall(func() error {
+		reg.accounts["ada"] = &Account{}
+		return nil
+	}))
+
+	err := validate(true)
+	fmt.Fprintf(w, "validate(true) == nil: %v (type %T, value %v)\n", err == nil, err, err)
+
+	// Explanation:
+	// Reading or writing through a nil pointer panics with a runtime error,
+	// and so does calling a method that then touches a field. A method
+	// call itself is fine: the receiver is just a parameter, and node.Len
+	// checks for nil and treats it as the empty list. Reading a nil map
+	// returns the zero value, but writing to one panics, so a struct with
+	// a map field needs a constructor or a lazy make. The last line is the
+	// subtlest: an interface holds a type and a value, and returning a nil
+	// *validationError as an error fills in the type, so the interface is
+	// not nil even though the pointer inside it is. Return a literal nil
+	// for "no error".
+}
@@ -0,0 +1,105 @@
+package chapter6
+
+import (
+	"runtime"
+	"slices"
+	"testing"
+
+	"learning-go/randsource"
+)
+
+func TestReceivers(t *testing.T) {
+	a := Account{Owner: "ada"}
+	a.DepositCopy(50)
+	if a.Balance != 0 || len(a.History) != 0 {
+		t.Errorf("DepositCopy changed the caller: %+v", a)
+	}
+	a.Deposit(50)
+	a.Deposit(25)
+	if a.Balance != 75 || !slices.Equal(a.History, []int{50, 25}) {
+		t.Errorf("after two Deposits: %+v", a)
+	}
+
+	accounts := []Account{{Owner: "ada"}, {Owner: "ken"}}
+	for _, acc := range accounts {
+		acc.Deposit(10)
+	}
+	if accounts[0].Balance != 0 {
+		t.Error("Deposit on the range variable changed the slice element")
+	}
+	for i := range accounts {
+		accounts[i].Deposit(10)
+	}
+	if accounts[0].Balance != 10 || accounts[1].Balance != 10 {
+		t.Errorf("Deposit by index: %+v", accounts)
+	}
+}
+
+func TestNilReceivers(t *testing.T) {
+	var list *node
+	if n := list.Len(); n != 0 {
+		t.Errorf("nil list Len() = %d", n)
+	}
+	if n := (&node{1, &node{2, &node{3, nil}}}).Len(); n != 3 {
+		t.Errorf("Len() = %d, want 3", n)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Deposit on a nil *Account did not panic")
+		}
+	}()
+	var a *Account
+	a.Deposit(1)
+}
+
+func TestTypedNilError(t *testing.T) {
+	if err := validate(false); err == nil {
+		t.Error("validate(false) = nil")
+	}
+	// The bug exercise 5 shows: no error, yet not nil.
+	if err := validate(true); err == nil {
+		t.Error("validate(true) is a nil interface; the exercise relies on it holding a typed nil")
+	}
+}
+
+// BenchmarkIndirection is exercise 4 as a benchmark: summing a field over
+// a slice of values, a slice of pointers in allocation order, and the same
+// pointers shuffled.
+func BenchmarkIndirection(b *testing.B) {
+	const n = 1 << 20
+	values := make([]point, n)
+	pointers := make([]*point, n)
+	for i := range values {
+		values[i] = point{X: i}
+		pointers[i] = &point{X: i}
+	}
+	shuffled := slices.Clone(pointers)
+	r := randsource.New("chapter6/pointers")
+	r.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+
+	var sum int
+	b.Run("values", func(b *testing.B) {
+		for range b.N {
+			for i := range values {
+				sum += values[i].X
+			}
+		}
+	})
+	for _, bc := range []struct {
+		name string
+		ps   []*point
+	}{
+		{"pointers", pointers},
+		{"pointers_shuffled", shuffled},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			for range b.N {
+				for _, p := range bc.ps {
+					sum += p.X
+				}
+			}
+		})
+	}
+	runtime.KeepAlive(sum)
+}
@@ -0,0 +1,90 @@
+package di
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+
+	"learning-go/chapter7/employees"
+)
+
+// Box is a minimal dependency injection container. Constructors are
+// registered per type and run at most once, on first Resolve of that
+// type; the result is shared by everything that depends on it.
+//
+// Unlike Manual and Wire, mistakes such as a missing or cyclic dependency
+// only show up at run time, as a panic from Resolve.
+type Box struct {
+	mu        sync.Mutex
+	providers map[reflect.Type]func(*Box) any
+	instances map[reflect.Type]any
+	building  map[reflect.Type]bool
+}
+
+// NewBox returns an empty container.
+func NewBox() *Box {
+	return &Box{
+		providers: make(map[reflect.Type]func(*Box) any),
+		instances: make(map[reflect.Type]any),
+		building:  make(map[reflect.Type]bool),
+	}
+}
+
+// Provide registers fn as the constructor for T, replacing any earlier one.
+func Provide[T any](b *Box, fn func(*Box) T) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.providers[reflect.TypeFor[T]()] = func(b *Box) any { return fn(b) }
+}
+
+// Resolve returns the T built by its registered constructor, building it
+// (and its own dependencies) the first time. It panics if no constructor
+// is registered for T or if T ends up depending on itself.
+func Resolve[T any](b *Box) T {
+	t := reflect.TypeFor[T]()
+
+	b.mu.Lock()
+	if v, ok := b.instances[t]; ok {
+		b.mu.Unlock()
+		return v.(T)
+	}
+	fn, ok := b.providers[t]
+	if !ok {
+		b.mu.Unlock()
+		panic(fmt.Sprintf("di: no provider for %v", t))
+	}
+	if b.building[t] {
+		b.mu.Unlock()
+		panic(fmt.Sprintf("di: dependency cycle through %v", t))
+	}
+	b.building[t] = true
+	b.mu.Unlock()
+
+	// Build without holding the lock: fn resolves its own dependencies.
+	v := fn(b)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.building, t)
+	b.instances[t] = v
+	return v.(T)
+}
+
+// Container wires the service through a Box. Each registration names only
+// the pieces its constructor needs, so they can be listed in any order
+// and swapped one at a time, at the cost of compile-time checking.
+func Container(log employees.Logger) http.Handler {
+	b := NewBox()
+	Provide(b, func(*Box) employees.Logger { return log })
+	Provide(b, func(*Box) employees.Store {
+		return employees.NewMemoryStore(employees.Seed)
+	})
+	Provide(b, func(b *Box) *employees.Service {
+		return employees.NewService(Resolve[employees.Store](b), Resolve[employees.Logger](b))
+	})
+	Provide(b, func(b *Box) http.Handler {
+		return employees.NewHandler(Resolve[*employees.Service](b))
+	})
+	return Resolve[http.Handler](b)
+}
@@ -0,0 +1,115 @@
+package di
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"learning-go/chapter7/employees"
+)
+
+// logRecorder is an employees.Logger that keeps what is logged.
+type logRecorder struct{ lines []string }
+
+func (l *logRecorder) Printf(format string, args ...any) {
+	l.lines = append(l.lines, fmt.Sprintf(format, args...))
+}
+
+var wirings = []struct {
+	name string
+	wire func(employees.Logger) http.Handler
+}{
+	{"Manual", Manual},
+	{"Container", Container},
+	{"Wire", Wire},
+}
+
+// TestWirings runs the same requests against every wiring.
+func TestWirings(t *testing.T) {
+	for _, w := range wirings {
+		t.Run(w.name, func(t *testing.T) {
+			log := &logRecorder{}
+			h := w.wire(log)
+			do := func(method, target string) *httptest.ResponseRecorder {
+				rec := httptest.NewRecorder()
+				h.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+				return rec
+			}
+
+			rec := do("GET", "/employees")
+			var list []employees.Employee
+			if err := json.NewDecoder(rec.Body).Decode(&list); err != nil || len(list) != len(employees.Seed) {
+				t.Errorf("GET /employees = %d %v, %v", rec.Code, list, err)
+			}
+
+			rec = do("POST", "/employees/2/raise?percent=10")
+			var e employees.Employee
+			if err := json.NewDecoder(rec.Body).Decode(&e); err != nil || e.Salary != 6600 {
+				t.Errorf("raise = %d %+v, %v; want Grace at 6600", rec.Code, e, err)
+			}
+			if len(log.lines) != 1 || !strings.Contains(log.lines[0], "raised Grace by 10%") {
+				t.Errorf("logged %q, want the raise", log.lines)
+			}
+			rec = do("GET", "/employees/2")
+			if !strings.Contains(rec.Body.String(), `"salary":6600`) {
+				t.Errorf("after the raise GET /employees/2 = %s", rec.Body.String())
+			}
+
+			for target, want := range map[string]int{
+				"/employees/99":                 http.StatusNotFound,
+				"/employees/x":                  http.StatusBadRequest,
+				"/employees/1/raise?percent=80": http.StatusBadRequest,
+				"/employees/99/raise?percent=5": http.StatusNotFound,
+			} {
+				method := "GET"
+				if strings.Contains(target, "raise") {
+					method = "POST"
+				}
+				if rec := do(method, target); rec.Code != want {
+					t.Errorf("%s %s = %d, want %d", method, target, rec.Code, want)
+				}
+			}
+		})
+	}
+	// Each wiring builds its own store from Seed, which stays untouched.
+	if employees.Seed[1].Salary != 6000 {
+		t.Errorf("Seed changed: %+v", employees.Seed[1])
+	}
+}
+
+func TestBoxSharesInstances(t *testing.T) {
+	b := NewBox()
+	built := 0
+	Provide(b, func(*Box) *employees.MemoryStore {
+		built++
+		return employees.NewMemoryStore(nil)
+	})
+	if Resolve[*employees.MemoryStore](b) != Resolve[*employees.MemoryStore](b) || built != 1 {
+		t.Errorf("the store was built %d times, want once and shared", built)
+	}
+}
+
+func TestBoxPanics(t *testing.T) {
+	type a struct{}
+	type b struct{}
+	box := NewBox()
+	Provide(box, func(box *Box) a { Resolve[b](box); return a{} })
+	Provide(box, func(box *Box) b { Resolve[a](box); return b{} })
+
+	for name, resolve := range map[string]func(){
+		"missing": func() { Resolve[int](box) },
+		"cycle":   func() { Resolve[a](box) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: Resolve did not panic", name)
+				}
+			}()
+			resolve()
+		}()
+	}
+}
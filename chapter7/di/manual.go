@@ -0,0 +1,26 @@
+// Package di wires the employees service together three ways, so the
+// results can be compared side by side:
+//
+//   - Manual calls each constructor in order by hand.
+//   - Container registers constructors in a tiny container that builds
+//     each piece on first use.
+//   - Wire is generated by github.com/google/wire from the injector in
+//     wire.go (run "go generate ./chapter7/di" after changing it).
+//
+// All three return the same handler given the same logger.
+package di
+
+import (
+	"net/http"
+
+	"learning-go/chapter7/employees"
+)
+
+// Manual wires the service with plain constructor calls. The dependency
+// graph is the code: easy to read, checked by the compiler, and it has
+// to be edited by hand whenever a constructor's parameters change.
+func Manual(log employees.Logger) http.Handler {
+	store := employees.NewMemoryStore(employees.Seed)
+	svc := employees.NewService(store, log)
+	return employees.NewHandler(svc)
+}
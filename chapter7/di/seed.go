@@ -0,0 +1,9 @@
+package di
+
+import "learning-go/chapter7/employees"
+
+// seed provides the sample data to wire, which needs a function rather
+// than a variable to build the store from.
+func seed() []employees.Employee {
+	return employees.Seed
+}
@@ -0,0 +1,28 @@
+//go:build wireinject
+
+package di
+
+import (
+	"net/http"
+
+	"github.com/google/wire"
+
+	"learning-go/chapter7/employees"
+)
+
+// Wire wires the service with code generated by wire. This injector only
+// lists the pieces; wire works out the order, checks that every
+// dependency is provided, and writes the equivalent of Manual to
+// wire_gen.go. Errors in the graph are reported when generating, not at
+// run time.
+func Wire(log employees.Logger) http.Handler {
+	wire.Build(
+		seed,
+		employees.NewMemoryStore,
+		wire.Bind(new(employees.Store), new(*employees.MemoryStore)),
+		employees.NewService,
+		employees.NewHandler,
+		wire.Bind(new(http.Handler), new(*employees.Handler)),
+	)
+	return nil
+}
@@ -0,0 +1,27 @@
+// Code generated by Wire. DO NOT EDIT.
+
+//go:generate go run -mod=mod github.com/google/wire/cmd/wire
+//go:build !wireinject
+// +build !wireinject
+
+package di
+
+import (
+	"learning-go/chapter7/employees"
+	"net/http"
+)
+
+// Injectors from wire.go:
+
+// Wire wires the service with code generated by wire. This injector only
+// lists the pieces; wire works out the order, checks that every
+// dependency is provided, and writes the equivalent of Manual to
+// wire_gen.go. Errors in the graph are reported when generating, not at
+// run time.
+func Wire(log employees.Logger) http.Handler {
+	v := seed()
+	memoryStore := employees.NewMemoryStore(v)
+	service := employees.NewService(memoryStore, log)
+	handler := employees.NewHandler(service)
+	return handler
+}
@@ -0,0 +1,105 @@
+// Package embedding covers composition in chapter 7: chapter 3's Employee
+// grows methods, and a Manager embeds it to reuse them. Embedding promotes
+// the embedded type's fields and methods to the outer type, which lets the
+// outer type satisfy interfaces it never declares methods for, but it is
+// not inheritance: there is no overriding and no virtual dispatch.
+package embedding
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Employee is chapter 3's Employee with exported fields and methods.
+type Employee struct {
+	FirstName string
+	LastName  string
+	ID        int
+}
+
+// FullName has a value receiver, so it is in the method set of both
+// Employee and *Employee.
+func (e Employee) FullName() string {
+	return e.FirstName + " " + e.LastName
+}
+
+// Describe says who e is.
+func (e Employee) Describe() string {
+	return fmt.Sprintf("%s (#%d)", e.FullName(), e.ID)
+}
+
+// Badge prints e's Describe. It calls Employee.Describe even when e is
+// embedded in a type with its own Describe: Go has no virtual methods.
+func (e Employee) Badge() string {
+	return "[" + e.Describe() + "]"
+}
+
+// Rename has a pointer receiver, so it is only in the method set of
+// *Employee.
+func (e *Employee) Rename(last string) {
+	e.LastName = last
+}
+
+// Describer is anything that can describe itself.
+type Describer interface {
+	Describe() string
+}
+
+// Renamer is anything whose last name can be changed.
+type Renamer interface {
+	Rename(last string)
+}
+
+// Manager embeds an Employee by value and adds the people who report to
+// them. Its own ID field, a string, shadows Employee's int ID.
+type Manager struct {
+	Employee
+	ID      string // the manager's badge code
+	Reports []Employee
+}
+
+// Describe shadows the promoted Employee.Describe.
+func (m Manager) Describe() string {
+	names := make([]string, len(m.Reports))
+	for i, r := range m.Reports {
+		names[i] = r.FirstName
+	}
+	return fmt.Sprintf("%s, manager %s of %s", m.Employee.Describe(), m.ID, strings.Join(names, ", "))
+}
+
+// Lead embeds a *Employee. The pointer's methods, Rename included, are
+// promoted to Lead values too, and a Lead shares its Employee with
+// whoever else points to it.
+type Lead struct {
+	*Employee
+	Team string
+}
+
+// SafeCounter embeds a sync.Mutex, so Lock and Unlock are promoted and
+// callers can hold the lock around several operations. The price is that
+// they are part of SafeCounter's API for everybody; a named, unexported mu
+// field keeps them private.
+type SafeCounter struct {
+	sync.Mutex
+	counts map[string]int
+}
+
+// NewSafeCounter returns an empty counter.
+func NewSafeCounter() *SafeCounter {
+	return &SafeCounter{counts: make(map[string]int)}
+}
+
+// Inc adds one to key.
+func (c *SafeCounter) Inc(key string) {
+	c.Lock()
+	defer c.Unlock()
+	c.counts[key]++
+}
+
+// Get returns the count for key.
+func (c *SafeCounter) Get(key string) int {
+	c.Lock()
+	defer c.Unlock()
+	return c.counts[key]
+}
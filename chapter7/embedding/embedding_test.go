@@ -0,0 +1,108 @@
+package embedding
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func newManager() Manager {
+	return Manager{
+		Employee: Employee{FirstName: "Grace", LastName: "Hopper", ID: 7},
+		ID:       "MGR-1",
+		Reports:  []Employee{{FirstName: "Ada", LastName: "Lovelace", ID: 12}},
+	}
+}
+
+func TestPromotionAndShadowing(t *testing.T) {
+	m := newManager()
+	if m.FirstName != "Grace" || m.FullName() != "Grace Hopper" {
+		t.Errorf("promoted field and method: %q, %q", m.FirstName, m.FullName())
+	}
+	if m.ID != "MGR-1" || m.Employee.ID != 7 {
+		t.Errorf("m.ID = %q, m.Employee.ID = %d; the outer field must shadow the inner", m.ID, m.Employee.ID)
+	}
+	if got, want := m.Describe(), "Grace Hopper (#7), manager MGR-1 of Ada"; got != want {
+		t.Errorf("Describe() = %q, want %q", got, want)
+	}
+	// Badge is promoted from Employee and calls Employee.Describe: there is
+	// no virtual dispatch back to Manager's Describe.
+	if got, want := m.Badge(), "[Grace Hopper (#7)]"; got != want {
+		t.Errorf("Badge() = %q, want %q", got, want)
+	}
+
+	m.Rename("Murray")
+	if m.LastName != "Murray" {
+		t.Errorf("Rename through the promoted pointer method: LastName = %q", m.LastName)
+	}
+}
+
+func TestPointerEmbeddingShares(t *testing.T) {
+	e := &Employee{FirstName: "Rob", LastName: "Pike", ID: 3}
+	a, b := Lead{e, "runtime"}, Lead{e, "tools"}
+	a.Rename("Griesemer")
+	if b.LastName != "Griesemer" || e.LastName != "Griesemer" {
+		t.Errorf("Leads embedding the same *Employee do not share it: %q, %q", b.LastName, e.LastName)
+	}
+
+	// A value embedding is copied along with the struct.
+	m := newManager()
+	c := m
+	c.Rename("Murray")
+	if m.LastName != "Hopper" {
+		t.Errorf("renaming a copy of a Manager renamed the original: %q", m.LastName)
+	}
+}
+
+func TestMethodSets(t *testing.T) {
+	renamer := reflect.TypeFor[Renamer]()
+	describer := reflect.TypeFor[Describer]()
+	tests := []struct {
+		typ                reflect.Type
+		describes, renames bool
+	}{
+		{reflect.TypeFor[Employee](), true, false},
+		{reflect.TypeFor[*Employee](), true, true},
+		// Embedding Employee by value promotes Rename only to *Manager.
+		{reflect.TypeFor[Manager](), true, false},
+		{reflect.TypeFor[*Manager](), true, true},
+		// Embedding *Employee promotes Rename to Lead itself.
+		{reflect.TypeFor[Lead](), true, true},
+		{reflect.TypeFor[*Lead](), true, true},
+	}
+	for _, tt := range tests {
+		if got := tt.typ.Implements(describer); got != tt.describes {
+			t.Errorf("%v implements Describer: %v, want %v", tt.typ, got, tt.describes)
+		}
+		if got := tt.typ.Implements(renamer); got != tt.renames {
+			t.Errorf("%v implements Renamer: %v, want %v", tt.typ, got, tt.renames)
+		}
+	}
+	// The embedded Mutex's Lock and Unlock have pointer receivers.
+	locker := reflect.TypeFor[sync.Locker]()
+	if reflect.TypeFor[SafeCounter]().Implements(locker) || !reflect.TypeFor[*SafeCounter]().Implements(locker) {
+		t.Error("only *SafeCounter should be a sync.Locker")
+	}
+}
+
+// Run with -race: the embedded mutex is what keeps the map safe.
+func TestSafeCounter(t *testing.T) {
+	c := NewSafeCounter()
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 500 {
+				c.Inc("hits")
+			}
+		}()
+	}
+	wg.Wait()
+	if got := c.Get("hits"); got != 4000 {
+		t.Errorf(`Get("hits") = %d, want 4000`, got)
+	}
+	if got := c.Get("misses"); got != 0 {
+		t.Errorf(`Get("misses") = %d, want 0`, got)
+	}
+}
@@ -0,0 +1,158 @@
+package embedding
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+
+	"learning-go/registry"
+)
+
+func init() {
+	// Register each exercise with the runner (cmd/learn)
+	registry.Register("chapter7/embedding", "exercise1", exercise1)
+	registry.Register("chapter7/embedding", "exercise2", exercise2)
+	registry.Register("chapter7/embedding", "exercise3", exercise3)
+}
+
+// Exercise 1: Build a Manager that embeds an Employee. Use the promoted
+// fields and methods, reach the shadowed ID and Describe through the
+// embedded field, and see which Describe runs when a Manager is used as a
+// Describer and when Employee's own Badge method calls Describe.
+func exercise1(w io.Writer) {
+	m := Manager{
+		Employee: Employee{FirstName: "Grace", LastName: "Hopper", ID: 7},
+		ID:       "MGR-1",
+		Reports: []Employee{
+			{FirstName: "Ada", LastName: "Lovelace", ID: 12},
+			{FirstName: "Ken", LastName: "Thompson", ID: 15},
+		},
+	}
+	fmt.Fprintln(w, "promoted field m.FirstName:", m.FirstName)
+	fmt.Fprintln(w, "promoted method m.FullName():", m.FullName())
+	fmt.Fprintf(w, "shadowed field: m.ID %q, m.Employee.ID %d\n", m.ID, m.Employee.ID)
+	fmt.Fprintln(w, "m.Describe():", m.Describe())
+	fmt.Fprintln(w, "m.Employee.Describe():", m.Employee.Describe())
+	fmt.Fprintln(w, "m.Badge():", m.Badge())
+
+	m.Rename("Murray") // m is addressable, so (&m.Employee).Rename is called
+	fmt.Fprintln(w, "after m.Rename:", m.FullName())
+
+	for _, d := range []Describer{m.Reports[0], m} {
+		fmt.Fprintf(w, "%-20T %s\n", d, d.Describe())
+	}
+
+	// Explanation:
+	// Embedding a type is declaring a field whose name is the type's name,
+	// here Employee, and Go promotes its fields and methods so m.FirstName
+	// and m.FullName() work as if Manager had declared them. When the
+	// outer type has a field or method of the same name, it wins and the
+	// embedded one is reached through m.Employee, like ID and Describe.
+	// This is not inheritance: a Manager cannot be assigned to an Employee
+	// variable, and Badge, an Employee method, calls Employee.Describe
+	// even on a Manager, because a method only knows its own receiver.
+	// Promotion does count for interfaces, though: a Manager is a
+	// Describer with its own Describe, and would be one through
+	// Employee's if it had none.
+}
+
+// methodSet returns the names of the methods in t's method set.
+func methodSet(t reflect.Type) string {
+	names := make([]string, t.NumMethod())
+	for i := range names {
+		names[i] = t.Method(i).Name
+	}
+	return strings.Join(names, " ")
+}
+
+// Exercise 2: Print the method sets of Employee, Manager (which embeds an
+// Employee), Lead (which embeds a *Employee) and SafeCounter, each as a
+// value and as a pointer, and which of them are Renamers.
+func exercise2(w io.Writer) {
+	renamer := reflect.TypeFor[Renamer]()
+	for _, t := range []reflect.Type{
+		reflect.TypeFor[Employee](),
+		reflect.TypeFor[*Employee](),
+		reflect.TypeFor[Manager](),
+		reflect.TypeFor[*Manager](),
+		reflect.TypeFor[Lead](),
+		reflect.TypeFor[*Lead](),
+		reflect.TypeFor[SafeCounter](),
+		reflect.TypeFor[*SafeCounter](),
+	} {
+		fmt.Fprintf(w, "%-23s Renamer %-5v {%s}\n", t, t.Implements(renamer), methodSet(t))
+	}
+
+	// A Lead shares its Employee with everyone else pointing to it.
+	ada := &Employee{FirstName: "Ada", LastName: "Lovelace", ID: 12}
+	lead := Lead{Employee: ada, Team: "compilers"}
+	lead.Rename("King")
+	fmt.Fprintln(w, "after lead.Rename, ada is:", ada.FullName())
+	var empty Lead
+	fmt.Fprintln(w, "promoted method on a Lead with a nil *Employee panics:", panics(func() { empty.FullName() }))
+
+	// Explanation:
+	// A type's method set decides which interfaces it satisfies. For a
+	// value type it holds the value-receiver methods; for the pointer type
+	// it also holds the pointer-receiver ones. Embedding a T by value
+	// promotes T's value methods to the outer value and all of *T's to the
+	// outer pointer, so only *Manager is a Renamer. Embedding a *T
+	// promotes all of *T's methods to both, because the outer value
+	// already holds a pointer; the cost is that the embedded value is
+	// shared, and a nil one makes every promoted method panic. SafeCounter
+	// shows that embedding sync.Mutex makes Lock and Unlock part of the
+	// API: on the pointer only, since they have pointer receivers.
+}
+
+// panics reports whether fn panics.
+func panics(fn func()) (panicked bool) {
+	defer func() { panicked = recover() != nil }()
+	fn()
+	return false
+}
+
+// Exercise 3: Count events from many goroutines with a SafeCounter, whose
+// embedded mutex also lets a caller hold the lock to move counts between
+// keys in one step.
+func exercise3(w io.Writer) {
+	c := NewSafeCounter()
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 1000 {
+				if (g+i)%4 == 0 {
+					c.Inc("error")
+				} else {
+					c.Inc("ok")
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	fmt.Fprintln(w, "ok:", c.Get("ok"), "error:", c.Get("error"))
+
+	// The promoted Lock lets this read-modify-write of two keys happen
+	// without another goroutine seeing it half done. Inc and Get would
+	// deadlock here, since the lock is already held, so the map is used
+	// directly.
+	c.Lock()
+	c.counts["retried"] += c.counts["error"]
+	c.counts["error"] = 0
+	c.Unlock()
+	fmt.Fprintln(w, "after moving errors to retried:", c.Get("error"), c.Get("retried"))
+
+	// Explanation:
+	// The zero sync.Mutex is unlocked and ready, so embedding one needs no
+	// setup, and every method that touches counts locks it first. The
+	// embedded mutex makes Lock and Unlock methods of SafeCounter, which is
+	// handy for callers that need several steps to be atomic but also lets
+	// any caller forget to unlock, and a sync.Mutex is not reentrant, so a
+	// method that locks cannot call another that locks. A SafeCounter must
+	// not be copied after first use, or the copy gets its own mutex
+	// guarding the same map; go vet's copylocks check reports such copies,
+	// and NewSafeCounter returns a pointer so callers never hold a value.
+}
@@ -0,0 +1,199 @@
+// Package employees is a small HTTP service built from three layers that
+// only know each other through interfaces: a Store holding the data, a
+// Service with the business rules, and a Handler speaking HTTP. Every
+// constructor takes its dependencies as parameters, which is what lets
+// package di wire the same pieces together in different ways.
+package employees
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// Employee is one record.
+type Employee struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Salary int    `json:"salary"`
+}
+
+// ErrNotFound is returned for an unknown employee ID.
+var ErrNotFound = errors.New("employee not found")
+
+// Store holds employees.
+type Store interface {
+	Get(id int) (Employee, error)
+	List() ([]Employee, error)
+	Put(e Employee) error
+}
+
+// Logger is the one method the service needs from a logger. *log.Logger
+// satisfies it.
+type Logger interface {
+	Printf(format string, args ...any)
+}
+
+// MemoryStore is a Store kept in a map.
+type MemoryStore struct {
+	mu   sync.RWMutex
+	byID map[int]Employee
+}
+
+// NewMemoryStore returns a store holding seed.
+func NewMemoryStore(seed []Employee) *MemoryStore {
+	s := &MemoryStore{byID: make(map[int]Employee, len(seed))}
+	for _, e := range seed {
+		s.byID[e.ID] = e
+	}
+	return s
+}
+
+func (s *MemoryStore) Get(id int) (Employee, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	e, ok := s.byID[id]
+	if !ok {
+		return Employee{}, ErrNotFound
+	}
+	return e, nil
+}
+
+func (s *MemoryStore) List() ([]Employee, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	list := make([]Employee, 0, len(s.byID))
+	for _, e := range s.byID {
+		list = append(list, e)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list, nil
+}
+
+func (s *MemoryStore) Put(e Employee) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.byID[e.ID] = e
+	return nil
+}
+
+// Service holds the business rules.
+type Service struct {
+	store Store
+	log   Logger
+}
+
+// NewService returns a service using store and logging to log.
+func NewService(store Store, log Logger) *Service {
+	return &Service{store: store, log: log}
+}
+
+// Employee returns one employee.
+func (s *Service) Employee(id int) (Employee, error) {
+	return s.store.Get(id)
+}
+
+// Employees returns all employees ordered by ID.
+func (s *Service) Employees() ([]Employee, error) {
+	return s.store.List()
+}
+
+// Raise increases an employee's salary by percent, which must be between 1
+// and 50.
+func (s *Service) Raise(id, percent int) (Employee, error) {
+	if percent < 1 || percent > 50 {
+		return Employee{}, fmt.Errorf("raise of %d%% is not between 1%% and 50%%", percent)
+	}
+	e, err := s.store.Get(id)
+	if err != nil {
+		return Employee{}, err
+	}
+	e.Salary += e.Salary * percent / 100
+	if err := s.store.Put(e); err != nil {
+		return Employee{}, err
+	}
+	s.log.Printf("raised %s by %d%% to %d", e.Name, percent, e.Salary)
+	return e, nil
+}
+
+// Handler serves the service over HTTP:
+//
+//	GET  /employees                       all employees
+//	GET  /employees/{id}                  one employee
+//	POST /employees/{id}/raise?percent=N  give a raise
+type Handler struct {
+	mux *http.ServeMux
+	svc *Service
+}
+
+// NewHandler returns a Handler for svc.
+func NewHandler(svc *Service) *Handler {
+	h := &Handler{mux: http.NewServeMux(), svc: svc}
+	h.mux.HandleFunc("GET /employees", h.list)
+	h.mux.HandleFunc("GET /employees/{id}", h.get)
+	h.mux.HandleFunc("POST /employees/{id}/raise", h.raise)
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) list(w http.ResponseWriter, r *http.Request) {
+	list, err := h.svc.Employees()
+	respond(w, list, err)
+}
+
+func (h *Handler) get(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "id must be a number", http.StatusBadRequest)
+		return
+	}
+	e, err := h.svc.Employee(id)
+	respond(w, e, err)
+}
+
+func (h *Handler) raise(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "id must be a number", http.StatusBadRequest)
+		return
+	}
+	percent, err := strconv.Atoi(r.URL.Query().Get("percent"))
+	if err != nil {
+		http.Error(w, "percent must be a number", http.StatusBadRequest)
+		return
+	}
+	e, err := h.svc.Raise(id, percent)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	respond(w, e, err)
+}
+
+func respond(w http.ResponseWriter, v any, err error) {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case err != nil:
+		log.Printf("employees: %v", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(v)
+	}
+}
+
+// Seed is the sample data every wiring starts with.
+var Seed = []Employee{
+	{ID: 1, Name: "Ada", Salary: 5000},
+	{ID: 2, Name: "Grace", Salary: 6000},
+	{ID: 3, Name: "Linus", Salary: 4500},
+}
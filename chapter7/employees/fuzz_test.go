@@ -0,0 +1,39 @@
+package employees
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"unicode/utf8"
+)
+
+// An Employee must survive a JSON round trip. A name that is not valid
+// UTF-8 cannot: encoding/json replaces the bad bytes with U+FFFD, so then
+// only the numbers and the validity of the name are checked.
+func FuzzEmployeeJSON(f *testing.F) {
+	f.Add(1, "Ada", 4200)
+	f.Add(-7, "", 0)
+	f.Add(math.MaxInt, "e\u0301 \u200d 👍🏽 🇮🇷", 1_000_000)
+	f.Add(0, `"quoted" \ <script>`, -1)
+	f.Add(3, "\x00\xff\xe6\x97\x80", 5)
+	f.Fuzz(func(t *testing.T, id int, name string, salary int) {
+		e := Employee{ID: id, Name: name, Salary: salary}
+		data, err := json.Marshal(e)
+		if err != nil {
+			t.Fatalf("Marshal(%#v): %v", e, err)
+		}
+		var got Employee
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("Unmarshal(%s) of %#v: %v", data, e, err)
+		}
+		if utf8.ValidString(name) {
+			if got != e {
+				t.Errorf("%#v came back as %#v through %s", e, got, data)
+			}
+			return
+		}
+		if got.ID != e.ID || got.Salary != e.Salary || !utf8.ValidString(got.Name) {
+			t.Errorf("%#v came back as %#v through %s", e, got, data)
+		}
+	})
+}
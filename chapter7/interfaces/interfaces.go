@@ -0,0 +1,149 @@
+// Package interfaces covers the second half of chapter 7: types that
+// satisfy an interface just by having its methods. It has an Employee
+// that is a fmt.Stringer, a Shape interface with three implementations, an
+// io.Reader and an io.Writer that plug into the standard library, and a
+// type switch that describes any value.
+package interfaces
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Employee is a person on the payroll.
+type Employee struct {
+	ID        int
+	FirstName string
+	LastName  string
+	Salary    int
+}
+
+// String implements fmt.Stringer, so the fmt verbs %v and %s and
+// fmt.Println print the employee this way instead of as a struct.
+func (e Employee) String() string {
+	return fmt.Sprintf("#%d %s %s", e.ID, e.FirstName, e.LastName)
+}
+
+// Shape is a closed plane figure.
+type Shape interface {
+	Area() float64
+	Perimeter() float64
+}
+
+// Rect is a rectangle.
+type Rect struct {
+	Width, Height float64
+}
+
+func (r Rect) Area() float64      { return r.Width * r.Height }
+func (r Rect) Perimeter() float64 { return 2 * (r.Width + r.Height) }
+
+// Circle is a circle.
+type Circle struct {
+	Radius float64
+}
+
+func (c Circle) Area() float64      { return math.Pi * c.Radius * c.Radius }
+func (c Circle) Perimeter() float64 { return 2 * math.Pi * c.Radius }
+
+// Triangle is a triangle given by the lengths of its sides.
+type Triangle struct {
+	A, B, C float64
+}
+
+// Area uses Heron's formula.
+func (t Triangle) Area() float64 {
+	s := t.Perimeter() / 2
+	return math.Sqrt(s * (s - t.A) * (s - t.B) * (s - t.C))
+}
+
+func (t Triangle) Perimeter() float64 { return t.A + t.B + t.C }
+
+// TotalArea returns the sum of the areas of shapes. It works for any
+// type with the methods of Shape, including ones written after it.
+func TotalArea(shapes ...Shape) float64 {
+	total := 0.0
+	for _, s := range shapes {
+		total += s.Area()
+	}
+	return total
+}
+
+// rot13Reader decodes or encodes ROT13 while reading from another reader.
+type rot13Reader struct {
+	r io.Reader
+}
+
+// NewRot13Reader returns a reader that reads from r and rotates every
+// ASCII letter 13 places. Applying it twice gives back the original text.
+func NewRot13Reader(r io.Reader) io.Reader {
+	return rot13Reader{r}
+}
+
+func (r rot13Reader) Read(p []byte) (int, error) {
+	// Transform only the n bytes that were read, even when err is not
+	// nil: a Read may return data and an error together.
+	n, err := r.r.Read(p)
+	for i, b := range p[:n] {
+		switch {
+		case 'a' <= b && b <= 'z':
+			p[i] = 'a' + (b-'a'+13)%26
+		case 'A' <= b && b <= 'Z':
+			p[i] = 'A' + (b-'A'+13)%26
+		}
+	}
+	return n, err
+}
+
+// LineCounter is an io.Writer that counts the lines written through it
+// and passes the bytes on to W, which may be nil to only count.
+type LineCounter struct {
+	W     io.Writer
+	Lines int
+	Bytes int
+}
+
+func (c *LineCounter) Write(p []byte) (int, error) {
+	n := len(p)
+	var err error
+	if c.W != nil {
+		n, err = c.W.Write(p)
+	}
+	c.Lines += strings.Count(string(p[:n]), "\n")
+	c.Bytes += n
+	return n, err
+}
+
+// Describe says what v is, using a type switch to pick a description for
+// each kind of value it knows about.
+func Describe(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "nothing (a nil interface)"
+	case int, int64:
+		return fmt.Sprintf("the integer %d", v)
+	case float64:
+		return "the number " + strconv.FormatFloat(v, 'g', -1, 64)
+	case string:
+		return fmt.Sprintf("a string of %d bytes: %q", len(v), v)
+	case error:
+		return "an error: " + v.Error()
+	case Shape:
+		return fmt.Sprintf("a shape, %T, with area %.2f", v, v.Area())
+	case fmt.Stringer:
+		return fmt.Sprintf("a Stringer, %T, that prints as %q", v, v.String())
+	case []any:
+		parts := make([]string, len(v))
+		for i, e := range v {
+			parts[i] = Describe(e)
+		}
+		return fmt.Sprintf("a list of %d: [%s]", len(v), strings.Join(parts, "; "))
+	case func():
+		return "a function with no arguments"
+	default:
+		return fmt.Sprintf("something of type %T", v)
+	}
+}
@@ -0,0 +1,138 @@
+package interfaces
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// Each type must keep satisfying the interface it was written for.
+var (
+	_ fmt.Stringer = Employee{}
+	_ Shape        = Rect{}
+	_ Shape        = Circle{}
+	_ Shape        = Triangle{}
+	_ io.Writer    = (*LineCounter)(nil)
+)
+
+func TestEmployeeString(t *testing.T) {
+	e := Employee{ID: 7, FirstName: "Grace", LastName: "Hopper", Salary: 100}
+	for _, got := range []string{e.String(), fmt.Sprint(e), fmt.Sprintf("%v", &e)} {
+		if got != "#7 Grace Hopper" {
+			t.Errorf("got %q, want #7 Grace Hopper", got)
+		}
+	}
+}
+
+func TestShapes(t *testing.T) {
+	tests := []struct {
+		shape           Shape
+		area, perimeter float64
+	}{
+		{Rect{3, 4}, 12, 14},
+		{Circle{1}, math.Pi, 2 * math.Pi},
+		{Triangle{3, 4, 5}, 6, 12},
+	}
+	total := 0.0
+	for _, tt := range tests {
+		if a := tt.shape.Area(); math.Abs(a-tt.area) > 1e-9 {
+			t.Errorf("%#v.Area() = %v, want %v", tt.shape, a, tt.area)
+		}
+		if p := tt.shape.Perimeter(); math.Abs(p-tt.perimeter) > 1e-9 {
+			t.Errorf("%#v.Perimeter() = %v, want %v", tt.shape, p, tt.perimeter)
+		}
+		total += tt.area
+	}
+	if got := TotalArea(Rect{3, 4}, Circle{1}, Triangle{3, 4, 5}); math.Abs(got-total) > 1e-9 {
+		t.Errorf("TotalArea = %v, want %v", got, total)
+	}
+	if TotalArea() != 0 {
+		t.Error("TotalArea() of nothing is not 0")
+	}
+}
+
+func TestRot13(t *testing.T) {
+	const plain = "Hello, Gophers! 123 é"
+	got, err := io.ReadAll(NewRot13Reader(strings.NewReader(plain)))
+	if err != nil || string(got) != "Uryyb, Tbcuref! 123 é" {
+		t.Errorf("ROT13 = %q, %v", got, err)
+	}
+	twice, _ := io.ReadAll(NewRot13Reader(NewRot13Reader(strings.NewReader(plain))))
+	if string(twice) != plain {
+		t.Errorf("ROT13 twice = %q, want the original", twice)
+	}
+	// A reader that returns data with its error, one byte at a time, must
+	// still have every byte rotated.
+	r := NewRot13Reader(iotest.DataErrReader(iotest.OneByteReader(strings.NewReader("abc"))))
+	if got, err := io.ReadAll(r); err != nil || string(got) != "nop" {
+		t.Errorf("ROT13 through DataErrReader = %q, %v; want nop", got, err)
+	}
+}
+
+func TestLineCounter(t *testing.T) {
+	var sb strings.Builder
+	c := &LineCounter{W: &sb}
+	fmt.Fprintf(c, "one\ntwo\n")
+	io.Copy(c, strings.NewReader("three\nfour"))
+	if c.Lines != 3 || c.Bytes != 18 || sb.String() != "one\ntwo\nthree\nfour" {
+		t.Errorf("Lines %d, Bytes %d, passed on %q", c.Lines, c.Bytes, sb.String())
+	}
+
+	// With no W it only counts, and composes with bufio like any writer.
+	var only LineCounter
+	bw := bufio.NewWriter(&only)
+	for range 5 {
+		bw.WriteString("line\n")
+	}
+	bw.Flush()
+	if only.Lines != 5 {
+		t.Errorf("counted %d lines through bufio, want 5", only.Lines)
+	}
+}
+
+// shortWriter accepts at most n bytes and then fails.
+type shortWriter struct{ n int }
+
+func (w *shortWriter) Write(p []byte) (int, error) {
+	if len(p) > w.n {
+		return w.n, errors.New("disk full")
+	}
+	return len(p), nil
+}
+
+func TestLineCounterCountsOnlyWritten(t *testing.T) {
+	c := &LineCounter{W: &shortWriter{n: 4}}
+	n, err := c.Write([]byte("ab\ncd\nef\n"))
+	if n != 4 || err == nil || c.Lines != 1 || c.Bytes != 4 {
+		t.Errorf("Write = %d, %v; Lines %d, Bytes %d; want 4, error, 1, 4", n, err, c.Lines, c.Bytes)
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		v    any
+		want string
+	}{
+		{nil, "nothing (a nil interface)"},
+		{42, "the integer 42"},
+		{int64(-1), "the integer -1"},
+		{2.5, "the number 2.5"},
+		{"héllo", `a string of 6 bytes: "héllo"`},
+		{errors.New("boom"), "an error: boom"},
+		{Rect{2, 3}, "a shape, interfaces.Rect, with area 6.00"},
+		{Employee{ID: 1, FirstName: "A", LastName: "B"}, `a Stringer, interfaces.Employee, that prints as "#1 A B"`},
+		{[]any{1, "x"}, `a list of 2: [the integer 1; a string of 1 bytes: "x"]`},
+		{func() {}, "a function with no arguments"},
+		{true, "something of type bool"},
+	}
+	for _, tt := range tests {
+		if got := Describe(tt.v); got != tt.want {
+			t.Errorf("Describe(%#v) = %q, want %q", tt.v, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package interfaces
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"learning-go/registry"
+)
+
+func init() {
+	// Register each exercise with the runner (cmd/learn)
+	registry.Register("chapter7/interfaces", "exercise1", exercise1)
+	registry.Register("chapter7/interfaces", "exercise2", exercise2)
+	registry.Register("chapter7/interfaces", "exercise3", exercise3)
+	registry.Register("chapter7/interfaces", "exercise4", exercise4)
+}
+
+// Team has a String method with a pointer receiver, so only a *Team is a
+// fmt.Stringer; a Team value is not.
+type Team struct {
+	Name    string
+	Members []Employee
+}
+
+func (t *Team) String() string {
+	return fmt.Sprintf("team %s (%d members)", t.Name, len(t.Members))
+}
+
+// Exercise 1: Give Employee a String method and print employees with
+// fmt.Println and the %v, %s and %+v verbs, on their own, through a
+// pointer and in a slice. Then show the pointer-receiver trap with Team.
+func exercise1(w io.Writer) {
+	ada := Employee{ID: 1, FirstName: "Ada", LastName: "Lovelace", Salary: 5000}
+	grace := Employee{ID: 2, FirstName: "Grace", LastName: "Hopper", Salary: 6000}
+
+	fmt.Fprintln(w, "Println:   ", ada)
+	fmt.Fprintf(w, "%%v and %%s:  %v / %s\n", ada, ada)
+	fmt.Fprintf(w, "%%+v:        %+v\n", ada)
+	fmt.Fprintf(w, "pointer:    %v\n", &ada)
+	fmt.Fprintf(w, "slice:      %v\n", []Employee{ada, grace})
+	// Converting to a type without the method gets the default format.
+	type plain Employee
+	fmt.Fprintf(w, "no String:  %+v\n", plain(ada))
+
+	team := Team{Name: "compilers", Members: []Employee{ada, grace}}
+	fmt.Fprintf(w, "Team value:   %v\n", team)
+	fmt.Fprintf(w, "Team pointer: %v\n", &team)
+	_, isStringer := any(team).(fmt.Stringer)
+	fmt.Fprintln(w, "Team value is a fmt.Stringer:", isStringer)
+
+	// Explanation:
+	// fmt checks whether each operand implements fmt.Stringer and, if it
+	// does, prints what String returns for %v, %s and %+v alike, even for
+	// elements of a slice. Employee's String has a value receiver, so it
+	// is in the method set of both Employee and *Employee. Team's has a
+	// pointer receiver, so a Team value does not implement Stringer and
+	// prints as a plain struct, with its members printed through their
+	// own String method. A local type with Employee's fields but none of
+	// its methods is the usual way to get the default format back, for
+	// instance inside a String method that wants to reuse it.
+}
+
+// Compile-time checks that each shape implements Shape. If a method is
+// missing or has the wrong signature, the build fails here.
+var (
+	_ Shape = Rect{}
+	_ Shape = Circle{}
+	_ Shape = Triangle{}
+)
+
+// Square is defined after TotalArea and outside this package's shape
+// list, to show that TotalArea accepts it without any change.
+type Square struct {
+	Side float64
+}
+
+func (s Square) Area() float64      { return s.Side * s.Side }
+func (s Square) Perimeter() float64 { return 4 * s.Side }
+
+// Exercise 2: Define a Shape interface with Rect, Circle and Triangle
+// implementations, sum their areas with TotalArea, sort them by area, and
+// add a new shape without touching TotalArea.
+func exercise2(w io.Writer) {
+	shapes := []Shape{
+		Rect{Width: 3, Height: 4},
+		Circle{Radius: 1},
+		Triangle{A: 3, B: 4, C: 5},
+	}
+	for _, s := range shapes {
+		name := strings.TrimPrefix(fmt.Sprintf("%T%+v", s, s), "interfaces.")
+		fmt.Fprintf(w, "%-22s area %6.2f  perimeter %6.2f\n", name, s.Area(), s.Perimeter())
+	}
+	fmt.Fprintf(w, "total area: %.2f\n", TotalArea(shapes...))
+
+	shapes = append(shapes, Square{Side: 2})
+	slices.SortFunc(shapes, func(a, b Shape) int { return cmp.Compare(a.Area(), b.Area()) })
+	fmt.Fprint(w, "sorted by area with a Square added:")
+	for _, s := range shapes {
+		fmt.Fprint(w, " ", strings.TrimPrefix(fmt.Sprintf("%T", s), "interfaces."))
+	}
+	fmt.Fprintf(w, "\ntotal area: %.2f\n", TotalArea(shapes...))
+
+	// Explanation:
+	// Go interfaces are satisfied implicitly: Rect never says it is a
+	// Shape, it just has Area and Perimeter. That is what lets Square,
+	// written long after TotalArea, be passed to it, and it is why the
+	// "var _ Shape = Rect{}" lines are worth having: they turn a missing
+	// method into a compile error next to the type instead of at some
+	// distant call. An interface value holds the concrete value and its
+	// type, so %T still reports Rect or Circle, and sorting a []Shape
+	// moves the interface values around without touching the shapes.
+}
+
+// Exercise 3: Write a ROT13 io.Reader and a line-counting io.Writer, and
+// combine them with io.Copy, bufio.Scanner, io.TeeReader and
+// io.MultiWriter from the standard library.
+func exercise3(w io.Writer) {
+	const secret = "Uryyb, tbcure!\nVagresnprf ner fngvfsvrq vzcyvpvgyl.\nQba'g pbzzhavpngr ol funevat zrzbel.\n"
+
+	var decoded bytes.Buffer
+	counter := &LineCounter{W: &decoded}
+	n, err := io.Copy(counter, NewRot13Reader(strings.NewReader(secret)))
+	fmt.Fprintf(w, "io.Copy: %d bytes, %d lines, err %v\n", n, counter.Lines, err)
+	fmt.Fprint(w, decoded.String())
+
+	// Twice is the identity.
+	var twice strings.Builder
+	io.Copy(&twice, NewRot13Reader(NewRot13Reader(strings.NewReader(secret))))
+	fmt.Fprintln(w, "rot13 twice gives the original:", twice.String() == secret)
+
+	// A Scanner reads lines from any io.Reader, including ours.
+	scanner := bufio.NewScanner(NewRot13Reader(strings.NewReader(secret)))
+	for i := 1; scanner.Scan(); i++ {
+		fmt.Fprintf(w, "line %d has %d words\n", i, len(strings.Fields(scanner.Text())))
+	}
+
+	// TeeReader copies what is read into a writer, here a counter that
+	// only counts; MultiWriter sends one write to several writers.
+	tally := &LineCounter{}
+	var copied strings.Builder
+	written := &LineCounter{W: io.Discard}
+	io.Copy(io.MultiWriter(&copied, written), io.TeeReader(NewRot13Reader(strings.NewReader(secret)), tally))
+	fmt.Fprintf(w, "TeeReader saw %d lines; MultiWriter wrote %d lines and a %d-byte copy\n",
+		tally.Lines, written.Lines, copied.Len())
+
+	// Explanation:
+	// io.Reader and io.Writer each have a single method, so anything can
+	// implement them, and everything that accepts them works with the
+	// new types at once. The rot13 reader wraps another reader and
+	// changes bytes on their way through, the same decorator pattern as
+	// gzip.NewReader or bufio.NewReader. Note that Read transforms only
+	// p[:n]: a reader may return fewer bytes than asked for, or data
+	// together with io.EOF. LineCounter has a pointer receiver because
+	// Write must update its counts, so it is a *LineCounter that is an
+	// io.Writer.
+}
+
+// Exercise 4: Describe values of many types with a type switch: numbers,
+// strings, errors, Shapes, Stringers, lists, functions and nil. Compare
+// it with a single type assertion using the comma-ok form.
+func exercise4(w io.Writer) {
+	values := []any{
+		nil,
+		42,
+		3.5,
+		"héllo",
+		errors.New("disk full"),
+		Circle{Radius: 2},
+		Employee{ID: 7, FirstName: "Ken", LastName: "Thompson"},
+		[]any{1, "two", Rect{Width: 1, Height: 3}},
+		func() {},
+		time.Second,
+		os.Stdout,
+		map[string]int{},
+	}
+	for _, v := range values {
+		fmt.Fprintln(w, "-", Describe(v))
+	}
+
+	var v any = Rect{Width: 2, Height: 5}
+	if s, ok := v.(Shape); ok {
+		fmt.Fprintf(w, "v.(Shape) ok, area %.0f\n", s.Area())
+	}
+	if _, ok := v.(fmt.Stringer); !ok {
+		fmt.Fprintln(w, "v.(fmt.Stringer) not ok: a Rect has no String method")
+	}
+
+	// Explanation:
+	// A type switch tests an interface value against each case in order
+	// and, inside the case, binds v to the concrete type, or keeps it as
+	// an interface when the case lists several types (int, int64) or
+	// names an interface (error, Shape, fmt.Stringer). Cases match exact
+	// types, so time.Duration, whose underlying type is int64, skips the
+	// int64 case and lands on fmt.Stringer. The first matching case wins,
+	// so a value that is both a Shape and a Stringer is described as a
+	// Shape. A single assertion without ", ok" panics when it fails, which
+	// is why the two-value form is the usual one.
+}
@@ -0,0 +1,126 @@
+// Package constraints explores how type constraints are built from unions of
+// type terms, and how those constraints unlock operators (+, /, <) inside
+// generic functions.
+package constraints
+
+// Signed matches every signed integer type, including user-defined types
+// whose underlying type is one of them (thanks to the ~ prefix).
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Unsigned matches every unsigned integer type.
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Integer is the union of Signed and Unsigned. Constraints can embed other
+// constraints, and the resulting type set is the union of both.
+type Integer interface {
+	Signed | Unsigned
+}
+
+// Float matches both floating-point types.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number matches every type that supports the arithmetic operators.
+type Number interface {
+	Integer | Float
+}
+
+// Addable matches every type that supports the + operator. Strings are
+// included because + concatenates them, but / is not allowed on strings,
+// so Addable cannot be used where division is needed.
+type Addable interface {
+	Number | ~string
+}
+
+// Sum adds all values together. For strings it concatenates them.
+// An empty slice returns the zero value of T. Like +, an integer sum wraps
+// around when it does not fit in T: Sum([]int8{100, 100}) is -56.
+func Sum[T Addable](values []T) T {
+	var total T
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+// Average returns the mean of values as a T. For integer types the result
+// is truncated toward zero: Average([]int{1, 2}) is 1, not 1.5. An empty
+// slice returns 0 instead of panicking with a division by zero.
+//
+// The values are added up in a 64-bit type rather than in T, and divided
+// by the length as a 64-bit number too: in T, 256 uint8s would divide by
+// T(256), which is 0, and a few int8s would already overflow the sum. The
+// mean of values of type T always fits in T, so converting back is safe,
+// as long as the sum fits in 64 bits.
+func Average[T Number](values []T) T {
+	if len(values) == 0 {
+		return 0
+	}
+	var zero T
+	switch {
+	case T(1)/T(2) != zero: // a float type
+		var total float64
+		for _, v := range values {
+			total += float64(v)
+		}
+		return T(total / float64(len(values)))
+	case zero-1 < zero: // a signed integer type
+		var total int64
+		for _, v := range values {
+			total += int64(v)
+		}
+		return T(total / int64(len(values)))
+	default: // an unsigned integer type
+		var total uint64
+		for _, v := range values {
+			total += uint64(v)
+		}
+		return T(total / uint64(len(values)))
+	}
+}
+
+// AverageFloat returns the mean of values as a float64, so integer inputs
+// keep their fractional part: AverageFloat([]int{1, 2}) is 1.5.
+// An empty slice returns 0.
+func AverageFloat[T Number](values []T) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var total float64
+	for _, v := range values {
+		total += float64(v)
+	}
+	return total / float64(len(values))
+}
+
+// Box is a generic container used to show the limits of generic methods.
+type Box[T any] struct {
+	Value T
+}
+
+// Get is fine: a method can use the type parameters declared on its type.
+func (b Box[T]) Get() T {
+	return b.Value
+}
+
+// A method cannot declare type parameters of its own. The following does
+// not compile ("methods cannot have type parameters"):
+//
+//	func (b Box[T]) Map[R any](f func(T) R) Box[R] {
+//		return Box[R]{Value: f(b.Value)}
+//	}
+//
+// The reason is that Go resolves method sets at compile time; a method with
+// its own type parameters could not be listed in an interface's method set,
+// and a type could never be checked against such an interface. The idiomatic
+// workaround is a package-level function, like MapBox below.
+
+// MapBox converts a Box[T] into a Box[R] by applying f to its value.
+func MapBox[T, R any](b Box[T], f func(T) R) Box[R] {
+	return Box[R]{Value: f(b.Value)}
+}
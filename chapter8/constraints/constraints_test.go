@@ -0,0 +1,89 @@
+package constraints
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+)
+
+// celsius has float64 as its underlying type, so the ~ in Float admits it.
+type celsius float64
+
+func TestSum(t *testing.T) {
+	if got := Sum([]int{1, 2, 3, 4}); got != 10 {
+		t.Errorf("Sum(ints) = %d, want 10", got)
+	}
+	if got := Sum([]float64{0.5, 0.25}); got != 0.75 {
+		t.Errorf("Sum(floats) = %v, want 0.75", got)
+	}
+	if got := Sum([]string{"go", "pher"}); got != "gopher" {
+		t.Errorf("Sum(strings) = %q, want %q", got, "gopher")
+	}
+	if got := Sum([]celsius{20, 1.5}); got != 21.5 {
+		t.Errorf("Sum(celsius) = %v, want 21.5", got)
+	}
+	if got := Sum[int](nil); got != 0 {
+		t.Errorf("Sum(nil) = %d, want 0", got)
+	}
+}
+
+func TestAverage(t *testing.T) {
+	tests := []struct {
+		name      string
+		ints      []int
+		wantInt   int
+		wantFloat float64
+	}{
+		{"exact", []int{2, 4, 6}, 4, 4},
+		{"truncated", []int{1, 2}, 1, 1.5},
+		{"negative truncates toward zero", []int{-1, -2}, -1, -1.5},
+		{"empty", nil, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Average(tt.ints); got != tt.wantInt {
+				t.Errorf("Average(%v) = %d, want %d", tt.ints, got, tt.wantInt)
+			}
+			if got := AverageFloat(tt.ints); got != tt.wantFloat {
+				t.Errorf("AverageFloat(%v) = %v, want %v", tt.ints, got, tt.wantFloat)
+			}
+		})
+	}
+	if got := Average([]float64{1, 2}); got != 1.5 {
+		t.Errorf("Average(floats) = %v, want 1.5", got)
+	}
+}
+
+// Small integer types can neither hold the sum nor, for long slices, the
+// length, so Average must not compute in T.
+func TestAverageSmallInts(t *testing.T) {
+	bytes := slices.Repeat([]uint8{200}, 300)
+	if got := Average(bytes); got != 200 {
+		t.Errorf("Average(300 × uint8(200)) = %d, want 200", got)
+	}
+	if got := Average(slices.Repeat([]uint8{0, 255}, 128)); got != 127 {
+		t.Errorf("Average(128 × [0 255]) = %d, want 127", got)
+	}
+	if got := Average(slices.Repeat([]int8{-100}, 200)); got != -100 {
+		t.Errorf("Average(200 × int8(-100)) = %d, want -100", got)
+	}
+	if got := Average([]int8{127, 126, -3}); got != 83 {
+		t.Errorf("Average(int8{127, 126, -3}) = %d, want 83", got)
+	}
+	if got := Average([]float32{0.5, 0.25}); got != 0.375 {
+		t.Errorf("Average(float32s) = %v, want 0.375", got)
+	}
+	if got := Average([]celsius{20, 21}); got != 20.5 {
+		t.Errorf("Average(celsius) = %v, want 20.5", got)
+	}
+	if got := Sum([]int8{100, 100}); got != -56 {
+		t.Errorf("Sum(int8{100, 100}) = %d, want it to wrap to -56", got)
+	}
+}
+
+func TestMapBox(t *testing.T) {
+	b := MapBox(Box[int]{Value: 42}, strconv.Itoa)
+	if got := b.Get(); got != "42" {
+		t.Errorf("MapBox(42, Itoa).Get() = %q, want %q", got, "42")
+	}
+}
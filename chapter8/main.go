@@ -0,0 +1,386 @@
+package chapter8
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"learning-go/cache/lru"
+	"learning-go/chapter8/constraints"
+	"learning-go/clock"
+	"learning-go/datastructures/collections"
+	"learning-go/generics/funcs"
+	"learning-go/registry"
+)
+
+func init() {
+	// Register each exercise with the runner (cmd/learn)
+	registry.Register("chapter8", "exercise1", exercise1)
+	registry.Register("chapter8", "exercise2", exercise2)
+	registry.Register("chapter8", "exercise3", exercise3)
+	registry.Register("chapter8", "exercise4", exercise4)
+	registry.Register("chapter8", "exercise5", exercise5)
+	registry.Register("chapter8", "exercise6", exercise6)
+	registry.Register("chapter8", "exercise7", exercise7)
+}
+
+// Book is the sample data for this chapter's exercises.
+type Book struct {
+	ISBN   string
+	Title  string
+	Pages  int
+	Rating float64
+}
+
+// Key makes Book satisfy funcs.Keyed[string].
+func (b Book) Key() string {
+	return b.ISBN
+}
+
+var books = []Book{
+	{"978-0134190440", "The Go Programming Language", 380, 4.7},
+	{"978-1098139292", "Learning Go", 494, 4.6},
+	{"978-1617291784", "Go in Action", 264, 4.1},
+	{"978-1492077213", "Efficient Go", 496, 4.5},
+	{"978-1801079310", "Mastering Go", 682, 4.3},
+}
+
+// Exercise 1: With funcs.Filter, funcs.Map and funcs.Reduce, find the
+// books over 400 pages, turn them into titles, and add up their pages.
+// Then do the same with one plain for loop and compare.
+func exercise1(w io.Writer) {
+	long := funcs.Filter(books, func(b Book) bool { return b.Pages > 400 })
+	titles := funcs.Map(long, func(b Book) string { return b.Title })
+	pages := funcs.Reduce(long, 0, func(total int, b Book) int { return total + b.Pages })
+	fmt.Fprintf(w, "long books: %s\n", strings.Join(titles, ", "))
+	fmt.Fprintf(w, "total pages: %d\n", pages)
+
+	var loopTitles []string
+	loopPages := 0
+	for _, b := range books {
+		if b.Pages > 400 {
+			loopTitles = append(loopTitles, b.Title)
+			loopPages += b.Pages
+		}
+	}
+	fmt.Fprintf(w, "same with a loop: %v\n", strings.Join(loopTitles, ", ") == strings.Join(titles, ", ") && loopPages == pages)
+
+	// Reduce's accumulator can have a different type from the elements.
+	byRating := funcs.Reduce(books, map[string]int{}, func(m map[string]int, b Book) map[string]int {
+		m[fmt.Sprintf("%.0f stars", b.Rating)]++
+		return m
+	})
+	for _, k := range funcs.SortedKeys(byRating) {
+		fmt.Fprintf(w, "%s: %d\n", k, byRating[k])
+	}
+
+	// Explanation:
+	// Before generics each of these helpers had to be written once per
+	// element type, or take interface{} and lose type checking. With type
+	// parameters the compiler infers T and R from the arguments, so
+	// Map(long, ...) is checked as []Book -> []string. The loop version
+	// is just as short here and walks the data once instead of three
+	// times; the helpers pay off when the steps are reused or passed
+	// around, not as a replacement for every loop.
+}
+
+// Exercise 2: Pair each title with its rating using funcs.Zip, group the
+// books by rating with funcs.GroupBy, split them into chunks of two with
+// funcs.Chunk, and list the keys and values of a map with funcs.Keys,
+// funcs.Values and funcs.SortedKeys.
+func exercise2(w io.Writer) {
+	titles := funcs.Map(books, func(b Book) string { return b.Title })
+	ratings := funcs.Map(books, func(b Book) float64 { return b.Rating })
+	for _, p := range funcs.Zip(titles, ratings[:3]) {
+		fmt.Fprintln(w, p)
+	}
+
+	byRating := funcs.GroupBy(books, func(b Book) bool { return b.Rating >= 4.5 })
+	for _, g := range byRating {
+		fmt.Fprintf(w, "rated 4.5 or more %t: %d books\n", g.First, len(g.Second))
+	}
+
+	for i, chunk := range funcs.Chunk(books, 2) {
+		fmt.Fprintf(w, "page %d: len %d, cap %d\n", i+1, len(chunk), cap(chunk))
+	}
+
+	pages := make(map[string]int)
+	for _, b := range books {
+		pages[b.Title] = b.Pages
+	}
+	fmt.Fprintf(w, "%d keys, %d values\n", len(funcs.Keys(pages)), len(funcs.Values(pages)))
+	fmt.Fprintln(w, "sorted keys:", funcs.SortedKeys(pages))
+
+	// Explanation:
+	// Zip stops at the shorter slice, so the three ratings give three
+	// pairs. GroupBy returns its groups in the order their keys first
+	// appear, so unlike a map of groups it prints the same every run.
+	// Each chunk is a subslice of books with its capacity clipped to its
+	// length (cap 2, then 1), so appending to a chunk reallocates instead
+	// of overwriting the next one. Keys and Values come back in
+	// map order, which Go randomizes; only their lengths are stable. When
+	// order matters, SortedKeys sorts them, which is why it asks for
+	// cmp.Ordered instead of plain comparable.
+}
+
+// countBy counts how many items fall into each group. K must be
+// comparable because it is used as a map key.
+func countBy[T any, K comparable](items []T, group func(T) K) map[K]int {
+	counts := make(map[K]int)
+	for _, item := range items {
+		counts[group(item)]++
+	}
+	return counts
+}
+
+// Exercise 3: Use constraints in three ways: index the books by ISBN with
+// funcs.IndexBy, whose constraint requires a Key method; sum and average
+// pages with the type-set constraints of chapter8/constraints; and write
+// countBy, a generic function of your own with a comparable key.
+//
+// Tags: generics, constraints
+func exercise3(w io.Writer) {
+	index := funcs.IndexBy(books)
+	fmt.Fprintln(w, "978-1098139292 is", index["978-1098139292"].Title)
+
+	pages := funcs.Map(books, func(b Book) int { return b.Pages })
+	fmt.Fprintf(w, "pages: sum %d, average %d, exact average %.1f\n",
+		constraints.Sum(pages), constraints.Average(pages), constraints.AverageFloat(pages))
+
+	counts := countBy(books, func(b Book) bool { return b.Pages > 400 })
+	fmt.Fprintf(w, "over 400 pages: %d, not: %d\n", counts[true], counts[false])
+
+	// These do not compile:
+	//
+	//	funcs.IndexBy([]string{"a"})         // string does not satisfy funcs.Keyed[K] (missing method Key)
+	//	constraints.Average([]string{"a"})   // string does not satisfy constraints.Number
+	//	funcs.SortedKeys(map[Book]int{})     // Book does not satisfy cmp.Ordered
+
+	// Explanation:
+	// A constraint is an interface, and it can restrict a type parameter
+	// in two ways. A type set (~int | ~float64 | ...) says which types are
+	// allowed and so which operators may be used: Average divides, so it
+	// needs Number. A method list says what the type can do: IndexBy only
+	// needs to call Key, so any type with that method qualifies, and K is
+	// inferred from Book's Key method. Inference works from the arguments,
+	// which is why countBy never needs countBy[Book, bool] spelled out.
+}
+
+// Exercise 4: Find which books two readers have in common, which only one
+// of them has read, and which neither has, using collections.Set.
+func exercise4(w io.Writer) {
+	all := collections.NewSet(funcs.Map(books, func(b Book) string { return b.Title })...)
+	ada := collections.NewSet("Learning Go", "Efficient Go", "Go in Action")
+	linus := collections.NewSet("Learning Go", "Mastering Go")
+
+	fmt.Fprintln(w, "read by either:", collections.Sorted(ada.Union(linus)))
+	fmt.Fprintln(w, "read by both:  ", collections.Sorted(ada.Intersection(linus)))
+	fmt.Fprintln(w, "only ada:      ", collections.Sorted(ada.Difference(linus)))
+	fmt.Fprintln(w, "read by nobody:", collections.Sorted(all.Difference(ada.Union(linus))))
+	fmt.Fprintln(w, "linus's books are all in the list:", linus.SubsetOf(all))
+
+	// Explanation:
+	// Set[T comparable] is a map[T]struct{} underneath: comparable is the
+	// constraint map keys need, and the empty struct takes no space. Each
+	// operation returns a new set, so ada and linus are unchanged and the
+	// calls can be combined like the last Difference. Sorted is a
+	// function, not a method, because sorting needs cmp.Ordered and a
+	// method cannot add constraints to the ones Set was declared with.
+}
+
+// pushPopper is what exercise 5 needs from a stack or queue, so one
+// benchmark function can drive both backings of each.
+type pushPopper interface {
+	Push(int)
+	Pop() (int, bool)
+}
+
+// Exercise 5: Benchmark the slice-backed and linked-list-backed versions
+// of collections.Stack, Queue and Deque by pushing and popping 1,000
+// values, and print which backing wins for each.
+func exercise5(w io.Writer) {
+	const n = 1000
+	bench := func(newColl func() pushPopper) testing.BenchmarkResult {
+		return testing.Benchmark(func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				c := newColl()
+				for i := range n {
+					c.Push(i)
+				}
+				for range n {
+					c.Pop()
+				}
+			}
+		})
+	}
+	benchDeque := func(push func(int), pop func() (int, bool)) testing.BenchmarkResult {
+		return testing.Benchmark(func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				for i := range n {
+					push(i)
+				}
+				for range n {
+					pop()
+				}
+			}
+		})
+	}
+
+	type comparison struct {
+		name          string
+		slice, linked testing.BenchmarkResult
+	}
+	results := []comparison{
+		{"Stack",
+			bench(func() pushPopper { return &collections.Stack[int]{} }),
+			bench(func() pushPopper { return &collections.LinkedStack[int]{} })},
+		{"Queue",
+			bench(func() pushPopper { return &collections.Queue[int]{} }),
+			bench(func() pushPopper { return &collections.LinkedQueue[int]{} })},
+	}
+	// The deques are reused across iterations, as a long-lived deque
+	// would be, so the ring buffer has already grown to size.
+	var d collections.Deque[int]
+	var ld collections.LinkedDeque[int]
+	results = append(results, comparison{"Deque", benchDeque(d.PushFront, d.PopBack), benchDeque(ld.PushFront, ld.PopBack)})
+
+	nsPerOp := func(r testing.BenchmarkResult) float64 {
+		return float64(r.T.Nanoseconds()) / float64(r.N)
+	}
+	for _, r := range results {
+		for _, backing := range []struct {
+			name string
+			res  testing.BenchmarkResult
+		}{{"slice", r.slice}, {"linked", r.linked}} {
+			fmt.Fprintf(w, "%-6s %-7s %10.0f ns/op %8d B/op %6d allocs/op\n",
+				r.name, backing.name, nsPerOp(backing.res), backing.res.AllocedBytesPerOp(), backing.res.AllocsPerOp())
+		}
+		fmt.Fprintf(w, "       slice is %.1fx faster\n", nsPerOp(r.linked)/nsPerOp(r.slice))
+	}
+
+	// Explanation:
+	// The linked versions allocate a node for every push, 1,000 allocations
+	// per round, and each node is a separate object the garbage collector
+	// has to trace. The slice versions allocate only when the slice grows:
+	// about ten times for the stack and queue, which start empty, and not
+	// at all for the reused deque. Their elements also sit next to each
+	// other in memory, so the CPU cache works for them. A linked backing
+	// only pays off when elements are inserted or removed in the middle,
+	// which none of these three types allow.
+}
+
+// Exercise 6: Cache books by ISBN in an lru.Cache of capacity 3. Show which
+// book is evicted as others are used, then give entries a TTL and expire
+// them by advancing a fake clock.
+func exercise6(w io.Writer) {
+	c := lru.New[string, Book](3)
+	for _, b := range books[:3] {
+		c.Put(b.ISBN, b)
+	}
+	fmt.Fprintln(w, "after 3 puts, most recent first:", titles(c))
+
+	if b, ok := c.Get(books[0].ISBN); ok {
+		fmt.Fprintln(w, "Get made this the most recent:", b.Title)
+	}
+	evicted := c.Put(books[3].ISBN, books[3])
+	fmt.Fprintf(w, "Put %q evicted a book: %v\n", books[3].Title, evicted)
+	fmt.Fprintln(w, "  now:", titles(c))
+	_, ok := c.Get(books[1].ISBN)
+	fmt.Fprintf(w, "  %q still cached: %v (it was the least recently used)\n", books[1].Title, ok)
+
+	c.Peek(books[2].ISBN) // Peek does not count as a use
+	c.Put(books[4].ISBN, books[4])
+	fmt.Fprintln(w, "after Peek and another Put:", titles(c))
+	fmt.Fprintln(w, "Remove", books[0].Title+":", c.Remove(books[0].ISBN), "- Len", c.Len())
+
+	fake := clock.NewFake(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))
+	ttl := lru.New[string, Book](3, lru.WithTTL(time.Hour), lru.WithClock(fake))
+	ttl.Put(books[0].ISBN, books[0])
+	ttl.PutTTL(books[1].ISBN, books[1], 10*time.Minute)
+	ttl.PutTTL(books[2].ISBN, books[2], 0) // never expires
+	fake.Advance(15 * time.Minute)
+	fmt.Fprintf(w, "after 15 minutes: %d entries, %q cached: %v\n",
+		ttl.Len(), books[1].Title, ttl.Contains(books[1].ISBN))
+	fake.Advance(time.Hour)
+	_, ok0 := ttl.Get(books[0].ISBN)
+	_, ok2 := ttl.Get(books[2].ISBN)
+	fmt.Fprintf(w, "after 75 minutes: %q cached: %v, %q cached: %v\n",
+		books[0].Title, ok0, books[2].Title, ok2)
+	ttl.Put(books[3].ISBN, books[3])
+	evicted = ttl.Put(books[4].ISBN, books[4])
+	fmt.Fprintf(w, "two more puts: %s; counted as an eviction: %v\n", titles(ttl), evicted)
+
+	// Explanation:
+	// Cache[K comparable, V any] needs comparable keys because they go in a
+	// map, while values can be anything; the type parameters let Get
+	// return a Book instead of an any to assert. Recency lives in a doubly
+	// linked list: Get and Put move an entry to the front in O(1) through
+	// the map's pointer to its element, and the back is always the one to
+	// evict. Peek reads without touching the order, so "Go in Action"
+	// stayed at the back and the next Put evicted it. Expired entries are
+	// removed lazily: Len still counts "Learning Go" after it expires,
+	// until a Get or an eviction reaches it, and dropping it to make room
+	// for "Mastering Go" is not counted as an eviction.
+}
+
+// titles lists the cached books from most to least recently used.
+func titles(c *lru.Cache[string, Book]) string {
+	var names []string
+	for _, isbn := range c.Keys() {
+		b, _ := c.Peek(isbn)
+		names = append(names, b.Title)
+	}
+	return strings.Join(names, ", ")
+}
+
+// Exercise 7: Benchmark the locked lru.Cache against a naive cache built
+// on sync.Map, which never evicts, with b.RunParallel for a read-heavy and
+// a write-heavy mix of operations.
+func exercise7(w io.Writer) {
+	const keys = 1000
+	bench := func(get func(int), put func(k, v int), readsPer10 int) float64 {
+		for k := range keys {
+			put(k, k)
+		}
+		res := testing.Benchmark(func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				for i := 0; pb.Next(); i++ {
+					k := i * 31 % keys
+					if i%10 < readsPer10 {
+						get(k)
+					} else {
+						put(k, i)
+					}
+				}
+			})
+		})
+		return float64(res.T.Nanoseconds()) / float64(res.N)
+	}
+
+	for _, mix := range []struct {
+		name  string
+		reads int
+	}{{"90% reads", 9}, {"50% reads", 5}} {
+		c := lru.New[int, int](keys, lru.WithLocking())
+		lruNs := bench(func(k int) { c.Get(k) }, func(k, v int) { c.Put(k, v) }, mix.reads)
+		var m sync.Map
+		mapNs := bench(func(k int) { m.Load(k) }, func(k, v int) { m.Store(k, v) }, mix.reads)
+		fmt.Fprintf(w, "%-10s lru.Cache %7.1f ns/op  sync.Map %7.1f ns/op  (lru.Cache takes %.1fx as long)\n",
+			mix.name, lruNs, mapNs, lruNs/mapNs)
+	}
+
+	// Explanation:
+	// sync.Map is built for keys that are written once and read many times
+	// from many goroutines: reads of existing keys take no lock at all. The
+	// LRU cache serializes every Get behind one mutex, because each Get
+	// updates the recency order, so adding cores does not make it faster.
+	// What the lock buys is the bound: the sync.Map keeps every key it was
+	// given, while the LRU cache stays at its capacity. When the lock does
+	// become the bottleneck, the usual fix is sharding: several smaller
+	// caches, each with its own lock, picked by a hash of the key.
+}
@@ -0,0 +1,359 @@
+package chapter9
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"learning-go/errs"
+	"learning-go/registry"
+	"learning-go/safe"
+)
+
+func init() {
+	// Register each exercise with the runner (cmd/learn)
+	registry.Register("chapter9", "exercise1", exercise1)
+	registry.Register("chapter9", "exercise2", exercise2)
+	registry.Register("chapter9", "exercise3", exercise3)
+	registry.Register("chapter9", "exercise4", exercise4)
+	registry.Register("chapter9", "exercise5", exercise5)
+	registry.Register("chapter9", "exercise6", exercise6)
+}
+
+// ErrNoSuchUser is a sentinel error: a package-level value that callers
+// compare against with errors.Is.
+var ErrNoSuchUser = errors.New("no such user")
+
+var users = map[int]string{1: "ada", 2: "grace"}
+
+// findUser returns ErrNoSuchUser, wrapped with the ID that was asked for.
+func findUser(id int) (string, error) {
+	name, ok := users[id]
+	if !ok {
+		return "", fmt.Errorf("user %d: %w", id, ErrNoSuchUser)
+	}
+	return name, nil
+}
+
+// loadProfile adds one more layer of context on top of findUser's.
+func loadProfile(id int) (string, error) {
+	name, err := findUser(id)
+	if err != nil {
+		return "", errs.Wrap(err, "loading profile")
+	}
+	return "profile of " + name, nil
+}
+
+// Exercise 1: Look up users with a function that returns a wrapped
+// sentinel error, and compare the error with == and with errors.Is. Then
+// check a standard library sentinel, fs.ErrNotExist, the same way.
+func exercise1(w io.Writer) {
+	for _, id := range []int{1, 3} {
+		profile, err := loadProfile(id)
+		if err != nil {
+			fmt.Fprintf(w, "id %d: %v\n", id, err)
+			fmt.Fprintf(w, "  err == ErrNoSuchUser:          %v\n", err == ErrNoSuchUser)
+			fmt.Fprintf(w, "  errors.Is(err, ErrNoSuchUser): %v\n", errors.Is(err, ErrNoSuchUser))
+			continue
+		}
+		fmt.Fprintf(w, "id %d: %s\n", id, profile)
+	}
+
+	_, err := os.Open("/no/such/file")
+	fmt.Fprintf(w, "os.Open of a missing file is fs.ErrNotExist: %v\n", errors.Is(err, fs.ErrNotExist))
+
+	// Explanation:
+	// A sentinel is compared by identity, and wrapping makes a new error
+	// value around it, so == fails as soon as anyone adds context.
+	// errors.Is follows the chain of Unwrap calls and compares each layer,
+	// which is why it is the only reliable test. os.Open returns a
+	// *fs.PathError, not fs.ErrNotExist itself, so the same rule applies to
+	// the standard library's sentinels.
+}
+
+// SignupForm is the data a user submits to create an account.
+type SignupForm struct {
+	Name  string
+	Email string
+	Age   string
+}
+
+// Validate checks every field and reports all the problems together, each
+// one as an *errs.ValidationError.
+func (f SignupForm) Validate() error {
+	var c errs.Collector
+	if strings.TrimSpace(f.Name) == "" {
+		c.Add(errs.Invalid("name", "must not be empty"))
+	}
+	if !strings.Contains(f.Email, "@") {
+		c.Add(errs.Invalid("email", "%q has no @", f.Email))
+	}
+	if age, err := strconv.Atoi(f.Age); err != nil {
+		c.Add(errs.Wrap(err, "invalid age"))
+	} else if age < 13 {
+		c.Add(errs.Invalid("age", "must be at least 13, got %d", age))
+	}
+	return c.Err()
+}
+
+// Exercise 2: Validate a sign-up form with a custom error type that has
+// fields, collecting every problem in an errs.Collector. Use errors.As to
+// get the first *errs.ValidationError out of the result, then go through
+// all of them.
+func exercise2(w io.Writer) {
+	forms := []SignupForm{
+		{Name: "Ada", Email: "ada@example.com", Age: "36"},
+		{Name: " ", Email: "grace.example.com", Age: "12"},
+		{Name: "Linus", Email: "linus@example.com", Age: "twenty"},
+	}
+	for _, f := range forms {
+		err := f.Validate()
+		if err == nil {
+			fmt.Fprintf(w, "%q: ok\n", f.Name)
+			continue
+		}
+		fmt.Fprintf(w, "%q: %v\n", f.Name, err)
+
+		var invalid *errs.ValidationError
+		if errors.As(err, &invalid) {
+			fmt.Fprintf(w, "  first invalid field: %s (%s)\n", invalid.Field, invalid.Reason)
+		}
+		var numErr *strconv.NumError
+		if errors.As(err, &numErr) {
+			fmt.Fprintf(w, "  strconv.%s could not parse %q\n", numErr.Func, numErr.Num)
+		}
+
+		var multi *errs.MultiError
+		if errors.As(err, &multi) {
+			for _, e := range multi.Errs {
+				if errors.As(e, &invalid) {
+					fmt.Fprintf(w, "  - %s\n", invalid.Field)
+				}
+			}
+		}
+		fmt.Fprintf(w, "  exit code: %d\n", errs.ExitCode(err))
+	}
+
+	// Explanation:
+	// A sentinel can only say what went wrong; a custom type can also
+	// carry data about it, here which field failed and why. errors.As
+	// walks the chain like errors.Is, but instead of comparing it looks
+	// for an error of the target's type and stores it in the target, so
+	// the fields can be read. MultiError implements Unwrap() []error,
+	// which makes both functions search every collected error: As found
+	// the NumError inside the wrapped age error, and ExitCode reports the
+	// usage code whenever any of the errors is a ValidationError.
+}
+
+// readConfig fails three layers down and wraps the error at each step.
+func readConfig(path string) error {
+	_, err := os.ReadFile(path)
+	if err != nil {
+		err = fmt.Errorf("reading %s: %w", path, err)
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	return nil
+}
+
+// Exercise 3: Wrap an error at several levels with %w, print each layer by
+// calling errors.Unwrap until nothing is left, and show that wrapping with
+// %v instead breaks the chain.
+func exercise3(w io.Writer) {
+	err := errs.Wrap(readConfig("/no/such/config.json"), "starting server")
+	fmt.Fprintln(w, "error:", err)
+	for layer := err; layer != nil; layer = errors.Unwrap(layer) {
+		fmt.Fprintf(w, "  %T\n", layer)
+	}
+
+	flattened := fmt.Errorf("starting server: %v", readConfig("/no/such/config.json"))
+	fmt.Fprintf(w, "with %%w, still fs.ErrNotExist: %v\n", errors.Is(err, fs.ErrNotExist))
+	fmt.Fprintf(w, "with %%v, still fs.ErrNotExist: %v\n", errors.Is(flattened, fs.ErrNotExist))
+	fmt.Fprintln(w, "errs.Wrap(nil, ...) is nil:", errs.Wrap(nil, "never happens") == nil)
+
+	// Explanation:
+	// Each %w produces a *fmt.wrapError whose Unwrap returns the error it
+	// wrapped, so the layers form a chain ending at the *fs.PathError from
+	// os.ReadFile, whose Unwrap in turn returns the syscall.Errno. %v only
+	// copies the text: the message looks the same, but the chain is gone
+	// and errors.Is can no longer find fs.ErrNotExist. errs.Wrap is the
+	// %w form that also passes nil through, so it can wrap any result.
+}
+
+// Exercise 4: Close several resources, collect every error with
+// errs.Collector instead of stopping at the first, and compare the result
+// with errors.Join from the standard library.
+func exercise4(w io.Writer) {
+	closers := map[string]error{
+		"database": nil,
+		"cache":    errors.New("connection reset"),
+		"log file": fs.ErrClosed,
+	}
+	names := []string{"database", "cache", "log file"}
+
+	var c errs.Collector
+	var joined []error
+	for _, name := range names {
+		err := errs.Wrap(closers[name], "closing %s", name)
+		c.Add(err)
+		joined = append(joined, err)
+	}
+	err := c.Err()
+	fmt.Fprintf(w, "collected %d: %v\n", c.Len(), err)
+	fmt.Fprintln(w, "contains fs.ErrClosed:", errors.Is(err, fs.ErrClosed))
+	fmt.Fprintf(w, "errors.Join:\n%v\n", errors.Join(joined...))
+
+	var none errs.Collector
+	none.Add(nil)
+	fmt.Fprintln(w, "nothing failed:", none.Err() == nil)
+
+	// Explanation:
+	// Cleanup code should try every step even when one fails, and then
+	// report everything. Collector drops nil errors, so each result can be
+	// added unchecked, and Err returns nil when all went well, which keeps
+	// the usual "if err != nil" working. errors.Join does the same job
+	// with one error per line; both implement Unwrap() []error, so
+	// errors.Is finds fs.ErrClosed inside either.
+}
+
+// Exercise 5: Show the rules of defer: deferred calls run in reverse
+// order, their arguments are evaluated when the defer statement runs, a
+// deferred closure sees later changes and can change named results, and
+// deferred calls still run when the function panics.
+func exercise5(w io.Writer) {
+	func() {
+		for i := range 3 {
+			defer fmt.Fprintln(w, "deferred", i)
+		}
+		fmt.Fprintln(w, "loop done")
+	}()
+
+	func() {
+		x := 1
+		defer fmt.Fprintln(w, "argument evaluated at defer:", x)
+		defer func() { fmt.Fprintln(w, "closure reads x at return:", x) }()
+		x = 2
+	}()
+
+	fmt.Fprintln(w, "divide(7, 2):", must(divide(7, 2)))
+	_, err := divide(1, 0)
+	fmt.Fprintln(w, "divide(1, 0):", err)
+
+	err = safe.SafeCall(func() error {
+		defer fmt.Fprintln(w, "deferred call runs during the panic")
+		panic("boom")
+	})
+	fmt.Fprintln(w, "then the panic arrives:", err)
+
+	// Explanation:
+	// Deferred calls are pushed on a stack and run last-in, first-out when
+	// the function returns, which undoes setup in the right order: what was
+	// opened last is closed first. A deferred call's arguments are
+	// evaluated right away, so the first defer printed x as 1; a closure
+	// instead reads x when it runs. Because deferred functions run after
+	// the return values are set but before the caller sees them, a
+	// closure can rewrite a named result, which is how divide turns its
+	// own panic into an error. And defers run while a panic unwinds the
+	// stack, so cleanup happens either way.
+}
+
+// divide returns a / b. A division by zero panics inside it; the deferred
+// function recovers and sets the named result err instead.
+func divide(a, b int) (q int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("divide %d by %d: %v", a, b, r)
+		}
+	}()
+	return a / b, nil
+}
+
+// must returns v, and panics if err is not nil.
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exercise 6: Recover from panics: only a deferred call can recover, a
+// recovered panic can be inspected and re-raised, runtime errors are
+// errors, and a panic in a goroutine is caught with safe.SafeGo and
+// reported to a custom logger instead of crashing the program.
+func exercise6(w io.Writer) {
+	notDeferred := safe.SafeCall(func() error {
+		if r := recover(); r != nil { // not called by a deferred function: returns nil
+			return fmt.Errorf("recovered %v", r)
+		}
+		var m map[string]int
+		m["x"] = 1
+		return nil
+	})
+	fmt.Fprintln(w, "recover outside a deferred call:", notDeferred)
+
+	err := safe.SafeCall(func() error {
+		var s []int
+		_ = s[3]
+		return nil
+	})
+	var re runtime.Error
+	fmt.Fprintln(w, "index out of range:", err)
+	fmt.Fprintln(w, "  is a runtime.Error:", errors.As(err, &re))
+	var pe *safe.PanicError
+	if errors.As(err, &pe) {
+		fmt.Fprintln(w, "  stack trace captured:", strings.Contains(string(pe.Stack), "chapter9.exercise6"))
+	}
+
+	// Recover only what you expect; re-panic with anything else.
+	errTooDeep := errors.New("too deep")
+	parse := func(depth int) (err error) {
+		defer func() {
+			r := recover()
+			if e, ok := r.(error); ok && errors.Is(e, errTooDeep) {
+				err = e
+				return
+			}
+			if r != nil {
+				panic(r)
+			}
+		}()
+		if depth > 10 {
+			panic(errTooDeep)
+		}
+		if depth < 0 {
+			panic("negative depth")
+		}
+		return nil
+	}
+	fmt.Fprintln(w, "parse(20):", parse(20))
+	fmt.Fprintln(w, "parse(-1):", safe.SafeCall(func() error { return parse(-1) }))
+
+	// A panic in a goroutine cannot be recovered by the code that started
+	// it. SafeGo recovers it inside the goroutine and logs it.
+	logged := make(chan *safe.PanicError)
+	previous := safe.SetLogger(func(err *safe.PanicError) { logged <- err })
+	safe.SafeGo(func() {
+		var a *struct{ n int }
+		a.n++
+	})
+	fmt.Fprintln(w, "goroutine panic logged:", <-logged)
+	safe.SetLogger(previous)
+
+	// Explanation:
+	// recover returns the panic value only when called directly by a
+	// deferred function while the goroutine is panicking; anywhere else it
+	// returns nil, so the first call did nothing and the nil-map write
+	// panicked as usual. Panics raised by the runtime, such as an index out
+	// of range, carry a runtime.Error, so errors.As can tell them from the
+	// program's own. A recover that swallows everything also hides bugs:
+	// parse only handles the panic it raises itself and passes any other
+	// on with panic(r). Every goroutine unwinds its own stack, so a panic
+	// in one is never seen by the code that started it, and the whole
+	// program crashes unless that goroutine recovers. SafeGo does the
+	// recovering and hands the panic, with its stack, to the Logger set
+	// with SetLogger.
+}
@@ -0,0 +1,211 @@
+// Package clock abstracts the parts of the time package that make code
+// hard to test: reading the current time, sleeping, and waiting on timers
+// and tickers.
+//
+// Production code takes a Clock and is given Real. Tests give it a *Fake
+// instead and move time forward explicitly with Advance, so code that
+// waits for minutes runs instantly and always behaves the same way.
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock is the subset of the time package that timing-dependent code uses.
+type Clock interface {
+	Now() time.Time
+	// Since returns the time elapsed since t.
+	Since(t time.Time) time.Duration
+	// After waits for d and then sends the current time on the channel.
+	After(d time.Duration) <-chan time.Time
+	// Sleep pauses the calling goroutine for at least d.
+	Sleep(d time.Duration)
+	// NewTicker returns a Ticker that ticks every d. d must be positive.
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker is the interface version of *time.Ticker. The channel is returned
+// by a method so that fakes can provide their own.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+	Reset(d time.Duration)
+}
+
+// Real is the Clock backed by the time package.
+var Real Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) Since(t time.Time) time.Duration        { return time.Since(t) }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (realClock) Sleep(d time.Duration)                  { time.Sleep(d) }
+func (realClock) NewTicker(d time.Duration) Ticker       { return realTicker{time.NewTicker(d)} }
+
+type realTicker struct {
+	t *time.Ticker
+}
+
+func (r realTicker) C() <-chan time.Time   { return r.t.C }
+func (r realTicker) Stop()                 { r.t.Stop() }
+func (r realTicker) Reset(d time.Duration) { r.t.Reset(d) }
+
+// Fake is a Clock whose time only moves when Advance or Set is called.
+// It is safe for concurrent use.
+type Fake struct {
+	mu      sync.Mutex
+	cond    *sync.Cond // signalled whenever a waiter is added
+	now     time.Time
+	waiters []*waiter
+}
+
+// waiter is a pending After, Sleep or ticker.
+type waiter struct {
+	when   time.Time
+	period time.Duration // non-zero for tickers
+	ch     chan time.Time
+}
+
+// NewFake returns a Fake clock set to start.
+func NewFake(start time.Time) *Fake {
+	f := &Fake{now: start}
+	f.cond = sync.NewCond(&f.mu)
+	return f
+}
+
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *Fake) Since(t time.Time) time.Duration {
+	return f.Now().Sub(t)
+}
+
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.addLocked(d, 0).ch
+}
+
+// Sleep blocks until another goroutine advances the clock by at least d.
+func (f *Fake) Sleep(d time.Duration) {
+	<-f.After(d)
+}
+
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("clock: non-positive interval for NewTicker")
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return &fakeTicker{f: f, w: f.addLocked(d, d)}
+}
+
+// addLocked registers a waiter that fires after d. f.mu must be held.
+func (f *Fake) addLocked(d, period time.Duration) *waiter {
+	// Like time.Ticker, the channel holds one value; ticks that nobody
+	// receives in time are dropped rather than blocking Advance.
+	w := &waiter{when: f.now.Add(d), period: period, ch: make(chan time.Time, 1)}
+	if d <= 0 {
+		w.ch <- f.now
+		return w
+	}
+	f.waiters = append(f.waiters, w)
+	f.cond.Broadcast()
+	return w
+}
+
+func (f *Fake) removeLocked(w *waiter) {
+	for i, other := range f.waiters {
+		if other == w {
+			f.waiters = append(f.waiters[:i], f.waiters[i+1:]...)
+			return
+		}
+	}
+}
+
+// Advance moves the clock forward by d, firing every timer and ticker that
+// comes due along the way in chronological order.
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.advanceToLocked(f.now.Add(d))
+}
+
+// Set moves the clock to t. Moving it backwards fires nothing.
+func (f *Fake) Set(t time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if t.Before(f.now) {
+		f.now = t
+		return
+	}
+	f.advanceToLocked(t)
+}
+
+func (f *Fake) advanceToLocked(end time.Time) {
+	for {
+		sort.SliceStable(f.waiters, func(i, j int) bool {
+			return f.waiters[i].when.Before(f.waiters[j].when)
+		})
+		if len(f.waiters) == 0 || f.waiters[0].when.After(end) {
+			break
+		}
+		w := f.waiters[0]
+		f.now = w.when
+		select {
+		case w.ch <- f.now:
+		default:
+		}
+		if w.period > 0 {
+			w.when = w.when.Add(w.period)
+		} else {
+			f.waiters = f.waiters[1:]
+		}
+	}
+	f.now = end
+}
+
+// BlockUntil waits until at least n timers, sleeps or tickers are pending.
+// Tests call it before Advance to be sure the code under test has reached
+// the point where it waits, instead of guessing with real sleeps.
+func (f *Fake) BlockUntil(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for len(f.waiters) < n {
+		f.cond.Wait()
+	}
+}
+
+type fakeTicker struct {
+	f *Fake
+	w *waiter
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.w.ch
+}
+
+func (t *fakeTicker) Stop() {
+	t.f.mu.Lock()
+	defer t.f.mu.Unlock()
+	t.f.removeLocked(t.w)
+}
+
+func (t *fakeTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("clock: non-positive interval for Ticker.Reset")
+	}
+	t.f.mu.Lock()
+	defer t.f.mu.Unlock()
+	t.f.removeLocked(t.w)
+	t.w.period = d
+	t.w.when = t.f.now.Add(d)
+	t.f.waiters = append(t.f.waiters, t.w)
+	t.f.cond.Broadcast()
+}
@@ -0,0 +1,151 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+var start = time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+// received returns the value waiting on ch, if there is one.
+func received(ch <-chan time.Time) (time.Time, bool) {
+	select {
+	case t := <-ch:
+		return t, true
+	default:
+		return time.Time{}, false
+	}
+}
+
+func TestFakeAfter(t *testing.T) {
+	f := NewFake(start)
+	ch := f.After(time.Minute)
+	f.Advance(59 * time.Second)
+	if _, ok := received(ch); ok {
+		t.Fatal("After(1m) fired after 59s")
+	}
+	f.Advance(time.Hour)
+	got, ok := received(ch)
+	if !ok || !got.Equal(start.Add(time.Minute)) {
+		t.Errorf("After(1m) sent %v, %v; want %v", got, ok, start.Add(time.Minute))
+	}
+	if want := start.Add(time.Hour + 59*time.Second); !f.Now().Equal(want) {
+		t.Errorf("Now() = %v, want %v", f.Now(), want)
+	}
+	if got := f.Since(start); got != time.Hour+59*time.Second {
+		t.Errorf("Since(start) = %v", got)
+	}
+
+	if _, ok := received(f.After(0)); !ok {
+		t.Error("After(0) did not fire at once")
+	}
+}
+
+func TestFakeFiresInOrder(t *testing.T) {
+	f := NewFake(start)
+	late, early := f.After(2*time.Second), f.After(time.Second)
+	f.Advance(5 * time.Second)
+	a, _ := received(early)
+	b, _ := received(late)
+	if !a.Equal(start.Add(time.Second)) || !b.Equal(start.Add(2*time.Second)) {
+		t.Errorf("timers fired at %v and %v", a, b)
+	}
+}
+
+func TestFakeSleep(t *testing.T) {
+	f := NewFake(start)
+	done := make(chan struct{})
+	go func() {
+		f.Sleep(time.Hour)
+		close(done)
+	}()
+	f.BlockUntil(1)
+	f.Advance(time.Hour)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Sleep(1h) did not return after Advance(1h)")
+	}
+}
+
+func TestFakeTicker(t *testing.T) {
+	f := NewFake(start)
+	tk := f.NewTicker(10 * time.Second)
+
+	f.Advance(10 * time.Second)
+	if got, ok := received(tk.C()); !ok || !got.Equal(start.Add(10*time.Second)) {
+		t.Fatalf("first tick = %v, %v", got, ok)
+	}
+	// Like time.Ticker, ticks nobody received are dropped, not queued.
+	f.Advance(35 * time.Second)
+	if got, ok := received(tk.C()); !ok || !got.Equal(start.Add(20*time.Second)) {
+		t.Errorf("buffered tick = %v, %v; want the one at 20s", got, ok)
+	}
+	if _, ok := received(tk.C()); ok {
+		t.Error("more than one tick was buffered")
+	}
+
+	tk.Reset(time.Minute) // now at 45s: next tick at 1m45s
+	f.Advance(59 * time.Second)
+	if _, ok := received(tk.C()); ok {
+		t.Error("ticked before the reset interval passed")
+	}
+	f.Advance(time.Second)
+	if got, ok := received(tk.C()); !ok || !got.Equal(start.Add(105*time.Second)) {
+		t.Errorf("tick after Reset = %v, %v", got, ok)
+	}
+
+	tk.Stop()
+	f.Advance(time.Hour)
+	if _, ok := received(tk.C()); ok {
+		t.Error("stopped ticker ticked")
+	}
+}
+
+func TestFakeTickerPanics(t *testing.T) {
+	f := NewFake(start)
+	for name, fn := range map[string]func(){
+		"NewTicker(0)": func() { f.NewTicker(0) },
+		"Reset(-1)":    func() { f.NewTicker(time.Second).Reset(-1) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", name)
+				}
+			}()
+			fn()
+		}()
+	}
+}
+
+func TestFakeSet(t *testing.T) {
+	f := NewFake(start)
+	ch := f.After(time.Hour)
+	f.Set(start.Add(-time.Hour))
+	if !f.Now().Equal(start.Add(-time.Hour)) {
+		t.Errorf("Now() = %v after setting it back", f.Now())
+	}
+	if _, ok := received(ch); ok {
+		t.Error("moving the clock back fired a timer")
+	}
+	f.Set(start.Add(time.Hour))
+	if _, ok := received(ch); !ok {
+		t.Error("Set past the deadline did not fire the timer")
+	}
+}
+
+func TestReal(t *testing.T) {
+	before := time.Now()
+	Real.Sleep(time.Millisecond)
+	if Real.Since(before) < time.Millisecond || Real.Now().Before(before) {
+		t.Error("Real does not follow the time package")
+	}
+	tk := Real.NewTicker(time.Millisecond)
+	defer tk.Stop()
+	select {
+	case <-tk.C():
+	case <-Real.After(5 * time.Second):
+		t.Fatal("real ticker did not tick")
+	}
+}
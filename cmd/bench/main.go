@@ -0,0 +1,51 @@
+// Command bench runs the benchmarks in package benchmarks and prints the
+// results as "go test -bench . -benchmem" would, with the lesson of each
+// group above its results:
+//
+//	go run ./cmd/bench
+//	go run ./cmd/bench -run 'Slice|Map'
+//	go run ./cmd/bench | go run ./cmd/benchtrack record -
+//
+// "make bench" runs it with no arguments.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+
+	"learning-go/benchmarks"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("bench: ")
+
+	run := flag.String("run", "", "only run the groups whose name matches this regexp")
+	list := flag.Bool("list", false, "list the groups and their benchmarks without running them")
+	flag.Parse()
+
+	var filter *regexp.Regexp
+	if *run != "" {
+		var err error
+		if filter, err = regexp.Compile(*run); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if *list {
+		for _, g := range benchmarks.Groups {
+			if filter != nil && !filter.MatchString(g.Name) {
+				continue
+			}
+			fmt.Println(g.Name)
+			for _, c := range g.Cases {
+				fmt.Println("  " + c.Name)
+			}
+		}
+		return
+	}
+	benchmarks.Run(os.Stdout, filter)
+}
@@ -0,0 +1,186 @@
+// Command benchtrack records benchmark results per commit and flags
+// regressions between two commits:
+//
+//	go run ./cmd/benchtrack record                 # run go test -bench ./... for HEAD
+//	go test -bench . -benchmem ./seq | go run ./cmd/benchtrack record -
+//	go run ./cmd/benchtrack compare HEAD~1         # HEAD~1 against HEAD
+//	go run ./cmd/benchtrack compare -threshold 5 v1.0 main
+//
+// Commits can be anything git understands; they are stored as full hashes
+// in .bench/history.json. compare exits with status 1 if any benchmark got
+// worse by more than -threshold percent, so it can gate CI.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"learning-go/benchtrack"
+	"learning-go/report"
+)
+
+const usage = `usage:
+	benchtrack record [-commit rev] [-count n] [- | packages]
+	benchtrack compare [-threshold percent] base [head]`
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("benchtrack: ")
+
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+	store := os.Getenv("BENCHTRACK_STORE")
+	if store == "" {
+		store = ".bench/history.json"
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "record":
+		err = record(store, os.Args[2:])
+	case "compare":
+		var regressed bool
+		regressed, err = compare(store, os.Args[2:])
+		if err == nil && regressed {
+			os.Exit(1)
+		}
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func record(storePath string, args []string) error {
+	fs := flag.NewFlagSet("record", flag.ExitOnError)
+	rev := fs.String("commit", "HEAD", "commit the results belong to")
+	count := fs.Int("count", 5, "runs per benchmark, for a stable median")
+	fs.Parse(args)
+
+	commit, err := resolve(*rev)
+	if err != nil {
+		return err
+	}
+
+	var input io.Reader
+	if fs.NArg() == 1 && fs.Arg(0) == "-" {
+		input = os.Stdin
+	} else {
+		pkgs := fs.Args()
+		if len(pkgs) == 0 {
+			pkgs = []string{"./..."}
+		}
+		cmdArgs := append([]string{"test", "-run", "^$", "-bench", ".", "-benchmem", fmt.Sprintf("-count=%d", *count)}, pkgs...)
+		cmd := exec.Command("go", cmdArgs...)
+		cmd.Stderr = os.Stderr
+		out, err := cmd.Output()
+		os.Stdout.Write(out)
+		if err != nil {
+			return fmt.Errorf("go test: %w", err)
+		}
+		input = bytes.NewReader(out)
+	}
+
+	results, err := benchtrack.Parse(input)
+	if err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		return fmt.Errorf("no benchmark results found")
+	}
+	store, err := benchtrack.Open(storePath)
+	if err != nil {
+		return err
+	}
+	store.Record(commit, results, time.Now())
+	if err := store.Save(); err != nil {
+		return err
+	}
+	fmt.Printf("recorded %d results for %s\n", len(results), commit[:12])
+	return nil
+}
+
+func compare(storePath string, args []string) (regressed bool, err error) {
+	fs := flag.NewFlagSet("compare", flag.ExitOnError)
+	threshold := fs.Float64("threshold", 10, "percent increase that counts as a regression")
+	fs.Parse(args)
+	if fs.NArg() < 1 || fs.NArg() > 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+	headRev := "HEAD"
+	if fs.NArg() == 2 {
+		headRev = fs.Arg(1)
+	}
+
+	store, err := benchtrack.Open(storePath)
+	if err != nil {
+		return false, err
+	}
+	runs := make([]benchtrack.Run, 2)
+	for i, rev := range []string{fs.Arg(0), headRev} {
+		commit, err := resolve(rev)
+		if err != nil {
+			return false, err
+		}
+		run, ok := store.Get(commit)
+		if !ok {
+			return false, fmt.Errorf("no results for %s (%s); check it out and run benchtrack record", rev, commit[:12])
+		}
+		runs[i] = run
+	}
+
+	t := report.Table{
+		Title:   fmt.Sprintf("%s → %s", fs.Arg(0), headRev),
+		Headers: []string{"benchmark", "metric", "old", "new", "change", ""},
+		Align:   []report.Align{report.Left, report.Left, report.Right, report.Right, report.Right},
+	}
+	deltas := benchtrack.Compare(runs[0].Results, runs[1].Results, *threshold/100)
+	for _, d := range deltas {
+		mark := ""
+		if d.Regression {
+			mark = "REGRESSION"
+			regressed = true
+		}
+		t.AddRow(d.Name, string(d.Metric), format(d.Old), format(d.New), percent(d.Change), mark)
+	}
+	if len(deltas) == 0 {
+		fmt.Println("no benchmarks in common")
+		return false, nil
+	}
+	return regressed, t.Render(os.Stdout)
+}
+
+func resolve(rev string) (string, error) {
+	out, err := exec.Command("git", "rev-parse", "--verify", rev+"^{commit}").Output()
+	if err != nil {
+		return "", fmt.Errorf("unknown commit %q", rev)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func format(v float64) string {
+	if v == math.Trunc(v) {
+		return fmt.Sprintf("%.0f", v)
+	}
+	return fmt.Sprintf("%.2f", v)
+}
+
+func percent(change float64) string {
+	if math.IsInf(change, 1) {
+		return "new"
+	}
+	return fmt.Sprintf("%+.1f%%", change*100)
+}
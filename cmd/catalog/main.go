@@ -0,0 +1,62 @@
+// Command catalog lists every exercise in the repository as JSON.
+//
+// The exercises are found by package catalog, which reads the exerciseN
+// functions and their doc comments: the first sentence after "Exercise N:"
+// becomes the title, the whole comment the description, and an optional
+// "Tags:" line a list of tags.
+//
+// With -lang (or a LANG such as fa_IR.UTF-8 in the environment), titles
+// and hints come from package messages where a translation exists.
+//
+//	go run ./cmd/catalog            # print the catalog
+//	go run ./cmd/catalog -o catalog.json
+//	go run ./cmd/catalog -lang fa
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"log"
+	"os"
+
+	"learning-go/catalog"
+	"learning-go/messages"
+)
+
+func main() {
+	root := flag.String("root", ".", "repository root to scan")
+	output := flag.String("o", "", "write the catalog to this file instead of stdout")
+	langFlag := flag.String("lang", "", "language for titles and hints (default from $LANG)")
+	flag.Parse()
+
+	log.SetFlags(0)
+	log.SetPrefix("catalog: ")
+
+	exercises, err := catalog.Scan(*root)
+	if err != nil {
+		log.Fatal(err)
+	}
+	lang := messages.Lang(*langFlag)
+	for i := range exercises {
+		ex := &exercises[i]
+		name := ex.Package + "/" + ex.Function
+		ex.Title = messages.Prompt(lang, name, ex.Title)
+		ex.Hint, _ = messages.Hint(lang, name)
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		w = f
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(exercises); err != nil {
+		log.Fatal(err)
+	}
+}
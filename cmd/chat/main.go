@@ -0,0 +1,44 @@
+// Command chat runs the TCP chat server from package chapter17/chat.
+// Connect with nc or telnet and type; /nick name changes your name and
+// /quit leaves:
+//
+//	go run ./cmd/chat -addr localhost:9000
+//	nc localhost 9000
+//
+// Ctrl-C (SIGINT) or SIGTERM shuts it down cleanly: every client is told
+// and disconnected before the command exits.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"learning-go/chapter17/chat"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:9000", "address to listen on")
+	buffer := flag.Int("buffer", 64, "lines queued per client before it is dropped as too slow")
+	flag.Parse()
+
+	log.SetFlags(log.LstdFlags)
+	log.SetPrefix("chat: ")
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("listening on %s", ln.Addr())
+	if err := chat.Serve(ctx, ln, chat.WithBuffer(*buffer)); err != nil {
+		log.Fatal(err)
+	}
+	log.Print("stopped")
+}
@@ -0,0 +1,80 @@
+// Command complexity reports cyclomatic complexity, length and nesting
+// depth for every function in a Go file. Given a reference solution with
+// -ref, it prints the reference's numbers alongside and marks functions
+// that are noticeably more complex:
+//
+//	go run ./cmd/complexity -ref chapter3/main.go my/chapter3/main.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+
+	"learning-go/complexity"
+	"learning-go/report"
+)
+
+func main() {
+	ref := flag.String("ref", "", "reference solution to compare against")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: complexity [-ref reference.go] solution.go")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	log.SetFlags(0)
+	log.SetPrefix("complexity: ")
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	solution, err := analyzeFile(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	var reference []complexity.Func
+	if *ref != "" {
+		if reference, err = analyzeFile(*ref); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	t := report.Table{
+		Headers: []string{"function", "cyclomatic", "lines", "nesting", "note"},
+		Align:   []report.Align{report.Left, report.Right, report.Right, report.Right},
+	}
+	for _, c := range complexity.Compare(solution, reference) {
+		s, r := c.Solution, c.Reference
+		if !c.HasReference {
+			t.AddRow(c.Name, s.Cyclomatic, s.Lines, s.Nesting, "")
+			continue
+		}
+		note := ""
+		if s.Cyclomatic > r.Cyclomatic+2 || s.Nesting > r.Nesting+1 {
+			note = "consider simplifying"
+		}
+		t.AddRow(c.Name,
+			fmt.Sprintf("%d (ref %d)", s.Cyclomatic, r.Cyclomatic),
+			fmt.Sprintf("%d (ref %d)", s.Lines, r.Lines),
+			fmt.Sprintf("%d (ref %d)", s.Nesting, r.Nesting),
+			note)
+	}
+	if err := t.Render(os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func analyzeFile(path string) ([]complexity.Func, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+	return complexity.Analyze(fset, f), nil
+}
@@ -0,0 +1,50 @@
+// Command employeeserver serves the employee REST API from package
+// chapter13/httpserver, with request logging and panic recovery. Ctrl-C
+// (SIGINT) shuts it down gracefully, letting requests in flight finish:
+//
+//	go run ./cmd/employeeserver -addr localhost:8080
+//	curl localhost:8080/employees
+//	curl -H 'Content-Type: application/json' -d '{"name":"Barbara","salary":5500}' localhost:8080/employees
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"time"
+
+	"learning-go/chapter13/httpserver"
+	"learning-go/chapter7/employees"
+	"learning-go/clock"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8080", "address to listen on")
+	grace := flag.Duration("grace", 10*time.Second, "how long to wait for requests in flight on shutdown")
+	flag.Parse()
+
+	log.SetFlags(log.LstdFlags)
+	log.SetPrefix("employeeserver: ")
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	logger := log.Default()
+	h := httpserver.Chain(
+		httpserver.NewHandler(httpserver.NewStore(employees.Seed)),
+		httpserver.Logging(logger, clock.Real),
+		httpserver.Recover(logger),
+	)
+	log.Printf("listening on %s", ln.Addr())
+	if err := httpserver.Serve(ctx, ln, h, *grace); err != nil {
+		log.Fatal(err)
+	}
+	log.Print("stopped")
+}
@@ -0,0 +1,226 @@
+// Command enumgen generates String, Parse and JSON methods for an integer
+// enum type, in the spirit of golang.org/x/tools/cmd/stringer.
+//
+// Put a go:generate comment next to the type:
+//
+//	//go:generate go run learning-go/cmd/enumgen -type=Weekday
+//	type Weekday int
+//
+//	const (
+//		Sunday Weekday = iota
+//		Monday
+//		...
+//	)
+//
+// and run `go generate` in that package. enumgen type-checks the package,
+// collects every constant of the named type, and writes
+// <type>_enum.go (lowercased) with:
+//
+//   - func (x T) String() string
+//   - func ParseT(s string) (T, error)
+//   - func (x T) MarshalJSON() ([]byte, error)
+//   - func (x *T) UnmarshalJSON(data []byte) error
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+func main() {
+	typeName := flag.String("type", "", "name of the enum type (required)")
+	output := flag.String("output", "", "output file name (default <type>_enum.go)")
+	flag.Parse()
+
+	log.SetFlags(0)
+	log.SetPrefix("enumgen: ")
+
+	if *typeName == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+	outName := *output
+	if outName == "" {
+		outName = strings.ToLower(*typeName) + "_enum.go"
+	}
+
+	pkgName, values, err := loadEnum(dir, *typeName, outName)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := generate(pkgName, *typeName, values)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, outName), src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// enumValue is one constant of the enum type.
+type enumValue struct {
+	Name  string
+	Value int64
+	pos   token.Pos // where the constant is declared
+}
+
+// loadEnum parses and type-checks the package in dir and returns its name
+// and the constants declared with type typeName, ordered by value.
+// The previously generated file is skipped so stale output cannot break
+// the type check.
+func loadEnum(dir, typeName, skip string) (string, []enumValue, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		name := fi.Name()
+		return !strings.HasSuffix(name, "_test.go") && name != skip
+	}, 0)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(pkgs) != 1 {
+		return "", nil, fmt.Errorf("expected one package in %s, found %d", dir, len(pkgs))
+	}
+
+	var pkgName string
+	var files []*ast.File
+	for name, pkg := range pkgs {
+		pkgName = name
+		for _, f := range pkg.Files {
+			files = append(files, f)
+		}
+	}
+
+	// Imports of other packages in this module cannot be resolved by the
+	// default importer. Those errors do not matter, because constant values
+	// are computed from the enum's own declarations, so they are ignored.
+	conf := types.Config{Importer: importer.Default(), Error: func(error) {}}
+	pkg, _ := conf.Check(pkgName, fset, files, nil)
+
+	obj := pkg.Scope().Lookup(typeName)
+	if obj == nil {
+		return "", nil, fmt.Errorf("type %s not found in package %s", typeName, pkgName)
+	}
+	named, ok := obj.Type().(*types.Named)
+	if !ok {
+		return "", nil, fmt.Errorf("%s is not a named type", typeName)
+	}
+	if basic, ok := named.Underlying().(*types.Basic); !ok || basic.Info()&types.IsInteger == 0 {
+		return "", nil, fmt.Errorf("%s must have an integer underlying type", typeName)
+	}
+
+	var values []enumValue
+	for _, name := range pkg.Scope().Names() {
+		c, ok := pkg.Scope().Lookup(name).(*types.Const)
+		if !ok || !types.Identical(c.Type(), named) || name == "_" {
+			continue
+		}
+		v, exact := constant.Int64Val(c.Val())
+		if !exact {
+			return "", nil, fmt.Errorf("constant %s does not fit in int64", name)
+		}
+		values = append(values, enumValue{Name: name, Value: v, pos: c.Pos()})
+	}
+	if len(values) == 0 {
+		return "", nil, fmt.Errorf("no constants of type %s found", typeName)
+	}
+	// Scope.Names is alphabetical. Order by declaration first, so that of
+	// two aliases the one declared first is the name generate keeps.
+	sort.Slice(values, func(i, j int) bool { return values[i].pos < values[j].pos })
+	sort.SliceStable(values, func(i, j int) bool { return values[i].Value < values[j].Value })
+	return pkgName, values, nil
+}
+
+var tmpl = template.Must(template.New("enum").Parse(`// Code generated by enumgen -type={{.Type}}; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// String returns the name of the {{.Type}} constant.
+func (x {{.Type}}) String() string {
+	switch x {
+{{- range .Values}}
+	case {{.Name}}:
+		return "{{.Name}}"
+{{- end}}
+	}
+	return fmt.Sprintf("{{.Type}}(%d)", int64(x))
+}
+
+// Parse{{.Type}} returns the {{.Type}} constant with the given name.
+func Parse{{.Type}}(s string) ({{.Type}}, error) {
+	switch s {
+{{- range .Values}}
+	case "{{.Name}}":
+		return {{.Name}}, nil
+{{- end}}
+	}
+	return 0, fmt.Errorf("invalid {{.Type}} %q", s)
+}
+
+// MarshalJSON encodes a {{.Type}} as its name.
+func (x {{.Type}}) MarshalJSON() ([]byte, error) {
+	return json.Marshal(x.String())
+}
+
+// UnmarshalJSON decodes a {{.Type}} from its name.
+func (x *{{.Type}}) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("{{.Type}} should be a string, got %s", data)
+	}
+	v, err := Parse{{.Type}}(s)
+	if err != nil {
+		return err
+	}
+	*x = v
+	return nil
+}
+`))
+
+func generate(pkgName, typeName string, values []enumValue) ([]byte, error) {
+	// Aliases (two constants with the same value) would produce duplicate
+	// case labels in String, so only the first name for each value is kept.
+	var unique []enumValue
+	seen := make(map[int64]bool)
+	for _, v := range values {
+		if !seen[v.Value] {
+			seen[v.Value] = true
+			unique = append(unique, v)
+		}
+	}
+
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, struct {
+		Package string
+		Type    string
+		Values  []enumValue
+	}{pkgName, typeName, unique})
+	if err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
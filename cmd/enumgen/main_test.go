@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadEnumAndGenerate(t *testing.T) {
+	dir := t.TempDir()
+	src := `package colors
+
+type Color int
+
+const (
+	Red Color = iota
+	Green
+	Blue
+	Crimson = Red // an alias: String must not get a duplicate case
+)
+
+const unrelated = 7
+`
+	if err := os.WriteFile(filepath.Join(dir, "colors.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// A stale generated file that no longer compiles must be skipped.
+	if err := os.WriteFile(filepath.Join(dir, "color_enum.go"), []byte("package colors\nbroken"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	pkg, values, err := loadEnum(dir, "Color", "color_enum.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pkg != "colors" {
+		t.Errorf("package = %q, want colors", pkg)
+	}
+	var names []string
+	for _, v := range values {
+		names = append(names, v.Name)
+	}
+	if got := strings.Join(names, " "); got != "Red Crimson Green Blue" {
+		t.Errorf("constants = %s, want Red Crimson Green Blue", got)
+	}
+
+	out, err := generate(pkg, "Color", values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := bytes.Count(out, []byte("case Red:")); n != 1 {
+		t.Errorf("generated String has %d cases for Red, want 1:\n%s", n, out)
+	}
+	if bytes.Contains(out, []byte("case Crimson:")) {
+		t.Errorf("generated code has a case for the alias Crimson:\n%s", out)
+	}
+	for _, want := range []string{"func ParseColor(s string) (Color, error)", "func (x *Color) UnmarshalJSON"} {
+		if !bytes.Contains(out, []byte(want)) {
+			t.Errorf("generated code lacks %q", want)
+		}
+	}
+}
+
+// The committed chapter11/weekday_enum.go must be what enumgen produces
+// now; otherwise someone changed the constants or the template without
+// running go generate.
+func TestWeekdayUpToDate(t *testing.T) {
+	dir := filepath.Join("..", "..", "chapter11")
+	pkg, values, err := loadEnum(dir, "Weekday", "weekday_enum.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := generate(pkg, "Weekday", values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(filepath.Join(dir, "weekday_enum.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("chapter11/weekday_enum.go is stale; run go generate ./chapter11")
+	}
+}
@@ -0,0 +1,207 @@
+// Command flashcards studies the exercises' explanations as flashcards in
+// the terminal, scheduled with spaced repetition (package review), or
+// exports them for Anki:
+//
+//	go run ./cmd/flashcards -chapter 3          # study chapter 3's due cards
+//	go run ./cmd/flashcards -export cards.tsv   # Anki import file, all chapters
+//
+// Each card shows an exercise; recall why its solution works, press Enter
+// to see the explanation, and grade yourself from 0 (blackout) to 5
+// (perfect). Review state is kept in -state between sessions.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"learning-go/flashcards"
+	"learning-go/review"
+)
+
+func main() {
+	root := flag.String("root", ".", "repository root to read exercises from")
+	chapter := flag.String("chapter", "", "only this chapter, e.g. 3 (default all)")
+	export := flag.String("export", "", "write an Anki TSV file (- for stdout) instead of studying")
+	state := flag.String("state", defaultStatePath(), "file holding review state")
+	limit := flag.Int("n", 20, "at most this many cards per session")
+	flag.Parse()
+
+	log.SetFlags(0)
+	log.SetPrefix("flashcards: ")
+
+	ch := *chapter
+	if ch != "" && !strings.HasPrefix(ch, "chapter") {
+		ch = "chapter" + ch
+	}
+	cards, err := flashcards.Extract(*root, ch)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(cards) == 0 {
+		log.Fatal("no exercises with explanations found")
+	}
+
+	if *export != "" {
+		if err := exportTSV(*export, cards); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if err := study(os.Stdin, os.Stdout, cards, *state, *limit); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func defaultStatePath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "flashcards.json"
+	}
+	return filepath.Join(dir, "learning-go", "flashcards.json")
+}
+
+func exportTSV(path string, cards []flashcards.Card) error {
+	if path == "-" {
+		return flashcards.WriteTSV(os.Stdout, cards)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := flashcards.WriteTSV(f, cards); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func study(in io.Reader, out io.Writer, cards []flashcards.Card, statePath string, limit int) error {
+	schedule, err := loadState(statePath)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	byID := make(map[string]flashcards.Card, len(cards))
+	var scheduled []review.Card
+	for _, c := range cards {
+		byID[c.ID] = c
+		rc, ok := schedule[c.ID]
+		if !ok {
+			rc = review.NewCard(c.ID, now)
+		}
+		scheduled = append(scheduled, rc)
+	}
+	due := review.DueCards(scheduled, now)
+	if len(due) == 0 {
+		next := scheduled[0].Due
+		for _, c := range scheduled {
+			if c.Due.Before(next) {
+				next = c.Due
+			}
+		}
+		fmt.Fprintf(out, "Nothing is due. The next card is due %s.\n", next.Format("Mon Jan 2 15:04"))
+		return nil
+	}
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	lines := bufio.NewScanner(in)
+	for i, rc := range due {
+		c := byID[rc.ID]
+		fmt.Fprintf(out, "\n[%d/%d] %s\n%s\n\nWhy does the solution work? Press Enter to check.", i+1, len(due), c.ID, c.Front)
+		if !lines.Scan() {
+			break
+		}
+		fmt.Fprintf(out, "\n%s\n\n", c.Back)
+
+		q, ok := askQuality(lines, out)
+		if !ok {
+			break
+		}
+		next, err := rc.Review(q, time.Now())
+		if err != nil {
+			return err
+		}
+		schedule[rc.ID] = next
+		// Save after every card so quitting early loses nothing.
+		if err := saveState(statePath, schedule); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Next review in %d day(s).\n", next.Interval)
+	}
+	return lines.Err()
+}
+
+// askQuality reads a grade from 0 to 5. It returns false on "q" or at the
+// end of input.
+func askQuality(lines *bufio.Scanner, out io.Writer) (review.Quality, bool) {
+	for {
+		fmt.Fprint(out, "How well did you remember it? 0 (not at all) to 5 (perfectly), q to quit: ")
+		if !lines.Scan() {
+			return 0, false
+		}
+		answer := strings.TrimSpace(lines.Text())
+		if answer == "q" {
+			return 0, false
+		}
+		n, err := strconv.Atoi(answer)
+		if err == nil && n >= int(review.Blackout) && n <= int(review.Perfect) {
+			return review.Quality(n), true
+		}
+	}
+}
+
+func loadState(path string) (map[string]review.Card, error) {
+	schedule := make(map[string]review.Card)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return schedule, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cards []review.Card
+	if err := json.Unmarshal(data, &cards); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for _, c := range cards {
+		schedule[c.ID] = c
+	}
+	return schedule, nil
+}
+
+func saveState(path string, schedule map[string]review.Card) error {
+	cards := make([]review.Card, 0, len(schedule))
+	for _, c := range schedule {
+		cards = append(cards, c)
+	}
+	sort.Slice(cards, func(i, j int) bool { return cards[i].ID < cards[j].ID })
+	data, err := json.MarshalIndent(cards, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	// Write to a temporary file and rename it, so a crash mid-write never
+	// leaves a truncated state file behind.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
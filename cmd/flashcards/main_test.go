@@ -0,0 +1,77 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"learning-go/flashcards"
+)
+
+var cards = []flashcards.Card{
+	{ID: "chapter3/exercise1", Chapter: "chapter3", Front: "Declare a slice", Back: "a slice literal"},
+	{ID: "chapter3/exercise2", Chapter: "chapter3", Front: "Print a rune", Back: "index []rune(s)"},
+}
+
+func TestStudy(t *testing.T) {
+	state := filepath.Join(t.TempDir(), "flashcards.json")
+
+	// Remembered the first card; forgot the second after a grade out of
+	// range and one that is not a number, which are asked again.
+	var out strings.Builder
+	if err := study(strings.NewReader("\n4\n\n9\nx\n1\n"), &out, cards, state, 20); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"[1/2] chapter3/exercise1", "a slice literal", "[2/2] chapter3/exercise2", "index []rune(s)"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("study output lacks %q:\n%s", want, out.String())
+		}
+	}
+	if n := strings.Count(out.String(), "How well did you remember it?"); n != 4 {
+		t.Errorf("asked for a grade %d times, want 4:\n%s", n, out.String())
+	}
+
+	schedule, err := loadState(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := schedule["chapter3/exercise1"]; c.Repetitions != 1 || c.Interval != 1 {
+		t.Errorf("remembered card: %+v, want 1 repetition and a 1 day interval", c)
+	}
+	if c := schedule["chapter3/exercise2"]; c.Repetitions != 0 || c.Interval != 1 || c.Ease >= 2.5 {
+		t.Errorf("forgotten card: %+v, want no repetitions, a 1 day interval and a lower ease", c)
+	}
+
+	// Both are scheduled for tomorrow now.
+	out.Reset()
+	if err := study(strings.NewReader(""), &out, cards, state, 20); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(out.String(), "Nothing is due.") {
+		t.Errorf("second session = %q, want nothing due", out.String())
+	}
+}
+
+// Quitting keeps the grades given so far, and the limit caps a session.
+func TestStudyQuitAndLimit(t *testing.T) {
+	state := filepath.Join(t.TempDir(), "flashcards.json")
+	var out strings.Builder
+	if err := study(strings.NewReader("\n5\n\nq\n"), &out, cards, state, 20); err != nil {
+		t.Fatal(err)
+	}
+	schedule, err := loadState(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := schedule["chapter3/exercise2"]; len(schedule) != 1 || ok {
+		t.Errorf("state after quitting on the second card = %+v, want the first card only", schedule)
+	}
+
+	out.Reset()
+	if err := study(strings.NewReader("\n5\n"), &out, cards, filepath.Join(t.TempDir(), "s.json"), 1); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "[1/1]") || strings.Contains(out.String(), "exercise2") {
+		t.Errorf("session limited to one card:\n%s", out.String())
+	}
+}
@@ -0,0 +1,38 @@
+// Command genemployees writes a sample file of employee records as NDJSON,
+// one JSON object per line, for experimenting with the streaming decoder
+// of package chapter13/jsonstream. The records are made up but the same on
+// every run.
+//
+//	go run ./cmd/genemployees -n 100000 -o employees.ndjson
+package main
+
+import (
+	"flag"
+	"io"
+	"log"
+	"os"
+
+	"learning-go/chapter13/jsonstream"
+)
+
+func main() {
+	n := flag.Int("n", 100_000, "number of records to write")
+	output := flag.String("o", "", "write the records to this file instead of stdout")
+	flag.Parse()
+
+	log.SetFlags(0)
+	log.SetPrefix("genemployees: ")
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := jsonstream.Generate(w, *n); err != nil {
+		log.Fatal(err)
+	}
+}
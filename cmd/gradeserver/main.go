@@ -0,0 +1,109 @@
+// Command gradeserver serves the grading API from package grade. Clients
+// POST a solution and get back compile errors, missing constructs,
+// analyzer diagnostics, output, its differences from the exercise's
+// golden file and timing as JSON:
+//
+//	go run ./cmd/gradeserver -addr :8080 -root .
+//	curl -d '{"exercise":"chapter12/exercise1","files":{"main.go":"..."}}' localhost:8080/grade
+//
+// With -db, submissions that name a user are stored in a SQLite
+// leaderboard, served under /leaderboard (see package leaderboard) and
+// viewable in a terminal with cmd/leaderboard.
+//
+// Ctrl-C (SIGINT) or SIGTERM shuts the server down gracefully, letting
+// gradings in flight finish, and closes the leaderboard.
+//
+// Solutions run in the sandbox, which only blocks networking on Linux; see
+// package sandbox before exposing the server to people you do not trust.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"learning-go/chapter13/httpserver"
+	"learning-go/eventbus"
+	"learning-go/grade"
+	"learning-go/leaderboard"
+	"learning-go/sandbox"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8080", "address to listen on")
+	timeout := flag.Duration("timeout", 10*time.Second, "wall-clock limit per solution")
+	cpu := flag.Int("cpu", 5, "CPU seconds per solution")
+	mem := flag.Int64("mem", 256, "memory limit per solution, in MiB")
+	dbPath := flag.String("db", "", "SQLite leaderboard database (none if empty)")
+	root := flag.String("root", ".", "repository root, where the golden files are")
+	grace := flag.Duration("grace", 30*time.Second, "how long to wait for gradings in flight on shutdown")
+	flag.Parse()
+
+	log.SetFlags(0)
+	log.SetPrefix("gradeserver: ")
+
+	g := &grade.Grader{
+		Sandbox: sandbox.Config{
+			Timeout:     *timeout,
+			CPUSeconds:  *cpu,
+			MemoryBytes: *mem << 20,
+		},
+		Root: *root,
+	}
+	if err := serve(g, *addr, *dbPath, *grace); err != nil {
+		log.Fatal(err)
+	}
+	log.Print("stopped")
+}
+
+// serve runs the server until SIGINT or SIGTERM. It returns, rather than
+// exiting, so the leaderboard is closed on the way out.
+func serve(g *grade.Grader, addr, dbPath string, grace time.Duration) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	mux := http.NewServeMux()
+	mux.Handle("/grade", grade.Handler(g))
+	if dbPath != "" {
+		store, err := leaderboard.Open(dbPath)
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+		// Record submissions off the request path: the bus queues them
+		// for a single subscriber goroutine.
+		bus := eventbus.New(eventbus.WithMode(eventbus.Async))
+		defer bus.Close()
+		eventbus.Subscribe(bus, func(e grade.Graded) {
+			if e.Submission.User == "" {
+				return
+			}
+			err := store.Record(context.Background(), leaderboard.Entry{
+				User:     e.Submission.User,
+				Exercise: e.Submission.Exercise,
+				Passed:   e.Report.Passed,
+				Duration: e.Duration,
+			})
+			if err != nil {
+				log.Printf("recording %s for %s: %v", e.Submission.Exercise, e.Submission.User, err)
+			}
+		})
+		g.Events = bus
+		lb := leaderboard.Handler(store)
+		mux.Handle("/leaderboard", lb)
+		mux.Handle("/leaderboard/", lb)
+	}
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	log.Printf("listening on %s", ln.Addr())
+	return httpserver.Serve(ctx, ln, mux, grace)
+}
@@ -0,0 +1,100 @@
+// Command leaderboard shows the fastest correct solutions per exercise,
+// those whose output matched the golden file, from a grading server's
+// leaderboard database, refreshing in place:
+//
+//	go run ./cmd/leaderboard -db leaderboard.db
+//	go run ./cmd/leaderboard -db leaderboard.db -exercise chapter12/exercise1 -n 20
+//
+// Press Ctrl-C to quit; -once prints a single snapshot instead.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"learning-go/leaderboard"
+	"learning-go/report"
+)
+
+func main() {
+	dbPath := flag.String("db", "leaderboard.db", "SQLite leaderboard database")
+	exercise := flag.String("exercise", "", "show only this exercise")
+	n := flag.Int("n", 5, "entries per exercise")
+	every := flag.Duration("every", 2*time.Second, "refresh interval")
+	once := flag.Bool("once", false, "print once and exit")
+	flag.Parse()
+
+	log.SetFlags(0)
+	log.SetPrefix("leaderboard: ")
+
+	store, err := leaderboard.Open(*dbPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer store.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	for {
+		screen, err := render(ctx, store, *exercise, *n)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Fatal(err)
+		}
+		if *once {
+			fmt.Print(screen)
+			return
+		}
+		// Clear the screen and move the cursor home before redrawing.
+		fmt.Print("\x1b[H\x1b[2J", screen)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(*every):
+		}
+	}
+}
+
+func render(ctx context.Context, store *leaderboard.Store, only string, n int) (string, error) {
+	exercises := []string{only}
+	if only == "" {
+		var err error
+		if exercises, err = store.Exercises(ctx); err != nil {
+			return "", err
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Leaderboard  %s\n\n", time.Now().Format(time.TimeOnly))
+	if len(exercises) == 0 {
+		b.WriteString("No correct solutions yet.\n")
+	}
+	for _, ex := range exercises {
+		entries, err := store.Fastest(ctx, ex, n)
+		if err != nil {
+			return "", err
+		}
+		t := report.Table{
+			Title:   ex,
+			Headers: []string{"#", "user", "time", "when"},
+			Align:   []report.Align{report.Right, report.Left, report.Right, report.Left},
+		}
+		for i, e := range entries {
+			t.AddRow(i+1, e.User, e.Duration.Round(time.Microsecond), e.At.Format(time.DateTime))
+		}
+		if err := t.Render(&b); err != nil {
+			return "", err
+		}
+		b.WriteString("\n")
+	}
+	return b.String(), nil
+}
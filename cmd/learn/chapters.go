@@ -0,0 +1,37 @@
+package main
+
+// Every chapter package registers its exercises in init, so importing it
+// is all the runner needs. A new chapter is one more line here.
+import (
+	_ "learning-go/chapter10"
+	_ "learning-go/chapter11"
+	_ "learning-go/chapter12"
+	_ "learning-go/chapter12/aggregate"
+	_ "learning-go/chapter12/memorymodel"
+	_ "learning-go/chapter12/rpc"
+	_ "learning-go/chapter12/selectfairness"
+	_ "learning-go/chapter12/workerpool"
+	_ "learning-go/chapter13"
+	_ "learning-go/chapter13/csvio"
+	_ "learning-go/chapter13/httpclient"
+	_ "learning-go/chapter13/httpserver"
+	_ "learning-go/chapter13/jsonstream"
+	_ "learning-go/chapter13/scheduler"
+	_ "learning-go/chapter13/todo"
+	_ "learning-go/chapter14/context"
+	_ "learning-go/chapter15/db"
+	_ "learning-go/chapter16"
+	_ "learning-go/chapter16/reflection"
+	_ "learning-go/chapter17/chat"
+	_ "learning-go/chapter2"
+	_ "learning-go/chapter3"
+	_ "learning-go/chapter3/maps"
+	_ "learning-go/chapter3/runes"
+	_ "learning-go/chapter5"
+	_ "learning-go/chapter6"
+	_ "learning-go/chapter7"
+	_ "learning-go/chapter7/embedding"
+	_ "learning-go/chapter7/interfaces"
+	_ "learning-go/chapter8"
+	_ "learning-go/chapter9"
+)
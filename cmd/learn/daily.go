@@ -0,0 +1,136 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"learning-go/catalog"
+	"learning-go/config"
+	"learning-go/errs"
+	"learning-go/messages"
+	"learning-go/progress"
+	"learning-go/randsource"
+	"learning-go/registry"
+)
+
+// daily prints the exercise of the day: its prompt and hint,
+// and how to run it. learn has no watch mode, so it stops there.
+func daily(args []string, w io.Writer) error {
+	o, positional, err := parse("daily", args)
+	if err != nil {
+		return err
+	}
+	switch {
+	case len(positional) > 0:
+		return errs.Invalid("arguments", "daily takes no arguments")
+	case o.progressFile == "":
+		return errs.Invalid("progress-file", "must not be empty")
+	}
+	pr, err := progress.Load(o.progressFile)
+	if err != nil {
+		return err
+	}
+	user := dailyUser(o)
+	now := time.Now()
+	ex, ok := dailyExercise(pr, registry.All(), now, user)
+	if !ok {
+		fmt.Fprintln(w, "every exercise is done")
+		return nil
+	}
+
+	fmt.Fprintf(w, "Exercise of the day for %s, %s: %s\n", user, now.Format(time.DateOnly), ex.ID())
+	if found, err := catalog.Scan(o.root); err == nil {
+		for _, c := range found {
+			if c.Package+"/"+c.Function != ex.ID() {
+				continue
+			}
+			fmt.Fprintf(w, "\n%s\n", messages.Prompt(o.lang, ex.ID(), c.Description))
+			if hint, ok := messages.Hint(o.lang, ex.ID()); ok {
+				fmt.Fprintf(w, "\nHint: %s\n", hint)
+			} else if c.Hint != "" {
+				fmt.Fprintf(w, "\nHint: %s\n", c.Hint)
+			}
+		}
+	}
+	fmt.Fprintf(w, "\nRun it with: learn run %s --exercise %s\n", ex.Chapter, strings.TrimPrefix(ex.Name, "exercise"))
+	return nil
+}
+
+// dailyUser is --user, or else the user in the config file, or else the
+// login name from the environment.
+func dailyUser(o options) string {
+	if o.user != "" {
+		return o.user
+	}
+	if cfg, err := config.Load(o.config); err == nil && cfg.User != "" {
+		return cfg.User
+	}
+	return cmp.Or(os.Getenv("USER"), os.Getenv("USERNAME"), "learner")
+}
+
+// dailyExercise picks the exercise of the day for user from those that
+// are not done but whose prerequisites are. The pick is random, but
+// seeded by the date and user (randsource.Fixed), so it stays the same
+// all day and differs between learners. Easier exercises, and those that
+// other exercises build on, are more likely to come up.
+func dailyExercise(pr *progress.Progress, exercises []registry.Exercise, day time.Time, user string) (registry.Exercise, bool) {
+	byID := make(map[string]registry.Exercise, len(exercises))
+	unlocks := make(map[string]int)
+	for _, ex := range exercises {
+		byID[ex.ID()] = ex
+		if !pr.Exercises[ex.ID()].Done() {
+			for _, id := range ex.Requires {
+				unlocks[id]++
+			}
+		}
+	}
+
+	var candidates []registry.Exercise
+	var weights []int
+	total := 0
+	for _, ex := range exercises {
+		if pr.Exercises[ex.ID()].Done() || len(missingPrerequisites(ex, pr, nil)) > 0 {
+			continue
+		}
+		weight := (1 + unlocks[ex.ID()]) * 1000 / difficulty(ex, byID)
+		candidates = append(candidates, ex)
+		weights = append(weights, weight)
+		total += weight
+	}
+	if len(candidates) == 0 {
+		return registry.Exercise{}, false
+	}
+
+	n := randsource.Fixed("daily", day.Local().Format(time.DateOnly)+"/"+user).IntN(total)
+	for i, weight := range weights {
+		if n < weight {
+			return candidates[i], true
+		}
+		n -= weight
+	}
+	panic("unreachable")
+}
+
+// difficulty estimates how hard ex is from where it sits in the book: its
+// chapter number, plus the number of exercises it builds on, directly or
+// through others. It is at least 1.
+func difficulty(ex registry.Exercise, byID map[string]registry.Exercise) int {
+	number, _ := strconv.Atoi(strings.TrimPrefix(strings.SplitN(ex.Chapter, "/", 2)[0], "chapter"))
+	seen := make(map[string]bool)
+	var walk func(registry.Exercise)
+	walk = func(ex registry.Exercise) {
+		for _, id := range ex.Requires {
+			if !seen[id] {
+				seen[id] = true
+				walk(byID[id])
+			}
+		}
+	}
+	walk(ex)
+	return max(number, 1) + len(seen)
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"learning-go/progress"
+	"learning-go/registry"
+)
+
+var dailyExercises = []registry.Exercise{
+	{Chapter: "chapter1", Name: "exercise1"},
+	{Chapter: "chapter1", Name: "exercise2", Requires: []string{"chapter1/exercise1"}},
+	{Chapter: "chapter2", Name: "exercise1"},
+	{Chapter: "chapter9", Name: "exercise1", Requires: []string{"chapter1/exercise2"}},
+}
+
+func TestDailyExercise(t *testing.T) {
+	day := time.Date(2024, 3, 1, 9, 0, 0, 0, time.Local)
+	p := &progress.Progress{}
+
+	first, ok := dailyExercise(p, dailyExercises, day, "alice")
+	if !ok {
+		t.Fatal("no exercise picked")
+	}
+	if again, _ := dailyExercise(p, dailyExercises, day.Add(10*time.Hour), "alice"); again.ID() != first.ID() {
+		t.Errorf("the pick changed during the day: %s, then %s", first.ID(), again.ID())
+	}
+
+	// Over many days, only exercises whose prerequisites are done come up,
+	// and different users get different picks.
+	picked := make(map[string]int)
+	differ := false
+	for i := range 200 {
+		d := day.AddDate(0, 0, i)
+		ex, _ := dailyExercise(p, dailyExercises, d, "alice")
+		picked[ex.ID()]++
+		if other, _ := dailyExercise(p, dailyExercises, d, "bob"); other.ID() != ex.ID() {
+			differ = true
+		}
+	}
+	if picked["chapter1/exercise2"] > 0 || picked["chapter9/exercise1"] > 0 {
+		t.Errorf("picked exercises whose prerequisites are not done: %v", picked)
+	}
+	// chapter1/exercise1 is easier and unlocks another, so it comes up
+	// more often than chapter2/exercise1.
+	if picked["chapter1/exercise1"] <= picked["chapter2/exercise1"] {
+		t.Errorf("picks = %v, want chapter1/exercise1 most often", picked)
+	}
+	if !differ {
+		t.Error("alice and bob got the same pick every day")
+	}
+
+	for _, ex := range dailyExercises {
+		p.RecordRun(ex.ID(), day, true)
+	}
+	if ex, ok := dailyExercise(p, dailyExercises, day, "alice"); ok {
+		t.Errorf("picked %s with everything done", ex.ID())
+	}
+}
+
+func TestDifficulty(t *testing.T) {
+	byID := make(map[string]registry.Exercise)
+	for _, ex := range dailyExercises {
+		byID[ex.ID()] = ex
+	}
+	for i, want := range []int{1, 2, 2, 11} {
+		if got := difficulty(dailyExercises[i], byID); got != want {
+			t.Errorf("difficulty(%s) = %d, want %d", dailyExercises[i].ID(), got, want)
+		}
+	}
+}
+
+func TestDaily(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress.json")
+	args := []string{"--progress-file", path, "--user", "alice", "--root", t.TempDir()}
+	var first, second bytes.Buffer
+	if err := daily(args, &first); err != nil {
+		t.Fatal(err)
+	}
+	if err := daily(args, &second); err != nil {
+		t.Fatal(err)
+	}
+	if first.String() != second.String() {
+		t.Errorf("two runs on the same day differ:\n%s\n%s", first.String(), second.String())
+	}
+	if !strings.Contains(first.String(), "Exercise of the day for alice") || !strings.Contains(first.String(), "Run it with: learn run") {
+		t.Errorf("output = %q", first.String())
+	}
+}
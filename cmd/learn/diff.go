@@ -0,0 +1,111 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"learning-go/diff"
+	"learning-go/errs"
+	"learning-go/golden"
+	"learning-go/registry"
+	"learning-go/transcript"
+)
+
+// ANSI colors for diff lines.
+const (
+	red   = "\x1b[31m"
+	green = "\x1b[32m"
+	reset = "\x1b[0m"
+)
+
+// showDiff compares an exercise's latest transcript with its golden file
+// and prints the lines that differ, removed ones prefixed with "-" and
+// added ones with "+". It fails with errs.ErrOutputMismatch if they
+// differ.
+func showDiff(args []string, w io.Writer) error {
+	o, positional, err := parse("diff", args)
+	if err != nil {
+		return err
+	}
+	ex, err := lookupOne(o, positional)
+	if err != nil {
+		return err
+	}
+
+	path := golden.Path(o.root, ex)
+	want, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return errs.InExercise(ex.Chapter, ex.Name, golden.ErrNoGolden)
+	}
+	if err != nil {
+		return err
+	}
+	tr, err := transcript.Latest(filepath.Join(o.root, "transcripts"), ex.ID())
+	if err != nil {
+		return err
+	}
+
+	recorded := tr.Date.Local().Format("2006-01-02 15:04")
+	if tr.Commit != "" {
+		recorded += " at " + tr.Commit[:min(len(tr.Commit), 12)]
+	}
+	if tr.Output == string(want) {
+		fmt.Fprintf(w, "%s: the transcript of %s matches the golden file\n", ex.ID(), recorded)
+		return nil
+	}
+
+	color := useColor(w)
+	fmt.Fprintf(w, "--- %s\n+++ transcript of %s\n", path, recorded)
+	for _, e := range diff.Align(string(want), tr.Output) {
+		switch e.Op {
+		case diff.Equal:
+			fmt.Fprintln(w, " "+e.Line)
+		case diff.Delete:
+			fmt.Fprintln(w, paint(color, red, "-"+e.Line))
+		case diff.Insert:
+			fmt.Fprintln(w, paint(color, green, "+"+e.Line))
+		}
+	}
+	return errs.InExercise(ex.Chapter, ex.Name, errs.ErrOutputMismatch)
+}
+
+// lookupOne returns the one exercise named on the command line, either
+// as chapter.exercise ("3.1", "chapter12/rpc.2") or as a chapter and
+// --exercise.
+func lookupOne(o options, positional []string) (registry.Exercise, error) {
+	if len(positional) != 1 {
+		return registry.Exercise{}, errs.Invalid("arguments", "give one exercise, such as 3.1")
+	}
+	chapter, exercise := positional[0], o.exercise
+	if exercise == "" {
+		i := strings.LastIndex(chapter, ".")
+		if i < 0 {
+			return registry.Exercise{}, errs.Invalid("arguments", "give an exercise such as 3.1, or a chapter and --exercise")
+		}
+		chapter, exercise = chapter[:i], chapter[i+1:]
+	}
+	ex, err := registry.Lookup(chapter, exercise)
+	return ex, notFound(o.lang, positional[0], err)
+}
+
+// useColor reports whether w is a terminal, and colors are not turned off
+// with $NO_COLOR.
+func useColor(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok || os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func paint(color bool, code, s string) string {
+	if !color {
+		return s
+	}
+	return code + s + reset
+}
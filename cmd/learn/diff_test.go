@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"learning-go/errs"
+	"learning-go/transcript"
+)
+
+func TestShowDiff(t *testing.T) {
+	root := t.TempDir()
+	goldenPath := filepath.Join(root, "chapter99", "hang", "testdata", "exercise2.golden")
+	if err := os.MkdirAll(filepath.Dir(goldenPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(goldenPath, []byte("first\nnext exercise\nlast\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	err := showDiff([]string{"chapter99/hang.2", "--root", root}, &out)
+	if !errors.Is(err, transcript.ErrNone) {
+		t.Fatalf("diff without a transcript = %v, want ErrNone", err)
+	}
+
+	dir := filepath.Join(root, "transcripts")
+	save := func(output string, at time.Time) {
+		t.Helper()
+		_, err := transcript.Save(dir, transcript.Transcript{Exercise: "chapter99/hang/exercise2", Date: at, Output: output, Commit: "0123456789abcdef"})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	now := time.Now()
+	save("first\nnext exercise\nlast\n", now.Add(-time.Hour))
+	save("first\nextra\nnext exercise\n", now)
+
+	out.Reset()
+	err = showDiff([]string{"chapter99/hang", "--exercise", "2", "--root", root}, &out)
+	if !errors.Is(err, errs.ErrOutputMismatch) {
+		t.Errorf("diff of a differing transcript = %v, want ErrOutputMismatch", err)
+	}
+	got := out.String()
+	wantLines := []string{"--- " + goldenPath, "+++ transcript of ", " at 0123456789ab\n", " first\n+extra\n next exercise\n-last\n"}
+	for _, want := range wantLines {
+		if !strings.Contains(got, want) {
+			t.Errorf("diff output lacks %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "\x1b[") {
+		t.Errorf("colored output to a buffer:\n%q", got)
+	}
+
+	save("first\nnext exercise\nlast\n", now.Add(time.Minute))
+	out.Reset()
+	if err := showDiff([]string{"chapter99/hang.2", "--root", root}, &out); err != nil || !strings.Contains(out.String(), "matches the golden file") {
+		t.Errorf("diff of a matching transcript = %v, output %q", err, out.String())
+	}
+}
+
+func TestLookupOne(t *testing.T) {
+	for _, args := range [][]string{{"chapter99/hang.1"}, {"99/hang.exercise1"}, {"chapter99/hang", "--exercise", "1"}} {
+		o, positional, err := parse("diff", args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ex, err := lookupOne(o, positional)
+		if err != nil || ex.ID() != "chapter99/hang/exercise1" {
+			t.Errorf("lookupOne(%q) = %s, %v", args, ex.ID(), err)
+		}
+	}
+	for _, args := range [][]string{{}, {"chapter99/hang"}, {"chapter99/hang.1", "chapter99/hang.2"}} {
+		o, positional, _ := parse("diff", args)
+		if _, err := lookupOne(o, positional); errs.ExitCode(err) != errs.ExitUsage {
+			t.Errorf("lookupOne(%q) = %v, want a usage error", args, err)
+		}
+	}
+	o, positional, _ := parse("diff", []string{"chapter99/hang.7"})
+	if _, err := lookupOne(o, positional); !errors.Is(err, errs.ErrExerciseNotFound) {
+		t.Errorf("lookupOne of a missing exercise = %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+//go:build unix
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"learning-go/registry"
+)
+
+// syncBuffer is written by the runner and the dump goroutine at once.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+var stderrForDump syncBuffer
+
+func init() {
+	// An exercise that blocks on a channel, asks for a dump the way a
+	// learner pressing Ctrl-\ would, and waits until the dump is out.
+	registry.Register("chapter99/dump", "exercise1", func(w io.Writer) {
+		stuck := make(chan int)
+		go func() { <-stuck }()
+		syscall.Kill(syscall.Getpid(), syscall.SIGQUIT)
+		for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+			if strings.Contains(stderrForDump.String(), "[chan receive]") {
+				break
+			}
+		}
+		close(stuck)
+	})
+}
+
+func TestRunDumpsOnSIGQUIT(t *testing.T) {
+	err := run([]string{"run", "chapter99/dump", "--progress-file", ""}, io.Discard, &stderrForDump)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := stderrForDump.String()
+	if !strings.Contains(out, "goroutines\n") || !strings.Contains(out, "[chan receive]") {
+		t.Errorf("no grouped goroutine dump on stderr:\n%s", out)
+	}
+}
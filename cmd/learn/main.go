@@ -0,0 +1,738 @@
+// Command learn runs the book's exercises. Every chapter package registers
+// its exercises with package registry, and learn picks them by name:
+//
+//	go run ./cmd/learn list                          # every exercise
+//	go run ./cmd/learn list chapter12                # one chapter
+//	go run ./cmd/learn run chapter3                  # all of chapter 3
+//	go run ./cmd/learn run chapter3 --exercise 2     # just one
+//	go run ./cmd/learn run chapter12/rpc
+//	go run ./cmd/learn run --all
+//	go run ./cmd/learn check chapter3                # compare with golden files
+//	go run ./cmd/learn check --all --update          # record golden files
+//	go run ./cmd/learn progress                      # exercises done so far
+//	go run ./cmd/learn run 3 --exercise 1 --record   # keep a transcript
+//	go run ./cmd/learn diff 3.1                      # compare it with the golden file
+//
+// Chapters can be written as "chapter3" or "3". Each exercise runs in
+// isolation: a panic is reported as a failure, with the exercise's hint
+// if it has one, and the remaining exercises still run. Headers and the
+// exercises' own output go to stdout; pass/fail lines and the summary go
+// to stderr, so the output of two runs can be compared directly.
+//
+// Exercises write to the io.Writer they are given; run passes os.Stdout,
+// and check captures the output and compares it with the exercise's
+// golden file (package golden). Exercises whose output changes from run
+// to run have no golden file and are skipped.
+//
+// Titles are read from the exercises' doc comments (package catalog) when
+// the source is available under --root. Messages are printed in the
+// language chosen by --lang or $LANG (package messages).
+//
+// run and check record each exercise they run, and whether check found
+// its output matching, in the file named by --progress-file (package
+// progress), by default under the user's config directory; an empty
+// --progress-file records nothing. learn progress prints how many
+// exercises of each chapter are done, and learn progress --reset starts
+// over. learn progress export --format json|csv writes the progress to
+// stdout, to move it to another machine or share it with a mentor, and
+// learn progress import file merges such an export into the progress
+// file, keeping the newer record of each exercise.
+//
+// run gives each exercise --timeout (a minute by default; 0 for no limit)
+// to return. An exercise that takes longer is reported as timed out and
+// abandoned: its goroutines keep running, but nothing more of its output
+// is printed, and the remaining exercises still run. --dump-on-timeout
+// prints every goroutine's stack first, to show where it hung. An
+// interrupt (Ctrl-C) abandons the current exercise and stops the run.
+//
+// run --record also saves what each exercise printed, with the date, the
+// time it took and the git commit, as a transcript under
+// <root>/transcripts (package transcript): a history of attempts.
+//
+// learn diff 3.1 compares the latest transcript of chapter 3's exercise 1
+// with its golden file and prints the lines that differ, in color on a
+// terminal.
+//
+// learn stats prints a dashboard from the same file: completion bars per
+// chapter, the streak of days with practice, the average time of an
+// attempt and the exercises that failed most often.
+//
+// learn daily picks the exercise of the day: one that is not done but
+// whose prerequisites are, at random but seeded by the date and --user
+// (by default the config file's user or $USER), so it stays the same all
+// day. Easier exercises and those that unlock others come up more often.
+// It prints the exercise's description and the command that runs it;
+// there is no watch mode to start.
+//
+// learn remind posts a reminder to practise, with the exercise of the day
+// and the current streak, to the Slack webhook or Telegram chat set up in
+// the file named by --config (package config), by default config.json
+// beside the default progress file. Run it daily from cron.
+//
+// learn review lists the done exercises due for a spaced-repetition review
+// (package review), and learn review 3.2 --grade 4 records how well the
+// learner remembered one, from 0 to 5, to schedule the next. The schedule
+// is kept in the progress file.
+//
+// While run is running exercises, SIGQUIT (Ctrl-\ in a terminal) prints
+// every goroutine's stack to stderr, grouped by state (package stackdump),
+// instead of killing learn: the way to find out where a hung concurrency
+// exercise is stuck.
+//
+// list, run and check also take --exercises dir, a directory of the
+// learner's own programs laid out as dir/chapter3/exercise7.go (package
+// provider). They are added to the compiled-in exercises and run with go
+// run; a name that is already taken is an error. --exercism dir does the
+// same for an Exercism track: its exercises are listed as chapter0/<track>
+// and running one runs its tests.
+//
+// learn repl reads Go statements and expressions from stdin and runs each
+// after the ones before, printing the values of expressions. The
+// repository's data structures and concurrency helpers are there without
+// imports, so l := list.New[int](1, 2) and then l.Len() prints 2. Each
+// snippet is compiled and run with go run, so it takes the go command and
+// a moment per line.
+//
+// Exercises may declare prerequisites (registry.Requires). run puts them
+// first when they are part of the same run, and warns about an exercise
+// whose prerequisites are neither done according to the progress file nor
+// passed earlier in the run; with --strict it skips that exercise instead.
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"learning-go/catalog"
+	"learning-go/errs"
+	"learning-go/golden"
+	"learning-go/messages"
+	"learning-go/progress"
+	"learning-go/provider"
+	"learning-go/registry"
+	"learning-go/report"
+	"learning-go/safe"
+	"learning-go/stackdump"
+	"learning-go/transcript"
+)
+
+const usage = `usage:
+  learn list [chapter] [--exercises dir] [--exercism dir] [--lang code]
+  learn run chapter [--exercise N] [--strict] [--timeout d] [--dump-on-timeout] [--record] [--exercises dir] [--exercism dir] [--lang code]
+  learn run --all [--strict] [--timeout d] [--dump-on-timeout] [--record] [--exercises dir] [--exercism dir] [--lang code]
+  learn check [chapter [--exercise N] | --all] [--update] [--exercises dir] [--exercism dir]
+  learn progress [--reset]
+  learn progress export [--format json|csv] | learn progress import file [--format json|csv]
+  learn diff chapter.N | chapter --exercise N
+  learn stats
+  learn review [--limit n] | learn review chapter.N --grade 0-5
+  learn daily [--user name] [--config path]
+  learn remind [--config path]
+  learn repl
+
+run, check, progress, stats, review, daily and remind take --progress-file path (empty to disable).
+`
+
+func main() {
+	err := run(os.Args[1:], os.Stdout, os.Stderr)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "learn:", err)
+		if errs.ExitCode(err) == errs.ExitUsage {
+			fmt.Fprint(os.Stderr, usage)
+		}
+	}
+	os.Exit(errs.ExitCode(err))
+}
+
+func run(args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		return errs.Invalid("command", "missing; use list, run, check, progress, stats, diff, review, daily, remind or repl")
+	}
+	switch args[0] {
+	case "list":
+		return list(args[1:], stdout)
+	case "run":
+		return runExercises(args[1:], stdout, stderr)
+	case "check":
+		return check(args[1:], stdout, stderr)
+	case "progress":
+		return showProgress(args[1:], stdout)
+	case "stats":
+		return showStats(args[1:], stdout)
+	case "diff":
+		return showDiff(args[1:], stdout)
+	case "review":
+		return showReview(args[1:], stdout)
+	case "daily":
+		return daily(args[1:], stdout)
+	case "remind":
+		return remind(args[1:], stdout)
+	case "repl":
+		return repl(args[1:], os.Stdin, stdout)
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, usage)
+		return nil
+	}
+	return errs.Invalid("command", "unknown command %q", args[0])
+}
+
+// options are the flags of every command; parse defines the ones each takes.
+type options struct {
+	exercise string
+	all      bool
+	update   bool
+	lang     string
+	root     string
+	// progressFile is where run and check record progress; empty records
+	// nothing.
+	progressFile string
+	reset        bool
+	strict       bool
+	// timeout is how long run waits for each exercise; zero is forever.
+	timeout       time.Duration
+	dumpOnTimeout bool
+	record        bool
+	// grade is review's --grade, or -1 to list the due exercises.
+	grade int
+	limit int
+	// format is progress export's and import's --format; import guesses
+	// it from the file name if it is empty.
+	format string
+	// config is the settings file of daily and remind (package config).
+	config string
+	user   string
+	// exercisesDir holds the learner's own exercises and exercismDir an
+	// Exercism track (package provider); empty for none.
+	exercisesDir string
+	exercismDir  string
+}
+
+// parse parses flags that may appear before or after the positional
+// arguments ("run chapter3 --exercise 2" as well as "run --exercise 2
+// chapter3"), which the flag package alone does not allow.
+func parse(name string, args []string) (options, []string, error) {
+	var o options
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	if name == "run" || name == "check" || name == "diff" || name == "review" {
+		fs.StringVar(&o.exercise, "exercise", "", "run only this exercise, e.g. 2")
+	}
+	if name == "run" || name == "check" {
+		fs.BoolVar(&o.all, "all", false, "run every exercise of every chapter")
+	}
+	if name == "run" {
+		fs.BoolVar(&o.strict, "strict", false, "skip exercises whose prerequisites are not done")
+		fs.DurationVar(&o.timeout, "timeout", time.Minute, "give up on an exercise after this long (0 for no limit)")
+		fs.BoolVar(&o.dumpOnTimeout, "dump-on-timeout", false, "print every goroutine's stack when an exercise times out")
+		fs.BoolVar(&o.record, "record", false, "save each exercise's output as a transcript")
+	}
+	if name == "list" || name == "run" || name == "check" {
+		fs.StringVar(&o.exercisesDir, "exercises", "", "directory of your own exercises, run with go run")
+		fs.StringVar(&o.exercismDir, "exercism", "", "Exercism track whose exercises to add")
+	}
+	if name == "check" {
+		fs.BoolVar(&o.update, "update", false, "record the output as the golden file")
+	}
+	if name == "run" || name == "check" || name == "progress" || name == "stats" || name == "review" || name == "daily" || name == "remind" {
+		fs.StringVar(&o.progressFile, "progress-file", progress.DefaultPath(), "file recording the exercises run (empty to disable)")
+	}
+	if name == "review" {
+		fs.IntVar(&o.grade, "grade", -1, "how well you remembered the exercise, from 0 to 5")
+		fs.IntVar(&o.limit, "limit", 10, "list at most this many exercises (0 for all)")
+	}
+	if name == "daily" {
+		fs.StringVar(&o.user, "user", "", "whose exercise of the day to pick (default from the config file or $USER)")
+	}
+	if name == "daily" || name == "remind" {
+		fs.StringVar(&o.config, "config", defaultConfigPath(), "settings file naming the chat services to post to")
+	}
+	if name == "progress" {
+		fs.BoolVar(&o.reset, "reset", false, "delete the recorded progress")
+		fs.StringVar(&o.format, "format", "", "format of an export: json or csv")
+	}
+	fs.StringVar(&o.lang, "lang", "", "language for messages (default from $LANG)")
+	fs.StringVar(&o.root, "root", ".", "repository root, for exercise titles and golden files")
+
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return o, nil, errs.Invalid("flags", "%v", err)
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	o.lang = messages.Lang(o.lang)
+	return o, positional, nil
+}
+
+func list(args []string, w io.Writer) error {
+	o, positional, err := parse("list", args)
+	if err != nil {
+		return err
+	}
+	if err := addProvided(o); err != nil {
+		return err
+	}
+	if len(positional) > 1 {
+		return errs.Invalid("arguments", "list takes at most one chapter")
+	}
+
+	exercises := registry.All()
+	if len(positional) == 1 {
+		if exercises, err = registry.Chapter(positional[0]); err != nil {
+			return notFound(o.lang, positional[0], err)
+		}
+	}
+	titles := loadTitles(o.root, o.lang)
+	t := report.Table{Headers: []string{"Exercise", "Title"}}
+	for _, ex := range exercises {
+		t.AddRow(ex.ID(), cmp.Or(titles[ex.ID()], ex.Title))
+	}
+	return t.Render(w)
+}
+
+func runExercises(args []string, stdout, stderr io.Writer) error {
+	o, positional, err := parse("run", args)
+	if err != nil {
+		return err
+	}
+	if err := addProvided(o); err != nil {
+		return err
+	}
+	exercises, err := selectExercises(o, positional)
+	if err != nil {
+		return err
+	}
+	if exercises, err = registry.Order(exercises); err != nil {
+		return err
+	}
+	recorded := &progress.Progress{}
+	if o.progressFile != "" {
+		if recorded, err = progress.Load(o.progressFile); err != nil {
+			return err
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	stackdump.OnSignal(ctx, stderr, syscall.SIGQUIT)
+
+	p := messages.Printer(o.lang)
+	titles := loadTitles(o.root, o.lang)
+	passed := 0
+	ran := make(map[string]bool)           // passed, by exercise ID
+	took := make(map[string]time.Duration) // by exercise ID
+	var commit string
+	if o.record {
+		commit = transcript.Commit(o.root)
+	}
+	defer func() {
+		recordProgress(o.progressFile, stderr, func(pr *progress.Progress, now time.Time) {
+			for id, ok := range ran {
+				pr.RecordRun(id, now, ok)
+				pr.RecordTime(id, took[id])
+			}
+		})
+	}()
+	for _, ex := range exercises {
+		// Without a progress file nothing is known to be done, so
+		// prerequisites are not checked.
+		if missing := missingPrerequisites(ex, recorded, ran); o.progressFile != "" && len(missing) > 0 {
+			if o.strict {
+				fmt.Fprintln(stderr, p.Sprintf(messages.RunBlocked, ex.ID(), strings.Join(missing, ", ")))
+				continue
+			}
+			fmt.Fprintln(stderr, p.Sprintf(messages.RunPrereq, ex.ID(), strings.Join(missing, ", ")))
+		}
+		fmt.Fprintln(stdout, p.Sprintf(messages.RunHeader,
+			strings.TrimPrefix(ex.Chapter, "chapter"), ex.Number, cmp.Or(titles[ex.ID()], ex.Title)))
+		start := time.Now()
+		var output bytes.Buffer
+		w := stdout
+		if o.record {
+			// runOne serializes the writes, so the buffer needs no lock.
+			w = io.MultiWriter(stdout, &output)
+		}
+		err := runOne(ctx, ex, w, o.timeout)
+		if errors.Is(err, context.Canceled) {
+			fmt.Fprintln(stderr, p.Sprintf(messages.RunFail, ex.ID(), "interrupted"))
+			break
+		}
+		if errors.Is(err, errTimeout) && o.dumpOnTimeout {
+			stackdump.Write(stderr, stackdump.Capture(), true)
+		}
+		ran[ex.ID()] = err == nil
+		took[ex.ID()] = time.Since(start)
+		if o.record {
+			_, saveErr := transcript.Save(filepath.Join(o.root, "transcripts"), transcript.Transcript{
+				Exercise: ex.ID(),
+				Date:     start,
+				Duration: time.Since(start),
+				Commit:   commit,
+				Passed:   err == nil,
+				Output:   output.String(),
+			})
+			if saveErr != nil {
+				fmt.Fprintln(stderr, "learn: recording transcript:", saveErr)
+			}
+		}
+		if err != nil {
+			fmt.Fprintln(stderr, p.Sprintf(messages.RunFail, ex.ID(), err))
+			if hint, ok := messages.Hint(o.lang, ex.ID()); ok {
+				fmt.Fprintln(stderr, p.Sprintf(messages.RunHint, hint))
+			}
+		} else {
+			passed++
+			fmt.Fprintln(stderr, p.Sprintf(messages.RunPass, ex.ID(), time.Since(start).Round(time.Millisecond)))
+		}
+		fmt.Fprintln(stdout)
+	}
+	if len(exercises) > 1 {
+		fmt.Fprintln(stderr, p.Sprintf(messages.RunSummary, passed, len(exercises)))
+	}
+	if passed < len(exercises) {
+		return fmt.Errorf("%d of %d exercises failed", len(exercises)-passed, len(exercises))
+	}
+	return nil
+}
+
+// errTimeout is the error of an exercise that did not return in time.
+var errTimeout = errors.New("timed out")
+
+// runOne runs ex, turning a panic into an error. It gives up when ctx is
+// done or after timeout, if that is not zero, and then returns ctx's error
+// or one wrapping errTimeout. A goroutine cannot be stopped from outside,
+// so the exercise is abandoned rather than killed; whatever it writes from
+// then on is dropped.
+func runOne(ctx context.Context, ex registry.Exercise, w io.Writer, timeout time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	gate := &gateWriter{w: w}
+	done := make(chan error, 1)
+	go func() {
+		done <- safe.SafeCall(func() error {
+			ex.Run(gate)
+			return nil
+		})
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		gate.close()
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("%w after %v", errTimeout, timeout)
+		}
+		return ctx.Err()
+	}
+}
+
+// gateWriter passes writes on to w until it is closed, and drops them
+// after.
+type gateWriter struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closed bool
+}
+
+func (g *gateWriter) Write(p []byte) (int, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.closed {
+		return len(p), nil
+	}
+	return g.w.Write(p)
+}
+
+func (g *gateWriter) close() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.closed = true
+}
+
+// missingPrerequisites returns the prerequisites of ex that are neither
+// done according to recorded nor passed earlier in this run.
+func missingPrerequisites(ex registry.Exercise, recorded *progress.Progress, ran map[string]bool) []string {
+	var missing []string
+	for _, id := range ex.Requires {
+		if !ran[id] && !recorded.Exercises[id].Done() {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
+
+// addProvided registers the exercises under --exercises and of the
+// --exercism track, if they were given.
+func addProvided(o options) error {
+	if o.exercisesDir != "" {
+		if err := registry.Add(provider.Dir(o.exercisesDir)); err != nil {
+			return err
+		}
+	}
+	if o.exercismDir != "" {
+		return registry.Add(provider.Exercism{Dir: o.exercismDir})
+	}
+	return nil
+}
+
+// selectExercises returns the exercises named on the command line of run
+// and check: one chapter, one exercise of it, or --all.
+func selectExercises(o options, positional []string) ([]registry.Exercise, error) {
+	switch {
+	case o.all && (len(positional) > 0 || o.exercise != ""):
+		return nil, errs.Invalid("arguments", "--all cannot be combined with a chapter or --exercise")
+	case o.all:
+		return registry.All(), nil
+	case len(positional) != 1:
+		return nil, errs.Invalid("arguments", "give one chapter, or --all")
+	case o.exercise != "":
+		ex, err := registry.Lookup(positional[0], o.exercise)
+		if err != nil {
+			return nil, notFound(o.lang, positional[0], err)
+		}
+		return []registry.Exercise{ex}, nil
+	}
+	list, err := registry.Chapter(positional[0])
+	return list, notFound(o.lang, positional[0], err)
+}
+
+// notFound replaces the text of an errs.ErrExerciseNotFound from package
+// registry with messages.RunNotFound in lang, naming the exercise or, if
+// the error does not say which, name. Other errors are returned as is.
+func notFound(lang, name string, err error) error {
+	if !errors.Is(err, errs.ErrExerciseNotFound) {
+		return err
+	}
+	var exErr *errs.ExerciseError
+	if errors.As(err, &exErr) {
+		name = exErr.Chapter + "/" + exErr.Exercise
+	}
+	return &translatedError{text: messages.Printer(lang).Sprintf(messages.RunNotFound, name), err: err}
+}
+
+// translatedError is err with its text in the user's language.
+type translatedError struct {
+	text string
+	err  error
+}
+
+func (e *translatedError) Error() string { return e.text }
+
+// Unwrap keeps errs.ExitCode and errors.Is working on the original error.
+func (e *translatedError) Unwrap() error { return e.err }
+
+// check compares exercises with their golden files, or records them with
+// --update. It fails with errs.ErrOutputMismatch if any output differs.
+func check(args []string, w, stderr io.Writer) error {
+	o, positional, err := parse("check", args)
+	if err != nil {
+		return err
+	}
+	if err := addProvided(o); err != nil {
+		return err
+	}
+	exercises, err := selectExercises(o, positional)
+	if err != nil {
+		return err
+	}
+
+	p := messages.Printer(o.lang)
+	var failed []error
+	checked := make(map[string]error) // check's result, by exercise ID
+	if !o.update {
+		defer func() {
+			recordProgress(o.progressFile, stderr, func(pr *progress.Progress, now time.Time) {
+				for id, err := range checked {
+					var panicErr *safe.PanicError
+					switch {
+					case err == nil:
+						pr.RecordCheck(id, now, progress.GoldenPass)
+					case errors.Is(err, errs.ErrOutputMismatch):
+						pr.RecordCheck(id, now, progress.GoldenFail)
+					case errors.As(err, &panicErr):
+						pr.RecordRun(id, now, false)
+					}
+				}
+			})
+		}()
+	}
+	for _, ex := range exercises {
+		if o.update {
+			err = golden.Update(o.root, ex)
+		} else {
+			err = golden.Check(o.root, ex)
+			checked[ex.ID()] = err
+		}
+		switch {
+		case errors.Is(err, golden.ErrNoGolden), errors.Is(err, golden.ErrNondeterministic), errors.Is(err, golden.ErrHostDependent):
+			fmt.Fprintln(w, p.Sprintf(messages.CheckSkip, ex.ID(), reason(err)))
+		case err != nil:
+			failed = append(failed, err)
+			fmt.Fprintln(w, p.Sprintf(messages.RunFail, ex.ID(), reason(err)))
+			// Every differing line, not just the first, from the golden
+			// file's version to this run's.
+			var mismatch *golden.MismatchError
+			if errors.As(err, &mismatch) && len(mismatch.Changes) > 1 {
+				for _, c := range mismatch.Changes {
+					fmt.Fprintln(w, "    "+c.String())
+				}
+			}
+		default:
+			fmt.Fprintln(w, p.Sprintf(messages.CheckOK, ex.ID()))
+		}
+	}
+	return errors.Join(failed...)
+}
+
+// showProgress prints how many exercises of each chapter are done,
+// deletes the recorded progress with --reset, or exports or imports it.
+func showProgress(args []string, w io.Writer) error {
+	o, positional, err := parse("progress", args)
+	if err != nil {
+		return err
+	}
+	switch {
+	case o.progressFile == "":
+		return errs.Invalid("progress-file", "must not be empty")
+	case len(positional) > 0 && positional[0] == "export":
+		return exportProgress(o, positional[1:], w)
+	case len(positional) > 0 && positional[0] == "import":
+		return importProgress(o, positional[1:], w)
+	case len(positional) > 0:
+		return errs.Invalid("arguments", "unknown progress command %q; use export or import", positional[0])
+	case o.reset:
+		if err := progress.Reset(o.progressFile); err != nil {
+			return err
+		}
+		fmt.Fprintln(w, "progress reset")
+		return nil
+	}
+
+	pr, err := progress.Load(o.progressFile)
+	if err != nil {
+		return err
+	}
+	chapters, total := progress.Summarize(pr, registry.All())
+	t := report.Table{
+		Headers: []string{"Chapter", "Done", "Total", "%"},
+		Align:   []report.Align{report.Left, report.Right, report.Right, report.Right},
+	}
+	for _, c := range chapters {
+		t.AddRow(c.Chapter, c.Done, c.Total, c.Percent())
+	}
+	if err := t.Render(w); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "%d of %d exercises done (%d%%)\n", total.Done, total.Total, total.Percent())
+	return nil
+}
+
+// exportProgress writes the progress file to w in --format, JSON by
+// default.
+func exportProgress(o options, positional []string, w io.Writer) error {
+	if len(positional) > 0 {
+		return errs.Invalid("arguments", "export takes no arguments; it writes to stdout")
+	}
+	pr, err := progress.Load(o.progressFile)
+	if err != nil {
+		return err
+	}
+	return progress.Export(w, pr, progress.Format(cmp.Or(o.format, string(progress.JSON))))
+}
+
+// importProgress merges the export named on the command line into the
+// progress file. Without --format, a .csv file is read as CSV and
+// anything else as JSON.
+func importProgress(o options, positional []string, w io.Writer) error {
+	if len(positional) != 1 {
+		return errs.Invalid("arguments", "give the file to import")
+	}
+	format := progress.Format(o.format)
+	if format == "" {
+		format = progress.JSON
+		if strings.EqualFold(filepath.Ext(positional[0]), ".csv") {
+			format = progress.CSV
+		}
+	}
+	f, err := os.Open(positional[0])
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	from, err := progress.Import(f, format)
+	if err != nil {
+		return errs.Wrap(err, "importing %s", positional[0])
+	}
+	pr, err := progress.Load(o.progressFile)
+	if err != nil {
+		return err
+	}
+	pr.Merge(from)
+	if err := progress.Save(o.progressFile, pr); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "imported %d exercise(s) from %s\n", len(from.Exercises), positional[0])
+	return nil
+}
+
+// recordProgress loads the progress file, lets record add to it and saves
+// it again. Nothing is recorded if path is empty. A failure is reported on
+// stderr but does not fail the command, since the exercises did run.
+func recordProgress(path string, stderr io.Writer, record func(p *progress.Progress, now time.Time)) {
+	if path == "" {
+		return
+	}
+	p, err := progress.Load(path)
+	if err == nil {
+		record(p, time.Now())
+		err = progress.Save(path, p)
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, "learn: recording progress:", err)
+	}
+}
+
+// reason strips the exercise name from err, for lines that already start
+// with it.
+func reason(err error) error {
+	var exErr *errs.ExerciseError
+	if errors.As(err, &exErr) {
+		return exErr.Err
+	}
+	return err
+}
+
+// loadTitles returns every exercise's title by ID, translated to lang
+// where a translation exists. Without the source (learn was started
+// outside the repository) the map is empty and titles are left blank.
+func loadTitles(root, lang string) map[string]string {
+	titles := make(map[string]string)
+	found, err := catalog.Scan(root)
+	if err != nil {
+		return titles
+	}
+	for _, ex := range found {
+		id := ex.Package + "/" + ex.Function
+		titles[id] = messages.Prompt(lang, id, ex.Title)
+	}
+	return titles
+}
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"learning-go/errs"
+	"learning-go/progress"
+	"learning-go/registry"
+	"learning-go/transcript"
+)
+
+// TestPrerequisites checks the declarations of every chapter: each names a
+// registered exercise, and none points forward, so ordering --all by
+// prerequisites keeps registry order.
+func TestPrerequisites(t *testing.T) {
+	all := registry.All()
+	known := make(map[string]bool)
+	for _, ex := range all {
+		known[ex.ID()] = true
+	}
+	for _, ex := range all {
+		for _, id := range ex.Requires {
+			if !known[id] {
+				t.Errorf("%s requires %s, which is not registered", ex.ID(), id)
+			}
+		}
+	}
+	ordered, err := registry.Order(all)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range all {
+		if ordered[i].ID() != all[i].ID() {
+			t.Errorf("ordering by prerequisites moved %s to position %d", ordered[i].ID(), i)
+			break
+		}
+	}
+}
+
+func TestMissingPrerequisites(t *testing.T) {
+	ex := registry.Exercise{Chapter: "chapter2", Name: "exercise1",
+		Requires: []string{"chapter1/exercise1", "chapter1/exercise2", "chapter1/exercise3"}}
+	recorded := &progress.Progress{}
+	recorded.RecordRun("chapter1/exercise1", time.Now(), true)
+	recorded.RecordCheck("chapter1/exercise2", time.Now(), progress.GoldenFail)
+	ran := map[string]bool{"chapter1/exercise3": true}
+
+	got := missingPrerequisites(ex, recorded, ran)
+	if want := []string{"chapter1/exercise2"}; !slices.Equal(got, want) {
+		t.Errorf("missingPrerequisites = %v, want %v", got, want)
+	}
+}
+
+func TestRunStrict(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress.json")
+	var stdout, stderr bytes.Buffer
+	err := run([]string{"run", "chapter12/workerpool", "--exercise", "2", "--strict", "--lang", "en", "--progress-file", path}, &stdout, &stderr)
+	if err == nil {
+		t.Fatal("run --strict succeeded without the prerequisite done")
+	}
+	if !strings.Contains(stderr.String(), "SKIP chapter12/workerpool/exercise2: do chapter12/workerpool/exercise1 first") {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("a blocked exercise printed %q", stdout.String())
+	}
+	p, err := progress.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Exercises) != 0 {
+		t.Errorf("a blocked exercise was recorded: %v", p.Exercises)
+	}
+}
+
+// release lets the hanging exercise below return.
+var release = make(chan struct{})
+
+func init() {
+	registry.Register("chapter99/hang", "exercise1", func(w io.Writer) {
+		fmt.Fprintln(w, "before")
+		<-release
+		fmt.Fprintln(w, "after the timeout")
+	})
+	registry.Register("chapter99/hang", "exercise2", func(w io.Writer) {
+		fmt.Fprintln(w, "next exercise")
+	})
+}
+
+func TestRunTimeout(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	err := run([]string{"run", "chapter99/hang", "--timeout", "50ms", "--dump-on-timeout",
+		"--lang", "en", "--progress-file", ""}, &stdout, &stderr)
+	if err == nil {
+		t.Error("run succeeded with an exercise timing out")
+	}
+	close(release)
+	time.Sleep(10 * time.Millisecond) // let the abandoned exercise write
+
+	out, errOut := stdout.String(), stderr.String()
+	if !strings.Contains(errOut, "FAIL chapter99/hang/exercise1: timed out after 50ms") {
+		t.Errorf("stderr does not report the timeout:\n%s", errOut)
+	}
+	if !strings.Contains(errOut, "[chan receive]") {
+		t.Errorf("--dump-on-timeout did not dump the blocked goroutine:\n%s", errOut)
+	}
+	if !strings.Contains(out, "before") || !strings.Contains(out, "next exercise") {
+		t.Errorf("stdout = %q, want the first exercise's output and the next exercise", out)
+	}
+	if strings.Contains(out, "after the timeout") {
+		t.Errorf("an abandoned exercise still printed: %q", out)
+	}
+}
+
+func TestRunOneCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	block := make(chan struct{})
+	defer close(block)
+	ex := registry.Exercise{Chapter: "chapter1", Name: "exercise1", Run: func(io.Writer) { <-block }}
+	if err := runOne(ctx, ex, io.Discard, 0); !errors.Is(err, context.Canceled) {
+		t.Errorf("runOne with a cancelled context = %v, want context.Canceled", err)
+	}
+
+	ex.Run = func(io.Writer) { panic("boom") }
+	if err := runOne(context.Background(), ex, io.Discard, time.Second); err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("runOne of a panicking exercise = %v", err)
+	}
+}
+
+func TestRunRecord(t *testing.T) {
+	root := t.TempDir()
+	var stdout bytes.Buffer
+	err := run([]string{"run", "chapter99/hang", "--exercise", "2", "--record", "--root", root, "--progress-file", ""}, &stdout, io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr, err := transcript.Latest(filepath.Join(root, "transcripts"), "chapter99/hang/exercise2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr.Output != "next exercise\n" || !tr.Passed || tr.Date.IsZero() {
+		t.Errorf("recorded %+v", tr)
+	}
+	if !strings.Contains(stdout.String(), "next exercise") {
+		t.Errorf("recording hid the output: %q", stdout.String())
+	}
+}
+
+// provided counts the runs of TestRunProvidedExercises, whose exercise
+// needs a new name each time, since the registry keeps it.
+var provided int
+
+func TestRunProvidedExercises(t *testing.T) {
+	provided++
+	dir := t.TempDir()
+	chapter := fmt.Sprintf("chapter98/run%d", provided)
+	src := "package main\n\nimport \"fmt\"\n\nfunc main() { fmt.Println(\"my own solution\") }\n"
+	if err := os.MkdirAll(filepath.Join(dir, filepath.FromSlash(chapter)), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, filepath.FromSlash(chapter), "exercise1.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	err := run([]string{"run", chapter, "--exercises", dir, "--lang", "en", "--progress-file", ""}, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("run: %v\n%s", err, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "my own solution") || !strings.Contains(stderr.String(), "PASS "+chapter+"/exercise1") {
+		t.Errorf("stdout = %q, stderr = %q", stdout.String(), stderr.String())
+	}
+
+	// The exercise is registered now, so providing it again clashes.
+	if err := run([]string{"list", "--exercises", dir}, io.Discard, io.Discard); err == nil {
+		t.Error("list with an exercise provided twice succeeded")
+	}
+}
+
+// An unknown exercise is reported in the chosen language, and still
+// exits with errs.ExitNotFound.
+func TestUnknownExerciseTranslated(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"run", "3", "--exercise", "99", "--lang", "en", "--progress-file", ""}, `no exercise named "chapter3/exercise99"`},
+		{[]string{"run", "3", "--exercise", "99", "--lang", "fa", "--progress-file", ""}, `تمرینی با نام "chapter3/exercise99" وجود ندارد`},
+		{[]string{"check", "chapter99", "--lang", "en", "--progress-file", ""}, `no exercise named "chapter99"`},
+		{[]string{"list", "99", "--lang", "fa"}, `تمرینی با نام "99" وجود ندارد`},
+		{[]string{"diff", "3.99", "--lang", "en"}, `no exercise named "chapter3/exercise99"`},
+	}
+	for _, tt := range tests {
+		err := run(tt.args, io.Discard, io.Discard)
+		if err == nil || err.Error() != tt.want || errs.ExitCode(err) != errs.ExitNotFound {
+			t.Errorf("learn %q: %v (exit %d), want %q (exit %d)", tt.args, err, errs.ExitCode(err), tt.want, errs.ExitNotFound)
+		}
+	}
+}
+
+func TestProgressExportImport(t *testing.T) {
+	dir := t.TempDir()
+	from, to := filepath.Join(dir, "from.json"), filepath.Join(dir, "to.json")
+	p := &progress.Progress{}
+	p.RecordRun("chapter99/hang/exercise2", time.Now(), true)
+	if err := progress.Save(from, p); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, format := range []string{"json", "csv"} {
+		var out bytes.Buffer
+		if err := run([]string{"progress", "export", "--format", format, "--progress-file", from}, &out, io.Discard); err != nil {
+			t.Fatalf("export %s: %v", format, err)
+		}
+		export := filepath.Join(dir, "export."+format)
+		if err := os.WriteFile(export, out.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		os.Remove(to)
+		out.Reset()
+		// The format is guessed from the file name.
+		if err := run([]string{"progress", "import", export, "--progress-file", to}, &out, io.Discard); err != nil {
+			t.Fatalf("import %s: %v", format, err)
+		}
+		got, err := progress.Load(to)
+		if err != nil || !got.Exercises["chapter99/hang/exercise2"].Done() {
+			t.Errorf("after importing the %s export: %+v, %v", format, got, err)
+		}
+	}
+
+	for _, args := range [][]string{{"progress", "export", "extra"}, {"progress", "import"}, {"progress", "export", "--format", "xml"}, {"progress", "send"}} {
+		if err := run(append(args, "--progress-file", from), io.Discard, io.Discard); errs.ExitCode(err) != errs.ExitUsage {
+			t.Errorf("%q = %v, want a usage error", args, err)
+		}
+	}
+}
+
+// TestProgressDefaultFile runs learn without --progress-file, so run and
+// progress share the default file, kept here in a temporary config
+// directory.
+func TestProgressDefaultFile(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	t.Setenv("AppData", dir)
+	path := progress.DefaultPath()
+	if !strings.HasPrefix(path, dir) {
+		t.Fatalf("DefaultPath() = %q, outside the temporary config directory", path)
+	}
+
+	if err := run([]string{"run", "chapter99/hang", "--exercise", "2", "--lang", "en"}, io.Discard, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	if err := run([]string{"progress"}, &out, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	total := len(registry.All())
+	if want := fmt.Sprintf("1 of %d exercises done", total); !strings.Contains(out.String(), want) {
+		t.Errorf("progress after one run does not say %q:\n%s", want, out.String())
+	}
+	if !regexp.MustCompile(`chapter99/hang\s*│\s*1\s*│\s*2\s*│\s*50`).MatchString(out.String()) {
+		t.Errorf("progress does not show chapter99/hang 1 of 2 done:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := run([]string{"progress", "--reset"}, &out, io.Discard); err != nil || out.String() != "progress reset\n" {
+		t.Fatalf("progress --reset = %q, %v", out.String(), err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("--reset left %s behind", path)
+	}
+	out.Reset()
+	run([]string{"progress"}, &out, io.Discard)
+	if want := fmt.Sprintf("0 of %d exercises done (0%%)", total); !strings.Contains(out.String(), want) {
+		t.Errorf("progress after --reset does not say %q:\n%s", want, out.String())
+	}
+}
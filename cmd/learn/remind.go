@@ -0,0 +1,87 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"time"
+
+	"learning-go/config"
+	"learning-go/errs"
+	"learning-go/notify"
+	"learning-go/progress"
+	"learning-go/registry"
+)
+
+// remindTimeout bounds how long sending a reminder may take.
+const remindTimeout = 30 * time.Second
+
+// remind posts the exercise of the day (see daily) and the current streak
+// to the chat services set up in the config file (package notify).
+func remind(args []string, w io.Writer) error {
+	o, positional, err := parse("remind", args)
+	if err != nil {
+		return err
+	}
+	switch {
+	case len(positional) > 0:
+		return errs.Invalid("arguments", "remind takes no arguments")
+	case o.progressFile == "":
+		return errs.Invalid("progress-file", "must not be empty")
+	}
+	cfg, err := config.Load(o.config)
+	if errors.Is(err, fs.ErrNotExist) {
+		return errs.Invalid("config", "%s does not exist; set slack_webhook, or telegram_token and telegram_chat, in it", o.config)
+	}
+	if err != nil {
+		return err
+	}
+	n := notifiers(cfg)
+	if len(n) == 0 {
+		return errs.Invalid("config", "%s sets neither slack_webhook nor telegram_token and telegram_chat", o.config)
+	}
+	pr, err := progress.Load(o.progressFile)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	ex, ok := dailyExercise(pr, registry.All(), now, cmp.Or(cfg.User, dailyUser(o)))
+	if !ok {
+		fmt.Fprintln(w, "every exercise is done; no reminder sent")
+		return nil
+	}
+	r := notify.Reminder{
+		Exercise: ex.ID(),
+		Title:    loadTitles(o.root, o.lang)[ex.ID()],
+		Streak:   pr.Streak(now),
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), remindTimeout)
+	defer cancel()
+	if err := n.Notify(ctx, r.Text()); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "sent to %d service(s):\n%s\n", len(n), r.Text())
+	return nil
+}
+
+// notifiers returns a notifier for each chat service cfg sets up.
+func notifiers(cfg *config.Config) notify.Multi {
+	var n notify.Multi
+	if cfg.SlackWebhook != "" {
+		n = append(n, &notify.Slack{WebhookURL: cfg.SlackWebhook})
+	}
+	if cfg.TelegramToken != "" {
+		n = append(n, &notify.Telegram{Token: cfg.TelegramToken, ChatID: cfg.TelegramChat})
+	}
+	return n
+}
+
+// defaultConfigPath is config.json beside the default progress file.
+func defaultConfigPath() string {
+	return filepath.Join(filepath.Dir(progress.DefaultPath()), "config.json")
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"learning-go/errs"
+	"learning-go/progress"
+	"learning-go/registry"
+)
+
+func TestRemind(t *testing.T) {
+	var posted string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		json.NewDecoder(r.Body).Decode(&payload)
+		posted = payload["text"]
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	cfg := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(cfg, []byte(`{"user": "learner", "slack_webhook": "`+srv.URL+`/hook"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "progress.json")
+	p := &progress.Progress{}
+	p.RecordRun("chapter3/exercise1", time.Now(), true)
+	if err := progress.Save(path, p); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := remind([]string{"--config", cfg, "--progress-file", path, "--root", dir}, &out); err != nil {
+		t.Fatal(err)
+	}
+	want, _ := dailyExercise(p, registry.All(), time.Now(), "learner")
+	if !strings.Contains(posted, want.ID()) || !strings.Contains(posted, "Streak: 1 day") {
+		t.Errorf("posted %q, want %s and a one-day streak", posted, want.ID())
+	}
+	if !strings.Contains(out.String(), "sent to 1 service(s)") {
+		t.Errorf("output = %q", out.String())
+	}
+
+	if err := os.WriteFile(cfg, []byte(`{}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{{"--config", cfg}, {"--config", filepath.Join(dir, "missing.json")}, {"--config", cfg, "extra"}} {
+		if err := remind(append(args, "--progress-file", path), &out); errs.ExitCode(err) != errs.ExitUsage {
+			t.Errorf("remind %q = %v, want a usage error", args, err)
+		}
+	}
+}
@@ -0,0 +1,324 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"learning-go/errs"
+)
+
+// replImports are the packages a repl session can use without importing
+// them: the data structures and concurrency helpers of this repository
+// and the standard packages that go with them. linkedlist is imported as
+// list, so a list is list.New[int]().
+var replImports = map[string]string{
+	"list":        "learning-go/datastructures/linkedlist",
+	"bst":         "learning-go/datastructures/bst",
+	"collections": "learning-go/datastructures/collections",
+	"graph":       "learning-go/datastructures/graph",
+	"heap":        "learning-go/datastructures/heap",
+	"pipeline":    "learning-go/concurrency/pipeline",
+	"pubsub":      "learning-go/concurrency/pubsub",
+	"ratelimit":   "learning-go/concurrency/ratelimit",
+	"funcs":       "learning-go/generics/funcs",
+	"lru":         "learning-go/cache/lru",
+	"memo":        "learning-go/memo",
+	"seq":         "learning-go/seq",
+	"tuple":       "learning-go/tuple",
+	"context":     "context",
+	"errors":      "errors",
+	"fmt":         "fmt",
+	"maps":        "maps",
+	"math":        "math",
+	"os":          "os",
+	"slices":      "slices",
+	"sort":        "sort",
+	"strconv":     "strconv",
+	"strings":     "strings",
+	"sync":        "sync",
+	"time":        "time",
+}
+
+const replHelp = `Enter Go statements or expressions; the value of an expression is printed.
+Statements spanning several lines continue until their brackets close.
+Packages such as list, heap, graph, pipeline, fmt and slices need no import.
+Functions are declared as variables: f := func(n int) int { return n * 2 }.
+  :show   print the program so far
+  :reset  start over
+  :quit   leave (or end the input)
+`
+
+// repl reads Go snippets from in and runs each with everything entered
+// before it, printing what the new snippet prints. There is no
+// interpreter: the session so far is compiled into one program and run
+// with go run, with the output of the earlier snippets discarded. That
+// costs a build per snippet, but the compiler is the real one, generics
+// and all. Earlier snippets run again each time, so one that uses random
+// numbers or the clock may see different values than it did at first.
+func repl(args []string, in io.Reader, w io.Writer) error {
+	o, positional, err := parse("repl", args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return errs.Invalid("arguments", "repl takes no arguments")
+	}
+	root, err := filepath.Abs(o.root)
+	if err != nil {
+		return err
+	}
+	// A directory inside the module, so the program can import its
+	// packages. The leading dot keeps it out of ./... patterns.
+	dir, err := os.MkdirTemp(root, ".repl-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	s := &session{dir: dir}
+	fmt.Fprint(w, replHelp)
+	lines := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(w, ">>> ")
+		snippet, ok := readSnippet(lines, w)
+		if !ok {
+			fmt.Fprintln(w)
+			return lines.Err()
+		}
+		switch strings.TrimSpace(snippet) {
+		case "":
+		case ":quit":
+			return nil
+		case ":reset":
+			s.stmts = nil
+		case ":show":
+			src, err := s.source("")
+			if err != nil {
+				fmt.Fprintln(w, err)
+				continue
+			}
+			w.Write(src)
+		default:
+			if err := s.eval(snippet, w); err != nil {
+				fmt.Fprintln(w, err)
+			}
+		}
+	}
+}
+
+// readSnippet reads one line, and more while brackets are left open. It
+// reports false at the end of the input.
+func readSnippet(lines *bufio.Scanner, w io.Writer) (string, bool) {
+	var sb strings.Builder
+	for lines.Scan() {
+		sb.WriteString(lines.Text() + "\n")
+		if openBrackets(sb.String()) <= 0 {
+			return sb.String(), true
+		}
+		fmt.Fprint(w, "... ")
+	}
+	return sb.String(), sb.Len() > 0
+}
+
+// openBrackets returns how many brackets src opens and does not close.
+func openBrackets(src string) int {
+	var sc scanner.Scanner
+	fset := token.NewFileSet()
+	sc.Init(fset.AddFile("", -1, len(src)), []byte(src), nil, 0)
+	depth := 0
+	for {
+		_, tok, _ := sc.Scan()
+		switch tok {
+		case token.EOF:
+			return depth
+		case token.LPAREN, token.LBRACE, token.LBRACK:
+			depth++
+		case token.RPAREN, token.RBRACE, token.RBRACK:
+			depth--
+		}
+	}
+}
+
+// session holds the snippets that ran so far.
+type session struct {
+	dir   string // where the program is written and run
+	stmts []string
+}
+
+// errCompile is a snippet that did not compile; it is left out of the
+// session.
+var errCompile = errors.New("does not compile")
+
+// eval runs snippet after the earlier ones and keeps it if it ran. An
+// expression is printed, unless it has no value.
+func (s *session) eval(snippet string, w io.Writer) error {
+	snippet = strings.TrimSpace(snippet)
+	tries := []string{snippet}
+	if _, err := parser.ParseExpr(snippet); err == nil {
+		tries = []string{"fmt.Println(" + snippet + ")", snippet}
+	}
+	var err error
+	for _, stmt := range tries {
+		var compileErr string
+		compileErr, err = s.run(stmt, w)
+		if compileErr == "" {
+			if err == nil {
+				s.stmts = append(s.stmts, stmt)
+			}
+			return err
+		}
+		err = fmt.Errorf("%w:\n%s", errCompile, compileErr)
+		// Printing failed only because the expression has no value: run
+		// it as a statement instead.
+		if !strings.Contains(compileErr, "used as value") {
+			break
+		}
+	}
+	return err
+}
+
+// run builds and runs the session followed by stmt. It returns the
+// compiler's complaints, if the program does not build, or an error if it
+// fails when run.
+func (s *session) run(stmt string, w io.Writer) (compileErr string, err error) {
+	src, err := s.source(stmt)
+	if err != nil {
+		return err.Error(), nil
+	}
+	if err := os.WriteFile(filepath.Join(s.dir, "main.go"), src, 0o644); err != nil {
+		return "", err
+	}
+	var stderr bytes.Buffer
+	cmd := exec.Command("go", "run", "main.go")
+	cmd.Dir = s.dir
+	cmd.Stdout, cmd.Stderr = w, &stderr
+	err = cmd.Run()
+	if out := stderr.String(); strings.HasPrefix(out, "# ") {
+		return compilerMessages(out), nil
+	}
+	if err != nil {
+		w.Write(stderr.Bytes())
+		return "", fmt.Errorf("snippet failed: %w", err)
+	}
+	return "", nil
+}
+
+// position matches the file and line the compiler puts before each
+// message, which mean nothing to someone who never saw the program.
+var position = regexp.MustCompile(`(?m)^\./main\.go:\d+:\d+: `)
+
+func compilerMessages(out string) string {
+	_, out, _ = strings.Cut(out, "\n") // the "# command-line-arguments" line
+	return strings.TrimRight(position.ReplaceAllString(out, "    "), "\n")
+}
+
+// source returns the program: the session's statements with their output
+// discarded, then next with its output kept. Every variable declared at
+// the top level is used once at the end, so that an unused one is not an
+// error, and only the packages the code refers to are imported.
+func (s *session) source(next string) ([]byte, error) {
+	var body strings.Builder
+	body.WriteString("\t_stdout := _os.Stdout\n")
+	body.WriteString("\t_os.Stdout, _ = _os.OpenFile(_os.DevNull, _os.O_WRONLY, 0)\n")
+	for _, stmt := range s.stmts {
+		body.WriteString(indent(stmt))
+	}
+	body.WriteString("\t_os.Stdout = _stdout\n")
+	body.WriteString(indent(next))
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "main.go", "package main\nfunc main() {\n"+body.String()+"}\n", 0)
+	if err != nil {
+		return nil, errors.New(strings.ReplaceAll(err.Error(), "main.go:", "line "))
+	}
+	for _, name := range declared(f.Decls[0].(*ast.FuncDecl).Body)[1:] { // not _stdout
+		fmt.Fprintf(&body, "\t_ = %s\n", name)
+	}
+
+	var src bytes.Buffer
+	src.WriteString("package main\n\nimport (\n\t_os \"os\"\n")
+	for _, name := range packagesUsed(body.String()) {
+		fmt.Fprintf(&src, "\t%s %q\n", name, replImports[name])
+	}
+	fmt.Fprintf(&src, ")\n\nfunc main() {\n%s}\n", body.String())
+	return src.Bytes(), nil
+}
+
+// indent indents each line of stmt by a tab.
+func indent(stmt string) string {
+	if stmt == "" {
+		return ""
+	}
+	return "\t" + strings.ReplaceAll(stmt, "\n", "\n\t") + "\n"
+}
+
+// declared returns the names of the variables declared directly in body.
+func declared(body *ast.BlockStmt) []string {
+	var names []string
+	add := func(id *ast.Ident) {
+		if id.Name != "_" && !slices.Contains(names, id.Name) {
+			names = append(names, id.Name)
+		}
+	}
+	for _, stmt := range body.List {
+		switch stmt := stmt.(type) {
+		case *ast.AssignStmt:
+			if stmt.Tok == token.DEFINE {
+				for _, lhs := range stmt.Lhs {
+					if id, ok := lhs.(*ast.Ident); ok {
+						add(id)
+					}
+				}
+			}
+		case *ast.DeclStmt:
+			if gd, ok := stmt.Decl.(*ast.GenDecl); ok && gd.Tok == token.VAR {
+				for _, spec := range gd.Specs {
+					for _, id := range spec.(*ast.ValueSpec).Names {
+						add(id)
+					}
+				}
+			}
+		}
+	}
+	return names
+}
+
+// packagesUsed returns, sorted, the names in replImports that src uses
+// as a package, that is, followed by a dot.
+func packagesUsed(src string) []string {
+	var sc scanner.Scanner
+	fset := token.NewFileSet()
+	sc.Init(fset.AddFile("", -1, len(src)), []byte(src), nil, 0)
+	var names []string
+	prev := ""
+	for {
+		_, tok, lit := sc.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if tok == token.PERIOD && prev != "" && !slices.Contains(names, prev) {
+			if _, ok := replImports[prev]; ok {
+				names = append(names, prev)
+			}
+		}
+		prev = ""
+		if tok == token.IDENT {
+			prev = lit
+		}
+	}
+	slices.Sort(names)
+	return names
+}
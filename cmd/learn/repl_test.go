@@ -0,0 +1,66 @@
+package main
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestRepl(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("no go command:", err)
+	}
+	if testing.Short() {
+		t.Skip("builds a program per line")
+	}
+	in := strings.Join([]string{
+		"l := list.New[int](1, 2)",
+		"l.PushBack(3)",
+		"l.Len()",
+		"undefinedName + 1",
+		"for i := range 2 {",
+		`	fmt.Println("line", i)`,
+		"}",
+		"l.Remove(0)",
+		"l.Len()",
+		":reset",
+		"l.Len()",
+	}, "\n")
+	var out strings.Builder
+	if err := repl([]string{"--root", "../.."}, strings.NewReader(in), &out); err != nil {
+		t.Fatalf("repl: %v", err)
+	}
+	got := out.String()
+	for _, want := range []string{
+		">>> 3\n",                  // the length after PushBack
+		"undefined: undefinedName", // reported, then dropped
+		"line 0\nline 1\n",         // only once: earlier output is discarded
+		">>> 1\n>>> 2\n",           // Remove(0) ran, and was not run twice
+		"undefined: l",             // after :reset
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("repl output lacks %q:\n%s", want, got)
+		}
+	}
+	if n := strings.Count(got, "line 0"); n != 1 {
+		t.Errorf("the loop printed %d times, want once:\n%s", n, got)
+	}
+}
+
+func TestOpenBrackets(t *testing.T) {
+	tests := []struct {
+		src  string
+		want int
+	}{
+		{"x := 1", 0},
+		{"for i := range 3 {", 1},
+		{"f(func() {", 2},
+		{"s := \"{\" + `(`", 0},
+		{"}", -1},
+	}
+	for _, tt := range tests {
+		if got := openBrackets(tt.src); got != tt.want {
+			t.Errorf("openBrackets(%q) = %d, want %d", tt.src, got, tt.want)
+		}
+	}
+}
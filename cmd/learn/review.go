@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"learning-go/errs"
+	"learning-go/progress"
+	"learning-go/registry"
+	"learning-go/report"
+	"learning-go/review"
+)
+
+// showReview lists the done exercises that are due for review, the most
+// overdue first, or with an exercise and --grade records how well the
+// learner remembered it and schedules the next review (package review).
+func showReview(args []string, w io.Writer) error {
+	o, positional, err := parse("review", args)
+	if err != nil {
+		return err
+	}
+	if o.progressFile == "" {
+		return errs.Invalid("progress-file", "must not be empty")
+	}
+	pr, err := progress.Load(o.progressFile)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+
+	if len(positional) > 0 || o.grade >= 0 {
+		if o.grade < int(review.Blackout) || o.grade > int(review.Perfect) {
+			return errs.Invalid("grade", "give --grade from 0 (forgotten) to 5 (perfect)")
+		}
+		ex, err := lookupOne(o, positional)
+		if err != nil {
+			return err
+		}
+		card, err := pr.Review(ex.ID(), review.Quality(o.grade), now)
+		if err != nil {
+			return err
+		}
+		if err := progress.Save(o.progressFile, pr); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%s: next review in %d day(s), on %s\n", ex.ID(), card.Interval, card.Due.Format("2006-01-02"))
+		return nil
+	}
+
+	var cards []review.Card
+	for _, ex := range registry.All() {
+		if c, ok := pr.ReviewCard(ex.ID()); ok {
+			cards = append(cards, c)
+		}
+	}
+	due := review.DueCards(cards, now)
+	if len(due) == 0 {
+		fmt.Fprintln(w, "nothing to review today")
+		return nil
+	}
+	if o.limit > 0 && len(due) > o.limit {
+		due = due[:o.limit]
+	}
+	titles := loadTitles(o.root, o.lang)
+	t := report.Table{Headers: []string{"Exercise", "Due", "Title"}}
+	for _, c := range due {
+		t.AddRow(c.ID, c.Due.Format("2006-01-02"), titles[c.ID])
+	}
+	if err := t.Render(w); err != nil {
+		return err
+	}
+	fmt.Fprintln(w, "Redo one with learn run, then grade it: learn review chapter.N --grade 0-5")
+	return nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"learning-go/errs"
+	"learning-go/progress"
+)
+
+func TestShowReview(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress.json")
+	review := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		err := showReview(append(args, "--progress-file", path, "--root", t.TempDir()), &out)
+		return out.String(), err
+	}
+
+	if out, err := review(); err != nil || !strings.Contains(out, "nothing to review") {
+		t.Errorf("review with no progress = %q, %v", out, err)
+	}
+
+	p := &progress.Progress{}
+	p.RecordRun("chapter99/hang/exercise2", time.Now().Add(-72*time.Hour), true)
+	p.RecordRun("chapter99/hang/exercise1", time.Now(), true)
+	if err := progress.Save(path, p); err != nil {
+		t.Fatal(err)
+	}
+	out, err := review()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "chapter99/hang/exercise2") || strings.Contains(out, "chapter99/hang/exercise1") {
+		t.Errorf("due list should hold only exercise2, run three days ago:\n%s", out)
+	}
+
+	out, err = review("chapter99/hang.2", "--grade", "5")
+	if err != nil || !strings.Contains(out, "next review in 1 day(s)") {
+		t.Errorf("grading = %q, %v", out, err)
+	}
+	if out, _ := review(); !strings.Contains(out, "nothing to review") {
+		t.Errorf("a graded exercise is still due:\n%s", out)
+	}
+
+	for _, args := range [][]string{{"chapter99/hang.2"}, {"chapter99/hang.2", "--grade", "9"}, {"--grade", "3"}} {
+		if _, err := review(args...); errs.ExitCode(err) != errs.ExitUsage {
+			t.Errorf("review %q = %v, want a usage error", args, err)
+		}
+	}
+}
+
+var exerciseID = regexp.MustCompile(`chapter\d+(/[a-z]+)*/exercise\d+`)
+
+// TestReviewSelection checks which exercises review lists: registered
+// ones that were done, most overdue first, at most --limit of them.
+func TestReviewSelection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress.json")
+	now := time.Now()
+	p := &progress.Progress{}
+	p.RecordRun("chapter2/exercise1", now.Add(-5*24*time.Hour), true)
+	p.RecordRun("chapter3/exercise1", now.Add(-10*24*time.Hour), true)
+	p.RecordRun("chapter3/exercise2", now.Add(-10*24*time.Hour), false) // not done
+	p.RecordRun("chapter1/exercise9", now.Add(-10*24*time.Hour), true)  // not registered
+	if err := progress.Save(path, p); err != nil {
+		t.Fatal(err)
+	}
+
+	listed := func(args ...string) []string {
+		t.Helper()
+		var out bytes.Buffer
+		if err := showReview(append(args, "--progress-file", path, "--root", t.TempDir()), &out); err != nil {
+			t.Fatal(err)
+		}
+		return exerciseID.FindAllString(out.String(), -1)
+	}
+	if got, want := listed(), []string{"chapter3/exercise1", "chapter2/exercise1"}; !slices.Equal(got, want) {
+		t.Errorf("review listed %v, want %v", got, want)
+	}
+	if got, want := listed("--limit", "1"), []string{"chapter3/exercise1"}; !slices.Equal(got, want) {
+		t.Errorf("review --limit 1 listed %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"learning-go/errs"
+	"learning-go/progress"
+	"learning-go/registry"
+	"learning-go/report"
+)
+
+// mostFailed is how many exercises stats lists under "Most failed".
+const mostFailed = 5
+
+// showStats prints a dashboard of the progress file: each chapter's
+// completion as a bar, the current streak of days with practice, the
+// average time of an attempt, and the exercises that failed most often.
+func showStats(args []string, w io.Writer) error {
+	o, positional, err := parse("stats", args)
+	if err != nil {
+		return err
+	}
+	switch {
+	case len(positional) > 0:
+		return errs.Invalid("arguments", "stats takes no arguments")
+	case o.progressFile == "":
+		return errs.Invalid("progress-file", "must not be empty")
+	}
+	pr, err := progress.Load(o.progressFile)
+	if err != nil {
+		return err
+	}
+
+	chapters, total := progress.Summarize(pr, registry.All())
+	t := report.Table{
+		Title:   "Completion",
+		Headers: []string{"Chapter", "Done", "", "%"},
+		Align:   []report.Align{report.Left, report.Right, report.Left, report.Right},
+	}
+	for _, c := range chapters {
+		t.AddRow(c.Chapter, fmt.Sprintf("%d/%d", c.Done, c.Total),
+			report.Bar(float64(c.Done)/float64(c.Total), 20), report.Percent(c.Done, c.Total))
+	}
+	t.AddRow("total", fmt.Sprintf("%d/%d", total.Done, total.Total),
+		report.Bar(float64(total.Done)/float64(max(total.Total, 1)), 20), report.Percent(total.Done, total.Total))
+	if err := t.Render(w); err != nil {
+		return err
+	}
+
+	avg, timed, failures := attempts(pr)
+	fmt.Fprintf(w, "\nStreak: %d day(s)\n", pr.Streak(time.Now()))
+	if timed > 0 {
+		fmt.Fprintf(w, "Average attempt: %v over %d run(s)\n", avg.Round(time.Millisecond), timed)
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+
+	f := report.Table{
+		Title:   "\nMost failed",
+		Headers: []string{"Exercise", "Failures", "Runs"},
+		Align:   []report.Align{report.Left, report.Right, report.Right},
+	}
+	for _, x := range failures[:min(len(failures), mostFailed)] {
+		f.AddRow(x.id, x.failures, x.runs)
+	}
+	return f.Render(w)
+}
+
+// failure is one exercise's line under "Most failed".
+type failure struct {
+	id             string
+	failures, runs int
+}
+
+// attempts adds up the runs of every exercise in pr: the average time of
+// the timed ones and how many there were, and the exercises that failed
+// at least once, the most failures first and then by ID.
+func attempts(pr *progress.Progress) (avg time.Duration, timed int, failures []failure) {
+	var sum time.Duration
+	for id, e := range pr.Exercises {
+		sum += e.Time
+		timed += e.Timed
+		if e.Failures > 0 {
+			failures = append(failures, failure{id, e.Failures, e.Runs})
+		}
+	}
+	if timed > 0 {
+		avg = sum / time.Duration(timed)
+	}
+	slices.SortFunc(failures, func(a, b failure) int {
+		return cmp.Or(cmp.Compare(b.failures, a.failures), cmp.Compare(a.id, b.id))
+	})
+	return avg, timed, failures
+}
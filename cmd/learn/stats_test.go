@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"learning-go/errs"
+	"learning-go/progress"
+)
+
+func TestShowStats(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress.json")
+	p := &progress.Progress{}
+	p.RecordRun("chapter99/hang/exercise1", time.Now(), false)
+	p.RecordRun("chapter99/hang/exercise2", time.Now(), true)
+	p.RecordTime("chapter99/hang/exercise2", 2*time.Second)
+	if err := progress.Save(path, p); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := showStats([]string{"--progress-file", path}, &out); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"chapter99/hang",
+		"1/2",
+		"Streak: 1 day(s)",
+		"Average attempt: 2s over 1 run(s)",
+		"Most failed",
+		"chapter99/hang/exercise1",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("stats output lacks %q:\n%s", want, out.String())
+		}
+	}
+
+	for _, args := range [][]string{{"extra"}, {"--progress-file", ""}} {
+		if err := showStats(args, &bytes.Buffer{}); errs.ExitCode(err) != errs.ExitUsage {
+			t.Errorf("stats %q = %v, want a usage error", args, err)
+		}
+	}
+}
+
+func TestAttempts(t *testing.T) {
+	p := &progress.Progress{}
+	at := time.Now()
+	run := func(id string, passed bool, d time.Duration) {
+		p.RecordRun(id, at, passed)
+		if d > 0 {
+			p.RecordTime(id, d)
+		}
+	}
+	run("chapter3/exercise1", false, time.Second)
+	run("chapter3/exercise1", false, 3*time.Second)
+	run("chapter3/exercise1", true, 2*time.Second)
+	run("chapter2/exercise1", false, 0) // failed before it could be timed
+	run("chapter5/exercise2", false, 6*time.Second)
+	run("chapter5/exercise2", true, 0)
+	run("chapter4/exercise1", true, 0) // never failed
+
+	avg, timed, failures := attempts(p)
+	if avg != 3*time.Second || timed != 4 {
+		t.Errorf("attempts average %v over %d, want 3s over 4", avg, timed)
+	}
+	// Most failures first; chapter2 and chapter5 tie and go by ID.
+	want := []failure{{"chapter3/exercise1", 2, 3}, {"chapter2/exercise1", 1, 1}, {"chapter5/exercise2", 1, 2}}
+	if !slices.Equal(failures, want) {
+		t.Errorf("attempts failures = %v, want %v", failures, want)
+	}
+
+	if avg, timed, failures := attempts(&progress.Progress{}); avg != 0 || timed != 0 || failures != nil {
+		t.Errorf("attempts of no progress = %v, %d, %v", avg, timed, failures)
+	}
+}
+
+// Only the mostFailed exercises with the most failures are listed.
+func TestStatsListsMostFailed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress.json")
+	p := &progress.Progress{}
+	for i := 1; i <= mostFailed+2; i++ {
+		for range i {
+			p.RecordRun(fmt.Sprintf("chapter99/hang/exercise%d", i), time.Now(), false)
+		}
+	}
+	if err := progress.Save(path, p); err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	if err := showStats([]string{"--progress-file", path}, &out); err != nil {
+		t.Fatal(err)
+	}
+	_, failed, _ := strings.Cut(out.String(), "Most failed")
+	got := exerciseID.FindAllString(failed, -1)
+	want := []string{"chapter99/hang/exercise7", "chapter99/hang/exercise6", "chapter99/hang/exercise5",
+		"chapter99/hang/exercise4", "chapter99/hang/exercise3"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Most failed lists %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,16 @@
+// Command learnvet runs the checks from learning-go/analysis. Install it
+// and hand it to go vet, which then runs these checks instead of its own:
+//
+//	go install learning-go/cmd/learnvet
+//	go vet -vettool=$(go env GOPATH)/bin/learnvet ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/multichecker"
+
+	"learning-go/analysis"
+)
+
+func main() {
+	multichecker.Main(analysis.Analyzers...)
+}
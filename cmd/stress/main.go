@@ -0,0 +1,304 @@
+// Command stress runs a chapter's exercises over and over under different
+// scheduling conditions and reports every run whose output differs from
+// the most common one. Order-dependent concurrent code, like a select loop
+// with a default case, usually passes a single run and fails here:
+//
+//	go run ./cmd/stress -chapter 12 -runs 1000
+//	go run ./cmd/stress -race -jitter -runs 200 chapter12/selectfairness
+//
+// The exercise runner (cmd/learn) is built once, and every run is a
+// "learn run <chapter>", so only the exercises' own output is compared,
+// not the timings learn prints to stderr. Each run gets a different
+// GOMAXPROCS, from 1 up to the number of CPUs. With -jitter, a goroutine
+// that randomly sleeps and yields is compiled into the runner (through a
+// build overlay, without touching the source) to shift goroutine
+// interleavings further. With -race, the binary is built with the race detector, and a
+// reported race fails the run.
+//
+// Every run gets its own seed derived from -seed, passed to the program in
+// $LEARN_SEED (see package randsource), so a deviating run can be repeated
+// with the seed printed next to it.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"learning-go/randsource"
+)
+
+// learnPkg is the runner every run goes through.
+const learnPkg = "learning-go/cmd/learn"
+
+// jitterSource is added to the runner with -jitter. Its only declaration
+// is init, so it cannot clash with the package's own names.
+const jitterSource = `package main
+
+import (
+	"runtime"
+	"time"
+
+	"learning-go/randsource"
+)
+
+func init() {
+	r := randsource.New("stress/jitter")
+	go func() {
+		for {
+			time.Sleep(time.Duration(r.IntN(200)) * time.Microsecond)
+			busy := time.Now().Add(time.Duration(r.IntN(100)) * time.Microsecond)
+			for time.Now().Before(busy) {
+				runtime.Gosched()
+			}
+		}
+	}()
+}
+`
+
+type result struct {
+	run        int
+	maxprocs   int
+	seed       uint64
+	stdout     string
+	stderr     string
+	err        error
+	timedOut   bool
+	raceReport bool
+}
+
+func main() {
+	chapter := flag.String("chapter", "", "chapter to stress, e.g. 12 (or give it as an argument)")
+	runs := flag.Int("runs", 100, "number of runs")
+	race := flag.Bool("race", false, "build with the race detector")
+	jitter := flag.Bool("jitter", false, "inject random scheduling jitter")
+	parallel := flag.Int("p", max(1, runtime.NumCPU()/2), "runs to execute at the same time")
+	timeout := flag.Duration("timeout", 30*time.Second, "time limit per run")
+	randsource.RegisterFlag(flag.CommandLine)
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: stress [flags] [-chapter N | chapter]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	log.SetFlags(0)
+	log.SetPrefix("stress: ")
+
+	target := flag.Arg(0)
+	switch {
+	case *chapter != "" && target == "":
+		target = *chapter
+	case *chapter == "" && target != "" && flag.NArg() == 1:
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir, err := os.MkdirTemp("", "stress-")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	bin, err := build(dir, *race, *jitter)
+	if err != nil {
+		os.RemoveAll(dir)
+		log.Fatal(err)
+	}
+
+	// Let the runner reject an unknown chapter once, not in every run.
+	if out, err := exec.Command(bin, "list", target).CombinedOutput(); err != nil {
+		os.RemoveAll(dir)
+		log.Fatalf("%s", bytes.TrimSpace(out))
+	}
+
+	fmt.Printf("seed %d (repeat with -seed %d)\n", randsource.Seed(), randsource.Seed())
+	results := runAll(bin, target, *runs, *parallel, *timeout)
+	if !report(os.Stdout, results) {
+		os.RemoveAll(dir)
+		os.Exit(1)
+	}
+}
+
+func build(dir string, race, jitter bool) (string, error) {
+	bin := filepath.Join(dir, "learn")
+	args := []string{"build", "-o", bin}
+	if race {
+		args = append(args, "-race")
+	}
+	if jitter {
+		pkgDir, err := packageDir(learnPkg)
+		if err != nil {
+			return "", err
+		}
+		src := filepath.Join(dir, "jitter.go")
+		if err := os.WriteFile(src, []byte(jitterSource), 0o644); err != nil {
+			return "", err
+		}
+		// The overlay adds a file that does not exist on disk.
+		overlay, _ := json.Marshal(map[string]map[string]string{
+			"Replace": {filepath.Join(pkgDir, "zz_stress_jitter.go"): src},
+		})
+		overlayPath := filepath.Join(dir, "overlay.json")
+		if err := os.WriteFile(overlayPath, overlay, 0o644); err != nil {
+			return "", err
+		}
+		args = append(args, "-overlay", overlayPath)
+	}
+	args = append(args, learnPkg)
+
+	cmd := exec.Command("go", args...)
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("building %s: %w", learnPkg, err)
+	}
+	return bin, nil
+}
+
+func packageDir(pkg string) (string, error) {
+	out, err := exec.Command("go", "list", "-f", "{{.Dir}}", pkg).Output()
+	if err != nil {
+		return "", fmt.Errorf("locating %s: %w", pkg, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func runAll(bin, chapter string, runs, parallel int, timeout time.Duration) []result {
+	results := make([]result, runs)
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range parallel {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				results[i] = runOnce(bin, chapter, i, timeout)
+			}
+		}()
+	}
+	for i := range runs {
+		next <- i
+		if (i+1)%100 == 0 {
+			fmt.Fprintf(os.Stderr, "\rstarted %d/%d runs", i+1, runs)
+		}
+	}
+	close(next)
+	wg.Wait()
+	if runs >= 100 {
+		fmt.Fprintln(os.Stderr)
+	}
+	return results
+}
+
+func runOnce(bin, chapter string, i int, timeout time.Duration) result {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	procs := 1 + i%runtime.NumCPU()
+	seed := randsource.Derive("stress", i)
+	// Stress runs are not the learner's own, so they record no progress.
+	cmd := exec.CommandContext(ctx, bin, "run", chapter, "--progress-file=")
+	cmd.Env = append(os.Environ(),
+		"GOMAXPROCS="+strconv.Itoa(procs),
+		randsource.EnvVar+"="+strconv.FormatUint(seed, 10),
+	)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	err := cmd.Run()
+
+	return result{
+		run:        i,
+		maxprocs:   procs,
+		seed:       seed,
+		stdout:     stdout.String(),
+		stderr:     stderr.String(),
+		err:        err,
+		timedOut:   errors.Is(ctx.Err(), context.DeadlineExceeded),
+		raceReport: strings.Contains(stderr.String(), "WARNING: DATA RACE"),
+	}
+}
+
+// report prints a summary to w and returns whether every run behaved the
+// same.
+func report(w io.Writer, results []result) bool {
+	counts := make(map[string]int)
+	for _, r := range results {
+		if r.err == nil {
+			counts[r.stdout]++
+		}
+	}
+	var expected string
+	best := 0
+	for out, n := range counts {
+		if n > best || n == best && out < expected {
+			expected, best = out, n
+		}
+	}
+
+	var bad []result
+	for _, r := range results {
+		if r.err != nil || r.stdout != expected {
+			bad = append(bad, r)
+		}
+	}
+	sort.Slice(bad, func(i, j int) bool { return bad[i].run < bad[j].run })
+
+	fmt.Fprintf(w, "%d runs, %d distinct outputs, %d deviated from the most common one\n",
+		len(results), len(counts), len(bad))
+	const shown = 10
+	for i, r := range bad {
+		if i == shown {
+			fmt.Fprintf(w, "... and %d more\n", len(bad)-shown)
+			break
+		}
+		fmt.Fprintf(w, "\nrun %d (GOMAXPROCS=%d %s=%d): %s\n", r.run, r.maxprocs, randsource.EnvVar, r.seed, describe(r, expected))
+	}
+	return len(bad) == 0
+}
+
+func describe(r result, expected string) string {
+	switch {
+	case r.timedOut:
+		return "timed out (deadlock or livelock?)"
+	case r.raceReport:
+		return "data race detected\n" + firstLines(r.stderr, 12)
+	case r.err != nil:
+		return fmt.Sprintf("%v\n%s", r.err, firstLines(r.stderr, 12))
+	}
+	got, want := strings.Split(r.stdout, "\n"), strings.Split(expected, "\n")
+	for i := 0; i < max(len(got), len(want)); i++ {
+		var g, w string
+		if i < len(got) {
+			g = got[i]
+		}
+		if i < len(want) {
+			w = want[i]
+		}
+		if g != w {
+			return fmt.Sprintf("output differs at line %d\n  got:  %q\n  want: %q", i+1, g, w)
+		}
+	}
+	return "output differs"
+}
+
+func firstLines(s string, n int) string {
+	lines := strings.SplitN(s, "\n", n+1)
+	if len(lines) > n {
+		lines = lines[:n]
+	}
+	return "  " + strings.Join(lines, "\n  ")
+}
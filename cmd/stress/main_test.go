@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReport(t *testing.T) {
+	results := []result{
+		{run: 0, stdout: "a\nb\n"},
+		{run: 1, stdout: "a\nb\n"},
+		{run: 2, stdout: "a\nc\n", maxprocs: 3, seed: 7},
+		{run: 3, err: errors.New("exit status 1"), stderr: "WARNING: DATA RACE\nat ...", raceReport: true},
+		{run: 4, err: errors.New("signal: killed"), timedOut: true},
+	}
+	var out bytes.Buffer
+	if report(&out, results) {
+		t.Error("report returned true with deviating runs")
+	}
+	for _, want := range []string{
+		"5 runs, 2 distinct outputs, 3 deviated",
+		"run 2 (GOMAXPROCS=3 LEARN_SEED=7): output differs at line 2",
+		`got:  "c"`,
+		"run 3 (GOMAXPROCS=0 LEARN_SEED=0): data race detected",
+		"run 4 (GOMAXPROCS=0 LEARN_SEED=0): timed out",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("report lacks %q:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	if !report(&out, results[:2]) {
+		t.Errorf("report returned false for identical runs:\n%s", out.String())
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	if got := describe(result{stdout: "a\n"}, "a\nb\n"); !strings.Contains(got, "line 2") {
+		t.Errorf("a missing last line: %q", got)
+	}
+	if got := firstLines("1\n2\n3\n4", 2); got != "  1\n  2" {
+		t.Errorf("firstLines = %q", got)
+	}
+}
+
+func TestJitterSourceParses(t *testing.T) {
+	f, err := parser.ParseFile(token.NewFileSet(), "jitter.go", jitterSource, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Only init, so it cannot clash with cmd/learn's own names.
+	if len(f.Scope.Objects) != 0 {
+		t.Errorf("jitterSource declares %d package-level names besides init", len(f.Scope.Objects))
+	}
+}
+
+// TestRunOnce runs a fake runner that prints what it was given.
+func TestRunOnce(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake runner is a shell script")
+	}
+	bin := filepath.Join(t.TempDir(), "learn")
+	script := "#!/bin/sh\necho \"$@\" \"$GOMAXPROCS\" \"$LEARN_SEED\"\n[ \"$2\" = hang ] && exec sleep 5\nexit 0\n"
+	if err := os.WriteFile(bin, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	r := runOnce(bin, "12", 0, 5*time.Second)
+	fields := strings.Fields(r.stdout)
+	if r.err != nil || len(fields) != 5 || fields[0] != "run" || fields[1] != "12" || fields[2] != "--progress-file=" || fields[3] != "1" {
+		t.Errorf("runOnce = %q, %v; want run 12 with no progress file and GOMAXPROCS=1", r.stdout, r.err)
+	}
+	if r.maxprocs != 1 || fields[4] == "" {
+		t.Errorf("maxprocs %d, seed %q", r.maxprocs, fields[4])
+	}
+
+	if r := runOnce(bin, "hang", 0, 100*time.Millisecond); !r.timedOut {
+		t.Errorf("a run past the timeout was not reported: %+v", r)
+	}
+}
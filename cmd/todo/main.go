@@ -0,0 +1,31 @@
+// Command todo keeps a to-do list in a JSON file, by default todo.json in
+// a learning-go directory under the user's config directory:
+//
+//	go run ./cmd/todo add Read chapter 13
+//	go run ./cmd/todo list                  # open tasks as a table
+//	go run ./cmd/todo list -all -format json
+//	go run ./cmd/todo done 1 2
+//	go run ./cmd/todo -file tasks.json list
+//
+// The commands are implemented by package learning-go/chapter13/todo. A
+// mistake in the arguments exits with status 2 and prints the usage.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"learning-go/chapter13/todo"
+	"learning-go/errs"
+)
+
+func main() {
+	err := todo.Run(os.Args[1:], os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "todo:", err)
+		if errs.ExitCode(err) == errs.ExitUsage {
+			fmt.Fprint(os.Stderr, todo.Usage)
+		}
+	}
+	os.Exit(errs.ExitCode(err))
+}
@@ -0,0 +1,134 @@
+// Package complexity measures how complicated Go functions are, using only
+// the syntax tree:
+//
+//   - Cyclomatic complexity: 1 plus one for every branch point (if, for,
+//     case, &&, ||). It approximates the number of paths through the code.
+//   - Length: the number of lines the function spans.
+//   - Nesting: the deepest level of nested blocks (if, for, switch,
+//     select, function literals) inside the function.
+//
+// None of these is a verdict on its own, but a solution whose numbers are
+// far above a reference solution's is usually worth simplifying.
+package complexity
+
+import (
+	"go/ast"
+	"go/token"
+	"sort"
+)
+
+// Func holds the measurements of one function or method.
+type Func struct {
+	Name       string `json:"name"`
+	Cyclomatic int    `json:"cyclomatic"`
+	Lines      int    `json:"lines"`
+	Nesting    int    `json:"nesting"`
+}
+
+// Analyze measures every function declared in file, in source order.
+func Analyze(fset *token.FileSet, file *ast.File) []Func {
+	var funcs []Func
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+		funcs = append(funcs, Func{
+			Name:       funcName(fn),
+			Cyclomatic: cyclomatic(fn.Body),
+			Lines:      fset.Position(fn.End()).Line - fset.Position(fn.Pos()).Line + 1,
+			Nesting:    nesting(fn.Body, 0),
+		})
+	}
+	return funcs
+}
+
+// funcName returns "Name" for functions and "Recv.Name" for methods.
+func funcName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+	t := fn.Recv.List[0].Type
+	if star, ok := t.(*ast.StarExpr); ok {
+		t = star.X
+	}
+	// Strip type parameters from generic receivers: List[T] -> List.
+	switch x := t.(type) {
+	case *ast.IndexExpr:
+		t = x.X
+	case *ast.IndexListExpr:
+		t = x.X
+	}
+	if id, ok := t.(*ast.Ident); ok {
+		return id.Name + "." + fn.Name.Name
+	}
+	return fn.Name.Name
+}
+
+func cyclomatic(body *ast.BlockStmt) int {
+	n := 1
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch x := node.(type) {
+		case *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt:
+			n++
+		case *ast.CaseClause:
+			if x.List != nil { // default does not add a path
+				n++
+			}
+		case *ast.CommClause:
+			if x.Comm != nil {
+				n++
+			}
+		case *ast.BinaryExpr:
+			if x.Op == token.LAND || x.Op == token.LOR {
+				n++
+			}
+		}
+		return true
+	})
+	return n
+}
+
+// nesting returns the deepest block nesting below node, where depth is
+// the nesting level of node itself.
+func nesting(node ast.Node, depth int) int {
+	deepest := depth
+	ast.Inspect(node, func(n ast.Node) bool {
+		if n == node {
+			return true
+		}
+		switch n.(type) {
+		case *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt,
+			*ast.TypeSwitchStmt, *ast.SelectStmt, *ast.FuncLit:
+			deepest = max(deepest, nesting(n, depth+1))
+			return false
+		}
+		return true
+	})
+	return deepest
+}
+
+// Comparison pairs a function's numbers with the reference solution's.
+type Comparison struct {
+	Name      string
+	Solution  Func
+	Reference Func
+	// HasReference is false when the reference has no function of that name.
+	HasReference bool
+}
+
+// Compare matches functions by name and returns one entry per function in
+// solution, sorted by name.
+func Compare(solution, reference []Func) []Comparison {
+	ref := make(map[string]Func, len(reference))
+	for _, f := range reference {
+		ref[f.Name] = f
+	}
+	out := make([]Comparison, 0, len(solution))
+	for _, f := range solution {
+		r, ok := ref[f.Name]
+		out = append(out, Comparison{Name: f.Name, Solution: f, Reference: r, HasReference: ok})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
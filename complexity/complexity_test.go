@@ -0,0 +1,81 @@
+package complexity
+
+import (
+	"go/parser"
+	"go/token"
+	"slices"
+	"testing"
+)
+
+const src = `package p
+
+func empty() {}
+
+func branches(a, b bool, xs []int) int {
+	n := 0
+	if a && b {
+		n++
+	}
+	for _, x := range xs {
+		switch {
+		case x > 0:
+			n += x
+		case x < 0 || a:
+			n -= x
+		default:
+		}
+	}
+	return n
+}
+
+func nested(ch chan int) {
+	go func() {
+		for {
+			select {
+			case v := <-ch:
+				if v > 0 {
+					return
+				}
+			default:
+			}
+		}
+	}()
+}
+
+type list[T any] struct{}
+
+func (l *list[T]) Len() int { return 0 }
+`
+
+func TestAnalyze(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Func{
+		{Name: "empty", Cyclomatic: 1, Lines: 1, Nesting: 0},
+		// if, &&, range, two cases and || add six paths; the switch in the
+		// range loop is two deep.
+		{Name: "branches", Cyclomatic: 7, Lines: 16, Nesting: 2},
+		// for, the receive case and if add three; the literal, for,
+		// select and if nest four deep.
+		{Name: "nested", Cyclomatic: 4, Lines: 13, Nesting: 4},
+		{Name: "list.Len", Cyclomatic: 1, Lines: 1, Nesting: 0},
+	}
+	if got := Analyze(fset, file); !slices.Equal(got, want) {
+		t.Errorf("Analyze =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	solution := []Func{{Name: "b", Cyclomatic: 5}, {Name: "a", Cyclomatic: 2}}
+	reference := []Func{{Name: "a", Cyclomatic: 1}, {Name: "c", Cyclomatic: 9}}
+	want := []Comparison{
+		{Name: "a", Solution: solution[1], Reference: reference[0], HasReference: true},
+		{Name: "b", Solution: solution[0]},
+	}
+	if got := Compare(solution, reference); !slices.Equal(got, want) {
+		t.Errorf("Compare = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,124 @@
+// Package pipeline provides the building blocks of the Go pipeline
+// pattern: a generator that feeds values into a channel, stages that
+// process them on several goroutines at once (fan-out), and FanIn, which
+// merges many channels into one.
+//
+//	ctx, cancel := context.WithCancel(ctx)
+//	defer cancel()
+//	nums := pipeline.Generate(ctx, 1, 2, 3, 4)
+//	squares := pipeline.Stage(ctx, nums, 4, func(n int) int { return n * n })
+//	for s := range squares {
+//		fmt.Println(s)
+//	}
+//
+// Every goroutine the package starts sends with a select on ctx.Done(),
+// so canceling the context stops all of them, even when the consumer has
+// quit reading halfway through. Each function closes the channel it
+// returns once its goroutines have exited, which is how a consumer
+// ranging over it learns the pipeline has finished: no value is lost, and
+// no goroutine is left blocked on a send.
+package pipeline
+
+import (
+	"context"
+	"sync"
+)
+
+// Generate returns a channel that yields values in order and is then
+// closed.
+func Generate[T any](ctx context.Context, values ...T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for _, v := range values {
+			select {
+			case out <- v:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Stage applies fn to every value from in, using workers goroutines (at
+// least one). Results come out in the order they finish, which need not
+// be the order they went in. The returned channel is closed when in is
+// closed and drained, or ctx is canceled.
+func Stage[T, R any](ctx context.Context, in <-chan T, workers int, fn func(T) R) <-chan R {
+	outs := make([]<-chan R, max(workers, 1))
+	for i := range outs {
+		outs[i] = work(ctx, in, fn)
+	}
+	return FanIn(ctx, outs...)
+}
+
+// work is one stage worker: all workers of a stage read from the same
+// channel, which is what spreads the values across them.
+func work[T, R any](ctx context.Context, in <-chan T, fn func(T) R) <-chan R {
+	out := make(chan R)
+	go func() {
+		defer close(out)
+		for v := range OrDone(ctx.Done(), in) {
+			select {
+			case out <- fn(v):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// FanIn merges chans into one channel, which is closed once every input
+// is closed or ctx is canceled.
+func FanIn[T any](ctx context.Context, chans ...<-chan T) <-chan T {
+	out := make(chan T)
+	var wg sync.WaitGroup
+	for _, ch := range chans {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := range OrDone(ctx.Done(), ch) {
+				select {
+				case out <- v:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	// Close only after every forwarder has stopped sending.
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// OrDone forwards values from in until in is closed or done is closed,
+// whichever happens first. It is the done-channel form of cancellation
+// that predates context.Context: ranging over OrDone(done, in) instead of
+// in makes any loop stop promptly once done is closed.
+func OrDone[T any](done <-chan struct{}, in <-chan T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-done:
+				return
+			case v, ok := <-in:
+				if !ok {
+					return
+				}
+				select {
+				case out <- v:
+				case <-done:
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
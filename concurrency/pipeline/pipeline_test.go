@@ -0,0 +1,101 @@
+package pipeline
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"learning-go/testutil/leak"
+)
+
+func TestStageProcessesEveryValue(t *testing.T) {
+	leak.Verify(t)
+	ctx := context.Background()
+	in := make([]int, 100)
+	for i := range in {
+		in[i] = i
+	}
+
+	var got []int
+	for v := range Stage(ctx, Generate(ctx, in...), 4, func(n int) int { return n * n }) {
+		got = append(got, v)
+	}
+	slices.Sort(got)
+	if len(got) != len(in) {
+		t.Fatalf("got %d values, want %d", len(got), len(in))
+	}
+	for i, v := range got {
+		if v != i*i {
+			t.Fatalf("got[%d] = %d, want %d", i, v, i*i)
+		}
+	}
+}
+
+func TestFanInMergesAll(t *testing.T) {
+	leak.Verify(t)
+	ctx := context.Background()
+	merged := FanIn(ctx, Generate(ctx, 1, 2, 3), Generate(ctx, 4, 5), Generate[int](ctx))
+	var got []int
+	for v := range merged {
+		got = append(got, v)
+	}
+	slices.Sort(got)
+	if want := []int{1, 2, 3, 4, 5}; !slices.Equal(got, want) {
+		t.Errorf("FanIn gave %v, want %v", got, want)
+	}
+	if _, ok := <-FanIn[int](ctx); ok {
+		t.Error("FanIn of no channels must close straight away")
+	}
+}
+
+// Abandoning a pipeline part way through must stop every goroutine in it
+// once the context is canceled; leak.Verify fails the test otherwise.
+func TestCancelStopsPipeline(t *testing.T) {
+	leak.Verify(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make([]int, 1000)
+	out := Stage(ctx, Generate(ctx, in...), 8, func(n int) int { return n })
+	for range 3 {
+		<-out
+	}
+	cancel()
+}
+
+func TestCancelUnblocksSlowStage(t *testing.T) {
+	leak.Verify(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	out := Stage(ctx, Generate(ctx, 1, 2, 3), 2, func(n int) int {
+		time.Sleep(5 * time.Millisecond)
+		return n
+	})
+	cancel()
+	// The output closes even though nobody reads the values in flight.
+	deadline := time.After(5 * time.Second)
+	for {
+		select {
+		case _, ok := <-out:
+			if !ok {
+				return
+			}
+		case <-deadline:
+			t.Fatal("output not closed after cancel")
+		}
+	}
+}
+
+func TestOrDone(t *testing.T) {
+	leak.Verify(t)
+	done := make(chan struct{})
+	in := make(chan int)
+	out := OrDone(done, in)
+	go func() { in <- 1 }()
+	if v := <-out; v != 1 {
+		t.Errorf("got %d, want 1", v)
+	}
+	// in is never closed; closing done alone must end the loop.
+	close(done)
+	if _, ok := <-out; ok {
+		t.Error("OrDone delivered a value after done closed")
+	}
+}
@@ -0,0 +1,240 @@
+// Package pubsub is a publish/subscribe broker built only on channels and
+// a mutex. Topics are strings, and every subscriber gets its own buffered
+// channel of messages:
+//
+//	b := pubsub.New[string](pubsub.WithBuffer(16))
+//	msgs, cancel := b.Subscribe("builds")
+//	defer cancel()
+//	b.Publish("builds", "chapter12 passed")
+//	fmt.Println(<-msgs)
+//
+// Unlike package eventbus, which calls handler functions, a subscriber
+// here is just a channel, so it can be used in a select, ranged over, and
+// read at whatever pace the subscriber likes. What happens when it does
+// not keep up is the broker's Policy:
+//
+//   - With Drop (the default), a message that does not fit in a full
+//     buffer is thrown away and counted, and Publish never waits.
+//   - With Block, Publish waits until every subscriber has room, so a slow
+//     subscriber slows down the publisher, and through it everybody else.
+//
+// Handle wraps a subscription in a goroutine that calls a function for
+// every message, for subscribers that would rather not read a channel.
+//
+// Cancelling a subscription or closing the broker closes the subscriber's
+// channel after the messages already buffered in it, so a range loop over
+// the channel ends by itself. A Publish blocked on a subscriber is let go
+// when that subscriber cancels or the broker closes.
+package pubsub
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"learning-go/safe"
+)
+
+// ErrClosed is returned by Publish after Close.
+var ErrClosed = errors.New("pubsub: broker is closed")
+
+// Policy decides what Publish does when a subscriber's buffer is full.
+type Policy int
+
+const (
+	// Drop discards the message for that subscriber.
+	Drop Policy = iota
+	// Block waits until the subscriber has room.
+	Block
+)
+
+func (p Policy) String() string {
+	switch p {
+	case Drop:
+		return "drop"
+	case Block:
+		return "block"
+	}
+	return "Policy(?)"
+}
+
+type options struct {
+	buffer int
+	policy Policy
+}
+
+// Option configures New.
+type Option func(*options)
+
+// WithBuffer sets how many messages each subscriber's channel holds. The
+// default is 1; 0 makes every delivery a hand-over to a waiting reader,
+// which with Drop means a subscriber only gets messages it is already
+// waiting for.
+func WithBuffer(n int) Option {
+	if n < 0 {
+		panic("pubsub: negative buffer")
+	}
+	return func(o *options) { o.buffer = n }
+}
+
+// WithPolicy sets what happens when a subscriber's buffer is full. The
+// default is Drop.
+func WithPolicy(p Policy) Option {
+	return func(o *options) { o.policy = p }
+}
+
+// Broker routes messages of type T from publishers to the subscribers of
+// a topic. It is safe for concurrent use.
+type Broker[T any] struct {
+	opts    options
+	closing chan struct{} // closed by Close, to release blocked publishers
+	once    sync.Once     // closes closing
+	dropped atomic.Int64
+
+	// mu is held for reading while a message is delivered and for writing
+	// while a channel is closed, so Publish never sends on a closed one.
+	mu     sync.RWMutex
+	topics map[string][]*subscriber[T]
+	closed bool
+}
+
+type subscriber[T any] struct {
+	ch   chan T
+	done chan struct{} // closed by cancel, to release blocked publishers
+}
+
+// New returns a broker with no subscribers.
+func New[T any](opts ...Option) *Broker[T] {
+	o := options{buffer: 1, policy: Drop}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Broker[T]{
+		opts:    o,
+		closing: make(chan struct{}),
+		topics:  make(map[string][]*subscriber[T]),
+	}
+}
+
+// Subscribe returns a channel that receives every message published on
+// topic from now on, and a function that ends the subscription and closes
+// the channel. Calling cancel more than once, or after Close, is harmless.
+// Subscribing to a closed broker returns a channel that is already closed.
+func (b *Broker[T]) Subscribe(topic string) (<-chan T, func()) {
+	s := &subscriber[T]{
+		ch:   make(chan T, b.opts.buffer),
+		done: make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		close(s.ch)
+		return s.ch, func() {}
+	}
+	b.topics[topic] = append(b.topics[topic], s)
+
+	var once sync.Once
+	return s.ch, func() {
+		once.Do(func() { b.unsubscribe(topic, s) })
+	}
+}
+
+// Handle subscribes to topic and calls fn with every message in a
+// goroutine of its own, until cancel is called or the broker is closed.
+// The goroutine is started with safe.SafeGo: if fn panics, the panic is
+// logged by the safe package's Logger and the subscription is cancelled,
+// so a broken handler neither crashes the program nor, with the Block
+// policy, stalls the publishers by no longer reading.
+func (b *Broker[T]) Handle(topic string, fn func(T)) (cancel func()) {
+	msgs, cancel := b.Subscribe(topic)
+	safe.SafeGo(func() {
+		defer cancel()
+		for msg := range msgs {
+			fn(msg)
+		}
+	})
+	return cancel
+}
+
+// Publish sends msg to every subscriber of topic, in the order they
+// subscribed. With the Block policy it returns once every subscriber has
+// taken the message into its buffer, has cancelled, or the broker has
+// closed.
+func (b *Broker[T]) Publish(topic string, msg T) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		return ErrClosed
+	}
+	for _, s := range b.topics[topic] {
+		if b.opts.policy == Drop {
+			select {
+			case s.ch <- msg:
+			default:
+				b.dropped.Add(1)
+			}
+			continue
+		}
+		select {
+		case s.ch <- msg:
+		case <-s.done:
+		case <-b.closing:
+			return ErrClosed
+		}
+	}
+	return nil
+}
+
+// Subscribers returns the number of subscribers of topic.
+func (b *Broker[T]) Subscribers(topic string) int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.topics[topic])
+}
+
+// Dropped returns the number of messages the Drop policy has discarded
+// since the broker was created, over all subscribers.
+func (b *Broker[T]) Dropped() int64 {
+	return b.dropped.Load()
+}
+
+// Close ends every subscription and closes the subscribers' channels.
+// Publish calls waiting on a full subscriber return ErrClosed, and later
+// ones return it at once. Messages already buffered can still be read.
+func (b *Broker[T]) Close() {
+	// Wake blocked publishers first: they hold the read lock, which the
+	// write lock below has to wait for.
+	b.once.Do(func() { close(b.closing) })
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.closed = true
+	for _, subs := range b.topics {
+		for _, s := range subs {
+			close(s.ch)
+		}
+	}
+	b.topics = nil
+}
+
+func (b *Broker[T]) unsubscribe(topic string, s *subscriber[T]) {
+	close(s.done)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	subs := b.topics[topic]
+	for i, other := range subs {
+		if other == s {
+			b.topics[topic] = append(subs[:i:i], subs[i+1:]...)
+			if len(b.topics[topic]) == 0 {
+				delete(b.topics, topic)
+			}
+			close(s.ch)
+			return
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package pubsub
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"learning-go/safe"
+)
+
+// drain reads ch until it closes and returns what it got.
+func drain[T any](ch <-chan T) []T {
+	var got []T
+	for v := range ch {
+		got = append(got, v)
+	}
+	return got
+}
+
+// returned waits for a value on ch, failing t if none comes in time.
+func returned(t *testing.T, ch <-chan error) error {
+	t.Helper()
+	select {
+	case err := <-ch:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatal("Publish did not return")
+		return nil
+	}
+}
+
+// stillBlocked fails t if a value arrives on ch within a short wait.
+func stillBlocked(t *testing.T, ch <-chan error) {
+	t.Helper()
+	select {
+	case err := <-ch:
+		t.Fatalf("Publish returned %v while it should block", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func TestFanOutByTopic(t *testing.T) {
+	b := New[string](WithBuffer(4))
+	a1, cancel1 := b.Subscribe("a")
+	a2, cancel2 := b.Subscribe("a")
+	other, cancel3 := b.Subscribe("b")
+	if n := b.Subscribers("a"); n != 2 {
+		t.Errorf(`Subscribers("a") = %d, want 2`, n)
+	}
+
+	for _, msg := range []string{"x", "y"} {
+		if err := b.Publish("a", msg); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+	cancel1()
+	cancel2()
+	cancel3()
+	want := []string{"x", "y"}
+	for name, ch := range map[string]<-chan string{"a1": a1, "a2": a2} {
+		if got := drain(ch); !slices.Equal(got, want) {
+			t.Errorf("%s got %q, want %q", name, got, want)
+		}
+	}
+	if got := drain(other); len(got) != 0 {
+		t.Errorf("subscriber of another topic got %q", got)
+	}
+	if n := b.Subscribers("a"); n != 0 {
+		t.Errorf(`Subscribers("a") = %d after cancelling both`, n)
+	}
+}
+
+func TestDropPolicy(t *testing.T) {
+	b := New[int](WithBuffer(2))
+	msgs, cancel := b.Subscribe("t")
+	for i := range 5 {
+		if err := b.Publish("t", i); err != nil {
+			t.Fatalf("Publish(%d) = %v; Drop must never fail or wait", i, err)
+		}
+	}
+	cancel()
+	if got := drain(msgs); !slices.Equal(got, []int{0, 1}) {
+		t.Errorf("got %v, want the first two messages", got)
+	}
+	if n := b.Dropped(); n != 3 {
+		t.Errorf("Dropped() = %d, want 3", n)
+	}
+}
+
+func TestBlockPolicy(t *testing.T) {
+	b := New[int](WithBuffer(1), WithPolicy(Block))
+	msgs, cancel := b.Subscribe("t")
+	defer cancel()
+	b.Publish("t", 1)
+
+	done := make(chan error, 1)
+	go func() { done <- b.Publish("t", 2) }()
+	stillBlocked(t, done)
+	if v := <-msgs; v != 1 {
+		t.Errorf("got %d, want 1", v)
+	}
+	if err := returned(t, done); err != nil {
+		t.Errorf("Publish = %v", err)
+	}
+	if v := <-msgs; v != 2 {
+		t.Errorf("got %d, want 2", v)
+	}
+	if n := b.Dropped(); n != 0 {
+		t.Errorf("Dropped() = %d with the Block policy", n)
+	}
+}
+
+func TestCancelReleasesPublisher(t *testing.T) {
+	b := New[int](WithBuffer(1), WithPolicy(Block))
+	msgs, cancel := b.Subscribe("t")
+	b.Publish("t", 1)
+	done := make(chan error, 1)
+	go func() { done <- b.Publish("t", 2) }()
+	stillBlocked(t, done)
+
+	cancel()
+	cancel() // harmless
+	if err := returned(t, done); err != nil {
+		t.Errorf("Publish = %v, want nil once the subscriber left", err)
+	}
+	// What was buffered before the cancel can still be read.
+	if got := drain(msgs); !slices.Equal(got, []int{1}) {
+		t.Errorf("got %v, want [1]", got)
+	}
+}
+
+func TestClose(t *testing.T) {
+	b := New[int](WithBuffer(1), WithPolicy(Block))
+	msgs, cancel := b.Subscribe("t")
+	b.Publish("t", 1)
+	done := make(chan error, 1)
+	go func() { done <- b.Publish("t", 2) }()
+	stillBlocked(t, done)
+
+	b.Close()
+	if err := returned(t, done); !errors.Is(err, ErrClosed) {
+		t.Errorf("blocked Publish = %v, want ErrClosed", err)
+	}
+	if got := drain(msgs); !slices.Equal(got, []int{1}) {
+		t.Errorf("got %v, want [1]", got)
+	}
+	cancel() // after Close, harmless
+	b.Close()
+
+	if err := b.Publish("t", 3); !errors.Is(err, ErrClosed) {
+		t.Errorf("Publish after Close = %v, want ErrClosed", err)
+	}
+	late, _ := b.Subscribe("t")
+	if _, ok := <-late; ok {
+		t.Error("Subscribe after Close returned an open channel")
+	}
+}
+
+func TestHandle(t *testing.T) {
+	b := New[string](WithBuffer(4), WithPolicy(Block))
+	defer b.Close()
+	var mu sync.Mutex
+	var got []string
+	seen := make(chan struct{}, 4)
+	cancel := b.Handle("t", func(msg string) {
+		mu.Lock()
+		got = append(got, msg)
+		mu.Unlock()
+		seen <- struct{}{}
+	})
+	defer cancel()
+	b.Publish("t", "a")
+	b.Publish("t", "b")
+	for range 2 {
+		<-seen
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("handler saw %q, want [a b]", got)
+	}
+}
+
+func TestHandlePanicCancels(t *testing.T) {
+	logged := make(chan *safe.PanicError, 1)
+	defer safe.SetLogger(safe.SetLogger(func(err *safe.PanicError) { logged <- err }))
+
+	b := New[int](WithBuffer(0), WithPolicy(Block))
+	defer b.Close()
+	b.Handle("t", func(int) { panic("handler broke") })
+	if err := b.Publish("t", 1); err != nil {
+		t.Fatalf("Publish = %v", err)
+	}
+	select {
+	case err := <-logged:
+		if err.Value != "handler broke" {
+			t.Errorf("logged %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the panic was not logged")
+	}
+	// The subscription ended with the panic, so a Block publisher does not
+	// wait for a reader that is gone.
+	if n := b.Subscribers("t"); n != 0 {
+		t.Errorf("Subscribers = %d after the handler panicked", n)
+	}
+	if err := b.Publish("t", 2); err != nil {
+		t.Errorf("Publish after the handler died = %v", err)
+	}
+}
+
+// Run with -race: publishers, subscribers that come and go, and Close all
+// overlap.
+func TestConcurrentUse(t *testing.T) {
+	for _, policy := range []Policy{Drop, Block} {
+		t.Run(policy.String(), func(t *testing.T) {
+			b := New[int](WithBuffer(2), WithPolicy(policy))
+			var wg sync.WaitGroup
+			for range 4 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for i := range 200 {
+						if err := b.Publish("t", i); err != nil && !errors.Is(err, ErrClosed) {
+							t.Errorf("Publish = %v", err)
+							return
+						}
+					}
+				}()
+			}
+			for range 4 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for range 10 {
+						msgs, cancel := b.Subscribe("t")
+						for range 5 {
+							<-msgs
+						}
+						cancel()
+						drain(msgs)
+					}
+				}()
+			}
+			// Subscribers wait for messages that may never come once the
+			// publishers are done, so Close is what lets them finish.
+			time.Sleep(10 * time.Millisecond)
+			b.Close()
+			wg.Wait()
+		})
+	}
+}
+
+func TestPolicyString(t *testing.T) {
+	for p, want := range map[Policy]string{Drop: "drop", Block: "block", Policy(7): "Policy(?)"} {
+		if got := p.String(); got != want {
+			t.Errorf("Policy(%d).String() = %q, want %q", int(p), got, want)
+		}
+	}
+}
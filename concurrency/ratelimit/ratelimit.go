@@ -0,0 +1,234 @@
+// Package ratelimit limits how often something may happen, with the two
+// classic algorithms:
+//
+//   - A TokenBucket holds up to burst tokens and gains one every interval.
+//     Each event takes a token, so after a quiet spell a burst of events
+//     goes through at once, and the long-run rate is one per interval.
+//   - A LeakyBucket lets events out at exactly one per interval, never
+//     faster, and queues up to a fixed number of callers waiting for
+//     their turn.
+//
+// Both implement Limiter:
+//
+//	l := ratelimit.NewTokenBucket(100*time.Millisecond, 5)
+//	for _, job := range jobs {
+//		if err := l.Wait(ctx); err != nil {
+//			return err
+//		}
+//		run(job)
+//	}
+//
+// Time comes from a clock.Clock, so with a *clock.Fake a limiter behaves
+// the same on every run: nothing happens until the fake clock is advanced.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"learning-go/clock"
+)
+
+// Limiter decides whether an event may happen now.
+type Limiter interface {
+	// Allow reports whether an event may happen now, and if so counts it.
+	// It never waits.
+	Allow() bool
+	// Wait blocks until an event may happen and counts it, or returns an
+	// error if ctx is done first or the limiter cannot accept more waiters.
+	Wait(ctx context.Context) error
+}
+
+// ErrQueueFull is returned by LeakyBucket.Wait when as many callers as the
+// queue holds are already waiting.
+var ErrQueueFull = errors.New("ratelimit: queue full")
+
+type options struct {
+	clock clock.Clock
+}
+
+// Option configures NewTokenBucket and NewLeakyBucket.
+type Option func(*options)
+
+// WithClock sets the clock the limiter measures time with. Tests pass a
+// *clock.Fake; the default is clock.Real.
+func WithClock(c clock.Clock) Option {
+	return func(o *options) { o.clock = c }
+}
+
+func newOptions(opts []Option) options {
+	o := options{clock: clock.Real}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// TokenBucket is a token-bucket Limiter. Create one with NewTokenBucket;
+// it is safe for concurrent use.
+type TokenBucket struct {
+	every  time.Duration
+	clock  clock.Clock
+	tokens chan struct{} // the bucket: one value per available token
+
+	mu       sync.Mutex
+	credited time.Time // tokens have been added for all time up to here
+}
+
+// NewTokenBucket returns a bucket that starts full with burst tokens and
+// gains one every interval. It panics if every or burst is not positive.
+func NewTokenBucket(every time.Duration, burst int, opts ...Option) *TokenBucket {
+	if every <= 0 || burst <= 0 {
+		panic("ratelimit: NewTokenBucket needs a positive interval and burst")
+	}
+	o := newOptions(opts)
+	b := &TokenBucket{
+		every:    every,
+		clock:    o.clock,
+		tokens:   make(chan struct{}, burst),
+		credited: o.clock.Now(),
+	}
+	for range burst {
+		b.tokens <- struct{}{}
+	}
+	return b
+}
+
+// refill adds a token for every whole interval since the last refill and
+// returns how long until the next one is due. Tokens that do not fit in a
+// full bucket are lost, which is what caps a burst.
+//
+// Refilling from the clock when the bucket is used, rather than from a
+// goroutine fed by a ticker, means there is nothing to stop, and no token
+// is lost when ticks would have been dropped because nobody was receiving.
+func (b *TokenBucket) refill() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	elapsed := b.clock.Now().Sub(b.credited)
+	n := int(min(elapsed/b.every, time.Duration(cap(b.tokens))))
+	b.credited = b.credited.Add(elapsed / b.every * b.every)
+	for range n {
+		select {
+		case b.tokens <- struct{}{}:
+		default: // full
+		}
+	}
+	return b.every - elapsed%b.every
+}
+
+// Allow takes a token if there is one.
+func (b *TokenBucket) Allow() bool {
+	b.refill()
+	select {
+	case <-b.tokens:
+		return true
+	default:
+		return false
+	}
+}
+
+// Wait takes a token, waiting for one to be added if the bucket is empty.
+// Waiting callers are not served in any particular order.
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	for {
+		next := b.refill()
+		select {
+		case <-b.tokens:
+			return nil
+		default:
+		}
+		select {
+		case <-b.clock.After(next):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Tokens returns the number of tokens available now.
+func (b *TokenBucket) Tokens() int {
+	b.refill()
+	return len(b.tokens)
+}
+
+// LeakyBucket is a leaky-bucket Limiter. Create one with NewLeakyBucket;
+// it is safe for concurrent use.
+type LeakyBucket struct {
+	every time.Duration
+	queue int
+	clock clock.Clock
+
+	mu      sync.Mutex
+	next    time.Time // the earliest time the next event may happen
+	waiting int
+}
+
+// NewLeakyBucket returns a bucket that lets one event through every
+// interval and lets up to queue callers of Wait wait for their turn. It
+// panics if every is not positive or queue is negative.
+func NewLeakyBucket(every time.Duration, queue int, opts ...Option) *LeakyBucket {
+	if every <= 0 || queue < 0 {
+		panic("ratelimit: NewLeakyBucket needs a positive interval and a non-negative queue")
+	}
+	o := newOptions(opts)
+	return &LeakyBucket{every: every, queue: queue, clock: o.clock}
+}
+
+// Allow reports whether an interval has passed since the last event, with
+// no caller waiting.
+func (b *LeakyBucket) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.clock.Now()
+	if now.Before(b.next) {
+		return false
+	}
+	b.next = now.Add(b.every)
+	return true
+}
+
+// Wait reserves the next free slot, one interval after the previous one,
+// and sleeps until it comes. It returns ErrQueueFull without waiting if
+// the queue is full. If ctx is done first, the slot is given back when no
+// later caller has reserved one after it.
+func (b *LeakyBucket) Wait(ctx context.Context) error {
+	b.mu.Lock()
+	now := b.clock.Now()
+	if !b.next.After(now) {
+		b.next = now.Add(b.every)
+		b.mu.Unlock()
+		return nil
+	}
+	slot := b.next
+	if b.waiting >= b.queue {
+		b.mu.Unlock()
+		return ErrQueueFull
+	}
+	b.next = slot.Add(b.every)
+	b.waiting++
+	b.mu.Unlock()
+
+	var err error
+	select {
+	case <-b.clock.After(slot.Sub(now)):
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.waiting--
+	if err != nil && b.next.Equal(slot.Add(b.every)) {
+		b.next = slot
+	}
+	return err
+}
+
+// Waiting returns the number of callers blocked in Wait.
+func (b *LeakyBucket) Waiting() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.waiting
+}
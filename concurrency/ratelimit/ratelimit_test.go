@@ -0,0 +1,214 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"learning-go/clock"
+)
+
+var start = time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+// result waits for a value on ch, failing t if none comes in time. Only a
+// missing wake-up can take that long; the fake clock makes everything else
+// immediate.
+func result(t *testing.T, ch <-chan error) error {
+	t.Helper()
+	select {
+	case err := <-ch:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatal("Wait did not return")
+		return nil
+	}
+}
+
+func TestTokenBucketAllow(t *testing.T) {
+	fake := clock.NewFake(start)
+	b := NewTokenBucket(time.Second, 3, WithClock(fake))
+	for i := range 3 {
+		if !b.Allow() {
+			t.Fatalf("Allow() #%d = false on a full bucket", i+1)
+		}
+	}
+	if b.Allow() {
+		t.Fatal("Allow() = true on an empty bucket")
+	}
+
+	fake.Advance(999 * time.Millisecond)
+	if b.Allow() {
+		t.Error("a token was added before the interval passed")
+	}
+	fake.Advance(time.Millisecond)
+	if !b.Allow() || b.Allow() {
+		t.Error("want exactly one token after one interval")
+	}
+
+	// A long quiet spell fills the bucket but does not overflow it.
+	fake.Advance(time.Hour)
+	if n := b.Tokens(); n != 3 {
+		t.Errorf("Tokens() = %d after an hour, want the burst 3", n)
+	}
+}
+
+func TestTokenBucketKeepsPartialInterval(t *testing.T) {
+	fake := clock.NewFake(start)
+	b := NewTokenBucket(time.Second, 1, WithClock(fake))
+	b.Allow()
+	// Two half intervals make a whole one, even with a refill in between.
+	fake.Advance(500 * time.Millisecond)
+	if b.Allow() {
+		t.Fatal("token after half an interval")
+	}
+	fake.Advance(500 * time.Millisecond)
+	if !b.Allow() {
+		t.Error("no token after two half intervals")
+	}
+}
+
+func TestTokenBucketWait(t *testing.T) {
+	fake := clock.NewFake(start)
+	b := NewTokenBucket(time.Second, 2, WithClock(fake))
+	const callers = 6
+	done := make(chan error, callers)
+	for range callers {
+		go func() { done <- b.Wait(context.Background()) }()
+	}
+	// The two tokens in the bucket go straight away, then one caller is
+	// let through per interval.
+	for range 2 {
+		if err := result(t, done); err != nil {
+			t.Fatalf("Wait = %v", err)
+		}
+	}
+	for left := callers - 2; left > 0; left-- {
+		fake.BlockUntil(left)
+		select {
+		case <-done:
+			t.Fatalf("a caller got through with %d waiting and no time passed", left)
+		default:
+		}
+		fake.Advance(time.Second)
+		if err := result(t, done); err != nil {
+			t.Fatalf("Wait = %v", err)
+		}
+	}
+	if n := b.Tokens(); n != 0 {
+		t.Errorf("Tokens() = %d, want 0", n)
+	}
+}
+
+func TestTokenBucketWaitCanceled(t *testing.T) {
+	fake := clock.NewFake(start)
+	b := NewTokenBucket(time.Second, 1, WithClock(fake))
+	b.Allow()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- b.Wait(ctx) }()
+	fake.BlockUntil(1)
+	cancel()
+	if err := result(t, done); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait = %v, want context.Canceled", err)
+	}
+}
+
+func TestLeakyBucketAllow(t *testing.T) {
+	fake := clock.NewFake(start)
+	b := NewLeakyBucket(time.Second, 0, WithClock(fake))
+	if !b.Allow() {
+		t.Fatal("first Allow() = false")
+	}
+	// Unlike a token bucket, a quiet spell earns no burst.
+	fake.Advance(time.Hour)
+	if !b.Allow() || b.Allow() {
+		t.Error("want one event per interval, however long the bucket was idle")
+	}
+	fake.Advance(time.Second)
+	if !b.Allow() {
+		t.Error("Allow() = false one interval after the last event")
+	}
+}
+
+func TestLeakyBucketQueue(t *testing.T) {
+	fake := clock.NewFake(start)
+	b := NewLeakyBucket(time.Second, 2, WithClock(fake))
+	ctx := context.Background()
+	if err := b.Wait(ctx); err != nil {
+		t.Fatalf("first Wait = %v", err)
+	}
+
+	done := make(chan error, 2)
+	for n := 1; n <= 2; n++ {
+		go func() { done <- b.Wait(ctx) }()
+		fake.BlockUntil(n)
+	}
+	if n := b.Waiting(); n != 2 {
+		t.Errorf("Waiting() = %d, want 2", n)
+	}
+	if err := b.Wait(ctx); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("Wait with a full queue = %v, want ErrQueueFull", err)
+	}
+
+	// The queued callers leave one interval apart.
+	for range 2 {
+		fake.Advance(time.Second)
+		if err := result(t, done); err != nil {
+			t.Fatalf("queued Wait = %v", err)
+		}
+	}
+	if n := b.Waiting(); n != 0 {
+		t.Errorf("Waiting() = %d after the queue drained", n)
+	}
+}
+
+func TestLeakyBucketCancelGivesBackSlot(t *testing.T) {
+	fake := clock.NewFake(start)
+	b := NewLeakyBucket(time.Second, 1, WithClock(fake))
+	b.Allow()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	canceled := make(chan error, 1)
+	go func() { canceled <- b.Wait(ctx) }()
+	fake.BlockUntil(1)
+	cancel()
+	if err := result(t, canceled); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Wait = %v, want context.Canceled", err)
+	}
+
+	// The next caller takes the slot the canceled one gave up, one interval
+	// from the start, not two. The canceled caller's timer is still pending
+	// in the fake clock, so two timers are waiting.
+	done := make(chan error, 1)
+	go func() { done <- b.Wait(context.Background()) }()
+	fake.BlockUntil(2)
+	fake.Advance(time.Second)
+	if err := result(t, done); err != nil {
+		t.Errorf("Wait = %v", err)
+	}
+}
+
+func TestConstructorsPanic(t *testing.T) {
+	for name, f := range map[string]func(){
+		"NewTokenBucket(0, 1)":  func() { NewTokenBucket(0, 1) },
+		"NewTokenBucket(1, 0)":  func() { NewTokenBucket(time.Second, 0) },
+		"NewLeakyBucket(0, 1)":  func() { NewLeakyBucket(0, 1) },
+		"NewLeakyBucket(1, -1)": func() { NewLeakyBucket(time.Second, -1) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("did not panic")
+				}
+			}()
+			f()
+		})
+	}
+}
+
+// Both buckets are Limiters.
+var (
+	_ Limiter = (*TokenBucket)(nil)
+	_ Limiter = (*LeakyBucket)(nil)
+)
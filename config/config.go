@@ -0,0 +1,92 @@
+// Package config loads the learning tool's settings from a JSON file and
+// can keep them up to date while the program runs: Watch reloads the file
+// whenever it changes, swaps the new settings in atomically, and announces
+// the change on an event bus.
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"learning-go/errs"
+)
+
+// Config holds the settings. It is treated as immutable once loaded;
+// a reload produces a new *Config rather than changing the old one, so a
+// reader holding one never sees a half-applied update.
+type Config struct {
+	// User is the name submissions and reminders are sent under.
+	User string `json:"user"`
+	// Timeout bounds how long a solution may run, as a duration string
+	// such as "10s".
+	Timeout Duration `json:"timeout"`
+	// MemoryMB caps a solution's memory.
+	MemoryMB int `json:"memory_mb"`
+	// SlackWebhook and the Telegram settings configure package notify.
+	SlackWebhook  string `json:"slack_webhook,omitempty"`
+	TelegramToken string `json:"telegram_token,omitempty"`
+	TelegramChat  string `json:"telegram_chat,omitempty"`
+}
+
+// Default is used for settings missing from the file.
+var Default = Config{
+	Timeout:  Duration(10 * time.Second),
+	MemoryMB: 256,
+}
+
+// Duration is a time.Duration written as a string in JSON ("1m30s").
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// Load reads and validates the file at path. Settings not in the file keep
+// their Default values.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse is like Load for data already read.
+func Parse(data []byte) (*Config, error) {
+	c := Default
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+	if err := c.validate(); err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+	return &c, nil
+}
+
+func (c *Config) validate() error {
+	if c.Timeout <= 0 {
+		return errs.Invalid("timeout", "must be positive, got %v", time.Duration(c.Timeout))
+	}
+	if c.MemoryMB < 0 {
+		return errs.Invalid("memory_mb", "must not be negative, got %d", c.MemoryMB)
+	}
+	if (c.TelegramToken == "") != (c.TelegramChat == "") {
+		return errs.Invalid("telegram_token", "telegram_token and telegram_chat must be set together")
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"learning-go/errs"
+)
+
+func TestParse(t *testing.T) {
+	c, err := Parse([]byte(`{"user": "ada", "timeout": "1m30s"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.User != "ada" || time.Duration(c.Timeout) != 90*time.Second || c.MemoryMB != Default.MemoryMB {
+		t.Errorf("Parse = %+v, want ada, 1m30s and the default memory", c)
+	}
+
+	for name, data := range map[string]string{
+		"not JSON":           `user: ada`,
+		"bad duration":       `{"timeout": "soon"}`,
+		"zero timeout":       `{"timeout": "0s"}`,
+		"negative memory":    `{"memory_mb": -1}`,
+		"telegram half done": `{"telegram_token": "123:abc"}`,
+	} {
+		if _, err := Parse([]byte(data)); err == nil {
+			t.Errorf("%s: Parse succeeded", name)
+		}
+	}
+	_, err = Parse([]byte(`{"memory_mb": -1}`))
+	if errs.ExitCode(err) != errs.ExitUsage {
+		t.Errorf("an invalid setting = %v, want a validation error", err)
+	}
+}
+
+func TestDurationJSON(t *testing.T) {
+	data, err := json.Marshal(Duration(2500 * time.Millisecond))
+	if err != nil || string(data) != `"2.5s"` {
+		t.Errorf("Marshal = %s, %v; want \"2.5s\"", data, err)
+	}
+	var d Duration
+	if err := json.Unmarshal(data, &d); err != nil || d != Duration(2500*time.Millisecond) {
+		t.Errorf("Unmarshal = %v, %v", time.Duration(d), err)
+	}
+	if err := json.Unmarshal([]byte(`30`), &d); err == nil {
+		t.Error("a bare number unmarshaled as a Duration")
+	}
+}
@@ -0,0 +1,119 @@
+package config
+
+import (
+	"context"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"learning-go/eventbus"
+)
+
+// Changed is published on the bus after a successful reload.
+type Changed struct {
+	Old, New *Config
+}
+
+// ReloadFailed is published on the bus when the file changed but could not
+// be loaded. The previous settings stay active.
+type ReloadFailed struct {
+	Err error
+}
+
+// settle is how long Watch waits after a change before reloading. Editors
+// and "cp" often write a file in several steps; reloading on the first
+// event would read it half written.
+const settle = 50 * time.Millisecond
+
+// Watcher holds the current settings of a watched file.
+type Watcher struct {
+	current atomic.Pointer[Config]
+	done    chan struct{}
+}
+
+// Current returns the active settings. It is safe to call from any
+// goroutine at any time and never blocks.
+func (w *Watcher) Current() *Config {
+	return w.current.Load()
+}
+
+// Done is closed when the watcher has stopped.
+func (w *Watcher) Done() <-chan struct{} {
+	return w.done
+}
+
+// Watch loads path and then reloads it whenever it changes, until ctx is
+// done. Each reload announces a Changed or ReloadFailed event on bus; bus
+// may be nil if nobody needs to know.
+//
+// The directory is watched rather than the file itself, so replacements
+// by rename (which is how most editors save) are seen too.
+func Watch(ctx context.Context, path string, bus *eventbus.Bus) (*Watcher, error) {
+	cfg, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := fw.Add(filepath.Dir(path)); err != nil {
+		fw.Close()
+		return nil, err
+	}
+
+	w := &Watcher{done: make(chan struct{})}
+	w.current.Store(cfg)
+	go w.loop(ctx, fw, filepath.Clean(path), bus)
+	return w, nil
+}
+
+func (w *Watcher) loop(ctx context.Context, fw *fsnotify.Watcher, path string, bus *eventbus.Bus) {
+	defer close(w.done)
+	defer fw.Close()
+
+	// A stopped timer that is reset on every event, so a burst of events
+	// causes one reload once the file has settled.
+	timer := time.NewTimer(settle)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev, ok := <-fw.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(ev.Name) == path && ev.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 {
+				timer.Reset(settle)
+			}
+		case err, ok := <-fw.Errors:
+			if !ok {
+				return
+			}
+			publish(bus, ReloadFailed{Err: err})
+		case <-timer.C:
+			w.reload(path, bus)
+		}
+	}
+}
+
+func (w *Watcher) reload(path string, bus *eventbus.Bus) {
+	cfg, err := Load(path)
+	if err != nil {
+		publish(bus, ReloadFailed{Err: err})
+		return
+	}
+	old := w.current.Swap(cfg)
+	publish(bus, Changed{Old: old, New: cfg})
+}
+
+func publish[T any](bus *eventbus.Bus, event T) {
+	if bus != nil {
+		eventbus.Publish(bus, event)
+	}
+}
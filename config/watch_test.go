@@ -0,0 +1,119 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"learning-go/eventbus"
+)
+
+// wait returns the next value from c, failing the test after a few
+// seconds: file system events can take a moment to arrive.
+func wait[T any](t *testing.T, c <-chan T) T {
+	t.Helper()
+	select {
+	case v := <-c:
+		return v
+	case <-time.After(5 * time.Second):
+		t.Fatalf("no %T event", *new(T))
+		panic("unreachable")
+	}
+}
+
+func TestWatch(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("config.json", `{"user": "ada", "timeout": "5s"}`)
+
+	bus := eventbus.New()
+	defer bus.Close()
+	changes := make(chan Changed, 4)
+	failures := make(chan ReloadFailed, 4)
+	eventbus.Subscribe(bus, func(e Changed) { changes <- e })
+	eventbus.Subscribe(bus, func(e ReloadFailed) { failures <- e })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w, err := Watch(ctx, path, bus)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Current().User != "ada" {
+		t.Fatalf("Current().User = %q, want ada", w.Current().User)
+	}
+
+	// Readers check that user and timeout always belong together, while
+	// the file is rewritten underneath them.
+	valid := map[string]Duration{
+		"ada":   Duration(5 * time.Second),
+		"grace": Duration(30 * time.Second),
+		"linus": Duration(time.Minute),
+	}
+	var torn atomic.Int64
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				if c := w.Current(); valid[c.User] != c.Timeout {
+					torn.Add(1)
+				}
+			}
+		}()
+	}
+
+	write("config.json", `{"user": "grace", "timeout": "30s"}`)
+	if e := wait(t, changes); e.Old.User != "ada" || e.New.User != "grace" {
+		t.Errorf("Changed = %s -> %s, want ada -> grace", e.Old.User, e.New.User)
+	}
+
+	write("config.json", `{"user": "broken"`)
+	wait(t, failures)
+	if w.Current().User != "grace" {
+		t.Errorf("after a failed reload Current().User = %q, want grace kept", w.Current().User)
+	}
+
+	// Editors save by writing a new file and renaming it over the old one.
+	write("config.json.tmp", `{"user": "linus", "timeout": "1m"}`)
+	if err := os.Rename(filepath.Join(dir, "config.json.tmp"), path); err != nil {
+		t.Fatal(err)
+	}
+	if e := wait(t, changes); e.New.User != "linus" {
+		t.Errorf("after the rename Changed.New.User = %q, want linus", e.New.User)
+	}
+	if w.Current().User != "linus" {
+		t.Errorf("Current().User = %q, want linus", w.Current().User)
+	}
+
+	close(stop)
+	wg.Wait()
+	if n := torn.Load(); n > 0 {
+		t.Errorf("readers saw %d half-applied configurations", n)
+	}
+
+	cancel()
+	wait(t, w.Done())
+}
+
+func TestWatchMissingFile(t *testing.T) {
+	if _, err := Watch(context.Background(), filepath.Join(t.TempDir(), "missing.json"), nil); err == nil {
+		t.Error("Watch of a missing file succeeded")
+	}
+}
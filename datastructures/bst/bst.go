@@ -0,0 +1,202 @@
+// Package bst implements a generic, unbalanced binary search tree.
+//
+// A Tree holds distinct values in sorted order. Insert, Delete and
+// Contains take time proportional to the tree's height: O(log n) when
+// values arrive in random order, O(n) when they arrive already sorted,
+// since nothing rebalances the tree.
+//
+// The values can be walked in order three ways, matching the styles of
+// chapter 5's exercise 1: Walk takes a callback, Chan returns a channel fed
+// by a goroutine, and All returns an iter.Seq for range-over-func.
+package bst
+
+import (
+	"cmp"
+	"iter"
+)
+
+type node[T any] struct {
+	val         T
+	left, right *node[T]
+}
+
+// Tree is a binary search tree. Create one with New or NewFunc.
+type Tree[T any] struct {
+	root    *node[T]
+	len     int
+	compare func(a, b T) int
+}
+
+// New returns an empty tree ordered by the natural order of T.
+func New[T cmp.Ordered]() *Tree[T] {
+	return NewFunc(cmp.Compare[T])
+}
+
+// NewFunc returns an empty tree ordered by compare, which returns a
+// negative number when a < b, zero when they are equal and a positive
+// number when a > b.
+func NewFunc[T any](compare func(a, b T) int) *Tree[T] {
+	return &Tree[T]{compare: compare}
+}
+
+// Len returns the number of values in the tree.
+func (t *Tree[T]) Len() int {
+	return t.len
+}
+
+// Insert adds v and reports whether it was added; it is not if an equal
+// value is already in the tree.
+func (t *Tree[T]) Insert(v T) bool {
+	link := &t.root
+	for *link != nil {
+		switch c := t.compare(v, (*link).val); {
+		case c < 0:
+			link = &(*link).left
+		case c > 0:
+			link = &(*link).right
+		default:
+			return false
+		}
+	}
+	*link = &node[T]{val: v}
+	t.len++
+	return true
+}
+
+// Contains reports whether a value equal to v is in the tree.
+func (t *Tree[T]) Contains(v T) bool {
+	_, ok := t.Search(v)
+	return ok
+}
+
+// Search returns the value in the tree equal to v. With a compare
+// function that looks at only part of T (a key), it finds the whole value
+// stored under that key.
+func (t *Tree[T]) Search(v T) (T, bool) {
+	for n := t.root; n != nil; {
+		switch c := t.compare(v, n.val); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n.val, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// Delete removes the value equal to v and reports whether there was one.
+func (t *Tree[T]) Delete(v T) bool {
+	link := &t.root
+	for *link != nil {
+		n := *link
+		switch c := t.compare(v, n.val); {
+		case c < 0:
+			link = &n.left
+			continue
+		case c > 0:
+			link = &n.right
+			continue
+		}
+
+		switch {
+		case n.left == nil:
+			*link = n.right
+		case n.right == nil:
+			*link = n.left
+		default:
+			// Two children: replace the value with its successor, the
+			// smallest value of the right subtree, and unlink that node
+			// instead. It has no left child, so that is the easy case.
+			succ := &n.right
+			for (*succ).left != nil {
+				succ = &(*succ).left
+			}
+			n.val = (*succ).val
+			*succ = (*succ).right
+		}
+		t.len--
+		return true
+	}
+	return false
+}
+
+// Min returns the smallest value, or false if the tree is empty.
+func (t *Tree[T]) Min() (T, bool) {
+	if t.root == nil {
+		var zero T
+		return zero, false
+	}
+	n := t.root
+	for n.left != nil {
+		n = n.left
+	}
+	return n.val, true
+}
+
+// Max returns the largest value, or false if the tree is empty.
+func (t *Tree[T]) Max() (T, bool) {
+	if t.root == nil {
+		var zero T
+		return zero, false
+	}
+	n := t.root
+	for n.right != nil {
+		n = n.right
+	}
+	return n.val, true
+}
+
+// Height returns the number of nodes on the longest path from the root to
+// a leaf; 0 for an empty tree.
+func (t *Tree[T]) Height() int {
+	var height func(n *node[T]) int
+	height = func(n *node[T]) int {
+		if n == nil {
+			return 0
+		}
+		return 1 + max(height(n.left), height(n.right))
+	}
+	return height(t.root)
+}
+
+// Walk calls yield for each value in order until yield returns false. It
+// reports whether the walk ran to the end.
+func (t *Tree[T]) Walk(yield func(T) bool) bool {
+	return walk(t.root, yield)
+}
+
+func walk[T any](n *node[T], yield func(T) bool) bool {
+	if n == nil {
+		return true
+	}
+	return walk(n.left, yield) && yield(n.val) && walk(n.right, yield)
+}
+
+// Chan returns a channel of the values in order, sent by a new goroutine.
+// The channel is closed after the last value. To stop early, close done;
+// otherwise the goroutine blocks forever on its next send.
+func (t *Tree[T]) Chan(done <-chan struct{}) <-chan T {
+	ch := make(chan T)
+	go func() {
+		defer close(ch)
+		t.Walk(func(v T) bool {
+			select {
+			case ch <- v:
+				return true
+			case <-done:
+				return false
+			}
+		})
+	}()
+	return ch
+}
+
+// All returns an iterator over the values in order.
+func (t *Tree[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		t.Walk(yield)
+	}
+}
@@ -0,0 +1,175 @@
+package bst
+
+import (
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"learning-go/testutil/leak"
+)
+
+// build inserts values into a new tree in the order given.
+func build(values ...int) *Tree[int] {
+	t := New[int]()
+	for _, v := range values {
+		t.Insert(v)
+	}
+	return t
+}
+
+func TestInsertSearch(t *testing.T) {
+	tr := build(5, 3, 8, 1, 4, 7, 9)
+	if tr.Insert(4) {
+		t.Error("Insert(4) added a duplicate")
+	}
+	if tr.Len() != 7 {
+		t.Errorf("Len() = %d, want 7", tr.Len())
+	}
+	for _, v := range []int{1, 3, 4, 5, 7, 8, 9} {
+		if !tr.Contains(v) {
+			t.Errorf("Contains(%d) = false", v)
+		}
+	}
+	for _, v := range []int{0, 2, 6, 10} {
+		if tr.Contains(v) {
+			t.Errorf("Contains(%d) = true", v)
+		}
+	}
+	if v, ok := tr.Min(); !ok || v != 1 {
+		t.Errorf("Min() = %d, %v", v, ok)
+	}
+	if v, ok := tr.Max(); !ok || v != 9 {
+		t.Errorf("Max() = %d, %v", v, ok)
+	}
+	if h := tr.Height(); h != 3 {
+		t.Errorf("Height() = %d, want 3", h)
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	tr := New[string]()
+	if _, ok := tr.Min(); ok {
+		t.Error("Min of an empty tree succeeded")
+	}
+	if _, ok := tr.Max(); ok {
+		t.Error("Max of an empty tree succeeded")
+	}
+	if tr.Delete("x") || tr.Height() != 0 || slices.Collect(tr.All()) != nil {
+		t.Error("empty tree is not empty")
+	}
+}
+
+// Search returns the stored value, which with NewFunc may differ from the
+// one searched for in fields the comparison ignores.
+func TestSearchFunc(t *testing.T) {
+	tr := NewFunc(func(a, b string) int { return strings.Compare(strings.ToLower(a), strings.ToLower(b)) })
+	tr.Insert("Go")
+	if v, ok := tr.Search("GO"); !ok || v != "Go" {
+		t.Errorf("Search(GO) = %q, %v; want Go", v, ok)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	//       5
+	//     3   8
+	//    1 4 7 9
+	//           10
+	tests := []struct {
+		name string
+		del  int
+		want []int
+	}{
+		{"leaf", 1, []int{3, 4, 5, 7, 8, 9, 10}},
+		{"one child", 9, []int{1, 3, 4, 5, 7, 8, 10}},
+		{"two children", 8, []int{1, 3, 4, 5, 7, 9, 10}},
+		{"root", 5, []int{1, 3, 4, 7, 8, 9, 10}},
+		{"missing", 6, []int{1, 3, 4, 5, 7, 8, 9, 10}},
+	}
+	for _, tt := range tests {
+		tr := build(5, 3, 8, 1, 4, 7, 9, 10)
+		if deleted := tr.Delete(tt.del); deleted != (tt.name != "missing") {
+			t.Errorf("%s: Delete(%d) = %v", tt.name, tt.del, deleted)
+		}
+		if got := slices.Collect(tr.All()); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: after Delete(%d): %v, want %v", tt.name, tt.del, got, tt.want)
+		}
+		if tr.Len() != len(tt.want) {
+			t.Errorf("%s: Len() = %d, want %d", tt.name, tr.Len(), len(tt.want))
+		}
+	}
+}
+
+func TestRandomAgainstSort(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	tr := New[int]()
+	set := make(map[int]bool)
+	for range 2000 {
+		v := r.IntN(500)
+		if r.IntN(3) == 0 {
+			if tr.Delete(v) != set[v] {
+				t.Fatalf("Delete(%d) disagrees with the map", v)
+			}
+			delete(set, v)
+		} else {
+			if tr.Insert(v) == set[v] {
+				t.Fatalf("Insert(%d) disagrees with the map", v)
+			}
+			set[v] = true
+		}
+	}
+	var want []int
+	for v := range set {
+		want = append(want, v)
+	}
+	slices.Sort(want)
+	if got := slices.Collect(tr.All()); !slices.Equal(got, want) {
+		t.Fatalf("All() = %v, want %v", got, want)
+	}
+}
+
+func TestIterationStyles(t *testing.T) {
+	leak.Verify(t)
+	tr := build(4, 2, 6, 1, 3, 5, 7)
+	want := []int{1, 2, 3, 4, 5, 6, 7}
+
+	var walked []int
+	if !tr.Walk(func(v int) bool { walked = append(walked, v); return true }) {
+		t.Error("Walk did not report running to the end")
+	}
+	var chans []int
+	for v := range tr.Chan(nil) {
+		chans = append(chans, v)
+	}
+	for name, got := range map[string][]int{"Walk": walked, "Chan": chans, "All": slices.Collect(tr.All())} {
+		if !slices.Equal(got, want) {
+			t.Errorf("%s gave %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestStopEarly(t *testing.T) {
+	leak.Verify(t)
+	tr := build(4, 2, 6, 1, 3, 5, 7)
+
+	var walked []int
+	if tr.Walk(func(v int) bool { walked = append(walked, v); return v < 3 }) {
+		t.Error("Walk reported running to the end after yield returned false")
+	}
+	if !slices.Equal(walked, []int{1, 2, 3}) {
+		t.Errorf("Walk visited %v, want [1 2 3]", walked)
+	}
+
+	// Closing done must end the goroutine behind Chan; leak.Verify
+	// checks that it did.
+	done := make(chan struct{})
+	ch := tr.Chan(done)
+	<-ch
+	close(done)
+
+	for v := range tr.All() {
+		if v == 2 {
+			break
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package collections
+
+import (
+	"slices"
+	"testing"
+)
+
+// The slice and linked versions share their methods, so each test runs
+// against both through these interfaces.
+
+type stack interface {
+	Push(int)
+	Pop() (int, bool)
+	Peek() (int, bool)
+	Len() int
+}
+
+// A queue has a stack's methods; only the order they give differs.
+type queue = stack
+
+type deque interface {
+	PushBack(int)
+	PushFront(int)
+	PopBack() (int, bool)
+	PopFront() (int, bool)
+	Front() (int, bool)
+	Back() (int, bool)
+	Len() int
+}
+
+func TestStack(t *testing.T) {
+	for name, s := range map[string]stack{"Stack": &Stack[int]{}, "LinkedStack": &LinkedStack[int]{}} {
+		t.Run(name, func(t *testing.T) {
+			if _, ok := s.Pop(); ok {
+				t.Error("Pop on an empty stack succeeded")
+			}
+			for i := range 5 {
+				s.Push(i)
+			}
+			if v, ok := s.Peek(); !ok || v != 4 {
+				t.Errorf("Peek() = %d, %v; want 4", v, ok)
+			}
+			for want := 4; want >= 0; want-- {
+				if v, ok := s.Pop(); !ok || v != want {
+					t.Fatalf("Pop() = %d, %v; want %d", v, ok, want)
+				}
+			}
+			if s.Len() != 0 {
+				t.Errorf("Len() = %d after popping everything", s.Len())
+			}
+		})
+	}
+}
+
+func TestQueue(t *testing.T) {
+	for name, q := range map[string]queue{"Queue": &Queue[int]{}, "LinkedQueue": &LinkedQueue[int]{}} {
+		t.Run(name, func(t *testing.T) {
+			if _, ok := q.Peek(); ok {
+				t.Error("Peek on an empty queue succeeded")
+			}
+			// Interleave pushes and pops so a ring buffer wraps around.
+			next, want := 0, 0
+			for range 20 {
+				for range 3 {
+					q.Push(next)
+					next++
+				}
+				for range 2 {
+					if v, ok := q.Pop(); !ok || v != want {
+						t.Fatalf("Pop() = %d, %v; want %d", v, ok, want)
+					}
+					want++
+				}
+			}
+			if q.Len() != next-want {
+				t.Errorf("Len() = %d, want %d", q.Len(), next-want)
+			}
+			for ; want < next; want++ {
+				if v, _ := q.Pop(); v != want {
+					t.Fatalf("Pop() = %d, want %d", v, want)
+				}
+			}
+			if _, ok := q.Pop(); ok {
+				t.Error("Pop on an emptied queue succeeded")
+			}
+		})
+	}
+}
+
+func TestDeque(t *testing.T) {
+	for name, d := range map[string]deque{"Deque": &Deque[int]{}, "LinkedDeque": &LinkedDeque[int]{}} {
+		t.Run(name, func(t *testing.T) {
+			if _, ok := d.PopBack(); ok {
+				t.Error("PopBack on an empty deque succeeded")
+			}
+			// PushFront 9 down to 0 and PushBack 10 up to 19 leave 0..19,
+			// growing the buffer with elements on both sides of its start.
+			for i := range 10 {
+				d.PushFront(9 - i)
+				d.PushBack(10 + i)
+			}
+			if d.Len() != 20 {
+				t.Fatalf("Len() = %d, want 20", d.Len())
+			}
+			if v, _ := d.Front(); v != 0 {
+				t.Errorf("Front() = %d, want 0", v)
+			}
+			if v, _ := d.Back(); v != 19 {
+				t.Errorf("Back() = %d, want 19", v)
+			}
+			for lo, hi := 0, 19; lo < hi; lo, hi = lo+1, hi-1 {
+				if v, ok := d.PopFront(); !ok || v != lo {
+					t.Fatalf("PopFront() = %d, %v; want %d", v, ok, lo)
+				}
+				if v, ok := d.PopBack(); !ok || v != hi {
+					t.Fatalf("PopBack() = %d, %v; want %d", v, ok, hi)
+				}
+			}
+			if d.Len() != 0 {
+				t.Errorf("Len() = %d after popping everything", d.Len())
+			}
+		})
+	}
+}
+
+func TestDequeOrder(t *testing.T) {
+	for name, d := range map[string]deque{"Deque": &Deque[int]{}, "LinkedDeque": &LinkedDeque[int]{}} {
+		t.Run(name, func(t *testing.T) {
+			d.PushBack(2)
+			d.PushFront(1)
+			d.PushBack(3)
+			d.PushFront(0)
+			var got []int
+			for {
+				v, ok := d.PopFront()
+				if !ok {
+					break
+				}
+				got = append(got, v)
+			}
+			if want := []int{0, 1, 2, 3}; !slices.Equal(got, want) {
+				t.Errorf("PopFront order %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestSet(t *testing.T) {
+	a := NewSet(1, 2, 3, 4)
+	b := NewSet(3, 4, 5)
+	for _, tt := range []struct {
+		name string
+		got  Set[int]
+		want []int
+	}{
+		{"Union", a.Union(b), []int{1, 2, 3, 4, 5}},
+		{"Intersection", a.Intersection(b), []int{3, 4}},
+		{"Difference", a.Difference(b), []int{1, 2}},
+		{"SymmetricDifference", a.SymmetricDifference(b), []int{1, 2, 5}},
+	} {
+		if got := Sorted(tt.got); !slices.Equal(got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if got := Sorted(a); !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Errorf("operations modified a: %v", got)
+	}
+	if !NewSet(3, 4).SubsetOf(a) || b.SubsetOf(a) {
+		t.Error("SubsetOf is wrong")
+	}
+	if !a.Equal(NewSet(4, 3, 2, 1, 1)) || a.Equal(b) {
+		t.Error("Equal is wrong")
+	}
+}
+
+func TestSetAddRemove(t *testing.T) {
+	s := NewSet[string]()
+	s.Add("go")
+	s.Add("go")
+	s.Add("rust")
+	if s.Len() != 2 || !s.Contains("go") {
+		t.Errorf("after adds: %v", s)
+	}
+	s.Remove("go")
+	s.Remove("zig")
+	if s.Contains("go") || s.Len() != 1 {
+		t.Errorf("after removes: %v", s)
+	}
+	if got := NewSet(10, 9, 1).String(); got != "{1 10 9}" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+const benchN = 1000
+
+func BenchmarkStack(b *testing.B) {
+	for name, s := range map[string]stack{"slice": &Stack[int]{}, "linked": &LinkedStack[int]{}} {
+		b.Run(name, func(b *testing.B) {
+			for range b.N {
+				for i := range benchN {
+					s.Push(i)
+				}
+				for range benchN {
+					s.Pop()
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkQueue(b *testing.B) {
+	for name, q := range map[string]queue{"slice": &Queue[int]{}, "linked": &LinkedQueue[int]{}} {
+		b.Run(name, func(b *testing.B) {
+			for range b.N {
+				for i := range benchN {
+					q.Push(i)
+				}
+				for range benchN {
+					q.Pop()
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkDeque(b *testing.B) {
+	for name, d := range map[string]deque{"slice": &Deque[int]{}, "linked": &LinkedDeque[int]{}} {
+		b.Run(name, func(b *testing.B) {
+			for range b.N {
+				for i := range benchN {
+					d.PushFront(i)
+					d.PushBack(i)
+				}
+				for range benchN {
+					d.PopBack()
+					d.PopFront()
+				}
+			}
+		})
+	}
+}
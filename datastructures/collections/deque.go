@@ -0,0 +1,183 @@
+package collections
+
+// Deque is a double-ended queue backed by a ring buffer: a slice used in a
+// circle, so pushing and popping at either end is O(1) without shifting
+// elements. The buffer doubles when it is full.
+type Deque[T any] struct {
+	buf  []T
+	head int // index of the front element
+	n    int
+}
+
+// PushBack adds v at the back.
+func (d *Deque[T]) PushBack(v T) {
+	d.grow()
+	d.buf[(d.head+d.n)%len(d.buf)] = v
+	d.n++
+}
+
+// PushFront adds v at the front.
+func (d *Deque[T]) PushFront(v T) {
+	d.grow()
+	d.head = (d.head - 1 + len(d.buf)) % len(d.buf)
+	d.buf[d.head] = v
+	d.n++
+}
+
+// PopFront removes and returns the front element, or false if the deque
+// is empty.
+func (d *Deque[T]) PopFront() (T, bool) {
+	var zero T
+	if d.n == 0 {
+		return zero, false
+	}
+	v := d.buf[d.head]
+	d.buf[d.head] = zero
+	d.head = (d.head + 1) % len(d.buf)
+	d.n--
+	return v, true
+}
+
+// PopBack removes and returns the back element, or false if the deque is
+// empty.
+func (d *Deque[T]) PopBack() (T, bool) {
+	var zero T
+	if d.n == 0 {
+		return zero, false
+	}
+	i := (d.head + d.n - 1) % len(d.buf)
+	v := d.buf[i]
+	d.buf[i] = zero
+	d.n--
+	return v, true
+}
+
+// Front returns the front element without removing it.
+func (d *Deque[T]) Front() (T, bool) {
+	if d.n == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.head], true
+}
+
+// Back returns the back element without removing it.
+func (d *Deque[T]) Back() (T, bool) {
+	if d.n == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[(d.head+d.n-1)%len(d.buf)], true
+}
+
+// Len returns the number of elements.
+func (d *Deque[T]) Len() int {
+	return d.n
+}
+
+// grow makes room for one more element, copying the elements to the start
+// of a buffer twice the size when the current one is full.
+func (d *Deque[T]) grow() {
+	if d.n < len(d.buf) {
+		return
+	}
+	buf := make([]T, max(2*len(d.buf), 8))
+	// The elements may wrap around the end; copy both parts in order.
+	n := copy(buf, d.buf[d.head:])
+	copy(buf[n:], d.buf[:d.head])
+	d.buf, d.head = buf, 0
+}
+
+type dnode[T any] struct {
+	val        T
+	prev, next *dnode[T]
+}
+
+// LinkedDeque is a double-ended queue backed by a doubly linked list.
+type LinkedDeque[T any] struct {
+	front, back *dnode[T]
+	n           int
+}
+
+// PushBack adds v at the back.
+func (d *LinkedDeque[T]) PushBack(v T) {
+	node := &dnode[T]{val: v, prev: d.back}
+	if d.back == nil {
+		d.front = node
+	} else {
+		d.back.next = node
+	}
+	d.back = node
+	d.n++
+}
+
+// PushFront adds v at the front.
+func (d *LinkedDeque[T]) PushFront(v T) {
+	node := &dnode[T]{val: v, next: d.front}
+	if d.front == nil {
+		d.back = node
+	} else {
+		d.front.prev = node
+	}
+	d.front = node
+	d.n++
+}
+
+// PopFront removes and returns the front element, or false if the deque
+// is empty.
+func (d *LinkedDeque[T]) PopFront() (T, bool) {
+	if d.front == nil {
+		var zero T
+		return zero, false
+	}
+	node := d.front
+	d.front = node.next
+	if d.front == nil {
+		d.back = nil
+	} else {
+		d.front.prev = nil
+	}
+	d.n--
+	return node.val, true
+}
+
+// PopBack removes and returns the back element, or false if the deque is
+// empty.
+func (d *LinkedDeque[T]) PopBack() (T, bool) {
+	if d.back == nil {
+		var zero T
+		return zero, false
+	}
+	node := d.back
+	d.back = node.prev
+	if d.back == nil {
+		d.front = nil
+	} else {
+		d.back.next = nil
+	}
+	d.n--
+	return node.val, true
+}
+
+// Front returns the front element without removing it.
+func (d *LinkedDeque[T]) Front() (T, bool) {
+	if d.front == nil {
+		var zero T
+		return zero, false
+	}
+	return d.front.val, true
+}
+
+// Back returns the back element without removing it.
+func (d *LinkedDeque[T]) Back() (T, bool) {
+	if d.back == nil {
+		var zero T
+		return zero, false
+	}
+	return d.back.val, true
+}
+
+// Len returns the number of elements.
+func (d *LinkedDeque[T]) Len() int {
+	return d.n
+}
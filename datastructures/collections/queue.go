@@ -0,0 +1,61 @@
+package collections
+
+import "learning-go/datastructures/linkedlist"
+
+// Queue is a first-in, first-out queue backed by a ring buffer. It is a
+// Deque used from one end each way.
+type Queue[T any] struct {
+	d Deque[T]
+}
+
+// Push adds v at the back of the queue.
+func (q *Queue[T]) Push(v T) {
+	q.d.PushBack(v)
+}
+
+// Pop removes and returns the front element, or false if the queue is
+// empty.
+func (q *Queue[T]) Pop() (T, bool) {
+	return q.d.PopFront()
+}
+
+// Peek returns the front element without removing it.
+func (q *Queue[T]) Peek() (T, bool) {
+	return q.d.Front()
+}
+
+// Len returns the number of elements.
+func (q *Queue[T]) Len() int {
+	return q.d.Len()
+}
+
+// LinkedQueue is a first-in, first-out queue backed by a linked list,
+// which keeps a tail pointer so both ends are O(1).
+type LinkedQueue[T any] struct {
+	list linkedlist.List[T]
+}
+
+// Push adds v at the back of the queue.
+func (q *LinkedQueue[T]) Push(v T) {
+	q.list.PushBack(v)
+}
+
+// Pop removes and returns the front element, or false if the queue is
+// empty.
+func (q *LinkedQueue[T]) Pop() (T, bool) {
+	if q.list.Len() == 0 {
+		var zero T
+		return zero, false
+	}
+	return q.list.Remove(0), true
+}
+
+// Peek returns the front element without removing it.
+func (q *LinkedQueue[T]) Peek() (T, bool) {
+	return q.list.Front()
+}
+
+// Len returns the number of elements.
+func (q *LinkedQueue[T]) Len() int {
+	return q.list.Len()
+}
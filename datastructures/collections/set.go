@@ -0,0 +1,137 @@
+package collections
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Set is an unordered set of comparable values, backed by a map. Copying
+// a Set copies a reference: both copies see the same elements.
+type Set[T comparable] struct {
+	m map[T]struct{}
+}
+
+// NewSet returns a set holding values.
+func NewSet[T comparable](values ...T) Set[T] {
+	s := Set[T]{m: make(map[T]struct{}, len(values))}
+	for _, v := range values {
+		s.m[v] = struct{}{}
+	}
+	return s
+}
+
+// Add adds v. Adding a value that is already present does nothing.
+func (s Set[T]) Add(v T) {
+	s.m[v] = struct{}{}
+}
+
+// Remove removes v if it is present.
+func (s Set[T]) Remove(v T) {
+	delete(s.m, v)
+}
+
+// Contains reports whether v is in the set.
+func (s Set[T]) Contains(v T) bool {
+	_, ok := s.m[v]
+	return ok
+}
+
+// Len returns the number of elements.
+func (s Set[T]) Len() int {
+	return len(s.m)
+}
+
+// All returns an iterator over the elements in no particular order.
+func (s Set[T]) All() iter.Seq[T] {
+	return maps.Keys(s.m)
+}
+
+// Union returns a new set with the elements that are in s, other or both.
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	out := NewSet[T]()
+	for v := range s.m {
+		out.m[v] = struct{}{}
+	}
+	for v := range other.m {
+		out.m[v] = struct{}{}
+	}
+	return out
+}
+
+// Intersection returns a new set with the elements that are in both s and
+// other.
+func (s Set[T]) Intersection(other Set[T]) Set[T] {
+	// Loop over the smaller set; the lookups go to the larger one.
+	small, large := s, other
+	if small.Len() > large.Len() {
+		small, large = large, small
+	}
+	out := NewSet[T]()
+	for v := range small.m {
+		if large.Contains(v) {
+			out.m[v] = struct{}{}
+		}
+	}
+	return out
+}
+
+// Difference returns a new set with the elements of s that are not in
+// other.
+func (s Set[T]) Difference(other Set[T]) Set[T] {
+	out := NewSet[T]()
+	for v := range s.m {
+		if !other.Contains(v) {
+			out.m[v] = struct{}{}
+		}
+	}
+	return out
+}
+
+// SymmetricDifference returns a new set with the elements that are in
+// exactly one of s and other.
+func (s Set[T]) SymmetricDifference(other Set[T]) Set[T] {
+	out := s.Difference(other)
+	for v := range other.m {
+		if !s.Contains(v) {
+			out.m[v] = struct{}{}
+		}
+	}
+	return out
+}
+
+// SubsetOf reports whether every element of s is also in other.
+func (s Set[T]) SubsetOf(other Set[T]) bool {
+	for v := range s.m {
+		if !other.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal reports whether s and other hold the same elements.
+func (s Set[T]) Equal(other Set[T]) bool {
+	return s.Len() == other.Len() && s.SubsetOf(other)
+}
+
+// Sorted returns the elements of s in ascending order. It is a function
+// rather than a method because it needs cmp.Ordered, a stricter
+// constraint than the comparable Set is declared with.
+func Sorted[T cmp.Ordered](s Set[T]) []T {
+	return slices.Sorted(s.All())
+}
+
+// String formats the set as "{a b c}", sorted by the elements' formatted
+// text so the same set always prints the same way.
+func (s Set[T]) String() string {
+	parts := make([]string, 0, s.Len())
+	for v := range s.m {
+		parts = append(parts, fmt.Sprint(v))
+	}
+	slices.Sort(parts)
+	return "{" + strings.Join(parts, " ") + "}"
+}
@@ -0,0 +1,82 @@
+// Package collections provides generic Stack, Queue, Deque and Set types.
+//
+// Stack, Queue and Deque each come in two versions with the same methods:
+// one backed by a slice (Stack, Queue, Deque) and one backed by linked
+// nodes (LinkedStack, LinkedQueue, LinkedDeque). The slice versions keep
+// their elements next to each other in memory and allocate only when
+// they grow; the linked versions allocate one node per element but never
+// copy. The package benchmarks, and chapter 8's exercise 5, compare the two.
+//
+// The zero value of every type is empty and ready to use, except Set,
+// which must be made with NewSet. None of the types is safe for
+// concurrent use.
+package collections
+
+import "learning-go/datastructures/linkedlist"
+
+// Stack is a last-in, first-out stack backed by a slice.
+type Stack[T any] struct {
+	items []T
+}
+
+// Push adds v to the top of the stack.
+func (s *Stack[T]) Push(v T) {
+	s.items = append(s.items, v)
+}
+
+// Pop removes and returns the top element, or false if the stack is empty.
+func (s *Stack[T]) Pop() (T, bool) {
+	var zero T
+	if len(s.items) == 0 {
+		return zero, false
+	}
+	last := len(s.items) - 1
+	v := s.items[last]
+	// Clear the slot so the backing array does not keep v alive.
+	s.items[last] = zero
+	s.items = s.items[:last]
+	return v, true
+}
+
+// Peek returns the top element without removing it.
+func (s *Stack[T]) Peek() (T, bool) {
+	if len(s.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return s.items[len(s.items)-1], true
+}
+
+// Len returns the number of elements.
+func (s *Stack[T]) Len() int {
+	return len(s.items)
+}
+
+// LinkedStack is a last-in, first-out stack backed by a linked list.
+type LinkedStack[T any] struct {
+	list linkedlist.List[T]
+}
+
+// Push adds v to the top of the stack.
+func (s *LinkedStack[T]) Push(v T) {
+	s.list.PushFront(v)
+}
+
+// Pop removes and returns the top element, or false if the stack is empty.
+func (s *LinkedStack[T]) Pop() (T, bool) {
+	if s.list.Len() == 0 {
+		var zero T
+		return zero, false
+	}
+	return s.list.Remove(0), true
+}
+
+// Peek returns the top element without removing it.
+func (s *LinkedStack[T]) Peek() (T, bool) {
+	return s.list.Front()
+}
+
+// Len returns the number of elements.
+func (s *LinkedStack[T]) Len() int {
+	return s.list.Len()
+}
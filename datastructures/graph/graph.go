@@ -0,0 +1,277 @@
+// Package graph implements directed and undirected weighted graphs stored
+// as adjacency lists, with breadth-first and depth-first search,
+// topological sorting and Dijkstra's shortest paths.
+//
+//	g := graph.NewDirected[string]()
+//	g.AddEdge("intro", "pointers", 1)
+//	g.AddEdge("pointers", "interfaces", 1)
+//	order, err := g.TopoSort() // [intro pointers interfaces]
+//
+// Nodes are any comparable type. Nodes and edges are kept in the order
+// they were added, so every traversal visits them in a predictable order.
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"learning-go/datastructures/heap"
+)
+
+var (
+	// ErrCycle is returned by TopoSort when the graph has a cycle, so no
+	// order can put every node after all of its predecessors.
+	ErrCycle = errors.New("graph has a cycle")
+	// ErrNoPath is returned by ShortestPath when the target cannot be
+	// reached from the source.
+	ErrNoPath = errors.New("no path")
+	// ErrNegativeWeight is returned by ShortestPath when it meets an edge
+	// with a negative weight, which Dijkstra's algorithm cannot handle.
+	ErrNegativeWeight = errors.New("negative edge weight")
+	// ErrNodeNotFound means a node passed to a search is not in the graph.
+	ErrNodeNotFound = errors.New("node not in graph")
+)
+
+// Edge is a connection to another node.
+type Edge[N comparable] struct {
+	To     N
+	Weight float64
+}
+
+// Graph is a weighted graph. Create one with NewDirected or NewUndirected.
+type Graph[N comparable] struct {
+	directed bool
+	nodes    []N
+	adj      map[N][]Edge[N]
+	edges    int
+}
+
+// NewDirected returns an empty graph whose edges go one way.
+func NewDirected[N comparable]() *Graph[N] {
+	return &Graph[N]{directed: true, adj: make(map[N][]Edge[N])}
+}
+
+// NewUndirected returns an empty graph whose edges go both ways.
+func NewUndirected[N comparable]() *Graph[N] {
+	return &Graph[N]{adj: make(map[N][]Edge[N])}
+}
+
+// Directed reports whether the graph's edges go one way.
+func (g *Graph[N]) Directed() bool {
+	return g.directed
+}
+
+// AddNode adds n if it is not already in the graph.
+func (g *Graph[N]) AddNode(n N) {
+	if _, ok := g.adj[n]; !ok {
+		g.adj[n] = nil
+		g.nodes = append(g.nodes, n)
+	}
+}
+
+// AddEdge adds an edge from one node to another, adding the nodes if
+// needed. In an undirected graph the edge goes both ways.
+func (g *Graph[N]) AddEdge(from, to N, weight float64) {
+	g.AddNode(from)
+	g.AddNode(to)
+	g.adj[from] = append(g.adj[from], Edge[N]{To: to, Weight: weight})
+	if !g.directed && from != to {
+		g.adj[to] = append(g.adj[to], Edge[N]{To: from, Weight: weight})
+	}
+	g.edges++
+}
+
+// Has reports whether n is in the graph.
+func (g *Graph[N]) Has(n N) bool {
+	_, ok := g.adj[n]
+	return ok
+}
+
+// Nodes returns every node in the order they were added.
+func (g *Graph[N]) Nodes() []N {
+	return slices.Clone(g.nodes)
+}
+
+// Edges returns the edges leaving n.
+func (g *Graph[N]) Edges(n N) []Edge[N] {
+	return slices.Clone(g.adj[n])
+}
+
+// Len returns the number of nodes.
+func (g *Graph[N]) Len() int {
+	return len(g.nodes)
+}
+
+// EdgeCount returns the number of edges added; an undirected edge counts
+// once.
+func (g *Graph[N]) EdgeCount() int {
+	return g.edges
+}
+
+// BFS visits every node reachable from start in breadth-first order:
+// start, then its neighbours, then theirs. It stops early if visit returns
+// false.
+func (g *Graph[N]) BFS(start N, visit func(N) bool) error {
+	if !g.Has(start) {
+		return fmt.Errorf("%v: %w", start, ErrNodeNotFound)
+	}
+	seen := map[N]bool{start: true}
+	queue := []N{start}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		if !visit(n) {
+			return nil
+		}
+		for _, e := range g.adj[n] {
+			if !seen[e.To] {
+				seen[e.To] = true
+				queue = append(queue, e.To)
+			}
+		}
+	}
+	return nil
+}
+
+// DFS visits every node reachable from start in depth-first order,
+// following each edge as far as it goes before backtracking. It stops
+// early if visit returns false.
+func (g *Graph[N]) DFS(start N, visit func(N) bool) error {
+	if !g.Has(start) {
+		return fmt.Errorf("%v: %w", start, ErrNodeNotFound)
+	}
+	seen := make(map[N]bool)
+	var dfs func(n N) bool
+	dfs = func(n N) bool {
+		seen[n] = true
+		if !visit(n) {
+			return false
+		}
+		for _, e := range g.adj[n] {
+			if !seen[e.To] && !dfs(e.To) {
+				return false
+			}
+		}
+		return true
+	}
+	dfs(start)
+	return nil
+}
+
+// CycleError is returned by TopoSort. It wraps ErrCycle and names the
+// nodes that could not be ordered: those on a cycle or after one.
+type CycleError[N comparable] struct {
+	Remaining []N
+}
+
+func (e *CycleError[N]) Error() string {
+	return fmt.Sprintf("%v: cannot order %v", ErrCycle, e.Remaining)
+}
+
+// Unwrap makes errors.Is(err, ErrCycle) true.
+func (e *CycleError[N]) Unwrap() error {
+	return ErrCycle
+}
+
+// TopoSort returns the nodes of a directed graph ordered so that every
+// edge goes from an earlier node to a later one. Among nodes that could
+// come next, the one added first wins. If the graph has a cycle, it
+// returns a *CycleError.
+func (g *Graph[N]) TopoSort() ([]N, error) {
+	if !g.directed {
+		return nil, errors.New("graph: TopoSort needs a directed graph")
+	}
+	// Kahn's algorithm: repeatedly take a node nothing points to any more.
+	indegree := make(map[N]int, len(g.nodes))
+	for _, n := range g.nodes {
+		for _, e := range g.adj[n] {
+			indegree[e.To]++
+		}
+	}
+	position := make(map[N]int, len(g.nodes))
+	for i, n := range g.nodes {
+		position[n] = i
+	}
+	ready := heap.New(func(a, b N) bool { return position[a] < position[b] })
+	for _, n := range g.nodes {
+		if indegree[n] == 0 {
+			ready.Push(n)
+		}
+	}
+
+	order := make([]N, 0, len(g.nodes))
+	for n, ok := ready.Pop(); ok; n, ok = ready.Pop() {
+		order = append(order, n)
+		for _, e := range g.adj[n] {
+			if indegree[e.To]--; indegree[e.To] == 0 {
+				ready.Push(e.To)
+			}
+		}
+	}
+	if len(order) < len(g.nodes) {
+		var remaining []N
+		for _, n := range g.nodes {
+			if indegree[n] > 0 {
+				remaining = append(remaining, n)
+			}
+		}
+		return order, &CycleError[N]{Remaining: remaining}
+	}
+	return order, nil
+}
+
+// ShortestPath returns the cheapest path from one node to another, as the
+// list of nodes from first to last, and its total weight. It uses
+// Dijkstra's algorithm, so every edge it meets must have a non-negative
+// weight.
+func (g *Graph[N]) ShortestPath(from, to N) ([]N, float64, error) {
+	for _, n := range []N{from, to} {
+		if !g.Has(n) {
+			return nil, 0, fmt.Errorf("%v: %w", n, ErrNodeNotFound)
+		}
+	}
+
+	type item struct {
+		node N
+		dist float64
+	}
+	dist := map[N]float64{from: 0}
+	prev := make(map[N]N)
+	done := make(map[N]bool)
+	queue := heap.New(func(a, b item) bool { return a.dist < b.dist }, item{from, 0})
+
+	for it, ok := queue.Pop(); ok; it, ok = queue.Pop() {
+		if done[it.node] {
+			// A stale entry: the node was already reached more cheaply.
+			continue
+		}
+		done[it.node] = true
+		if it.node == to {
+			break
+		}
+		for _, e := range g.adj[it.node] {
+			if e.Weight < 0 {
+				return nil, 0, fmt.Errorf("%v -> %v (%v): %w", it.node, e.To, e.Weight, ErrNegativeWeight)
+			}
+			d := it.dist + e.Weight
+			if old, seen := dist[e.To]; !seen || d < old {
+				dist[e.To] = d
+				prev[e.To] = it.node
+				queue.Push(item{e.To, d})
+			}
+		}
+	}
+
+	if !done[to] {
+		return nil, math.Inf(1), fmt.Errorf("%v to %v: %w", from, to, ErrNoPath)
+	}
+	path := []N{to}
+	for n := to; n != from; {
+		n = prev[n]
+		path = append(path, n)
+	}
+	slices.Reverse(path)
+	return path, dist[to], nil
+}
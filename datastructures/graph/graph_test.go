@@ -0,0 +1,200 @@
+package graph
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// visited collects the nodes a search visits.
+func visited(search func(int, func(int) bool) error, start int) ([]int, error) {
+	var got []int
+	err := search(start, func(n int) bool {
+		got = append(got, n)
+		return true
+	})
+	return got, err
+}
+
+// sample returns this graph, every edge of weight 1 and pointing down:
+//
+//	  1
+//	 / \
+//	2   3
+//	|   |
+//	4   5
+//	 \ /
+//	  6
+func sample() *Graph[int] {
+	g := NewDirected[int]()
+	for _, e := range [][2]int{{1, 2}, {1, 3}, {2, 4}, {3, 5}, {4, 6}, {5, 6}} {
+		g.AddEdge(e[0], e[1], 1)
+	}
+	return g
+}
+
+func TestSearchOrder(t *testing.T) {
+	g := sample()
+	if got, _ := visited(g.BFS, 1); !slices.Equal(got, []int{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("BFS = %v", got)
+	}
+	if got, _ := visited(g.DFS, 1); !slices.Equal(got, []int{1, 2, 4, 6, 3, 5}) {
+		t.Errorf("DFS = %v", got)
+	}
+	// Edges go one way: from 3 only 5 and 6 are reachable.
+	if got, _ := visited(g.BFS, 3); !slices.Equal(got, []int{3, 5, 6}) {
+		t.Errorf("BFS from 3 = %v", got)
+	}
+	if _, err := visited(g.DFS, 9); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("DFS from a missing node: err = %v", err)
+	}
+}
+
+func TestSearchStopsEarly(t *testing.T) {
+	g := sample()
+	for name, search := range map[string]func(int, func(int) bool) error{"BFS": g.BFS, "DFS": g.DFS} {
+		n := 0
+		search(1, func(int) bool { n++; return n < 3 })
+		if n != 3 {
+			t.Errorf("%s visited %d nodes after visit returned false at the third", name, n)
+		}
+	}
+}
+
+func TestUndirected(t *testing.T) {
+	g := NewUndirected[string]()
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("b", "c", 1)
+	if g.Directed() || g.EdgeCount() != 2 || g.Len() != 3 {
+		t.Errorf("Directed %v, EdgeCount %d, Len %d", g.Directed(), g.EdgeCount(), g.Len())
+	}
+	var got []string
+	g.BFS("c", func(n string) bool { got = append(got, n); return true })
+	if !slices.Equal(got, []string{"c", "b", "a"}) {
+		t.Errorf("BFS from c = %v; edges must go both ways", got)
+	}
+	if _, err := g.TopoSort(); err == nil {
+		t.Error("TopoSort of an undirected graph succeeded")
+	}
+}
+
+func TestTopoSort(t *testing.T) {
+	order, err := sample().TopoSort()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{1, 2, 3, 4, 5, 6}; !slices.Equal(order, want) {
+		t.Errorf("TopoSort = %v, want %v", order, want)
+	}
+
+	g := sample()
+	g.AddEdge(6, 2, 1) // 2 -> 4 -> 6 -> 2
+	_, err = g.TopoSort()
+	var cycle *CycleError[int]
+	if !errors.Is(err, ErrCycle) || !errors.As(err, &cycle) {
+		t.Fatalf("TopoSort with a cycle: err = %v", err)
+	}
+	if want := []int{2, 4, 6}; !slices.Equal(cycle.Remaining, want) {
+		t.Errorf("Remaining = %v, want %v", cycle.Remaining, want)
+	}
+}
+
+func TestTopoSortRandom(t *testing.T) {
+	g := randomDAG(300, 1500)
+	order, err := g.TopoSort()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(order) != g.Len() {
+		t.Fatalf("TopoSort gave %d nodes of %d", len(order), g.Len())
+	}
+	position := make(map[int]int)
+	for i, n := range order {
+		position[n] = i
+	}
+	for _, n := range g.Nodes() {
+		for _, e := range g.Edges(n) {
+			if position[n] >= position[e.To] {
+				t.Fatalf("edge %d -> %d goes backwards in %v", n, e.To, order)
+			}
+		}
+	}
+}
+
+func TestShortestPath(t *testing.T) {
+	g := NewDirected[string]()
+	g.AddEdge("a", "b", 4)
+	g.AddEdge("a", "c", 1)
+	g.AddEdge("c", "b", 2)
+	g.AddEdge("b", "d", 1)
+	g.AddEdge("c", "d", 5)
+	g.AddNode("island")
+
+	path, cost, err := g.ShortestPath("a", "d")
+	if err != nil || cost != 4 || !slices.Equal(path, []string{"a", "c", "b", "d"}) {
+		t.Errorf("ShortestPath(a, d) = %v, %v, %v; want [a c b d], 4", path, cost, err)
+	}
+	if path, cost, err := g.ShortestPath("a", "a"); err != nil || cost != 0 || !slices.Equal(path, []string{"a"}) {
+		t.Errorf("ShortestPath(a, a) = %v, %v, %v", path, cost, err)
+	}
+	if _, _, err := g.ShortestPath("a", "island"); !errors.Is(err, ErrNoPath) {
+		t.Errorf("ShortestPath to an unreachable node: err = %v", err)
+	}
+	if _, _, err := g.ShortestPath("a", "nowhere"); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("ShortestPath to a missing node: err = %v", err)
+	}
+	g.AddEdge("d", "e", -1)
+	if _, _, err := g.ShortestPath("a", "e"); !errors.Is(err, ErrNegativeWeight) {
+		t.Errorf("ShortestPath over a negative edge: err = %v", err)
+	}
+}
+
+// randomDAG returns n nodes joined by up to m random edges, each from a
+// lower to a higher number so that there is no cycle.
+func randomDAG(n, m int) *Graph[int] {
+	r := rand.New(rand.NewPCG(uint64(n), uint64(m)))
+	g := NewDirected[int]()
+	for i := range n {
+		g.AddNode(i)
+	}
+	for range m {
+		a, b := r.IntN(n), r.IntN(n)
+		if a != b {
+			g.AddEdge(min(a, b), max(a, b), float64(1+r.IntN(100)))
+		}
+	}
+	return g
+}
+
+func BenchmarkBFS(b *testing.B) {
+	g := randomDAG(2000, 10000)
+	b.ReportAllocs()
+	for range b.N {
+		g.BFS(0, func(int) bool { return true })
+	}
+}
+
+func BenchmarkDFS(b *testing.B) {
+	g := randomDAG(2000, 10000)
+	b.ReportAllocs()
+	for range b.N {
+		g.DFS(0, func(int) bool { return true })
+	}
+}
+
+func BenchmarkTopoSort(b *testing.B) {
+	g := randomDAG(2000, 10000)
+	b.ReportAllocs()
+	for range b.N {
+		g.TopoSort()
+	}
+}
+
+func BenchmarkShortestPath(b *testing.B) {
+	g := randomDAG(2000, 10000)
+	b.ReportAllocs()
+	for range b.N {
+		g.ShortestPath(0, 1999)
+	}
+}
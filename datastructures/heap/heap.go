@@ -0,0 +1,117 @@
+// Package heap implements a generic binary heap, a type-safe counterpart
+// of container/heap.
+//
+// container/heap works on any type implementing its five-method
+// Interface, and Push and Pop pass values as any, so every caller writes
+// the same boilerplate and type-asserts what comes out. Heap[T] keeps the
+// same algorithm and ordering guarantees (the smallest element by less is
+// always at index 0; Push and Pop are O(log n); Init is O(n)) behind an
+// API that takes and returns T.
+//
+//	h := heap.NewMin[int]()
+//	h.Push(3)
+//	h.Push(1)
+//	v, _ := h.Pop() // 1
+package heap
+
+import "cmp"
+
+// Heap is a binary heap ordered by a less function: Pop always returns an
+// element e such that less(x, e) is false for every other x. The zero
+// value is not usable; create one with New, NewMin or NewMax.
+type Heap[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+// New returns a heap ordered by less and holding values, which it
+// arranges into a heap in O(n). The heap takes ownership of the values
+// slice.
+func New[T any](less func(a, b T) bool, values ...T) *Heap[T] {
+	h := &Heap[T]{items: values, less: less}
+	// Like heap.Init: sift down every node that has children, from the
+	// last one up to the root.
+	for i := len(h.items)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+	return h
+}
+
+// NewMin returns a heap that pops the smallest value first.
+func NewMin[T cmp.Ordered](values ...T) *Heap[T] {
+	return New(cmp.Less[T], values...)
+}
+
+// NewMax returns a heap that pops the largest value first.
+func NewMax[T cmp.Ordered](values ...T) *Heap[T] {
+	return New(func(a, b T) bool { return cmp.Less(b, a) }, values...)
+}
+
+// Len returns the number of elements.
+func (h *Heap[T]) Len() int {
+	return len(h.items)
+}
+
+// Push adds v.
+func (h *Heap[T]) Push(v T) {
+	h.items = append(h.items, v)
+	h.up(len(h.items) - 1)
+}
+
+// Pop removes and returns the first element, or false if the heap is
+// empty.
+func (h *Heap[T]) Pop() (T, bool) {
+	var zero T
+	if len(h.items) == 0 {
+		return zero, false
+	}
+	last := len(h.items) - 1
+	h.items[0], h.items[last] = h.items[last], h.items[0]
+	v := h.items[last]
+	h.items[last] = zero
+	h.items = h.items[:last]
+	h.down(0)
+	return v, true
+}
+
+// Peek returns the first element without removing it.
+func (h *Heap[T]) Peek() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.items[0], true
+}
+
+// up moves the element at i toward the root until its parent is not
+// greater than it.
+func (h *Heap[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.less(h.items[i], h.items[parent]) {
+			break
+		}
+		h.items[i], h.items[parent] = h.items[parent], h.items[i]
+		i = parent
+	}
+}
+
+// down moves the element at i toward the leaves until neither child is
+// less than it.
+func (h *Heap[T]) down(i int) {
+	n := len(h.items)
+	for {
+		smallest := i
+		if l := 2*i + 1; l < n && h.less(h.items[l], h.items[smallest]) {
+			smallest = l
+		}
+		if r := 2*i + 2; r < n && h.less(h.items[r], h.items[smallest]) {
+			smallest = r
+		}
+		if smallest == i {
+			return
+		}
+		h.items[i], h.items[smallest] = h.items[smallest], h.items[i]
+		i = smallest
+	}
+}
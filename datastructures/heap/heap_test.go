@@ -0,0 +1,84 @@
+package heap
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// drain pops h until it is empty.
+func drain[T any](h *Heap[T]) []T {
+	var out []T
+	for h.Len() > 0 {
+		v, _ := h.Pop()
+		out = append(out, v)
+	}
+	return out
+}
+
+func TestMinMax(t *testing.T) {
+	values := []int{5, 2, 8, 2, 9, 1, 7}
+	sorted := slices.Sorted(slices.Values(values))
+
+	if got := drain(NewMin(slices.Clone(values)...)); !slices.Equal(got, sorted) {
+		t.Errorf("NewMin popped %v, want %v", got, sorted)
+	}
+	slices.Reverse(sorted)
+	if got := drain(NewMax(slices.Clone(values)...)); !slices.Equal(got, sorted) {
+		t.Errorf("NewMax popped %v, want %v", got, sorted)
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	h := NewMin[int]()
+	if _, ok := h.Pop(); ok {
+		t.Error("Pop on an empty heap succeeded")
+	}
+	if _, ok := h.Peek(); ok {
+		t.Error("Peek on an empty heap succeeded")
+	}
+}
+
+func TestPushPopRandom(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	h := NewMin[int]()
+	var ref []int
+	for range 5000 {
+		if len(ref) > 0 && r.IntN(3) == 0 {
+			slices.Sort(ref)
+			want := ref[0]
+			ref = ref[1:]
+			if peek, _ := h.Peek(); peek != want {
+				t.Fatalf("Peek() = %d, want %d", peek, want)
+			}
+			if got, ok := h.Pop(); !ok || got != want {
+				t.Fatalf("Pop() = %d, %v; want %d", got, ok, want)
+			}
+		} else {
+			v := r.IntN(1000)
+			h.Push(v)
+			ref = append(ref, v)
+		}
+		if h.Len() != len(ref) {
+			t.Fatalf("Len() = %d, want %d", h.Len(), len(ref))
+		}
+	}
+}
+
+func TestCustomOrder(t *testing.T) {
+	type task struct {
+		name     string
+		priority int
+	}
+	h := New(func(a, b task) bool {
+		return cmp.Or(cmp.Compare(b.priority, a.priority), cmp.Compare(a.name, b.name)) < 0
+	}, task{"write", 1}, task{"deploy", 3}, task{"test", 2}, task{"build", 3})
+	var got []string
+	for _, tk := range drain(h) {
+		got = append(got, tk.name)
+	}
+	if want := []string{"build", "deploy", "test", "write"}; !slices.Equal(got, want) {
+		t.Errorf("popped %v, want %v", got, want)
+	}
+}
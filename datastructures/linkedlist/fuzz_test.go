@@ -0,0 +1,81 @@
+package linkedlist
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// ints turns fuzzer bytes into small ints, so that equal values, which
+// merging has to order carefully, are common.
+func ints(data []byte) []int {
+	s := make([]int, len(data))
+	for i, b := range data {
+		s[i] = int(b%21) - 10
+	}
+	return s
+}
+
+// Merge of two sorted lists must equal sorting both joined together.
+func FuzzMerge(f *testing.F) {
+	f.Add([]byte{}, []byte{})
+	f.Add([]byte{1, 3, 5}, []byte{2, 4, 6})
+	f.Add([]byte{1, 1, 2}, []byte{1, 2, 2})
+	f.Add([]byte{9, 9}, []byte{})
+	f.Fuzz(func(t *testing.T, x, y []byte) {
+		a, b := ints(x), ints(y)
+		slices.Sort(a)
+		slices.Sort(b)
+		want := slices.Sorted(slices.Values(slices.Concat(a, b)))
+		merged := Merge(New(a...), New(b...))
+		check(t, merged, want)
+		merged.PushBack(100)
+		check(t, merged, append(want, 100))
+	})
+}
+
+// MergeFunc must match a stable sort: equal elements keep a's before b's,
+// and each list's own order.
+func FuzzMergeFunc(f *testing.F) {
+	f.Add([]byte{1, 2, 2}, []byte{1, 2, 3})
+	f.Add([]byte{0, 0, 0}, []byte{0, 0})
+	f.Fuzz(func(t *testing.T, x, y []byte) {
+		type item struct {
+			key   int
+			label string
+		}
+		label := func(prefix string, keys []int) []item {
+			slices.Sort(keys)
+			items := make([]item, len(keys))
+			for i, k := range keys {
+				items[i] = item{k, fmt.Sprint(prefix, i)}
+			}
+			return items
+		}
+		a, b := label("a", ints(x)), label("b", ints(y))
+		byKey := func(p, q item) int { return cmp.Compare(p.key, q.key) }
+		want := slices.Concat(a, b)
+		slices.SortStableFunc(want, byKey)
+		check(t, MergeFunc(New(a...), New(b...), byKey), want)
+	})
+}
+
+// Reverse must match slices.Reverse and leave head and tail pointing at
+// the right nodes, which pushing onto both ends would show.
+func FuzzReverse(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{1})
+	f.Add([]byte{1, 2, 3, 4, 5})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		s := ints(data)
+		l := New(s...)
+		l.Reverse()
+		want := slices.Clone(s)
+		slices.Reverse(want)
+		check(t, l, want)
+		l.PushBack(100)
+		l.PushFront(-100)
+		check(t, l, slices.Concat([]int{-100}, want, []int{100}))
+	})
+}
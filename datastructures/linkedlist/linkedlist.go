@@ -0,0 +1,239 @@
+// Package linkedlist implements a generic singly linked list.
+//
+// It grew out of the list in playground.go. A List keeps a pointer to its
+// last node as well as its first, so PushBack is O(1) like PushFront;
+// InsertAt and Remove walk the list and are O(n).
+//
+//	var l linkedlist.List[int]
+//	l.PushBack(2)
+//	l.PushFront(1)
+//	for v := range l.All() {
+//		fmt.Println(v)
+//	}
+//
+// The zero value is an empty list ready to use. Lists must not be copied
+// after first use, because the copy would share nodes with the original.
+package linkedlist
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"strings"
+)
+
+type node[T any] struct {
+	val  T
+	next *node[T]
+}
+
+// List is a singly linked list of T.
+type List[T any] struct {
+	head, tail *node[T]
+	len        int
+}
+
+// New returns a list holding values in order.
+func New[T any](values ...T) *List[T] {
+	l := &List[T]{}
+	for _, v := range values {
+		l.PushBack(v)
+	}
+	return l
+}
+
+// Len returns the number of elements. It is O(1).
+func (l *List[T]) Len() int {
+	return l.len
+}
+
+// PushFront adds v at the start of the list.
+func (l *List[T]) PushFront(v T) {
+	n := &node[T]{val: v, next: l.head}
+	l.head = n
+	if l.tail == nil {
+		l.tail = n
+	}
+	l.len++
+}
+
+// PushBack adds v at the end of the list.
+func (l *List[T]) PushBack(v T) {
+	n := &node[T]{val: v}
+	if l.tail == nil {
+		l.head = n
+	} else {
+		l.tail.next = n
+	}
+	l.tail = n
+	l.len++
+}
+
+// Front returns the first element, or false if the list is empty.
+func (l *List[T]) Front() (T, bool) {
+	if l.head == nil {
+		var zero T
+		return zero, false
+	}
+	return l.head.val, true
+}
+
+// Back returns the last element, or false if the list is empty.
+func (l *List[T]) Back() (T, bool) {
+	if l.tail == nil {
+		var zero T
+		return zero, false
+	}
+	return l.tail.val, true
+}
+
+// InsertAt inserts v so that it becomes the element at index i, shifting
+// the rest back. i may be Len(), which appends. Like slices.Insert, it
+// panics if i is out of range.
+func (l *List[T]) InsertAt(i int, v T) {
+	if i < 0 || i > l.len {
+		panic(fmt.Sprintf("linkedlist: InsertAt index %d out of range [0:%d]", i, l.len))
+	}
+	switch i {
+	case 0:
+		l.PushFront(v)
+	case l.len:
+		l.PushBack(v)
+	default:
+		prev := l.nodeAt(i - 1)
+		prev.next = &node[T]{val: v, next: prev.next}
+		l.len++
+	}
+}
+
+// Remove removes the element at index i and returns it. It panics if i is
+// out of range.
+func (l *List[T]) Remove(i int) T {
+	if i < 0 || i >= l.len {
+		panic(fmt.Sprintf("linkedlist: Remove index %d out of range [0:%d]", i, l.len))
+	}
+	var removed *node[T]
+	if i == 0 {
+		removed = l.head
+		l.head = removed.next
+		if l.head == nil {
+			l.tail = nil
+		}
+	} else {
+		prev := l.nodeAt(i - 1)
+		removed = prev.next
+		prev.next = removed.next
+		if removed == l.tail {
+			l.tail = prev
+		}
+	}
+	l.len--
+	return removed.val
+}
+
+// nodeAt returns the node at index i, which must be in range.
+func (l *List[T]) nodeAt(i int) *node[T] {
+	n := l.head
+	for range i {
+		n = n.next
+	}
+	return n
+}
+
+// Reverse reverses the list in place, without allocating.
+func (l *List[T]) Reverse() {
+	var prev *node[T]
+	l.tail = l.head
+	for n := l.head; n != nil; {
+		next := n.next
+		n.next = prev
+		prev, n = n, next
+	}
+	l.head = prev
+}
+
+// All returns an iterator over the elements from front to back. The list
+// must not be modified while the iteration is running.
+func (l *List[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := l.head; n != nil; n = n.next {
+			if !yield(n.val) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice returns the elements in order in a new slice.
+func (l *List[T]) ToSlice() []T {
+	s := make([]T, 0, l.len)
+	for v := range l.All() {
+		s = append(s, v)
+	}
+	return s
+}
+
+// String formats the list as "1 -> 2 -> 3 -> nil".
+func (l *List[T]) String() string {
+	var b strings.Builder
+	for v := range l.All() {
+		fmt.Fprintf(&b, "%v -> ", v)
+	}
+	b.WriteString("nil")
+	return b.String()
+}
+
+// Merge merges two sorted lists into one sorted list. It works for any
+// ordered type (integers, floats, strings).
+func Merge[T cmp.Ordered](a, b *List[T]) *List[T] {
+	return MergeFunc(a, b, cmp.Compare[T])
+}
+
+// MergeFunc merges two lists sorted according to compare, which returns a
+// negative number when x < y, zero when they are equal and a positive
+// number when x > y. When two values are equal the element from a comes
+// first, so the merge is stable.
+//
+// The nodes of a and b are relinked into the result rather than copied,
+// so both are left empty.
+func MergeFunc[T any](a, b *List[T], compare func(x, y T) int) *List[T] {
+	// A dummy node to serve as the start of the merged list
+	dummy := &node[T]{}
+	current := dummy
+	x, y := a.head, b.head
+
+	for x != nil && y != nil {
+		// Link the smaller node onto the merged list. It has to be
+		// current.next = x, not current = x: the latter never links
+		// anything and loses every node.
+		if compare(x.val, y.val) <= 0 {
+			current.next = x
+			x = x.next
+		} else {
+			current.next = y
+			y = y.next
+		}
+		current = current.next
+	}
+
+	// Whichever list still has nodes supplies the rest, and its tail is
+	// the merged list's tail.
+	merged := &List[T]{len: a.len + b.len}
+	switch {
+	case x != nil:
+		current.next = x
+		merged.tail = a.tail
+	case y != nil:
+		current.next = y
+		merged.tail = b.tail
+	default:
+		merged.tail = current
+	}
+	merged.head = dummy.next
+	if merged.head == nil {
+		merged.tail = nil
+	}
+
+	*a, *b = List[T]{}, List[T]{}
+	return merged
+}
@@ -0,0 +1,173 @@
+package linkedlist
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// check fails t unless l holds want and its length, head and tail agree
+// with it.
+func check[T comparable](t *testing.T, l *List[T], want []T) {
+	t.Helper()
+	if got := l.ToSlice(); !slices.Equal(got, want) {
+		t.Errorf("ToSlice() = %v, want %v", got, want)
+	}
+	if l.Len() != len(want) {
+		t.Errorf("Len() = %d, want %d", l.Len(), len(want))
+	}
+	front, okf := l.Front()
+	back, okb := l.Back()
+	if len(want) == 0 {
+		if okf || okb {
+			t.Errorf("Front, Back of an empty list = %v %v, %v %v", front, okf, back, okb)
+		}
+		return
+	}
+	if !okf || front != want[0] {
+		t.Errorf("Front() = %v, %v; want %v", front, okf, want[0])
+	}
+	if !okb || back != want[len(want)-1] {
+		t.Errorf("Back() = %v, %v; want %v", back, okb, want[len(want)-1])
+	}
+}
+
+func TestZeroValue(t *testing.T) {
+	var l List[int]
+	check(t, &l, []int{})
+	if got := l.String(); got != "nil" {
+		t.Errorf("String() = %q", got)
+	}
+	l.Reverse()
+	check(t, &l, []int{})
+}
+
+func TestPush(t *testing.T) {
+	var l List[int]
+	l.PushBack(2)
+	l.PushFront(1)
+	l.PushBack(3)
+	check(t, &l, []int{1, 2, 3})
+	if got := l.String(); got != "1 -> 2 -> 3 -> nil" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestInsertAt(t *testing.T) {
+	l := New(1, 3)
+	l.InsertAt(1, 2)
+	l.InsertAt(0, 0)
+	l.InsertAt(l.Len(), 4)
+	check(t, l, []int{0, 1, 2, 3, 4})
+	l.PushBack(5) // the tail must have moved with the append
+	check(t, l, []int{0, 1, 2, 3, 4, 5})
+}
+
+func TestRemove(t *testing.T) {
+	l := New("a", "b", "c", "d")
+	for _, tt := range []struct {
+		i    int
+		want string
+		left []string
+	}{
+		{1, "b", []string{"a", "c", "d"}},
+		{2, "d", []string{"a", "c"}},
+		{0, "a", []string{"c"}},
+		{0, "c", []string{}},
+	} {
+		if got := l.Remove(tt.i); got != tt.want {
+			t.Errorf("Remove(%d) = %q, want %q", tt.i, got, tt.want)
+		}
+		check(t, l, tt.left)
+	}
+	// Removing the tail must leave a usable tail behind.
+	n := New(1, 2, 3)
+	n.Remove(2)
+	n.PushBack(4)
+	check(t, n, []int{1, 2, 4})
+}
+
+func TestOutOfRange(t *testing.T) {
+	for name, f := range map[string]func(*List[int]){
+		"InsertAt(-1)": func(l *List[int]) { l.InsertAt(-1, 0) },
+		"InsertAt(3)":  func(l *List[int]) { l.InsertAt(3, 0) },
+		"Remove(-1)":   func(l *List[int]) { l.Remove(-1) },
+		"Remove(2)":    func(l *List[int]) { l.Remove(2) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if s, _ := r.(string); !strings.Contains(s, "out of range") {
+					t.Errorf("panic = %v, want out of range", r)
+				}
+			}()
+			f(New(1, 2))
+		})
+	}
+}
+
+func TestReverse(t *testing.T) {
+	for _, in := range [][]int{{}, {1}, {1, 2}, {1, 2, 3, 4, 5}} {
+		l := New(in...)
+		l.Reverse()
+		want := slices.Clone(in)
+		slices.Reverse(want)
+		check(t, l, want)
+		l.PushBack(9)
+		check(t, l, append(want, 9))
+	}
+}
+
+func TestAllStopsEarly(t *testing.T) {
+	var got []int
+	for v := range New(1, 2, 3, 4).All() {
+		if v == 3 {
+			break
+		}
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("got %v before break, want [1 2]", got)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	for _, tt := range []struct {
+		a, b, want []int
+	}{
+		{nil, nil, []int{}},
+		{[]int{1, 2}, nil, []int{1, 2}},
+		{nil, []int{1, 2}, []int{1, 2}},
+		{[]int{1, 3, 5}, []int{2, 4, 6}, []int{1, 2, 3, 4, 5, 6}},
+		{[]int{1, 2, 3}, []int{4, 5}, []int{1, 2, 3, 4, 5}},
+		{[]int{4, 5}, []int{1, 2, 3}, []int{1, 2, 3, 4, 5}},
+		{[]int{1, 1, 2}, []int{1, 2, 2}, []int{1, 1, 1, 2, 2, 2}},
+	} {
+		a, b := New(tt.a...), New(tt.b...)
+		merged := Merge(a, b)
+		check(t, merged, tt.want)
+		check(t, a, []int{})
+		check(t, b, []int{})
+		// The merged tail must be right, or this lands in the wrong place.
+		merged.PushBack(100)
+		check(t, merged, append(tt.want, 100))
+	}
+}
+
+func TestMergeFuncStable(t *testing.T) {
+	type item struct {
+		key   int
+		label string
+	}
+	a := New(item{1, "a1"}, item{2, "a2"}, item{2, "a3"})
+	b := New(item{1, "b1"}, item{2, "b2"}, item{3, "b3"})
+	merged := MergeFunc(a, b, func(x, y item) int { return x.key - y.key })
+	var labels []string
+	for it := range merged.All() {
+		labels = append(labels, it.label)
+	}
+	want := []string{"a1", "b1", "a2", "a3", "b2", "b3"}
+	if !slices.Equal(labels, want) {
+		t.Errorf("merged %v, want %v", labels, want)
+	}
+}
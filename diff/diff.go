@@ -0,0 +1,376 @@
+// Package diff compares two Go values with reflection and reports every
+// place where they differ, addressed by a path such as
+// `.Employees[2].firstName` or `["alice"].id`.
+//
+// It is meant for comparing a learner's result with a reference result:
+// instead of "got X, want Y" for a whole value, Diff points at exactly the
+// fields, elements and keys that diverge.
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ChangeType says how a path differs between the two values.
+type ChangeType int
+
+const (
+	// Modified means the path exists in both values with different contents.
+	Modified ChangeType = iota
+	// Added means the path only exists in the second value.
+	Added
+	// Removed means the path only exists in the first value.
+	Removed
+)
+
+func (t ChangeType) String() string {
+	switch t {
+	case Modified:
+		return "modified"
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	}
+	return fmt.Sprintf("ChangeType(%d)", int(t))
+}
+
+// Change is a single difference between two values.
+type Change struct {
+	// Path locates the difference, relative to the compared values.
+	// The empty path means the values differ at the top level.
+	Path string
+	Type ChangeType
+	// From and To are the formatted values at Path. From is empty
+	// for Added changes and To is empty for Removed changes.
+	From string
+	To   string
+}
+
+func (c Change) String() string {
+	path := c.Path
+	if path == "" {
+		path = "(root)"
+	}
+	switch c.Type {
+	case Added:
+		return fmt.Sprintf("%s: added %s", path, c.To)
+	case Removed:
+		return fmt.Sprintf("%s: removed %s", path, c.From)
+	}
+	return fmt.Sprintf("%s: %s -> %s", path, c.From, c.To)
+}
+
+// Diff returns the differences between a and b, or nil if they are deeply
+// equal. Struct fields are compared in declaration order, slice and array
+// elements by index and map entries by key (in sorted key order), so the
+// result is deterministic.
+func Diff(a, b any) []Change {
+	d := &differ{visited: make(map[visit]bool)}
+	d.compare("", reflect.ValueOf(a), reflect.ValueOf(b))
+	return d.changes
+}
+
+// Lines compares two texts line by line and reports the lines that
+// differ, with paths such as "line 3". Lines are matched by position, the
+// way Diff matches slice elements, not aligned like a text diff: one extra
+// line early on shows up as a change to every line after it. For checking
+// output against an expected copy that is enough, since any difference is
+// a failure and the first one is usually the cause.
+func Lines(from, to string) []Change {
+	changes := Diff(strings.Split(from, "\n"), strings.Split(to, "\n"))
+	for i := range changes {
+		var n int
+		if _, err := fmt.Sscanf(changes[i].Path, "[%d]", &n); err == nil {
+			changes[i].Path = fmt.Sprintf("line %d", n+1)
+		}
+	}
+	return changes
+}
+
+// Op says what an Edit does with a line.
+type Op int
+
+const (
+	Equal  Op = iota // the line is in both texts
+	Delete           // the line is only in the first text
+	Insert           // the line is only in the second text
+)
+
+// Edit is one line of an aligned comparison.
+type Edit struct {
+	Op   Op
+	Line string
+}
+
+// Align compares two texts the way a text diff does: it keeps the longest
+// sequence of lines both share and marks every other line as deleted from
+// the first text or inserted into the second, so an extra line shows up
+// once instead of shifting every line after it as in Lines. Deletions come
+// before insertions where they meet. It takes time proportional to the
+// product of the line counts, which is fine for an exercise's output.
+func Align(from, to string) []Edit {
+	a, b := splitLines(from), splitLines(to)
+	// common[i][j] is the length of the longest common subsequence of
+	// a[i:] and b[j:].
+	common := make([][]int, len(a)+1)
+	for i := range common {
+		common[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+
+	var edits []Edit
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			edits = append(edits, Edit{Equal, a[i]})
+			i, j = i+1, j+1
+		case i < len(a) && (j == len(b) || common[i+1][j] >= common[i][j+1]):
+			edits = append(edits, Edit{Delete, a[i]})
+			i++
+		default:
+			edits = append(edits, Edit{Insert, b[j]})
+			j++
+		}
+	}
+	return edits
+}
+
+// splitLines splits s into lines. A final newline ends the last line
+// rather than starting an empty one.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// visit records a pair of pointers that is already being compared, so
+// cyclic structures terminate.
+type visit struct {
+	a, b uintptr
+	typ  reflect.Type
+}
+
+type differ struct {
+	changes []Change
+	visited map[visit]bool
+}
+
+func (d *differ) add(path string, typ ChangeType, a, b reflect.Value) {
+	c := Change{Path: path, Type: typ}
+	if typ != Added {
+		c.From = format(a)
+	}
+	if typ != Removed {
+		c.To = format(b)
+	}
+	d.changes = append(d.changes, c)
+}
+
+func (d *differ) compare(path string, a, b reflect.Value) {
+	if !a.IsValid() || !b.IsValid() {
+		if a.IsValid() != b.IsValid() {
+			d.add(path, Modified, a, b)
+		}
+		return
+	}
+	if a.Type() != b.Type() {
+		d.add(path, Modified, a, b)
+		return
+	}
+
+	switch a.Kind() {
+	case reflect.Pointer:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				d.add(path, Modified, a, b)
+			}
+			return
+		}
+		if a.Pointer() == b.Pointer() {
+			return
+		}
+		v := visit{a.Pointer(), b.Pointer(), a.Type()}
+		if d.visited[v] {
+			return
+		}
+		d.visited[v] = true
+		d.compare(path, a.Elem(), b.Elem())
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				d.add(path, Modified, a, b)
+			}
+			return
+		}
+		d.compare(path, a.Elem(), b.Elem())
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			d.compare(path+"."+a.Type().Field(i).Name, a.Field(i), b.Field(i))
+		}
+	case reflect.Slice, reflect.Array:
+		if a.Kind() == reflect.Slice && a.IsNil() != b.IsNil() {
+			d.add(path, Modified, a, b)
+			return
+		}
+		n := min(a.Len(), b.Len())
+		for i := 0; i < n; i++ {
+			d.compare(fmt.Sprintf("%s[%d]", path, i), a.Index(i), b.Index(i))
+		}
+		for i := n; i < a.Len(); i++ {
+			d.add(fmt.Sprintf("%s[%d]", path, i), Removed, a.Index(i), reflect.Value{})
+		}
+		for i := n; i < b.Len(); i++ {
+			d.add(fmt.Sprintf("%s[%d]", path, i), Added, reflect.Value{}, b.Index(i))
+		}
+	case reflect.Map:
+		if a.IsNil() != b.IsNil() {
+			d.add(path, Modified, a, b)
+			return
+		}
+		for _, k := range sortedKeys(a, b) {
+			keyPath := fmt.Sprintf("%s[%s]", path, formatKey(k))
+			va, vb := a.MapIndex(k), b.MapIndex(k)
+			switch {
+			case !vb.IsValid():
+				d.add(keyPath, Removed, va, vb)
+			case !va.IsValid():
+				d.add(keyPath, Added, va, vb)
+			default:
+				d.compare(keyPath, va, vb)
+			}
+		}
+	case reflect.Func:
+		// Functions are only equal when both are nil, like reflect.DeepEqual.
+		if !a.IsNil() || !b.IsNil() {
+			d.add(path, Modified, a, b)
+		}
+	default:
+		if !equalScalar(a, b) {
+			d.add(path, Modified, a, b)
+		}
+	}
+}
+
+// equalScalar compares values of the non-composite kinds without calling
+// Interface, which would panic on unexported struct fields.
+func equalScalar(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() == b.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return a.Complex() == b.Complex()
+	case reflect.String:
+		return a.String() == b.String()
+	case reflect.Chan, reflect.UnsafePointer:
+		return a.Pointer() == b.Pointer()
+	}
+	return false
+}
+
+// sortedKeys returns the union of the keys of two maps of the same type,
+// ordered by their formatted representation. Keys are matched with the
+// map's own equality, not by their formatted form: 1 and "1" in a map[any]
+// print the same but are different keys, and so are two pointers to equal
+// values.
+func sortedKeys(a, b reflect.Value) []reflect.Value {
+	keys := a.MapKeys()
+	for _, k := range b.MapKeys() {
+		if !a.MapIndex(k).IsValid() {
+			keys = append(keys, k)
+		}
+	}
+	formatted := make([]string, len(keys))
+	for i, k := range keys {
+		formatted[i] = formatKey(k)
+	}
+	order := make([]int, len(keys))
+	for i := range order {
+		order[i] = i
+	}
+	// Keys that print the same are ordered by type, and otherwise keep
+	// a's keys first, so the output is as stable as the printed forms.
+	sort.SliceStable(order, func(i, j int) bool {
+		fi, fj := formatted[order[i]], formatted[order[j]]
+		if fi != fj {
+			return fi < fj
+		}
+		return dynamicType(keys[order[i]]) < dynamicType(keys[order[j]])
+	})
+	sorted := make([]reflect.Value, len(keys))
+	for i, o := range order {
+		sorted[i] = keys[o]
+	}
+	return sorted
+}
+
+// dynamicType returns the name of the type k holds, looking inside
+// interfaces.
+func dynamicType(k reflect.Value) string {
+	if k.Kind() == reflect.Interface && !k.IsNil() {
+		k = k.Elem()
+	}
+	return k.Type().String()
+}
+
+func formatKey(k reflect.Value) string {
+	// A key of interface type is formatted by what it holds, so the string
+	// "1" is quoted and told apart from the number 1.
+	if k.Kind() == reflect.Interface && !k.IsNil() {
+		k = k.Elem()
+	}
+	if k.Kind() == reflect.String {
+		return fmt.Sprintf("%q", k.String())
+	}
+	return format(k)
+}
+
+// format renders a value for a Change. Values that cannot be turned back
+// into an interface (unexported fields) are formatted kind by kind.
+func format(v reflect.Value) string {
+	if !v.IsValid() {
+		return "<nil>"
+	}
+	if v.CanInterface() {
+		if v.Kind() == reflect.String {
+			return fmt.Sprintf("%q", v.String())
+		}
+		return fmt.Sprintf("%v", v.Interface())
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		return fmt.Sprint(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return fmt.Sprint(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return fmt.Sprint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return fmt.Sprint(v.Float())
+	case reflect.String:
+		return fmt.Sprintf("%q", v.String())
+	case reflect.Pointer, reflect.Map, reflect.Slice:
+		if v.IsNil() {
+			return "<nil>"
+		}
+	}
+	return "<" + strings.TrimPrefix(v.Type().String(), "*") + ">"
+}
@@ -0,0 +1,111 @@
+package diff
+
+import (
+	"slices"
+	"testing"
+)
+
+type employee struct {
+	Name  string
+	Tags  []string
+	Boss  *employee
+	notes map[string]int
+}
+
+func TestDiff(t *testing.T) {
+	a := employee{Name: "Ana", Tags: []string{"go", "sql"}, notes: map[string]int{"x": 1}}
+	b := employee{Name: "Bo", Tags: []string{"go"}, Boss: &employee{}, notes: map[string]int{"x": 2, "y": 3}}
+	got := Diff(a, b)
+	want := []string{
+		`.Name: "Ana" -> "Bo"`,
+		`.Tags[1]: removed "sql"`,
+		`.Boss: <nil> -> &{ [] <nil> map[]}`,
+		`.notes["x"]: 1 -> 2`,
+		`.notes["y"]: added 3`,
+	}
+	var gotStrings []string
+	for _, c := range got {
+		gotStrings = append(gotStrings, c.String())
+	}
+	if !slices.Equal(gotStrings, want) {
+		t.Errorf("Diff =\n%q\nwant\n%q", gotStrings, want)
+	}
+	if got := Diff(a, a); got != nil {
+		t.Errorf("Diff(a, a) = %v, want nil", got)
+	}
+}
+
+// Keys that print the same are still different keys.
+func TestDiffKeysThatFormatAlike(t *testing.T) {
+	a := map[any]int{1: 1, "1": 2}
+	b := map[any]int{1: 1, "1": 3}
+	if got := changeStrings(Diff(a, b)); !slices.Equal(got, []string{`["1"]: 2 -> 3`}) {
+		t.Errorf("Diff of map[any] with 1 and \"1\" = %q", got)
+	}
+	b = map[any]int{"1": 2}
+	if got := changeStrings(Diff(a, b)); !slices.Equal(got, []string{`[1]: removed 1`}) {
+		t.Errorf("Diff after removing 1 but keeping \"1\" = %q", got)
+	}
+
+	p, q := &struct{ N int }{1}, &struct{ N int }{1}
+	ma := map[*struct{ N int }]string{p: "p", q: "q"}
+	mb := map[*struct{ N int }]string{p: "p", q: "changed"}
+	if got := changeStrings(Diff(ma, mb)); !slices.Equal(got, []string{`[&{1}]: "q" -> "changed"`}) {
+		t.Errorf("Diff of a map with two equal-looking pointer keys = %q", got)
+	}
+}
+
+// changeStrings returns the String of every change.
+func changeStrings(changes []Change) []string {
+	var s []string
+	for _, c := range changes {
+		s = append(s, c.String())
+	}
+	return s
+}
+
+func TestDiffCycle(t *testing.T) {
+	a, b := &employee{Name: "a"}, &employee{Name: "a"}
+	a.Boss, b.Boss = a, b
+	if got := Diff(a, b); got != nil {
+		t.Errorf("Diff of equal cyclic values = %v, want nil", got)
+	}
+}
+
+func TestLines(t *testing.T) {
+	got := Lines("one\ntwo\nthree\n", "one\n2\nthree\n\nfour")
+	want := []Change{
+		{Path: "line 2", Type: Modified, From: `"two"`, To: `"2"`},
+		{Path: "line 5", Type: Added, To: `"four"`},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Lines = %v, want %v", got, want)
+	}
+	if got := Lines("same\n", "same\n"); got != nil {
+		t.Errorf("Lines of equal texts = %v, want nil", got)
+	}
+}
+
+func TestAlign(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     []Edit
+	}{
+		{"", "", nil},
+		{"a\nb\n", "a\nb\n", []Edit{{Equal, "a"}, {Equal, "b"}}},
+		// One inserted line is one Insert, not a change to every line
+		// after it.
+		{"a\nb\nc\n", "a\nnew\nb\nc\n", []Edit{{Equal, "a"}, {Insert, "new"}, {Equal, "b"}, {Equal, "c"}}},
+		{"a\nold\nc\n", "a\nnew\nc\n", []Edit{{Equal, "a"}, {Delete, "old"}, {Insert, "new"}, {Equal, "c"}}},
+		{"a\nb\n", "", []Edit{{Delete, "a"}, {Delete, "b"}}},
+		{"", "x", []Edit{{Insert, "x"}}},
+		// Only lines are compared, so a missing final newline is not a
+		// difference.
+		{"a\nb\n", "a\nb", []Edit{{Equal, "a"}, {Equal, "b"}}},
+	}
+	for _, tt := range tests {
+		if got := Align(tt.from, tt.to); !slices.Equal(got, tt.want) {
+			t.Errorf("Align(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
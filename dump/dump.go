@@ -0,0 +1,231 @@
+// Package dump pretty-prints arbitrary Go values using reflection.
+//
+// Unlike fmt's %v, Dump shows the type of every value, the names of struct
+// fields (exported or not), and follows pointers. Pointers that lead back to
+// a value that is already being printed are reported as cycles instead of
+// recursing forever, which makes it safe to dump linked structures.
+package dump
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Config controls how values are printed.
+type Config struct {
+	// MaxDepth limits how many levels of nesting are expanded. Values below
+	// that depth are printed as "...". Zero means no limit.
+	MaxDepth int
+	// Indent is repeated once per nesting level.
+	Indent string
+	// OmitAddresses prints pointers, channels and functions without their
+	// addresses, which change from run to run, so the output can be
+	// compared against a saved copy.
+	OmitAddresses bool
+}
+
+// Default is the configuration used by Dump.
+var Default = Config{MaxDepth: 10, Indent: "  "}
+
+// Dump returns a multi-line description of v using the Default config.
+func Dump(v any) string {
+	return Default.Dump(v)
+}
+
+// Dump returns a multi-line description of v.
+func (c Config) Dump(v any) string {
+	p := &printer{
+		cfg:     c,
+		visited: make(map[visit]bool),
+	}
+	p.value(reflect.ValueOf(v), 0)
+	return p.sb.String()
+}
+
+type printer struct {
+	cfg Config
+	sb  strings.Builder
+	// visited holds the pointers and maps on the current path, so a value
+	// that refers back to one of its parents is detected.
+	visited map[visit]bool
+}
+
+// visit is a pointer or map being printed. The type is part of the key
+// because a pointer to a struct and a pointer to its first field hold the
+// same address, and only the first is a parent of the second.
+type visit struct {
+	addr uintptr
+	typ  reflect.Type
+}
+
+func (p *printer) indent(depth int) {
+	p.sb.WriteString(strings.Repeat(p.cfg.Indent, depth))
+}
+
+func (p *printer) value(v reflect.Value, depth int) {
+	if !v.IsValid() {
+		p.sb.WriteString("nil")
+		return
+	}
+
+	// Interfaces are transparent: show the dynamic type and value they hold.
+	if v.Kind() == reflect.Interface && !v.IsNil() {
+		p.value(v.Elem(), depth)
+		return
+	}
+
+	fmt.Fprintf(&p.sb, "(%s) ", v.Type())
+
+	if p.cfg.MaxDepth > 0 && depth >= p.cfg.MaxDepth {
+		p.sb.WriteString("...")
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		fmt.Fprintf(&p.sb, "%t", v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		fmt.Fprintf(&p.sb, "%d", v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		fmt.Fprintf(&p.sb, "%d", v.Uint())
+	case reflect.Float32, reflect.Float64:
+		fmt.Fprintf(&p.sb, "%g", v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		fmt.Fprintf(&p.sb, "%g", v.Complex())
+	case reflect.String:
+		fmt.Fprintf(&p.sb, "%q", v.String())
+	case reflect.Pointer:
+		p.pointer(v, depth)
+	case reflect.Interface:
+		p.sb.WriteString("nil")
+	case reflect.Struct:
+		p.structValue(v, depth)
+	case reflect.Slice:
+		if v.IsNil() {
+			p.sb.WriteString("nil")
+			return
+		}
+		p.list(v, depth)
+	case reflect.Array:
+		p.list(v, depth)
+	case reflect.Map:
+		p.mapValue(v, depth)
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		if v.IsNil() {
+			p.sb.WriteString("nil")
+			return
+		}
+		p.address(v.Pointer())
+	default:
+		fmt.Fprintf(&p.sb, "<%s>", v.Kind())
+	}
+}
+
+func (p *printer) pointer(v reflect.Value, depth int) {
+	if v.IsNil() {
+		p.sb.WriteString("nil")
+		return
+	}
+	addr := v.Pointer()
+	p.address(addr)
+	key := visit{addr, v.Type()}
+	if p.visited[key] {
+		p.sb.WriteString(" <cycle>")
+		return
+	}
+	p.visited[key] = true
+	defer delete(p.visited, key)
+
+	p.sb.WriteString(" -> ")
+	p.value(v.Elem(), depth)
+}
+
+// address prints addr, or a placeholder when addresses are omitted.
+func (p *printer) address(addr uintptr) {
+	if p.cfg.OmitAddresses {
+		p.sb.WriteString("<ptr>")
+		return
+	}
+	fmt.Fprintf(&p.sb, "%#x", addr)
+}
+
+func (p *printer) structValue(v reflect.Value, depth int) {
+	t := v.Type()
+	if t.NumField() == 0 {
+		p.sb.WriteString("{}")
+		return
+	}
+	p.sb.WriteString("{\n")
+	for i := 0; i < t.NumField(); i++ {
+		p.indent(depth + 1)
+		// Unexported fields can be read through reflection as long as we
+		// never call Interface() on them, which is why value() formats
+		// every kind by hand.
+		fmt.Fprintf(&p.sb, "%s: ", t.Field(i).Name)
+		p.value(v.Field(i), depth+1)
+		p.sb.WriteString("\n")
+	}
+	p.indent(depth)
+	p.sb.WriteString("}")
+}
+
+func (p *printer) list(v reflect.Value, depth int) {
+	if v.Len() == 0 {
+		p.sb.WriteString("[]")
+		return
+	}
+	p.sb.WriteString("[\n")
+	for i := 0; i < v.Len(); i++ {
+		p.indent(depth + 1)
+		fmt.Fprintf(&p.sb, "%d: ", i)
+		p.value(v.Index(i), depth+1)
+		p.sb.WriteString("\n")
+	}
+	p.indent(depth)
+	p.sb.WriteString("]")
+}
+
+func (p *printer) mapValue(v reflect.Value, depth int) {
+	if v.IsNil() {
+		p.sb.WriteString("nil")
+		return
+	}
+	if v.Len() == 0 {
+		p.sb.WriteString("{}")
+		return
+	}
+	key := visit{v.Pointer(), v.Type()}
+	if p.visited[key] {
+		p.sb.WriteString("<cycle>")
+		return
+	}
+	p.visited[key] = true
+	defer delete(p.visited, key)
+
+	// Map iteration order is random; sort the keys by their printed form so
+	// the output is stable between runs.
+	type entry struct {
+		key   string
+		value reflect.Value
+	}
+	entries := make([]entry, 0, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		kp := &printer{cfg: Config{MaxDepth: 1}, visited: map[visit]bool{}}
+		kp.value(iter.Key(), 0)
+		entries = append(entries, entry{key: kp.sb.String(), value: iter.Value()})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+
+	p.sb.WriteString("{\n")
+	for _, e := range entries {
+		p.indent(depth + 1)
+		fmt.Fprintf(&p.sb, "%s: ", e.key)
+		p.value(e.value, depth+1)
+		p.sb.WriteString("\n")
+	}
+	p.indent(depth)
+	p.sb.WriteString("}")
+}
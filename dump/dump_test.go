@@ -0,0 +1,116 @@
+package dump
+
+import (
+	"strings"
+	"testing"
+)
+
+// cfg omits addresses, so outputs can be compared exactly.
+var cfg = Config{Indent: "  ", OmitAddresses: true}
+
+type node struct {
+	Val  int
+	next *node
+}
+
+type inner struct{ N int }
+
+// outer's first field sits at outer's own address, so P, pointing at it,
+// holds the same address as a pointer to the whole outer.
+type outer struct {
+	In inner
+	P  *inner
+}
+
+func TestScalarsAndStructs(t *testing.T) {
+	tests := []struct {
+		v    any
+		want string
+	}{
+		{nil, "nil"},
+		{42, "(int) 42"},
+		{uint8(7), "(uint8) 7"},
+		{2.5, "(float64) 2.5"},
+		{"hi\n", `(string) "hi\n"`},
+		{true, "(bool) true"},
+		{[]int(nil), "([]int) nil"},
+		{[]string{}, "([]string) []"},
+		{struct{}{}, "(struct {}) {}"},
+		{(*node)(nil), "(*dump.node) nil"},
+		{map[string]int{"b": 2, "a": 1}, "(map[string]int) {\n  (string) \"a\": (int) 1\n  (string) \"b\": (int) 2\n}"},
+		{[2]any{1, nil}, "([2]interface {}) [\n  0: (int) 1\n  1: (interface {}) nil\n]"},
+		{node{Val: 3}, "(dump.node) {\n  Val: (int) 3\n  next: (*dump.node) nil\n}"},
+	}
+	for _, tt := range tests {
+		if got := cfg.Dump(tt.v); got != tt.want {
+			t.Errorf("Dump(%#v) =\n%s\nwant\n%s", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestCycle(t *testing.T) {
+	n := &node{Val: 1}
+	n.next = &node{Val: 2, next: n}
+	want := `(*dump.node) <ptr> -> (dump.node) {
+  Val: (int) 1
+  next: (*dump.node) <ptr> -> (dump.node) {
+    Val: (int) 2
+    next: (*dump.node) <ptr> <cycle>
+  }
+}`
+	if got := cfg.Dump(n); got != want {
+		t.Errorf("Dump of a two-node cycle =\n%s\nwant\n%s", got, want)
+	}
+
+	m := map[string]any{}
+	m["self"] = m
+	if got := cfg.Dump(m); !strings.Contains(got, `"self": (map[string]interface {}) <cycle>`) {
+		t.Errorf("Dump of a map holding itself =\n%s", got)
+	}
+}
+
+// A pointer seen twice on different branches is shared, not a cycle.
+func TestSharedPointerIsNotACycle(t *testing.T) {
+	shared := &node{Val: 7}
+	pair := [2]*node{shared, shared}
+	got := cfg.Dump(pair)
+	if strings.Contains(got, "<cycle>") {
+		t.Errorf("Dump of two pointers to one node reported a cycle:\n%s", got)
+	}
+	if strings.Count(got, "Val: (int) 7") != 2 {
+		t.Errorf("the shared node was not printed on both branches:\n%s", got)
+	}
+}
+
+func TestPointerToFirstField(t *testing.T) {
+	o := &outer{In: inner{N: 5}}
+	o.P = &o.In
+	want := `(*dump.outer) <ptr> -> (dump.outer) {
+  In: (dump.inner) {
+    N: (int) 5
+  }
+  P: (*dump.inner) <ptr> -> (dump.inner) {
+    N: (int) 5
+  }
+}`
+	if got := cfg.Dump(o); got != want {
+		t.Errorf("Dump of a pointer to its own first field =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestMaxDepth(t *testing.T) {
+	l := &node{Val: 1, next: &node{Val: 2, next: &node{Val: 3}}}
+	c := cfg
+	c.MaxDepth = 2
+	got := c.Dump(l)
+	if !strings.Contains(got, "next: (*dump.node) ...") || strings.Contains(got, "Val: (int) 2") {
+		t.Errorf("Dump with MaxDepth 2 =\n%s", got)
+	}
+}
+
+func TestAddresses(t *testing.T) {
+	n := &node{Val: 1}
+	if got := Dump(n); !strings.HasPrefix(got, "(*dump.node) 0x") {
+		t.Errorf("Dump without OmitAddresses = %q, want it to show the address", got)
+	}
+}
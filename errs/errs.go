@@ -0,0 +1,103 @@
+// Package errs defines the errors shared by the exercise tooling and shows
+// the error-handling patterns from chapter 9 in real code: sentinel errors
+// compared with errors.Is, custom error types matched with errors.As, and
+// wrapping with %w to add context without losing the original error.
+package errs
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors. Compare against them with errors.Is, never with ==,
+// because they usually arrive wrapped in extra context.
+var (
+	// ErrExerciseNotFound means the requested chapter or exercise is not
+	// registered.
+	ErrExerciseNotFound = errors.New("exercise not found")
+	// ErrOutputMismatch means an exercise ran but printed something other
+	// than the expected output.
+	ErrOutputMismatch = errors.New("output does not match the expected output")
+)
+
+// ValidationError reports bad input, such as a malformed flag or an invalid
+// field in a request. Extract it with errors.As to read the fields.
+type ValidationError struct {
+	Field  string
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Field, e.Reason)
+}
+
+// ExerciseError attaches the exercise that failed to an underlying error.
+type ExerciseError struct {
+	Chapter  string
+	Exercise string
+	Err      error
+}
+
+func (e *ExerciseError) Error() string {
+	return fmt.Sprintf("%s/%s: %v", e.Chapter, e.Exercise, e.Err)
+}
+
+// Unwrap lets errors.Is and errors.As see the underlying error.
+func (e *ExerciseError) Unwrap() error {
+	return e.Err
+}
+
+// InExercise wraps err in an ExerciseError. It returns nil if err is nil,
+// so it can be applied to any return value.
+func InExercise(chapter, exercise string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &ExerciseError{Chapter: chapter, Exercise: exercise, Err: err}
+}
+
+// Wrap adds context to err in the form "context: err", keeping err
+// reachable through errors.Is and errors.As. Like InExercise it returns nil
+// if err is nil, so
+//
+//	return errs.Wrap(f.Close(), "closing %s", name)
+//
+// is safe whether or not Close failed.
+func Wrap(err error, format string, args ...any) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err)
+}
+
+// Invalid returns a *ValidationError for field.
+func Invalid(field, format string, args ...any) error {
+	return &ValidationError{Field: field, Reason: fmt.Sprintf(format, args...)}
+}
+
+// Process exit codes used by command-line tools.
+const (
+	ExitOK       = 0
+	ExitFailure  = 1 // any error without a more specific code
+	ExitUsage    = 2 // a ValidationError: the user asked for something invalid
+	ExitNotFound = 3 // ErrExerciseNotFound
+	ExitMismatch = 4 // ErrOutputMismatch
+)
+
+// ExitCode classifies err into one of the Exit constants. Because it relies
+// on errors.Is and errors.As, the classification still works when err has
+// been wrapped any number of times.
+func ExitCode(err error) int {
+	var invalid *ValidationError
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, ErrExerciseNotFound):
+		return ExitNotFound
+	case errors.Is(err, ErrOutputMismatch):
+		return ExitMismatch
+	case errors.As(err, &invalid):
+		return ExitUsage
+	}
+	return ExitFailure
+}
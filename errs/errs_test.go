@@ -0,0 +1,96 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+)
+
+func TestWrapKeepsChain(t *testing.T) {
+	if Wrap(nil, "reading %s", "x") != nil {
+		t.Error("Wrap(nil) is not nil")
+	}
+	err := Wrap(fs.ErrNotExist, "reading %s", "notes.txt")
+	if got := err.Error(); got != "reading notes.txt: file does not exist" {
+		t.Errorf("Error() = %q", got)
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Error("errors.Is lost the wrapped error")
+	}
+}
+
+func TestInExercise(t *testing.T) {
+	if InExercise("chapter9", "exercise1", nil) != nil {
+		t.Error("InExercise(nil) is not nil")
+	}
+	err := fmt.Errorf("running: %w", InExercise("chapter9", "exercise1", ErrOutputMismatch))
+	var ex *ExerciseError
+	if !errors.As(err, &ex) || ex.Chapter != "chapter9" || ex.Exercise != "exercise1" {
+		t.Fatalf("errors.As found %+v", ex)
+	}
+	if !errors.Is(err, ErrOutputMismatch) {
+		t.Error("errors.Is cannot see through ExerciseError")
+	}
+	if got := err.Error(); got != "running: chapter9/exercise1: output does not match the expected output" {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	err := Wrap(Invalid("salary", "must be at least %d", 0), "decoding request")
+	var v *ValidationError
+	if !errors.As(err, &v) || v.Field != "salary" || v.Reason != "must be at least 0" {
+		t.Fatalf("errors.As found %+v", v)
+	}
+	if got := v.Error(); got != "invalid salary: must be at least 0" {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{nil, ExitOK},
+		{errors.New("boom"), ExitFailure},
+		{Invalid("flag", "bad"), ExitUsage},
+		{Wrap(ErrExerciseNotFound, "chapter 99"), ExitNotFound},
+		{InExercise("c", "e", ErrOutputMismatch), ExitMismatch},
+		// The first match in ExitCode's order wins.
+		{&MultiError{Errs: []error{Invalid("a", "b"), ErrExerciseNotFound}}, ExitNotFound},
+	}
+	for _, tt := range tests {
+		if got := ExitCode(tt.err); got != tt.want {
+			t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestCollector(t *testing.T) {
+	var c Collector
+	c.Add(nil)
+	if c.Err() != nil || c.Len() != 0 {
+		t.Fatal("a Collector with only nil added is not empty")
+	}
+	c.Add(Invalid("name", "must not be empty"))
+	if got := c.Err().Error(); got != "invalid name: must not be empty" {
+		t.Errorf("one error: Error() = %q", got)
+	}
+	c.Add(fs.ErrPermission)
+	err := c.Err()
+	if got := err.Error(); got != "2 errors: invalid name: must not be empty; permission denied" {
+		t.Errorf("two errors: Error() = %q", got)
+	}
+	var v *ValidationError
+	if !errors.Is(err, fs.ErrPermission) || !errors.As(err, &v) {
+		t.Error("errors.Is or errors.As does not look at every error")
+	}
+
+	// Err returns a copy, so adding more later does not change it.
+	c.Add(fs.ErrClosed)
+	if n := len(err.(*MultiError).Errs); n != 2 {
+		t.Errorf("earlier Err() result grew to %d errors", n)
+	}
+}
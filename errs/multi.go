@@ -0,0 +1,65 @@
+package errs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MultiError holds several errors that happened together, such as one per
+// invalid field of a form. It implements Unwrap() []error, so errors.Is and
+// errors.As look at every error in the list.
+type MultiError struct {
+	Errs []error
+}
+
+func (e *MultiError) Error() string {
+	if len(e.Errs) == 1 {
+		return e.Errs[0].Error()
+	}
+	msgs := make([]string, len(e.Errs))
+	for i, err := range e.Errs {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d errors: %s", len(e.Errs), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the errors in the list.
+func (e *MultiError) Unwrap() []error {
+	return e.Errs
+}
+
+// Collector gathers errors so that a function can report all of its
+// problems at once instead of stopping at the first. The zero value is
+// ready to use.
+//
+//	var c errs.Collector
+//	if name == "" {
+//		c.Add(errs.Invalid("name", "must not be empty"))
+//	}
+//	c.Add(checkEmail(email))
+//	return c.Err()
+type Collector struct {
+	errs []error
+}
+
+// Add records err. A nil err is ignored, so the result of a check can be
+// passed straight in.
+func (c *Collector) Add(err error) {
+	if err != nil {
+		c.errs = append(c.errs, err)
+	}
+}
+
+// Len returns the number of errors recorded.
+func (c *Collector) Len() int {
+	return len(c.errs)
+}
+
+// Err returns nil if nothing was recorded, and otherwise a *MultiError
+// holding every recorded error in order.
+func (c *Collector) Err() error {
+	if len(c.errs) == 0 {
+		return nil
+	}
+	return &MultiError{Errs: append([]error(nil), c.errs...)}
+}
@@ -0,0 +1,235 @@
+// Package eventbus is an in-process publish/subscribe bus with typed
+// topics. The topic is the event's Go type, so subscribers receive exactly
+// the events published with the same type argument:
+//
+//	bus := eventbus.New()
+//	eventbus.Subscribe(bus, func(e ExercisePassed) { fmt.Println(e.Name) })
+//	eventbus.Publish(bus, ExercisePassed{Name: "chapter12/exercise1"})
+//
+// Publishers and subscribers only share the event types, which lets, for
+// example, a runner announce results without knowing who records or
+// reports them.
+//
+// Delivery guarantees:
+//
+//   - Every subscriber sees every event published after it subscribed and
+//     before it unsubscribed, in publish order (per publishing goroutine).
+//   - In Sync mode, Publish returns after all handlers have run.
+//   - In Async mode, each subscriber has its own goroutine and unbounded
+//     queue, so a slow handler delays only itself and Publish never blocks.
+//     Close waits for every queued event to be handled.
+//   - A panicking handler does not affect the publisher or other handlers;
+//     the panic is passed to the panic handler as a *safe.PanicError.
+package eventbus
+
+import (
+	"errors"
+	"log"
+	"reflect"
+	"sync"
+
+	"learning-go/safe"
+)
+
+// ErrClosed is returned by Publish after Close.
+var ErrClosed = errors.New("eventbus: bus is closed")
+
+// Mode selects how events are dispatched.
+type Mode int
+
+const (
+	// Sync runs handlers in the publishing goroutine, one after another.
+	Sync Mode = iota
+	// Async queues events and runs each subscriber in its own goroutine.
+	Async
+)
+
+// Option configures New.
+type Option func(*Bus)
+
+// WithMode sets the dispatch mode. The default is Sync.
+func WithMode(m Mode) Option {
+	return func(b *Bus) { b.mode = m }
+}
+
+// WithPanicHandler sets the function called when a handler panics. The
+// default logs the panic with the standard logger.
+func WithPanicHandler(fn func(err error)) Option {
+	return func(b *Bus) { b.onPanic = fn }
+}
+
+// Bus routes events to subscribers. It is safe for concurrent use.
+type Bus struct {
+	mode    Mode
+	onPanic func(error)
+
+	mu     sync.RWMutex
+	subs   map[reflect.Type][]*subscription
+	closed bool
+	wg     sync.WaitGroup // running async subscribers
+}
+
+// New returns an empty bus.
+func New(opts ...Option) *Bus {
+	b := &Bus{
+		subs: make(map[reflect.Type][]*subscription),
+		onPanic: func(err error) {
+			log.Printf("eventbus: handler %v", err)
+		},
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+type subscription struct {
+	call func(any)
+
+	// Async mode only.
+	mu      sync.Mutex
+	ready   *sync.Cond
+	queue   []any
+	stopped bool
+}
+
+// Subscribe registers handler for events of type T and returns a function
+// that removes it. In Async mode, events already queued for the handler
+// are still delivered after unsubscribing. Subscribing to a closed bus
+// does nothing.
+func Subscribe[T any](b *Bus, handler func(T)) (unsubscribe func()) {
+	topic := reflect.TypeFor[T]()
+	s := &subscription{call: func(e any) { handler(e.(T)) }}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return func() {}
+	}
+	b.subs[topic] = append(b.subs[topic], s)
+	if b.mode == Async {
+		s.ready = sync.NewCond(&s.mu)
+		b.wg.Add(1)
+		go b.run(s)
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { b.unsubscribe(topic, s) })
+	}
+}
+
+// Publish sends event to every subscriber of type T.
+func Publish[T any](b *Bus, event T) error {
+	topic := reflect.TypeFor[T]()
+
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return ErrClosed
+	}
+	// Copy the list so handlers can subscribe or unsubscribe while the
+	// event is dispatched without deadlocking.
+	subs := append([]*subscription(nil), b.subs[topic]...)
+	if b.mode == Async {
+		// Enqueue while holding the lock so Close cannot stop a
+		// subscriber between the check above and the enqueue.
+		for _, s := range subs {
+			s.enqueue(event)
+		}
+		b.mu.RUnlock()
+		return nil
+	}
+	b.mu.RUnlock()
+
+	for _, s := range subs {
+		b.deliver(s, event)
+	}
+	return nil
+}
+
+// Close stops the bus. In Async mode it waits until every queued event has
+// been handled.
+func (b *Bus) Close() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	for _, subs := range b.subs {
+		for _, s := range subs {
+			s.stop()
+		}
+	}
+	b.subs = nil
+	b.mu.Unlock()
+
+	b.wg.Wait()
+}
+
+func (b *Bus) unsubscribe(topic reflect.Type, s *subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	subs := b.subs[topic]
+	for i, other := range subs {
+		if other == s {
+			// Build a new slice: Publish may still be iterating a copy
+			// of the old one, which must not change under it.
+			b.subs[topic] = append(subs[:i:i], subs[i+1:]...)
+			s.stop()
+			return
+		}
+	}
+}
+
+func (b *Bus) deliver(s *subscription, event any) {
+	err := safe.SafeCall(func() error {
+		s.call(event)
+		return nil
+	})
+	if err != nil {
+		b.onPanic(err)
+	}
+}
+
+// run handles s's queue until s is stopped and the queue is empty.
+func (b *Bus) run(s *subscription) {
+	defer b.wg.Done()
+	for {
+		s.mu.Lock()
+		for len(s.queue) == 0 && !s.stopped {
+			s.ready.Wait()
+		}
+		if len(s.queue) == 0 {
+			s.mu.Unlock()
+			return
+		}
+		event := s.queue[0]
+		s.queue[0] = nil
+		s.queue = s.queue[1:]
+		s.mu.Unlock()
+
+		b.deliver(s, event)
+	}
+}
+
+func (s *subscription) enqueue(event any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return
+	}
+	s.queue = append(s.queue, event)
+	s.ready.Signal()
+}
+
+func (s *subscription) stop() {
+	if s.ready == nil {
+		return // Sync mode
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopped = true
+	s.ready.Signal()
+}
@@ -0,0 +1,144 @@
+package eventbus
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"learning-go/safe"
+)
+
+type passed struct{ id int }
+type failed struct{ id int }
+
+func TestTypedTopics(t *testing.T) {
+	b := New()
+	var got []any
+	Subscribe(b, func(e passed) { got = append(got, e) })
+	Subscribe(b, func(e failed) { got = append(got, e) })
+
+	Publish(b, passed{1})
+	Publish(b, failed{2})
+	Publish(b, "nobody listens to strings")
+	if want := []any{passed{1}, failed{2}}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestSyncOrderAndUnsubscribe(t *testing.T) {
+	b := New()
+	var got []int
+	unsubscribe := Subscribe(b, func(e passed) { got = append(got, e.id) })
+	for i := range 5 {
+		Publish(b, passed{i})
+	}
+	unsubscribe()
+	unsubscribe() // a second call does nothing
+	Publish(b, passed{99})
+	if want := []int{0, 1, 2, 3, 4}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestPanicIsolation(t *testing.T) {
+	for _, mode := range []Mode{Sync, Async} {
+		var panics []error
+		var mu sync.Mutex
+		b := New(WithMode(mode), WithPanicHandler(func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			panics = append(panics, err)
+		}))
+		var delivered atomic.Int32
+		Subscribe(b, func(passed) { panic("boom") })
+		Subscribe(b, func(passed) { delivered.Add(1) })
+
+		if err := Publish(b, passed{1}); err != nil {
+			t.Fatalf("mode %d: Publish = %v", mode, err)
+		}
+		b.Close()
+
+		var pe *safe.PanicError
+		if len(panics) != 1 || !errors.As(panics[0], &pe) {
+			t.Errorf("mode %d: panics = %v, want one *safe.PanicError", mode, panics)
+		}
+		if delivered.Load() != 1 {
+			t.Errorf("mode %d: the other handler got %d events, want 1", mode, delivered.Load())
+		}
+	}
+}
+
+func TestAsyncDeliversEverythingInOrder(t *testing.T) {
+	b := New(WithMode(Async))
+	const publishers, events = 4, 500
+	got := make([][]int, publishers)
+	Subscribe(b, func(e passed) {
+		// One goroutine runs this subscriber, so no lock is needed.
+		got[e.id/events] = append(got[e.id/events], e.id%events)
+	})
+
+	var wg sync.WaitGroup
+	for p := range publishers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range events {
+				Publish(b, passed{p*events + i})
+			}
+		}()
+	}
+	wg.Wait()
+	b.Close()
+
+	for p, ids := range got {
+		if len(ids) != events || !slices.IsSorted(ids) {
+			t.Errorf("publisher %d: %d events delivered, sorted %v; want all %d in order", p, len(ids), slices.IsSorted(ids), events)
+		}
+	}
+}
+
+func TestClose(t *testing.T) {
+	b := New(WithMode(Async))
+	var n atomic.Int32
+	Subscribe(b, func(passed) { n.Add(1) })
+	Publish(b, passed{1})
+	b.Close()
+	b.Close() // closing twice is fine
+
+	if n.Load() != 1 {
+		t.Errorf("%d events handled before Close returned, want 1", n.Load())
+	}
+	if err := Publish(b, passed{2}); !errors.Is(err, ErrClosed) {
+		t.Errorf("Publish after Close = %v, want ErrClosed", err)
+	}
+	Subscribe(b, func(passed) { t.Error("subscribed to a closed bus") })
+}
+
+// TestConcurrentSubscribe publishes while handlers come and go, for the
+// race detector (make race).
+func TestConcurrentSubscribe(t *testing.T) {
+	for _, mode := range []Mode{Sync, Async} {
+		b := New(WithMode(mode))
+		var wg sync.WaitGroup
+		for range 4 {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				for range 100 {
+					unsubscribe := Subscribe(b, func(passed) {})
+					unsubscribe()
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				for i := range 100 {
+					Publish(b, passed{i})
+				}
+			}()
+		}
+		wg.Wait()
+		b.Close()
+	}
+}
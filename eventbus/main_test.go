@@ -0,0 +1,11 @@
+package eventbus
+
+import (
+	"testing"
+
+	"learning-go/testutil/leak"
+)
+
+func TestMain(m *testing.M) {
+	leak.VerifyMain(m)
+}
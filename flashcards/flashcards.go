@@ -0,0 +1,193 @@
+// Package flashcards turns the "Explanation:" comment at the end of each
+// exercise into a flashcard: the exercise's title on the front, the
+// explanation on the back. Cards can be studied in the terminal with
+// cmd/flashcards or exported to Anki as tab-separated values.
+package flashcards
+
+import (
+	"bufio"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Card is one flashcard.
+type Card struct {
+	// ID names the exercise, e.g. "chapter3/exercise2".
+	ID      string
+	Chapter string
+	Front   string
+	Back    string
+}
+
+var (
+	exerciseFunc  = regexp.MustCompile(`^exercise(\d+)$`)
+	exerciseTitle = regexp.MustCompile(`(?s)^Exercise \d+(?: \([^)]*\))?:\s*(.*)$`)
+)
+
+// Extract walks the chapterN directories under root and returns a card for
+// every exercise that has an explanation, ordered by chapter and exercise.
+// If chapter is not empty (e.g. "chapter3"), only that chapter is scanned.
+func Extract(root, chapter string) ([]Card, error) {
+	var cards []Card
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		top := strings.Split(rel, "/")[0]
+		if d.IsDir() {
+			if rel != "." && (!strings.HasPrefix(top, "chapter") || chapter != "" && top != chapter) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		found, err := extractFile(path, top, filepath.ToSlash(filepath.Dir(rel)))
+		if err != nil {
+			return err
+		}
+		cards = append(cards, found...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(cards, func(i, j int) bool {
+		a, b := cards[i], cards[j]
+		if a.Chapter != b.Chapter {
+			return number(a.Chapter, "chapter") < number(b.Chapter, "chapter")
+		}
+		da, db := filepath.Dir(a.ID), filepath.Dir(b.ID)
+		if da != db {
+			return da < db
+		}
+		return number(filepath.Base(a.ID), "exercise") < number(filepath.Base(b.ID), "exercise")
+	})
+	return cards, nil
+}
+
+func number(s, prefix string) int {
+	n, _ := strconv.Atoi(strings.TrimPrefix(s, prefix))
+	return n
+}
+
+func extractFile(path, chapter, pkgDir string) ([]Card, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	var cards []Card
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || fn.Body == nil || !exerciseFunc.MatchString(fn.Name.Name) {
+			continue
+		}
+		back := explanation(f, fn)
+		if back == "" {
+			continue
+		}
+		cards = append(cards, Card{
+			ID:      pkgDir + "/" + fn.Name.Name,
+			Chapter: chapter,
+			Front:   title(fn),
+			Back:    back,
+		})
+	}
+	return cards, nil
+}
+
+// title returns the exercise statement from the doc comment, without the
+// "Exercise N:" prefix, as a single line.
+func title(fn *ast.FuncDecl) string {
+	text := strings.TrimSpace(fn.Doc.Text())
+	if m := exerciseTitle.FindStringSubmatch(text); m != nil {
+		text = m[1]
+	}
+	// Drop a trailing "Tags:" line.
+	if i := strings.Index(text, "\nTags:"); i >= 0 {
+		text = text[:i]
+	}
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// explanation returns the text of the comment inside fn's body that starts
+// with "Explanation:". Wrapped lines are joined, but list items ("- ...")
+// and paragraphs keep their line breaks.
+func explanation(f *ast.File, fn *ast.FuncDecl) string {
+	for _, cg := range f.Comments {
+		if cg.Pos() < fn.Body.Lbrace || cg.End() > fn.Body.Rbrace {
+			continue
+		}
+		text := cg.Text()
+		if rest, ok := strings.CutPrefix(text, "Explanation:"); ok {
+			return unwrap(rest)
+		}
+	}
+	return ""
+}
+
+func unwrap(text string) string {
+	var b strings.Builder
+	newParagraph := true
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			if b.Len() > 0 {
+				b.WriteString("\n")
+			}
+			newParagraph = true
+			continue
+		case newParagraph || strings.HasPrefix(line, "- "):
+			if b.Len() > 0 {
+				b.WriteString("\n")
+			}
+		default:
+			b.WriteString(" ")
+		}
+		b.WriteString(line)
+		newParagraph = false
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// WriteTSV writes cards in the format Anki imports with "Fields separated
+// by: Tab" and "Allow HTML": front, back, and tags (the chapter). Tabs and
+// newlines inside a field are not allowed by the format, so they become
+// spaces and <br>, and HTML special characters are escaped.
+func WriteTSV(w io.Writer, cards []Card) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "#separator:tab")
+	fmt.Fprintln(bw, "#html:true")
+	fmt.Fprintln(bw, "#tags column:3")
+	for _, c := range cards {
+		front := tsvField(c.ID + ": " + c.Front)
+		fmt.Fprintf(bw, "%s\t%s\t%s\n", front, tsvField(c.Back), c.Chapter)
+	}
+	return bw.Flush()
+}
+
+var tsvEscaper = strings.NewReplacer(
+	"&", "&amp;", "<", "&lt;", ">", "&gt;",
+	"\t", " ", "\r\n", "<br>", "\n", "<br>", "\r", "<br>",
+)
+
+func tsvField(s string) string {
+	return tsvEscaper.Replace(s)
+}
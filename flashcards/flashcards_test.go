@@ -0,0 +1,114 @@
+package flashcards
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const chapter3 = `package main
+
+// Exercise 1: Declare a slice of greetings
+// and print it.
+//
+// Tags: slices
+func exercise1() {
+	// Explanation: a slice literal
+	// lists its elements.
+	//
+	// - len counts them
+	// - cap may be larger
+}
+
+// Exercise 2: No explanation, so no card.
+func exercise2() {}
+
+// helper is not an exercise.
+func helper() {
+	// Explanation: never read.
+}
+`
+
+const chapter12rpc = `package rpc
+
+// Exercise 1 (hard): Call a method remotely.
+func exercise1() {
+	// Explanation: net/rpc encodes the arguments with gob.
+}
+`
+
+// tree writes a repository with three chapters under a temporary root.
+func tree(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, src := range map[string]string{
+		"chapter3/main.go":         chapter3,
+		"chapter3/main_test.go":    chapter12rpc,
+		"chapter12/rpc/main.go":    chapter12rpc,
+		"chapter10/exercise.go":    strings.ReplaceAll(chapter12rpc, "package rpc", "package main"),
+		"datastructures/skip/x.go": chapter12rpc,
+	} {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestExtract(t *testing.T) {
+	root := tree(t)
+	cards, err := Extract(root, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, c := range cards {
+		ids = append(ids, c.ID)
+	}
+	// By chapter number, not name; test files and directories that are
+	// not chapters are skipped.
+	want := []string{"chapter3/exercise1", "chapter10/exercise1", "chapter12/rpc/exercise1"}
+	if !slices.Equal(ids, want) {
+		t.Fatalf("Extract IDs = %q, want %q", ids, want)
+	}
+
+	c := cards[0]
+	if c.Chapter != "chapter3" || c.Front != "Declare a slice of greetings and print it." {
+		t.Errorf("card %s: chapter %q, front %q", c.ID, c.Chapter, c.Front)
+	}
+	if want := "a slice literal lists its elements.\n\n- len counts them\n- cap may be larger"; c.Back != want {
+		t.Errorf("card %s: back %q, want %q", c.ID, c.Back, want)
+	}
+	if c := cards[2]; c.Chapter != "chapter12" || c.Front != "Call a method remotely." {
+		t.Errorf("card %s: chapter %q, front %q", c.ID, c.Chapter, c.Front)
+	}
+
+	cards, err = Extract(root, "chapter12")
+	if err != nil || len(cards) != 1 || cards[0].ID != "chapter12/rpc/exercise1" {
+		t.Errorf("Extract(chapter12) = %+v, %v; want the rpc card alone", cards, err)
+	}
+}
+
+func TestWriteTSV(t *testing.T) {
+	var sb strings.Builder
+	err := WriteTSV(&sb, []Card{{
+		ID:      "chapter8/exercise1",
+		Chapter: "chapter8",
+		Front:   "Write Max[T cmp.Ordered]",
+		Back:    "a<b\tuses <\nthe constraint",
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "#separator:tab\n#html:true\n#tags column:3\n" +
+		"chapter8/exercise1: Write Max[T cmp.Ordered]\ta&lt;b uses &lt;<br>the constraint\tchapter8\n"
+	if sb.String() != want {
+		t.Errorf("WriteTSV =\n%q\nwant\n%q", sb.String(), want)
+	}
+}
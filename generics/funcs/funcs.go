@@ -0,0 +1,143 @@
+// Package funcs provides generic helpers for slices and maps in the
+// functional style: Map, Filter and Reduce, plus Zip, GroupBy, Chunk and
+// helpers that pull the keys or values out of a map.
+//
+// Every function takes and returns plain slices, so the results can be
+// passed straight to package slices. For lazy versions that work on
+// iterators instead, see package seq.
+//
+// The constraints show the three kinds a type parameter can have: any
+// (Map, Filter), a standard constraint such as comparable or cmp.Ordered
+// (Keys, SortedKeys), and one declared here, Keyed, which requires a
+// method rather than a set of types (IndexBy).
+package funcs
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"learning-go/tuple"
+)
+
+// Map returns f applied to every element of s, in order.
+func Map[T, R any](s []T, f func(T) R) []R {
+	out := make([]R, len(s))
+	for i, v := range s {
+		out[i] = f(v)
+	}
+	return out
+}
+
+// Filter returns the elements of s for which keep returns true, in order.
+// The result is a new slice; s is not modified.
+func Filter[T any](s []T, keep func(T) bool) []T {
+	var out []T
+	for _, v := range s {
+		if keep(v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Reduce folds s into a single value: it calls f with the accumulator and
+// each element in turn, starting from init. The accumulator's type need
+// not match the elements', so Reduce can count, sum or build a map.
+func Reduce[T, A any](s []T, init A, f func(acc A, v T) A) A {
+	acc := init
+	for _, v := range s {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
+// Zip pairs the elements of a and b by index. The result is as long as
+// the shorter slice; extra elements of the longer one are ignored.
+func Zip[A, B any](a []A, b []B) []tuple.Pair[A, B] {
+	out := make([]tuple.Pair[A, B], min(len(a), len(b)))
+	for i := range out {
+		out[i] = tuple.NewPair(a[i], b[i])
+	}
+	return out
+}
+
+// GroupBy splits s into groups of elements with the same key. The groups
+// come in the order their keys first appear in s, and each keeps the
+// order of its elements, so unlike grouping into a map the result is
+// deterministic.
+func GroupBy[T any, K comparable](s []T, key func(T) K) []tuple.Pair[K, []T] {
+	var groups []tuple.Pair[K, []T]
+	index := make(map[K]int)
+	for _, v := range s {
+		k := key(v)
+		i, ok := index[k]
+		if !ok {
+			i = len(groups)
+			index[k] = i
+			groups = append(groups, tuple.NewPair(k, []T(nil)))
+		}
+		groups[i].Second = append(groups[i].Second, v)
+	}
+	return groups
+}
+
+// Chunk splits s into consecutive slices of size elements; the last one
+// holds whatever is left and may be shorter. The chunks share s's backing
+// array, with their capacity clipped so appending to one cannot overwrite
+// the next. It panics if size is less than 1.
+func Chunk[T any](s []T, size int) [][]T {
+	if size < 1 {
+		panic(fmt.Sprintf("funcs: Chunk size %d must be at least 1", size))
+	}
+	chunks := make([][]T, 0, (len(s)+size-1)/size)
+	for len(s) > 0 {
+		n := min(size, len(s))
+		chunks = append(chunks, s[:n:n])
+		s = s[n:]
+	}
+	return chunks
+}
+
+// Keys returns the keys of m in no particular order, like ranging over
+// the map. The ~map[K]V constraint accepts named map types as well.
+func Keys[M ~map[K]V, K comparable, V any](m M) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Values returns the values of m in no particular order.
+func Values[M ~map[K]V, K comparable, V any](m M) []V {
+	values := make([]V, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	return values
+}
+
+// SortedKeys returns the keys of m in ascending order. It needs
+// cmp.Ordered rather than comparable, because sorting uses <.
+func SortedKeys[M ~map[K]V, K cmp.Ordered, V any](m M) []K {
+	keys := Keys(m)
+	slices.Sort(keys)
+	return keys
+}
+
+// Keyed is a constraint made of a method instead of a type set: any type
+// that can report a key of type K satisfies it.
+type Keyed[K comparable] interface {
+	Key() K
+}
+
+// IndexBy returns a map from each item's key to the item. Later items
+// replace earlier ones with the same key.
+func IndexBy[T Keyed[K], K comparable](items []T) map[K]T {
+	index := make(map[K]T, len(items))
+	for _, item := range items {
+		index[item.Key()] = item
+	}
+	return index
+}
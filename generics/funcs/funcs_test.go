@@ -0,0 +1,181 @@
+package funcs
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"testing"
+
+	"learning-go/tuple"
+)
+
+func TestMap(t *testing.T) {
+	tests := []struct {
+		in   []int
+		want []string
+	}{
+		{nil, []string{}},
+		{[]int{1}, []string{"1"}},
+		{[]int{3, 1, 2}, []string{"3", "1", "2"}},
+	}
+	for _, tt := range tests {
+		if got := Map(tt.in, strconv.Itoa); !slices.Equal(got, tt.want) {
+			t.Errorf("Map(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	even := func(n int) bool { return n%2 == 0 }
+	tests := []struct {
+		in, want []int
+	}{
+		{nil, nil},
+		{[]int{1, 3}, nil},
+		{[]int{1, 2, 3, 4, 6}, []int{2, 4, 6}},
+	}
+	for _, tt := range tests {
+		in := slices.Clone(tt.in)
+		if got := Filter(in, even); !slices.Equal(got, tt.want) {
+			t.Errorf("Filter(%v) = %v, want %v", tt.in, got, tt.want)
+		}
+		if !slices.Equal(in, tt.in) {
+			t.Errorf("Filter modified its input: %v, was %v", in, tt.in)
+		}
+	}
+}
+
+func TestReduce(t *testing.T) {
+	words := []string{"go", "is", "fun", "go"}
+	if got := Reduce(words, 0, func(n int, w string) int { return n + len(w) }); got != 9 {
+		t.Errorf("total length = %d, want 9", got)
+	}
+	counts := Reduce(words, map[string]int{}, func(m map[string]int, w string) map[string]int {
+		m[w]++
+		return m
+	})
+	if want := map[string]int{"go": 2, "is": 1, "fun": 1}; !maps.Equal(counts, want) {
+		t.Errorf("counts = %v, want %v", counts, want)
+	}
+	if got := Reduce([]int(nil), 7, func(a, b int) int { return a + b }); got != 7 {
+		t.Errorf("Reduce of nothing = %d, want init 7", got)
+	}
+}
+
+func TestZip(t *testing.T) {
+	tests := []struct {
+		a    []int
+		b    []string
+		want []tuple.Pair[int, string]
+	}{
+		{nil, nil, nil},
+		{[]int{1, 2}, []string{"a", "b"}, []tuple.Pair[int, string]{tuple.NewPair(1, "a"), tuple.NewPair(2, "b")}},
+		{[]int{1, 2, 3}, []string{"a"}, []tuple.Pair[int, string]{tuple.NewPair(1, "a")}},
+		{[]int{1}, []string{"a", "b"}, []tuple.Pair[int, string]{tuple.NewPair(1, "a")}},
+	}
+	for _, tt := range tests {
+		if got := Zip(tt.a, tt.b); !slices.Equal(got, tt.want) {
+			t.Errorf("Zip(%v, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	type group = tuple.Pair[int, []string]
+	words := []string{"go", "map", "ok", "for", "a", "if", "func"}
+	tests := []struct {
+		in   []string
+		want []group
+	}{
+		{nil, nil},
+		{[]string{"x"}, []group{tuple.NewPair(1, []string{"x"})}},
+		// Keys in order of first appearance, words in their original order.
+		{words, []group{
+			tuple.NewPair(2, []string{"go", "ok", "if"}),
+			tuple.NewPair(3, []string{"map", "for"}),
+			tuple.NewPair(1, []string{"a"}),
+			tuple.NewPair(4, []string{"func"}),
+		}},
+	}
+	for _, tt := range tests {
+		got := GroupBy(tt.in, func(s string) int { return len(s) })
+		equal := slices.EqualFunc(got, tt.want, func(g, w group) bool {
+			return g.First == w.First && slices.Equal(g.Second, w.Second)
+		})
+		if !equal {
+			t.Errorf("GroupBy(%q, len) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestChunk(t *testing.T) {
+	tests := []struct {
+		in   []int
+		size int
+		want [][]int
+	}{
+		{nil, 2, [][]int{}},
+		{[]int{1, 2, 3, 4}, 2, [][]int{{1, 2}, {3, 4}}},
+		{[]int{1, 2, 3, 4, 5}, 2, [][]int{{1, 2}, {3, 4}, {5}}},
+		{[]int{1, 2}, 5, [][]int{{1, 2}}},
+	}
+	for _, tt := range tests {
+		got := Chunk(tt.in, tt.size)
+		if !slices.EqualFunc(got, tt.want, slices.Equal) {
+			t.Errorf("Chunk(%v, %d) = %v, want %v", tt.in, tt.size, got, tt.want)
+		}
+	}
+}
+
+func TestChunkClipsCapacity(t *testing.T) {
+	s := []int{1, 2, 3, 4}
+	chunks := Chunk(s, 2)
+	_ = append(chunks[0], 99)
+	if s[2] != 3 {
+		t.Errorf("appending to the first chunk overwrote the second: %v", s)
+	}
+}
+
+func TestChunkPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Chunk(s, 0) did not panic")
+		}
+	}()
+	Chunk([]int{1}, 0)
+}
+
+type celsius map[string]float64
+
+func TestKeysValues(t *testing.T) {
+	m := celsius{"oslo": -3, "rome": 14, "cairo": 25}
+	keys := Keys(m)
+	slices.Sort(keys)
+	if want := []string{"cairo", "oslo", "rome"}; !slices.Equal(keys, want) {
+		t.Errorf("Keys = %v, want %v", keys, want)
+	}
+	if got := SortedKeys(m); !slices.Equal(got, keys) {
+		t.Errorf("SortedKeys = %v, want %v", got, keys)
+	}
+	values := Values(m)
+	slices.Sort(values)
+	if want := []float64{-3, 14, 25}; !slices.Equal(values, want) {
+		t.Errorf("Values = %v, want %v", values, want)
+	}
+}
+
+type user struct {
+	email string
+	name  string
+}
+
+func (u user) Key() string { return u.email }
+
+func TestIndexBy(t *testing.T) {
+	users := []user{{"a@x", "Ann"}, {"b@x", "Bob"}, {"a@x", "Anna"}}
+	got := IndexBy(users)
+	want := map[string]user{"a@x": {"a@x", "Anna"}, "b@x": {"b@x", "Bob"}}
+	if !maps.Equal(got, want) {
+		t.Errorf("IndexBy = %v, want %v", got, want)
+	}
+}
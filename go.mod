@@ -1,3 +1,34 @@
 module learning-go
 
 go 1.23.1
+
+require (
+	github.com/fsnotify/fsnotify v1.9.0
+	github.com/google/wire v0.6.0
+	golang.org/x/sync v0.16.0
+	golang.org/x/tools v0.36.0
+	learning-go/chapter10/greetings v1.0.0
+	learning-go/chapter10/greetings/v2 v2.0.0
+	modernc.org/sqlite v1.38.0
+)
+
+require (
+	github.com/dustin/go-humanize v1.0.1 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/ncruces/go-strftime v0.1.9 // indirect
+	github.com/remyoudompheng/bigfft v0.0.0-20230129092748-24d4a6f8daec // indirect
+	golang.org/x/exp v0.0.0-20250408133849-7e4ce0ab07d0 // indirect
+	golang.org/x/mod v0.27.0 // indirect
+	golang.org/x/sys v0.35.0 // indirect
+	modernc.org/libc v1.65.10 // indirect
+	modernc.org/mathutil v1.7.1 // indirect
+	modernc.org/memory v1.11.0 // indirect
+)
+
+// The greetings module lives inside this repository, so both of its major
+// versions are resolved from local directories instead of a proxy.
+replace (
+	learning-go/chapter10/greetings => ./chapter10/greetings
+	learning-go/chapter10/greetings/v2 => ./chapter10/greetings/v2
+)
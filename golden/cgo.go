@@ -0,0 +1,6 @@
+//go:build cgo
+
+package golden
+
+// cgoEnabled reports whether this program was built with cgo.
+const cgoEnabled = true
@@ -0,0 +1,209 @@
+// Package golden checks exercises against golden files: the output a
+// correct run prints, stored beside the chapter's code in
+// testdata/<exercise>.golden (for example chapter3/testdata/exercise2.golden).
+//
+// Only exercises whose output is the same on every run have a golden file.
+// Update refuses to write one for an exercise that prints timings,
+// addresses or anything else that changes between runs. Some exercises
+// print the same thing every run but something different on every
+// machine, such as the uptime; they are listed in hostDependent and never
+// get a golden file either.
+//
+// Exercises whose output depends only on how the program was built, such
+// as struct sizes on 32- and 64-bit platforms, have one golden file per
+// build variant instead: testdata/<exercise>_<variant>.golden, for
+// example chapter16/testdata/exercise2_amd64.golden.
+package golden
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"learning-go/diff"
+	"learning-go/errs"
+	"learning-go/registry"
+	"learning-go/safe"
+)
+
+var (
+	// ErrNoGolden means the exercise has no golden file to compare with.
+	ErrNoGolden = errors.New("no golden file")
+	// ErrNondeterministic means two runs of the exercise printed different
+	// output, so the output cannot be recorded as a golden file.
+	ErrNondeterministic = errors.New("output differs between runs")
+	// ErrHostDependent means the exercise prints something about the
+	// machine it runs on, so no golden file can hold its output.
+	ErrHostDependent = errors.New("output depends on the host")
+)
+
+// hostDependent lists the exercises that print something about the host,
+// by exercise ID, with what they print. Check and Update skip them.
+var hostDependent = map[string]string{
+	"chapter11/exercise2": "prints the operating system, the uptime and the user's config directory",
+}
+
+// variants lists the exercises whose output depends on the build, by
+// exercise ID. Each function returns the variant of a build with or
+// without cgo, for the platform this program was built for.
+var variants = map[string]func(cgo bool) string{
+	// cgoexample greets from C with cgo and from Go without.
+	"chapter16/exercise1": func(cgo bool) string {
+		if cgo {
+			return "cgo"
+		}
+		return "nocgo"
+	},
+	// Struct sizes and alignments depend on the architecture.
+	"chapter16/exercise2": func(bool) string { return runtime.GOARCH },
+}
+
+// MismatchError reports the first line where an exercise's output and its
+// golden file differ, and every differing line in Changes. It wraps
+// errs.ErrOutputMismatch.
+type MismatchError struct {
+	Line      int
+	Got, Want string
+	// Changes lists each differing line, from the golden file to the
+	// output, as package diff reports them.
+	Changes []diff.Change
+}
+
+func (e *MismatchError) Error() string {
+	return fmt.Sprintf("line %d: got %q, want %q", e.Line, e.Got, e.Want)
+}
+
+// Unwrap makes errors.Is(err, errs.ErrOutputMismatch) true.
+func (e *MismatchError) Unwrap() error {
+	return errs.ErrOutputMismatch
+}
+
+// Path returns the golden file of ex under the repository root, for a
+// build like this program's.
+func Path(root string, ex registry.Exercise) string {
+	return PathFor(root, ex, cgoEnabled)
+}
+
+// PathFor is like Path for a build with or without cgo, on the same
+// platform. The grader uses it for solutions built with cgo disabled.
+func PathFor(root string, ex registry.Exercise, cgo bool) string {
+	name := ex.Name
+	if variant, ok := variants[ex.ID()]; ok {
+		name += "_" + variant(cgo)
+	}
+	return filepath.Join(root, filepath.FromSlash(ex.Chapter), "testdata", name+".golden")
+}
+
+// Capture runs ex and returns everything it wrote. A panic is returned as
+// a *safe.PanicError along with the output written before it.
+func Capture(ex registry.Exercise) ([]byte, error) {
+	var w lockedWriter
+	err := safe.SafeCall(func() error {
+		ex.Run(&w)
+		return nil
+	})
+	return w.bytes(), err
+}
+
+// Check runs ex and compares its output with the golden file. It returns
+// an error wrapping ErrNoGolden if there is no golden file,
+// ErrHostDependent for an exercise in hostDependent, and a
+// *MismatchError if the output differs.
+func Check(root string, ex registry.Exercise) error {
+	if err := checkHost(ex); err != nil {
+		return err
+	}
+	want, err := os.ReadFile(Path(root, ex))
+	if errors.Is(err, os.ErrNotExist) {
+		return errs.InExercise(ex.Chapter, ex.Name, ErrNoGolden)
+	}
+	if err != nil {
+		return err
+	}
+	got, err := Capture(ex)
+	if err != nil {
+		return errs.InExercise(ex.Chapter, ex.Name, err)
+	}
+	return errs.InExercise(ex.Chapter, ex.Name, compare(got, want))
+}
+
+// Update runs ex twice and writes its output as the golden file. If the
+// two runs disagree, nothing is written and the error wraps
+// ErrNondeterministic. It refuses exercises in hostDependent with
+// ErrHostDependent.
+func Update(root string, ex registry.Exercise) error {
+	if err := checkHost(ex); err != nil {
+		return err
+	}
+	first, err := Capture(ex)
+	if err != nil {
+		return errs.InExercise(ex.Chapter, ex.Name, err)
+	}
+	second, err := Capture(ex)
+	if err != nil {
+		return errs.InExercise(ex.Chapter, ex.Name, err)
+	}
+	if mismatch := compare(second, first); mismatch != nil {
+		return errs.InExercise(ex.Chapter, ex.Name, fmt.Errorf("%w (%v)", ErrNondeterministic, mismatch))
+	}
+	path := Path(root, ex)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, first, 0o644)
+}
+
+func checkHost(ex registry.Exercise) error {
+	if reason, ok := hostDependent[ex.ID()]; ok {
+		return errs.InExercise(ex.Chapter, ex.Name, fmt.Errorf("%w: it %s", ErrHostDependent, reason))
+	}
+	return nil
+}
+
+// compare returns a *MismatchError for the differing lines, or nil.
+func compare(got, want []byte) error {
+	if bytes.Equal(got, want) {
+		return nil
+	}
+	changes := diff.Lines(string(want), string(got))
+	if len(changes) == 0 {
+		return errs.ErrOutputMismatch
+	}
+	e := &MismatchError{Changes: changes}
+	fmt.Sscanf(changes[0].Path, "line %d", &e.Line)
+	g, w := strings.Split(string(got), "\n"), strings.Split(string(want), "\n")
+	if e.Line <= len(g) {
+		e.Got = g[e.Line-1]
+	}
+	if e.Line <= len(w) {
+		e.Want = w[e.Line-1]
+	}
+	return e
+}
+
+// lockedWriter is a buffer that exercises may write to from several
+// goroutines at once, as they can with os.Stdout.
+type lockedWriter struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+var _ io.Writer = (*lockedWriter)(nil)
+
+func (w *lockedWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *lockedWriter) bytes() []byte {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return bytes.Clone(w.buf.Bytes())
+}
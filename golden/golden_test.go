@@ -0,0 +1,102 @@
+package golden
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"learning-go/errs"
+	"learning-go/registry"
+)
+
+func exercise(chapter, name, output string) registry.Exercise {
+	return registry.Exercise{Chapter: chapter, Name: name, Run: func(w io.Writer) { io.WriteString(w, output) }}
+}
+
+func TestPathFor(t *testing.T) {
+	tests := []struct {
+		ex   registry.Exercise
+		cgo  bool
+		want string
+	}{
+		{exercise("chapter3", "exercise2", ""), true, "chapter3/testdata/exercise2.golden"},
+		{exercise("chapter12/rpc", "exercise1", ""), false, "chapter12/rpc/testdata/exercise1.golden"},
+		{exercise("chapter16", "exercise1", ""), true, "chapter16/testdata/exercise1_cgo.golden"},
+		{exercise("chapter16", "exercise1", ""), false, "chapter16/testdata/exercise1_nocgo.golden"},
+		{exercise("chapter16", "exercise2", ""), false, "chapter16/testdata/exercise2_" + runtime.GOARCH + ".golden"},
+	}
+	for _, tt := range tests {
+		if got := PathFor("root", tt.ex, tt.cgo); got != filepath.Join("root", filepath.FromSlash(tt.want)) {
+			t.Errorf("PathFor(%s, cgo %v) = %s, want root/%s", tt.ex.ID(), tt.cgo, got, tt.want)
+		}
+	}
+	ex := exercise("chapter16", "exercise1", "")
+	if Path("root", ex) != PathFor("root", ex, cgoEnabled) {
+		t.Errorf("Path = %s, want the variant of this build", Path("root", ex))
+	}
+}
+
+func TestCheckAndUpdate(t *testing.T) {
+	root := t.TempDir()
+	ex := exercise("chapter99", "exercise1", "one\ntwo\n")
+	if err := Check(root, ex); !errors.Is(err, ErrNoGolden) {
+		t.Errorf("Check without a golden file = %v, want ErrNoGolden", err)
+	}
+	if err := Update(root, ex); err != nil {
+		t.Fatal(err)
+	}
+	if err := Check(root, ex); err != nil {
+		t.Errorf("Check after Update = %v", err)
+	}
+
+	changed := exercise("chapter99", "exercise1", "one\nthree\n")
+	err := Check(root, changed)
+	var mismatch *MismatchError
+	if !errors.As(err, &mismatch) || !errors.Is(err, errs.ErrOutputMismatch) {
+		t.Fatalf("Check of different output = %v, want a *MismatchError", err)
+	}
+	if mismatch.Line != 2 || mismatch.Got != "three" || mismatch.Want != "two" {
+		t.Errorf("mismatch = %+v, want line 2, three for two", mismatch)
+	}
+
+	n := 0
+	counter := registry.Exercise{Chapter: "chapter99", Name: "exercise2", Run: func(w io.Writer) {
+		n++
+		fmt.Fprintln(w, n)
+	}}
+	if err := Update(root, counter); !errors.Is(err, ErrNondeterministic) {
+		t.Errorf("Update of changing output = %v, want ErrNondeterministic", err)
+	}
+	if _, err := os.Stat(Path(root, counter)); !errors.Is(err, os.ErrNotExist) {
+		t.Error("Update wrote a golden file for changing output")
+	}
+}
+
+func TestHostDependent(t *testing.T) {
+	root := t.TempDir()
+	ex := exercise("chapter11", "exercise2", "same every run\n")
+	if err := Update(root, ex); !errors.Is(err, ErrHostDependent) {
+		t.Errorf("Update = %v, want ErrHostDependent", err)
+	}
+	if err := Check(root, ex); !errors.Is(err, ErrHostDependent) {
+		t.Errorf("Check = %v, want ErrHostDependent", err)
+	}
+	if _, err := os.Stat(Path(root, ex)); !errors.Is(err, os.ErrNotExist) {
+		t.Error("Update wrote a golden file for a host-dependent exercise")
+	}
+}
+
+func TestCapturePanic(t *testing.T) {
+	ex := registry.Exercise{Chapter: "chapter99", Name: "exercise3", Run: func(w io.Writer) {
+		io.WriteString(w, "before\n")
+		panic("boom")
+	}}
+	out, err := Capture(ex)
+	if string(out) != "before\n" || err == nil {
+		t.Errorf("Capture = %q, %v; want the output before the panic and an error", out, err)
+	}
+}
@@ -0,0 +1,5 @@
+//go:build !cgo
+
+package golden
+
+const cgoEnabled = false
@@ -0,0 +1,28 @@
+package grade
+
+import (
+	"errors"
+
+	"learning-go/errs"
+	"learning-go/internal/solutions"
+)
+
+// CheckAnswer grades an answer to one of the interview problems, such as
+// "two-sum", for the input it answers, written the way the problem
+// statement writes them. The reference solutions are internal to this
+// module; this is how code outside it checks an answer against them.
+//
+// It returns nil for a right answer and an error wrapping
+// errs.ErrOutputMismatch for a wrong one, which does not reveal the right
+// answer. An unknown problem or input that cannot be parsed is a
+// *errs.ValidationError.
+func CheckAnswer(problem, input, answer string) error {
+	err := solutions.CheckAnswer(problem, input, answer)
+	switch {
+	case err == nil, errors.Is(err, errs.ErrOutputMismatch):
+		return err
+	case errors.Is(err, solutions.ErrUnknown):
+		return errs.Invalid("problem", "no problem called %q", problem)
+	}
+	return errs.Invalid("input", "%v", err)
+}
@@ -0,0 +1,197 @@
+// Package grade checks a submitted solution against an exercise: it runs
+// the solution in the sandbox, compares what it prints with the
+// exercise's golden file (package golden), checks that it uses the
+// constructs the exercise is about (package verify), and runs the
+// analyzers from package analysis over it. Handler exposes the same thing as a JSON API, so
+// editor plugins and study groups can grade against one shared server.
+//
+// CheckAnswer grades answers to the interview problems against their
+// reference solutions, which are internal to the module.
+package grade
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"learning-go/diff"
+	"learning-go/errs"
+	"learning-go/eventbus"
+	"learning-go/golden"
+	"learning-go/registry"
+	"learning-go/sandbox"
+	"learning-go/verify"
+)
+
+// Submission is a solution for one exercise.
+type Submission struct {
+	// Exercise is written as "<chapter>/<exercise>", e.g. "chapter12/exercise1".
+	Exercise string `json:"exercise"`
+	// Files maps file names to Go source. All files belong to package main.
+	Files map[string]string `json:"files"`
+	// User is who submitted it. Anonymous submissions are graded but not
+	// put on the leaderboard.
+	User string `json:"user,omitempty"`
+}
+
+// Diagnostic is one analyzer finding.
+type Diagnostic struct {
+	Analyzer string `json:"analyzer"`
+	Pos      string `json:"pos"`
+	Message  string `json:"message"`
+}
+
+// Report is the outcome of grading a submission.
+type Report struct {
+	Exercise string `json:"exercise"`
+	// Passed is true when the solution compiled, ran successfully, printed
+	// exactly the exercise's golden output and uses every required
+	// construct. Diagnostics are advice and do not fail it.
+	Passed       bool     `json:"passed"`
+	CompileError string   `json:"compile_error,omitempty"`
+	Missing      []string `json:"missing,omitempty"`
+	// Diff lists the lines of the golden output and of Stdout when they
+	// differ: " " before a line both have, "-" before one only the
+	// golden output has and "+" before one only Stdout has.
+	Diff        []string     `json:"diff,omitempty"`
+	Diagnostics []Diagnostic `json:"diagnostics,omitempty"`
+	Stdout      string       `json:"stdout"`
+	Stderr      string       `json:"stderr"`
+	ExitCode    int          `json:"exit_code"`
+	TimedOut    bool         `json:"timed_out"`
+	DurationMS  int64        `json:"duration_ms"`
+}
+
+// Grader grades submissions.
+type Grader struct {
+	// Sandbox sets the limits the solution runs under.
+	Sandbox sandbox.Config
+	// Root is the repository root, where the exercises' golden files are.
+	Root string
+	// Events, if set, receives a Graded event for every graded
+	// submission. The grader does not know who listens; the server hooks
+	// up the leaderboard this way.
+	Events *eventbus.Bus
+}
+
+// Graded is published on Grader.Events after a submission is graded.
+type Graded struct {
+	Submission Submission
+	Report     *Report
+	// Duration is the program's exact running time; Report rounds it.
+	Duration time.Duration
+}
+
+var exerciseName = regexp.MustCompile(`^chapter[0-9]+/exercise[0-9]+$`)
+
+// Grade grades sub. Problems with the submission itself are returned as
+// *errs.ValidationError; any other error means grading could not be done.
+func (g *Grader) Grade(ctx context.Context, sub Submission) (*Report, error) {
+	if !exerciseName.MatchString(sub.Exercise) {
+		return nil, errs.Invalid("exercise", "%q is not of the form chapterN/exerciseN", sub.Exercise)
+	}
+	if len(sub.Files) == 0 {
+		return nil, errs.Invalid("files", "no source files")
+	}
+	for name := range sub.Files {
+		if name != filepath.Base(name) || filepath.Ext(name) != ".go" {
+			return nil, errs.Invalid("files", "%q is not a plain .go file name", name)
+		}
+	}
+
+	chapter, name, _ := strings.Cut(sub.Exercise, "/")
+	// The sandbox builds without cgo, so compare with that variant.
+	want, err := os.ReadFile(golden.PathFor(g.Root, registry.Exercise{Chapter: chapter, Name: name}, false))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, errs.Invalid("exercise", "%s has no golden file to grade against", sub.Exercise)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("grade: %w", err)
+	}
+
+	rep := &Report{Exercise: sub.Exercise}
+
+	fset := token.NewFileSet()
+	files, err := parseFiles(fset, sub.Files)
+	if err != nil {
+		// Not worth running the compiler for; report it the same way.
+		rep.CompileError = err.Error()
+		return rep, nil
+	}
+
+	if err := verify.CheckFiles(sub.Exercise, files, verify.Requirements[sub.Exercise]); err != nil {
+		var missing *verify.MissingError
+		if !errors.As(err, &missing) {
+			return nil, err
+		}
+		for _, c := range missing.Missing {
+			rep.Missing = append(rep.Missing, string(c))
+		}
+	}
+
+	sources := make(map[string][]byte, len(sub.Files))
+	for name, src := range sub.Files {
+		sources[name] = []byte(src)
+	}
+	res, err := sandbox.Run(ctx, g.Sandbox, sources)
+	if err != nil {
+		return nil, fmt.Errorf("grade: %w", err)
+	}
+	rep.CompileError = res.CompileError
+	rep.Stdout, rep.Stderr = res.Stdout, res.Stderr
+	rep.ExitCode, rep.TimedOut = res.ExitCode, res.TimedOut
+	rep.DurationMS = res.Duration.Milliseconds()
+	if res.CompileError != "" {
+		return rep, nil
+	}
+
+	// The build succeeded, so type checking will too.
+	rep.Diagnostics, err = analyze(fset, files)
+	if err != nil {
+		return nil, fmt.Errorf("grade: running analyzers: %w", err)
+	}
+	if !res.TimedOut && res.Stdout != string(want) {
+		for _, e := range diff.Align(string(want), res.Stdout) {
+			rep.Diff = append(rep.Diff, diffPrefix[e.Op]+e.Line)
+		}
+	}
+	rep.Passed = res.OK() && res.Stdout == string(want) && len(rep.Missing) == 0
+
+	if g.Events != nil {
+		// Only a closed bus fails, and then nobody is listening anyway.
+		eventbus.Publish(g.Events, Graded{Submission: sub, Report: rep, Duration: res.Duration})
+	}
+	return rep, nil
+}
+
+var diffPrefix = map[diff.Op]string{diff.Equal: " ", diff.Delete: "-", diff.Insert: "+"}
+
+// parseFiles parses files in name order, so positions and diagnostics come
+// out the same way every time.
+func parseFiles(fset *token.FileSet, sources map[string]string) ([]*ast.File, error) {
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var files []*ast.File
+	for _, name := range names {
+		f, err := parser.ParseFile(fset, name, sources[name], parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
@@ -0,0 +1,70 @@
+package grade
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"learning-go/errs"
+)
+
+func TestGradeComparesGolden(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds programs")
+	}
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "chapter99", "testdata"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "chapter99", "testdata", "exercise1.golden"), []byte("one\ntwo\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	g := &Grader{Root: root}
+	submit := func(exercise, body string) (*Report, error) {
+		return g.Grade(context.Background(), Submission{
+			Exercise: exercise,
+			Files:    map[string]string{"main.go": "package main\n\nimport \"fmt\"\n\nfunc main() {\n" + body + "\n}\n"},
+		})
+	}
+
+	rep, err := submit("chapter99/exercise1", `fmt.Println("one"); fmt.Println("two")`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rep.Passed || rep.Diff != nil {
+		t.Errorf("matching output: passed %v, diff %q", rep.Passed, rep.Diff)
+	}
+
+	rep, err = submit("chapter99/exercise1", `fmt.Println("one"); fmt.Println("three")`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{" one", "-two", "+three"}; rep.Passed || !slices.Equal(rep.Diff, want) {
+		t.Errorf("different output: passed %v, diff %q, want %q", rep.Passed, rep.Diff, want)
+	}
+
+	_, err = submit("chapter99/exercise2", `fmt.Println("one")`)
+	var invalid *errs.ValidationError
+	if !errors.As(err, &invalid) {
+		t.Errorf("grading an exercise without a golden file = %v, want a validation error", err)
+	}
+}
+
+func TestCheckAnswer(t *testing.T) {
+	const input = "nums = [3,2,4], target = 6"
+	if err := CheckAnswer("two-sum", input, "[1,2]"); err != nil {
+		t.Errorf("right answer: %v", err)
+	}
+	if err := CheckAnswer("two-sum", input, "[0,2]"); !errors.Is(err, errs.ErrOutputMismatch) {
+		t.Errorf("wrong answer = %v, want ErrOutputMismatch", err)
+	}
+	var invalid *errs.ValidationError
+	for _, c := range [][2]string{{"no-such-problem", input}, {"two-sum", "nums = 3"}} {
+		if err := CheckAnswer(c[0], c[1], "[]"); !errors.As(err, &invalid) {
+			t.Errorf("CheckAnswer(%q, %q) = %v, want a validation error", c[0], c[1], err)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package grade
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"learning-go/errs"
+)
+
+// maxRequestBytes bounds the size of a submission.
+const maxRequestBytes = 1 << 20
+
+// Handler serves the grading API:
+//
+//	POST /grade   body: Submission   response: Report
+//
+// Errors are returned as {"error": "..."} with status 400 for bad
+// submissions and 500 when grading itself failed.
+func Handler(g *Grader) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /grade", func(w http.ResponseWriter, r *http.Request) {
+		var sub Submission
+		dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&sub); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		rep, err := g.Grade(r.Context(), sub)
+		if err != nil {
+			var invalid *errs.ValidationError
+			if errors.As(err, &invalid) {
+				writeError(w, http.StatusBadRequest, err)
+				return
+			}
+			log.Printf("grade %s: %v", sub.Exercise, err)
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, rep)
+	})
+	return mux
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
@@ -0,0 +1,53 @@
+package solutions
+
+import (
+	"fmt"
+	"strconv"
+
+	"learning-go/memo"
+)
+
+func init() {
+	Register(climbStairs{},
+		Example{"n = 2", "2"},
+		Example{"n = 3", "3"},
+		Example{"n = 45", "1836311903"},
+	)
+}
+
+// climbStairs counts the ways to climb n steps taking one or two at a
+// time.
+type climbStairs struct{}
+
+func (climbStairs) Name() string { return "climbing-stairs" }
+
+func (climbStairs) Run(input string) (string, error) {
+	a, err := args(input)
+	if err != nil {
+		return "", err
+	}
+	n, err := intArg(a, "n")
+	if err != nil {
+		return "", err
+	}
+	if n < 0 || n > 90 {
+		return "", fmt.Errorf("n = %d is outside 0 to 90", n)
+	}
+	return strconv.FormatInt(ClimbStairs(n), 10), nil
+}
+
+// ClimbStairs returns the number of ways to climb n steps one or two at a
+// time. The last move is a one or a two, so ways(n) = ways(n-1) +
+// ways(n-2): Fibonacci again. Written as that recursion it makes an
+// exponential number of calls; memo.Func makes each n computed once, so
+// it is O(n). The count fits in an int64 up to n = 90.
+func ClimbStairs(n int) int64 {
+	var ways func(int) int64
+	ways = memo.Func(func(n int) int64 {
+		if n <= 1 {
+			return 1
+		}
+		return ways(n-1) + ways(n-2)
+	})
+	return ways(n)
+}
@@ -0,0 +1,71 @@
+package solutions
+
+import (
+	"fmt"
+	"strconv"
+
+	"learning-go/memo"
+)
+
+func init() {
+	Register(coinChange{},
+		Example{"coins = [1,2,5], amount = 11", "3"},
+		Example{"coins = [2], amount = 3", "-1"},
+		Example{"coins = [1], amount = 0", "0"},
+	)
+}
+
+// coinChange finds the fewest coins that add up to an amount.
+type coinChange struct{}
+
+func (coinChange) Name() string { return "coin-change" }
+
+func (coinChange) Run(input string) (string, error) {
+	a, err := args(input)
+	if err != nil {
+		return "", err
+	}
+	coins, err := intsArg(a, "coins")
+	if err != nil {
+		return "", err
+	}
+	amount, err := intArg(a, "amount")
+	if err != nil {
+		return "", err
+	}
+	for _, c := range coins {
+		if c <= 0 {
+			return "", fmt.Errorf("coin %d is not positive", c)
+		}
+	}
+	if amount < 0 {
+		return "", fmt.Errorf("amount %d is negative", amount)
+	}
+	return strconv.Itoa(CoinChange(coins, amount)), nil
+}
+
+// CoinChange returns the fewest coins from coins, each usable any number
+// of times, that add up to amount, or -1 if no combination does. The best
+// for an amount is one coin plus the best for what is left, tried for
+// every coin; memo.Func keeps the best for each amount, so the recursion
+// is O(amount × len(coins)) instead of exponential. The coins must be
+// positive.
+func CoinChange(coins []int, amount int) int {
+	var fewest func(int) int
+	fewest = memo.Func(func(amount int) int {
+		if amount == 0 {
+			return 0
+		}
+		best := -1
+		for _, c := range coins {
+			if c > amount {
+				continue
+			}
+			if n := fewest(amount - c); n >= 0 && (best < 0 || n+1 < best) {
+				best = n + 1
+			}
+		}
+		return best
+	})
+	return fewest(amount)
+}
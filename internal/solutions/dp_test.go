@@ -0,0 +1,36 @@
+package solutions
+
+import "testing"
+
+func TestClimbStairs(t *testing.T) {
+	for _, tt := range []struct {
+		n    int
+		want int64
+	}{
+		{0, 1}, {1, 1}, {2, 2}, {3, 3}, {10, 89}, {90, 4660046610375530309},
+	} {
+		if got := ClimbStairs(tt.n); got != tt.want {
+			t.Errorf("ClimbStairs(%d) = %d, want %d", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestCoinChange(t *testing.T) {
+	for _, tt := range []struct {
+		coins  []int
+		amount int
+		want   int
+	}{
+		{[]int{1, 2, 5}, 11, 3},
+		{[]int{2}, 3, -1},
+		{[]int{1}, 0, 0},
+		{nil, 5, -1},
+		// Greedy would take 25+1+1+1+1+1; the best is 10+10+10.
+		{[]int{1, 10, 25}, 30, 3},
+		{[]int{186, 419, 83, 408}, 6249, 20},
+	} {
+		if got := CoinChange(tt.coins, tt.amount); got != tt.want {
+			t.Errorf("CoinChange(%v, %d) = %d, want %d", tt.coins, tt.amount, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package solutions
+
+// ListNode is the singly linked list node the problem statements use, with
+// a type parameter so the same solutions work for any ordered values. The
+// generic datastructures/linkedlist package hides its nodes; these
+// problems are about relinking them by hand.
+type ListNode[T any] struct {
+	Val  T
+	Next *ListNode[T]
+}
+
+// newList builds a list holding vals in order, or nil for none.
+func newList[T any](vals []T) *ListNode[T] {
+	var dummy ListNode[T]
+	tail := &dummy
+	for _, v := range vals {
+		tail.Next = &ListNode[T]{Val: v}
+		tail = tail.Next
+	}
+	return dummy.Next
+}
+
+// values returns the values of the list starting at l.
+func (l *ListNode[T]) values() []T {
+	vals := []T{}
+	for ; l != nil; l = l.Next {
+		vals = append(vals, l.Val)
+	}
+	return vals
+}
@@ -0,0 +1,87 @@
+package solutions
+
+import (
+	"cmp"
+
+	"learning-go/datastructures/heap"
+)
+
+func init() {
+	examples := []Example{
+		{"lists = [[1,4,5],[1,3,4],[2,6]]", "[1,1,2,3,4,4,5,6]"},
+		{"lists = []", "[]"},
+		{"lists = [[]]", "[]"},
+	}
+	// Both implementations must give the same answers.
+	Register(mergeKLists{"merge-k-sorted-lists", MergeKLists[int]}, examples...)
+	Register(mergeKLists{"merge-k-sorted-lists/divide", MergeKListsDivide[int]}, examples...)
+}
+
+// mergeKLists merges any number of sorted lists into one sorted list,
+// using merge.
+type mergeKLists struct {
+	name  string
+	merge func(lists []*ListNode[int]) *ListNode[int]
+}
+
+func (p mergeKLists) Name() string { return p.name }
+
+func (p mergeKLists) Run(input string) (string, error) {
+	a, err := args(input)
+	if err != nil {
+		return "", err
+	}
+	s, err := arg(a, "lists")
+	if err != nil {
+		return "", err
+	}
+	vals, err := parseIntLists(s)
+	if err != nil {
+		return "", err
+	}
+	lists := make([]*ListNode[int], len(vals))
+	for i, v := range vals {
+		lists[i] = newList(v)
+	}
+	return formatInts(p.merge(lists).values()), nil
+}
+
+// MergeKLists merges k sorted lists with a min-heap holding the head of
+// every list that still has nodes. Each of the n nodes costs a Pop and at
+// most one Push, so the whole merge is O(n log k) time and O(k) space.
+func MergeKLists[T cmp.Ordered](lists []*ListNode[T]) *ListNode[T] {
+	h := heap.New(func(a, b *ListNode[T]) bool { return a.Val < b.Val })
+	for _, l := range lists {
+		if l != nil {
+			h.Push(l)
+		}
+	}
+	var dummy ListNode[T]
+	tail := &dummy
+	for n, ok := h.Pop(); ok; n, ok = h.Pop() {
+		tail.Next, tail = n, n
+		if n.Next != nil {
+			h.Push(n.Next)
+		}
+	}
+	return dummy.Next
+}
+
+// MergeKListsDivide merges k sorted lists by merging them in pairs with
+// MergeTwoLists, then the merged pairs in pairs, and so on. There are
+// log k rounds and each touches every node once, so it is O(n log k) like
+// the heap, but its inner loop is a single comparison instead of a heap
+// operation.
+func MergeKListsDivide[T cmp.Ordered](lists []*ListNode[T]) *ListNode[T] {
+	if len(lists) == 0 {
+		return nil
+	}
+	// Work on a copy so the caller's slice of heads is left as it was.
+	lists = append([]*ListNode[T](nil), lists...)
+	for step := 1; step < len(lists); step *= 2 {
+		for i := 0; i+step < len(lists); i += 2 * step {
+			lists[i] = MergeTwoLists(lists[i], lists[i+step])
+		}
+	}
+	return lists[0]
+}
@@ -0,0 +1,66 @@
+package solutions
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestMergeKLists(t *testing.T) {
+	tests := []struct {
+		lists [][]int
+		want  []int
+	}{
+		// The examples from the problem statement.
+		{[][]int{{1, 4, 5}, {1, 3, 4}, {2, 6}}, []int{1, 1, 2, 3, 4, 4, 5, 6}},
+		{nil, []int{}},
+		{[][]int{{}}, []int{}},
+		{[][]int{{}, {1}, {}, {0, 2}}, []int{0, 1, 2}},
+		{[][]int{{3}, {2}, {1}, {0}, {-1}}, []int{-1, 0, 1, 2, 3}},
+	}
+	for name, merge := range map[string]func([]*ListNode[int]) *ListNode[int]{
+		"heap":   MergeKLists[int],
+		"divide": MergeKListsDivide[int],
+	} {
+		for _, tt := range tests {
+			lists := make([]*ListNode[int], len(tt.lists))
+			for i, l := range tt.lists {
+				lists[i] = newList(l)
+			}
+			if got := merge(lists).values(); !slices.Equal(got, tt.want) {
+				t.Errorf("%s: merging %v = %v, want %v", name, tt.lists, got, tt.want)
+			}
+		}
+	}
+}
+
+// BenchmarkMergeKLists compares the heap and divide-and-conquer merges on
+// the same 10,000 values split into more and more lists.
+func BenchmarkMergeKLists(b *testing.B) {
+	const n = 10_000
+	for _, k := range []int{2, 16, 128, 1024} {
+		vals := make([][]int, k)
+		for i := range n {
+			vals[i%k] = append(vals[i%k], i)
+		}
+		for _, m := range []struct {
+			name  string
+			merge func([]*ListNode[int]) *ListNode[int]
+		}{
+			{"heap", MergeKLists[int]},
+			{"divide", MergeKListsDivide[int]},
+		} {
+			b.Run(fmt.Sprintf("k=%d/%s", k, m.name), func(b *testing.B) {
+				for range b.N {
+					b.StopTimer()
+					lists := make([]*ListNode[int], k)
+					for i, v := range vals {
+						lists[i] = newList(v)
+					}
+					b.StartTimer()
+					m.merge(lists)
+				}
+			})
+		}
+	}
+}
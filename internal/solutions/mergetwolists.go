@@ -0,0 +1,58 @@
+package solutions
+
+import "cmp"
+
+func init() {
+	Register(mergeTwoLists{},
+		Example{"list1 = [1,2,4], list2 = [1,3,4]", "[1,1,2,3,4,4]"},
+		Example{"list1 = [], list2 = []", "[]"},
+		Example{"list1 = [], list2 = [0]", "[0]"},
+	)
+}
+
+// mergeTwoLists merges two sorted lists into one sorted list.
+type mergeTwoLists struct{}
+
+func (mergeTwoLists) Name() string { return "merge-two-sorted-lists" }
+
+func (mergeTwoLists) Run(input string) (string, error) {
+	a, err := args(input)
+	if err != nil {
+		return "", err
+	}
+	l1, err := intsArg(a, "list1")
+	if err != nil {
+		return "", err
+	}
+	l2, err := intsArg(a, "list2")
+	if err != nil {
+		return "", err
+	}
+	return formatInts(MergeTwoLists(newList(l1), newList(l2)).values()), nil
+}
+
+// MergeTwoLists splices the nodes of two sorted lists into one sorted list
+// and returns its head. No nodes are allocated or lost: tail always points
+// at the last node of the result, and each step links the smaller head in
+// with tail.Next = ..., then advances tail onto it. Assigning to tail
+// itself instead, as the first version in playground.go did, only moves
+// the local variable and drops the node from the result.
+func MergeTwoLists[T cmp.Ordered](l1, l2 *ListNode[T]) *ListNode[T] {
+	var dummy ListNode[T]
+	tail := &dummy
+	for l1 != nil && l2 != nil {
+		if l2.Val < l1.Val {
+			tail.Next, l2 = l2, l2.Next
+		} else {
+			tail.Next, l1 = l1, l1.Next
+		}
+		tail = tail.Next
+	}
+	// At most one list has nodes left, already sorted: link it whole.
+	if l1 != nil {
+		tail.Next = l1
+	} else {
+		tail.Next = l2
+	}
+	return dummy.Next
+}
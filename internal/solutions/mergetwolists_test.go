@@ -0,0 +1,34 @@
+package solutions
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMergeTwoLists(t *testing.T) {
+	tests := []struct {
+		l1, l2, want []int
+	}{
+		// The examples from the problem statement.
+		{[]int{1, 2, 4}, []int{1, 3, 4}, []int{1, 1, 2, 3, 4, 4}},
+		{nil, nil, []int{}},
+		{nil, []int{0}, []int{0}},
+		// The version in the old playground.go dropped every node; these
+		// check that none go missing whichever list runs out first.
+		{[]int{5, 6, 7}, []int{1}, []int{1, 5, 6, 7}},
+		{[]int{1}, []int{2, 3, 4}, []int{1, 2, 3, 4}},
+	}
+	for _, tt := range tests {
+		got := MergeTwoLists(newList(tt.l1), newList(tt.l2)).values()
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("MergeTwoLists(%v, %v) = %v, want %v", tt.l1, tt.l2, got, tt.want)
+		}
+	}
+}
+
+func TestMergeTwoListsStrings(t *testing.T) {
+	got := MergeTwoLists(newList([]string{"a", "c"}), newList([]string{"b"})).values()
+	if want := []string{"a", "b", "c"}; !slices.Equal(got, want) {
+		t.Errorf("merged %v, want %v", got, want)
+	}
+}
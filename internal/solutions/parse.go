@@ -0,0 +1,126 @@
+package solutions
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"learning-go/errs"
+)
+
+// args splits input of the form "nums = [2,7,11], target = 9" into its
+// named values. Commas inside brackets do not separate arguments.
+func args(input string) (map[string]string, error) {
+	out := make(map[string]string)
+	for _, part := range splitTop(input) {
+		name, value, ok := strings.Cut(part, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("expected name = value, got %q", strings.TrimSpace(part))
+		}
+		out[name] = strings.TrimSpace(value)
+	}
+	return out, nil
+}
+
+// splitTop splits s at the commas that are not inside brackets.
+func splitTop(s string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, c := range s {
+		switch c {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// arg returns the value called name from input parsed by args.
+func arg(a map[string]string, name string) (string, error) {
+	v, ok := a[name]
+	if !ok {
+		return "", fmt.Errorf("missing argument %s", name)
+	}
+	return v, nil
+}
+
+// intArg returns the argument called name as an integer.
+func intArg(a map[string]string, name string) (int, error) {
+	s, err := arg(a, name)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(s)
+	return n, errs.Wrap(err, "argument %s", name)
+}
+
+// intsArg returns the argument called name as a list of integers.
+func intsArg(a map[string]string, name string) ([]int, error) {
+	s, err := arg(a, name)
+	if err != nil {
+		return nil, err
+	}
+	nums, err := parseInts(s)
+	return nums, errs.Wrap(err, "argument %s", name)
+}
+
+// parseInts parses a list of integers such as "[1,2,4]" or "[]".
+func parseInts(s string) ([]int, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "[") || !strings.HasSuffix(s, "]") {
+		return nil, fmt.Errorf("expected a list like [1,2,3], got %q", s)
+	}
+	s = strings.TrimSpace(s[1 : len(s)-1])
+	if s == "" {
+		return []int{}, nil
+	}
+	fields := strings.Split(s, ",")
+	nums := make([]int, len(fields))
+	for i, f := range fields {
+		n, err := strconv.Atoi(strings.TrimSpace(f))
+		if err != nil {
+			return nil, fmt.Errorf("list element %d: %w", i, err)
+		}
+		nums[i] = n
+	}
+	return nums, nil
+}
+
+// parseIntLists parses a list of lists such as "[[1,4],[],[2]]".
+func parseIntLists(s string) ([][]int, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "[") || !strings.HasSuffix(s, "]") {
+		return nil, fmt.Errorf("expected a list of lists like [[1,2],[3]], got %q", s)
+	}
+	s = strings.TrimSpace(s[1 : len(s)-1])
+	if s == "" {
+		return [][]int{}, nil
+	}
+	parts := splitTop(s)
+	lists := make([][]int, len(parts))
+	for i, part := range parts {
+		nums, err := parseInts(part)
+		if err != nil {
+			return nil, fmt.Errorf("list %d: %w", i, err)
+		}
+		lists[i] = nums
+	}
+	return lists, nil
+}
+
+// formatInts writes nums in the notation parseInts reads.
+func formatInts(nums []int) string {
+	parts := make([]string, len(nums))
+	for i, n := range nums {
+		parts[i] = strconv.Itoa(n)
+	}
+	return "[" + strings.Join(parts, ",") + "]"
+}
@@ -0,0 +1,37 @@
+package solutions
+
+func init() {
+	Register(reverseList{},
+		Example{"head = [1,2,3,4,5]", "[5,4,3,2,1]"},
+		Example{"head = [1,2]", "[2,1]"},
+		Example{"head = []", "[]"},
+	)
+}
+
+// reverseList reverses a singly linked list.
+type reverseList struct{}
+
+func (reverseList) Name() string { return "reverse-linked-list" }
+
+func (reverseList) Run(input string) (string, error) {
+	a, err := args(input)
+	if err != nil {
+		return "", err
+	}
+	head, err := intsArg(a, "head")
+	if err != nil {
+		return "", err
+	}
+	return formatInts(ReverseList(newList(head)).values()), nil
+}
+
+// ReverseList reverses the list starting at head in place and returns the
+// new head. Each step points one node back at the part already reversed,
+// so it takes O(n) time and O(1) extra space.
+func ReverseList[T any](head *ListNode[T]) *ListNode[T] {
+	var prev *ListNode[T]
+	for head != nil {
+		head.Next, prev, head = prev, head, head.Next
+	}
+	return prev
+}
@@ -0,0 +1,23 @@
+package solutions
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestReverseList(t *testing.T) {
+	tests := []struct {
+		head, want []int
+	}{
+		// The examples from the problem statement.
+		{[]int{1, 2, 3, 4, 5}, []int{5, 4, 3, 2, 1}},
+		{[]int{1, 2}, []int{2, 1}},
+		{nil, []int{}},
+		{[]int{7}, []int{7}},
+	}
+	for _, tt := range tests {
+		if got := ReverseList(newList(tt.head)).values(); !slices.Equal(got, tt.want) {
+			t.Errorf("ReverseList(%v) = %v, want %v", tt.head, got, tt.want)
+		}
+	}
+}
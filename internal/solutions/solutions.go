@@ -0,0 +1,140 @@
+// Package solutions collects reference solutions to interview-style
+// problems behind one interface, so that a runner can list them, run any
+// of them on new input and check each one against the examples from its
+// statement.
+//
+// The package is internal so that code importing the data structures from
+// outside this module cannot come to depend on the answers. Package grade
+// checks answers against them with CheckAnswer, which says whether an
+// answer is right without giving the right one away.
+//
+// Inputs and outputs are written the way the problem statements write
+// them, so an example can be pasted in unchanged:
+//
+//	p, _ := solutions.Lookup("two-sum")
+//	out, err := p.Run("nums = [2,7,11,15], target = 9") // "[0,1]"
+//
+// Each problem lives in its own file and registers itself, with its
+// examples, from an init function.
+package solutions
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"learning-go/errs"
+)
+
+// Problem is one solved problem.
+type Problem interface {
+	// Name is the problem's slug, e.g. "two-sum".
+	Name() string
+	// Run solves the problem for input and returns the answer, both in the
+	// statement's notation. The error reports input that cannot be parsed.
+	Run(input string) (string, error)
+}
+
+// Example is an input from a problem statement and its expected output.
+type Example struct {
+	Input  string
+	Output string
+}
+
+// ErrUnknown means no problem is registered under the name.
+var ErrUnknown = errors.New("unknown problem")
+
+type entry struct {
+	problem  Problem
+	examples []Example
+}
+
+var (
+	mu       sync.RWMutex
+	problems = make(map[string]entry)
+)
+
+// Register adds p with the examples it must pass. It panics if the name is
+// empty or already taken, which can only be a programming error.
+func Register(p Problem, examples ...Example) {
+	name := p.Name()
+	if name == "" {
+		panic(fmt.Sprintf("solutions: Register %T with an empty name", p))
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if _, dup := problems[name]; dup {
+		panic("solutions: Register called twice for " + name)
+	}
+	problems[name] = entry{p, examples}
+}
+
+// Names returns the names of every registered problem, sorted.
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(problems))
+	for name := range problems {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Lookup returns the problem registered under name.
+func Lookup(name string) (Problem, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	e, ok := problems[name]
+	return e.problem, ok
+}
+
+// Examples returns the examples registered with the problem called name.
+func Examples(name string) []Example {
+	mu.RLock()
+	defer mu.RUnlock()
+	return problems[name].examples
+}
+
+// Check runs the problem called name on each of its examples. It returns
+// nil if every answer matches, and otherwise an error listing each example
+// that failed, with errs.ErrOutputMismatch for a wrong answer.
+func Check(name string) error {
+	p, ok := Lookup(name)
+	if !ok {
+		return fmt.Errorf("solutions: %w %q", ErrUnknown, name)
+	}
+	var c errs.Collector
+	for i, ex := range Examples(name) {
+		got, err := p.Run(ex.Input)
+		if err != nil {
+			c.Add(errs.Wrap(err, "%s example %d", name, i+1))
+			continue
+		}
+		if strings.TrimSpace(got) != strings.TrimSpace(ex.Output) {
+			c.Add(fmt.Errorf("%s example %d: got %s, want %s: %w", name, i+1, got, ex.Output, errs.ErrOutputMismatch))
+		}
+	}
+	return c.Err()
+}
+
+// CheckAnswer runs the reference solution to the problem called name on
+// input and compares its output with answer, ignoring surrounding space.
+// A wrong answer gives an error wrapping errs.ErrOutputMismatch that does
+// not include the expected output.
+func CheckAnswer(name, input, answer string) error {
+	p, ok := Lookup(name)
+	if !ok {
+		return fmt.Errorf("solutions: %w %q", ErrUnknown, name)
+	}
+	want, err := p.Run(input)
+	if err != nil {
+		return errs.Wrap(err, "%s", name)
+	}
+	if strings.TrimSpace(answer) != strings.TrimSpace(want) {
+		return fmt.Errorf("%s: wrong answer %s: %w", name, answer, errs.ErrOutputMismatch)
+	}
+	return nil
+}
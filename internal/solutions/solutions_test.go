@@ -0,0 +1,99 @@
+package solutions
+
+import (
+	"errors"
+	"testing"
+
+	"learning-go/errs"
+)
+
+// Every registered problem must pass the examples it was registered with.
+func TestExamples(t *testing.T) {
+	for _, name := range Names() {
+		t.Run(name, func(t *testing.T) {
+			if len(Examples(name)) == 0 {
+				t.Error("registered without examples")
+			}
+			if err := Check(name); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestLookupUnknown(t *testing.T) {
+	if _, ok := Lookup("no-such-problem"); ok {
+		t.Error("Lookup found an unregistered problem")
+	}
+	if err := Check("no-such-problem"); !errors.Is(err, ErrUnknown) {
+		t.Errorf("Check = %v, want ErrUnknown", err)
+	}
+}
+
+// wrong answers every input with "[]".
+type wrong struct{}
+
+func (wrong) Name() string               { return "always-empty" }
+func (wrong) Run(string) (string, error) { return "[]", nil }
+
+func TestCheckReportsMismatch(t *testing.T) {
+	Register(wrong{}, Example{"x = 1", "[]"}, Example{"x = 2", "[2]"})
+	t.Cleanup(func() {
+		mu.Lock()
+		delete(problems, "always-empty")
+		mu.Unlock()
+	})
+	err := Check("always-empty")
+	if !errors.Is(err, errs.ErrOutputMismatch) {
+		t.Fatalf("Check = %v, want ErrOutputMismatch", err)
+	}
+	var multi *errs.MultiError
+	if errors.As(err, &multi) && len(multi.Errs) != 1 {
+		t.Errorf("Check reported %d failures, want only example 2", len(multi.Errs))
+	}
+}
+
+func TestCheckAnswer(t *testing.T) {
+	tests := []struct {
+		name, input, answer string
+		want                error
+	}{
+		{"two-sum", "nums = [2,7,11,15], target = 9", "[0,1]", nil},
+		{"two-sum", "nums = [2,7,11,15], target = 9", " [0,1]\n", nil},
+		{"two-sum", "nums = [2,7,11,15], target = 9", "[1,2]", errs.ErrOutputMismatch},
+		{"no-such-problem", "", "", ErrUnknown},
+	}
+	for _, tt := range tests {
+		if err := CheckAnswer(tt.name, tt.input, tt.answer); !errors.Is(err, tt.want) {
+			t.Errorf("CheckAnswer(%q, %q, %q) = %v, want %v", tt.name, tt.input, tt.answer, err, tt.want)
+		}
+	}
+	if err := CheckAnswer("two-sum", "not the input format", "[]"); err == nil || errors.Is(err, errs.ErrOutputMismatch) {
+		t.Errorf("CheckAnswer with unparsable input = %v, want a parse error", err)
+	}
+}
+
+func TestRegisterPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("registering two-sum twice did not panic")
+		}
+	}()
+	Register(twoSum{})
+}
+
+func TestBadInput(t *testing.T) {
+	tests := []struct{ problem, input string }{
+		{"two-sum", "nums = [1,2]"},
+		{"two-sum", "nums = [1,x], target = 3"},
+		{"two-sum", "nums = 1,2, target = 3"},
+		{"merge-k-sorted-lists", "lists = [[1],2]"},
+		{"reverse-linked-list", "tail = [1]"},
+	}
+	for _, tt := range tests {
+		p, _ := Lookup(tt.problem)
+		if out, err := p.Run(tt.input); err == nil {
+			t.Errorf("%s(%q) = %s, want an error", tt.problem, tt.input, out)
+		}
+	}
+}
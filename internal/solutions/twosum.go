@@ -0,0 +1,46 @@
+package solutions
+
+func init() {
+	Register(twoSum{},
+		Example{"nums = [2,7,11,15], target = 9", "[0,1]"},
+		Example{"nums = [3,2,4], target = 6", "[1,2]"},
+		Example{"nums = [3,3], target = 6", "[0,1]"},
+	)
+}
+
+// twoSum finds the indices of the two numbers in nums that add up to
+// target.
+type twoSum struct{}
+
+func (twoSum) Name() string { return "two-sum" }
+
+func (twoSum) Run(input string) (string, error) {
+	a, err := args(input)
+	if err != nil {
+		return "", err
+	}
+	nums, err := intsArg(a, "nums")
+	if err != nil {
+		return "", err
+	}
+	target, err := intArg(a, "target")
+	if err != nil {
+		return "", err
+	}
+	return formatInts(TwoSum(nums, target)), nil
+}
+
+// TwoSum returns the indices of two different elements of nums that add up
+// to target, or nil if there are none. It makes one pass, remembering the
+// index of every number seen so far, so it is O(n) instead of the O(n²) of
+// trying every pair.
+func TwoSum(nums []int, target int) []int {
+	seen := make(map[int]int, len(nums))
+	for i, n := range nums {
+		if j, ok := seen[target-n]; ok {
+			return []int{j, i}
+		}
+		seen[n] = i
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package solutions
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTwoSum(t *testing.T) {
+	tests := []struct {
+		nums   []int
+		target int
+		want   []int
+	}{
+		// The examples from the problem statement.
+		{[]int{2, 7, 11, 15}, 9, []int{0, 1}},
+		{[]int{3, 2, 4}, 6, []int{1, 2}},
+		{[]int{3, 3}, 6, []int{0, 1}},
+		// An element may not be paired with itself.
+		{[]int{3, 5}, 6, nil},
+		{[]int{-1, -2, -3, -4}, -7, []int{2, 3}},
+		{nil, 0, nil},
+	}
+	for _, tt := range tests {
+		if got := TwoSum(tt.nums, tt.target); !slices.Equal(got, tt.want) {
+			t.Errorf("TwoSum(%v, %d) = %v, want %v", tt.nums, tt.target, got, tt.want)
+		}
+	}
+}
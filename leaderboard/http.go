@@ -0,0 +1,56 @@
+package leaderboard
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// defaultLimit is how many entries an endpoint returns without ?limit=.
+const defaultLimit = 10
+
+// Handler serves the leaderboard as JSON:
+//
+//	GET /leaderboard                      exercise names
+//	GET /leaderboard/{chapter}/{exercise} fastest entries, ?limit=N
+func Handler(s *Store) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /leaderboard", func(w http.ResponseWriter, r *http.Request) {
+		names, err := s.Exercises(r.Context())
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, names)
+	})
+	mux.HandleFunc("GET /leaderboard/{chapter}/{exercise}", func(w http.ResponseWriter, r *http.Request) {
+		limit := defaultLimit
+		if v := r.URL.Query().Get("limit"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n <= 0 {
+				writeJSON(w, http.StatusBadRequest, map[string]string{"error": "limit must be a positive integer"})
+				return
+			}
+			limit = n
+		}
+		exercise := r.PathValue("chapter") + "/" + r.PathValue("exercise")
+		entries, err := s.Fastest(r.Context(), exercise, limit)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return
+		}
+		if entries == nil {
+			entries = []Entry{}
+		}
+		writeJSON(w, http.StatusOK, entries)
+	})
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
@@ -0,0 +1,44 @@
+package leaderboard
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandler(t *testing.T) {
+	s := open(t,
+		Entry{User: "ana", Exercise: "chapter12/exercise1", Passed: true, Duration: 2 * time.Millisecond},
+		Entry{User: "bo", Exercise: "chapter12/exercise1", Passed: true, Duration: time.Millisecond},
+	)
+	h := Handler(s)
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	var names []string
+	if rec := get("/leaderboard"); rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &names) != nil ||
+		len(names) != 1 || names[0] != "chapter12/exercise1" {
+		t.Errorf("GET /leaderboard = %d %s", rec.Code, rec.Body)
+	}
+
+	var entries []Entry
+	rec := get("/leaderboard/chapter12/exercise1?limit=1")
+	if err := json.Unmarshal(rec.Body.Bytes(), &entries); rec.Code != http.StatusOK || err != nil ||
+		len(entries) != 1 || entries[0].User != "bo" {
+		t.Errorf("GET /leaderboard/chapter12/exercise1?limit=1 = %d %s, want bo alone", rec.Code, rec.Body)
+	}
+
+	if rec := get("/leaderboard/chapter1/exercise1"); rec.Code != http.StatusOK || rec.Body.String() != "[]\n" {
+		t.Errorf("GET of an exercise without entries = %d %q, want an empty list", rec.Code, rec.Body)
+	}
+	for _, limit := range []string{"0", "-1", "x"} {
+		if rec := get("/leaderboard/chapter12/exercise1?limit=" + limit); rec.Code != http.StatusBadRequest {
+			t.Errorf("GET with limit=%s = %d, want %d", limit, rec.Code, http.StatusBadRequest)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Package leaderboard stores graded submissions in SQLite and ranks the
+// fastest correct solution per user for each exercise. A solution is
+// correct when package grade passed it: it ran, printed exactly the
+// exercise's golden output and used the required constructs. It backs
+// the leaderboard of the shared grading server.
+package leaderboard
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "modernc.org/sqlite" // registers the "sqlite" driver, pure Go
+)
+
+// Entry is one graded submission.
+type Entry struct {
+	User     string `json:"user"`
+	Exercise string `json:"exercise"`
+	// Passed is grade.Report.Passed: only submissions whose output
+	// matched the golden file are ranked.
+	Passed   bool          `json:"passed"`
+	Duration time.Duration `json:"duration_ns"`
+	At       time.Time     `json:"at"`
+}
+
+// Store is a leaderboard backed by a SQLite database. It is safe for
+// concurrent use.
+type Store struct {
+	db *sql.DB
+}
+
+const schema = `
+CREATE TABLE IF NOT EXISTS submissions (
+	id          INTEGER PRIMARY KEY,
+	user        TEXT    NOT NULL,
+	exercise    TEXT    NOT NULL,
+	passed      INTEGER NOT NULL,
+	duration_ns INTEGER NOT NULL,
+	at_unix_ms  INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS submissions_exercise ON submissions (exercise, passed, duration_ns);
+`
+
+// Open opens (creating if needed) the database at path. Use ":memory:" for
+// a throwaway leaderboard.
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite allows one writer at a time; a single connection turns
+	// "database is locked" errors into waiting.
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("leaderboard: creating schema: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Record stores e. A zero At means now.
+func (s *Store) Record(ctx context.Context, e Entry) error {
+	if e.At.IsZero() {
+		e.At = time.Now()
+	}
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO submissions (user, exercise, passed, duration_ns, at_unix_ms) VALUES (?, ?, ?, ?, ?)`,
+		e.User, e.Exercise, e.Passed, int64(e.Duration), e.At.UnixMilli())
+	return err
+}
+
+// Fastest returns the fastest passing, and so correct, submission of
+// each user for exercise, quickest first, at most limit of them. Ties go
+// to whoever got there first.
+func (s *Store) Fastest(ctx context.Context, exercise string, limit int) ([]Entry, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT user, duration_ns, at_unix_ms FROM (
+			SELECT user, duration_ns, at_unix_ms,
+			       ROW_NUMBER() OVER (PARTITION BY user ORDER BY duration_ns, at_unix_ms) AS rank
+			FROM submissions
+			WHERE exercise = ? AND passed = 1
+		)
+		WHERE rank = 1
+		ORDER BY duration_ns, at_unix_ms
+		LIMIT ?`, exercise, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []Entry
+	for rows.Next() {
+		e := Entry{Exercise: exercise, Passed: true}
+		var ns, ms int64
+		if err := rows.Scan(&e.User, &ns, &ms); err != nil {
+			return nil, err
+		}
+		e.Duration, e.At = time.Duration(ns), time.UnixMilli(ms)
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// Exercises returns every exercise that has at least one passing
+// submission, in name order.
+func (s *Store) Exercises(ctx context.Context) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT DISTINCT exercise FROM submissions WHERE passed = 1 ORDER BY exercise`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
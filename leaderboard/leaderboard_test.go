@@ -0,0 +1,88 @@
+package leaderboard
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+// open returns an empty in-memory store holding entries.
+func open(t *testing.T, entries ...Entry) *Store {
+	t.Helper()
+	s, err := Open(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+	for _, e := range entries {
+		if err := s.Record(context.Background(), e); err != nil {
+			t.Fatalf("Record(%+v): %v", e, err)
+		}
+	}
+	return s
+}
+
+func TestFastest(t *testing.T) {
+	day := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	at := func(min int) time.Time { return day.Add(time.Duration(min) * time.Minute) }
+	const ex = "chapter12/exercise1"
+	s := open(t,
+		Entry{User: "ana", Exercise: ex, Passed: true, Duration: 30 * time.Millisecond, At: at(0)},
+		Entry{User: "ana", Exercise: ex, Passed: true, Duration: 20 * time.Millisecond, At: at(5)},
+		// Faster, but wrong: not ranked.
+		Entry{User: "bo", Exercise: ex, Passed: false, Duration: time.Millisecond, At: at(1)},
+		Entry{User: "bo", Exercise: ex, Passed: true, Duration: 40 * time.Millisecond, At: at(2)},
+		// Ties with ana's best; ana got there later, so cy ranks first.
+		Entry{User: "cy", Exercise: ex, Passed: true, Duration: 20 * time.Millisecond, At: at(3)},
+		// Another exercise.
+		Entry{User: "dee", Exercise: "chapter3/exercise1", Passed: true, Duration: time.Millisecond, At: at(0)},
+	)
+
+	got, err := s.Fastest(context.Background(), ex, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Entry{
+		{User: "cy", Exercise: ex, Passed: true, Duration: 20 * time.Millisecond, At: at(3)},
+		{User: "ana", Exercise: ex, Passed: true, Duration: 20 * time.Millisecond, At: at(5)},
+		{User: "bo", Exercise: ex, Passed: true, Duration: 40 * time.Millisecond, At: at(2)},
+	}
+	equal := func(a, b Entry) bool {
+		return a.User == b.User && a.Exercise == b.Exercise && a.Passed == b.Passed &&
+			a.Duration == b.Duration && a.At.Equal(b.At)
+	}
+	if !slices.EqualFunc(got, want, equal) {
+		t.Errorf("Fastest(%s) =\n%+v\nwant\n%+v", ex, got, want)
+	}
+
+	got, err = s.Fastest(context.Background(), ex, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.EqualFunc(got, want[:2], equal) {
+		t.Errorf("Fastest(%s, limit 2) = %+v, want %+v", ex, got, want[:2])
+	}
+
+	got, err = s.Fastest(context.Background(), "chapter1/exercise1", 10)
+	if err != nil || len(got) != 0 {
+		t.Errorf("Fastest of an exercise nobody passed = %v, %v; want nothing", got, err)
+	}
+}
+
+func TestExercises(t *testing.T) {
+	s := open(t,
+		Entry{User: "ana", Exercise: "chapter3/exercise2", Passed: true},
+		Entry{User: "bo", Exercise: "chapter12/exercise1", Passed: true},
+		Entry{User: "ana", Exercise: "chapter12/exercise1", Passed: true},
+		Entry{User: "ana", Exercise: "chapter5/exercise1", Passed: false},
+	)
+	got, err := s.Exercises(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"chapter12/exercise1", "chapter3/exercise2"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Exercises() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,117 @@
+// Package memo wraps a function so that its results are cached by argument.
+//
+// A recursive function can memoize itself by declaring the variable first:
+//
+//	var fib func(int) int
+//	fib = memo.Func(func(n int) int {
+//		if n < 2 {
+//			return n
+//		}
+//		return fib(n-1) + fib(n-2)
+//	})
+//
+// The returned function is safe for concurrent use. When several goroutines
+// ask for the same key at the same time, fn runs once and all of them
+// receive its result.
+//
+// Memoize does the same for a function that can fail; errors are not
+// cached. Group deduplicates concurrent calls without caching anything.
+//
+// Results are kept in a cache/lru Cache, which applies WithMaxSize and
+// WithTTL.
+package memo
+
+import (
+	"math"
+	"time"
+
+	"learning-go/cache/lru"
+	"learning-go/clock"
+)
+
+type options struct {
+	ttl     time.Duration
+	maxSize int
+	clock   clock.Clock
+}
+
+// Option configures Func.
+type Option func(*options)
+
+// WithTTL makes cached results expire after d. A zero or negative d keeps
+// results forever, which is the default.
+func WithTTL(d time.Duration) Option {
+	return func(o *options) { o.ttl = d }
+}
+
+// WithMaxSize caps the number of cached results. When the cache is full the
+// least recently used result is evicted. A zero or negative n means no limit,
+// which is the default.
+func WithMaxSize(n int) Option {
+	return func(o *options) { o.maxSize = n }
+}
+
+// WithClock sets the clock used to expire results. Tests pass a
+// *clock.Fake to control expiry; the default is clock.Real.
+func WithClock(c clock.Clock) Option {
+	return func(o *options) { o.clock = c }
+}
+
+type memoizer[K comparable, V any] struct {
+	fn    func(K) (V, error)
+	calls Group[K, V]      // the calls of fn still running
+	cache *lru.Cache[K, V] // the results, locked, with the TTL and size limit
+}
+
+// Func returns a memoized version of fn.
+//
+// If fn panics, the panic is propagated to the caller that ran it and to
+// every caller waiting on the same key, and nothing is cached.
+func Func[K comparable, V any](fn func(K) V, opts ...Option) func(K) V {
+	get := Memoize(func(key K) (V, error) { return fn(key), nil }, opts...)
+	return func(key K) V {
+		v, _ := get(key)
+		return v
+	}
+}
+
+// Memoize returns a memoized version of fn, a function that can fail.
+// Only successful results are cached: an error is returned to the caller
+// that ran fn and to every caller waiting on the same key, and the next
+// call for the key runs fn again. Panics are handled as in Func.
+func Memoize[K comparable, V any](fn func(K) (V, error), opts ...Option) func(K) (V, error) {
+	o := options{clock: clock.Real}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	size := o.maxSize
+	if size <= 0 {
+		size = math.MaxInt
+	}
+	m := &memoizer[K, V]{
+		fn:    fn,
+		cache: lru.New[K, V](size, lru.WithLocking(), lru.WithTTL(o.ttl), lru.WithClock(o.clock)),
+	}
+	return m.get
+}
+
+func (m *memoizer[K, V]) get(key K) (V, error) {
+	if v, ok := m.cache.Get(key); ok {
+		return v, nil
+	}
+	// fn runs without holding the cache's lock, so a recursive fn can call
+	// back into the memoized function for other keys.
+	v, err, _ := m.calls.Do(key, func() (V, error) {
+		// A call for key may have finished, and stored its value, between
+		// the lookup above and Do; running fn again would waste it.
+		if v, ok := m.cache.Get(key); ok {
+			return v, nil
+		}
+		v, err := m.fn(key)
+		if err == nil {
+			m.cache.Put(key, v)
+		}
+		return v, err
+	})
+	return v, err
+}
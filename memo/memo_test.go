@@ -0,0 +1,233 @@
+package memo
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"learning-go/clock"
+)
+
+func TestFuncRecursive(t *testing.T) {
+	calls := make(map[int]int)
+	// int64, because fib(50) does not fit in a 32-bit int.
+	var fib func(int) int64
+	fib = Func(func(n int) int64 {
+		calls[n]++
+		if n < 2 {
+			return int64(n)
+		}
+		return fib(n-1) + fib(n-2)
+	})
+	if got := fib(50); got != 12586269025 {
+		t.Errorf("fib(50) = %d, want 12586269025", got)
+	}
+	for n, c := range calls {
+		if c != 1 {
+			t.Errorf("fib(%d) was computed %d times, want once", n, c)
+		}
+	}
+	if len(calls) != 51 {
+		t.Errorf("computed %d values, want 51", len(calls))
+	}
+}
+
+func TestErrorsAreNotCached(t *testing.T) {
+	boom := errors.New("temporarily down")
+	var calls int
+	get := Memoize(func(key string) (int, error) {
+		calls++
+		if calls == 1 {
+			return 0, boom
+		}
+		return len(key), nil
+	})
+	if _, err := get("gopher"); !errors.Is(err, boom) {
+		t.Fatalf("first call = %v, want %v", err, boom)
+	}
+	for range 2 {
+		if v, err := get("gopher"); v != 6 || err != nil {
+			t.Errorf(`get("gopher") = %d, %v; want 6, nil`, v, err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("fn ran %d times, want 2: once for the error, once for the cached value", calls)
+	}
+}
+
+func TestPanicIsNotCached(t *testing.T) {
+	var calls int
+	f := Func(func(n int) int {
+		calls++
+		if calls == 1 {
+			panic("first call fails")
+		}
+		return n * 2
+	})
+	func() {
+		defer func() {
+			if r := recover(); r != "first call fails" {
+				t.Errorf("recovered %v, want the panic from fn", r)
+			}
+		}()
+		f(1)
+	}()
+	if got := f(1); got != 2 {
+		t.Errorf("f(1) after a panic = %d, want 2", got)
+	}
+}
+
+func TestTTL(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	var calls int
+	f := Func(func(k string) int { calls++; return calls }, WithTTL(time.Minute), WithClock(fake))
+
+	f("k")
+	fake.Advance(59 * time.Second)
+	if got := f("k"); got != 1 {
+		t.Errorf("after 59s f = %d, want the cached 1", got)
+	}
+	fake.Advance(time.Second)
+	if got := f("k"); got != 2 {
+		t.Errorf("after a minute f = %d, want a fresh 2", got)
+	}
+	// A reused result does not extend its lifetime.
+	fake.Advance(30 * time.Second)
+	f("k")
+	fake.Advance(30 * time.Second)
+	if got := f("k"); got != 3 {
+		t.Errorf("a minute after the refresh f = %d, want 3", got)
+	}
+}
+
+func TestMaxSize(t *testing.T) {
+	var computed []string
+	f := Func(func(k string) string { computed = append(computed, k); return k }, WithMaxSize(2))
+	for _, k := range []string{"a", "b", "a", "c", "a", "b"} {
+		f(k)
+	}
+	// c evicted b, the least recently used, so only b was computed again.
+	if got := fmt.Sprint(computed); got != "[a b c b]" {
+		t.Errorf("computed %s, want [a b c b]", got)
+	}
+}
+
+// Run with -race: many goroutines ask for a few keys at once.
+func TestConcurrentCallsRunOnce(t *testing.T) {
+	var calls [4]atomic.Int32
+	release := make(chan struct{})
+	get := Memoize(func(k int) (int, error) {
+		calls[k].Add(1)
+		<-release
+		return k * k, nil
+	})
+	var wg sync.WaitGroup
+	for i := range 64 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			k := i % len(calls)
+			if v, err := get(k); v != k*k || err != nil {
+				t.Errorf("get(%d) = %d, %v", k, v, err)
+			}
+		}()
+	}
+	close(release)
+	wg.Wait()
+	for k := range calls {
+		if n := calls[k].Load(); n != 1 {
+			t.Errorf("fn(%d) ran %d times, want once", k, n)
+		}
+	}
+}
+
+// waiting returns how many callers wait on the call for key, or -1 if
+// there is none.
+func waiting[K comparable, V any](g *Group[K, V], key K) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if c, ok := g.calls[key]; ok {
+		return c.dups
+	}
+	return -1
+}
+
+func TestGroupShares(t *testing.T) {
+	var g Group[string, int]
+	var calls atomic.Int32
+	release := make(chan struct{})
+	fn := func() (int, error) {
+		calls.Add(1)
+		<-release
+		return 42, nil
+	}
+
+	const n = 5
+	shared := make(chan bool, n)
+	go func() {
+		_, _, s := g.Do("k", fn)
+		shared <- s
+	}()
+	for waiting(&g, "k") < 0 {
+		time.Sleep(time.Millisecond)
+	}
+	for range n - 1 {
+		go func() {
+			v, _, s := g.Do("k", fn)
+			if v != 42 {
+				t.Errorf("a waiting caller got %d, want 42", v)
+			}
+			shared <- s
+		}()
+	}
+	for waiting(&g, "k") < n-1 {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	for range n {
+		if !<-shared {
+			t.Error("a caller was told the result was not shared")
+		}
+	}
+	if calls.Load() != 1 {
+		t.Errorf("fn ran %d times, want once", calls.Load())
+	}
+
+	// Group keeps nothing: the next call runs fn again, alone.
+	if v, _, s := g.Do("k", func() (int, error) { return 7, nil }); v != 7 || s {
+		t.Errorf("Do after the call finished = %d, shared %v; want 7, false", v, s)
+	}
+}
+
+func TestGroupPanicReachesWaiters(t *testing.T) {
+	var g Group[string, int]
+	release := make(chan struct{})
+	recovered := make(chan any, 2)
+	do := func() {
+		defer func() { recovered <- recover() }()
+		g.Do("k", func() (int, error) {
+			<-release
+			panic("boom")
+		})
+	}
+	go do()
+	for waiting(&g, "k") < 0 {
+		time.Sleep(time.Millisecond)
+	}
+	go do()
+	for waiting(&g, "k") < 1 {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	for range 2 {
+		if r := <-recovered; r != "boom" {
+			t.Errorf("recovered %v, want boom", r)
+		}
+	}
+	if waiting(&g, "k") != -1 {
+		t.Error("the panicked call was left in the group")
+	}
+}
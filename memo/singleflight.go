@@ -0,0 +1,70 @@
+package memo
+
+import "sync"
+
+// Group suppresses duplicate calls. While a call for a key is running,
+// other calls to Do with the same key wait for it and receive its result
+// instead of running their own function. Nothing is kept once the call
+// returns, so Group suits results that must be fresh, such as a request
+// to a slow API that a burst of clients asks for at the same moment.
+//
+// The zero value is ready to use. Func and Memoize use a Group too, and
+// keep each result afterwards.
+type Group[K comparable, V any] struct {
+	mu    sync.Mutex
+	calls map[K]*call[V]
+}
+
+// call is an execution of fn that is still running. Callers asking for
+// the same key wait on done instead of calling fn again.
+type call[V any] struct {
+	done     chan struct{}
+	dups     int // callers waiting besides the one running fn
+	value    V
+	err      error
+	panicked bool
+	panicVal any
+}
+
+// Do runs fn and returns its results, unless a call for key is already
+// running, in which case it waits for that call and returns its results.
+// shared reports whether the results were given to more than one caller.
+//
+// If fn panics, the panic is propagated to the caller that ran it and to
+// every caller waiting on it.
+func (g *Group[K, V]) Do(key K, fn func() (V, error)) (v V, err error, shared bool) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[K]*call[V])
+	}
+	if c, ok := g.calls[key]; ok {
+		c.dups++
+		g.mu.Unlock()
+		<-c.done
+		if c.panicked {
+			panic(c.panicVal)
+		}
+		return c.value, c.err, true
+	}
+	c := &call[V]{done: make(chan struct{})}
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	// fn runs without holding the lock, so it can call Do for other keys.
+	defer func() {
+		if r := recover(); r != nil {
+			c.panicked = true
+			c.panicVal = r
+		}
+		g.mu.Lock()
+		delete(g.calls, key)
+		shared = c.dups > 0
+		g.mu.Unlock()
+		close(c.done)
+		if c.panicked {
+			panic(c.panicVal)
+		}
+	}()
+	c.value, c.err = fn()
+	return c.value, c.err, shared
+}
@@ -0,0 +1,22 @@
+package messages
+
+func init() {
+	Catalog.Add("en", map[string]string{
+		RunHeader:   "Chapter %s, exercise %d: %s",
+		RunPass:     "PASS %s (%v)",
+		RunFail:     "FAIL %s: %v",
+		RunNotFound: "no exercise named %q",
+		RunHint:     "Hint: %s",
+		RunSummary:  "%d of %d exercises passed",
+		RunPrereq:   "NOTE %s builds on %s, not done yet",
+		RunBlocked:  "SKIP %s: do %s first",
+		CheckOK:     "OK   %s",
+		CheckSkip:   "SKIP %s: %v",
+
+		"hint:chapter3/exercise2":  "Indexing a string gives bytes. Convert it to []rune first, or range over it.",
+		"hint:chapter3/exercise3":  "Try all three ways to build a struct: positional, with field names, and field by field.",
+		"hint:chapter12/exercise1": "A select with a default case never waits. Think about what should end the loop instead.",
+		"hint:chapter12/exercise2": "chan<- int can only be sent to, <-chan int can only be received from.",
+		"hint:chapter13/exercise4": "filepath.Join cleans \"..\" away instead of stopping it. Check the name before joining.",
+	})
+}
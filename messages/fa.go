@@ -0,0 +1,32 @@
+package messages
+
+// Persian is written right to left. Format verbs and the Latin text they
+// expand to (exercise names, Go identifiers) are laid out by the terminal's
+// bidirectional algorithm, so keep them at the start or end of a sentence
+// where possible.
+func init() {
+	Catalog.Add("fa", map[string]string{
+		RunHeader:   "فصل %s، تمرین %d: %s",
+		RunPass:     "قبول %s (%v)",
+		RunFail:     "رد %s: %v",
+		RunNotFound: "تمرینی با نام %q وجود ندارد",
+		RunHint:     "راهنمایی: %s",
+		RunSummary:  "%d از %d تمرین قبول شد",
+		RunPrereq:   "توجه %s بر پایهٔ %s است که هنوز انجام نشده",
+		RunBlocked:  "رد شد %s: اول %s را انجام دهید",
+		CheckOK:     "درست %s",
+		CheckSkip:   "رد شد %s: %v",
+
+		"prompt:chapter3/exercise1":  "یک متغیر به نام greetings از نوع برش رشته‌ها با مقادیر \"Hello\"، \"Hola\"، \"नमस्कार\"، \"こんにちは\" و \"Привіт\" تعریف کنید.",
+		"prompt:chapter3/exercise2":  "یک متغیر رشته‌ای به نام message با مقدار \"Hi 😘 and 😊 \" تعریف کنید و چهارمین rune آن را به صورت نویسه چاپ کنید، نه عدد.",
+		"prompt:chapter3/exercise3":  "یک struct به نام Employee با سه فیلد firstName، lastName و id تعریف کنید.",
+		"prompt:chapter12/exercise1": "از هر یک از سه گوروتین تولیدکننده یک مقدار بخوانید.",
+		"prompt:chapter12/exercise2": "اشتباه‌هایی را که نوع‌های کانال جهت‌دار به خطای کامپایل تبدیل می‌کنند، کنار کد درست نشان دهید.",
+
+		"hint:chapter3/exercise2":  "اندیس‌گذاری روی رشته بایت برمی‌گرداند. ابتدا آن را به []rune تبدیل کنید یا روی آن range بزنید.",
+		"hint:chapter3/exercise3":  "هر سه روش ساختن struct را امتحان کنید: ترتیبی، با نام فیلدها، و فیلد به فیلد.",
+		"hint:chapter12/exercise1": "select با حالت default هرگز منتظر نمی‌ماند. به این فکر کنید که چه چیزی باید حلقه را تمام کند.",
+		"hint:chapter12/exercise2": "به chan<- int فقط می‌توان فرستاد و از <-chan int فقط می‌توان دریافت کرد.",
+		"hint:chapter13/exercise4": "filepath.Join بخش‌های \"..\" را پاک می‌کند، جلویشان را نمی‌گیرد. نام را پیش از Join بررسی کنید.",
+	})
+}
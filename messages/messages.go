@@ -0,0 +1,60 @@
+// Package messages holds the translatable text of the learning tool:
+// messages printed while running exercises, and exercise prompts and hints.
+// Each language lives in its own file as a map added to Catalog, so a new
+// translation is one new file.
+//
+// English prompts are the exercises' doc comments, which is why en.go only
+// needs the tool's own messages; other languages translate prompts by
+// exercise name ("chapter12/exercise1").
+package messages
+
+import "learning-go/chapter10/greetings/v2/i18n"
+
+// Catalog contains every message in every language.
+var Catalog = i18n.NewCatalog("en")
+
+// Message keys used by the tool.
+const (
+	RunHeader   = "run.header"   // chapter, exercise number, title
+	RunPass     = "run.pass"     // exercise, duration
+	RunFail     = "run.fail"     // exercise, error
+	RunNotFound = "run.notfound" // exercise
+	RunHint     = "run.hint"     // hint text
+	RunSummary  = "run.summary"  // passed, total
+	RunPrereq   = "run.prereq"   // exercise, prerequisites not done
+	RunBlocked  = "run.blocked"  // exercise, prerequisites not done
+	CheckOK     = "check.ok"     // exercise
+	CheckSkip   = "check.skip"   // exercise, reason
+)
+
+// Lang picks the language to use: flag if set (from a --lang option), else
+// the environment's locale, else English. Languages without a catalog are
+// skipped.
+func Lang(flag string) string {
+	return Catalog.Choose(flag, i18n.FromEnv())
+}
+
+// Printer returns a printer for lang.
+func Printer(lang string) *i18n.Printer {
+	return Catalog.Printer(lang)
+}
+
+// Prompt returns the translated prompt of an exercise ("chapter3/exercise2")
+// or fallback, normally the English title from its doc comment.
+func Prompt(lang, exercise, fallback string) string {
+	if s, ok := Catalog.Lookup(lang, "prompt:"+exercise); ok {
+		return s
+	}
+	return fallback
+}
+
+// Hint returns the exercise's hint in lang, falling back to English.
+func Hint(lang, exercise string) (string, bool) {
+	key := "hint:" + exercise
+	if _, ok := Catalog.Lookup(lang, key); !ok {
+		if _, ok := Catalog.Lookup("en", key); !ok {
+			return "", false
+		}
+	}
+	return Catalog.Sprintf(lang, key), true
+}
@@ -0,0 +1,67 @@
+package messages
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+)
+
+// verbs matches fmt verbs, so a translation can be checked to take the
+// same arguments as the English message.
+var verbs = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+
+// Every English message must be translated to Persian, with the same
+// format verbs in the same order.
+func TestPersianIsComplete(t *testing.T) {
+	for _, key := range Catalog.Keys("en") {
+		fa, ok := Catalog.Lookup("fa", key)
+		if !ok {
+			t.Errorf("%s has no Persian translation", key)
+			continue
+		}
+		en, _ := Catalog.Lookup("en", key)
+		if got, want := verbs.FindAllString(fa, -1), verbs.FindAllString(en, -1); !slices.Equal(got, want) {
+			t.Errorf("%s: Persian verbs %q, English %q", key, got, want)
+		}
+	}
+}
+
+// Every message the tool prints has an English text.
+func TestKeysHaveEnglish(t *testing.T) {
+	for _, key := range []string{RunHeader, RunPass, RunFail, RunNotFound, RunHint, RunSummary, RunPrereq, RunBlocked, CheckOK, CheckSkip} {
+		if _, ok := Catalog.Lookup("en", key); !ok {
+			t.Errorf("%s has no English text", key)
+		}
+	}
+}
+
+func TestLang(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "fa_IR.UTF-8")
+	tests := []struct{ flag, want string }{
+		{"", "fa"},   // from $LANG
+		{"en", "en"}, // the flag wins
+		{"de", "fa"}, // no German catalog
+	}
+	for _, tt := range tests {
+		if got := Lang(tt.flag); got != tt.want {
+			t.Errorf("Lang(%q) with LANG=fa_IR.UTF-8 = %q, want %q", tt.flag, got, tt.want)
+		}
+	}
+}
+
+func TestPromptAndHint(t *testing.T) {
+	if got := Prompt("en", "chapter3/exercise3", "Declare Employee"); got != "Declare Employee" {
+		t.Errorf("Prompt(en) = %q, want the fallback", got)
+	}
+	if got := Prompt("fa", "chapter3/exercise3", "Declare Employee"); got == "Declare Employee" {
+		t.Errorf("Prompt(fa) = %q, want the Persian prompt", got)
+	}
+	if got, ok := Hint("de", "chapter12/exercise1"); !ok || got != Catalog.Sprintf("en", "hint:chapter12/exercise1") {
+		t.Errorf("Hint(de) = %q, %v; want the English hint", got, ok)
+	}
+	if _, ok := Hint("fa", "chapter1/exercise1"); ok {
+		t.Errorf("Hint of an exercise without one reported a hint")
+	}
+}
@@ -0,0 +1,123 @@
+// Package notify posts short text messages to chat services through their
+// webhook APIs. "learn remind" uses it to send daily practice reminders
+// to Slack or Telegram.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// A Notifier delivers a message.
+type Notifier interface {
+	Notify(ctx context.Context, text string) error
+}
+
+// Slack posts to a Slack incoming webhook.
+type Slack struct {
+	// WebhookURL is the https://hooks.slack.com/services/... address.
+	WebhookURL string
+	// Client is used for requests; nil means http.DefaultClient.
+	Client *http.Client
+}
+
+// Notify implements Notifier.
+func (s *Slack) Notify(ctx context.Context, text string) error {
+	return postJSON(ctx, s.Client, s.WebhookURL, map[string]string{"text": text})
+}
+
+// Telegram sends through a Telegram bot.
+type Telegram struct {
+	// Token is the bot token from @BotFather.
+	Token string
+	// ChatID is the chat, group or channel to post to.
+	ChatID string
+	// BaseURL defaults to https://api.telegram.org.
+	BaseURL string
+	// Client is used for requests; nil means http.DefaultClient.
+	Client *http.Client
+}
+
+// Notify implements Notifier.
+func (t *Telegram) Notify(ctx context.Context, text string) error {
+	base := t.BaseURL
+	if base == "" {
+		base = "https://api.telegram.org"
+	}
+	endpoint := fmt.Sprintf("%s/bot%s/sendMessage", strings.TrimSuffix(base, "/"), t.Token)
+	return postJSON(ctx, t.Client, endpoint, map[string]string{"chat_id": t.ChatID, "text": text})
+}
+
+// Multi sends to every notifier and returns all of their errors joined.
+type Multi []Notifier
+
+// Notify implements Notifier.
+func (m Multi) Notify(ctx context.Context, text string) error {
+	var errs []error
+	for _, n := range m {
+		errs = append(errs, n.Notify(ctx, text))
+	}
+	return errors.Join(errs...)
+}
+
+// StatusError is returned when the service answers with a non-2xx status.
+type StatusError struct {
+	// URL is the scheme and host of the webhook. The path, which holds
+	// the secret, is replaced with "...".
+	URL    string
+	Status int
+	Body   string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("notify: %s: %d %s: %s", e.URL, e.Status, http.StatusText(e.Status), e.Body)
+}
+
+func postJSON(ctx context.Context, client *http.Client, endpoint string, payload any) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		// The URL carries the secret for both services, and *url.Error
+		// includes it; report only the cause.
+		var uerr *url.Error
+		if errors.As(err, &uerr) {
+			err = uerr.Err
+		}
+		return fmt.Errorf("notify: posting to %s: %w", redact(endpoint), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return &StatusError{URL: redact(endpoint), Status: resp.StatusCode, Body: strings.TrimSpace(string(msg))}
+	}
+	return nil
+}
+
+// redact keeps the scheme and host of a webhook URL and hides the path,
+// where Slack and Telegram put their secrets.
+func redact(endpoint string) string {
+	scheme, rest, ok := strings.Cut(endpoint, "://")
+	if !ok {
+		return "webhook"
+	}
+	host, _, _ := strings.Cut(rest, "/")
+	return scheme + "://" + host + "/..."
+}
@@ -0,0 +1,84 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// recorder is a fake chat service that remembers the last request.
+type recorder struct {
+	status  int
+	path    string
+	payload map[string]string
+}
+
+func (rec *recorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rec.path = r.URL.Path
+	json.NewDecoder(r.Body).Decode(&rec.payload)
+	w.WriteHeader(rec.status)
+	w.Write([]byte("nope"))
+}
+
+func TestSlackAndTelegram(t *testing.T) {
+	rec := &recorder{status: http.StatusOK}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+	ctx := context.Background()
+
+	if err := (&Slack{WebhookURL: srv.URL + "/services/T/B/secret"}).Notify(ctx, "hi"); err != nil {
+		t.Fatal(err)
+	}
+	if rec.path != "/services/T/B/secret" || rec.payload["text"] != "hi" {
+		t.Errorf("Slack posted %v to %s", rec.payload, rec.path)
+	}
+
+	tg := &Telegram{Token: "123:secret", ChatID: "42", BaseURL: srv.URL + "/"}
+	if err := tg.Notify(ctx, "hi"); err != nil {
+		t.Fatal(err)
+	}
+	if rec.path != "/bot123:secret/sendMessage" || rec.payload["chat_id"] != "42" || rec.payload["text"] != "hi" {
+		t.Errorf("Telegram posted %v to %s", rec.payload, rec.path)
+	}
+}
+
+func TestStatusErrorHidesSecret(t *testing.T) {
+	rec := &recorder{status: http.StatusForbidden}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	err := Multi{&Slack{WebhookURL: srv.URL + "/services/T/B/secret"}}.Notify(context.Background(), "hi")
+	var se *StatusError
+	if !errors.As(err, &se) {
+		t.Fatalf("Notify = %v, want a *StatusError", err)
+	}
+	if se.Status != http.StatusForbidden || se.Body != "nope" {
+		t.Errorf("StatusError = %+v", se)
+	}
+	if strings.Contains(se.URL, "secret") || strings.Contains(err.Error(), "secret") {
+		t.Errorf("the error shows the webhook's secret: URL %q, %v", se.URL, err)
+	}
+	if want := srv.URL + "/..."; se.URL != want {
+		t.Errorf("URL = %q, want %q", se.URL, want)
+	}
+}
+
+func TestReminderText(t *testing.T) {
+	tests := []struct {
+		r    Reminder
+		want string
+	}{
+		{Reminder{Exercise: "chapter3/exercise1"}, "Today's exercise: chapter3/exercise1\nNo streak yet, today is a good day to start one."},
+		{Reminder{Exercise: "chapter3/exercise1", Title: "Slices", Streak: 1}, "Today's exercise: chapter3/exercise1 (Slices)\nStreak: 1 day. Keep it going!"},
+		{Reminder{Exercise: "chapter3/exercise1", Streak: 5}, "Today's exercise: chapter3/exercise1\nStreak: 5 days. Keep it going!"},
+	}
+	for _, tt := range tests {
+		if got := tt.r.Text(); got != tt.want {
+			t.Errorf("%+v.Text() = %q, want %q", tt.r, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package notify
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Reminder is the daily practice message.
+type Reminder struct {
+	// Exercise is the suggested exercise, e.g. "chapter12/exercise1".
+	Exercise string
+	// Title is its one-line description.
+	Title string
+	// Streak is the number of consecutive days practised up to yesterday.
+	Streak int
+}
+
+// Text formats r for a chat message.
+func (r Reminder) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Today's exercise: %s", r.Exercise)
+	if r.Title != "" {
+		fmt.Fprintf(&b, " (%s)", r.Title)
+	}
+	b.WriteString("\n")
+	switch {
+	case r.Streak == 0:
+		b.WriteString("No streak yet, today is a good day to start one.")
+	case r.Streak == 1:
+		b.WriteString("Streak: 1 day. Keep it going!")
+	default:
+		fmt.Fprintf(&b, "Streak: %d days. Keep it going!", r.Streak)
+	}
+	return b.String()
+}
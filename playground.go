@@ -1,70 +1,128 @@
 package main
 
-import "fmt"
+import (
+	"cmp"
+	"fmt"
+	"iter"
 
-// Define the ListNode structure
-type ListNode struct {
-	Val  int
-	Next *ListNode
+	"learning-go/datastructures/graph"
+	"learning-go/datastructures/heap"
+	"learning-go/datastructures/linkedlist"
+	"learning-go/dump"
+	"learning-go/internal/solutions"
+)
+
+// cursor is the next unmerged value of one list, and how to get the one
+// after it.
+type cursor[T any] struct {
+	val  T
+	next func() (T, bool)
 }
 
-// Function to merge two sorted linked lists
-func mergeTwoLists(l1 *ListNode, l2 *ListNode) *ListNode {
-	// Create a dummy node to serve as the start of the merged list
-	dummy := &ListNode{}
-	current := dummy
-
-	for l1 != nil && l2 != nil {
-		if l1.Val <= l2.Val {
-			current = l1
-			l1 = l1.Next
-		} else {
-			current = l2
-			l2 = l2.Next
+// MergeK merges any number of sorted lists into one sorted list. A min-heap
+// holds the smallest unmerged value of every list, so each step is a Pop
+// and a Push, O(log k) for k lists, instead of comparing every list's head.
+func MergeK[T cmp.Ordered](lists ...*linkedlist.List[T]) *linkedlist.List[T] {
+	h := heap.New(func(a, b cursor[T]) bool { return a.val < b.val })
+	for _, l := range lists {
+		next, stop := iter.Pull(l.All())
+		defer stop()
+		if v, ok := next(); ok {
+			h.Push(cursor[T]{v, next})
 		}
-
-		current = current.Next
 	}
 
-	// If either list still has nodes, append them to the merged list
-	if l1 != nil {
-		current.Next = l1
-	} else if l2 != nil {
-		current.Next = l2
+	merged := linkedlist.New[T]()
+	for c, ok := h.Pop(); ok; c, ok = h.Pop() {
+		merged.PushBack(c.val)
+		if v, ok := c.next(); ok {
+			h.Push(cursor[T]{v, c.next})
+		}
 	}
-
-	return dummy.Next
+	return merged
 }
 
-// Helper function to print the linked list
-func printList(head *ListNode) {
-	current := head
-	for current != nil {
-		fmt.Printf("%d -> ", current.Val)
-		current = current.Next
+// courseOrder solves the "course schedule" problem: given pairs of
+// {course, prerequisite}, find an order in which every course comes after
+// its prerequisites, or report that they contradict each other.
+func courseOrder(prereqs [][2]string) ([]string, error) {
+	g := graph.NewDirected[string]()
+	for _, p := range prereqs {
+		g.AddEdge(p[1], p[0], 1)
 	}
-	fmt.Println("nil")
+	return g.TopoSort()
 }
 
 func main() {
 	// Create first sorted linked list: 1 -> 2 -> 4
-	l1 := &ListNode{Val: 1}
-	l1.Next = &ListNode{Val: 2}
-	l1.Next.Next = &ListNode{Val: 4}
+	l1 := linkedlist.New(1, 2, 4)
 
 	// Create second sorted linked list: 1 -> 3 -> 4
-	l2 := &ListNode{Val: 1}
-	l2.Next = &ListNode{Val: 3}
-	l2.Next.Next = &ListNode{Val: 4}
+	l2 := linkedlist.New(1, 3, 4)
 
+	// dump.Dump follows the node pointers and shows every node's fields
 	fmt.Println("List 1:")
-	printList(l1)
+	fmt.Println(dump.Dump(l1))
 	fmt.Println("List 2:")
-	printList(l2)
+	fmt.Println(dump.Dump(l2))
 
-	// Merge the two sorted linked lists
-	mergedList := mergeTwoLists(l1, l2)
+	// Merge the two sorted linked lists. Their nodes are relinked into
+	// the result, so l1 and l2 are empty afterwards.
+	mergedList := linkedlist.Merge(l1, l2)
 
 	fmt.Println("Merged List:")
-	printList(mergedList)
+	fmt.Println(mergedList)
+
+	// The same function works for strings and floats
+	fmt.Println("Merged strings:")
+	fmt.Println(linkedlist.Merge(linkedlist.New("apple", "cherry"), linkedlist.New("banana", "date")))
+	fmt.Println("Merged floats:")
+	fmt.Println(linkedlist.Merge(linkedlist.New(0.5, 2.25), linkedlist.New(1.75, 3.0)))
+
+	// MergeFunc takes a comparator, here merging lists sorted by length
+	byLen := func(x, y string) int { return cmp.Compare(len(x), len(y)) }
+	fmt.Println("Merged by length:")
+	fmt.Println(linkedlist.MergeFunc(linkedlist.New("go", "rust"), linkedlist.New("c", "java", "python"), byLen))
+
+	// The rest of the list API
+	mergedList.Reverse()
+	mergedList.InsertAt(3, 99)
+	removed := mergedList.Remove(0)
+	fmt.Printf("Reversed, 99 inserted at 3, %d removed: %v (len %d)\n", removed, mergedList, mergedList.Len())
+	fmt.Println("As a slice:", mergedList.ToSlice())
+
+	// MergeK takes any number of lists and merges them with a heap
+	fmt.Println("Merged 4 lists with a heap:")
+	fmt.Println(MergeK(
+		linkedlist.New(1, 4, 7, 10),
+		linkedlist.New(2, 5, 8),
+		linkedlist.New[int](),
+		linkedlist.New(0, 3, 6, 9, 11),
+	))
+
+	// Course schedule: prerequisites as {course, prerequisite} pairs
+	order, err := courseOrder([][2]string{
+		{"generics", "types"},
+		{"concurrency", "functions"},
+		{"types", "basics"},
+		{"functions", "basics"},
+		{"errors", "types"},
+	})
+	fmt.Println("Course order:", order, err)
+	_, err = courseOrder([][2]string{{"a", "b"}, {"b", "c"}, {"c", "a"}})
+	fmt.Println("Impossible schedule:", err)
+
+	// Every problem in internal/solutions, checked against the examples
+	// from its statement
+	fmt.Println("Problems:")
+	for _, name := range solutions.Names() {
+		status := "ok"
+		if err := solutions.Check(name); err != nil {
+			status = err.Error()
+		}
+		fmt.Printf("  %-28s %d examples: %s\n", name, len(solutions.Examples(name)), status)
+	}
+	p, _ := solutions.Lookup("two-sum")
+	answer, err := p.Run("nums = [1,5,9,14], target = 23")
+	fmt.Println("two-sum on new input:", answer, err)
 }
@@ -0,0 +1,182 @@
+package progress
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+
+	"learning-go/errs"
+	"learning-go/review"
+)
+
+// SchemaVersion is the version of the JSON export that Export writes.
+// Version 0 is the progress file itself, which has no version field; it
+// imports as is. Import refuses versions newer than SchemaVersion.
+const SchemaVersion = 1
+
+// Format is the format of an export.
+type Format string
+
+const (
+	// JSON holds everything in the progress file: runs, reviews and days.
+	JSON Format = "json"
+	// CSV holds one row per exercise, for a spreadsheet. Reviews and
+	// practice days are left out.
+	CSV Format = "csv"
+)
+
+// csvHeader names the CSV columns. Import matches columns by name, so
+// exports with fewer (older) or more (newer) columns still load.
+var csvHeader = []string{"exercise", "runs", "last_run", "passed", "golden", "failures", "time", "timed"}
+
+// exported is the JSON export: the progress file with a version.
+type exported struct {
+	Version int `json:"version"`
+	Progress
+}
+
+// Export writes p to w in format f.
+func Export(w io.Writer, p *Progress, f Format) error {
+	switch f {
+	case JSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(exported{Version: SchemaVersion, Progress: *p})
+	case CSV:
+		cw := csv.NewWriter(w)
+		cw.Write(csvHeader)
+		ids := make([]string, 0, len(p.Exercises))
+		for id := range p.Exercises {
+			ids = append(ids, id)
+		}
+		slices.Sort(ids)
+		for _, id := range ids {
+			e := p.Exercises[id]
+			cw.Write([]string{
+				id,
+				strconv.Itoa(e.Runs),
+				e.LastRun.Format(time.RFC3339Nano),
+				strconv.FormatBool(e.Passed),
+				string(e.Golden),
+				strconv.Itoa(e.Failures),
+				e.Time.String(),
+				strconv.Itoa(e.Timed),
+			})
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+	return errs.Invalid("format", "unknown format %q; use json or csv", f)
+}
+
+// Import reads progress exported in format f.
+func Import(r io.Reader, f Format) (*Progress, error) {
+	switch f {
+	case JSON:
+		var x exported
+		if err := json.NewDecoder(r).Decode(&x); err != nil {
+			return nil, errs.Wrap(err, "reading the export")
+		}
+		if x.Version > SchemaVersion {
+			return nil, fmt.Errorf("export has schema version %d, newer than %d; update learn", x.Version, SchemaVersion)
+		}
+		return &x.Progress, nil
+	case CSV:
+		return importCSV(r)
+	}
+	return nil, errs.Invalid("format", "unknown format %q; use json or csv", f)
+}
+
+func importCSV(r io.Reader) (*Progress, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, errs.Wrap(err, "reading the export")
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("export is empty")
+	}
+	column := make(map[string]int)
+	for i, name := range rows[0] {
+		column[name] = i
+	}
+	if _, ok := column["exercise"]; !ok {
+		return nil, fmt.Errorf("export has no exercise column")
+	}
+
+	p := &Progress{Exercises: make(map[string]Entry)}
+	for n, row := range rows[1:] {
+		field := func(name string) string {
+			if i, ok := column[name]; ok && i < len(row) {
+				return row[i]
+			}
+			return ""
+		}
+		var e Entry
+		var err error
+		if s := field("runs"); s != "" && err == nil {
+			e.Runs, err = strconv.Atoi(s)
+		}
+		if s := field("last_run"); s != "" && err == nil {
+			e.LastRun, err = time.Parse(time.RFC3339Nano, s)
+		}
+		if s := field("passed"); s != "" && err == nil {
+			e.Passed, err = strconv.ParseBool(s)
+		}
+		if s := field("failures"); s != "" && err == nil {
+			e.Failures, err = strconv.Atoi(s)
+		}
+		if s := field("time"); s != "" && err == nil {
+			e.Time, err = time.ParseDuration(s)
+		}
+		if s := field("timed"); s != "" && err == nil {
+			e.Timed, err = strconv.Atoi(s)
+		}
+		if err != nil {
+			// Line 1 is the header.
+			return nil, errs.Wrap(err, "line %d", n+2)
+		}
+		switch g := Golden(field("golden")); g {
+		case NotChecked, GoldenPass, GoldenFail:
+			e.Golden = g
+		default:
+			return nil, fmt.Errorf("line %d: unknown golden result %q", n+2, g)
+		}
+		id := field("exercise")
+		if id == "" {
+			return nil, fmt.Errorf("line %d: no exercise", n+2)
+		}
+		p.Exercises[id] = e
+	}
+	return p, nil
+}
+
+// Merge adds from to p. Of two entries for the same exercise the one run
+// last wins, and of two review cards the one due last, so merging an
+// export from another machine keeps the newer record of each exercise.
+func (p *Progress) Merge(from *Progress) {
+	if p.Exercises == nil {
+		p.Exercises = make(map[string]Entry)
+	}
+	for id, e := range from.Exercises {
+		if old, ok := p.Exercises[id]; !ok || e.LastRun.After(old.LastRun) {
+			p.Exercises[id] = e
+		}
+	}
+	for id, c := range from.Reviews {
+		if p.Reviews == nil {
+			p.Reviews = make(map[string]review.Card)
+		}
+		if old, ok := p.Reviews[id]; !ok || c.Due.After(old.Due) {
+			p.Reviews[id] = c
+		}
+	}
+	p.Days = append(p.Days, from.Days...)
+	slices.Sort(p.Days)
+	p.Days = slices.Compact(p.Days)
+}
@@ -0,0 +1,97 @@
+package progress
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"learning-go/review"
+)
+
+func sample() *Progress {
+	var p Progress
+	p.RecordRun("chapter3/exercise1", day1, false)
+	p.RecordRun("chapter3/exercise1", day1.Add(time.Hour), true)
+	p.RecordTime("chapter3/exercise1", 1500*time.Millisecond)
+	p.RecordCheck("chapter3/exercise2", day1, GoldenFail)
+	p.Review("chapter3/exercise1", review.Perfect, day1.Add(48*time.Hour))
+	return &p
+}
+
+func TestExportImport(t *testing.T) {
+	for _, f := range []Format{JSON, CSV} {
+		p := sample()
+		var buf bytes.Buffer
+		if err := Export(&buf, p, f); err != nil {
+			t.Fatalf("%s: Export: %v", f, err)
+		}
+		got, err := Import(&buf, f)
+		if err != nil {
+			t.Fatalf("%s: Import: %v", f, err)
+		}
+		if f == CSV {
+			// CSV keeps only the entries.
+			p = &Progress{Exercises: p.Exercises}
+		}
+		if !reflect.DeepEqual(got, p) {
+			t.Errorf("%s round trip:\n got %+v\nwant %+v", f, got, p)
+		}
+	}
+}
+
+func TestImportVersions(t *testing.T) {
+	// A progress file, from before exports had a version, is version 0.
+	old := `{"exercises": {"chapter3/exercise1": {"runs": 2, "last_run": "2024-03-01T18:00:00Z", "passed": true}}}`
+	p, err := Import(strings.NewReader(old), JSON)
+	if err != nil || p.Exercises["chapter3/exercise1"].Runs != 2 {
+		t.Errorf("importing a progress file = %+v, %v", p, err)
+	}
+	// A CSV from before the failures and time columns.
+	oldCSV := "exercise,runs,last_run,passed,golden\nchapter3/exercise1,2,2024-03-01T18:00:00Z,true,pass\n"
+	p, err = Import(strings.NewReader(oldCSV), CSV)
+	if e := p.Exercises["chapter3/exercise1"]; err != nil || e.Runs != 2 || !e.Done() || e.Golden != GoldenPass {
+		t.Errorf("importing an older CSV = %+v, %v", p, err)
+	}
+
+	for name, bad := range map[string]struct {
+		f    Format
+		data string
+	}{
+		"newer version": {JSON, `{"version": 99}`},
+		"not JSON":      {JSON, `exercise,runs`},
+		"no exercise":   {CSV, "runs\n1\n"},
+		"bad number":    {CSV, "exercise,runs\nchapter3/exercise1,many\n"},
+		"bad golden":    {CSV, "exercise,golden\nchapter3/exercise1,maybe\n"},
+		"empty id":      {CSV, "exercise,runs\n,1\n"},
+		"unknown":       {"xml", ""},
+	} {
+		if _, err := Import(strings.NewReader(bad.data), bad.f); err == nil {
+			t.Errorf("%s: Import succeeded", name)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	var here, there Progress
+	here.RecordRun("chapter3/exercise1", day1, false)
+	here.RecordRun("chapter3/exercise2", day1.Add(time.Hour), true)
+	there.RecordRun("chapter3/exercise1", day1.Add(24*time.Hour), true)
+	there.RecordRun("chapter3/exercise2", day1, false)
+	there.RecordRun("chapter3/exercise3", day1, true)
+
+	here.Merge(&there)
+	if !here.Exercises["chapter3/exercise1"].Done() {
+		t.Error("the newer run of exercise1, from the import, was not kept")
+	}
+	if !here.Exercises["chapter3/exercise2"].Done() {
+		t.Error("the newer run of exercise2, already here, was replaced")
+	}
+	if _, ok := here.Exercises["chapter3/exercise3"]; !ok {
+		t.Error("exercise3 was not added")
+	}
+	if want := []string{day1.Local().Format(time.DateOnly), day1.Add(24 * time.Hour).Local().Format(time.DateOnly)}; !reflect.DeepEqual(here.Days, want) {
+		t.Errorf("Days = %q, want %q", here.Days, want)
+	}
+}
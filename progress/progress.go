@@ -0,0 +1,262 @@
+// Package progress records which exercises a learner has run, and whether
+// their output matched the golden file, in a JSON file that outlives the
+// command:
+//
+//	p, err := progress.Load(path)
+//	p.RecordRun("chapter3/exercise2", time.Now(), true)
+//	err = progress.Save(path, p)
+//
+// cmd/learn records every run and check there and prints the totals per
+// chapter with "learn progress". Two commands saving at the same moment
+// do not merge their records; the last one to save wins.
+//
+// Export and Import move progress between machines as versioned JSON, or
+// as CSV for a spreadsheet; Merge combines an import with local progress.
+package progress
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"learning-go/errs"
+	"learning-go/registry"
+	"learning-go/review"
+)
+
+// Golden is the outcome of the last golden-file check of an exercise.
+type Golden string
+
+const (
+	NotChecked Golden = ""     // never checked, or no golden file
+	GoldenPass Golden = "pass" // the output matched
+	GoldenFail Golden = "fail" // the output differed
+)
+
+// Entry is what is known about one exercise.
+type Entry struct {
+	Runs    int       `json:"runs"`
+	LastRun time.Time `json:"last_run"`
+	// Passed reports whether the last run finished without panicking.
+	Passed bool   `json:"passed"`
+	Golden Golden `json:"golden,omitempty"`
+	// Failures counts the runs that did not pass.
+	Failures int `json:"failures,omitempty"`
+	// Time is the total time of the Timed runs whose duration was
+	// recorded with RecordTime.
+	Time  time.Duration `json:"time,omitempty"`
+	Timed int           `json:"timed,omitempty"`
+}
+
+// Done reports whether the exercise counts as completed: its last run
+// passed and its output did not differ from the golden file the last time
+// it was checked. Exercises without a golden file are done once they run.
+func (e Entry) Done() bool {
+	return e.Passed && e.Golden != GoldenFail
+}
+
+// AverageTime returns the mean duration of the timed runs, or zero.
+func (e Entry) AverageTime() time.Duration {
+	if e.Timed == 0 {
+		return 0
+	}
+	return e.Time / time.Duration(e.Timed)
+}
+
+// Progress holds an Entry per exercise, by exercise ID such as
+// "chapter12/rpc/exercise1".
+type Progress struct {
+	Exercises map[string]Entry `json:"exercises"`
+	// Reviews is the spaced-repetition schedule of the exercises that have
+	// been reviewed, by exercise ID.
+	Reviews map[string]review.Card `json:"reviews,omitempty"`
+	// Days lists the days anything was run, as "2006-01-02" in local
+	// time, oldest first.
+	Days []string `json:"days,omitempty"`
+}
+
+// RecordRun records a run of exercise id at time at.
+func (p *Progress) RecordRun(id string, at time.Time, passed bool) {
+	if p.Exercises == nil {
+		p.Exercises = make(map[string]Entry)
+	}
+	e := p.Exercises[id]
+	e.Runs++
+	e.LastRun = at
+	e.Passed = passed
+	if !passed {
+		e.Failures++
+	}
+	p.Exercises[id] = e
+
+	if day := at.Local().Format(time.DateOnly); len(p.Days) == 0 || p.Days[len(p.Days)-1] < day {
+		p.Days = append(p.Days, day)
+	}
+}
+
+// RecordTime adds how long one run of exercise id took.
+func (p *Progress) RecordTime(id string, d time.Duration) {
+	if p.Exercises == nil {
+		p.Exercises = make(map[string]Entry)
+	}
+	e := p.Exercises[id]
+	e.Time += d
+	e.Timed++
+	p.Exercises[id] = e
+}
+
+// Streak returns the number of days in a row, up to today (now's date) or
+// yesterday, on which something was run. A streak is not broken until a
+// whole day passes without practice.
+func (p *Progress) Streak(now time.Time) int {
+	day := now.Local()
+	if len(p.Days) == 0 {
+		return 0
+	}
+	if p.Days[len(p.Days)-1] != day.Format(time.DateOnly) {
+		day = day.AddDate(0, 0, -1)
+	}
+	streak := 0
+	for i := len(p.Days) - 1; i >= 0 && p.Days[i] == day.Format(time.DateOnly); i-- {
+		streak++
+		day = day.AddDate(0, 0, -1)
+	}
+	return streak
+}
+
+// RecordCheck records a golden-file check of exercise id at time at. A
+// check runs the exercise, so it counts as a run that passed: an exercise
+// that panics fails the check before its output is compared, and is
+// recorded with RecordRun instead.
+func (p *Progress) RecordCheck(id string, at time.Time, golden Golden) {
+	p.RecordRun(id, at, true)
+	e := p.Exercises[id]
+	e.Golden = golden
+	p.Exercises[id] = e
+}
+
+// ReviewCard returns the review schedule of exercise id. An exercise that
+// is done but was never reviewed gets a new card, due a day after its last
+// run. It reports false for an exercise that is not done and has no card.
+func (p *Progress) ReviewCard(id string) (review.Card, bool) {
+	if c, ok := p.Reviews[id]; ok {
+		return c, true
+	}
+	e, ok := p.Exercises[id]
+	if !ok || !e.Done() {
+		return review.Card{}, false
+	}
+	return review.NewCard(id, e.LastRun.Add(24*time.Hour)), true
+}
+
+// Review records a review of exercise id at time at, graded q, and returns
+// the card with its next due date.
+func (p *Progress) Review(id string, q review.Quality, at time.Time) (review.Card, error) {
+	c, ok := p.ReviewCard(id)
+	if !ok {
+		c = review.NewCard(id, at)
+	}
+	c, err := c.Review(q, at)
+	if err != nil {
+		return c, err
+	}
+	if p.Reviews == nil {
+		p.Reviews = make(map[string]review.Card)
+	}
+	p.Reviews[id] = c
+	return c, nil
+}
+
+// Load reads the progress stored at path. A missing file is no progress
+// yet.
+func Load(path string) (*Progress, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Progress{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var p Progress
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, errs.Wrap(err, "reading %s", path)
+	}
+	return &p, nil
+}
+
+// Save writes p to path, creating its directory if needed.
+func Save(path string, p *Progress) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	// Write to a temporary file and rename it, so a crash mid-write never
+	// loses the progress recorded so far.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Reset deletes the progress stored at path. A missing file is not an
+// error.
+func Reset(path string) error {
+	err := os.Remove(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// DefaultPath returns where progress is kept: progress.json in a
+// learning-go directory under the user's config directory.
+func DefaultPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "progress.json"
+	}
+	return filepath.Join(dir, "learning-go", "progress.json")
+}
+
+// Chapter is the completion of one chapter.
+type Chapter struct {
+	Chapter     string
+	Done, Total int
+}
+
+// Percent returns Done as a whole percentage of Total, rounded down.
+func (c Chapter) Percent() int {
+	if c.Total == 0 {
+		return 0
+	}
+	return c.Done * 100 / c.Total
+}
+
+// Summarize counts the done exercises of each chapter in exercises, in the
+// order they are given (registry.All's order), and in total. Entries for
+// exercises that no longer exist are ignored.
+func Summarize(p *Progress, exercises []registry.Exercise) (chapters []Chapter, total Chapter) {
+	index := make(map[string]int)
+	for _, ex := range exercises {
+		i, ok := index[ex.Chapter]
+		if !ok {
+			i = len(chapters)
+			index[ex.Chapter] = i
+			chapters = append(chapters, Chapter{Chapter: ex.Chapter})
+		}
+		chapters[i].Total++
+		total.Total++
+		if p.Exercises[ex.ID()].Done() {
+			chapters[i].Done++
+			total.Done++
+		}
+	}
+	return chapters, total
+}
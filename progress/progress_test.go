@@ -0,0 +1,211 @@
+package progress
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"learning-go/registry"
+	"learning-go/review"
+)
+
+var day1 = time.Date(2024, 3, 1, 18, 0, 0, 0, time.UTC)
+
+func TestReviewCard(t *testing.T) {
+	var p Progress
+	p.RecordRun("chapter3/exercise1", day1, true)
+	p.RecordRun("chapter3/exercise2", day1, false)
+	p.RecordCheck("chapter3/exercise3", day1, GoldenFail)
+
+	c, ok := p.ReviewCard("chapter3/exercise1")
+	if !ok || c.ID != "chapter3/exercise1" || !c.Due.Equal(day1.Add(24*time.Hour)) {
+		t.Errorf("ReviewCard of a done exercise = %+v, %v; want one due a day after its run", c, ok)
+	}
+	for _, id := range []string{"chapter3/exercise2", "chapter3/exercise3", "chapter9/exercise1"} {
+		if _, ok := p.ReviewCard(id); ok {
+			t.Errorf("ReviewCard(%s) returned a card for an exercise that is not done", id)
+		}
+	}
+}
+
+func TestReview(t *testing.T) {
+	var p Progress
+	p.RecordRun("chapter3/exercise1", day1, true)
+	at := day1.Add(48 * time.Hour)
+
+	c, err := p.Review("chapter3/exercise1", review.Perfect, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Repetitions != 1 || !c.Due.Equal(at.Add(24*time.Hour)) {
+		t.Errorf("after the first review: %+v", c)
+	}
+	c, _ = p.Review("chapter3/exercise1", review.Perfect, c.Due)
+	if c.Interval != 6 || p.Reviews["chapter3/exercise1"] != c {
+		t.Errorf("after the second review: %+v, stored %+v", c, p.Reviews["chapter3/exercise1"])
+	}
+	if stored, _ := p.ReviewCard("chapter3/exercise1"); stored != c {
+		t.Errorf("ReviewCard = %+v, want the stored card %+v", stored, c)
+	}
+	if _, err := p.Review("chapter3/exercise1", 6, at); err == nil {
+		t.Error("Review with quality 6 succeeded")
+	}
+}
+
+func TestRecordRunCounts(t *testing.T) {
+	var p Progress
+	p.RecordRun("chapter3/exercise1", day1, false)
+	p.RecordRun("chapter3/exercise1", day1.Add(time.Hour), false)
+	p.RecordRun("chapter3/exercise1", day1.Add(2*time.Hour), true)
+	p.RecordTime("chapter3/exercise1", 100*time.Millisecond)
+	p.RecordTime("chapter3/exercise1", 300*time.Millisecond)
+
+	e := p.Exercises["chapter3/exercise1"]
+	if e.Runs != 3 || e.Failures != 2 || !e.Done() {
+		t.Errorf("entry = %+v, want 3 runs, 2 failures, done", e)
+	}
+	if got := e.AverageTime(); got != 200*time.Millisecond {
+		t.Errorf("AverageTime = %v, want 200ms", got)
+	}
+	if got := (Entry{}).AverageTime(); got != 0 {
+		t.Errorf("AverageTime of an untimed entry = %v, want 0", got)
+	}
+	if len(p.Days) != 1 {
+		t.Errorf("Days = %q, want one day for runs on the same day", p.Days)
+	}
+}
+
+func TestStreak(t *testing.T) {
+	day := func(n int) time.Time { return time.Date(2024, 3, n, 12, 0, 0, 0, time.Local) }
+	tests := []struct {
+		name string
+		runs []int
+		now  time.Time
+		want int
+	}{
+		{"never", nil, day(10), 0},
+		{"today", []int{10}, day(10), 1},
+		{"up to today", []int{7, 8, 9, 10}, day(10), 4},
+		{"up to yesterday", []int{8, 9}, day(10), 2},
+		{"broken", []int{7, 8}, day(10), 0},
+		{"gap", []int{5, 6, 8, 9, 10}, day(10), 3},
+	}
+	for _, tt := range tests {
+		var p Progress
+		for _, n := range tt.runs {
+			p.RecordRun("chapter3/exercise1", day(n), true)
+		}
+		if got := p.Streak(tt.now); got != tt.want {
+			t.Errorf("%s: Streak = %d, want %d (days %q)", tt.name, got, tt.want, p.Days)
+		}
+	}
+}
+
+func TestDone(t *testing.T) {
+	tests := []struct {
+		e    Entry
+		want bool
+	}{
+		{Entry{}, false},
+		{Entry{Passed: true}, true},
+		{Entry{Passed: true, Golden: GoldenPass}, true},
+		{Entry{Passed: true, Golden: GoldenFail}, false},
+		{Entry{Passed: false, Golden: GoldenPass}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.e.Done(); got != tt.want {
+			t.Errorf("%+v.Done() = %v, want %v", tt.e, got, tt.want)
+		}
+	}
+}
+
+func TestSaveLoadReset(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "learning-go", "progress.json")
+	if p, err := Load(path); err != nil || len(p.Exercises) != 0 {
+		t.Fatalf("Load of a missing file = %+v, %v; want empty progress", p, err)
+	}
+
+	var p Progress
+	p.RecordRun("chapter3/exercise1", day1, true)
+	p.RecordCheck("chapter3/exercise2", day1, GoldenFail)
+	if err := Save(path, &p); err != nil {
+		t.Fatalf("Save into a missing directory: %v", err)
+	}
+	if _, err := os.Stat(path + ".tmp"); !errors.Is(err, fs.ErrNotExist) {
+		t.Error("Save left its temporary file behind")
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, &p) {
+		t.Errorf("Load = %+v, want %+v", got, &p)
+	}
+
+	if err := Reset(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+		t.Error("Reset left the file behind")
+	}
+	if err := Reset(path); err != nil {
+		t.Errorf("Reset of a missing file = %v, want nil", err)
+	}
+}
+
+func TestLoadCorrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress.json")
+	if err := os.WriteFile(path, []byte(`{"exercises": {`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), path) {
+		t.Errorf("Load of a truncated file = %v, want an error naming the file", err)
+	}
+}
+
+func TestDefaultPath(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	t.Setenv("AppData", dir)
+	got := DefaultPath()
+	if !strings.HasPrefix(got, dir) || !strings.HasSuffix(got, filepath.Join("learning-go", "progress.json")) {
+		t.Errorf("DefaultPath() = %q, want learning-go/progress.json under %s", got, dir)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	noop := func(io.Writer) {}
+	exercises := []registry.Exercise{
+		{Chapter: "chapter3", Name: "exercise1", Run: noop},
+		{Chapter: "chapter3", Name: "exercise2", Run: noop},
+		{Chapter: "chapter3", Name: "exercise3", Run: noop},
+		{Chapter: "chapter12/rpc", Name: "exercise1", Run: noop},
+	}
+	var p Progress
+	p.RecordRun("chapter3/exercise1", day1, true)
+	p.RecordCheck("chapter3/exercise2", day1, GoldenFail)
+	p.RecordCheck("chapter12/rpc/exercise1", day1, GoldenPass)
+	p.RecordRun("chapter2/exercise9", day1, true) // no longer exists
+
+	chapters, total := Summarize(&p, exercises)
+	want := []Chapter{{"chapter3", 1, 3}, {"chapter12/rpc", 1, 1}}
+	if !slices.Equal(chapters, want) || total != (Chapter{"", 2, 4}) {
+		t.Errorf("Summarize = %v, %v; want %v and 2 of 4", chapters, total, want)
+	}
+	for _, tt := range []struct {
+		c    Chapter
+		want int
+	}{{chapters[0], 33}, {chapters[1], 100}, {total, 50}, {Chapter{}, 0}} {
+		if got := tt.c.Percent(); got != tt.want {
+			t.Errorf("%+v.Percent() = %d, want %d", tt.c, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"learning-go/registry"
+)
+
+// Exercism is an Exercism track: a directory with a config.json listing
+// its exercises, each in exercises/concept/<slug> or
+// exercises/practice/<slug> with a .meta/config.json naming its stub (the
+// solution file the learner fills in) and its tests.
+//
+// The exercises are registered under Chapter as exercise1, exercise2 and
+// so on, concept exercises first and each kind in the order config.json
+// lists them; deprecated ones are left out. Running one runs its tests
+// with go test in its directory, so it passes once the stub is solved.
+type Exercism struct {
+	// Dir is the track's root, holding config.json.
+	Dir string
+	// Chapter is where the exercises are registered. Empty means
+	// "chapter0/" followed by the track's slug, e.g. "chapter0/go".
+	Chapter string
+}
+
+// trackConfig is the part of a track's config.json that is used.
+type trackConfig struct {
+	Slug      string `json:"slug"`
+	Exercises struct {
+		Concept  []trackExercise `json:"concept"`
+		Practice []trackExercise `json:"practice"`
+	} `json:"exercises"`
+}
+
+type trackExercise struct {
+	Slug   string `json:"slug"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// exerciseConfig is the part of an exercise's .meta/config.json that is
+// used.
+type exerciseConfig struct {
+	Files struct {
+		Solution []string `json:"solution"`
+		Test     []string `json:"test"`
+	} `json:"files"`
+}
+
+// notSlug matches what a track slug may hold that a chapter name may not.
+var notSlug = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Exercises reads the track's configuration and returns its exercises. It
+// fails if an exercise's directory, stub or tests are missing.
+func (e Exercism) Exercises() ([]registry.Exercise, error) {
+	var track trackConfig
+	if err := readJSON(filepath.Join(e.Dir, "config.json"), &track); err != nil {
+		return nil, err
+	}
+	chapter := e.Chapter
+	if chapter == "" {
+		chapter = "chapter0/" + notSlug.ReplaceAllString(track.Slug, "")
+	}
+
+	var list []registry.Exercise
+	for _, kind := range []struct {
+		dir       string
+		exercises []trackExercise
+	}{
+		{"concept", track.Exercises.Concept},
+		{"practice", track.Exercises.Practice},
+	} {
+		for _, te := range kind.exercises {
+			if te.Status == "deprecated" {
+				continue
+			}
+			dir := filepath.Join(e.Dir, "exercises", kind.dir, te.Slug)
+			if err := checkExercise(dir); err != nil {
+				return nil, fmt.Errorf("exercism: %s: %w", te.Slug, err)
+			}
+			list = append(list, registry.Exercise{
+				Chapter: chapter,
+				Name:    "exercise" + strconv.Itoa(len(list)+1),
+				Title:   te.Name,
+				Run:     goCommand(dir, "test", "-count=1", "."),
+			})
+		}
+	}
+	return list, nil
+}
+
+// checkExercise checks that the exercise in dir has the stub and tests its
+// .meta/config.json names.
+func checkExercise(dir string) error {
+	var cfg exerciseConfig
+	if err := readJSON(filepath.Join(dir, ".meta", "config.json"), &cfg); err != nil {
+		return err
+	}
+	if len(cfg.Files.Solution) == 0 || len(cfg.Files.Test) == 0 {
+		return errors.New("config.json names no solution or no test files")
+	}
+	for _, name := range append(cfg.Files.Solution, cfg.Files.Test...) {
+		if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(name))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readJSON(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
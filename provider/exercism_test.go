@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"bytes"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"learning-go/safe"
+)
+
+// track writes a two-exercise Exercism track, plus a deprecated one, to a
+// temporary directory. The stub of "leap" is already solved; "bob"'s is
+// not.
+func track(t *testing.T) string {
+	dir := t.TempDir()
+	meta := func(stub, test string) string {
+		return `{"files": {"solution": ["` + stub + `"], "test": ["` + test + `"], "example": [".meta/example.go"]}}`
+	}
+	write(t, dir, map[string]string{
+		"config.json": `{"slug": "go", "exercises": {
+			"concept": [{"slug": "leap", "name": "Leap"}],
+			"practice": [
+				{"slug": "old", "name": "Old", "status": "deprecated"},
+				{"slug": "bob", "name": "Bob", "status": "active"}
+			]}}`,
+		"exercises/concept/leap/.meta/config.json": meta("leap.go", "leap_test.go"),
+		"exercises/concept/leap/go.mod":            "module leap\n\ngo 1.23\n",
+		"exercises/concept/leap/leap.go":           "package leap\n\nfunc IsLeap(y int) bool { return y%4 == 0 && (y%100 != 0 || y%400 == 0) }\n",
+		"exercises/concept/leap/leap_test.go": "package leap\n\nimport \"testing\"\n\nfunc TestLeap(t *testing.T) {\n" +
+			"\tif !IsLeap(2000) || IsLeap(1900) {\n\t\tt.Error(\"wrong\")\n\t}\n}\n",
+		"exercises/practice/bob/.meta/config.json": meta("bob.go", "bob_test.go"),
+		"exercises/practice/bob/go.mod":            "module bob\n\ngo 1.23\n",
+		"exercises/practice/bob/bob.go":            "package bob\n\nfunc Hey(string) string { panic(\"Please implement Hey\") }\n",
+		"exercises/practice/bob/bob_test.go": "package bob\n\nimport \"testing\"\n\nfunc TestHey(t *testing.T) {\n" +
+			"\tif Hey(\"Hi\") != \"Whatever.\" {\n\t\tt.Error(\"wrong\")\n\t}\n}\n",
+	})
+	return dir
+}
+
+func TestExercismExercises(t *testing.T) {
+	dir := track(t)
+	list, err := Exercism{Dir: dir}.Exercises()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, ex := range list {
+		got = append(got, ex.ID()+" "+ex.Title)
+	}
+	if want := []string{"chapter0/go/exercise1 Leap", "chapter0/go/exercise2 Bob"}; !slices.Equal(got, want) {
+		t.Errorf("Exercises() = %q, want %q", got, want)
+	}
+
+	list, err = Exercism{Dir: dir, Chapter: "chapter20/track"}.Exercises()
+	if err != nil || list[0].ID() != "chapter20/track/exercise1" {
+		t.Errorf("Exercises() with a chapter = %v, %v", list, err)
+	}
+
+	write(t, dir, map[string]string{"exercises/practice/bob/.meta/config.json": `{"files": {"solution": ["bob.go"], "test": ["missing_test.go"]}}`})
+	if _, err := (Exercism{Dir: dir}).Exercises(); err == nil || !strings.Contains(err.Error(), "bob") {
+		t.Errorf("Exercises() with a test file missing = %v, want an error naming bob", err)
+	}
+	if _, err := (Exercism{Dir: filepath.Join(dir, "exercises")}).Exercises(); err == nil {
+		t.Error("Exercises() without config.json succeeded")
+	}
+}
+
+func TestExercismRun(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("needs the go command")
+	}
+	list, err := Exercism{Dir: track(t)}.Exercises()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	if err := safe.SafeCall(func() error { list[0].Run(&out); return nil }); err != nil {
+		t.Errorf("running solved Leap: %v\n%s", err, out.String())
+	}
+	out.Reset()
+	err = safe.SafeCall(func() error { list[1].Run(&out); return nil })
+	if err == nil || !strings.Contains(out.String(), "Please implement Hey") {
+		t.Errorf("running unsolved Bob = %v, printed\n%s", err, out.String())
+	}
+}
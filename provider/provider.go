@@ -0,0 +1,74 @@
+// Package provider has registry.Providers: sources of exercises that are
+// not compiled into the runner.
+//
+// Dir turns a directory of the learner's own Go programs into exercises.
+// Each program is a file named after its exercise, in a directory named
+// after its chapter:
+//
+//	mine/chapter3/exercise7.go          -> chapter3/exercise7
+//	mine/chapter12/pipes/exercise1.go   -> chapter12/pipes/exercise1
+//
+// and is run with go run, so it must be a complete package main. Its
+// stdout and stderr become the exercise's output, and a program that
+// fails to build or exits with an error fails the exercise.
+//
+// Exercism imports a track in the layout of exercism.org, whose exercises
+// are a stub for the learner to fill in and the tests it must pass.
+package provider
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"learning-go/registry"
+)
+
+// Dir is the root of a directory of exercise programs.
+type Dir string
+
+// exerciseFile matches the file names Dir picks up; other files are
+// ignored.
+var exerciseFile = regexp.MustCompile(`^exercise\d+\.go$`)
+
+// Exercises returns an exercise for every exerciseN.go file under d. The
+// files are not read until the exercises run.
+func (d Dir) Exercises() ([]registry.Exercise, error) {
+	var list []registry.Exercise
+	err := filepath.WalkDir(string(d), func(path string, e fs.DirEntry, err error) error {
+		if err != nil || e.IsDir() || !exerciseFile.MatchString(e.Name()) {
+			return err
+		}
+		rel, err := filepath.Rel(string(d), filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		list = append(list, registry.Exercise{
+			Chapter: filepath.ToSlash(rel),
+			Name:    strings.TrimSuffix(e.Name(), ".go"),
+			Run:     goCommand(filepath.Dir(path), "run", filepath.Base(path)),
+		})
+		return nil
+	})
+	return list, err
+}
+
+// goCommand returns a Run func that runs the go command with args in dir.
+// It panics when the command fails, which is how the runner learns that
+// an exercise failed. The command is started without a context, so one
+// abandoned by the runner's --timeout keeps running until it exits by
+// itself.
+func goCommand(dir string, args ...string) func(w io.Writer) {
+	return func(w io.Writer) {
+		cmd := exec.Command("go", args...)
+		cmd.Dir = dir
+		cmd.Stdout, cmd.Stderr = w, w
+		if err := cmd.Run(); err != nil {
+			panic(fmt.Errorf("go %s in %s: %w", strings.Join(args, " "), dir, err))
+		}
+	}
+}
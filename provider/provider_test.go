@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"learning-go/registry"
+	"learning-go/safe"
+)
+
+// write creates the files under dir, making directories as needed.
+func write(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, src := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDirExercises(t *testing.T) {
+	dir := t.TempDir()
+	write(t, dir, map[string]string{
+		"chapter3/exercise7.go":        "package main\n",
+		"chapter12/pipes/exercise1.go": "package main\n",
+		"chapter3/helpers.go":          "package main\n",
+		"chapter3/exercise7_test.go":   "package main\n",
+		"chapter3/README.md":           "notes\n",
+	})
+	list, err := Dir(dir).Exercises()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, ex := range list {
+		got = append(got, ex.ID())
+	}
+	slices.Sort(got)
+	if want := []string{"chapter12/pipes/exercise1", "chapter3/exercise7"}; !slices.Equal(got, want) {
+		t.Errorf("Exercises() = %v, want %v", got, want)
+	}
+
+	if _, err := Dir(filepath.Join(dir, "missing")).Exercises(); err == nil {
+		t.Error("Exercises() of a missing directory succeeded")
+	}
+}
+
+func TestDirRun(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("needs the go command")
+	}
+	dir := t.TempDir()
+	write(t, dir, map[string]string{
+		"chapter5/exercise1.go": "package main\n\nimport \"fmt\"\n\nfunc main() { fmt.Println(\"hello from a file\") }\n",
+		"chapter5/exercise2.go": "package main\n\nfunc main() { undefined() }\n",
+		"chapter5/exercise3.go": "package main\n\nimport \"os\"\n\nfunc main() { os.Exit(3) }\n",
+	})
+	list, err := Dir(dir).Exercises()
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := make(map[string]registry.Exercise)
+	for _, ex := range list {
+		byName[ex.Name] = ex
+	}
+
+	var out bytes.Buffer
+	if err := safe.SafeCall(func() error { byName["exercise1"].Run(&out); return nil }); err != nil {
+		t.Fatalf("running exercise1: %v", err)
+	}
+	if got := out.String(); got != "hello from a file\n" {
+		t.Errorf("exercise1 printed %q", got)
+	}
+
+	// One does not build and one exits with an error; both fail, and the
+	// compiler's complaint is part of the output.
+	for _, name := range []string{"exercise2", "exercise3"} {
+		out.Reset()
+		err := safe.SafeCall(func() error { byName[name].Run(&out); return nil })
+		if err == nil || !strings.Contains(err.Error(), "go run") {
+			t.Errorf("%s = %v, want a go run failure", name, err)
+		}
+		if name == "exercise2" && !strings.Contains(out.String(), "undefined") {
+			t.Errorf("exercise2 printed %q, want the compile error", out.String())
+		}
+	}
+}
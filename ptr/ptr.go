@@ -0,0 +1,24 @@
+// Package ptr has the two pointer helpers Go code keeps rewriting.
+//
+// Go has no way to take the address of a literal or a function result:
+// &5 and &time.Now() do not compile. Optional fields, like a JSON field
+// that may be absent, are usually pointers, so filling them in takes a
+// temporary variable every time. To removes that variable, and Deref reads
+// such a field back without a nil check at every use:
+//
+//	u := Update{Salary: ptr.To(4200)}
+//	fmt.Println(ptr.Deref(u.Name, "(unchanged)"))
+package ptr
+
+// To returns a pointer to a new variable holding v.
+func To[T any](v T) *T {
+	return &v
+}
+
+// Deref returns the value p points to, or fallback if p is nil.
+func Deref[T any](p *T, fallback T) T {
+	if p == nil {
+		return fallback
+	}
+	return *p
+}
@@ -0,0 +1,45 @@
+package ptr
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTo(t *testing.T) {
+	p := To(42)
+	if *p != 42 {
+		t.Fatalf("*To(42) = %d", *p)
+	}
+	// Every call gets a variable of its own.
+	q := To(42)
+	*q = 7
+	if p == q || *p != 42 {
+		t.Errorf("To(42) twice shares a variable: %p %p", p, q)
+	}
+
+	v := 3
+	r := To(v)
+	*r = 4
+	if v != 3 {
+		t.Errorf("writing through To(v) changed v to %d", v)
+	}
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if got := To(now); !got.Equal(now) {
+		t.Errorf("*To(now) = %v", got)
+	}
+}
+
+func TestDeref(t *testing.T) {
+	if got := Deref(To("set"), "fallback"); got != "set" {
+		t.Errorf("Deref(To(%q)) = %q", "set", got)
+	}
+	if got := Deref(nil, "fallback"); got != "fallback" {
+		t.Errorf("Deref(nil) = %q, want the fallback", got)
+	}
+	// A pointer to the zero value is not nil: Deref returns the zero value,
+	// which is the point of an optional field.
+	if got := Deref(To(0), 5); got != 0 {
+		t.Errorf("Deref(To(0), 5) = %d, want 0", got)
+	}
+}
@@ -0,0 +1,100 @@
+// Package randsource is the one place randomness in this repository comes
+// from. Every random stream is derived from a single seed, so any run
+// that used randomness (random inputs, shuffles, jitter) can be repeated
+// exactly by passing the same seed again.
+//
+// The seed is taken from, in order: SetSeed (usually through the -seed
+// flag registered by RegisterFlag), the LEARN_SEED environment variable,
+// or a random value chosen on first use. Print Seed() when a randomized
+// run fails so it can be reproduced.
+package randsource
+
+import (
+	"flag"
+	"hash/fnv"
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// EnvVar is the environment variable holding the seed. Setting it on a
+// child process makes the child use the same seed.
+const EnvVar = "LEARN_SEED"
+
+var (
+	mu     sync.Mutex
+	seed   uint64
+	seeded bool
+)
+
+// Seed returns the seed in use, choosing it on the first call if SetSeed
+// has not been called.
+func Seed() uint64 {
+	mu.Lock()
+	defer mu.Unlock()
+	if !seeded {
+		seed, seeded = initialSeed(), true
+	}
+	return seed
+}
+
+func initialSeed() uint64 {
+	if v := os.Getenv(EnvVar); v != "" {
+		if s, err := strconv.ParseUint(v, 10, 64); err == nil {
+			return s
+		}
+	}
+	// The runtime's generator is randomly seeded at startup.
+	return rand.Uint64()
+}
+
+// SetSeed sets the seed. Streams created afterwards by New depend on it;
+// streams created before keep the old seed.
+func SetSeed(s uint64) {
+	mu.Lock()
+	defer mu.Unlock()
+	seed, seeded = s, true
+}
+
+// New returns a random number generator for the named use, such as
+// "quickselect" or "stress/jitter". The same seed and name always give the
+// same sequence, and different names give independent sequences, so adding
+// a new user of randomness does not change what existing ones see.
+//
+// The generator is not safe for concurrent use; create one per goroutine.
+func New(name string) *rand.Rand {
+	return rand.New(rand.NewPCG(Seed(), hash(name)))
+}
+
+// Fixed returns a random number generator that depends only on name and
+// key, not on the seed: for choices that must come out the same every
+// time they are made, such as the exercise of the day for a user, where
+// key would be the date and the user.
+func Fixed(name, key string) *rand.Rand {
+	return rand.New(rand.NewPCG(hash(key), hash(name)))
+}
+
+// Derive returns a seed for a sub-run, such as the i-th of many repeated
+// runs, computed from the current seed.
+func Derive(name string, i int) uint64 {
+	return hash(strconv.FormatUint(Seed(), 10) + "/" + name + "/" + strconv.Itoa(i))
+}
+
+func hash(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return h.Sum64()
+}
+
+// RegisterFlag defines -seed on fs. Setting it calls SetSeed.
+func RegisterFlag(fs *flag.FlagSet) {
+	fs.Func("seed", "seed for all randomness (default: $"+EnvVar+" or random)", func(v string) error {
+		s, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return err
+		}
+		SetSeed(s)
+		return nil
+	})
+}
@@ -0,0 +1,81 @@
+package randsource
+
+import (
+	"flag"
+	"io"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// draw returns the first few numbers of r.
+func draw(r *rand.Rand) []uint64 {
+	out := make([]uint64, 8)
+	for i := range out {
+		out[i] = r.Uint64()
+	}
+	return out
+}
+
+// withSeed sets the seed for the rest of the test.
+func withSeed(t *testing.T, s uint64) {
+	old := Seed()
+	t.Cleanup(func() { SetSeed(old) })
+	SetSeed(s)
+}
+
+func TestSameSeedSameSequence(t *testing.T) {
+	withSeed(t, 42)
+	first := draw(New("quickselect"))
+	other := draw(New("shuffle"))
+	if slices.Equal(first, other) {
+		t.Errorf("New(quickselect) and New(shuffle) gave the same sequence %v", first)
+	}
+
+	SetSeed(7)
+	if got := draw(New("quickselect")); slices.Equal(got, first) {
+		t.Errorf("seeds 42 and 7 gave the same sequence %v", got)
+	}
+
+	SetSeed(42)
+	if got := draw(New("quickselect")); !slices.Equal(got, first) {
+		t.Errorf("seed 42 again gave %v, want %v", got, first)
+	}
+	if got := Derive("stress", 3); got != Derive("stress", 3) || got == Derive("stress", 4) {
+		t.Errorf("Derive(stress, 3) = %d is not stable or equals Derive(stress, 4)", got)
+	}
+}
+
+func TestFixedIgnoresSeed(t *testing.T) {
+	withSeed(t, 1)
+	a := draw(Fixed("daily", "2026-03-01/ana"))
+	SetSeed(2)
+	if b := draw(Fixed("daily", "2026-03-01/ana")); !slices.Equal(a, b) {
+		t.Errorf("Fixed changed with the seed: %v, then %v", a, b)
+	}
+	if c := draw(Fixed("daily", "2026-03-02/ana")); slices.Equal(a, c) {
+		t.Errorf("Fixed gave the same sequence for two keys")
+	}
+}
+
+func TestSeedSources(t *testing.T) {
+	withSeed(t, 0)
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	RegisterFlag(fs)
+	if err := fs.Parse([]string{"-seed", "12345"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := Seed(); got != 12345 {
+		t.Errorf("Seed() after -seed 12345 = %d", got)
+	}
+	if err := fs.Parse([]string{"-seed", "-1"}); err == nil {
+		t.Errorf("-seed -1 was accepted")
+	}
+
+	t.Setenv(EnvVar, "99")
+	if got := initialSeed(); got != 99 {
+		t.Errorf("initialSeed() with %s=99 = %d", EnvVar, got)
+	}
+}
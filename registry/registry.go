@@ -0,0 +1,302 @@
+// Package registry is where every chapter's exercises make themselves known
+// to the runner. Each chapter package registers its exercises from an init
+// function; importing the package is enough to make them runnable:
+//
+//	func init() {
+//		registry.Register("chapter3", "exercise1", exercise1)
+//	}
+//
+// Exercises write their output to the io.Writer they are given instead of
+// os.Stdout, so the runner can print it or capture it and compare it with
+// a golden file.
+//
+// Chapters are named after their directory, so exercises in a nested
+// package such as chapter12/rpc are registered under "chapter12/rpc".
+//
+// An exercise that builds on others declares them with Requires, right
+// after registering it:
+//
+//	registry.Requires("chapter12/workerpool", "exercise1", "chapter12/exercise1")
+//
+// The runner warns about prerequisites that are not done yet, and Order
+// sorts a list so that prerequisites come first.
+//
+// Exercises can also come from outside the program: a Provider lists
+// them, and Add registers them next to the compiled-in ones, after which
+// the runner cannot tell them apart. Package provider has one that runs
+// the Go files in a directory.
+package registry
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"learning-go/datastructures/graph"
+	"learning-go/errs"
+)
+
+// Exercise is one registered exercise.
+type Exercise struct {
+	// Chapter is e.g. "chapter3" or "chapter12/rpc".
+	Chapter string
+	// Name is e.g. "exercise2".
+	Name string
+	// Number is the 2 in "exercise2".
+	Number int
+	Run    func(w io.Writer)
+	// Requires lists the IDs of the exercises to do before this one.
+	Requires []string
+	// Title is set by Providers that know it. Compiled-in exercises leave
+	// it empty; their titles are read from the source (package catalog).
+	Title string
+}
+
+// ID returns "chapter/name", the form used across the tooling (package
+// verify, cmd/catalog, the grading API).
+func (e Exercise) ID() string {
+	return e.Chapter + "/" + e.Name
+}
+
+var (
+	mu        sync.RWMutex
+	exercises = make(map[string]Exercise)
+
+	chapterName  = regexp.MustCompile(`^chapter(\d+)(/[a-z0-9]+)*$`)
+	exerciseName = regexp.MustCompile(`^exercise(\d+)$`)
+)
+
+// Register adds an exercise. It panics if the names are malformed or the
+// exercise is already registered, which can only be a programming error.
+func Register(chapter, name string, fn func(w io.Writer)) {
+	if fn == nil {
+		panic("registry: Register " + chapter + "/" + name + " with nil func")
+	}
+	ex, err := newExercise(chapter, name)
+	if err != nil {
+		panic(err.Error())
+	}
+	ex.Run = fn
+
+	mu.Lock()
+	defer mu.Unlock()
+	if _, dup := exercises[ex.ID()]; dup {
+		panic("registry: Register called twice for " + ex.ID())
+	}
+	exercises[ex.ID()] = ex
+}
+
+// newExercise checks the names and returns an exercise with its Number
+// set.
+func newExercise(chapter, name string) (Exercise, error) {
+	if !chapterName.MatchString(chapter) {
+		return Exercise{}, fmt.Errorf("registry: bad chapter name %q", chapter)
+	}
+	m := exerciseName.FindStringSubmatch(name)
+	if m == nil {
+		return Exercise{}, fmt.Errorf("registry: bad exercise name %q", name)
+	}
+	number, _ := strconv.Atoi(m[1])
+	return Exercise{Chapter: chapter, Name: name, Number: number}, nil
+}
+
+// Requires declares that an exercise, which must already be registered,
+// builds on the exercises with the given IDs, such as
+// "chapter12/exercise1". Prerequisites may be in other chapters and
+// need not be registered yet. Like Register, it panics on malformed
+// names.
+func Requires(chapter, name string, prerequisites ...string) {
+	id := chapter + "/" + name
+	for _, p := range prerequisites {
+		if err := checkPrerequisite(id, p); err != nil {
+			panic(err.Error())
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	ex, ok := exercises[id]
+	if !ok {
+		panic("registry: Requires called before Register for " + id)
+	}
+	ex.Requires = append(ex.Requires, prerequisites...)
+	exercises[id] = ex
+}
+
+func checkPrerequisite(id, p string) error {
+	i := strings.LastIndex(p, "/")
+	if i < 0 || !chapterName.MatchString(p[:i]) || !exerciseName.MatchString(p[i+1:]) {
+		return fmt.Errorf("registry: bad prerequisite %q of %s", p, id)
+	}
+	if p == id {
+		return fmt.Errorf("registry: %s requires itself", id)
+	}
+	return nil
+}
+
+// Provider is a source of exercises other than the chapter packages
+// compiled into the runner: a directory of Go files the learner wrote, say,
+// or a remote catalog. Each exercise needs Chapter, Name and Run, and may
+// have Requires; Number is filled in by Add.
+type Provider interface {
+	Exercises() ([]Exercise, error)
+}
+
+// Add registers every exercise of p, so that All, Chapter and Lookup
+// return them with the compiled-in ones. Unlike Register it returns an
+// error instead of panicking, since a provider's exercises come from
+// outside the program: for malformed names, a nil Run, or an exercise that
+// is already registered. Then none of p's exercises are added.
+func Add(p Provider) error {
+	list, err := p.Exercises()
+	if err != nil {
+		return fmt.Errorf("registry: loading exercises: %w", err)
+	}
+	added := make(map[string]Exercise, len(list))
+	for _, e := range list {
+		ex, err := newExercise(e.Chapter, e.Name)
+		if err != nil {
+			return err
+		}
+		if e.Run == nil {
+			return fmt.Errorf("registry: %s has no Run func", ex.ID())
+		}
+		for _, p := range e.Requires {
+			if err := checkPrerequisite(ex.ID(), p); err != nil {
+				return err
+			}
+		}
+		if _, dup := added[ex.ID()]; dup {
+			return fmt.Errorf("registry: %s is provided twice", ex.ID())
+		}
+		ex.Run, ex.Requires, ex.Title = e.Run, e.Requires, e.Title
+		added[ex.ID()] = ex
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for id := range added {
+		if _, dup := exercises[id]; dup {
+			return fmt.Errorf("registry: %s is already registered", id)
+		}
+	}
+	for id, ex := range added {
+		exercises[id] = ex
+	}
+	return nil
+}
+
+// All returns every exercise, ordered by chapter number, then nested
+// package, then exercise number.
+func All() []Exercise {
+	mu.RLock()
+	list := make([]Exercise, 0, len(exercises))
+	for _, ex := range exercises {
+		list = append(list, ex)
+	}
+	mu.RUnlock()
+
+	sort.Slice(list, func(i, j int) bool { return less(list[i], list[j]) })
+	return list
+}
+
+func less(a, b Exercise) bool {
+	if na, nb := chapterNumber(a.Chapter), chapterNumber(b.Chapter); na != nb {
+		return na < nb
+	}
+	if a.Chapter != b.Chapter {
+		return a.Chapter < b.Chapter
+	}
+	return a.Number < b.Number
+}
+
+func chapterNumber(chapter string) int {
+	m := chapterName.FindStringSubmatch(chapter)
+	n, _ := strconv.Atoi(m[1])
+	return n
+}
+
+// Chapters returns the names of all chapters with exercises, in order.
+func Chapters() []string {
+	var names []string
+	for _, ex := range All() {
+		if len(names) == 0 || names[len(names)-1] != ex.Chapter {
+			names = append(names, ex.Chapter)
+		}
+	}
+	return names
+}
+
+// Chapter returns the exercises of one chapter in order. Chapters may be
+// given as "chapter3" or just "3". The error wraps errs.ErrExerciseNotFound
+// if the chapter has no exercises.
+func Chapter(chapter string) ([]Exercise, error) {
+	chapter = normalize(chapter)
+	var list []Exercise
+	for _, ex := range All() {
+		if ex.Chapter == chapter {
+			list = append(list, ex)
+		}
+	}
+	if len(list) == 0 {
+		return nil, fmt.Errorf("%s: %w", chapter, errs.ErrExerciseNotFound)
+	}
+	return list, nil
+}
+
+// Lookup returns one exercise. Exercises may be given as "exercise2" or
+// just "2". The error wraps errs.ErrExerciseNotFound if there is no such
+// exercise.
+func Lookup(chapter, name string) (Exercise, error) {
+	chapter = normalize(chapter)
+	if !strings.HasPrefix(name, "exercise") {
+		name = "exercise" + name
+	}
+	mu.RLock()
+	ex, ok := exercises[chapter+"/"+name]
+	mu.RUnlock()
+	if !ok {
+		return Exercise{}, errs.InExercise(chapter, name, errs.ErrExerciseNotFound)
+	}
+	return ex, nil
+}
+
+// Order returns list reordered so that every exercise comes after those
+// of its prerequisites that are also in list, and otherwise in the order
+// list has. Prerequisites missing from list are ignored. If prerequisites
+// form a cycle, the error wraps graph.ErrCycle.
+func Order(list []Exercise) ([]Exercise, error) {
+	g := graph.NewDirected[string]()
+	byID := make(map[string]Exercise, len(list))
+	for _, ex := range list {
+		g.AddNode(ex.ID())
+		byID[ex.ID()] = ex
+	}
+	for _, ex := range list {
+		for _, p := range ex.Requires {
+			if _, ok := byID[p]; ok {
+				g.AddEdge(p, ex.ID(), 1)
+			}
+		}
+	}
+	ids, err := g.TopoSort()
+	if err != nil {
+		return nil, fmt.Errorf("ordering exercises by prerequisites: %w", err)
+	}
+	ordered := make([]Exercise, len(ids))
+	for i, id := range ids {
+		ordered[i] = byID[id]
+	}
+	return ordered, nil
+}
+
+func normalize(chapter string) string {
+	if !strings.HasPrefix(chapter, "chapter") {
+		return "chapter" + chapter
+	}
+	return chapter
+}
@@ -0,0 +1,137 @@
+package registry
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"testing"
+
+	"learning-go/datastructures/graph"
+	"learning-go/errs"
+)
+
+func nop(io.Writer) {}
+
+func ids(list []Exercise) []string {
+	var s []string
+	for _, ex := range list {
+		s = append(s, ex.ID())
+	}
+	return s
+}
+
+func TestRegisterAndLookup(t *testing.T) {
+	Register("chapter90", "exercise10", nop)
+	Register("chapter90", "exercise2", nop)
+	Register("chapter90/sub", "exercise1", nop)
+	Requires("chapter90", "exercise10", "chapter90/exercise2", "chapter1/exercise1")
+
+	list, err := Chapter("90")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := ids(list), []string{"chapter90/exercise2", "chapter90/exercise10"}; !slices.Equal(got, want) {
+		t.Errorf("Chapter(90) = %v, want %v", got, want)
+	}
+	ex, err := Lookup("chapter90", "10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"chapter90/exercise2", "chapter1/exercise1"}; !slices.Equal(ex.Requires, want) {
+		t.Errorf("Requires = %v, want %v", ex.Requires, want)
+	}
+	if _, err := Lookup("90", "7"); !errors.Is(err, errs.ErrExerciseNotFound) {
+		t.Errorf("Lookup(90, 7) = %v, want ErrExerciseNotFound", err)
+	}
+}
+
+func TestRequiresPanics(t *testing.T) {
+	Register("chapter91", "exercise1", nop)
+	for name, f := range map[string]func(){
+		"unregistered":  func() { Requires("chapter91", "exercise2", "chapter91/exercise1") },
+		"malformed":     func() { Requires("chapter91", "exercise1", "chapter91-exercise2") },
+		"bad name":      func() { Requires("chapter91", "exercise1", "chapter91/part1") },
+		"requires self": func() { Requires("chapter91", "exercise1", "chapter91/exercise1") },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: Requires did not panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestOrder(t *testing.T) {
+	ex := func(id string, requires ...string) Exercise {
+		return Exercise{Chapter: "chapter1", Name: id, Requires: requires}
+	}
+	list := []Exercise{
+		ex("exercise1"),
+		ex("exercise2", "chapter1/exercise4"),
+		ex("exercise3", "chapter9/exercise1"), // not in the list: ignored
+		ex("exercise4"),
+		ex("exercise5", "chapter1/exercise2", "chapter1/exercise1"),
+	}
+	got, err := Order(list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"chapter1/exercise1", "chapter1/exercise3", "chapter1/exercise4", "chapter1/exercise2", "chapter1/exercise5"}
+	if !slices.Equal(ids(got), want) {
+		t.Errorf("Order = %v, want %v", ids(got), want)
+	}
+
+	list[0].Requires = []string{"chapter1/exercise5"}
+	if _, err := Order(list); !errors.Is(err, graph.ErrCycle) {
+		t.Errorf("Order with a cycle = %v, want ErrCycle", err)
+	}
+}
+
+// provided is a Provider of a fixed list.
+type provided []Exercise
+
+func (p provided) Exercises() ([]Exercise, error) { return p, nil }
+
+func TestAdd(t *testing.T) {
+	Register("chapter92", "exercise1", nop)
+	err := Add(provided{
+		{Chapter: "chapter92", Name: "exercise3", Run: nop, Requires: []string{"chapter92/exercise1"}},
+		{Chapter: "chapter92/mine", Name: "exercise1", Run: nop, Title: "Mine"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	list, err := Chapter("92")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := ids(list), []string{"chapter92/exercise1", "chapter92/exercise3"}; !slices.Equal(got, want) {
+		t.Errorf("Chapter(92) = %v, want %v", got, want)
+	}
+	if list[1].Number != 3 || !slices.Equal(list[1].Requires, []string{"chapter92/exercise1"}) {
+		t.Errorf("added exercise = %+v, want Number 3 and its Requires", list[1])
+	}
+	if ex, err := Lookup("chapter92/mine", "1"); err != nil || ex.Title != "Mine" {
+		t.Errorf("Lookup of an added exercise = %+v, %v", ex, err)
+	}
+
+	for name, p := range map[string]provided{
+		"registered":   {{Chapter: "chapter93", Name: "exercise1", Run: nop}, {Chapter: "chapter92", Name: "exercise1", Run: nop}},
+		"twice":        {{Chapter: "chapter93", Name: "exercise1", Run: nop}, {Chapter: "chapter93", Name: "exercise1", Run: nop}},
+		"bad chapter":  {{Chapter: "chapter93", Name: "exercise1", Run: nop}, {Chapter: "ch93", Name: "exercise2", Run: nop}},
+		"nil Run":      {{Chapter: "chapter93", Name: "exercise1", Run: nop}, {Chapter: "chapter93", Name: "exercise2"}},
+		"bad requires": {{Chapter: "chapter93", Name: "exercise1", Run: nop, Requires: []string{"chapter93/exercise1"}}},
+	} {
+		if err := Add(p); err == nil {
+			t.Errorf("%s: Add succeeded", name)
+		}
+	}
+	// A failed Add adds nothing, not even the valid exercises before the
+	// bad one.
+	if _, err := Chapter("93"); !errors.Is(err, errs.ErrExerciseNotFound) {
+		t.Errorf("Chapter(93) after failed Adds = %v, want ErrExerciseNotFound", err)
+	}
+}
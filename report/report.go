@@ -0,0 +1,142 @@
+// Package report renders plain-text tables and bar charts with Unicode
+// box-drawing characters, for dashboards printed to a terminal.
+//
+//	t := report.Table{Headers: []string{"Chapter", "Done"}}
+//	t.AddRow("chapter3", "5/5")
+//	t.Render(os.Stdout)
+//
+// prints
+//
+//	┌──────────┬──────┐
+//	│ Chapter  │ Done │
+//	├──────────┼──────┤
+//	│ chapter3 │ 5/5  │
+//	└──────────┴──────┘
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// Align says how a column's cells are padded.
+type Align int
+
+const (
+	Left Align = iota
+	Right
+)
+
+// Table is a grid of text cells with a header row.
+type Table struct {
+	Title   string
+	Headers []string
+	// Align optionally sets the alignment of each column; missing entries
+	// default to Left.
+	Align []Align
+	Rows  [][]string
+}
+
+// AddRow appends a row. Values are formatted with fmt.Sprint.
+func (t *Table) AddRow(values ...any) {
+	row := make([]string, len(values))
+	for i, v := range values {
+		row[i] = fmt.Sprint(v)
+	}
+	t.Rows = append(t.Rows, row)
+}
+
+// Render writes the table to w.
+func (t *Table) Render(w io.Writer) error {
+	cols := len(t.Headers)
+	for _, r := range t.Rows {
+		cols = max(cols, len(r))
+	}
+	if cols == 0 {
+		return nil
+	}
+
+	widths := make([]int, cols)
+	measure := func(row []string) {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+	measure(t.Headers)
+	for _, r := range t.Rows {
+		measure(r)
+	}
+
+	var sb strings.Builder
+	line := func(left, mid, right string) {
+		sb.WriteString(left)
+		for i, wd := range widths {
+			if i > 0 {
+				sb.WriteString(mid)
+			}
+			sb.WriteString(strings.Repeat("─", wd+2))
+		}
+		sb.WriteString(right + "\n")
+	}
+	row := func(cells []string) {
+		sb.WriteString("│")
+		for i, wd := range widths {
+			cell := ""
+			if i < len(cells) {
+				cell = cells[i]
+			}
+			pad := strings.Repeat(" ", wd-utf8.RuneCountInString(cell))
+			if i < len(t.Align) && t.Align[i] == Right {
+				sb.WriteString(" " + pad + cell + " │")
+			} else {
+				sb.WriteString(" " + cell + pad + " │")
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	if t.Title != "" {
+		sb.WriteString(t.Title + "\n")
+	}
+	line("┌", "┬", "┐")
+	if len(t.Headers) > 0 {
+		row(t.Headers)
+		line("├", "┼", "┤")
+	}
+	for _, r := range t.Rows {
+		row(r)
+	}
+	line("└", "┴", "┘")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// Bar returns a horizontal bar of width cells filled in proportion to
+// fraction (clamped to [0, 1]), using eighth-block characters for a
+// smooth edge: Bar(0.5, 10) is "█████     ".
+func Bar(fraction float64, width int) string {
+	fraction = min(max(fraction, 0), 1)
+	eighths := int(fraction * float64(width*8))
+	full, part := eighths/8, eighths%8
+
+	var sb strings.Builder
+	sb.WriteString(strings.Repeat("█", full))
+	if part > 0 {
+		sb.WriteRune([]rune(" ▏▎▍▌▋▊▉")[part])
+		full++
+	}
+	sb.WriteString(strings.Repeat(" ", width-full))
+	return sb.String()
+}
+
+// Percent formats a completion ratio such as 3 of 4 as "75%". A zero total
+// formats as "0%".
+func Percent(done, total int) string {
+	if total == 0 {
+		return "0%"
+	}
+	return fmt.Sprintf("%d%%", done*100/total)
+}
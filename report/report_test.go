@@ -0,0 +1,77 @@
+package report
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTableRender(t *testing.T) {
+	tb := Table{
+		Title:   "Completion",
+		Headers: []string{"Chapter", "Done"},
+		Align:   []Align{Left, Right},
+	}
+	tb.AddRow("chapter3", 5)
+	tb.AddRow("ch12", "10/12")
+	tb.AddRow("short row")
+	want := `Completion
+┌───────────┬───────┐
+│ Chapter   │  Done │
+├───────────┼───────┤
+│ chapter3  │     5 │
+│ ch12      │ 10/12 │
+│ short row │       │
+└───────────┴───────┘
+`
+	var sb strings.Builder
+	if err := tb.Render(&sb); err != nil {
+		t.Fatal(err)
+	}
+	if sb.String() != want {
+		t.Errorf("Render =\n%s\nwant\n%s", sb.String(), want)
+	}
+
+	sb.Reset()
+	if err := (&Table{}).Render(&sb); err != nil || sb.Len() != 0 {
+		t.Errorf("Render of an empty table = %q, %v", sb.String(), err)
+	}
+}
+
+func TestBar(t *testing.T) {
+	tests := []struct {
+		fraction float64
+		width    int
+		want     string
+	}{
+		{0, 4, "    "},
+		{0.5, 10, "█████     "},
+		{1, 4, "████"},
+		{1.5, 4, "████"},
+		{-1, 4, "    "},
+		{0.3, 2, "▌ "}, // 4.8 eighths, rounded down to 4
+		{1.0 / 16, 1, " "},
+		{0.125, 1, "▏"},
+	}
+	for _, tt := range tests {
+		if got := Bar(tt.fraction, tt.width); got != tt.want {
+			t.Errorf("Bar(%v, %d) = %q, want %q", tt.fraction, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestPercent(t *testing.T) {
+	tests := []struct {
+		done, total int
+		want        string
+	}{
+		{3, 4, "75%"},
+		{2, 3, "66%"}, // truncated, so 100% means every exercise
+		{0, 0, "0%"},
+		{5, 5, "100%"},
+	}
+	for _, tt := range tests {
+		if got := Percent(tt.done, tt.total); got != tt.want {
+			t.Errorf("Percent(%d, %d) = %q, want %q", tt.done, tt.total, got, tt.want)
+		}
+	}
+}
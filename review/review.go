@@ -0,0 +1,104 @@
+// Package review schedules spaced-repetition reviews with the SM-2
+// algorithm (the one popularized by SuperMemo and Anki).
+//
+// Each item being learned is a Card. After reviewing it, the learner
+// grades how well they remembered it from 0 (blackout) to 5 (perfect).
+// Good grades push the next review further into the future; a grade below
+// 3 starts the card over, so weak material comes back soon.
+package review
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Quality is the learner's grade for one review, from 0 to 5.
+type Quality int
+
+const (
+	Blackout  Quality = iota // no recollection at all
+	Wrong                    // wrong, but the answer looked familiar
+	WrongEasy                // wrong, but the answer seemed easy once shown
+	Hard                     // correct after serious difficulty
+	Hesitant                 // correct after some hesitation
+	Perfect                  // correct immediately
+)
+
+const (
+	initialEase = 2.5
+	minimumEase = 1.3
+	day         = 24 * time.Hour
+)
+
+// Card is the scheduling state of one item, e.g. "chapter3/exercise2".
+type Card struct {
+	ID string `json:"id"`
+	// Ease grows when reviews go well and shrinks when they do not; the
+	// interval is multiplied by it after every successful review.
+	Ease float64 `json:"ease"`
+	// Interval is the number of days between the last review and Due.
+	Interval int `json:"interval_days"`
+	// Repetitions counts successful reviews in a row.
+	Repetitions int       `json:"repetitions"`
+	Due         time.Time `json:"due"`
+}
+
+// NewCard returns a card that is due immediately.
+func NewCard(id string, now time.Time) Card {
+	return Card{ID: id, Ease: initialEase, Due: now}
+}
+
+// Review returns the card's state after a review graded q at time now.
+func (c Card) Review(q Quality, now time.Time) (Card, error) {
+	if q < Blackout || q > Perfect {
+		return c, fmt.Errorf("review: quality %d out of range 0-5", q)
+	}
+	if c.Ease == 0 {
+		c.Ease = initialEase
+	}
+
+	if q < Hard {
+		// Forgotten: start the repetition sequence again, but keep the
+		// ease so difficult cards stay difficult.
+		c.Repetitions = 0
+		c.Interval = 1
+	} else {
+		switch c.Repetitions {
+		case 0:
+			c.Interval = 1
+		case 1:
+			c.Interval = 6
+		default:
+			c.Interval = int(math.Round(float64(c.Interval) * c.Ease))
+		}
+		c.Repetitions++
+	}
+
+	// The SM-2 ease update: +0.1 for a perfect answer, down to -0.8 for a
+	// blackout, never below 1.3.
+	diff := float64(Perfect - q)
+	c.Ease += 0.1 - diff*(0.08+diff*0.02)
+	c.Ease = math.Max(c.Ease, minimumEase)
+
+	c.Due = now.Add(time.Duration(c.Interval) * day)
+	return c, nil
+}
+
+// IsDue reports whether the card should be reviewed at time now.
+func (c Card) IsDue(now time.Time) bool {
+	return !c.Due.After(now)
+}
+
+// DueCards returns the cards due at time now, the most overdue first.
+func DueCards(cards []Card, now time.Time) []Card {
+	var due []Card
+	for _, c := range cards {
+		if c.IsDue(now) {
+			due = append(due, c)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool { return due[i].Due.Before(due[j].Due) })
+	return due
+}
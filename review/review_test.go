@@ -0,0 +1,79 @@
+package review
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+var start = time.Date(2024, time.May, 1, 9, 0, 0, 0, time.UTC)
+
+func TestReviewSchedule(t *testing.T) {
+	tests := []struct {
+		name     string
+		grades   []Quality
+		interval int
+		reps     int
+		ease     float64
+	}{
+		{"first perfect", []Quality{Perfect}, 1, 1, 2.6},
+		{"second perfect", []Quality{Perfect, Perfect}, 6, 2, 2.7},
+		// 6 days times ease 2.7 is 16.2, rounded.
+		{"third perfect", []Quality{Perfect, Perfect, Perfect}, 16, 3, 2.8},
+		// Hard still counts as remembered, but costs ease.
+		{"hard", []Quality{Hard}, 1, 1, 2.36},
+		// A lapse starts over and keeps the lower ease.
+		{"lapse", []Quality{Perfect, Perfect, Perfect, Wrong}, 1, 0, 2.26},
+		{"ease floor", []Quality{Blackout, Blackout, Blackout}, 1, 0, minimumEase},
+	}
+	for _, tt := range tests {
+		c := NewCard("chapter3/exercise2", start)
+		// Each review happens on the day the card is due.
+		var reviewed time.Time
+		for _, q := range tt.grades {
+			reviewed = c.Due
+			var err error
+			if c, err = c.Review(q, reviewed); err != nil {
+				t.Fatalf("%s: Review(%d): %v", tt.name, q, err)
+			}
+		}
+		if c.Interval != tt.interval || c.Repetitions != tt.reps || math.Abs(c.Ease-tt.ease) > 1e-9 ||
+			!c.Due.Equal(reviewed.AddDate(0, 0, tt.interval)) {
+			t.Errorf("%s: card = %+v, want interval %d, %d repetitions, ease %.2f",
+				tt.name, c, tt.interval, tt.reps, tt.ease)
+		}
+	}
+}
+
+func TestReviewRejectsBadQuality(t *testing.T) {
+	c := NewCard("x", start)
+	for _, q := range []Quality{-1, 6} {
+		if got, err := c.Review(q, start); err == nil || got != c {
+			t.Errorf("Review(%d) = %+v, %v, want the card unchanged and an error", q, got, err)
+		}
+	}
+	// A card decoded without an ease starts from the default.
+	if got, _ := (Card{ID: "x"}).Review(Perfect, start); math.Abs(got.Ease-2.6) > 1e-9 {
+		t.Errorf("Review of a zero-ease card gave ease %v, want 2.6", got.Ease)
+	}
+}
+
+func TestDueCards(t *testing.T) {
+	cards := []Card{
+		{ID: "later", Due: start.Add(time.Hour)},
+		{ID: "yesterday", Due: start.Add(-day)},
+		{ID: "now", Due: start},
+		{ID: "last week", Due: start.Add(-7 * day)},
+	}
+	var got []string
+	for _, c := range DueCards(cards, start) {
+		got = append(got, c.ID)
+	}
+	if want := []string{"last week", "yesterday", "now"}; !slices.Equal(got, want) {
+		t.Errorf("DueCards = %v, want %v", got, want)
+	}
+	if due := DueCards(nil, start); len(due) != 0 {
+		t.Errorf("DueCards(nil) = %v", due)
+	}
+}
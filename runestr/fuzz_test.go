@@ -0,0 +1,79 @@
+package runestr
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// seeds are strings chosen to break string code: multi-byte runes, a
+// combining accent, a flag and a skin-tone emoji, a zero-width joiner,
+// JSON's special characters, and bytes and truncated sequences that are
+// not valid UTF-8.
+var seeds = []string{
+	"", "a", "Z", " ", "\u00e9", "e\u0301", "日本", "🇮🇷", "👍🏽", "\u200d",
+	`"`, `\`, "<", "\x00", "\xff", "\xe6\x97", "\x80", message,
+}
+
+// Truncate must return a prefix of s with min(n, runes in s) runes, and
+// valid UTF-8 whenever s is.
+func FuzzTruncate(f *testing.F) {
+	for i, s := range seeds {
+		f.Add(s, i%5-1)
+	}
+	f.Fuzz(func(t *testing.T, s string, n int) {
+		got := Truncate(s, n)
+		want := min(max(n, 0), utf8.RuneCountInString(s))
+		if !strings.HasPrefix(s, got) {
+			t.Errorf("Truncate(%q, %d) = %q, not a prefix", s, n, got)
+		}
+		if c := utf8.RuneCountInString(got); c != want {
+			t.Errorf("Truncate(%q, %d) = %q, %d runes, want %d", s, n, got, c, want)
+		}
+		if utf8.ValidString(s) && !utf8.ValidString(got) {
+			t.Errorf("Truncate(%q, %d) = %q, not valid UTF-8", s, n, got)
+		}
+	})
+}
+
+// Reverse must keep the rune count, always return valid UTF-8, and undo
+// itself on valid UTF-8. Invalid bytes become U+FFFD, so reversing twice
+// cannot give them back.
+func FuzzReverse(f *testing.F) {
+	for _, s := range seeds {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		got := Reverse(s)
+		if !utf8.ValidString(got) {
+			t.Errorf("Reverse(%q) = %q, not valid UTF-8", s, got)
+		}
+		if c, want := utf8.RuneCountInString(got), utf8.RuneCountInString(s); c != want {
+			t.Errorf("Reverse(%q) = %q, %d runes, want %d", s, got, c, want)
+		}
+		if utf8.ValidString(s) && Reverse(got) != s {
+			t.Errorf("Reverse(Reverse(%q)) = %q", s, Reverse(got))
+		}
+	})
+}
+
+// Graphemes must split s into non-empty pieces that join back into s.
+func FuzzGraphemes(f *testing.F) {
+	for _, s := range seeds {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		g := Graphemes(s)
+		for _, c := range g {
+			if c == "" {
+				t.Fatalf("Graphemes(%q) = %q, with an empty cluster", s, g)
+			}
+		}
+		if joined := strings.Join(g, ""); joined != s {
+			t.Errorf("Graphemes(%q) = %q, which joins to %q", s, g, joined)
+		}
+		if GraphemeLen(s) > utf8.RuneCountInString(s) {
+			t.Errorf("GraphemeLen(%q) = %d, more than its runes", s, GraphemeLen(s))
+		}
+	})
+}
@@ -0,0 +1,190 @@
+// Package runestr provides string helpers that count in runes instead of
+// bytes.
+//
+// Indexing a string (s[i]) and slicing it (s[i:j]) use byte offsets, which
+// only line up with characters for ASCII text. For a string like
+// "Hi 😘 and 😊 ", s[3] is the first of the four bytes that encode 😘, not the
+// emoji itself. The functions here walk the string rune by rune instead.
+//
+// Some characters that look like one symbol are several runes, such as a
+// letter followed by a combining accent or an emoji joined with U+200D.
+// The Grapheme* functions treat those clusters as a single unit.
+//
+// Package strutil offers the most common of these under the names
+// RuneAt, ReverseRunes, Truncate and GraphemeLen.
+package runestr
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// RuneLen returns the number of runes in s.
+func RuneLen(s string) int {
+	return utf8.RuneCountInString(s)
+}
+
+// RuneAt returns the rune at rune index i. It reports false if i is out of
+// range.
+func RuneAt(s string, i int) (rune, bool) {
+	if i < 0 {
+		return 0, false
+	}
+	n := 0
+	for _, r := range s {
+		if n == i {
+			return r, true
+		}
+		n++
+	}
+	return 0, false
+}
+
+// RuneSlice returns the runes from index i up to, but not including, index
+// j, just like s[i:j] does for bytes. Like slicing, it panics if
+// 0 <= i <= j <= RuneLen(s) does not hold.
+func RuneSlice(s string, i, j int) string {
+	if i < 0 || j < i {
+		panic("runestr: slice bounds out of range")
+	}
+	start, end := -1, -1
+	n := 0
+	for offset := range s {
+		if n == i {
+			start = offset
+		}
+		if n == j {
+			end = offset
+			break
+		}
+		n++
+	}
+	// Indexes equal to the rune count point just past the last rune.
+	if n == i && start < 0 {
+		start = len(s)
+	}
+	if n == j && end < 0 {
+		end = len(s)
+	}
+	if start < 0 || end < 0 {
+		panic("runestr: slice bounds out of range")
+	}
+	return s[start:end]
+}
+
+// Reverse returns s with its runes in reverse order. Combining marks end up
+// before the character they modified; use ReverseGraphemes to keep them
+// attached.
+func Reverse(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
+
+// Truncate returns the first n runes of s, or s itself if it is shorter.
+// Unlike s[:n], it never cuts a multi-byte character in half.
+func Truncate(s string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	count := 0
+	for offset := range s {
+		if count == n {
+			return s[:offset]
+		}
+		count++
+	}
+	return s
+}
+
+// Graphemes splits s into user-perceived characters. It implements the
+// common cases of Unicode grapheme clustering rather than the full
+// algorithm: a base rune followed by combining marks, variation selectors
+// and skin-tone modifiers; runes joined by a zero-width joiner (as in
+// family emoji); and pairs of regional indicators (flags).
+func Graphemes(s string) []string {
+	var out []string
+	start := 0
+	var prev rune
+	regional := 0 // regional indicators in the current cluster
+	for offset, r := range s {
+		if offset == start {
+			prev = r
+			if isRegional(r) {
+				regional = 1
+			}
+			continue
+		}
+		joins := isExtend(r) || prev == zwj
+		if isRegional(r) && regional == 1 {
+			joins = true
+		}
+		if !joins {
+			out = append(out, s[start:offset])
+			start = offset
+			regional = 0
+		}
+		if isRegional(r) {
+			regional++
+		}
+		prev = r
+	}
+	if start < len(s) {
+		out = append(out, s[start:])
+	}
+	return out
+}
+
+// GraphemeLen returns the number of user-perceived characters in s.
+func GraphemeLen(s string) int {
+	return len(Graphemes(s))
+}
+
+// ReverseGraphemes reverses s one grapheme cluster at a time, so accents
+// and emoji sequences survive intact.
+func ReverseGraphemes(s string) string {
+	g := Graphemes(s)
+	buf := make([]byte, 0, len(s))
+	for i := len(g) - 1; i >= 0; i-- {
+		buf = append(buf, g[i]...)
+	}
+	return string(buf)
+}
+
+// TruncateGraphemes returns the first n grapheme clusters of s.
+func TruncateGraphemes(s string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	size := 0
+	for i, g := range Graphemes(s) {
+		if i == n {
+			break
+		}
+		size += len(g)
+	}
+	return s[:size]
+}
+
+const zwj = '\u200d' // zero-width joiner
+
+// isExtend reports whether r attaches to the rune before it.
+func isExtend(r rune) bool {
+	switch {
+	case r == zwj:
+		return true
+	case unicode.Is(unicode.Mn, r), unicode.Is(unicode.Me, r):
+		return true
+	case r >= 0xfe00 && r <= 0xfe0f: // variation selectors
+		return true
+	case r >= 0x1f3fb && r <= 0x1f3ff: // emoji skin-tone modifiers
+		return true
+	}
+	return false
+}
+
+func isRegional(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
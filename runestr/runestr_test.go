@@ -0,0 +1,127 @@
+package runestr
+
+import (
+	"slices"
+	"testing"
+)
+
+// message is chapter3's exercise2 string, whose byte and rune indexes
+// disagree from the fourth character on.
+const message = "Hi 😘 and 😊 "
+
+func TestRuneAt(t *testing.T) {
+	tests := []struct {
+		s    string
+		i    int
+		want rune
+		ok   bool
+	}{
+		{message, 0, 'H', true},
+		{message, 3, '😘', true},
+		{message, 9, '😊', true},
+		{message, 10, ' ', true},
+		{message, 11, 0, false},
+		{message, -1, 0, false},
+		{"", 0, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := RuneAt(tt.s, tt.i)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("RuneAt(%q, %d) = %q, %v; want %q, %v", tt.s, tt.i, got, ok, tt.want, tt.ok)
+		}
+	}
+	if got := RuneLen(message); got != 11 {
+		t.Errorf("RuneLen(%q) = %d, want 11", message, got)
+	}
+}
+
+func TestRuneSlice(t *testing.T) {
+	tests := []struct {
+		i, j int
+		want string
+	}{
+		{0, 2, "Hi"},
+		{3, 4, "😘"},
+		{3, 9, "😘 and "},
+		{9, 11, "😊 "},
+		{11, 11, ""},
+		{0, 11, message},
+	}
+	for _, tt := range tests {
+		if got := RuneSlice(message, tt.i, tt.j); got != tt.want {
+			t.Errorf("RuneSlice(%q, %d, %d) = %q, want %q", message, tt.i, tt.j, got, tt.want)
+		}
+	}
+	for _, bounds := range [][2]int{{-1, 2}, {3, 2}, {0, 12}, {12, 12}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("RuneSlice(%q, %d, %d) did not panic", message, bounds[0], bounds[1])
+				}
+			}()
+			RuneSlice(message, bounds[0], bounds[1])
+		}()
+	}
+}
+
+func TestReverseAndTruncate(t *testing.T) {
+	if got, want := Reverse(message), " 😊 dna 😘 iH"; got != want {
+		t.Errorf("Reverse(%q) = %q, want %q", message, got, want)
+	}
+	// Rune by rune, the accent moves in front of its letter.
+	if got, want := Reverse("e\u0301!"), "!\u0301e"; got != want {
+		t.Errorf("Reverse = %q, want %q", got, want)
+	}
+
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{-1, ""},
+		{0, ""},
+		{3, "Hi "},
+		{4, "Hi 😘"},
+		{11, message},
+		{50, message},
+	}
+	for _, tt := range tests {
+		if got := Truncate(message, tt.n); got != tt.want {
+			t.Errorf("Truncate(%q, %d) = %q, want %q", message, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestGraphemes(t *testing.T) {
+	tests := []struct {
+		s    string
+		want []string
+	}{
+		{"", nil},
+		{"abc", []string{"a", "b", "c"}},
+		{"e\u0301t\u00e9", []string{"e\u0301", "t", "\u00e9"}},
+		{"👍🏽!", []string{"👍🏽", "!"}},
+		{"👨‍👩‍👧x", []string{"👨‍👩‍👧", "x"}},
+		{"🇮🇷🇯🇵🇮", []string{"🇮🇷", "🇯🇵", "🇮"}},
+		{"❤️", []string{"❤️"}},
+	}
+	for _, tt := range tests {
+		got := Graphemes(tt.s)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Graphemes(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+		if GraphemeLen(tt.s) != len(tt.want) {
+			t.Errorf("GraphemeLen(%q) = %d, want %d", tt.s, GraphemeLen(tt.s), len(tt.want))
+		}
+	}
+
+	s := "ae\u0301🇮🇷👍🏽"
+	if got, want := ReverseGraphemes(s), "👍🏽🇮🇷e\u0301a"; got != want {
+		t.Errorf("ReverseGraphemes(%q) = %q, want %q", s, got, want)
+	}
+	if got, want := TruncateGraphemes(s, 3), "ae\u0301🇮🇷"; got != want {
+		t.Errorf("TruncateGraphemes(%q, 3) = %q, want %q", s, got, want)
+	}
+	if got := TruncateGraphemes(s, 0); got != "" {
+		t.Errorf("TruncateGraphemes(%q, 0) = %q", s, got)
+	}
+}
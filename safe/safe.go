@@ -0,0 +1,89 @@
+// Package safe runs code that might panic and turns the panic into an
+// ordinary error, so one misbehaving piece of code (for example a broken
+// exercise) cannot take down the whole program.
+//
+// SafeCall is for code the caller waits for; SafeGo is for goroutines,
+// whose panics have nobody to return to and are logged instead.
+package safe
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"sync/atomic"
+)
+
+// PanicError is returned in place of a recovered panic.
+type PanicError struct {
+	// Value is what was passed to panic.
+	Value any
+	// Stack is the goroutine's stack trace at the moment of the panic.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it was an error, so errors.Is and
+// errors.As can look through a PanicError.
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// SafeCall runs fn and returns its error. If fn panics, SafeCall recovers
+// and returns a *PanicError holding the panic value and stack trace instead.
+func SafeCall(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return fn()
+}
+
+// Logger is called with every panic that SafeGo recovers.
+type Logger func(err *PanicError)
+
+// logger holds the Logger SafeGo reports to. It is read by every
+// recovering goroutine, so it is swapped atomically.
+var logger atomic.Pointer[Logger]
+
+func init() {
+	SetLogger(nil)
+}
+
+// SetLogger makes SafeGo report recovered panics to l and returns the
+// Logger it replaces. A nil l restores the default, which writes the panic
+// and its stack trace with the standard logger.
+func SetLogger(l Logger) Logger {
+	if l == nil {
+		l = func(err *PanicError) {
+			log.Printf("safe: goroutine %v\n%s", err, err.Stack)
+		}
+	}
+	if old := logger.Swap(&l); old != nil {
+		return *old
+	}
+	return nil
+}
+
+// SafeGo runs fn in a new goroutine. A panic in fn is recovered and passed
+// to the Logger instead of crashing the program, which is what an
+// unrecovered panic in any goroutine does.
+func SafeGo(fn func()) {
+	go func() {
+		err := SafeCall(func() error {
+			fn()
+			return nil
+		})
+		var pe *PanicError
+		if errors.As(err, &pe) {
+			(*logger.Load())(pe)
+		}
+	}()
+}
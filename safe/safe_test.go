@@ -0,0 +1,104 @@
+package safe
+
+import (
+	"errors"
+	"io/fs"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"learning-go/testutil/leak"
+)
+
+func TestSafeCallReturnsError(t *testing.T) {
+	if err := SafeCall(func() error { return nil }); err != nil {
+		t.Errorf("SafeCall of a nil-returning func = %v", err)
+	}
+	if err := SafeCall(func() error { return fs.ErrNotExist }); err != fs.ErrNotExist {
+		t.Errorf("SafeCall = %v, want the func's own error unchanged", err)
+	}
+}
+
+// boom panics from a named function, so the stack trace has something to
+// look for.
+func boom() error {
+	panic("boom")
+}
+
+func TestSafeCallRecovers(t *testing.T) {
+	err := SafeCall(boom)
+	var pe *PanicError
+	if !errors.As(err, &pe) {
+		t.Fatalf("SafeCall = %v (%T), want a *PanicError", err, err)
+	}
+	if pe.Value != "boom" || err.Error() != "panic: boom" {
+		t.Errorf("Value = %v, Error() = %q", pe.Value, err.Error())
+	}
+	if !strings.Contains(string(pe.Stack), "safe.boom") {
+		t.Errorf("stack trace does not show where the panic happened:\n%s", pe.Stack)
+	}
+	if pe.Unwrap() != nil {
+		t.Errorf("Unwrap() = %v for a string panic, want nil", pe.Unwrap())
+	}
+}
+
+func TestSafeCallUnwrapsErrorPanics(t *testing.T) {
+	err := SafeCall(func() error { panic(fs.ErrPermission) })
+	if !errors.Is(err, fs.ErrPermission) {
+		t.Errorf("errors.Is(%v, fs.ErrPermission) = false", err)
+	}
+
+	err = SafeCall(func() error {
+		var m map[string]int
+		m["x"] = 1
+		return nil
+	})
+	var re runtime.Error
+	if !errors.As(err, &re) {
+		t.Errorf("nil map write: %v, want a runtime.Error inside", err)
+	}
+}
+
+func TestSafeGoLogsPanics(t *testing.T) {
+	leak.Verify(t)
+	logged := make(chan *PanicError, 1)
+	old := SetLogger(func(err *PanicError) { logged <- err })
+	defer SetLogger(old)
+
+	ran := make(chan struct{})
+	SafeGo(func() { close(ran) })
+	<-ran
+	SafeGo(func() { panic("in a goroutine") })
+	select {
+	case err := <-logged:
+		if err.Value != "in a goroutine" {
+			t.Errorf("logged %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the panic never reached the logger")
+	}
+	select {
+	case err := <-logged:
+		t.Errorf("a goroutine that returned normally was logged: %v", err)
+	default:
+	}
+}
+
+func TestSetLogger(t *testing.T) {
+	var calls int
+	mine := Logger(func(*PanicError) { calls++ })
+	old := SetLogger(mine)
+	if old == nil {
+		t.Fatal("SetLogger returned no previous logger; the default should be set")
+	}
+	if prev := SetLogger(nil); prev == nil {
+		t.Error("SetLogger(nil) did not return the logger it replaced")
+	} else {
+		prev(&PanicError{})
+	}
+	if calls != 1 {
+		t.Errorf("the returned logger is not the one installed: %d calls", calls)
+	}
+	SetLogger(old)
+}
@@ -0,0 +1,33 @@
+//go:build unix
+
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"syscall"
+)
+
+// limitedCommand runs bin through /bin/sh so that ulimit can apply the CPU
+// and memory caps to the program before it starts. The program is put in
+// its own process group so a timeout kills anything it started too.
+func limitedCommand(ctx context.Context, cfg Config, bin string) *exec.Cmd {
+	var limits []string
+	if cfg.CPUSeconds > 0 {
+		limits = append(limits, fmt.Sprintf("ulimit -t %d", cfg.CPUSeconds))
+	}
+	if cfg.MemoryBytes > 0 {
+		limits = append(limits, fmt.Sprintf("ulimit -d %d", cfg.MemoryBytes/1024))
+	}
+	script := strings.Join(append(limits, `exec "$0"`), " && ")
+
+	cmd := exec.CommandContext(ctx, "/bin/sh", "-c", script, bin)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		// A negative pid signals the whole process group.
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	return cmd
+}
@@ -0,0 +1,12 @@
+package sandbox
+
+import (
+	"context"
+	"os/exec"
+)
+
+// limitedCommand runs bin directly. Windows has no ulimit, so only the
+// context's timeout limits the program.
+func limitedCommand(ctx context.Context, cfg Config, bin string) *exec.Cmd {
+	return exec.CommandContext(ctx, bin)
+}
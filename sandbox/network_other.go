@@ -0,0 +1,10 @@
+//go:build !linux
+
+package sandbox
+
+import "os/exec"
+
+// isolateNetwork cannot block networking on this platform.
+func isolateNetwork(cmd *exec.Cmd) bool {
+	return false
+}
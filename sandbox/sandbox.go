@@ -0,0 +1,241 @@
+// Package sandbox compiles and runs untrusted Go source in a separate
+// process, with limits on wall-clock time, CPU time and memory, and with
+// networking disabled where the operating system allows it.
+//
+// The isolation is best effort and depends on the platform:
+//
+//   - Linux: the program runs in a fresh network namespace (no network at
+//     all), and CPU and memory are capped with ulimit. Where the kernel
+//     does not let unprivileged users create namespaces, it runs without.
+//   - Other Unix systems: CPU and memory are capped with ulimit; the
+//     network is not blocked.
+//   - Windows: only the wall-clock timeout applies.
+//
+// Result.NetworkIsolated reports whether networking was actually disabled.
+// Submissions are built with cgo disabled.
+// Do not expose Run to untrusted users on a platform where it is false.
+package sandbox
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Config sets the limits for one run.
+type Config struct {
+	// Timeout bounds the wall-clock time of the program (not the build).
+	// Zero means 10 seconds.
+	Timeout time.Duration
+	// BuildTimeout bounds compilation. Zero means one minute.
+	BuildTimeout time.Duration
+	// CPUSeconds caps the CPU time of the program. Zero means no cap.
+	CPUSeconds int
+	// MemoryBytes caps the program's data segment (ulimit -d), which on
+	// Linux covers the Go heap. Virtual memory is not capped because the Go
+	// runtime reserves far more address space than it uses. Zero means no
+	// cap.
+	MemoryBytes int64
+	// Stdin is fed to the program.
+	Stdin []byte
+	// MaxOutput truncates stdout and stderr to this many bytes each.
+	// Zero means 1 MiB.
+	MaxOutput int
+}
+
+// Result describes what happened to a submission.
+type Result struct {
+	// CompileError holds the compiler output when the build failed. The
+	// program was not run in that case.
+	CompileError string
+	Stdout       string
+	Stderr       string
+	ExitCode     int
+	Duration     time.Duration
+	// TimedOut is set when the program was killed for exceeding Timeout.
+	TimedOut        bool
+	NetworkIsolated bool
+}
+
+// OK reports whether the program compiled, finished in time and exited 0.
+func (r *Result) OK() bool {
+	return r.CompileError == "" && !r.TimedOut && r.ExitCode == 0
+}
+
+// Run writes files (file name to source) into a temporary module, builds
+// it with the standard library only, and runs the binary under cfg's
+// limits. It returns an error only when the sandbox itself fails; compile
+// errors, crashes and timeouts are reported in the Result.
+func Run(ctx context.Context, cfg Config, files map[string][]byte) (*Result, error) {
+	cfg = withDefaults(cfg)
+
+	dir, err := os.MkdirTemp("", "sandbox-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	if err := writeModule(dir, files); err != nil {
+		return nil, err
+	}
+
+	res := &Result{}
+	bin := filepath.Join(dir, "solution")
+	if output, err := build(ctx, cfg, dir, bin); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) || errors.Is(err, context.DeadlineExceeded) {
+			res.CompileError = strings.TrimSpace(output)
+			if res.CompileError == "" {
+				res.CompileError = err.Error()
+			}
+			return res, nil
+		}
+		return nil, fmt.Errorf("sandbox: running go build: %w", err)
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+
+	stdout := &limitedBuffer{max: cfg.MaxOutput}
+	stderr := &limitedBuffer{max: cfg.MaxOutput}
+	command := func() *exec.Cmd {
+		cmd := limitedCommand(runCtx, cfg, bin)
+		cmd.Dir = dir
+		cmd.Env = []string{"HOME=" + dir, "TMPDIR=" + dir, "PATH=/usr/bin:/bin"}
+		cmd.Stdin = bytes.NewReader(cfg.Stdin)
+		cmd.Stdout, cmd.Stderr = stdout, stderr
+		return cmd
+	}
+
+	cmd := command()
+	isolated := isolateNetwork(cmd)
+	start := time.Now()
+	err = cmd.Start()
+	if isolated && (errors.Is(err, syscall.EPERM) || errors.Is(err, syscall.EINVAL)) {
+		// The kernel refused the new namespaces, as it does where
+		// unprivileged user namespaces are disabled. Run the program
+		// anyway, and report that the network is reachable.
+		isolated = false
+		cmd = command()
+		start = time.Now()
+		err = cmd.Start()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("sandbox: starting program: %w", err)
+	}
+	res.NetworkIsolated = isolated
+	err = cmd.Wait()
+	res.Duration = time.Since(start)
+	res.Stdout, res.Stderr = stdout.String(), stderr.String()
+
+	switch {
+	case runCtx.Err() == context.DeadlineExceeded:
+		res.TimedOut = true
+		res.ExitCode = -1
+	case err == nil:
+	default:
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("sandbox: running program: %w", err)
+		}
+		res.ExitCode = exitErr.ExitCode()
+	}
+	return res, nil
+}
+
+func withDefaults(cfg Config) Config {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	if cfg.BuildTimeout <= 0 {
+		cfg.BuildTimeout = time.Minute
+	}
+	if cfg.MaxOutput <= 0 {
+		cfg.MaxOutput = 1 << 20
+	}
+	return cfg
+}
+
+func writeModule(dir string, files map[string][]byte) error {
+	if len(files) == 0 {
+		return errors.New("sandbox: no files to run")
+	}
+	for name, src := range files {
+		// Only plain file names are accepted, so a submission cannot write
+		// outside the temporary directory.
+		if name != filepath.Base(name) || !strings.HasSuffix(name, ".go") {
+			return fmt.Errorf("sandbox: invalid file name %q", name)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), src, 0o600); err != nil {
+			return err
+		}
+	}
+	gomod := "module sandbox\n\ngo 1.23\n"
+	return os.WriteFile(filepath.Join(dir, "go.mod"), []byte(gomod), 0o600)
+}
+
+// build compiles the module in dir. Downloads are disabled, so only the
+// standard library can be imported, and so is cgo, so a submission cannot
+// compile C code or pass flags to the C compiler and linker.
+func build(ctx context.Context, cfg Config, dir, bin string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, cfg.BuildTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "go", "build", "-o", bin, ".")
+	cmd.Dir = dir
+	cmd.Env = buildEnv()
+	out, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return string(out), ctx.Err()
+	}
+	return string(out), err
+}
+
+// buildEnv returns the environment of go build: the settings above, and
+// from our own environment only what the go command needs to find itself
+// and its caches. Nothing else is inherited, so no CGO_ENABLED, CC or
+// GOFLAGS of the server's can change the build.
+func buildEnv() []string {
+	env := []string{"CGO_ENABLED=0", "GOPROXY=off", "GOFLAGS=-mod=mod", "GOTOOLCHAIN=local", "GOWORK=off"}
+	for _, key := range []string{
+		"PATH", "HOME", "GOROOT", "GOPATH", "GOCACHE", "GOMODCACHE", "XDG_CACHE_HOME", "TMPDIR",
+		// Windows finds its system files, temporary directory and the
+		// build cache through these.
+		"SYSTEMROOT", "TEMP", "TMP", "LOCALAPPDATA",
+	} {
+		if v, ok := os.LookupEnv(key); ok {
+			env = append(env, key+"="+v)
+		}
+	}
+	return env
+}
+
+// limitedBuffer keeps at most max bytes and silently drops the rest, so a
+// program printing in an endless loop cannot exhaust memory.
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	max       int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.max - b.buf.Len(); room < len(p) {
+		b.buf.Write(p[:max(room, 0)])
+		b.truncated = true
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+func (b *limitedBuffer) String() string {
+	if b.truncated {
+		return b.buf.String() + "\n[output truncated]"
+	}
+	return b.buf.String()
+}
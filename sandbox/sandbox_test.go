@@ -0,0 +1,60 @@
+package sandbox
+
+import (
+	"context"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestBuildEnv(t *testing.T) {
+	t.Setenv("CGO_ENABLED", "1")
+	t.Setenv("CC", "/tmp/evil-cc")
+	env := buildEnv()
+	if !slices.Contains(env, "CGO_ENABLED=0") {
+		t.Errorf("buildEnv() = %q, want CGO_ENABLED=0", env)
+	}
+	for _, kv := range env {
+		if kv == "CGO_ENABLED=1" || strings.HasPrefix(kv, "CC=") {
+			t.Errorf("buildEnv() inherits %s", kv)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds programs")
+	}
+	ctx := context.Background()
+
+	res, err := Run(ctx, Config{}, map[string][]byte{"main.go": []byte(`package main
+
+import "fmt"
+
+func main() { fmt.Println("hello") }
+`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.OK() || res.Stdout != "hello\n" {
+		t.Errorf("Run = %+v, want hello", res)
+	}
+	if runtime.GOOS != "linux" && res.NetworkIsolated {
+		t.Errorf("NetworkIsolated on %s", runtime.GOOS)
+	}
+
+	res, err = Run(ctx, Config{}, map[string][]byte{"main.go": []byte(`package main
+
+// int answer(void) { return 42; }
+import "C"
+
+func main() { println(C.answer()) }
+`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.CompileError == "" {
+		t.Errorf("a cgo program compiled: %+v", res)
+	}
+}
@@ -0,0 +1,160 @@
+// Package seq provides small building blocks for Go 1.23 range-over-func
+// iterators. Every helper takes and returns an iter.Seq, so they compose:
+//
+//	for w := range seq.Window(seq.Take(numbers, 10), 3) { ... }
+//
+// All helpers stop pulling from their source as soon as the consumer stops
+// ranging (yield returns false), so they are safe to use on infinite
+// sequences.
+package seq
+
+import (
+	"cmp"
+	"iter"
+)
+
+// Take yields at most the first n values of s.
+func Take[T any](s iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		i := 0
+		for v := range s {
+			if !yield(v) {
+				return
+			}
+			i++
+			if i == n {
+				return
+			}
+		}
+	}
+}
+
+// Drop skips the first n values of s and yields the rest.
+func Drop[T any](s iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		i := 0
+		for v := range s {
+			if i < n {
+				i++
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Window yields every run of size consecutive values of s, sliding one value
+// at a time: Window([1 2 3 4], 3) yields [1 2 3] and [2 3 4]. Each yielded
+// slice is a fresh copy, so callers may keep it. If s has fewer than size
+// values nothing is yielded. Window panics if size is less than 1.
+func Window[T any](s iter.Seq[T], size int) iter.Seq[[]T] {
+	if size < 1 {
+		panic("seq: window size must be at least 1")
+	}
+	return func(yield func([]T) bool) {
+		buf := make([]T, 0, size)
+		for v := range s {
+			if len(buf) == size {
+				// Shift left by one to make room for the new value.
+				copy(buf, buf[1:])
+				buf = buf[:size-1]
+			}
+			buf = append(buf, v)
+			if len(buf) == size {
+				if !yield(append([]T(nil), buf...)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Chunk splits s into consecutive, non-overlapping slices of size values.
+// The last chunk may be shorter. Each yielded slice is a fresh copy.
+// Chunk panics if size is less than 1.
+func Chunk[T any](s iter.Seq[T], size int) iter.Seq[[]T] {
+	if size < 1 {
+		panic("seq: chunk size must be at least 1")
+	}
+	return func(yield func([]T) bool) {
+		chunk := make([]T, 0, size)
+		for v := range s {
+			chunk = append(chunk, v)
+			if len(chunk) == size {
+				if !yield(chunk) {
+					return
+				}
+				chunk = make([]T, 0, size)
+			}
+		}
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// Enumerate pairs every value of s with its zero-based position.
+func Enumerate[T any](s iter.Seq[T]) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for v := range s {
+			if !yield(i, v) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Merge combines two sorted sequences into one sorted sequence. When both
+// sides hold equal values, the value from a is yielded first, so the merge
+// is stable. Merge uses iter.Pull, and the pulled iterators are always
+// stopped, even when the consumer breaks out early.
+func Merge[T cmp.Ordered](a, b iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		nextA, stopA := iter.Pull(a)
+		defer stopA()
+		nextB, stopB := iter.Pull(b)
+		defer stopB()
+
+		va, okA := nextA()
+		vb, okB := nextB()
+		for okA && okB {
+			if vb < va {
+				if !yield(vb) {
+					return
+				}
+				vb, okB = nextB()
+			} else {
+				if !yield(va) {
+					return
+				}
+				va, okA = nextA()
+			}
+		}
+		for ; okA; va, okA = nextA() {
+			if !yield(va) {
+				return
+			}
+		}
+		for ; okB; vb, okB = nextB() {
+			if !yield(vb) {
+				return
+			}
+		}
+	}
+}
+
+// Collect gathers every value of s into a slice.
+func Collect[T any](s iter.Seq[T]) []T {
+	var out []T
+	for v := range s {
+		out = append(out, v)
+	}
+	return out
+}
@@ -0,0 +1,158 @@
+package seq
+
+import (
+	"iter"
+	"slices"
+	"testing"
+)
+
+// naturals yields 0, 1, 2, ... forever and counts how many values it has
+// produced in *pulled, so tests can check that helpers stop early.
+func naturals(pulled *int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			*pulled++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+func TestTake(t *testing.T) {
+	var pulled int
+	if got := Collect(Take(naturals(&pulled), 3)); !slices.Equal(got, []int{0, 1, 2}) {
+		t.Errorf("Take(naturals, 3) = %v, want [0 1 2]", got)
+	}
+	if pulled != 3 {
+		t.Errorf("Take(naturals, 3) pulled %d values, want 3", pulled)
+	}
+	if got := Collect(Take(slices.Values([]int{1, 2}), 5)); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("Take([1 2], 5) = %v, want [1 2]", got)
+	}
+	if got := Collect(Take(naturals(new(int)), 0)); got != nil {
+		t.Errorf("Take(naturals, 0) = %v, want nothing", got)
+	}
+}
+
+func TestDrop(t *testing.T) {
+	if got := Collect(Drop(slices.Values([]int{1, 2, 3, 4}), 2)); !slices.Equal(got, []int{3, 4}) {
+		t.Errorf("Drop([1 2 3 4], 2) = %v, want [3 4]", got)
+	}
+	if got := Collect(Drop(slices.Values([]int{1}), 5)); got != nil {
+		t.Errorf("Drop([1], 5) = %v, want nothing", got)
+	}
+}
+
+func TestWindow(t *testing.T) {
+	var got [][]int
+	for w := range Window(slices.Values([]int{1, 2, 3, 4}), 3) {
+		got = append(got, w)
+	}
+	want := [][]int{{1, 2, 3}, {2, 3, 4}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("Window([1 2 3 4], 3) = %v, want %v", got, want)
+	}
+	if got := Collect(Window(slices.Values([]int{1, 2}), 3)); got != nil {
+		t.Errorf("Window([1 2], 3) = %v, want nothing", got)
+	}
+}
+
+func TestChunk(t *testing.T) {
+	got := Collect(Chunk(slices.Values([]int{1, 2, 3, 4, 5}), 2))
+	want := [][]int{{1, 2}, {3, 4}, {5}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("Chunk([1 2 3 4 5], 2) = %v, want %v", got, want)
+	}
+}
+
+func TestSizePanics(t *testing.T) {
+	for name, f := range map[string]func(){
+		"Window": func() { Window(slices.Values([]int{1}), 0) },
+		"Chunk":  func() { Chunk(slices.Values([]int{1}), 0) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s with size 0 did not panic", name)
+				}
+			}()
+			f()
+		})
+	}
+}
+
+func TestEnumerate(t *testing.T) {
+	var idx []int
+	var vals []string
+	for i, v := range Enumerate(slices.Values([]string{"a", "b", "c"})) {
+		idx, vals = append(idx, i), append(vals, v)
+	}
+	if !slices.Equal(idx, []int{0, 1, 2}) || !slices.Equal(vals, []string{"a", "b", "c"}) {
+		t.Errorf("Enumerate([a b c]) = %v %v", idx, vals)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		a, b, want []int
+	}{
+		{[]int{1, 3, 5}, []int{2, 4, 6}, []int{1, 2, 3, 4, 5, 6}},
+		{nil, []int{1, 2}, []int{1, 2}},
+		{[]int{1, 2}, nil, []int{1, 2}},
+		{[]int{1, 1}, []int{1}, []int{1, 1, 1}},
+		{nil, nil, nil},
+	}
+	for _, tt := range tests {
+		if got := Collect(Merge(slices.Values(tt.a), slices.Values(tt.b))); !slices.Equal(got, tt.want) {
+			t.Errorf("Merge(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+// Merge pulls from its sources with iter.Pull, which starts a coroutine for
+// each; breaking out early must stop both of them, and infinite sources
+// must not be drained.
+func TestMergeEarlyTermination(t *testing.T) {
+	var pulledA, pulledB int
+	var got []int
+	for v := range Merge(naturals(&pulledA), naturals(&pulledB)) {
+		got = append(got, v)
+		if len(got) == 4 {
+			break
+		}
+	}
+	if !slices.Equal(got, []int{0, 0, 1, 1}) {
+		t.Errorf("first four of Merge(naturals, naturals) = %v, want [0 0 1 1]", got)
+	}
+	if pulledA > 3 || pulledB > 3 {
+		t.Errorf("Merge pulled %d and %d values for four results", pulledA, pulledB)
+	}
+}
+
+func TestComposition(t *testing.T) {
+	var pulled int
+	// Windows of 2 over 10, 11, ... taking the first three: the source must
+	// be read only as far as the last window needs.
+	s := Take(Window(Drop(naturals(&pulled), 10), 2), 3)
+	got := Collect(s)
+	want := [][]int{{10, 11}, {11, 12}, {12, 13}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if pulled != 14 {
+		t.Errorf("pulled %d values from the source, want 14", pulled)
+	}
+
+	var sums []int
+	for i, c := range Enumerate(Chunk(Take(naturals(new(int)), 7), 3)) {
+		sum := 0
+		for _, v := range c {
+			sum += v
+		}
+		sums = append(sums, i*100+sum)
+	}
+	if !slices.Equal(sums, []int{3, 112, 206}) {
+		t.Errorf("enumerated chunk sums = %v, want [3 112 206]", sums)
+	}
+}
@@ -0,0 +1,178 @@
+// Package stackdump captures the stacks of every goroutine in the program
+// and prints them grouped by what each goroutine is doing ("running",
+// "chan receive", "select", "semacquire", ...).
+//
+// It is meant for debugging concurrency code that hangs: install
+// OnSignal at startup, and when the program gets stuck send it the signal
+// (Ctrl-\ in a terminal sends SIGQUIT) to see where every goroutine is
+// blocked, without killing the process.
+package stackdump
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Goroutine is one parsed entry of a runtime.Stack dump.
+type Goroutine struct {
+	ID int
+	// State is what the goroutine is doing, e.g. "running" or "chan send".
+	State string
+	// Wait is how long it has been blocked, e.g. "2 minutes" (often empty).
+	Wait string
+	// Frames lists the stack from the innermost call outwards, one
+	// "function (file:line)" string per frame.
+	Frames []string
+	// CreatedBy is the frame that started the goroutine, if reported.
+	CreatedBy string
+}
+
+// Top returns the innermost frame, which is usually where it is blocked.
+func (g Goroutine) Top() string {
+	if len(g.Frames) == 0 {
+		return ""
+	}
+	return g.Frames[0]
+}
+
+// Capture returns the current stacks of all goroutines.
+func Capture() []Goroutine {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return Parse(buf[:n])
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// Parse parses the text format produced by runtime.Stack (and by
+// panics and SIGQUIT crashes).
+func Parse(dump []byte) []Goroutine {
+	var gs []Goroutine
+	var cur *Goroutine
+	var pendingFunc string
+
+	scanner := bufio.NewScanner(bytes.NewReader(dump))
+	scanner.Buffer(make([]byte, 0, 64<<10), 1<<20)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "goroutine "):
+			gs = append(gs, parseHeader(line))
+			cur = &gs[len(gs)-1]
+			pendingFunc = ""
+		case cur == nil || line == "":
+			continue
+		case strings.HasPrefix(line, "\t"):
+			// A file:line entry belongs to the function line before it.
+			loc := strings.TrimSpace(line)
+			if i := strings.LastIndex(loc, " +0x"); i >= 0 {
+				loc = loc[:i]
+			}
+			frame := fmt.Sprintf("%s (%s)", pendingFunc, loc)
+			if strings.HasPrefix(pendingFunc, "created by ") {
+				cur.CreatedBy = strings.TrimPrefix(frame, "created by ")
+			} else {
+				cur.Frames = append(cur.Frames, frame)
+			}
+			pendingFunc = ""
+		default:
+			pendingFunc = trimArgs(line)
+		}
+	}
+	return gs
+}
+
+// parseHeader parses "goroutine 18 [chan receive, 2 minutes]:".
+func parseHeader(line string) Goroutine {
+	var g Goroutine
+	rest := strings.TrimPrefix(line, "goroutine ")
+	idStr, rest, _ := strings.Cut(rest, " ")
+	g.ID, _ = strconv.Atoi(idStr)
+
+	start, end := strings.Index(rest, "["), strings.LastIndex(rest, "]")
+	if start >= 0 && end > start {
+		state, wait, _ := strings.Cut(rest[start+1:end], ", ")
+		g.State, g.Wait = state, wait
+	}
+	return g
+}
+
+// trimArgs turns "main.worker(0xc000012345, 0x3)" into "main.worker".
+func trimArgs(fn string) string {
+	if i := strings.LastIndex(fn, "("); i > 0 && strings.HasSuffix(fn, ")") {
+		return fn[:i]
+	}
+	return fn
+}
+
+// Write prints goroutines grouped by state, largest group first. With full
+// set, every frame is printed; otherwise only the innermost one.
+func Write(w io.Writer, gs []Goroutine, full bool) {
+	groups := make(map[string][]Goroutine)
+	for _, g := range gs {
+		groups[g.State] = append(groups[g.State], g)
+	}
+	states := make([]string, 0, len(groups))
+	for s := range groups {
+		states = append(states, s)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		if len(groups[states[i]]) != len(groups[states[j]]) {
+			return len(groups[states[i]]) > len(groups[states[j]])
+		}
+		return states[i] < states[j]
+	})
+
+	fmt.Fprintf(w, "%d goroutines\n", len(gs))
+	for _, state := range states {
+		fmt.Fprintf(w, "\n[%s] x%d\n", state, len(groups[state]))
+		for _, g := range groups[state] {
+			wait := ""
+			if g.Wait != "" {
+				wait = " for " + g.Wait
+			}
+			fmt.Fprintf(w, "  goroutine %d%s\n", g.ID, wait)
+			frames := g.Frames
+			if !full && len(frames) > 1 {
+				frames = frames[:1]
+			}
+			for _, f := range frames {
+				fmt.Fprintf(w, "    at %s\n", f)
+			}
+			if g.CreatedBy != "" {
+				fmt.Fprintf(w, "    created by %s\n", g.CreatedBy)
+			}
+		}
+	}
+}
+
+// OnSignal prints a grouped dump to w every time one of sigs arrives,
+// until ctx is cancelled. Catching a signal this way replaces its default
+// action, so SIGQUIT no longer terminates the program.
+func OnSignal(ctx context.Context, w io.Writer, sigs ...os.Signal) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, sigs...)
+	go func() {
+		defer signal.Stop(ch)
+		for {
+			select {
+			case <-ch:
+				Write(w, Capture(), true)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
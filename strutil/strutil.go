@@ -0,0 +1,40 @@
+// Package strutil is the small set of rune-safe string helpers most code
+// needs: indexing, reversing and truncating by rune, and counting what a
+// reader sees as characters.
+//
+//	strutil.RuneAt("Hi 😘", 3)   // '😘', true, where s[3] is a lone byte
+//	strutil.ReverseRunes("añb") // "bña"
+//	strutil.Truncate("😘😊", 1)  // "😘", never half an emoji
+//	strutil.GraphemeLen("é🇮🇷")  // 2
+//
+// Each function is a thin wrapper over package runestr, which explains how
+// they work and has variants such as RuneSlice and TruncateGraphemes.
+package strutil
+
+import "learning-go/runestr"
+
+// RuneAt returns the rune at rune index i, not byte index i. It reports
+// false if i is out of range.
+func RuneAt(s string, i int) (rune, bool) {
+	return runestr.RuneAt(s, i)
+}
+
+// ReverseRunes returns s with its runes in reverse order. Invalid UTF-8
+// bytes become U+FFFD, and combining marks end up before the letter they
+// belonged to; runestr.ReverseGraphemes keeps them attached.
+func ReverseRunes(s string) string {
+	return runestr.Reverse(s)
+}
+
+// Truncate returns the first n runes of s, or s itself if it has no more
+// than n. It never cuts a multi-byte character in half.
+func Truncate(s string, n int) string {
+	return runestr.Truncate(s, n)
+}
+
+// GraphemeLen returns the number of user-perceived characters in s,
+// counting a letter with its accents, an emoji with its modifiers or a
+// flag as one.
+func GraphemeLen(s string) int {
+	return runestr.GraphemeLen(s)
+}
@@ -0,0 +1,104 @@
+package strutil
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+// Strings where counting bytes, runes and graphemes gives different
+// answers.
+const (
+	kiss    = "Hi 😘!"              // a 4-byte emoji
+	accent  = "cafe\u0301"         // e followed by a combining acute accent
+	family  = "👨\u200d👩\u200d👧 ok" // three emoji joined by U+200D
+	thumbs  = "👍🏽👍"                // a skin-tone modifier on the first
+	flagged = "🇮🇷 and 🇯🇵"          // flags are pairs of regional indicators
+)
+
+func TestRuneAt(t *testing.T) {
+	tests := []struct {
+		s    string
+		i    int
+		want rune
+		ok   bool
+	}{
+		{kiss, 3, '😘', true},
+		{kiss, 4, '!', true},
+		{kiss, 5, 0, false},
+		{kiss, -1, 0, false},
+		{accent, 4, '\u0301', true},
+		{thumbs, 1, '🏽', true},
+		{"", 0, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := RuneAt(tt.s, tt.i)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("RuneAt(%q, %d) = %q, %v; want %q, %v", tt.s, tt.i, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestReverseRunes(t *testing.T) {
+	tests := []struct{ s, want string }{
+		{"", ""},
+		{kiss, "!😘 iH"},
+		// Rune by rune, the accent comes loose from its e.
+		{accent, "\u0301efac"},
+		{thumbs, "👍🏽👍"},
+		{"\xff", "�"},
+	}
+	for _, tt := range tests {
+		if got := ReverseRunes(tt.s); got != tt.want {
+			t.Errorf("ReverseRunes(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s    string
+		n    int
+		want string
+	}{
+		{kiss, 0, ""},
+		{kiss, -2, ""},
+		{kiss, 4, "Hi 😘"},
+		{kiss, 5, kiss},
+		{kiss, 99, kiss},
+		// Counting runes, not graphemes, can separate an accent or a
+		// modifier from its base.
+		{accent, 4, "cafe"},
+		{thumbs, 1, "👍"},
+	}
+	for _, tt := range tests {
+		got := Truncate(tt.s, tt.n)
+		if got != tt.want {
+			t.Errorf("Truncate(%q, %d) = %q, want %q", tt.s, tt.n, got, tt.want)
+		}
+		if !utf8.ValidString(got) {
+			t.Errorf("Truncate(%q, %d) cut a rune in half: %q", tt.s, tt.n, got)
+		}
+	}
+}
+
+func TestGraphemeLen(t *testing.T) {
+	tests := []struct {
+		s             string
+		runes, graphs int
+	}{
+		{"", 0, 0},
+		{kiss, 5, 5},
+		{accent, 5, 4},
+		{family, 8, 4},
+		{thumbs, 3, 2},
+		{flagged, 9, 7},
+	}
+	for _, tt := range tests {
+		if got := utf8.RuneCountInString(tt.s); got != tt.runes {
+			t.Errorf("%q has %d runes, the test expects %d", tt.s, got, tt.runes)
+		}
+		if got := GraphemeLen(tt.s); got != tt.graphs {
+			t.Errorf("GraphemeLen(%q) = %d, want %d", tt.s, got, tt.graphs)
+		}
+	}
+}
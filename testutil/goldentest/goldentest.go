@@ -0,0 +1,86 @@
+// Package goldentest checks a chapter's exercises against their golden
+// files from go test, the way "learn check" does from the command line.
+// Each chapter package with golden files has a test that calls Run:
+//
+//	func TestGolden(t *testing.T) {
+//		goldentest.Run(t)
+//	}
+//
+// Run finds the chapter from the package's directory, so the test needs
+// no arguments. Exercises without a golden file are skipped.
+package goldentest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"learning-go/golden"
+	"learning-go/registry"
+)
+
+// Run checks, as a subtest each, every exercise registered for the
+// chapter in the current directory, which go test makes the package's
+// directory.
+func Run(t *testing.T) {
+	t.Helper()
+	root, chapter, err := locate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Exercises run from the root, as under learn check; some read files
+	// by paths relative to it.
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+	found := false
+	for _, ex := range registry.All() {
+		if ex.Chapter != chapter {
+			continue
+		}
+		found = true
+		t.Run(ex.Name, func(t *testing.T) {
+			err := golden.Check(root, ex)
+			switch {
+			case errors.Is(err, golden.ErrNoGolden), errors.Is(err, golden.ErrHostDependent):
+				t.Skip(err)
+			case err != nil:
+				t.Error(err)
+				var mismatch *golden.MismatchError
+				if errors.As(err, &mismatch) {
+					for _, c := range mismatch.Changes {
+						t.Log(c)
+					}
+				}
+			}
+		})
+	}
+	if !found {
+		t.Fatalf("no exercises registered for %s", chapter)
+	}
+}
+
+// locate returns the repository root, the nearest directory up with a
+// go.mod, and the current directory relative to it as a chapter name.
+func locate() (root, chapter string, err error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", "", err
+	}
+	for root = dir; ; root = filepath.Dir(root) {
+		if _, err := os.Stat(filepath.Join(root, "go.mod")); err == nil {
+			break
+		}
+		if filepath.Dir(root) == root {
+			return "", "", errors.New("goldentest: no go.mod above " + dir)
+		}
+	}
+	rel, err := filepath.Rel(root, dir)
+	return root, filepath.ToSlash(rel), err
+}
@@ -0,0 +1,180 @@
+// Package leak detects goroutines that outlive the code that started them.
+//
+// A goroutine blocked forever on a channel nobody will ever use again is
+// never collected: it keeps its stack and everything it references until
+// the program exits. Take a Snapshot before the code under test runs and
+// ask it afterwards which goroutines started since are still there:
+//
+//	snap := leak.Take()
+//	runPipeline()
+//	if err := snap.Check(); err != nil {
+//		fmt.Println(err) // names every leaked goroutine and where it is blocked
+//	}
+//
+// In a test, Verify does the same from t.Cleanup and fails the test:
+//
+//	func TestPipeline(t *testing.T) {
+//		leak.Verify(t)
+//		runPipeline()
+//	}
+//
+// A package whose code starts goroutines checks all of its tests at once
+// from TestMain, allowing the long-lived helpers it knows about:
+//
+//	func TestMain(m *testing.M) {
+//		leak.VerifyMain(m, "net/http.(*persistConn)")
+//	}
+//
+// Goroutines are told apart by their IDs from runtime.Stack, so ones that
+// were already running when the snapshot was taken are never reported.
+package leak
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"learning-go/stackdump"
+)
+
+// Timeout is how long Check and Verify wait for goroutines to exit before
+// reporting them. Goroutines that are shutting down need a moment to
+// return after the code that stopped them does.
+const Timeout = time.Second
+
+// Snapshot records which goroutines were running at one moment.
+type Snapshot struct {
+	before map[int]bool
+}
+
+// Take records the goroutines running now.
+func Take() Snapshot {
+	s := Snapshot{before: make(map[int]bool)}
+	for _, g := range stackdump.Capture() {
+		s.before[g.ID] = true
+	}
+	return s
+}
+
+// Leaked waits up to timeout for every goroutine started since the
+// snapshot to exit, and returns those still running. Goroutines with a
+// frame containing one of the ignore strings, such as "net/http.(*persistConn)"
+// for idle keep-alive connections, are not counted.
+func (s Snapshot) Leaked(timeout time.Duration, ignore ...string) []stackdump.Goroutine {
+	deadline := time.Now().Add(timeout)
+	for {
+		leaked := s.started(ignore)
+		if len(leaked) == 0 || !time.Now().Before(deadline) {
+			return leaked
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// started returns the goroutines not in the snapshot, apart from the
+// ignored ones.
+func (s Snapshot) started(ignore []string) []stackdump.Goroutine {
+	var gs []stackdump.Goroutine
+	for _, g := range stackdump.Capture() {
+		if !s.before[g.ID] && !matches(g, ignore) {
+			gs = append(gs, g)
+		}
+	}
+	return gs
+}
+
+func matches(g stackdump.Goroutine, ignore []string) bool {
+	for _, pattern := range ignore {
+		if strings.Contains(g.CreatedBy, pattern) {
+			return true
+		}
+		for _, f := range g.Frames {
+			if strings.Contains(f, pattern) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Check returns a *Error if goroutines started since the snapshot are
+// still running after Timeout, and nil otherwise. See Leaked for ignore.
+func (s Snapshot) Check(ignore ...string) error {
+	if leaked := s.Leaked(Timeout, ignore...); len(leaked) > 0 {
+		return &Error{Goroutines: leaked}
+	}
+	return nil
+}
+
+// Error lists leaked goroutines.
+type Error struct {
+	Goroutines []stackdump.Goroutine
+}
+
+// Error names each leaked goroutine by what it is doing and where, e.g.
+// "2 goroutines leaked: [chan send] main.worker (main.go:12); ...".
+func (e *Error) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d goroutine(s) leaked", len(e.Goroutines))
+	for i, g := range e.Goroutines {
+		sep := "; "
+		if i == 0 {
+			sep = ": "
+		}
+		fmt.Fprintf(&b, "%s[%s] %s", sep, g.State, g.Top())
+	}
+	return b.String()
+}
+
+// TB is the part of testing.TB that Verify needs.
+type TB interface {
+	Helper()
+	Cleanup(func())
+	Errorf(format string, args ...any)
+}
+
+// Verify takes a snapshot now and, when the test finishes, fails it with
+// the full stacks of any goroutine the test left running.
+func Verify(t TB, ignore ...string) {
+	t.Helper()
+	snap := Take()
+	t.Cleanup(func() {
+		leaked := snap.Leaked(Timeout, ignore...)
+		if len(leaked) == 0 {
+			return
+		}
+		var b strings.Builder
+		stackdump.Write(&b, leaked, true)
+		t.Errorf("leaked goroutines:\n%s", b.String())
+	})
+}
+
+// M is the part of *testing.M that VerifyMain needs.
+type M interface {
+	Run() int
+}
+
+// VerifyMain runs the tests and exits with their status, or with status 1
+// and the full stacks on stderr if goroutines they started are still
+// running after Timeout. Goroutines matching ignore (see Leaked) are
+// allowed to stay. A failed run is not checked, since a test that stops
+// early may leave its goroutines behind.
+func VerifyMain(m M, ignore ...string) {
+	os.Exit(verifyMain(m, os.Stderr, ignore))
+}
+
+func verifyMain(m M, w io.Writer, ignore []string) int {
+	snap := Take()
+	code := m.Run()
+	if code != 0 {
+		return code
+	}
+	if leaked := snap.Leaked(Timeout, ignore...); len(leaked) > 0 {
+		fmt.Fprintln(w, "leak: goroutines still running after the tests:")
+		stackdump.Write(w, leaked, true)
+		return 1
+	}
+	return 0
+}
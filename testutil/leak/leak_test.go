@@ -0,0 +1,112 @@
+package leak
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// blocked starts a goroutine that waits on release, in a named function
+// so it can be recognized in a stack.
+func blocked(release chan struct{}) {
+	go waitForRelease(release)
+}
+
+func waitForRelease(release chan struct{}) {
+	<-release
+}
+
+func TestCheck(t *testing.T) {
+	snap := Take()
+	if err := snap.Check(); err != nil {
+		t.Fatalf("Check with nothing started = %v", err)
+	}
+
+	release := make(chan struct{})
+	blocked(release)
+	err := snap.Check()
+	var leakErr *Error
+	if !errors.As(err, &leakErr) || len(leakErr.Goroutines) != 1 {
+		t.Fatalf("Check = %v, want one leaked goroutine", err)
+	}
+	if msg := err.Error(); !strings.Contains(msg, "[chan receive]") || !strings.Contains(msg, "waitForRelease") {
+		t.Errorf("Error() = %q, want the state and the blocked function", msg)
+	}
+	if err := snap.Check("leak.waitForRelease"); err != nil {
+		t.Errorf("Check with the goroutine ignored = %v", err)
+	}
+
+	close(release)
+	if err := snap.Check(); err != nil {
+		t.Errorf("Check after the goroutine exited = %v", err)
+	}
+}
+
+func TestLeakedWaitsForExit(t *testing.T) {
+	snap := Take()
+	release := make(chan struct{})
+	blocked(release)
+	time.AfterFunc(20*time.Millisecond, func() { close(release) })
+	if leaked := snap.Leaked(Timeout); len(leaked) != 0 {
+		t.Errorf("Leaked reported a goroutine that was about to exit: %v", leaked)
+	}
+}
+
+// fakeTB records what Verify does with a test.
+type fakeTB struct {
+	cleanups []func()
+	errors   []string
+}
+
+func (f *fakeTB) Helper()           {}
+func (f *fakeTB) Cleanup(fn func()) { f.cleanups = append(f.cleanups, fn) }
+func (f *fakeTB) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func TestVerify(t *testing.T) {
+	var tb fakeTB
+	release := make(chan struct{})
+	defer close(release)
+	Verify(&tb)
+	blocked(release)
+	for _, fn := range tb.cleanups {
+		fn()
+	}
+	if len(tb.errors) != 1 || !strings.Contains(tb.errors[0], "waitForRelease") {
+		t.Errorf("Verify reported %q, want the leaked goroutine's stack", tb.errors)
+	}
+}
+
+// fakeM stands in for *testing.M.
+type fakeM struct {
+	code int
+	run  func()
+}
+
+func (m fakeM) Run() int {
+	m.run()
+	return m.code
+}
+
+func TestVerifyMain(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	leaky := fakeM{run: func() { blocked(release) }}
+
+	var out bytes.Buffer
+	if code := verifyMain(leaky, &out, nil); code != 1 || !strings.Contains(out.String(), "waitForRelease") {
+		t.Errorf("verifyMain with a leak = %d, output %q", code, out.String())
+	}
+	out.Reset()
+	if code := verifyMain(leaky, &out, []string{"leak.waitForRelease"}); code != 0 || out.Len() != 0 {
+		t.Errorf("verifyMain with the leak allowed = %d, output %q", code, out.String())
+	}
+	failing := fakeM{code: 3, run: func() { blocked(release) }}
+	if code := verifyMain(failing, &out, nil); code != 3 {
+		t.Errorf("verifyMain of a failed run = %d, want its own status 3", code)
+	}
+}
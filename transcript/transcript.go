@@ -0,0 +1,115 @@
+// Package transcript keeps a history of exercise runs: what each run
+// printed, when, how long it took and at which git commit. Every run is
+// one JSON file named after the time it started, in a directory per
+// exercise:
+//
+//	transcripts/chapter12/workerpool/exercise1/20240315T091502.123Z.json
+//
+// "learn run --record" saves them, and "learn diff" compares the latest
+// one with the exercise's golden file.
+package transcript
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"learning-go/errs"
+)
+
+// ErrNone means an exercise has no recorded transcript.
+var ErrNone = errors.New("no transcript recorded")
+
+// stamp is the file name format: sortable, and free of the colons some
+// file systems reject.
+const stamp = "20060102T150405.000Z"
+
+// Transcript is one recorded run.
+type Transcript struct {
+	// Exercise is the exercise's ID, e.g. "chapter3/exercise2".
+	Exercise string        `json:"exercise"`
+	Date     time.Time     `json:"date"`
+	Duration time.Duration `json:"duration"`
+	// Commit is the git commit the run was made at, if known.
+	Commit string `json:"commit,omitempty"`
+	// Passed reports whether the run finished without panicking or
+	// timing out.
+	Passed bool   `json:"passed"`
+	Output string `json:"output"`
+}
+
+// Save writes t into dir and returns the path of the new file.
+func Save(dir string, t Transcript) (string, error) {
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, filepath.FromSlash(t.Exercise), t.Date.UTC().Format(stamp)+".json")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	return path, os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// List returns the paths of exercise's transcripts in dir, oldest first.
+func List(dir, exercise string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(dir, filepath.FromSlash(exercise)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".json") {
+			paths = append(paths, filepath.Join(dir, filepath.FromSlash(exercise), e.Name()))
+		}
+	}
+	// The names are timestamps, so sorting them sorts by time.
+	slices.Sort(paths)
+	return paths, nil
+}
+
+// Load reads one transcript file.
+func Load(path string) (Transcript, error) {
+	var t Transcript
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return t, err
+	}
+	if err := json.Unmarshal(data, &t); err != nil {
+		return t, errs.Wrap(err, "reading %s", path)
+	}
+	return t, nil
+}
+
+// Latest returns exercise's most recent transcript in dir. The error
+// wraps ErrNone if there is none.
+func Latest(dir, exercise string) (Transcript, error) {
+	paths, err := List(dir, exercise)
+	if err != nil {
+		return Transcript{}, err
+	}
+	if len(paths) == 0 {
+		return Transcript{}, fmt.Errorf("%s: %w", exercise, ErrNone)
+	}
+	return Load(paths[len(paths)-1])
+}
+
+// Commit returns the commit checked out in the git repository at root,
+// or "" if git is missing or root is not a repository.
+func Commit(root string) string {
+	out, err := exec.Command("git", "-C", root, "rev-parse", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
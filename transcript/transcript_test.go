@@ -0,0 +1,54 @@
+package transcript
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSaveAndLatest(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := Latest(dir, "chapter3/exercise2"); !errors.Is(err, ErrNone) {
+		t.Fatalf("Latest with nothing recorded = %v, want ErrNone", err)
+	}
+
+	base := time.Date(2024, 3, 15, 9, 15, 2, 123e6, time.UTC)
+	// Saved out of order, and one in another zone: Latest goes by time.
+	for _, tr := range []Transcript{
+		{Exercise: "chapter3/exercise2", Date: base.Add(time.Hour), Output: "second\n", Passed: true},
+		{Exercise: "chapter3/exercise2", Date: base, Output: "first\n"},
+		{Exercise: "chapter3/exercise2", Date: base.Add(2 * time.Hour).In(time.FixedZone("IRST", 3*3600+1800)), Output: "third\n", Commit: "abc123", Duration: time.Second},
+		{Exercise: "chapter3/exercise1", Date: base.Add(3 * time.Hour), Output: "other\n"},
+	} {
+		path, err := Save(dir, tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(path, filepath.Join(dir, "chapter3", tr.Exercise[len("chapter3/"):])) {
+			t.Errorf("Save put %s in %s", tr.Exercise, path)
+		}
+	}
+
+	paths, err := List(dir, "chapter3/exercise2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 3 || filepath.Base(paths[0]) != "20240315T091502.123Z.json" {
+		t.Errorf("List = %v", paths)
+	}
+	got, err := Latest(dir, "chapter3/exercise2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Output != "third\n" || got.Commit != "abc123" || got.Duration != time.Second || !got.Date.Equal(base.Add(2*time.Hour)) {
+		t.Errorf("Latest = %+v", got)
+	}
+}
+
+func TestCommit(t *testing.T) {
+	if got := Commit(t.TempDir()); got != "" {
+		t.Errorf("Commit outside a repository = %q, want empty", got)
+	}
+}
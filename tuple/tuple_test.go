@@ -0,0 +1,57 @@
+package tuple
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestPair(t *testing.T) {
+	p := NewPair("age", 42)
+	if k, v := p.Unpack(); k != "age" || v != 42 {
+		t.Errorf("Unpack() = %q, %d", k, v)
+	}
+	if got := p.Swap(); got != NewPair(42, "age") {
+		t.Errorf("Swap() = %v", got)
+	}
+	if got := p.Swap().Swap(); got != p {
+		t.Errorf("Swap().Swap() = %v, want %v", got, p)
+	}
+	if got := p.String(); got != "(age, 42)" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestTriple(t *testing.T) {
+	tr := NewTriple(1, "two", 3.0)
+	if a, b, c := tr.Unpack(); a != 1 || b != "two" || c != 3.0 {
+		t.Errorf("Unpack() = %v, %v, %v", a, b, c)
+	}
+	if got := tr.String(); got != "(1, two, 3)" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestEntriesRoundTrip(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	entries := Entries(m)
+	slices.SortFunc(entries, func(x, y Pair[string, int]) int { return cmp.Compare(x.First, y.First) })
+	want := []Pair[string, int]{{"a", 1}, {"b", 2}, {"c", 3}}
+	if !slices.Equal(entries, want) {
+		t.Errorf("Entries = %v, want %v", entries, want)
+	}
+	if got := FromEntries(entries); !maps.Equal(got, m) {
+		t.Errorf("FromEntries(Entries(m)) = %v, want %v", got, m)
+	}
+	if got := Entries(map[string]int(nil)); len(got) != 0 {
+		t.Errorf("Entries(nil) = %v", got)
+	}
+}
+
+func TestFromEntriesLastWins(t *testing.T) {
+	got := FromEntries([]Pair[string, int]{{"a", 1}, {"a", 2}})
+	if got["a"] != 2 || len(got) != 1 {
+		t.Errorf("FromEntries with a repeated key = %v, want map[a:2]", got)
+	}
+}
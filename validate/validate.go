@@ -0,0 +1,216 @@
+// Package validate checks struct fields against rules declared in struct
+// tags:
+//
+//	type Employee struct {
+//		Name  string `validate:"required,min=1,max=100"`
+//		Email string `validate:"required,email"`
+//		Age   int    `validate:"min=18"`
+//	}
+//
+// Supported rules:
+//
+//   - required: the field must not be its zero value
+//   - min=N, max=N: bounds on the value for numbers, and on the length for
+//     strings (counted in runes), slices, arrays and maps
+//   - email: the string must be a bare email address (empty strings pass,
+//     combine with required to reject them)
+//
+// Nested structs, pointers to structs and slices of structs are validated
+// recursively. A struct reached through a pointer is validated once, so
+// values that share a pointer or point back to themselves are safe. Struct
+// validates every field and reports all violations at once rather than
+// stopping at the first one.
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// FieldError describes one rule that a field failed.
+type FieldError struct {
+	// Path is the location of the field, e.g. "Address.City" or "Items[2].Name".
+	Path string
+	// Rule is the failed rule as written in the tag, e.g. "min=1".
+	Rule    string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// Errors is the list of every violation found in a value.
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// ErrNotStruct is returned when Struct is given something other than a
+// struct or a pointer to one.
+var ErrNotStruct = errors.New("validate: value is not a struct")
+
+// Struct validates v, which must be a struct or a non-nil pointer to one.
+// It returns nil when every rule passes, an Errors value listing every
+// violation otherwise, or an error wrapping ErrNotStruct (or describing a
+// malformed tag) when v cannot be validated at all.
+func Struct(v any) error {
+	c := &checker{seen: make(map[visit]bool)}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		c.seen[visit{rv.Pointer(), rv.Type()}] = true
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("%w: got %T", ErrNotStruct, v)
+	}
+
+	if err := c.checkStruct(rv, ""); err != nil {
+		return err
+	}
+	if len(c.errs) > 0 {
+		return c.errs
+	}
+	return nil
+}
+
+// visit is a pointer that has been followed. The type is part of the key
+// because a pointer to a struct and a pointer to its first field hold the
+// same address.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// checker collects violations while walking one value.
+type checker struct {
+	errs Errors
+	// seen holds the pointers already followed, so a cycle is walked once.
+	seen map[visit]bool
+}
+
+func (c *checker) checkStruct(v reflect.Value, prefix string) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		path := field.Name
+		if prefix != "" {
+			path = prefix + "." + field.Name
+		}
+		fv := v.Field(i)
+
+		if tag, ok := field.Tag.Lookup("validate"); ok && tag != "" && tag != "-" {
+			for _, rule := range strings.Split(tag, ",") {
+				msg, err := checkRule(fv, strings.TrimSpace(rule))
+				if err != nil {
+					return fmt.Errorf("validate: field %s: %w", path, err)
+				}
+				if msg != "" {
+					c.errs = append(c.errs, FieldError{Path: path, Rule: rule, Message: msg})
+				}
+			}
+		}
+
+		if err := c.descend(fv, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// descend validates structs reachable from v: nested structs, pointers to
+// structs, and elements of slices and arrays.
+func (c *checker) descend(v reflect.Value, path string) error {
+	switch v.Kind() {
+	case reflect.Pointer:
+		key := visit{v.Pointer(), v.Type()}
+		if v.IsNil() || c.seen[key] {
+			return nil
+		}
+		c.seen[key] = true
+		return c.descend(v.Elem(), path)
+	case reflect.Struct:
+		return c.checkStruct(v, path)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := c.descend(v.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkRule returns a violation message, or "" if v satisfies rule. An error
+// means the rule itself is invalid for this field.
+func checkRule(v reflect.Value, rule string) (string, error) {
+	name, arg, _ := strings.Cut(rule, "=")
+	switch name {
+	case "required":
+		if v.IsZero() {
+			return "is required", nil
+		}
+	case "min", "max":
+		limit, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return "", fmt.Errorf("bad %s argument %q", name, arg)
+		}
+		size, isLength, err := measure(v)
+		if err != nil {
+			return "", fmt.Errorf("rule %s: %w", name, err)
+		}
+		what := "must be"
+		if isLength {
+			what = "length must be"
+		}
+		if name == "min" && size < limit {
+			return fmt.Sprintf("%s at least %s", what, arg), nil
+		}
+		if name == "max" && size > limit {
+			return fmt.Sprintf("%s at most %s", what, arg), nil
+		}
+	case "email":
+		if v.Kind() != reflect.String {
+			return "", fmt.Errorf("rule email needs a string, got %s", v.Kind())
+		}
+		s := v.String()
+		if s == "" {
+			return "", nil
+		}
+		addr, err := mail.ParseAddress(s)
+		if err != nil || addr.Address != s {
+			return "must be a valid email address", nil
+		}
+	default:
+		return "", fmt.Errorf("unknown rule %q", name)
+	}
+	return "", nil
+}
+
+// measure returns the number that min and max compare against, and whether
+// it is a length rather than the value itself.
+func measure(v reflect.Value) (float64, bool, error) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), false, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), false, nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), false, nil
+	case reflect.String:
+		return float64(utf8.RuneCountInString(v.String())), true, nil
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(v.Len()), true, nil
+	}
+	return 0, false, fmt.Errorf("cannot measure a %s", v.Kind())
+}
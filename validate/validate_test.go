@@ -0,0 +1,132 @@
+package validate
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+type address struct {
+	City string `validate:"required"`
+}
+
+type person struct {
+	Name    string   `validate:"required,min=2,max=5"`
+	Email   string   `validate:"email"`
+	Age     int      `validate:"min=18,max=130"`
+	Tags    []string `validate:"max=2"`
+	Home    address
+	Work    *address
+	Friends []person
+}
+
+func paths(t *testing.T, err error) []string {
+	t.Helper()
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Struct returned %v, want Errors", err)
+	}
+	var p []string
+	for _, fe := range errs {
+		p = append(p, fe.Path+" "+fe.Rule)
+	}
+	return p
+}
+
+func TestStructValid(t *testing.T) {
+	p := person{Name: "Ana", Email: "ana@example.com", Age: 30, Home: address{City: "Tehran"}}
+	if err := Struct(p); err != nil {
+		t.Errorf("Struct(valid) = %v", err)
+	}
+	if err := Struct(&p); err != nil {
+		t.Errorf("Struct(&valid) = %v", err)
+	}
+}
+
+func TestStructReportsEveryViolation(t *testing.T) {
+	p := person{
+		Name:    "Alexander",
+		Email:   "Ana <ana@example.com>",
+		Age:     12,
+		Tags:    []string{"a", "b", "c"},
+		Work:    &address{},
+		Friends: []person{{Name: "Bo", Age: 20, Home: address{City: "x"}}, {}},
+	}
+	got := paths(t, Struct(p))
+	want := []string{
+		"Name max=5",
+		"Email email",
+		"Age min=18",
+		"Tags max=2",
+		"Home.City required",
+		"Work.City required",
+		"Friends[1].Name required",
+		"Friends[1].Name min=2",
+		"Friends[1].Age min=18",
+		"Friends[1].Home.City required",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("violations =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestStructCountsRunes(t *testing.T) {
+	// Five runes, but more than five bytes.
+	p := person{Name: "ژاله‌", Age: 20, Home: address{City: "x"}}
+	if err := Struct(p); err != nil {
+		t.Errorf("Struct(%q) = %v, want nil", p.Name, err)
+	}
+}
+
+type node struct {
+	Name string `validate:"required"`
+	Next *node
+}
+
+func TestStructCycle(t *testing.T) {
+	n := &node{Name: "a"}
+	n.Next = n
+	if err := Struct(n); err != nil {
+		t.Errorf("Struct(self-referencing) = %v, want nil", err)
+	}
+	bad := &node{}
+	bad.Next = &node{Name: "b", Next: bad}
+	if got := paths(t, Struct(bad)); !slices.Equal(got, []string{"Name required"}) {
+		t.Errorf("violations = %q, want the one empty name reported once", got)
+	}
+}
+
+type wrapper struct {
+	Inner inner
+}
+
+type inner struct {
+	Value string `validate:"required"`
+}
+
+// A pointer to a struct and a pointer to its first field share an address;
+// following one must not mark the other as seen.
+func TestStructSameAddressDifferentType(t *testing.T) {
+	w := &wrapper{}
+	type both struct {
+		W *wrapper
+		I *inner
+	}
+	got := paths(t, Struct(both{W: w, I: &w.Inner}))
+	want := []string{"W.Inner.Value required", "I.Value required"}
+	if !slices.Equal(got, want) {
+		t.Errorf("violations = %q, want %q", got, want)
+	}
+}
+
+func TestStructBadInput(t *testing.T) {
+	if err := Struct(42); !errors.Is(err, ErrNotStruct) {
+		t.Errorf("Struct(42) = %v, want ErrNotStruct", err)
+	}
+	type badRule struct {
+		X int `validate:"between=1"`
+	}
+	if err := Struct(badRule{}); err == nil || errors.As(err, new(Errors)) {
+		t.Errorf("Struct(unknown rule) = %v, want a tag error", err)
+	}
+}
@@ -0,0 +1,147 @@
+// Package verify checks that a solution actually uses the language
+// construct an exercise is about. An exercise on select can be "solved"
+// with a mutex and a loop, and its output may even be correct; parsing the
+// source and looking for a select statement catches that before the
+// solution is accepted.
+package verify
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strings"
+)
+
+// Construct is a language feature that can be required.
+type Construct string
+
+const (
+	Select        Construct = "select statement"
+	TypeSwitch    Construct = "type switch"
+	Generics      Construct = "type parameters"
+	Goroutine     Construct = "go statement"
+	Defer         Construct = "defer statement"
+	Closure       Construct = "function literal"
+	RangeLoop     Construct = "for ... range loop"
+	Struct        Construct = "struct type"
+	Interface     Construct = "interface type"
+	Method        Construct = "method declaration"
+	ChannelType   Construct = "channel type"
+	TypeAssertion Construct = "type assertion"
+)
+
+// Requirements maps an exercise, written as "<chapter>/<exercise>", to the
+// constructs its solution must contain.
+var Requirements = map[string][]Construct{
+	"chapter3/exercise3":  {Struct},
+	"chapter7/exercise1":  {Method, Struct},
+	"chapter8/exercise3":  {Generics},
+	"chapter12/exercise1": {Select, Goroutine, ChannelType},
+	"chapter12/exercise2": {ChannelType},
+}
+
+// MissingError lists the required constructs a solution does not use.
+type MissingError struct {
+	Exercise string
+	Missing  []Construct
+}
+
+func (e *MissingError) Error() string {
+	names := make([]string, len(e.Missing))
+	for i, c := range e.Missing {
+		names[i] = string(c)
+	}
+	return fmt.Sprintf("%s: solution must use: %s", e.Exercise, strings.Join(names, ", "))
+}
+
+// Constructs returns every construct that appears in file.
+func Constructs(file *ast.File) map[Construct]bool {
+	found := make(map[Construct]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectStmt:
+			found[Select] = true
+		case *ast.TypeSwitchStmt:
+			found[TypeSwitch] = true
+		case *ast.GoStmt:
+			found[Goroutine] = true
+		case *ast.DeferStmt:
+			found[Defer] = true
+		case *ast.FuncLit:
+			found[Closure] = true
+		case *ast.RangeStmt:
+			found[RangeLoop] = true
+		case *ast.StructType:
+			found[Struct] = true
+		case *ast.InterfaceType:
+			found[Interface] = true
+		case *ast.ChanType:
+			found[ChannelType] = true
+		case *ast.TypeAssertExpr:
+			// x.(type) in a type switch is also a TypeAssertExpr, with a
+			// nil Type; only count real assertions.
+			if n.Type != nil {
+				found[TypeAssertion] = true
+			}
+		case *ast.FuncDecl:
+			if n.Recv != nil {
+				found[Method] = true
+			}
+			if n.Type.TypeParams != nil && n.Type.TypeParams.NumFields() > 0 {
+				found[Generics] = true
+			}
+		case *ast.TypeSpec:
+			if n.TypeParams != nil && n.TypeParams.NumFields() > 0 {
+				found[Generics] = true
+			}
+		}
+		return true
+	})
+	return found
+}
+
+// Check parses src and returns a *MissingError if it lacks any of the
+// constructs required for exercise. Exercises without requirements always
+// pass. A parse error is returned as is.
+func Check(exercise, filename string, src []byte) error {
+	required, ok := Requirements[exercise]
+	if !ok {
+		return nil
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.SkipObjectResolution)
+	if err != nil {
+		return err
+	}
+	return CheckFile(exercise, file, required)
+}
+
+// CheckFile is like Check for an already parsed file and an explicit list
+// of requirements.
+func CheckFile(exercise string, file *ast.File, required []Construct) error {
+	return CheckFiles(exercise, []*ast.File{file}, required)
+}
+
+// CheckFiles is like CheckFile for a solution split over several files; a
+// construct counts if any of them contains it.
+func CheckFiles(exercise string, files []*ast.File, required []Construct) error {
+	found := make(map[Construct]bool)
+	for _, file := range files {
+		for c := range Constructs(file) {
+			found[c] = true
+		}
+	}
+	var missing []Construct
+	for _, c := range required {
+		if !found[c] {
+			missing = append(missing, c)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	sort.Slice(missing, func(i, j int) bool { return missing[i] < missing[j] })
+	return &MissingError{Exercise: exercise, Missing: missing}
+}
@@ -0,0 +1,121 @@
+package verify
+
+import (
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"slices"
+	"testing"
+)
+
+func TestConstructs(t *testing.T) {
+	tests := []struct {
+		src  string
+		want []Construct
+	}{
+		{"package p; func f() {}", nil},
+		{"package p; func f(ch chan int) { select { case <-ch: } }", []Construct{ChannelType, Select}},
+		{"package p; func f(v any) { switch v.(type) {} }", []Construct{TypeSwitch}},
+		{"package p; func f(v any) { _ = v.(int) }", []Construct{TypeAssertion}},
+		{"package p; func f[T any](v T) {}", []Construct{Generics}},
+		{"package p; type box[T any] struct{ v T }", []Construct{Generics, Struct}},
+		{"package p; type t struct{}; func (t) m() {}", []Construct{Method, Struct}},
+		{"package p; func f() { go func() {}(); defer func() {}() }", []Construct{Closure, Defer, Goroutine}},
+		{"package p; type i interface{ m() }; func f(s []int) { for range s {} }", []Construct{Interface, RangeLoop}},
+	}
+	for _, tt := range tests {
+		file, err := parser.ParseFile(token.NewFileSet(), "p.go", tt.src, 0)
+		if err != nil {
+			t.Fatalf("parse %q: %v", tt.src, err)
+		}
+		var got []Construct
+		for c := range Constructs(file) {
+			got = append(got, c)
+		}
+		slices.Sort(got)
+		want := slices.Clone(tt.want)
+		slices.Sort(want)
+		if !slices.Equal(got, want) {
+			t.Errorf("Constructs(%q) = %v, want %v", tt.src, got, want)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	const withSelect = `package main
+
+func main() {
+	ch := make(chan int)
+	go func() { ch <- 1 }()
+	select {
+	case <-ch:
+	}
+}
+`
+	const withMutex = `package main
+
+import "sync"
+
+func main() {
+	var mu sync.Mutex
+	go func() { mu.Lock() }()
+}
+`
+	tests := []struct {
+		name     string
+		exercise string
+		src      string
+		missing  []Construct // nil for a pass
+	}{
+		{"pass", "chapter12/exercise1", withSelect, nil},
+		{"fail", "chapter12/exercise1", withMutex, []Construct{ChannelType, Select}},
+		{"no requirements", "chapter1/exercise1", withMutex, nil},
+	}
+	for _, tt := range tests {
+		err := Check(tt.exercise, "main.go", []byte(tt.src))
+		if tt.missing == nil {
+			if err != nil {
+				t.Errorf("%s: Check(%s) = %v, want nil", tt.name, tt.exercise, err)
+			}
+			continue
+		}
+		var me *MissingError
+		if !errors.As(err, &me) {
+			t.Errorf("%s: Check(%s) = %v, want a *MissingError", tt.name, tt.exercise, err)
+			continue
+		}
+		if me.Exercise != tt.exercise || !slices.Equal(me.Missing, tt.missing) {
+			t.Errorf("%s: Check(%s) = %+v, want %s missing %v", tt.name, tt.exercise, me, tt.exercise, tt.missing)
+		}
+	}
+}
+
+// A source that does not parse is an error, but not a *MissingError.
+func TestCheckParseError(t *testing.T) {
+	err := Check("chapter12/exercise1", "main.go", []byte("package main\nfunc main() {"))
+	var me *MissingError
+	if err == nil || errors.As(err, &me) {
+		t.Errorf("Check of unparsable source = %v, want a parse error", err)
+	}
+}
+
+// A construct counts when any one of the files has it.
+func TestCheckFiles(t *testing.T) {
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, src := range []string{"package p; type s struct{}", "package p; func (s) m() {}"} {
+		f, err := parser.ParseFile(fset, "p.go", src, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, f)
+	}
+	required := []Construct{Method, Struct}
+	if err := CheckFiles("chapter7/exercise1", files, required); err != nil {
+		t.Errorf("CheckFiles over both files = %v, want nil", err)
+	}
+	if err := CheckFiles("chapter7/exercise1", files[:1], required); err == nil {
+		t.Errorf("CheckFiles without the method = nil, want an error")
+	}
+}